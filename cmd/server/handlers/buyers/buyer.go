@@ -4,7 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/buyer"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/purchaseOrder"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/export"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
 	"net/http"
 	"strconv"
@@ -48,13 +51,8 @@ func (handler *BuyerHandler) Get() gin.HandlerFunc {
 
 		ctx := c.Request.Context()
 
-		if buyerResponse, err := handler.buyerService.Get(&ctx, id); err != nil {
-			switch err {
-			case buyer.ErrNotFound:
-				web.Error(c, http.StatusNotFound, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, err.Error())
-			}
+		if buyerResponse, err := handler.buyerService.Get(ctx, id); err != nil {
+			_ = c.Error(err)
 			return
 		} else {
 			web.Response(c, http.StatusOK, buyerResponse)
@@ -70,23 +68,49 @@ func (handler *BuyerHandler) Get() gin.HandlerFunc {
 //	@Tags			Buyers
 //	@Description	Get the details of all buyers on the database.
 //	@Accept			json
-//	@Produce		json
-//	@Success		200	{object}	web.response{data=[]domain.Buyer}
-//	@Failure		500	{object}	web.errorResponse
+//	@Produce		json,text/csv,application/x-ndjson
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			offset	query		int		false	"Number of buyers to skip"
+//	@Param			sort	query		string	false	"Field to sort by"
+//	@Param			order	query		string	false	"asc or desc"
+//	@Param			format	query		string	false	"json, csv or ndjson, or use the Accept header"
+//	@Param			include_deleted	query		bool	false	"List deleted records too"
+//	@Success		200		{object}	web.response{data=[]domain.Buyer}
+//	@Failure		400		{object}	web.errorResponse
+//	@Failure		500		{object}	web.errorResponse
 //	@Router			/api/v1/buyers [get]
 func (handler *BuyerHandler) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		options, err := listing.ParseSoftDeleted(c.Request.URL.Query(), buyer.Fields, "buyers.deleted_at")
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if format != export.JSON {
+			web.Export(c, format, domain.Buyer{}, func(write func(row interface{}) error) error {
+				return handler.buyerService.Each(c.Request.Context(), options.Unpaged(), func(row domain.Buyer) error {
+					return write(row)
+				})
+			})
+			return
+		}
 
 		ctx := c.Request.Context()
-		if buyers, err := handler.buyerService.GetAll(&ctx); err != nil {
-			web.Error(c, http.StatusInternalServerError, err.Error())
+		if buyers, total, err := handler.buyerService.GetPage(ctx, options); err != nil {
+			_ = c.Error(err)
 			return
 		} else {
 			if len(*buyers) == 0 {
 				web.Success(c, http.StatusNoContent, buyers)
 				return
 			}
-			web.Success(c, http.StatusOK, buyers)
+			web.Page(c, http.StatusOK, buyers, total, options)
 			return
 		}
 	}
@@ -109,20 +133,14 @@ func (handler *BuyerHandler) Create() gin.HandlerFunc {
 
 		createBuyerRequest := new(dtos.CreateBuyerRequestDTO)
 		if err := c.ShouldBindJSON(createBuyerRequest); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, err.Error())
+			web.ValidationError(c, err)
 			return
 		}
 
 		ctx := c.Request.Context()
-		if createdBuyer, err := handler.buyerService.Create(&ctx, createBuyerRequest); err != nil {
-			switch err {
-			case buyer.ErrCardNumberDuplicated:
-				web.Error(c, http.StatusConflict, err.Error())
-				return
-			default:
-				web.Error(c, http.StatusInternalServerError, err.Error())
-				return
-			}
+		if createdBuyer, err := handler.buyerService.Create(ctx, createBuyerRequest); err != nil {
+			_ = c.Error(err)
+			return
 		} else {
 			web.Response(c, http.StatusCreated, createdBuyer)
 			return
@@ -154,21 +172,14 @@ func (handler *BuyerHandler) Update() gin.HandlerFunc {
 		}
 
 		updateBuyerRequest := new(dtos.UpdateBuyerRequestDTO)
-		if err := c.ShouldBind(updateBuyerRequest); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, err.Error())
+		if err := c.ShouldBindJSON(updateBuyerRequest); err != nil {
+			web.ValidationError(c, err)
 			return
 		}
 
 		ctx := c.Request.Context()
-		if updatedBuyer, err := handler.buyerService.Update(&ctx, id, updateBuyerRequest); err != nil {
-			switch err {
-			case buyer.ErrNotFound:
-				web.Error(c, http.StatusNotFound, err.Error())
-			case buyer.ErrCardNumberDuplicated:
-				web.Error(c, http.StatusConflict, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, err.Error())
-			}
+		if updatedBuyer, err := handler.buyerService.Update(ctx, id, updateBuyerRequest); err != nil {
+			_ = c.Error(err)
 			return
 		} else {
 			web.Response(c, http.StatusOK, updatedBuyer)
@@ -200,13 +211,8 @@ func (handler *BuyerHandler) Delete() gin.HandlerFunc {
 		}
 
 		ctx := c.Request.Context()
-		if err := handler.buyerService.Delete(&ctx, id); err != nil {
-			switch err {
-			case buyer.ErrNotFound:
-				web.Error(c, http.StatusNotFound, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, err.Error())
-			}
+		if err := handler.buyerService.Delete(ctx, id); err != nil {
+			_ = c.Error(err)
 			return
 		} else {
 			web.Response(c, http.StatusNoContent, nil)
@@ -215,51 +221,94 @@ func (handler *BuyerHandler) Delete() gin.HandlerFunc {
 	}
 }
 
-// CountPurchaseOrders is the handler search for a purchaseOrder and return the number of sellers
+// RestoreBuyer godoc
+//
+//	@Summary		Restore Buyer
+//	@Tags			Buyers
+//	@Description	Restore a deleted buyer
+//	@Produce		json
+//	@Param			id	path		int	true	"Buyer ID"
+//	@Success		200	{object}	domain.Buyer
+//	@Failure		404	{object}	web.errorResponse
+//	@Router			/api/v1/buyers/{id}/restore [post]
+func (handler *BuyerHandler) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		id, err := getIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ctx := c.Request.Context()
+		buyerResponse, err := handler.buyerService.Restore(ctx, id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		web.Response(c, http.StatusOK, buyerResponse)
+	}
+}
+
+// CountPurchaseOrders is the handler to report the purchase orders of a buyer, or of every buyer when no id is given
 //
 //	@Summary		CountPurchaseOrders
 //	@Tags			PurchaseOrders
-//	@Description	search for a purchaseOrder and return the number of sellers.
-//	@Accept			json
-//	@Produce		json
-//	@Param			id		path		string						true	"ID of PurchaseOrder to be searched"
-//	@Success		200		{object}	web.response{data=dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO}
-//	@Failure		400		{object}	web.errorResponse
-//	@Failure		404		{object}	web.errorResponse
-//	@Failure		422		{object}	web.errorResponse
-//	@Failure		500		{object}	web.errorResponse
+//	@Description	Report the number of purchase orders of a buyer, or of every buyer when no id is given, by order status and by carrier.
+//	@Produce		json,text/csv,application/x-ndjson
+//	@Param			id				path		string	false	"ID of the Buyer"
+//	@Param			order_date_from	query		string	false	"First order date, inclusive"
+//	@Param			order_date_to	query		string	false	"Last order date, inclusive"
+//	@Param			format			query		string	false	"json, csv or ndjson, or use the Accept header"
+//	@Success		200				{object}	web.response{data=dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO}
+//	@Success		200				{object}	web.response{data=[]dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO}
+//	@Failure		400				{object}	web.errorResponse
+//	@Failure		404				{object}	web.errorResponse
+//	@Failure		422				{object}	web.errorResponse
+//	@Failure		500				{object}	web.errorResponse
 //	@Router			/api/v1/buyers/{id}/report-purchase-orders [get]
+//	@Router			/api/v1/buyers/report-purchase-orders [get]
 func (handler *BuyerHandler) CountPurchaseOrders() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		id, err := getIdFromUri(c)
+		format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
 		if err != nil {
 			web.Error(c, http.StatusBadRequest, err.Error())
 			return
 		}
 
+		var filter dtos.GetPurchaseOrdersReportRequestDTO
+		if err := c.ShouldBindQuery(&filter); err != nil {
+			web.ValidationError(c, err)
+			return
+		}
+
 		ctx := c.Request.Context()
-		count, err := handler.purchaseOrderService.CountByBuyerID(&ctx, id)
-		if err != nil {
-			switch err {
-			case buyer.ErrNotFound:
-				web.Error(c, http.StatusNotFound, err.Error())
-				return
-			default:
-				web.Error(c, http.StatusInternalServerError, err.Error())
+		if c.Param("id") == "" {
+			reports, err := handler.purchaseOrderService.ReportAllBuyers(ctx, filter)
+			if err != nil {
+				_ = c.Error(err)
 				return
 			}
+
+			web.Report(c, format, reports, dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO{})
+			return
 		}
 
-		response := dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO{
-			BuyerID:             id,
-			PurchaseOrdersCount: count,
+		id, err := getIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
 		}
 
-		web.Success(c, http.StatusOK, response)
-		return
+		report, err := handler.purchaseOrderService.ReportByBuyer(ctx, id, filter)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
 
+		web.Report(c, format, report, dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO{})
 	}
-
 }
 
 func getIdFromUri(c *gin.Context) (id int, err error) {
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/buyers"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/buyer"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/buyer"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/buyer/mocks"
@@ -70,7 +71,7 @@ func TestGet(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			buyerServiceMock := mocks.NewBuyerServiceMock()
-			buyerServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(test.expectedBuyer, test.expectedGetError)
+			buyerServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(test.expectedBuyer, test.expectedGetError)
 
 			purchaseOrderServiceMock := servicesMocks.NewMockPurchaseOrderService(t)
 			buyerHandler := buyers.NewBuyerHandler(buyerServiceMock, purchaseOrderServiceMock)
@@ -78,6 +79,7 @@ func TestGet(t *testing.T) {
 			//Configurar o servidor
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(middlewares.Errors())
 			r.GET("/api/v1/buyers/:id", buyerHandler.Get())
 
 			//Definir request e response
@@ -123,43 +125,52 @@ func TestGetAll(t *testing.T) {
 	}
 
 	tests := []struct {
-		name                 string
-		expectedGetAllResult *[]domain.Buyer
-		expectedGetAllError  error
-		expectedGetAllCalls  int
-		expectedResponse     *[]domain.Buyer
-		expectedCode         int
+		name                  string
+		query                 string
+		expectedGetPageResult *[]domain.Buyer
+		expectedGetPageTotal  int
+		expectedGetPageError  error
+		expectedGetPageCalls  int
+		expectedResponse      *[]domain.Buyer
+		expectedCode          int
 	}{
 		{
-			name:                 "Successfully get all expectedBuyers",
-			expectedGetAllResult: expectedBuyers,
-			expectedGetAllError:  nil,
-			expectedGetAllCalls:  1,
-			expectedResponse:     expectedBuyers,
-			expectedCode:         http.StatusOK,
+			name:                  "Successfully get all expectedBuyers",
+			expectedGetPageResult: expectedBuyers,
+			expectedGetPageTotal:  2,
+			expectedGetPageError:  nil,
+			expectedGetPageCalls:  1,
+			expectedResponse:      expectedBuyers,
+			expectedCode:          http.StatusOK,
 		},
 		{
-			name:                 "Success empty database",
-			expectedGetAllResult: &[]domain.Buyer{},
-			expectedGetAllError:  nil,
-			expectedGetAllCalls:  1,
-			expectedResponse:     &[]domain.Buyer{},
-			expectedCode:         http.StatusNoContent,
+			name:                  "Success empty database",
+			expectedGetPageResult: &[]domain.Buyer{},
+			expectedGetPageError:  nil,
+			expectedGetPageCalls:  1,
+			expectedResponse:      &[]domain.Buyer{},
+			expectedCode:          http.StatusNoContent,
 		},
 		{
-			name:                 "Error getting all expectedBuyers",
-			expectedGetAllResult: &[]domain.Buyer{},
-			expectedGetAllError:  assert.AnError,
-			expectedGetAllCalls:  1,
-			expectedResponse:     &[]domain.Buyer{},
-			expectedCode:         http.StatusInternalServerError,
+			name:                  "Error getting all expectedBuyers",
+			expectedGetPageResult: &[]domain.Buyer{},
+			expectedGetPageError:  assert.AnError,
+			expectedGetPageCalls:  1,
+			expectedResponse:      &[]domain.Buyer{},
+			expectedCode:          http.StatusInternalServerError,
+		},
+		{
+			name:                 "Error invalid query",
+			query:                "?order=sideways",
+			expectedGetPageCalls: 0,
+			expectedCode:         http.StatusBadRequest,
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			buyerServiceMock := mocks.NewBuyerServiceMock()
-			buyerServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(test.expectedGetAllResult, test.expectedGetAllError)
+			buyerServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(test.expectedGetPageResult, test.expectedGetPageTotal, test.expectedGetPageError)
 
 			purchaseOrderServiceMock := servicesMocks.NewMockPurchaseOrderService(t)
 			buyerHandler := buyers.NewBuyerHandler(buyerServiceMock, purchaseOrderServiceMock)
@@ -167,17 +178,18 @@ func TestGetAll(t *testing.T) {
 			//Configurar o servidor
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(middlewares.Errors())
 			r.GET("/api/v1/expectedBuyers", buyerHandler.GetAll())
 
 			//Definir request e response
-			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s", "/api/v1/expectedBuyers"), nil)
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", "/api/v1/expectedBuyers", test.query), nil)
 			res := httptest.NewRecorder()
 
 			//Executar request
 			r.ServeHTTP(res, req)
 
 			//Validar resultado
-			buyerServiceMock.AssertNumberOfCalls(t, "GetAll", test.expectedGetAllCalls)
+			buyerServiceMock.AssertNumberOfCalls(t, "GetPage", test.expectedGetPageCalls)
 			assert.Equal(t, test.expectedCode, res.Code)
 
 			// Só testa o body em caso de sucesso
@@ -240,7 +252,7 @@ func TestDelete(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			buyerServiceMock := mocks.NewBuyerServiceMock()
-			buyerServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(test.expectedDeleteError)
+			buyerServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(test.expectedDeleteError)
 
 			purchaseOrderServiceMock := servicesMocks.NewMockPurchaseOrderService(t)
 			buyerHandler := buyers.NewBuyerHandler(buyerServiceMock, purchaseOrderServiceMock)
@@ -248,6 +260,7 @@ func TestDelete(t *testing.T) {
 			//Configurar o servidor
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(middlewares.Errors())
 			r.DELETE("/api/v1/buyers/:id", buyerHandler.Delete())
 
 			//Definir request e response
@@ -323,7 +336,7 @@ func TestCreate(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			buyerServiceMock := mocks.NewBuyerServiceMock()
-			buyerServiceMock.On("Create", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("*dtos.CreateBuyerRequestDTO")).Return(test.expectedCreateResult, test.expectedCreateError)
+			buyerServiceMock.On("Create", mock.Anything, mock.AnythingOfType("*dtos.CreateBuyerRequestDTO")).Return(test.expectedCreateResult, test.expectedCreateError)
 
 			purchaseOrderServiceMock := servicesMocks.NewMockPurchaseOrderService(t)
 			buyerHandler := buyers.NewBuyerHandler(buyerServiceMock, purchaseOrderServiceMock)
@@ -331,6 +344,7 @@ func TestCreate(t *testing.T) {
 			//Configurar o servidor
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(middlewares.Errors())
 			r.POST("/api/v1/buyers", buyerHandler.Create())
 
 			requestBody, _ := json.Marshal(test.createBuyerRequest)
@@ -422,12 +436,12 @@ func TestUpdate(t *testing.T) {
 			expectedUpdateCalls:  1,
 			expectedCode:         http.StatusInternalServerError,
 		},
-		//{
-		//	name:               "Error invalid buyer",
-		//	id:                 "1",
-		//	updateBuyerRequest: dtos.UpdateBuyerRequestDTO{},
-		//	expectedCode:       http.StatusUnprocessableEntity,
-		//},
+		{
+			name:               "Error invalid buyer",
+			id:                 "1",
+			updateBuyerRequest: dtos.UpdateBuyerRequestDTO{},
+			expectedCode:       http.StatusUnprocessableEntity,
+		},
 		{
 			name:               "Error invalid id",
 			id:                 "xyz",
@@ -439,20 +453,15 @@ func TestUpdate(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			buyerServiceMock := mocks.NewBuyerServiceMock()
-			buyerServiceMock.On("Update", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("*dtos.UpdateBuyerRequestDTO")).Return(test.expectedUpdateResult, test.expectedUpdateError)
+			buyerServiceMock.On("Update", mock.Anything, mock.AnythingOfType("*dtos.UpdateBuyerRequestDTO")).Return(test.expectedUpdateResult, test.expectedUpdateError)
 
 			purchaseOrderServiceMock := servicesMocks.NewMockPurchaseOrderService(t)
 			buyerHandler := buyers.NewBuyerHandler(buyerServiceMock, purchaseOrderServiceMock)
 
 			//Configurar o servidor
-
-			//// Create custom validation
-			//if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-			//	v.RegisterStructValidation(dtos.UpdateBuyerRequestValidation, dtos.UpdateBuyerRequestDTO{})
-			//}
-
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(middlewares.Errors())
 			r.PATCH("/api/v1/buyers/:id", buyerHandler.Update())
 
 			requestBody, _ := json.Marshal(test.updateBuyerRequest)
@@ -486,52 +495,66 @@ func TestUpdate(t *testing.T) {
 
 func TestCountPurchaseOrders(t *testing.T) {
 
-	var validBuyer domain.Buyer
-	buyerSerialized, _ := os.ReadFile("../../../../test/resources/valid_buyer.json")
-	if err := json.Unmarshal(buyerSerialized, &validBuyer); err != nil {
-		t.Fatal(err)
-	}
-
-	expectedResponse := dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO{
+	carrierID, companyName := 1, "Meli Express"
+	report := dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO{
 		BuyerID:             1,
-		PurchaseOrdersCount: 1,
+		CardNumberID:        "402323",
+		FirstName:           "Jhon",
+		LastName:            "Doe",
+		PurchaseOrdersCount: 2,
+		ByOrderStatus: []dtos.PurchaseOrdersByOrderStatusDTO{
+			{OrderStatusID: 1, OrderStatus: "created", PurchaseOrdersCount: 1},
+			{OrderStatusID: 3, OrderStatus: "shipped", PurchaseOrdersCount: 1},
+		},
+		ByCarrier: []dtos.PurchaseOrdersByCarrierDTO{
+			{CarrierID: &carrierID, CompanyName: &companyName, PurchaseOrdersCount: 1},
+			{PurchaseOrdersCount: 1},
+		},
 	}
 
 	tests := []struct {
-		name                         string
-		id                           string
-		expectedCountByBuyerIDResult int
-		expectedCountByBuyerIDError  error
-		expectedCountByBuyerIDCalls  int
-		expectedResponse             dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO
-		expectedCode                 int
+		name                        string
+		id                          string
+		query                       string
+		expectedReportByBuyerError  error
+		expectedReportByBuyerCalls  int
+		expectedReportAllBuyerCalls int
+		expectedResponse            interface{}
+		expectedCode                int
 	}{
 		{
-			name:                         "Successfully counting purchase orders",
-			id:                           "1",
-			expectedCountByBuyerIDResult: 1,
-			expectedCountByBuyerIDError:  nil,
-			expectedCountByBuyerIDCalls:  1,
-			expectedResponse:             expectedResponse,
-			expectedCode:                 http.StatusOK,
+			name:                       "Successfully reporting purchase orders of a buyer",
+			id:                         "1",
+			query:                      "?order_date_from=2023-01-01&order_date_to=2023-12-31",
+			expectedReportByBuyerCalls: 1,
+			expectedResponse:           report,
+			expectedCode:               http.StatusOK,
+		},
+		{
+			name:                        "Successfully reporting purchase orders of all buyers",
+			expectedReportAllBuyerCalls: 1,
+			expectedResponse:            []dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO{report},
+			expectedCode:                http.StatusOK,
 		},
 		{
-			name:                         "Error buyer not found",
-			id:                           "1",
-			expectedCountByBuyerIDResult: 0,
-			expectedCountByBuyerIDError:  buyer.ErrNotFound,
-			expectedCountByBuyerIDCalls:  1,
-			expectedResponse:             dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO{},
-			expectedCode:                 http.StatusNotFound,
+			name:                       "Error buyer not found",
+			id:                         "1",
+			expectedReportByBuyerError: buyer.ErrNotFound,
+			expectedReportByBuyerCalls: 1,
+			expectedCode:               http.StatusNotFound,
 		},
 		{
-			name:                         "Error counting purchase orders",
-			id:                           "1",
-			expectedCountByBuyerIDResult: 0,
-			expectedCountByBuyerIDError:  assert.AnError,
-			expectedCountByBuyerIDCalls:  1,
-			expectedResponse:             dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO{},
-			expectedCode:                 http.StatusInternalServerError,
+			name:                       "Error reporting purchase orders",
+			id:                         "1",
+			expectedReportByBuyerError: assert.AnError,
+			expectedReportByBuyerCalls: 1,
+			expectedCode:               http.StatusInternalServerError,
+		},
+		{
+			name:         "Error invalid order date",
+			id:           "1",
+			query:        "?order_date_from=01/01/2023",
+			expectedCode: http.StatusUnprocessableEntity,
 		},
 		{
 			name:         "Invalid ID",
@@ -546,24 +569,32 @@ func TestCountPurchaseOrders(t *testing.T) {
 			buyerServiceMock := mocks.NewBuyerServiceMock()
 
 			purchaseOrderServiceMock := servicesMocks.NewMockPurchaseOrderService(t)
-			purchaseOrderServiceMock.On("CountByBuyerID", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(test.expectedCountByBuyerIDResult, test.expectedCountByBuyerIDError)
+			purchaseOrderServiceMock.On("ReportByBuyer", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("dtos.GetPurchaseOrdersReportRequestDTO")).Return(report, test.expectedReportByBuyerError).Maybe()
+			purchaseOrderServiceMock.On("ReportAllBuyers", mock.Anything, mock.AnythingOfType("dtos.GetPurchaseOrdersReportRequestDTO")).Return([]dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO{report}, nil).Maybe()
 
 			buyerHandler := buyers.NewBuyerHandler(buyerServiceMock, purchaseOrderServiceMock)
 
 			//Configurar o servidor
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(middlewares.Errors())
 			r.GET("/api/v1/buyers/:id/report-purchase-orders", buyerHandler.CountPurchaseOrders())
+			r.GET("/api/v1/buyers/report-purchase-orders", buyerHandler.CountPurchaseOrders())
 
 			//Definir request e response
-			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/buyers/%s/report-purchase-orders", test.id), nil)
+			url := "/api/v1/buyers/report-purchase-orders" + test.query
+			if test.id != "" {
+				url = fmt.Sprintf("/api/v1/buyers/%s/report-purchase-orders%s", test.id, test.query)
+			}
+			req := httptest.NewRequest(http.MethodGet, url, nil)
 			res := httptest.NewRecorder()
 
 			//Executar request
 			r.ServeHTTP(res, req)
 
 			//Validar resultado
-			purchaseOrderServiceMock.AssertNumberOfCalls(t, "CountByBuyerID", test.expectedCountByBuyerIDCalls)
+			purchaseOrderServiceMock.AssertNumberOfCalls(t, "ReportByBuyer", test.expectedReportByBuyerCalls)
+			purchaseOrderServiceMock.AssertNumberOfCalls(t, "ReportAllBuyers", test.expectedReportAllBuyerCalls)
 			assert.Equal(t, test.expectedCode, res.Code)
 
 			// Só testa o body em caso de sucesso
@@ -572,15 +603,66 @@ func TestCountPurchaseOrders(t *testing.T) {
 				//Parsear response
 				body, _ := io.ReadAll(res.Body)
 
-				var response struct {
-					Data dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO `json:"data"`
-				}
-
-				json.Unmarshal(body, &response)
+				expected, _ := json.Marshal(map[string]interface{}{"data": test.expectedResponse})
 
 				// Valida o response
-				assert.Equal(t, test.expectedResponse, response.Data)
+				assert.JSONEq(t, string(expected), string(body))
 			}
 		})
 	}
 }
+
+func TestRestore(t *testing.T) {
+	t.Run("restore_ok", func(t *testing.T) {
+		serviceMock := mocks.NewBuyerServiceMock()
+		serviceMock.On("Restore", mock.Anything, 1).Return(&domain.Buyer{ID: 1, CardNumberID: "123", FirstName: "Roberto", LastName: "Pêra"}, nil)
+		handler := buyers.NewBuyerHandler(serviceMock, servicesMocks.NewMockPurchaseOrderService(t))
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/buyers/:id/restore", handler.Restore())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/buyers/1/restore", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"id":1,"card_number_id":"123","first_name":"Roberto","last_name":"Pêra"}`, res.Body.String())
+	})
+
+	t.Run("restore_not_deleted", func(t *testing.T) {
+		serviceMock := mocks.NewBuyerServiceMock()
+		serviceMock.On("Restore", mock.Anything, 1).Return((*domain.Buyer)(nil), buyer.ErrNotFound)
+		handler := buyers.NewBuyerHandler(serviceMock, servicesMocks.NewMockPurchaseOrderService(t))
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/buyers/:id/restore", handler.Restore())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/buyers/1/restore", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("restore_invalid_id", func(t *testing.T) {
+		serviceMock := mocks.NewBuyerServiceMock()
+		serviceMock.On("Restore", mock.Anything, 1).Return((*domain.Buyer)(nil), buyer.ErrNotFound)
+		handler := buyers.NewBuyerHandler(serviceMock, servicesMocks.NewMockPurchaseOrderService(t))
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/buyers/:id/restore", handler.Restore())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/buyers/xyz/restore", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		serviceMock.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+}
@@ -1,12 +1,14 @@
 package carriers
 
 import (
-	"errors"
 	"net/http"
 	"strconv"
 
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/carrier"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/carriers"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/export"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
 	"github.com/gin-gonic/gin"
 )
@@ -26,15 +28,41 @@ func NewCarrier(s carriers.Service) *Carrier {
 //	@Summary		List carriers
 //	@Tags			Carriers
 //	@Description	get carriers
-//	@Produce		json
-//	@Success		200	{object}	[]domain.Carrier
+//	@Produce		json,text/csv,application/x-ndjson
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			offset	query		int		false	"Number of carriers to skip"
+//	@Param			sort	query		string	false	"Field to sort by"
+//	@Param			order	query		string	false	"asc or desc"
+//	@Param			format	query		string	false	"json, csv or ndjson, or use the Accept header"
+//	@Param			include_deleted	query		bool	false	"List deleted records too"
+//	@Success		200		{object}	[]domain.Carrier
 //	@Router			/api/v1/carriers [get]
 func (carrier *Carrier) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		options, err := listing.ParseSoftDeleted(c.Request.URL.Query(), carriers.Fields, "deleted_at")
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "Invalid query: %s", err.Error())
+			return
+		}
+
+		format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if format != export.JSON {
+			web.Export(c, format, domain.Carrier{}, func(write func(row interface{}) error) error {
+				return carrier.carrierService.Each(c.Request.Context(), options.Unpaged(), func(row domain.Carrier) error {
+					return write(row)
+				})
+			})
+			return
+		}
+
 		ctx := c.Request.Context()
-		carriers, err := carrier.carrierService.GetAll(&ctx)
+		carriers, total, err := carrier.carrierService.GetPage(ctx, options)
 		if err != nil {
-			web.Error(c, http.StatusInternalServerError, "Failed to get carriers: %s", err.Error())
+			_ = c.Error(err)
 			return
 		}
 
@@ -43,7 +71,7 @@ func (carrier *Carrier) GetAll() gin.HandlerFunc {
 			return
 		}
 
-		web.Success(c, http.StatusOK, *carriers)
+		web.Page(c, http.StatusOK, *carriers, total, options)
 	}
 }
 
@@ -61,63 +89,60 @@ func (carrier *Carrier) Create() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req dtos.CarrierRequestDTO
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, "JSON format may be wrong")
+			web.ValidationError(c, err)
 			return
 		}
 		ctx := c.Request.Context()
-		if err := CarrierFullRequestValidator(c, req); err != nil {
-			web.Error(c, http.StatusBadRequest, err.Error())
-			return
-		}
-		result, e := carrier.carrierService.Create(&ctx, req)
+		result, e := carrier.carrierService.Create(ctx, req)
 
 		if e != nil {
-			web.Error(c, http.StatusConflict, e.Error())
+			_ = c.Error(e)
 			return
 		}
 		web.Success(c, http.StatusCreated, result)
 	}
 }
 
-func CarrierFullRequestValidator(c *gin.Context, req dtos.CarrierRequestDTO) error {
-	if req.CID == "" {
-		return errors.New("field cid is required")
-	}
-	if req.CompanyName == "" {
-		return errors.New("field company_name is required")
-	}
-	if req.Address == "" {
-		return errors.New("field address is required")
-	}
-	if req.Telephone == "" {
-		return errors.New("field telephone is required")
-	}
-	if req.LocalityId == 0 {
-		return errors.New("field locality_id is required")
-	}
-
-	return nil
-}
-
 // GetReportCarriersByLocalities godoc
 //
 //	@Summary		Get Report Carriers By Localities
 //	@Tags			Carriers
 //	@Description	get report carriers by localities
-//	@Produce		json
-//	@Param			id	path	int	false	"ID of a Locality to search"
-//	@Success		200	{object}	[]dtos.DataLocalityAndCarrier
+//	@Produce		json,text/csv,application/x-ndjson
+//	@Param			id		query		int		false	"ID of a Locality to search"
+//	@Param			limit	query		int		false	"Page size when listing every locality"
+//	@Param			offset	query		int		false	"Number of localities to skip"
+//	@Param			format	query		string	false	"json, csv or ndjson, or use the Accept header"
+//	@Success		200		{object}	[]dtos.DataLocalityAndCarrier
 //	@Router			/api/v1/localities/reportCarries [get]
 func (carrier *Carrier) GetReportCarriersByLocalities() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
+		format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		if c.Query("id") == "" {
-			data, err := carrier.carrierService.GetCountAndDataByLocality(&ctx)
+			options, err := listing.Parse(c.Request.URL.Query(), carriers.ReportFields)
+			if err != nil {
+				web.Error(c, http.StatusBadRequest, "Invalid query: %s", err.Error())
+				return
+			}
+			if format != export.JSON {
+				options = options.Unpaged()
+			}
+			data, total, err := carrier.carrierService.GetCountAndDataByLocality(ctx, options)
 			if err != nil {
 				web.Error(c, http.StatusNoContent, "data not found")
 				return
 			}
-			web.Success(c, http.StatusOK, data)
+			if format != export.JSON {
+				web.Report(c, format, data, dtos.DataLocalityAndCarrier{})
+				return
+			}
+			web.Page(c, http.StatusOK, data, total, options)
 
 		} else {
 			localityId, e := strconv.Atoi(c.Query("id"))
@@ -125,10 +150,14 @@ func (carrier *Carrier) GetReportCarriersByLocalities() gin.HandlerFunc {
 				web.Error(c, http.StatusBadRequest, "parameter id must be a integer")
 				return
 			}
-			locality, err := carrier.carrierService.GetLocalityById(&ctx, localityId)
-			count, err := carrier.carrierService.GetCountCarriersByLocalityId(&ctx, localityId)
+			locality, err := carrier.carrierService.GetLocalityById(ctx, localityId)
+			if err != nil {
+				_ = c.Error(err)
+				return
+			}
+			count, err := carrier.carrierService.GetCountCarriersByLocalityId(ctx, localityId)
 			if err != nil {
-				web.Error(c, http.StatusNotFound, "none carrier exists with this location_id")
+				_ = c.Error(err)
 				return
 			}
 			response := &dtos.DataLocalityAndCarrier{
@@ -136,7 +165,61 @@ func (carrier *Carrier) GetReportCarriersByLocalities() gin.HandlerFunc {
 				LocalityName: locality.LocalityName,
 				CountCarrier: *count,
 			}
-			web.Success(c, http.StatusOK, *response)
+			web.Report(c, format, *response, dtos.DataLocalityAndCarrier{})
+		}
+	}
+}
+
+// deleteCarriers godoc
+//
+//	@Summary		Delete carriers
+//	@Tags			Carriers
+//	@Description	Delete a carrier, it can be restored later
+//	@Param			id	path	int	true	"Carrier ID"
+//	@Success		204
+//	@Failure		404	{object}	web.errorResponse
+//	@Router			/api/v1/carriers/{id} [delete]
+func (carrier *Carrier) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "parameter id must be a integer")
+			return
+		}
+
+		if err := carrier.carrierService.Delete(c.Request.Context(), id); err != nil {
+			_ = c.Error(err)
+			return
 		}
+
+		web.Success(c, http.StatusNoContent, nil)
+	}
+}
+
+// restoreCarriers godoc
+//
+//	@Summary		Restore carriers
+//	@Tags			Carriers
+//	@Description	Restore a deleted carrier
+//	@Produce		json
+//	@Param			id	path		int	true	"Carrier ID"
+//	@Success		200	{object}	domain.Carrier
+//	@Failure		404	{object}	web.errorResponse
+//	@Router			/api/v1/carriers/{id}/restore [post]
+func (carrier *Carrier) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "parameter id must be a integer")
+			return
+		}
+
+		restored, err := carrier.carrierService.Restore(c.Request.Context(), id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		web.Success(c, http.StatusOK, restored)
 	}
 }
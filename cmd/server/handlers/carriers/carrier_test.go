@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	carrier_handler "github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/carriers"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/carrier"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/carriers"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/carriers/mocks"
@@ -40,11 +41,12 @@ func TestGetAll(t *testing.T) {
 			},
 		}
 		carrierServiceMock := new(mocks.CarrierServiceMock)
-		carrierServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(carriersFounds, nil)
+		carrierServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(carriersFounds, len(*carriersFounds), nil)
 		handler := carrier_handler.NewCarrier(carrierServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/carriers", handler.GetAll())
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/carriers", nil)
 		res := httptest.NewRecorder()
@@ -65,10 +67,11 @@ func TestGetAll(t *testing.T) {
 	t.Run("empty_database", func(t *testing.T) {
 		carriersFounds := &[]domain.Carrier{}
 		carrierServiceMock := new(mocks.CarrierServiceMock)
-		carrierServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(carriersFounds, nil)
+		carrierServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(carriersFounds, len(*carriersFounds), nil)
 		handler := carrier_handler.NewCarrier(carrierServiceMock)
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/carriers", handler.GetAll())
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/carriers", nil)
 		res := httptest.NewRecorder()
@@ -80,11 +83,12 @@ func TestGetAll(t *testing.T) {
 	t.Run("internal_server_error", func(t *testing.T) {
 		carriersFounds := &[]domain.Carrier{}
 		carrierServiceMock := new(mocks.CarrierServiceMock)
-		carrierServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(carriersFounds, assert.AnError)
+		carrierServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(carriersFounds, 0, assert.AnError)
 		handler := carrier_handler.NewCarrier(carrierServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/carriers", handler.GetAll())
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/carriers", nil)
 		res := httptest.NewRecorder()
@@ -93,6 +97,24 @@ func TestGetAll(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, res.Code)
 
 	})
+
+	t.Run("getAll_invalid_query", func(t *testing.T) {
+		serviceMock := new(mocks.CarrierServiceMock)
+		handler := carrier_handler.NewCarrier(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/carriers", handler.GetAll())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/carriers?limit=0", nil)
+		res := httptest.NewRecorder()
+
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		serviceMock.AssertNotCalled(t, "GetPage", mock.Anything, mock.Anything)
+	})
 }
 
 func TestCreate(t *testing.T) {
@@ -113,11 +135,12 @@ func TestCreate(t *testing.T) {
 			LocalityId:  6700,
 		}
 		carrierServiceMock := new(mocks.CarrierServiceMock)
-		carrierServiceMock.On("Create", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("dtos.CarrierRequestDTO")).Return(expectedCarrier, nil)
+		carrierServiceMock.On("Create", mock.Anything, mock.AnythingOfType("dtos.CarrierRequestDTO")).Return(expectedCarrier, nil)
 		handler := carrier_handler.NewCarrier(carrierServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/carriers", handler.Create())
 
 		requestBody, _ := json.Marshal(createCarrierRequestDTO)
@@ -138,26 +161,6 @@ func TestCreate(t *testing.T) {
 		assert.Equal(t, http.StatusCreated, res.Code)
 		assert.Equal(t, *expectedCarrier, *actualCarrier)
 	})
-	t.Run("create_fail", func(t *testing.T) {
-		createCarrierRequestDTO := dtos.CarrierRequestDTO{
-			CID:         "CID#1",
-			CompanyName: "some name",
-			Address:     "corrientes 800",
-			Telephone:   "4567-4567",
-			LocalityId:  6700,
-		}
-
-		carrierServiceMock := new(mocks.CarrierServiceMock)
-		carrierServiceMock.On("Create", mock.AnythingOfType("*context.Context")).Return(createCarrierRequestDTO, carriers.ErrUnprocessableEntity)
-		handler := carrier_handler.NewCarrier(carrierServiceMock)
-		gin.SetMode(gin.TestMode)
-		r := gin.Default()
-		r.POST("/api/v1/carriers", handler.Create())
-		req := httptest.NewRequest(http.MethodPost, "/api/v1/carriers", nil)
-		res := httptest.NewRecorder()
-		r.ServeHTTP(res, req)
-		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
-	})
 	t.Run("create_conflit", func(t *testing.T) {
 		expectedCarrier := &domain.Carrier{}
 		createCarrierRequestDTO := dtos.CarrierRequestDTO{
@@ -168,11 +171,12 @@ func TestCreate(t *testing.T) {
 			LocalityId:  6700,
 		}
 		carrierServiceMock := new(mocks.CarrierServiceMock)
-		carrierServiceMock.On("Create", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("dtos.CarrierRequestDTO")).Return(expectedCarrier, carriers.ErrConflict)
+		carrierServiceMock.On("Create", mock.Anything, mock.AnythingOfType("dtos.CarrierRequestDTO")).Return(expectedCarrier, carriers.ErrConflict)
 		handler := carrier_handler.NewCarrier(carrierServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/carriers", handler.Create())
 
 		requestBody, _ := json.Marshal(createCarrierRequestDTO)
@@ -197,6 +201,7 @@ func TestCreate(t *testing.T) {
 		handler := carrier_handler.NewCarrier(carrierServiceMock)
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/carriers", handler.Create())
 
 		requestBody, _ := json.Marshal(createCarrierRequestDTO)
@@ -205,7 +210,8 @@ func TestCreate(t *testing.T) {
 		res := httptest.NewRecorder()
 		r.ServeHTTP(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+		assert.JSONEq(t, `{"code":"unprocessable_entity","message":"invalid request body","details":[{"field":"cid","rule":"required","message":"is required"}]}`, res.Body.String())
 	})
 	t.Run("create_fail_company_name_nil", func(t *testing.T) {
 
@@ -220,6 +226,7 @@ func TestCreate(t *testing.T) {
 		handler := carrier_handler.NewCarrier(carrierServiceMock)
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/carriers", handler.Create())
 
 		requestBody, _ := json.Marshal(createCarrierRequestDTO)
@@ -228,7 +235,7 @@ func TestCreate(t *testing.T) {
 		res := httptest.NewRecorder()
 		r.ServeHTTP(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
 	})
 	t.Run("create_fail_address_nil", func(t *testing.T) {
 
@@ -243,6 +250,7 @@ func TestCreate(t *testing.T) {
 		handler := carrier_handler.NewCarrier(carrierServiceMock)
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/carriers", handler.Create())
 
 		requestBody, _ := json.Marshal(createCarrierRequestDTO)
@@ -251,7 +259,7 @@ func TestCreate(t *testing.T) {
 		res := httptest.NewRecorder()
 		r.ServeHTTP(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
 	})
 	t.Run("create_fail_telephone_nil", func(t *testing.T) {
 
@@ -266,6 +274,7 @@ func TestCreate(t *testing.T) {
 		handler := carrier_handler.NewCarrier(carrierServiceMock)
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/carriers", handler.Create())
 
 		requestBody, _ := json.Marshal(createCarrierRequestDTO)
@@ -274,7 +283,7 @@ func TestCreate(t *testing.T) {
 		res := httptest.NewRecorder()
 		r.ServeHTTP(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
 	})
 	t.Run("create_fail_locality_id_nil", func(t *testing.T) {
 
@@ -289,6 +298,7 @@ func TestCreate(t *testing.T) {
 		handler := carrier_handler.NewCarrier(carrierServiceMock)
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/carriers", handler.Create())
 
 		requestBody, _ := json.Marshal(createCarrierRequestDTO)
@@ -297,7 +307,7 @@ func TestCreate(t *testing.T) {
 		res := httptest.NewRecorder()
 		r.ServeHTTP(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
 	})
 }
 
@@ -308,6 +318,7 @@ func TestGetReportCarriersByLocalities(t *testing.T) {
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/localities/reportCarries", handler.GetReportCarriersByLocalities())
 
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/localities/reportCarries?id=xyz", nil)
@@ -319,11 +330,12 @@ func TestGetReportCarriersByLocalities(t *testing.T) {
 	t.Run("get_locality_not_found", func(t *testing.T) {
 
 		carrierServiceMock := new(mocks.CarrierServiceMock)
-		carrierServiceMock.On("GetLocalityById", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(&domain.Locality{}, assert.AnError)
+		carrierServiceMock.On("GetLocalityById", mock.Anything, mock.AnythingOfType("int")).Return(&domain.Locality{}, assert.AnError)
 		handler := carrier_handler.NewCarrier(carrierServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v/localities/reportCarries", handler.GetReportCarriersByLocalities())
 
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/localities/reportCarries?id=1", nil)
@@ -334,18 +346,13 @@ func TestGetReportCarriersByLocalities(t *testing.T) {
 	})
 
 	t.Run("none_carrier_exist", func(t *testing.T) {
-		localityExpected := &domain.Locality{
-			ID:           1,
-			ProvinceName: "Teste",
-			LocalityName: "Teste",
-		}
 		carrierServiceMock := new(mocks.CarrierServiceMock)
-		carrierServiceMock.On("GetLocalityById", mock.AnythingOfType("*context.Context")).Return(localityExpected, nil)
-		carrierServiceMock.On("GetCountCarriersByLocalityId", mock.AnythingOfType("*context.Context")).Return(0, assert.AnError)
+		carrierServiceMock.On("GetLocalityById", mock.Anything).Return(&domain.Locality{}, carriers.ErrLocalityNotFound)
 		handler := carrier_handler.NewCarrier(carrierServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/localities/reportCarries", handler.GetReportCarriersByLocalities())
 
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/localities/reportCarries?id=1", nil)
@@ -361,12 +368,13 @@ func TestGetReportCarriersByLocalities(t *testing.T) {
 			LocalityName: "Teste",
 		}
 		carrierServiceMock := new(mocks.CarrierServiceMock)
-		carrierServiceMock.On("GetLocalityById", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(localityExpected, nil)
-		carrierServiceMock.On("GetCountCarriersByLocalityId", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(nil, errors.New("error"))
+		carrierServiceMock.On("GetLocalityById", mock.Anything, mock.AnythingOfType("int")).Return(localityExpected, nil)
+		carrierServiceMock.On("GetCountCarriersByLocalityId", mock.Anything, mock.AnythingOfType("int")).Return(nil, errors.New("error"))
 		handler := carrier_handler.NewCarrier(carrierServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/localities/reportCarries", handler.GetReportCarriersByLocalities())
 
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/localities/reportCarries?id=1", nil)
@@ -383,12 +391,13 @@ func TestGetReportCarriersByLocalities(t *testing.T) {
 		}
 
 		carrierServiceMock := new(mocks.CarrierServiceMock)
-		carrierServiceMock.On("GetLocalityById", mock.AnythingOfType("*context.Context")).Return(localityExpected, nil)
-		carrierServiceMock.On("GetCountCarriersByLocalityId", mock.AnythingOfType("*context.Context")).Return(23, nil)
+		carrierServiceMock.On("GetLocalityById", mock.Anything).Return(localityExpected, nil)
+		carrierServiceMock.On("GetCountCarriersByLocalityId", mock.Anything).Return(23, nil)
 		handler := carrier_handler.NewCarrier(carrierServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/localities/reportCarries", handler.GetReportCarriersByLocalities())
 
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/localities/reportCarries?id=1", nil)
@@ -421,11 +430,12 @@ func TestGetReportCarriersByLocalities(t *testing.T) {
 			},
 		}
 		carrierServiceMock := new(mocks.CarrierServiceMock)
-		carrierServiceMock.On("GetCountAndDataByLocality", mock.AnythingOfType("*context.Context")).Return(responsesFounds, nil)
+		carrierServiceMock.On("GetCountAndDataByLocality", mock.Anything, mock.AnythingOfType("listing.Options")).Return(responsesFounds, len(*responsesFounds), nil)
 		handler := carrier_handler.NewCarrier(carrierServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/localities/reportCarries", handler.GetReportCarriersByLocalities())
 
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/localities/reportCarries", nil)
@@ -449,11 +459,12 @@ func TestGetReportCarriersByLocalities(t *testing.T) {
 	t.Run("get_all_carriers_no_content", func(t *testing.T) {
 		responsesFounds := &[]dtos.DataLocalityAndCarrier{}
 		carrierServiceMock := new(mocks.CarrierServiceMock)
-		carrierServiceMock.On("GetCountAndDataByLocality", mock.AnythingOfType("*context.Context")).Return(responsesFounds, assert.AnError)
+		carrierServiceMock.On("GetCountAndDataByLocality", mock.Anything, mock.AnythingOfType("listing.Options")).Return(responsesFounds, 0, assert.AnError)
 		handler := carrier_handler.NewCarrier(carrierServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/localities/reportCarries", handler.GetReportCarriersByLocalities())
 
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/localities/reportCarries", nil)
@@ -464,6 +475,24 @@ func TestGetReportCarriersByLocalities(t *testing.T) {
 		assert.Equal(t, http.StatusNoContent, res.Code)
 	})
 
+	t.Run("get_all_carriers_invalid_query", func(t *testing.T) {
+		carrierServiceMock := new(mocks.CarrierServiceMock)
+		handler := carrier_handler.NewCarrier(carrierServiceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/localities/reportCarries", handler.GetReportCarriersByLocalities())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/localities/reportCarries?sort=count", nil)
+		res := httptest.NewRecorder()
+
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		carrierServiceMock.AssertNotCalled(t, "GetCountAndDataByLocality", mock.Anything, mock.Anything)
+	})
+
 	t.Run("none_carrier_exists", func(t *testing.T) {
 		localityExpected := &domain.Locality{
 			ID:           1,
@@ -471,12 +500,13 @@ func TestGetReportCarriersByLocalities(t *testing.T) {
 			LocalityName: "Teste",
 		}
 		carrierServiceMock := new(mocks.CarrierServiceMock)
-		carrierServiceMock.On("GetLocalityById", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(localityExpected, nil)
-		carrierServiceMock.On("GetCountCarriersByLocalityId", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(0, nil)
+		carrierServiceMock.On("GetLocalityById", mock.Anything, mock.AnythingOfType("int")).Return(localityExpected, nil)
+		carrierServiceMock.On("GetCountCarriersByLocalityId", mock.Anything, mock.AnythingOfType("int")).Return(0, nil)
 		handler := carrier_handler.NewCarrier(carrierServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v/localities/reportCarries", handler.GetReportCarriersByLocalities())
 
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/localities/reportCarries?id=1", nil)
@@ -486,3 +516,111 @@ func TestGetReportCarriersByLocalities(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, res.Code)
 	})
 }
+
+func TestRestore(t *testing.T) {
+	t.Run("restore_ok", func(t *testing.T) {
+		serviceMock := new(mocks.CarrierServiceMock)
+		serviceMock.On("Restore", mock.Anything, 1).Return(&domain.Carrier{ID: 1, CID: "CID#1", LocalityId: 6700}, nil)
+		handler := carrier_handler.NewCarrier(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/carriers/:id/restore", handler.Restore())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/carriers/1/restore", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"data":{"id":1,"cid":"CID#1","company_name":"","address":"","telephone":"","locality_id":6700}}`, res.Body.String())
+	})
+
+	t.Run("restore_not_deleted", func(t *testing.T) {
+		serviceMock := new(mocks.CarrierServiceMock)
+		serviceMock.On("Restore", mock.Anything, 1).Return((*domain.Carrier)(nil), carriers.ErrNotFound)
+		handler := carrier_handler.NewCarrier(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/carriers/:id/restore", handler.Restore())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/carriers/1/restore", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("restore_invalid_id", func(t *testing.T) {
+		serviceMock := new(mocks.CarrierServiceMock)
+		serviceMock.On("Restore", mock.Anything, 1).Return((*domain.Carrier)(nil), carriers.ErrNotFound)
+		handler := carrier_handler.NewCarrier(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/carriers/:id/restore", handler.Restore())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/carriers/xyz/restore", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		serviceMock.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("delete_ok", func(t *testing.T) {
+		serviceMock := new(mocks.CarrierServiceMock)
+		serviceMock.On("Delete", mock.Anything, 1).Return(nil)
+		handler := carrier_handler.NewCarrier(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.DELETE("/api/v1/carriers/:id", handler.Delete())
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/carriers/1", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusNoContent, res.Code)
+	})
+
+	t.Run("delete_not_found", func(t *testing.T) {
+		serviceMock := new(mocks.CarrierServiceMock)
+		serviceMock.On("Delete", mock.Anything, 1).Return(carriers.ErrNotFound)
+		handler := carrier_handler.NewCarrier(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.DELETE("/api/v1/carriers/:id", handler.Delete())
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/carriers/1", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("delete_unexpected_error", func(t *testing.T) {
+		serviceMock := new(mocks.CarrierServiceMock)
+		serviceMock.On("Delete", mock.Anything, 1).Return(errors.New("error"))
+		handler := carrier_handler.NewCarrier(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.DELETE("/api/v1/carriers/:id", handler.Delete())
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/carriers/1", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+	})
+}
@@ -0,0 +1,204 @@
+package countries
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/country"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CountryHandler struct {
+	countryService country.CountryService
+}
+
+func NewCountryHandler(countryService country.CountryService) *CountryHandler {
+	return &CountryHandler{
+		countryService,
+	}
+}
+
+// Get is the handler to search for a country and return their details.
+//
+//	@Summary		Get Country
+//	@Tags			Countries
+//	@Description	Get the details of a Country
+//	@Produce		json
+//	@Param			id	path		string	true	"ID of Country to be searched"
+//	@Success		200	{object}	domain.Country
+//	@Failure		400	{object}	web.errorResponse
+//	@Failure		404	{object}	web.errorResponse
+//	@Failure		500	{object}	web.errorResponse
+//	@Router			/api/v1/countries/{id} [get]
+func (handler *CountryHandler) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		id, err := getIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ctx := c.Request.Context()
+		if countryFound, err := handler.countryService.Get(ctx, id); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Response(c, http.StatusOK, countryFound)
+			return
+		}
+	}
+}
+
+// GetAll is the handler to search for all countries.
+//
+//	@Summary		List Countries
+//	@Tags			Countries
+//	@Description	Get the details of all countries on the database.
+//	@Produce		json
+//	@Success		200	{object}	web.response{data=[]domain.Country}
+//	@Failure		500	{object}	web.errorResponse
+//	@Router			/api/v1/countries [get]
+func (handler *CountryHandler) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		ctx := c.Request.Context()
+		if countries, err := handler.countryService.GetAll(ctx); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			if len(countries) == 0 {
+				web.Success(c, http.StatusNoContent, countries)
+				return
+			}
+			web.Success(c, http.StatusOK, countries)
+			return
+		}
+	}
+}
+
+// Create is the handler to create a country.
+//
+//	@Summary		Create Country
+//	@Tags			Countries
+//	@Description	Save a country on the database.
+//	@Accept			json
+//	@Produce		json
+//	@Param			Country	body		domain.Country	true	"Country to Create"
+//	@Success		201		{object}	domain.Country
+//	@Failure		409		{object}	web.errorResponse
+//	@Failure		422		{object}	web.errorResponse
+//	@Failure		500		{object}	web.errorResponse
+//	@Router			/api/v1/countries [post]
+func (handler *CountryHandler) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		var createCountryRequest domain.Country
+		if err := c.ShouldBindJSON(&createCountryRequest); err != nil {
+			web.ValidationError(c, err)
+			return
+		}
+
+		ctx := c.Request.Context()
+		if createdCountry, err := handler.countryService.Create(ctx, createCountryRequest); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Response(c, http.StatusCreated, createdCountry)
+			return
+		}
+	}
+}
+
+// Update is the handler to update a country details.
+//
+//	@Summary		Update Country
+//	@Tags			Countries
+//	@Description	Update the details of a Country
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"ID of Country to be updated"
+//	@Param			Country	body		dtos.UpdateCountryRequestDTO	true	"Updated Country details"
+//	@Success		200		{object}	domain.Country
+//	@Failure		400		{object}	web.errorResponse
+//	@Failure		404		{object}	web.errorResponse
+//	@Failure		422		{object}	web.errorResponse
+//	@Failure		500		{object}	web.errorResponse
+//	@Router			/api/v1/countries/{id} [patch]
+func (handler *CountryHandler) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		id, err := getIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var updateCountryRequest dtos.UpdateCountryRequestDTO
+		if err := c.ShouldBindJSON(&updateCountryRequest); err != nil {
+			web.ValidationError(c, err)
+			return
+		}
+
+		ctx := c.Request.Context()
+		if updatedCountry, err := handler.countryService.Update(ctx, id, updateCountryRequest); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Response(c, http.StatusOK, updatedCountry)
+			return
+		}
+	}
+}
+
+// Delete is the handler to delete a country.
+//
+//	@Summary		Delete Country
+//	@Tags			Countries
+//	@Description	Delete Countries
+//	@Produce		json
+//	@Param			id	path	string	true	"ID of a Country to be excluded"
+//	@Success		204
+//	@Failure		400	{object}	web.errorResponse
+//	@Failure		404	{object}	web.errorResponse
+//	@Failure		500	{object}	web.errorResponse
+//	@Router			/api/v1/countries/{id} [delete]
+func (handler *CountryHandler) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		id, err := getIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ctx := c.Request.Context()
+		if err := handler.countryService.Delete(ctx, id); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Response(c, http.StatusNoContent, nil)
+			return
+		}
+	}
+}
+
+func getIdFromUri(c *gin.Context) (id int, err error) {
+
+	value, _ := c.Params.Get("id")
+	id, err = strconv.Atoi(value)
+
+	if err != nil {
+		err = errors.New(fmt.Sprintf("Invalid id on request: %s", c.Request.RequestURI))
+		return
+	}
+
+	return
+
+}
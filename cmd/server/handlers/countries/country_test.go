@@ -0,0 +1,372 @@
+package countries_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/countries"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/country/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+
+	countrySerialized, _ := os.ReadFile("../../../../test/resources/valid_country.json")
+	var validCountry domain.Country
+	if err := json.Unmarshal(countrySerialized, &validCountry); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name             string
+		id               string
+		expectedGetCalls int
+		expectedGetError error
+		expectedCountry  domain.Country
+		expectedCode     int
+	}{
+		{
+			name:             "Success finding country",
+			id:               "1",
+			expectedGetCalls: 1,
+			expectedCountry:  validCountry,
+			expectedCode:     http.StatusOK,
+		},
+		{
+			name:             "Error finding country",
+			id:               "999",
+			expectedGetCalls: 1,
+			expectedGetError: errors.ErrNotFound,
+			expectedCode:     http.StatusNotFound,
+		},
+		{
+			name:             "Error connecting db",
+			id:               "1",
+			expectedGetCalls: 1,
+			expectedGetError: assert.AnError,
+			expectedCode:     http.StatusInternalServerError,
+		},
+		{
+			name:         "Error invalid id",
+			id:           "xyz",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			countryServiceMock := mocks.NewMockCountryService(t)
+			countryServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(test.expectedCountry, test.expectedGetError)
+
+			countryHandler := countries.NewCountryHandler(countryServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.GET("/api/v1/countries/:id", countryHandler.Get())
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/%s", "/api/v1/countries", test.id), nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			countryServiceMock.AssertNumberOfCalls(t, "Get", test.expectedGetCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			if test.expectedCode == http.StatusOK {
+				body, _ := io.ReadAll(res.Body)
+				var response domain.Country
+				json.Unmarshal(body, &response)
+
+				assert.Equal(t, test.expectedCountry, response)
+			}
+		})
+	}
+}
+
+func TestGetAll(t *testing.T) {
+
+	countriesSerialized, _ := os.ReadFile("../../../../test/resources/valid_countries.json")
+	var validCountries []domain.Country
+	if err := json.Unmarshal(countriesSerialized, &validCountries); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                 string
+		expectedGetAllResult []domain.Country
+		expectedGetAllError  error
+		expectedCode         int
+	}{
+		{
+			name:                 "Successfully get all countries",
+			expectedGetAllResult: validCountries,
+			expectedCode:         http.StatusOK,
+		},
+		{
+			name:                 "Success empty database",
+			expectedGetAllResult: []domain.Country{},
+			expectedCode:         http.StatusNoContent,
+		},
+		{
+			name:                 "Error getting all countries",
+			expectedGetAllResult: []domain.Country{},
+			expectedGetAllError:  assert.AnError,
+			expectedCode:         http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			countryServiceMock := mocks.NewMockCountryService(t)
+			countryServiceMock.On("GetAll", mock.Anything).Return(test.expectedGetAllResult, test.expectedGetAllError)
+
+			countryHandler := countries.NewCountryHandler(countryServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.GET("/api/v1/countries", countryHandler.GetAll())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/countries", nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			countryServiceMock.AssertNumberOfCalls(t, "GetAll", 1)
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			if test.expectedCode == http.StatusOK {
+				body, _ := io.ReadAll(res.Body)
+				var responseDTO struct {
+					Data []domain.Country `json:"data"`
+				}
+				json.Unmarshal(body, &responseDTO)
+
+				assert.Equal(t, test.expectedGetAllResult, responseDTO.Data)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+
+	countrySerialized, _ := os.ReadFile("../../../../test/resources/valid_country.json")
+	var validCountry domain.Country
+	if err := json.Unmarshal(countrySerialized, &validCountry); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                 string
+		request              interface{}
+		expectedCreateResult domain.Country
+		expectedCreateError  error
+		expectedCreateCalls  int
+		expectedCode         int
+	}{
+		{
+			name:                 "Successfully creating country",
+			request:              domain.Country{CountryName: validCountry.CountryName},
+			expectedCreateResult: validCountry,
+			expectedCreateCalls:  1,
+			expectedCode:         http.StatusCreated,
+		},
+		{
+			name:                "Error duplicated country",
+			request:             domain.Country{CountryName: validCountry.CountryName},
+			expectedCreateError: errors.ErrConflict,
+			expectedCreateCalls: 1,
+			expectedCode:        http.StatusConflict,
+		},
+		{
+			name:                "Error creating country",
+			request:             domain.Country{CountryName: validCountry.CountryName},
+			expectedCreateError: assert.AnError,
+			expectedCreateCalls: 1,
+			expectedCode:        http.StatusInternalServerError,
+		},
+		{
+			name:         "Error invalid country",
+			request:      domain.Country{},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			countryServiceMock := mocks.NewMockCountryService(t)
+			countryServiceMock.On("Create", mock.Anything, mock.AnythingOfType("domain.Country")).Return(test.expectedCreateResult, test.expectedCreateError)
+
+			countryHandler := countries.NewCountryHandler(countryServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.POST("/api/v1/countries", countryHandler.Create())
+
+			requestBody, _ := json.Marshal(test.request)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/countries", bytes.NewReader(requestBody))
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			countryServiceMock.AssertNumberOfCalls(t, "Create", test.expectedCreateCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			if test.expectedCode == http.StatusCreated {
+				body, _ := io.ReadAll(res.Body)
+				var response domain.Country
+				json.Unmarshal(body, &response)
+
+				assert.Equal(t, test.expectedCreateResult, response)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+
+	newCountryName := "Uruguay"
+	updatedCountry := domain.Country{ID: 1, CountryName: newCountryName}
+
+	tests := []struct {
+		name                 string
+		id                   string
+		request              interface{}
+		expectedUpdateResult domain.Country
+		expectedUpdateError  error
+		expectedUpdateCalls  int
+		expectedCode         int
+	}{
+		{
+			name:                 "Successfully updating country",
+			id:                   "1",
+			request:              dtos.UpdateCountryRequestDTO{CountryName: &newCountryName},
+			expectedUpdateResult: updatedCountry,
+			expectedUpdateCalls:  1,
+			expectedCode:         http.StatusOK,
+		},
+		{
+			name:                "Error updating inexisting country",
+			id:                  "999",
+			request:             dtos.UpdateCountryRequestDTO{CountryName: &newCountryName},
+			expectedUpdateError: errors.ErrNotFound,
+			expectedUpdateCalls: 1,
+			expectedCode:        http.StatusNotFound,
+		},
+		{
+			name:         "Error invalid update request",
+			id:           "1",
+			request:      interface{}(""),
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:         "Error invalid id",
+			id:           "xyz",
+			request:      dtos.UpdateCountryRequestDTO{CountryName: &newCountryName},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			countryServiceMock := mocks.NewMockCountryService(t)
+			countryServiceMock.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("dtos.UpdateCountryRequestDTO")).Return(test.expectedUpdateResult, test.expectedUpdateError)
+
+			countryHandler := countries.NewCountryHandler(countryServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.PATCH("/api/v1/countries/:id", countryHandler.Update())
+
+			requestBody, _ := json.Marshal(test.request)
+			req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("%s/%s", "/api/v1/countries", test.id), bytes.NewReader(requestBody))
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			countryServiceMock.AssertNumberOfCalls(t, "Update", test.expectedUpdateCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			if test.expectedCode == http.StatusOK {
+				body, _ := io.ReadAll(res.Body)
+				var response domain.Country
+				json.Unmarshal(body, &response)
+
+				assert.Equal(t, test.expectedUpdateResult, response)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+
+	tests := []struct {
+		name                string
+		id                  string
+		expectedDeleteError error
+		expectedDeleteCalls int
+		expectedCode        int
+	}{
+		{
+			name:                "Successfully deleting country",
+			id:                  "1",
+			expectedDeleteCalls: 1,
+			expectedCode:        http.StatusNoContent,
+		},
+		{
+			name:                "Error deleting inexisting country",
+			id:                  "999",
+			expectedDeleteError: errors.ErrNotFound,
+			expectedDeleteCalls: 1,
+			expectedCode:        http.StatusNotFound,
+		},
+		{
+			name:                "Error deleting country",
+			id:                  "1",
+			expectedDeleteError: assert.AnError,
+			expectedDeleteCalls: 1,
+			expectedCode:        http.StatusInternalServerError,
+		},
+		{
+			name:         "Error invalid id",
+			id:           "xyz",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			countryServiceMock := mocks.NewMockCountryService(t)
+			countryServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(test.expectedDeleteError)
+
+			countryHandler := countries.NewCountryHandler(countryServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.DELETE("/api/v1/countries/:id", countryHandler.Delete())
+
+			req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%s", "/api/v1/countries", test.id), nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			countryServiceMock.AssertNumberOfCalls(t, "Delete", test.expectedDeleteCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+		})
+	}
+}
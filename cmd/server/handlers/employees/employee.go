@@ -1,21 +1,17 @@
 package employees
 
 import (
-	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/employee"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/export"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
 	"github.com/gin-gonic/gin"
 )
 
-var (
-	ErrNotFound = errors.New("employee not found")
-	ErrConflict = errors.New("409 Conflict: Employee with CardNumberID already exists")
-)
-
 type Employee struct {
 	service employee.Service
 }
@@ -46,18 +42,14 @@ func (e *Employee) Get() gin.HandlerFunc {
 		}
 
 		ctx := c.Request.Context()
-		employee, err := e.service.Get(&ctx, id)
+		employeeFound, err := e.service.Get(ctx, id)
 
 		if err != nil {
-			if errors.Is(err, ErrNotFound) {
-				web.Error(c, http.StatusNotFound, "Employee not found: %s", err.Error())
-				return
-			}
-			web.Error(c, http.StatusInternalServerError, "Failed to get employee: %s", err.Error())
+			_ = c.Error(err)
 			return
 		}
 
-		web.Success(c, http.StatusOK, *employee)
+		web.Success(c, http.StatusOK, *employeeFound)
 	}
 }
 
@@ -68,25 +60,51 @@ func (e *Employee) Get() gin.HandlerFunc {
 //	@Tags			Employees
 //	@Description	getAll employees
 //	@Accept			json
-//	@Produce		json
-//	@Success		200	{object}	web.response
+//	@Produce		json,text/csv,application/x-ndjson
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			offset	query		int		false	"Number of employees to skip"
+//	@Param			sort	query		string	false	"Field to sort by"
+//	@Param			order	query		string	false	"asc or desc"
+//	@Param			format	query		string	false	"json, csv or ndjson, or use the Accept header"
+//	@Param			include_deleted	query		bool	false	"List deleted records too"
+//	@Success		200		{object}	web.response
 //	@Router			/api/v1/employees [get]
 func (e *Employee) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		options, err := listing.ParseSoftDeleted(c.Request.URL.Query(), employee.Fields, "deleted_at")
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "Invalid query: %s", err.Error())
+			return
+		}
+
+		format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if format != export.JSON {
+			web.Export(c, format, domain.Employee{}, func(write func(row interface{}) error) error {
+				return e.service.Each(c.Request.Context(), options.Unpaged(), func(row domain.Employee) error {
+					return write(row)
+				})
+			})
+			return
+		}
+
 		ctx := c.Request.Context()
-		employee, err := e.service.GetAll(&ctx)
+		employees, total, err := e.service.GetPage(ctx, options)
 
 		if err != nil {
-			web.Error(c, http.StatusInternalServerError, "Failed to get employee: %s", err.Error())
+			_ = c.Error(err)
 			return
 		}
 
-		if len(*employee) == 0 {
+		if len(*employees) == 0 {
 			web.Error(c, http.StatusNoContent, "There are no employee stored: ")
 			return
 		}
 
-		web.Success(c, http.StatusOK, *employee)
+		web.Page(c, http.StatusOK, *employees, total, options)
 	}
 }
 
@@ -106,7 +124,7 @@ func (e *Employee) Save() gin.HandlerFunc {
 		// createEmployee := domain.RequestCreateEmployee{}
 		createEmployee := new(domain.RequestCreateEmployee)
 		if err := c.ShouldBindJSON(&createEmployee); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, "JSON format may be wrong")
+			web.ValidationError(c, err)
 			return
 		}
 
@@ -117,35 +135,11 @@ func (e *Employee) Save() gin.HandlerFunc {
 			WarehouseID:  createEmployee.WarehouseID,
 		}
 
-		if employeeDomain.CardNumberID == "" {
-			web.Error(c, http.StatusBadRequest, "Field Card Number ID is required: %s", "")
-			return
-		}
-
-		if employeeDomain.FirstName == "" {
-			web.Error(c, http.StatusBadRequest, "Field First Name is required: %s", "")
-		}
-
-		if employeeDomain.LastName == "" {
-			web.Error(c, http.StatusBadRequest, "Field Last Name is required: %s", "")
-		}
-
-		if employeeDomain.WarehouseID == 0 {
-			web.Error(c, http.StatusBadRequest, "Field Ware House ID is required: %s", "")
-		}
-
 		ctx := c.Request.Context()
-		employeeDomain, err := e.service.Save(&ctx, *employeeDomain)
+		employeeDomain, err := e.service.Save(ctx, *employeeDomain)
 		if err != nil {
-			switch err {
-			case employee.ErrConflict:
-				web.Error(c, http.StatusConflict, err.Error())
-				return
-			default:
-				web.Error(c, http.StatusBadRequest, "Error to save request: %s", err.Error())
-				return
-			}
-
+			_ = c.Error(err)
+			return
 		}
 
 		web.Success(c, http.StatusCreated, *employeeDomain)
@@ -175,14 +169,14 @@ func (e *Employee) Update() gin.HandlerFunc {
 		ReqUpdateEmployee := new(domain.RequestUpdateEmployee)
 
 		if err := c.ShouldBindJSON(&ReqUpdateEmployee); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, "Error to read request: %s", err.Error())
+			web.ValidationError(c, err)
 			return
 		}
 
 		ctx := c.Request.Context()
-		employeeUpdate, err := e.service.Update(&ctx, id, ReqUpdateEmployee)
+		employeeUpdate, err := e.service.Update(ctx, id, ReqUpdateEmployee)
 		if err != nil {
-			web.Error(c, http.StatusNotFound, "Error to update: %s", err.Error())
+			_ = c.Error(err)
 			return
 		}
 
@@ -209,12 +203,40 @@ func (e *Employee) Delete() gin.HandlerFunc {
 			return
 		}
 		ctx := c.Request.Context()
-		err = e.service.Delete(&ctx, int(id))
+		err = e.service.Delete(ctx, int(id))
 		if err != nil {
-			web.Error(c, http.StatusNotFound, "Error to delete: %s", err.Error())
+			_ = c.Error(err)
 			return
 		}
 
 		web.Success(c, http.StatusNoContent, nil)
 	}
 }
+
+// RestoreEmployee godoc
+//
+//	@Summary		Restore employee
+//	@Tags			Employees
+//	@Description	Restore a deleted employee
+//	@Produce		json
+//	@Param			id	path		int	true	"Employee ID"
+//	@Success		200	{object}	web.response
+//	@Router			/api/v1/employees/{id}/restore [post]
+func (e *Employee) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "Invalid ID: %s", err.Error())
+			return
+		}
+
+		ctx := c.Request.Context()
+		restored, err := e.service.Restore(ctx, id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		web.Success(c, http.StatusOK, *restored)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/employees"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/employee"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/employee/mocks"
@@ -32,12 +33,13 @@ func TestGet(t *testing.T) {
 
 		//Configurar o mock do service
 		employeeServiceMock := mocks.NewEmployeeServiceMock()
-		employeeServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(employeeFound, nil)
+		employeeServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(employeeFound, nil)
 		employees := employees.NewEmployee(employeeServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/employees/:id", employees.Get())
 
 		//Definir request e response
@@ -67,12 +69,13 @@ func TestGet(t *testing.T) {
 	t.Run("find_by_id_non_existent", func(t *testing.T) {
 		//Configurar o mock do service
 		employeeServiceMock := mocks.NewEmployeeServiceMock()
-		employeeServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(&domain.Employee{}, employees.ErrNotFound)
+		employeeServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(&domain.Employee{}, employee.ErrNotFound)
 		employees := employees.NewEmployee(employeeServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/employees/:id", employees.Get())
 
 		//Definir request e response
@@ -89,12 +92,13 @@ func TestGet(t *testing.T) {
 	t.Run("invalid_id", func(t *testing.T) {
 		//Configurar o mock do service
 		employeeServiceMock := mocks.NewEmployeeServiceMock()
-		employeeServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(&domain.Employee{}, employees.ErrNotFound)
+		employeeServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(&domain.Employee{}, employee.ErrNotFound)
 		employees := employees.NewEmployee(employeeServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/employees/:id", employees.Get())
 
 		//Definir request e response
@@ -111,12 +115,13 @@ func TestGet(t *testing.T) {
 	t.Run("internal_server_error", func(t *testing.T) {
 		//Configurar o mock do service
 		employeeServiceMock := mocks.NewEmployeeServiceMock()
-		employeeServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(&domain.Employee{}, assert.AnError)
+		employeeServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(&domain.Employee{}, assert.AnError)
 		employees := employees.NewEmployee(employeeServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/employees/:id", employees.Get())
 
 		//Definir request e response
@@ -154,12 +159,13 @@ func TestGetAll(t *testing.T) {
 
 		//Configurar o mock do service
 		employeeServiceMock := mocks.NewEmployeeServiceMock()
-		employeeServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(expectedEmployee, nil)
+		employeeServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(expectedEmployee, len(*expectedEmployee), nil)
 		employees := employees.NewEmployee(employeeServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/employees", employees.GetAll())
 
 		//Definir request e response
@@ -188,12 +194,13 @@ func TestGetAll(t *testing.T) {
 	t.Run("internal_server_error", func(t *testing.T) {
 		//Configurar o mock do service
 		employeeServiceMock := mocks.NewEmployeeServiceMock()
-		employeeServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(&[]domain.Employee{}, assert.AnError)
+		employeeServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(&[]domain.Employee{}, 0, assert.AnError)
 		employees := employees.NewEmployee(employeeServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/employees", employees.GetAll())
 
 		//Definir request e response
@@ -211,12 +218,13 @@ func TestGetAll(t *testing.T) {
 
 		//Configurar o mock do service
 		employeeServiceMock := mocks.NewEmployeeServiceMock()
-		employeeServiceMock.On("GetAll", mock.Anything).Return(employeeFound, nil)
+		employeeServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(employeeFound, len(*employeeFound), nil)
 		employees := employees.NewEmployee(employeeServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/employees", employees.GetAll())
 
 		//Definir request e response
@@ -230,6 +238,23 @@ func TestGetAll(t *testing.T) {
 		assert.Equal(t, http.StatusNoContent, res.Code)
 	})
 
+	t.Run("getAll_invalid_query", func(t *testing.T) {
+		serviceMock := mocks.NewEmployeeServiceMock()
+		handler := employees.NewEmployee(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/employees", handler.GetAll())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/employees?limit=0", nil)
+		res := httptest.NewRecorder()
+
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		serviceMock.AssertNotCalled(t, "GetPage", mock.Anything, mock.Anything)
+	})
 }
 
 func TestSave(t *testing.T) {
@@ -251,12 +276,13 @@ func TestSave(t *testing.T) {
 
 		//Configurar o mock do service
 		employeeServiceMock := mocks.NewEmployeeServiceMock()
-		employeeServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("domain.Employee")).Return(expectedEmployeeCreate, nil)
+		employeeServiceMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Employee")).Return(expectedEmployeeCreate, nil)
 		employees := employees.NewEmployee(employeeServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/employees", employees.Save())
 
 		requestBody, _ := json.Marshal(requestEmployeeCreated)
@@ -286,33 +312,6 @@ func TestSave(t *testing.T) {
 
 	})
 
-	t.Run("create_fail", func(t *testing.T) {
-		requestEmployeeCreated := domain.RequestCreateEmployee{
-			CardNumberID: "123",
-			FirstName:    "Maria",
-			LastName:     "Silva",
-			WarehouseID:  1,
-		}
-
-		//Configurar o mock do service
-		employeeServiceMock := mocks.NewEmployeeServiceMock()
-		employeeServiceMock.On("Save", mock.AnythingOfType("*context.Context")).Return(requestEmployeeCreated, employee.ErrUnprocessableEntity)
-		employees := employees.NewEmployee(employeeServiceMock)
-
-		//Configurar o servidor
-		gin.SetMode(gin.TestMode)
-		r := gin.Default()
-		r.POST("/api/v1/employees", employees.Save())
-
-		//Definir request e response
-		req := httptest.NewRequest(http.MethodPost, "/api/v1/employees", nil)
-		res := httptest.NewRecorder()
-
-		r.ServeHTTP(res, req)
-
-		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
-	})
-
 	t.Run("create_conflit", func(t *testing.T) {
 		expectedEmployeeCreate := &domain.Employee{}
 
@@ -325,12 +324,13 @@ func TestSave(t *testing.T) {
 
 		//Configurar o mock do service
 		employeeServiceMock := mocks.NewEmployeeServiceMock()
-		employeeServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("domain.Employee")).Return(expectedEmployeeCreate, employee.ErrConflict)
+		employeeServiceMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Employee")).Return(expectedEmployeeCreate, employee.ErrConflict)
 		employees := employees.NewEmployee(employeeServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/employees", employees.Save())
 
 		requestBody, _ := json.Marshal(requestEmployeeCreated)
@@ -359,6 +359,7 @@ func TestSave(t *testing.T) {
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/employees", employees.Save())
 
 		requestBody, _ := json.Marshal(requestEmployeeCreated)
@@ -369,7 +370,7 @@ func TestSave(t *testing.T) {
 
 		r.ServeHTTP(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
 	})
 
 	t.Run("create_fail_first_name_nil", func(t *testing.T) {
@@ -386,6 +387,7 @@ func TestSave(t *testing.T) {
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/employees", employees.Save())
 
 		requestBody, _ := json.Marshal(requestEmployeeCreated)
@@ -396,7 +398,7 @@ func TestSave(t *testing.T) {
 
 		r.ServeHTTP(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
 	})
 
 	t.Run("create_fail_last_name_nil", func(t *testing.T) {
@@ -413,6 +415,7 @@ func TestSave(t *testing.T) {
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/employees", employees.Save())
 
 		requestBody, _ := json.Marshal(requestEmployeeCreated)
@@ -423,7 +426,7 @@ func TestSave(t *testing.T) {
 
 		r.ServeHTTP(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
 	})
 
 	t.Run("create_fail_warehouse_id_nil", func(t *testing.T) {
@@ -440,6 +443,7 @@ func TestSave(t *testing.T) {
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/employees", employees.Save())
 
 		requestBody, _ := json.Marshal(requestEmployeeCreated)
@@ -450,7 +454,7 @@ func TestSave(t *testing.T) {
 
 		r.ServeHTTP(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
 	})
 
 	t.Run("create_internal_server_error", func(t *testing.T) {
@@ -471,11 +475,12 @@ func TestSave(t *testing.T) {
 
 		//Configurar o mock do service
 		employeeServiceMock := mocks.NewEmployeeServiceMock()
-		employeeServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("domain.Employee")).Return(*employeeCreate, assert.AnError)
+		employeeServiceMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Employee")).Return(*employeeCreate, assert.AnError)
 		employees := employees.NewEmployee(employeeServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/employees", employees.Save())
 
 		requestBody, _ := json.Marshal(requestEmployeeCreated)
@@ -495,7 +500,7 @@ func TestSave(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, res.Code)
 	})
 
-	t.Run("create_bad_request", func(t *testing.T) {
+	t.Run("create_internal_server_error", func(t *testing.T) {
 		requestEmployeeCreated := domain.RequestCreateEmployee{
 			CardNumberID: "123",
 			FirstName:    "Maria",
@@ -505,11 +510,12 @@ func TestSave(t *testing.T) {
 
 		//Configurar o mock do service
 		employeeServiceMock := mocks.NewEmployeeServiceMock()
-		employeeServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("domain.Employee")).Return(&domain.Employee{}, assert.AnError)
+		employeeServiceMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Employee")).Return(&domain.Employee{}, assert.AnError)
 		employees := employees.NewEmployee(employeeServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/employees", employees.Save())
 
 		requestBody, _ := json.Marshal(requestEmployeeCreated)
@@ -526,7 +532,7 @@ func TestSave(t *testing.T) {
 			Data domain.Warehouse `json:"data"`
 		}
 		json.Unmarshal(body, &responseDTO)
-		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
 	})
 }
 
@@ -536,13 +542,14 @@ func TestDelete(t *testing.T) {
 
 		//Configurar o mock do service
 		employeeServiceMock := mocks.NewEmployeeServiceMock()
-		employeeServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(employeeFound, nil)
-		employeeServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(nil)
+		employeeServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(employeeFound, nil)
+		employeeServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(nil)
 		employees := employees.NewEmployee(employeeServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.DELETE("/api/v1/employees/:id", employees.Delete())
 
 		//Definir request e response
@@ -557,13 +564,14 @@ func TestDelete(t *testing.T) {
 	t.Run("delete_non_existent", func(t *testing.T) {
 		//Configurar o mock do service
 		employeeServiceMock := mocks.NewEmployeeServiceMock()
-		employeeServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(domain.Employee{}, nil)
-		employeeServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(employee.ErrNotFound)
+		employeeServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(domain.Employee{}, nil)
+		employeeServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(employee.ErrNotFound)
 		employees := employees.NewEmployee(employeeServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.DELETE("/api/v1/employees/:id", employees.Delete())
 
 		//Definir request e response
@@ -579,12 +587,13 @@ func TestDelete(t *testing.T) {
 
 		//Configurar o mock do service
 		employeeServiceMock := mocks.NewEmployeeServiceMock()
-		employeeServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(nil)
+		employeeServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(nil)
 		employees := employees.NewEmployee(employeeServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.DELETE("/api/v1/employees/:id", employees.Delete())
 
 		//Definir request e response
@@ -617,12 +626,13 @@ func TestUpdate(t *testing.T) {
 		}
 
 		employeeServiceMock := mocks.NewEmployeeServiceMock()
-		employeeServiceMock.On("Update", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int"), mock.AnythingOfType("*domain.RequestUpdateEmployee")).Return(&employeeUpdated, nil)
+		employeeServiceMock.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("*domain.RequestUpdateEmployee")).Return(&employeeUpdated, nil)
 		employees := employees.NewEmployee(employeeServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/employees/:id", employees.Update())
 
 		requestBody, _ := json.Marshal(RequestUpdateEmployee)
@@ -653,6 +663,7 @@ func TestUpdate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/employees/:id", employees.Update())
 
 		req := httptest.NewRequest(http.MethodPatch, "/api/v1/employees/1", nil)
@@ -677,12 +688,13 @@ func TestUpdate(t *testing.T) {
 		updatedEmployee := &domain.Employee{}
 
 		employeeServiceMock := mocks.NewEmployeeServiceMock()
-		employeeServiceMock.On("Update", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int"), mock.AnythingOfType("*domain.RequestUpdateEmployee")).Return(updatedEmployee, employee.ErrNotFound)
+		employeeServiceMock.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("*domain.RequestUpdateEmployee")).Return(updatedEmployee, employee.ErrNotFound)
 		employees := employees.NewEmployee(employeeServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/employees/:id", employees.Update())
 
 		requestBody, _ := json.Marshal(RequestUpdateEmployee)
@@ -715,6 +727,7 @@ func TestUpdate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/employees/:id", employees.Update())
 
 		requestBody, _ := json.Marshal(RequestUpdateEmployee)
@@ -728,3 +741,58 @@ func TestUpdate(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, res.Code)
 	})
 }
+
+func TestRestore(t *testing.T) {
+	t.Run("restore_ok", func(t *testing.T) {
+		serviceMock := mocks.NewEmployeeServiceMock()
+		serviceMock.On("Restore", mock.Anything, 1).Return(&domain.Employee{ID: 1, CardNumberID: "123", FirstName: "Maria", LastName: "Silva", WarehouseID: 1}, nil)
+		handler := employees.NewEmployee(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/employees/:id/restore", handler.Restore())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/employees/1/restore", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"data":{"id":1,"card_number_id":"123","first_name":"Maria","last_name":"Silva","warehouse_id":1}}`, res.Body.String())
+	})
+
+	t.Run("restore_not_deleted", func(t *testing.T) {
+		serviceMock := mocks.NewEmployeeServiceMock()
+		serviceMock.On("Restore", mock.Anything, 1).Return((*domain.Employee)(nil), employee.ErrNotFound)
+		handler := employees.NewEmployee(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/employees/:id/restore", handler.Restore())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/employees/1/restore", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("restore_invalid_id", func(t *testing.T) {
+		serviceMock := mocks.NewEmployeeServiceMock()
+		serviceMock.On("Restore", mock.Anything, 1).Return((*domain.Employee)(nil), employee.ErrNotFound)
+		handler := employees.NewEmployee(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/employees/:id/restore", handler.Restore())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/employees/xyz/restore", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		serviceMock.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+}
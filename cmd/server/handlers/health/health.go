@@ -0,0 +1,94 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/health"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
+
+	"github.com/gin-gonic/gin"
+)
+
+// checkTimeout bounds each dependency check so a hung dependency cannot hang the probe.
+const checkTimeout = 2 * time.Second
+
+// Check reports whether a dependency is usable.
+type Check func(ctx context.Context) error
+
+type HealthHandler struct {
+	checks map[string]Check
+}
+
+// NewHealthHandler builds the probes; checks are keyed by the dependency name reported by Readiness.
+func NewHealthHandler(checks map[string]Check) *HealthHandler {
+	return &HealthHandler{
+		checks,
+	}
+}
+
+// Liveness is the handler to report that the process is up.
+//
+//	@Summary		Liveness probe
+//	@Tags			Health
+//	@Description	Report that the server is running, without checking its dependencies.
+//	@Produce		json
+//	@Success		200	{object}	dtos.HealthResponseDTO
+//	@Router			/healthz [get]
+func (handler *HealthHandler) Liveness() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		web.Response(c, http.StatusOK, dtos.HealthResponseDTO{Status: dtos.StatusUp})
+	}
+}
+
+// Readiness is the handler to report whether the server can serve requests.
+//
+//	@Summary		Readiness probe
+//	@Tags			Health
+//	@Description	Check every dependency, such as the database, and report the status of each one.
+//	@Produce		json
+//	@Success		200	{object}	dtos.ReadinessResponseDTO
+//	@Failure		503	{object}	dtos.ReadinessResponseDTO
+//	@Router			/readyz [get]
+func (handler *HealthHandler) Readiness() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		response := dtos.ReadinessResponseDTO{
+			Status:       dtos.StatusUp,
+			Dependencies: make(map[string]dtos.DependencyStatusDTO, len(handler.checks)),
+		}
+
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for name, check := range handler.checks {
+			wg.Add(1)
+			go func(name string, check Check) {
+				defer wg.Done()
+
+				ctx, cancel := context.WithTimeout(c.Request.Context(), checkTimeout)
+				defer cancel()
+
+				dependency := dtos.DependencyStatusDTO{Status: dtos.StatusUp}
+				if err := check(ctx); err != nil {
+					dependency = dtos.DependencyStatusDTO{Status: dtos.StatusDown, Error: err.Error()}
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+				response.Dependencies[name] = dependency
+				if dependency.Status == dtos.StatusDown {
+					response.Status = dtos.StatusDown
+				}
+			}(name, check)
+		}
+		wg.Wait()
+
+		if response.Status == dtos.StatusDown {
+			web.Response(c, http.StatusServiceUnavailable, response)
+			return
+		}
+		web.Response(c, http.StatusOK, response)
+	}
+}
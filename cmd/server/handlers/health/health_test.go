@@ -0,0 +1,93 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/health"
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/health"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiveness(t *testing.T) {
+	healthHandler := health.NewHealthHandler(map[string]health.Check{
+		"database": func(ctx context.Context) error { return errors.New("connection refused") },
+	})
+
+	gin.SetMode(gin.TestMode)
+	r := gin.Default()
+	r.GET("/healthz", healthHandler.Liveness())
+
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	res := httptest.NewRecorder()
+
+	r.ServeHTTP(res, req)
+
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.JSONEq(t, `{"status": "up"}`, res.Body.String())
+}
+
+func TestReadiness(t *testing.T) {
+	up := func(ctx context.Context) error { return nil }
+	down := func(ctx context.Context) error { return errors.New("connection refused") }
+
+	tests := []struct {
+		name             string
+		checks           map[string]health.Check
+		expectedCode     int
+		expectedResponse dtos.ReadinessResponseDTO
+	}{
+		{
+			name:         "Every dependency is up",
+			checks:       map[string]health.Check{"database": up},
+			expectedCode: http.StatusOK,
+			expectedResponse: dtos.ReadinessResponseDTO{
+				Status: dtos.StatusUp,
+				Dependencies: map[string]dtos.DependencyStatusDTO{
+					"database": {Status: dtos.StatusUp},
+				},
+			},
+		},
+		{
+			name:         "A dependency is down",
+			checks:       map[string]health.Check{"database": down, "cache": up},
+			expectedCode: http.StatusServiceUnavailable,
+			expectedResponse: dtos.ReadinessResponseDTO{
+				Status: dtos.StatusDown,
+				Dependencies: map[string]dtos.DependencyStatusDTO{
+					"database": {Status: dtos.StatusDown, Error: "connection refused"},
+					"cache":    {Status: dtos.StatusUp},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			healthHandler := health.NewHealthHandler(test.checks)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.GET("/readyz", healthHandler.Readiness())
+
+			req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			body, _ := io.ReadAll(res.Body)
+			var response dtos.ReadinessResponseDTO
+			json.Unmarshal(body, &response)
+
+			assert.Equal(t, test.expectedResponse, response)
+		})
+	}
+}
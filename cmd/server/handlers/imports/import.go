@@ -0,0 +1,153 @@
+package imports
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/products"
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/sections"
+	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/employee"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/product"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/section"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/bulk"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
+	"github.com/gin-gonic/gin"
+)
+
+type ImportHandler struct {
+	unitOfWork database.UnitOfWork
+}
+
+func NewImportHandler(unitOfWork database.UnitOfWork) *ImportHandler {
+	return &ImportHandler{
+		unitOfWork: unitOfWork,
+	}
+}
+
+// ImportProducts godoc
+//
+//	@Summary		Import products
+//	@Tags			Products
+//	@Description	Create many products from a CSV file with a header or a JSON array, each row like the body of POST /products
+//	@Accept			json,text/csv
+//	@Produce		json
+//	@Param			mode	query		string	false	"all_or_nothing (default) or best_effort"
+//	@Success		201		{object}	bulk.Report
+//	@Success		200		{object}	bulk.Report	"best_effort import with failed rows"
+//	@Failure		422		{object}	web.errorResponse	"all_or_nothing import rolled back, the report is in details"
+//	@Router			/api/v1/products/import [post]
+func (handler *ImportHandler) Products(service product.Service) gin.HandlerFunc {
+	return handler.handle(
+		func() interface{} { return &products.RequestCreateProduct{} },
+		func(ctx context.Context, row interface{}) (int, error) {
+			req := row.(*products.RequestCreateProduct)
+			created, err := service.Save(ctx, req.Description, req.ExpirationRate, req.FreezingRate, req.Height,
+				req.Length, req.Netweight, req.ProductCode, req.RecomFreezTemp, req.Width, req.ProductTypeID, req.SellerID)
+			if err != nil {
+				return 0, reject(database.TranslateError(err, "product"))
+			}
+			return created.ID, nil
+		},
+	)
+}
+
+// ImportSections godoc
+//
+//	@Summary		Import sections
+//	@Tags			Sections
+//	@Description	Create many sections from a CSV file with a header or a JSON array, each row like the body of POST /sections
+//	@Accept			json,text/csv
+//	@Produce		json
+//	@Param			mode	query		string	false	"all_or_nothing (default) or best_effort"
+//	@Success		201		{object}	bulk.Report
+//	@Success		200		{object}	bulk.Report	"best_effort import with failed rows"
+//	@Failure		422		{object}	web.errorResponse	"all_or_nothing import rolled back, the report is in details"
+//	@Router			/api/v1/sections/import [post]
+func (handler *ImportHandler) Sections(service section.Service) gin.HandlerFunc {
+	return handler.handle(
+		func() interface{} { return &dtos.CreateSectionRequestDTO{} },
+		func(ctx context.Context, row interface{}) (int, error) {
+			req := row.(*dtos.CreateSectionRequestDTO)
+			created, err := service.Save(ctx, req.SectionNumber, req.CurrentTemperature, req.MinimumTemperature, req.CurrentCapacity,
+				req.MinimumCapacity, req.MaximumCapacity, req.WarehouseID, req.ProductTypeID)
+			if err != nil {
+				return 0, reject(database.TranslateError(err, "section"))
+			}
+			return created.ID, nil
+		},
+	)
+}
+
+// ImportEmployees godoc
+//
+//	@Summary		Import employees
+//	@Tags			Employees
+//	@Description	Create many employees from a CSV file with a header or a JSON array, each row like the body of POST /employees
+//	@Accept			json,text/csv
+//	@Produce		json
+//	@Param			mode	query		string	false	"all_or_nothing (default) or best_effort"
+//	@Success		201		{object}	bulk.Report
+//	@Success		200		{object}	bulk.Report	"best_effort import with failed rows"
+//	@Failure		422		{object}	web.errorResponse	"all_or_nothing import rolled back, the report is in details"
+//	@Router			/api/v1/employees/import [post]
+func (handler *ImportHandler) Employees(service employee.Service) gin.HandlerFunc {
+	return handler.handle(
+		func() interface{} { return &domain.RequestCreateEmployee{} },
+		func(ctx context.Context, row interface{}) (int, error) {
+			req := row.(*domain.RequestCreateEmployee)
+			created, err := service.Save(ctx, domain.Employee{
+				CardNumberID: req.CardNumberID,
+				FirstName:    req.FirstName,
+				LastName:     req.LastName,
+				WarehouseID:  req.WarehouseID,
+			})
+			if err != nil {
+				return 0, reject(database.TranslateError(err, "employee"))
+			}
+			return created.ID, nil
+		},
+	)
+}
+
+func (handler *ImportHandler) handle(newRow func() interface{}, save bulk.Save) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		mode, err := bulk.ParseMode(c.Query("mode"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		reader, err := bulk.NewReader(c.GetHeader("Content-Type"), c.Request.Body)
+		if err != nil {
+			web.Error(c, http.StatusUnsupportedMediaType, err.Error())
+			return
+		}
+
+		report, err := bulk.Import(c.Request.Context(), handler.unitOfWork, mode, reader, newRow, save)
+		switch {
+		case errors.Is(err, bulk.ErrMalformed):
+			web.ErrorWithDetails(c, http.StatusBadRequest, report, "%s", err.Error())
+		case err != nil:
+			_ = c.Error(err)
+		case report.RolledBack:
+			web.ErrorWithDetails(c, http.StatusUnprocessableEntity, report, "import rolled back, %d of %d rows failed", report.Failed, report.Total)
+		case report.Failed > 0:
+			web.Success(c, http.StatusOK, report)
+		default:
+			web.Success(c, http.StatusCreated, report)
+		}
+	}
+}
+
+// reject fails only the row for domain errors, such as a code already in use or a warehouse that does not exist.
+func reject(err error) error {
+	var domainErr *errors2.Error
+	if errors.As(err, &domainErr) {
+		return bulk.Reject(err)
+	}
+	return err
+}
@@ -0,0 +1,152 @@
+package imports_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/imports"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
+	dbMocks "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/employee"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/employee/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestImportEmployees(t *testing.T) {
+	first := domain.Employee{CardNumberID: "CN1", FirstName: "Ana", LastName: "Silva", WarehouseID: 1}
+	duplicated := domain.Employee{CardNumberID: "CN2", FirstName: "Bruno", LastName: "Souza", WarehouseID: 1}
+
+	const csvBody = "card_number_id,first_name,last_name,warehouse_id\nCN1,Ana,Silva,1\nCN2,Bruno,Souza,1\nCN3,Carla,,1\n"
+
+	tests := []struct {
+		name                   string
+		url                    string
+		contentType            string
+		body                   string
+		expectedSaveCalls      int
+		expectedUnitOfWorkCall int
+		expectedCode           int
+		expectedBody           string
+	}{
+		{
+			name:                   "Best effort",
+			url:                    "/api/v1/employees/import?mode=best_effort",
+			contentType:            "text/csv",
+			body:                   csvBody,
+			expectedSaveCalls:      2,
+			expectedUnitOfWorkCall: 0,
+			expectedCode:           http.StatusOK,
+			expectedBody: `{"data":{"mode":"best_effort","total":3,"created":1,"failed":2,"rows":[
+				{"row":1,"id":7},
+				{"row":2,"error":"409 Conflict: Employee with CardNumberID already exists"},
+				{"row":3,"error":"invalid row","fields":[{"field":"last_name","rule":"required","message":"is required"}]}]}}`,
+		},
+		{
+			name:                   "All or nothing rolled back",
+			url:                    "/api/v1/employees/import",
+			contentType:            "text/csv",
+			body:                   csvBody,
+			expectedSaveCalls:      2,
+			expectedUnitOfWorkCall: 1,
+			expectedCode:           http.StatusUnprocessableEntity,
+			expectedBody: `{"code":"unprocessable_entity","message":"import rolled back, 2 of 3 rows failed","details":{"mode":"all_or_nothing","total":3,"created":0,"failed":2,"rolled_back":true,"rows":[
+				{"row":1},
+				{"row":2,"error":"409 Conflict: Employee with CardNumberID already exists"},
+				{"row":3,"error":"invalid row","fields":[{"field":"last_name","rule":"required","message":"is required"}]}]}}`,
+		},
+		{
+			name:                   "All or nothing created",
+			url:                    "/api/v1/employees/import",
+			contentType:            "application/json",
+			body:                   `[{"card_number_id":"CN1","first_name":"Ana","last_name":"Silva","warehouse_id":1}]`,
+			expectedSaveCalls:      1,
+			expectedUnitOfWorkCall: 1,
+			expectedCode:           http.StatusCreated,
+			expectedBody:           `{"data":{"mode":"all_or_nothing","total":1,"created":1,"failed":0,"rows":[{"row":1,"id":7}]}}`,
+		},
+		{
+			name:         "Invalid mode",
+			url:          "/api/v1/employees/import?mode=some",
+			contentType:  "text/csv",
+			body:         csvBody,
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"code":"bad_request","message":"mode must be all_or_nothing or best_effort"}`,
+		},
+		{
+			name:         "Unsupported format",
+			url:          "/api/v1/employees/import",
+			contentType:  "application/xml",
+			body:         `<employees/>`,
+			expectedCode: http.StatusUnsupportedMediaType,
+			expectedBody: `{"code":"unsupported_media_type","message":"import must be text/csv or a JSON array in application/json"}`,
+		},
+		{
+			name:                   "Malformed body",
+			url:                    "/api/v1/employees/import?mode=best_effort",
+			contentType:            "application/json",
+			body:                   `{"card_number_id":"CN1"}`,
+			expectedUnitOfWorkCall: 0,
+			expectedCode:           http.StatusBadRequest,
+			expectedBody:           `{"code":"bad_request","message":"malformed import: expected a JSON array","details":{"mode":"best_effort","total":0,"created":0,"failed":0,"rows":[]}}`,
+		},
+		{
+			name:                   "Malformed body with a percent sign",
+			url:                    "/api/v1/employees/import?mode=best_effort",
+			contentType:            "application/json",
+			body:                   `[%]`,
+			expectedUnitOfWorkCall: 0,
+			expectedCode:           http.StatusBadRequest,
+			expectedBody:           `{"code":"bad_request","message":"malformed import: invalid character '%' looking for beginning of value","details":{"mode":"best_effort","total":0,"created":0,"failed":0,"rows":[]}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			employeeServiceMock := mocks.NewEmployeeServiceMock()
+			employeeServiceMock.On("Save", mock.Anything, first).Return(&domain.Employee{ID: 7}, nil)
+			employeeServiceMock.On("Save", mock.Anything, duplicated).Return(&domain.Employee{}, employee.ErrConflict)
+			unitOfWorkMock := dbMocks.NewUnitOfWorkMock()
+			handler := imports.NewImportHandler(unitOfWorkMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.POST("/api/v1/employees/import", handler.Employees(employeeServiceMock))
+
+			req := httptest.NewRequest(http.MethodPost, test.url, strings.NewReader(test.body))
+			req.Header.Set("Content-Type", test.contentType)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			employeeServiceMock.AssertNumberOfCalls(t, "Save", test.expectedSaveCalls)
+			assert.Equal(t, test.expectedUnitOfWorkCall, unitOfWorkMock.Calls)
+			assert.Equal(t, test.expectedCode, res.Code)
+			assert.JSONEq(t, test.expectedBody, res.Body.String())
+		})
+	}
+
+	t.Run("Unexpected error", func(t *testing.T) {
+		employeeServiceMock := mocks.NewEmployeeServiceMock()
+		employeeServiceMock.On("Save", mock.Anything, first).Return(&domain.Employee{}, assert.AnError)
+		handler := imports.NewImportHandler(dbMocks.NewUnitOfWorkMock())
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/employees/import", handler.Employees(employeeServiceMock))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/employees/import", strings.NewReader(csvBody))
+		req.Header.Set("Content-Type", "text/csv")
+		res := httptest.NewRecorder()
+
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusInternalServerError, res.Code)
+	})
+}
@@ -1,21 +1,17 @@
 package inbound_orders
 
 import (
-	"errors"
 	"net/http"
 	"strconv"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	inbound_order "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/inbound_order"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/export"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
 	"github.com/gin-gonic/gin"
 )
 
-var (
-	ErrNotFound = errors.New("inbound orders not found")
-	ErrConflict = errors.New("409 Conflict: Inbound Orders with ID already exists")
-)
-
 type InboundOrders struct {
 	service inbound_order.Service
 }
@@ -46,14 +42,10 @@ func (i *InboundOrders) Get() gin.HandlerFunc {
 		}
 
 		ctx := c.Request.Context()
-		inboundOrders, err := i.service.Get(&ctx, id)
+		inboundOrders, err := i.service.Get(ctx, id)
 
 		if err != nil {
-			if errors.Is(err, ErrNotFound) {
-				web.Error(c, http.StatusNotFound, "InboundOrders not found: %s", err.Error())
-				return
-			}
-			web.Error(c, http.StatusInternalServerError, "Failed to get InboundOrders: %s", err.Error())
+			_ = c.Error(err)
 			return
 		}
 
@@ -68,16 +60,41 @@ func (i *InboundOrders) Get() gin.HandlerFunc {
 //	@Tags			InboundOrders
 //	@Description	getAll inboundOrders
 //	@Accept			json
-//	@Produce		json
-//	@Success		200	{object}	web.response
+//	@Produce		json,text/csv,application/x-ndjson
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			offset	query		int		false	"Number of inboundOrders to skip"
+//	@Param			sort	query		string	false	"Field to sort by"
+//	@Param			order	query		string	false	"asc or desc"
+//	@Param			format	query		string	false	"json, csv or ndjson, or use the Accept header"
+//	@Success		200		{object}	web.response
 //	@Router			/api/v1/inbound-orders [get]
 func (i *InboundOrders) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		options, err := listing.Parse(c.Request.URL.Query(), inbound_order.Fields)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if format != export.JSON {
+			web.Export(c, format, domain.InboundOrders{}, func(write func(row interface{}) error) error {
+				return i.service.Each(c.Request.Context(), options.Unpaged(), func(row domain.InboundOrders) error {
+					return write(row)
+				})
+			})
+			return
+		}
+
 		ctx := c.Request.Context()
-		inboundOrders, err := i.service.GetAll(&ctx)
+		inboundOrders, total, err := i.service.GetPage(ctx, options)
 
 		if err != nil {
-			web.Error(c, http.StatusInternalServerError, "Failed to get inbound Orders: %s", err.Error())
+			_ = c.Error(err)
 			return
 		}
 
@@ -86,7 +103,7 @@ func (i *InboundOrders) GetAll() gin.HandlerFunc {
 			return
 		}
 
-		web.Success(c, http.StatusOK, *inboundOrders)
+		web.Page(c, http.StatusOK, *inboundOrders, total, options)
 	}
 }
 
@@ -95,18 +112,19 @@ func (i *InboundOrders) GetAll() gin.HandlerFunc {
 //
 //	@Summary		Create InboundOrders
 //	@Tags			InboundOrders
-//	@Description	Create inboundOrders
+//	@Description	Create inboundOrders, adding its quantity to the product batch and to the capacity of its section
 //	@Accept			json
 //	@Produce		json
 //	@Param			InboundOrders	body		domain.RequestCreateInboundOrders	true	"InboundOrders to Create"
 //	@Success		200			{object}	web.response
+//	@Failure		409			{object}	web.errorResponse
 //	@Router			/api/v1/inbound-orders [post]
 func (i *InboundOrders) Save() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// createEmployee := domain.RequestCreateEmployee{}
 		createInboundOrders := new(domain.RequestCreateInboundOrders)
 		if err := c.ShouldBindJSON(&createInboundOrders); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, "JSON format may be wrong")
+			web.ValidationError(c, err)
 			return
 		}
 
@@ -116,41 +134,14 @@ func (i *InboundOrders) Save() gin.HandlerFunc {
 			EmployeeID:     createInboundOrders.EmployeeID,
 			ProductBatchID: createInboundOrders.ProductBatchID,
 			WarehouseID:    createInboundOrders.WarehouseID,
-		}
-
-		if inboundOrdersDomain.OrderDate == "" {
-			web.Error(c, http.StatusBadRequest, "Field Order Date is required: %s", "")
-			return
-		}
-
-		if inboundOrdersDomain.OrderNumber == "" {
-			web.Error(c, http.StatusBadRequest, "Field Order Number is required: %s", "")
-		}
-
-		if inboundOrdersDomain.EmployeeID == "" {
-			web.Error(c, http.StatusBadRequest, "Field Employee ID is required: %s", "")
-		}
-
-		if inboundOrdersDomain.ProductBatchID == "" {
-			web.Error(c, http.StatusBadRequest, "Field Product Batch ID is required: %s", "")
-		}
-
-		if inboundOrdersDomain.WarehouseID == "" {
-			web.Error(c, http.StatusBadRequest, "Field Warehouse ID is required: %s", "")
+			Quantity:       createInboundOrders.Quantity,
 		}
 
 		ctx := c.Request.Context()
-		inboundOrdersDomain, err := i.service.Save(&ctx, *inboundOrdersDomain)
+		inboundOrdersDomain, err := i.service.Save(ctx, *inboundOrdersDomain)
 		if err != nil {
-			switch err {
-			case inbound_order.ErrConflict:
-				web.Error(c, http.StatusConflict, err.Error())
-				return
-			default:
-				web.Error(c, http.StatusInternalServerError, "Error to save request: %s", err.Error())
-				return
-			}
-
+			_ = c.Error(err)
+			return
 		}
 
 		web.Success(c, http.StatusCreated, *inboundOrdersDomain)
@@ -180,14 +171,14 @@ func (i *InboundOrders) Update() gin.HandlerFunc {
 		ReqUpdateInboundOrders := new(domain.RequestUpdateInboundOrders)
 
 		if err := c.ShouldBindJSON(&ReqUpdateInboundOrders); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, "Error to read request: %s", err.Error())
+			web.ValidationError(c, err)
 			return
 		}
 
 		ctx := c.Request.Context()
-		inboundOrdersUpdate, err := i.service.Update(&ctx, id, ReqUpdateInboundOrders)
+		inboundOrdersUpdate, err := i.service.Update(ctx, id, ReqUpdateInboundOrders)
 		if err != nil {
-			web.Error(c, http.StatusNotFound, "Error to update: %s", err.Error())
+			_ = c.Error(err)
 			return
 		}
 
@@ -214,9 +205,9 @@ func (i *InboundOrders) Delete() gin.HandlerFunc {
 			return
 		}
 		ctx := c.Request.Context()
-		err = i.service.Delete(&ctx, int(id))
+		err = i.service.Delete(ctx, int(id))
 		if err != nil {
-			web.Error(c, http.StatusNotFound, "Error to delete: %s", err.Error())
+			_ = c.Error(err)
 			return
 		}
 
@@ -231,25 +222,32 @@ func (i *InboundOrders) Delete() gin.HandlerFunc {
 //	@Tags			CountInboundOrders
 //	@Description	get countInboundOrders
 //	@Accept			json
-//	@Produce		json
+//	@Produce		json,text/csv,application/x-ndjson
+//	@Param			format	query		string	false	"json, csv or ndjson, or use the Accept header"
 //	@Success		200	{object}	web.response
 //	@Router			/api/v1/reportInboundOrders [get]
 func (i *InboundOrders) CountInboundOrders() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		ctx := c.Request.Context()
-		countInboundOrders, err := i.service.CountInboundOrders(&ctx)
+		countInboundOrders, err := i.service.CountInboundOrders(ctx)
 
 		if err != nil {
-			web.Error(c, http.StatusInternalServerError, "Failed to get inbound Orders: %s", err.Error())
+			_ = c.Error(err)
 			return
 		}
 
-		if len(countInboundOrders) == 0 {
+		if len(countInboundOrders) == 0 && format == export.JSON {
 			web.Error(c, http.StatusNoContent, "There are no inbound Orders stored: ")
 			return
 		}
 
-		web.Success(c, http.StatusOK, countInboundOrders)
+		web.Report(c, format, countInboundOrders, domain.EmployeeInboundOrdersCount{})
 	}
 }
 
@@ -260,7 +258,8 @@ func (i *InboundOrders) CountInboundOrders() gin.HandlerFunc {
 //	@Tags			CountInboundOrdersByID
 //	@Description	get countInboundOrdersById
 //	@Accept			json
-//	@Produce		json
+//	@Produce		json,text/csv,application/x-ndjson
+//	@Param			format	query		string	false	"json, csv or ndjson, or use the Accept header"
 //	@Success		200	{object}	web.response
 //	@Router			/api/v1/reportInboundOrders/{id} [get]
 func (i *InboundOrders) CountInboundOrdersByID() gin.HandlerFunc {
@@ -270,19 +269,20 @@ func (i *InboundOrders) CountInboundOrdersByID() gin.HandlerFunc {
 			web.Error(c, http.StatusBadRequest, "Invalid employee ID: %s", err.Error())
 			return
 		}
+		format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
 
 		ctx := c.Request.Context()
-		countInboundOrders, err := i.service.CountInboundOrdersByID(&ctx, id)
+		countInboundOrders, err := i.service.CountInboundOrdersByID(ctx, id)
 
 		if err != nil {
-			if errors.Is(err, ErrNotFound) {
-				web.Error(c, http.StatusNotFound, "InboundOrders not found: %s", err.Error())
-				return
-			}
-			web.Error(c, http.StatusInternalServerError, "Failed to CountInboundOrdersByID: %s", err.Error())
+			_ = c.Error(err)
 			return
 		}
 
-		web.Success(c, http.StatusOK, countInboundOrders)
+		web.Report(c, format, countInboundOrders, domain.EmployeeInboundOrdersCount{})
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/inbound_orders"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	inbound_order "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/inbound_order"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/inbound_order/mocks"
@@ -23,21 +24,22 @@ func TestGet(t *testing.T) {
 		// Definir resultado da consulta
 		inboundOrdersFound := &domain.InboundOrders{
 			ID:             1,
-			OrderDate:      "teste",
+			OrderDate:      "2023-07-01",
 			OrderNumber:    "teste",
-			EmployeeID:     "teste",
-			ProductBatchID: "teste",
-			WarehouseID:    "teste",
+			EmployeeID:     "1",
+			ProductBatchID: "1",
+			WarehouseID:    "1",
 		}
 
 		//Configurar o mock do service
 		inboundOrdersServiceMock := mocks.NewInboundOrdersServiceMock()
-		inboundOrdersServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(inboundOrdersFound, nil)
+		inboundOrdersServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(inboundOrdersFound, nil)
 		inboundOrders := inbound_orders.NewInboundOrders(inboundOrdersServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/inboundOrders/:id", inboundOrders.Get())
 
 		//Definir request e response
@@ -64,38 +66,40 @@ func TestGet(t *testing.T) {
 
 	})
 
-	// // find_by_id_non_existent
-	// t.Run("find_by_id_non_existent", func(t *testing.T) {
-	// 	//Configurar o mock do service
-	// 	inboundOrdersServiceMock := mocks.NewInboundOrdersServiceMock()
-	// 	inboundOrdersServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(&domain.InboundOrders{}, inbound_order.ErrNotFound)
-	// 	inboundOrders := inbound_orders.NewInboundOrders(inboundOrdersServiceMock)
+	// find_by_id_non_existent
+	t.Run("find_by_id_non_existent", func(t *testing.T) {
+		//Configurar o mock do service
+		inboundOrdersServiceMock := mocks.NewInboundOrdersServiceMock()
+		inboundOrdersServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(&domain.InboundOrders{}, inbound_order.ErrNotFound)
+		inboundOrders := inbound_orders.NewInboundOrders(inboundOrdersServiceMock)
 
-	// 	//Configurar o servidor
-	// 	gin.SetMode(gin.TestMode)
-	// 	r := gin.Default()
-	// 	r.GET("/api/v1/inboundOrders/:id", inboundOrders.Get())
+		//Configurar o servidor
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/inboundOrders/:id", inboundOrders.Get())
 
-	// 	//Definir request e response
-	// 	req := httptest.NewRequest(http.MethodGet, "/api/v1/inboundOrders/1", nil)
-	// 	res := httptest.NewRecorder()
+		//Definir request e response
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/inboundOrders/1", nil)
+		res := httptest.NewRecorder()
 
-	// 	//Executar request
-	// 	r.ServeHTTP(res, req)
+		//Executar request
+		r.ServeHTTP(res, req)
 
-	// 	//Validar resultado
-	// 	assert.Equal(t, http.StatusNotFound, res.Code)
-	// })
+		//Validar resultado
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
 
 	t.Run("invalid_id", func(t *testing.T) {
 		//Configurar o mock do service
 		inboundOrdersServiceMock := mocks.NewInboundOrdersServiceMock()
-		inboundOrdersServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(&domain.InboundOrders{}, inbound_order.ErrNotFound)
+		inboundOrdersServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(&domain.InboundOrders{}, inbound_order.ErrNotFound)
 		inboundOrders := inbound_orders.NewInboundOrders(inboundOrdersServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/inboundOrders/:id", inboundOrders.Get())
 
 		//Definir request e response
@@ -112,12 +116,13 @@ func TestGet(t *testing.T) {
 	t.Run("internal_server_error", func(t *testing.T) {
 		//Configurar o mock do service
 		inboundOrdersServiceMock := mocks.NewInboundOrdersServiceMock()
-		inboundOrdersServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(&domain.InboundOrders{}, assert.AnError)
+		inboundOrdersServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(&domain.InboundOrders{}, assert.AnError)
 		inboundOrders := inbound_orders.NewInboundOrders(inboundOrdersServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/inboundOrders/:id", inboundOrders.Get())
 
 		//Definir request e response
@@ -139,30 +144,31 @@ func TestGetAll(t *testing.T) {
 		expectedinboundOrders := &[]domain.InboundOrders{
 			{
 				ID:             1,
-				OrderDate:      "teste",
+				OrderDate:      "2023-07-01",
 				OrderNumber:    "teste",
-				EmployeeID:     "teste",
-				ProductBatchID: "teste",
-				WarehouseID:    "teste",
+				EmployeeID:     "1",
+				ProductBatchID: "1",
+				WarehouseID:    "1",
 			},
 			{
 				ID:             2,
-				OrderDate:      "teste",
+				OrderDate:      "2023-07-01",
 				OrderNumber:    "teste",
-				EmployeeID:     "teste",
-				ProductBatchID: "teste",
-				WarehouseID:    "teste",
+				EmployeeID:     "1",
+				ProductBatchID: "1",
+				WarehouseID:    "1",
 			},
 		}
 
 		//Configurar o mock do service
 		inboundOrdersServiceMock := mocks.NewInboundOrdersServiceMock()
-		inboundOrdersServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(expectedinboundOrders, nil)
+		inboundOrdersServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(expectedinboundOrders, len(*expectedinboundOrders), nil)
 		inboundOrders := inbound_orders.NewInboundOrders(inboundOrdersServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/inboundOrders", inboundOrders.GetAll())
 
 		//Definir request e response
@@ -191,12 +197,13 @@ func TestGetAll(t *testing.T) {
 	t.Run("internal_server_error", func(t *testing.T) {
 		//Configurar o mock do service
 		inboundOrdersServiceMock := mocks.NewInboundOrdersServiceMock()
-		inboundOrdersServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(&[]domain.InboundOrders{}, assert.AnError)
+		inboundOrdersServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(&[]domain.InboundOrders{}, 0, assert.AnError)
 		inboundOrders := inbound_orders.NewInboundOrders(inboundOrdersServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/inboundOrders", inboundOrders.GetAll())
 
 		//Definir request e response
@@ -214,12 +221,13 @@ func TestGetAll(t *testing.T) {
 
 		//Configurar o mock do service
 		inboundOrdersServiceMock := mocks.NewInboundOrdersServiceMock()
-		inboundOrdersServiceMock.On("GetAll", mock.Anything).Return(inboundOrdersFound, nil)
+		inboundOrdersServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(inboundOrdersFound, len(*inboundOrdersFound), nil)
 		inboundOrders := inbound_orders.NewInboundOrders(inboundOrdersServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/inboundOrders", inboundOrders.GetAll())
 
 		//Definir request e response
@@ -233,6 +241,23 @@ func TestGetAll(t *testing.T) {
 		assert.Equal(t, http.StatusNoContent, res.Code)
 	})
 
+	t.Run("getAll_invalid_query", func(t *testing.T) {
+		serviceMock := mocks.NewInboundOrdersServiceMock()
+		handler := inbound_orders.NewInboundOrders(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/inboundOrders", handler.GetAll())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/inboundOrders?limit=0", nil)
+		res := httptest.NewRecorder()
+
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		serviceMock.AssertNotCalled(t, "GetPage", mock.Anything, mock.Anything)
+	})
 }
 
 func TestSave(t *testing.T) {
@@ -240,29 +265,39 @@ func TestSave(t *testing.T) {
 
 		expectedInboundOrdersCreate := &domain.InboundOrders{
 			ID:             1,
-			OrderDate:      "teste",
+			OrderDate:      "2023-07-01",
 			OrderNumber:    "teste",
-			EmployeeID:     "teste",
-			ProductBatchID: "teste",
-			WarehouseID:    "teste",
+			EmployeeID:     "1",
+			ProductBatchID: "1",
+			WarehouseID:    "1",
+			Quantity:       10,
+			Stock: &domain.StockPosition{
+				ProductBatchID:  1,
+				CurrentQuantity: 110,
+				SectionID:       2,
+				CurrentCapacity: 60,
+				MaximumCapacity: 100,
+			},
 		}
 
 		requestInboundOrdersCreate := &domain.RequestCreateInboundOrders{
-			OrderDate:      "teste",
+			OrderDate:      "2023-07-01",
 			OrderNumber:    "teste",
-			EmployeeID:     "teste",
-			ProductBatchID: "teste",
-			WarehouseID:    "teste",
+			EmployeeID:     "1",
+			ProductBatchID: "1",
+			WarehouseID:    "1",
+			Quantity:       10,
 		}
 
 		//Configurar o mock do service
 		inboundOrdersServiceMock := mocks.NewInboundOrdersServiceMock()
-		inboundOrdersServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("domain.InboundOrders")).Return(expectedInboundOrdersCreate, nil)
+		inboundOrdersServiceMock.On("Save", mock.Anything, mock.AnythingOfType("domain.InboundOrders")).Return(expectedInboundOrdersCreate, nil)
 		inboundOrders := inbound_orders.NewInboundOrders(inboundOrdersServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/inboundOrders", inboundOrders.Save())
 
 		requestBody, _ := json.Marshal(requestInboundOrdersCreate)
@@ -292,59 +327,63 @@ func TestSave(t *testing.T) {
 
 	})
 
-	t.Run("create_fail", func(t *testing.T) {
+	t.Run("create_conflit", func(t *testing.T) {
+		expectedInboundOrdersCreate := &domain.InboundOrders{}
+
 		requestInboundOrdersCreate := domain.RequestCreateInboundOrders{
-			OrderDate:      "teste",
+			OrderDate:      "2023-07-01",
 			OrderNumber:    "teste",
-			EmployeeID:     "teste",
-			ProductBatchID: "teste",
-			WarehouseID:    "teste",
+			EmployeeID:     "1",
+			ProductBatchID: "1",
+			WarehouseID:    "1",
+			Quantity:       10,
 		}
 
 		//Configurar o mock do service
 		inboundOrdersServiceMock := mocks.NewInboundOrdersServiceMock()
-		inboundOrdersServiceMock.On("Save", mock.AnythingOfType("*context.Context")).Return(requestInboundOrdersCreate, inbound_order.ErrUnprocessableEntity)
+		inboundOrdersServiceMock.On("Save", mock.Anything, mock.AnythingOfType("domain.InboundOrders")).Return(expectedInboundOrdersCreate, inbound_order.ErrConflict)
 		inboundOrders := inbound_orders.NewInboundOrders(inboundOrdersServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/inboundOrders", inboundOrders.Save())
 
+		requestBody, _ := json.Marshal(requestInboundOrdersCreate)
+		request := bytes.NewReader(requestBody)
+
 		//Definir request e response
-		req := httptest.NewRequest(http.MethodPost, "/api/v1/inboundOrders", nil)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/inboundOrders", request)
 		res := httptest.NewRecorder()
 
 		r.ServeHTTP(res, req)
 
-		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+		assert.Equal(t, http.StatusConflict, res.Code)
 	})
 
-	t.Run("create_conflit", func(t *testing.T) {
-		expectedInboundOrdersCreate := &domain.InboundOrders{}
-
+	t.Run("create_capacity_exceeded", func(t *testing.T) {
 		requestInboundOrdersCreate := domain.RequestCreateInboundOrders{
-			OrderDate:      "teste",
+			OrderDate:      "2023-07-01",
 			OrderNumber:    "teste",
-			EmployeeID:     "teste",
-			ProductBatchID: "teste",
-			WarehouseID:    "teste",
+			EmployeeID:     "1",
+			ProductBatchID: "1",
+			WarehouseID:    "1",
+			Quantity:       500,
 		}
 
-		//Configurar o mock do service
 		inboundOrdersServiceMock := mocks.NewInboundOrdersServiceMock()
-		inboundOrdersServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("domain.InboundOrders")).Return(expectedInboundOrdersCreate, inbound_order.ErrConflict)
+		inboundOrdersServiceMock.On("Save", mock.Anything, mock.AnythingOfType("domain.InboundOrders")).Return(&domain.InboundOrders{}, inbound_order.ErrCapacityExceeded)
 		inboundOrders := inbound_orders.NewInboundOrders(inboundOrdersServiceMock)
 
-		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/inboundOrders", inboundOrders.Save())
 
 		requestBody, _ := json.Marshal(requestInboundOrdersCreate)
 		request := bytes.NewReader(requestBody)
 
-		//Definir request e response
 		req := httptest.NewRequest(http.MethodPost, "/api/v1/inboundOrders", request)
 		res := httptest.NewRecorder()
 
@@ -353,13 +392,74 @@ func TestSave(t *testing.T) {
 		assert.Equal(t, http.StatusConflict, res.Code)
 	})
 
+	t.Run("create_employee_not_found", func(t *testing.T) {
+		requestInboundOrdersCreate := domain.RequestCreateInboundOrders{
+			OrderDate:      "2023-07-01",
+			OrderNumber:    "teste",
+			EmployeeID:     "1",
+			ProductBatchID: "1",
+			WarehouseID:    "1",
+			Quantity:       10,
+		}
+
+		inboundOrdersServiceMock := mocks.NewInboundOrdersServiceMock()
+		inboundOrdersServiceMock.On("Save", mock.Anything, mock.AnythingOfType("domain.InboundOrders")).Return(&domain.InboundOrders{}, inbound_order.ErrEmployeeNotFound)
+		inboundOrders := inbound_orders.NewInboundOrders(inboundOrdersServiceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/inboundOrders", inboundOrders.Save())
+
+		requestBody, _ := json.Marshal(requestInboundOrdersCreate)
+		request := bytes.NewReader(requestBody)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/inboundOrders", request)
+		res := httptest.NewRecorder()
+
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+	})
+
+	t.Run("create_fail_quantity_not_positive", func(t *testing.T) {
+		requestInboundOrdersCreate := domain.RequestCreateInboundOrders{
+			OrderDate:      "2023-07-01",
+			OrderNumber:    "teste",
+			EmployeeID:     "1",
+			ProductBatchID: "1",
+			WarehouseID:    "1",
+			Quantity:       0,
+		}
+
+		inboundOrdersServiceMock := mocks.NewInboundOrdersServiceMock()
+		inboundOrders := inbound_orders.NewInboundOrders(inboundOrdersServiceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/inboundOrders", inboundOrders.Save())
+
+		requestBody, _ := json.Marshal(requestInboundOrdersCreate)
+		request := bytes.NewReader(requestBody)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/inboundOrders", request)
+		res := httptest.NewRecorder()
+
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+		inboundOrdersServiceMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
 	t.Run("create_fail_order_date_nil", func(t *testing.T) {
 		requestInboundOrdersCreate := domain.RequestCreateInboundOrders{
-			// OrderDate:      "teste",
+			// OrderDate:      "2023-07-01",
 			OrderNumber:    "teste",
-			EmployeeID:     "teste",
-			ProductBatchID: "teste",
-			WarehouseID:    "teste",
+			EmployeeID:     "1",
+			ProductBatchID: "1",
+			WarehouseID:    "1",
+			Quantity:       10,
 		}
 
 		//Configurar o mock do service
@@ -368,6 +468,7 @@ func TestSave(t *testing.T) {
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/inboundOrders", inboundOrders.Save())
 
 		requestBody, _ := json.Marshal(requestInboundOrdersCreate)
@@ -378,16 +479,17 @@ func TestSave(t *testing.T) {
 
 		r.ServeHTTP(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
 	})
 
 	t.Run("create_fail_order_number_nil", func(t *testing.T) {
 		requestInboundOrdersCreate := domain.RequestCreateInboundOrders{
-			OrderDate: "teste",
+			OrderDate: "2023-07-01",
 			// OrderNumber:    "teste",
-			EmployeeID:     "teste",
-			ProductBatchID: "teste",
-			WarehouseID:    "teste",
+			EmployeeID:     "1",
+			ProductBatchID: "1",
+			WarehouseID:    "1",
+			Quantity:       10,
 		}
 
 		//Configurar o mock do service
@@ -396,6 +498,7 @@ func TestSave(t *testing.T) {
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/inboundOrders", inboundOrders.Save())
 
 		requestBody, _ := json.Marshal(requestInboundOrdersCreate)
@@ -406,16 +509,17 @@ func TestSave(t *testing.T) {
 
 		r.ServeHTTP(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
 	})
 
 	t.Run("create_fail_employee_id_nil", func(t *testing.T) {
 		requestInboundOrdersCreate := domain.RequestCreateInboundOrders{
-			OrderDate:   "teste",
+			OrderDate:   "2023-07-01",
 			OrderNumber: "teste",
-			// EmployeeID:     "teste",
-			ProductBatchID: "teste",
-			WarehouseID:    "teste",
+			// EmployeeID:     "1",
+			ProductBatchID: "1",
+			WarehouseID:    "1",
+			Quantity:       10,
 		}
 
 		//Configurar o mock do service
@@ -424,6 +528,7 @@ func TestSave(t *testing.T) {
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/inboundOrders", inboundOrders.Save())
 
 		requestBody, _ := json.Marshal(requestInboundOrdersCreate)
@@ -434,16 +539,17 @@ func TestSave(t *testing.T) {
 
 		r.ServeHTTP(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
 	})
 
 	t.Run("create_fail_product_batch_id_nil", func(t *testing.T) {
 		requestInboundOrdersCreate := domain.RequestCreateInboundOrders{
-			OrderDate:   "teste",
+			OrderDate:   "2023-07-01",
 			OrderNumber: "teste",
-			EmployeeID:  "teste",
-			// ProductBatchID: "teste",
-			WarehouseID: "teste",
+			EmployeeID:  "1",
+			// ProductBatchID: "1",
+			WarehouseID: "1",
+			Quantity:    10,
 		}
 
 		//Configurar o mock do service
@@ -452,6 +558,7 @@ func TestSave(t *testing.T) {
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/inboundOrders", inboundOrders.Save())
 
 		requestBody, _ := json.Marshal(requestInboundOrdersCreate)
@@ -462,15 +569,16 @@ func TestSave(t *testing.T) {
 
 		r.ServeHTTP(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
 	})
 	t.Run("create_fail_warehouse_id_nil", func(t *testing.T) {
 		requestInboundOrdersCreate := domain.RequestCreateInboundOrders{
-			OrderDate:      "teste",
+			OrderDate:      "2023-07-01",
 			OrderNumber:    "teste",
-			EmployeeID:     "teste",
-			ProductBatchID: "teste",
-			// WarehouseID:    "teste",
+			EmployeeID:     "1",
+			ProductBatchID: "1",
+			// WarehouseID:    "1",
+			Quantity: 10,
 		}
 
 		//Configurar o mock do service
@@ -479,6 +587,7 @@ func TestSave(t *testing.T) {
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/inboundOrders", inboundOrders.Save())
 
 		requestBody, _ := json.Marshal(requestInboundOrdersCreate)
@@ -489,34 +598,36 @@ func TestSave(t *testing.T) {
 
 		r.ServeHTTP(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
 	})
 
 	t.Run("create_internal_server_error", func(t *testing.T) {
 		inboundOrdersCreate := domain.InboundOrders{
 			ID:             1,
-			OrderDate:      "teste",
+			OrderDate:      "2023-07-01",
 			OrderNumber:    "teste",
-			EmployeeID:     "teste",
-			ProductBatchID: "teste",
-			WarehouseID:    "teste",
+			EmployeeID:     "1",
+			ProductBatchID: "1",
+			WarehouseID:    "1",
 		}
 
 		requestInboundOrdersCreate := domain.RequestCreateInboundOrders{
-			OrderDate:      "teste",
+			OrderDate:      "2023-07-01",
 			OrderNumber:    "teste",
-			EmployeeID:     "teste",
-			ProductBatchID: "teste",
-			WarehouseID:    "teste",
+			EmployeeID:     "1",
+			ProductBatchID: "1",
+			WarehouseID:    "1",
+			Quantity:       10,
 		}
 
 		//Configurar o mock do service
 		inboundOrdersServiceMock := mocks.NewInboundOrdersServiceMock()
-		inboundOrdersServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("domain.InboundOrders")).Return(inboundOrdersCreate, assert.AnError)
+		inboundOrdersServiceMock.On("Save", mock.Anything, mock.AnythingOfType("domain.InboundOrders")).Return(inboundOrdersCreate, assert.AnError)
 		inboundOrders := inbound_orders.NewInboundOrders(inboundOrdersServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/inboundOrders", inboundOrders.Save())
 
 		requestBody, _ := json.Marshal(requestInboundOrdersCreate)
@@ -538,16 +649,16 @@ func TestSave(t *testing.T) {
 
 	// t.Run("create_bad_request", func(t *testing.T) {
 	// 	requestInboundOrdersCreate := domain.RequestCreateInboundOrders{
-	// 		OrderDate:      "teste",
+	// 		OrderDate:      "2023-07-01",
 	// 		OrderNumber:    "teste",
-	// 		EmployeeID:     "teste",
-	// 		ProductBatchID: "teste",
-	// 		WarehouseID:    "teste",
+	// 		EmployeeID:     "1",
+	// 		ProductBatchID: "1",
+	// 		WarehouseID:    "1",
 	// 	}
 
 	// 	//Configurar o mock do service
 	// 	inboundOrdersServiceMock := mocks.NewInboundOrdersServiceMock()
-	// 	inboundOrdersServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("domain.InboundOrders")).Return(&domain.InboundOrders{}, assert.AnError)
+	// 	inboundOrdersServiceMock.On("Save", mock.Anything, mock.AnythingOfType("domain.InboundOrders")).Return(&domain.InboundOrders{}, assert.AnError)
 	// 	inboundOrders := inbound_orders.NewInboundOrders(inboundOrdersServiceMock)
 
 	// 	gin.SetMode(gin.TestMode)
@@ -578,13 +689,14 @@ func TestDelete(t *testing.T) {
 
 		//Configurar o mock do service
 		inboundOrdersServiceMock := mocks.NewInboundOrdersServiceMock()
-		inboundOrdersServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(inboundOrdersFound, nil)
-		inboundOrdersServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(nil)
+		inboundOrdersServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(inboundOrdersFound, nil)
+		inboundOrdersServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(nil)
 		inboundOrders := inbound_orders.NewInboundOrders(inboundOrdersServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.DELETE("/api/v1/inboundOrders/:id", inboundOrders.Delete())
 
 		//Definir request e response
@@ -599,13 +711,14 @@ func TestDelete(t *testing.T) {
 	t.Run("delete_non_existent", func(t *testing.T) {
 		//Configurar o mock do service
 		inboundOrdersServiceMock := mocks.NewInboundOrdersServiceMock()
-		inboundOrdersServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(domain.InboundOrders{}, nil)
-		inboundOrdersServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(inbound_order.ErrNotFound)
+		inboundOrdersServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(domain.InboundOrders{}, nil)
+		inboundOrdersServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(inbound_order.ErrNotFound)
 		inboundOrders := inbound_orders.NewInboundOrders(inboundOrdersServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.DELETE("/api/v1/inboundOrders/:id", inboundOrders.Delete())
 
 		//Definir request e response
@@ -621,12 +734,13 @@ func TestDelete(t *testing.T) {
 
 		//Configurar o mock do service
 		inboundOrdersServiceMock := mocks.NewInboundOrdersServiceMock()
-		inboundOrdersServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(nil)
+		inboundOrdersServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(nil)
 		inboundOrders := inbound_orders.NewInboundOrders(inboundOrdersServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.DELETE("/api/v1/inboundOrders/:id", inboundOrders.Delete())
 
 		//Definir request e response
@@ -642,9 +756,9 @@ func TestDelete(t *testing.T) {
 func TestUpdate(t *testing.T) {
 	t.Run("update_ok", func(t *testing.T) {
 
-		orderDate := "updated"
+		orderDate := "2023-07-02"
 		orderNumber := "updated"
-		employeeID := "updated"
+		employeeID := "2"
 
 		requestUpdateInboundOrders := domain.RequestUpdateInboundOrders{
 			OrderDate:   &orderDate,
@@ -654,20 +768,21 @@ func TestUpdate(t *testing.T) {
 
 		inboundOrdersUpdated := domain.InboundOrders{
 			ID:             1,
-			OrderDate:      "updated",
+			OrderDate:      "2023-07-02",
 			OrderNumber:    "updated",
-			EmployeeID:     "updated",
-			ProductBatchID: "teste",
-			WarehouseID:    "teste",
+			EmployeeID:     "2",
+			ProductBatchID: "1",
+			WarehouseID:    "1",
 		}
 
 		inboundOrdersServiceMock := mocks.NewInboundOrdersServiceMock()
-		inboundOrdersServiceMock.On("Update", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int"), mock.AnythingOfType("*domain.RequestUpdateInboundOrders")).Return(&inboundOrdersUpdated, nil)
+		inboundOrdersServiceMock.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("*domain.RequestUpdateInboundOrders")).Return(&inboundOrdersUpdated, nil)
 		inboundOrders := inbound_orders.NewInboundOrders(inboundOrdersServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/inboundOrders/:id", inboundOrders.Update())
 
 		requestBody, _ := json.Marshal(requestUpdateInboundOrders)
@@ -698,6 +813,7 @@ func TestUpdate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/inboundOrders/:id", inboundOrders.Update())
 
 		req := httptest.NewRequest(http.MethodPatch, "/api/v1/inboundOrders/1", nil)
@@ -709,9 +825,9 @@ func TestUpdate(t *testing.T) {
 	})
 
 	// t.Run("update_not_found", func(t *testing.T) {
-	// 	orderDate := "updated"
+	// 	orderDate := "2023-07-02"
 	// 	orderNumber := "updated"
-	// 	employeeID := "updated"
+	// 	employeeID := "2"
 
 	// 	requestUpdateInboundOrders := domain.RequestUpdateInboundOrders{
 	// 		OrderDate:   &orderDate,
@@ -722,7 +838,7 @@ func TestUpdate(t *testing.T) {
 	// 	inboundOrdersUpdated := &domain.InboundOrders{}
 
 	// 	inboundOrdersServiceMock := mocks.NewInboundOrdersServiceMock()
-	// 	inboundOrdersServiceMock.On("Update", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int"), mock.AnythingOfType("*domain.requestUpdateInboundOrders")).Return(inboundOrdersUpdated, inbound_order.ErrNotFound)
+	// 	inboundOrdersServiceMock.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("*domain.requestUpdateInboundOrders")).Return(inboundOrdersUpdated, inbound_order.ErrNotFound)
 	// 	inboundOrders := inbound_orders.NewInboundOrders(inboundOrdersServiceMock)
 
 	// 	//Configurar o servidor
@@ -742,9 +858,9 @@ func TestUpdate(t *testing.T) {
 	// })
 
 	t.Run("update_status_bad_request", func(t *testing.T) {
-		orderDate := "updated"
+		orderDate := "2023-07-02"
 		orderNumber := "updated"
-		employeeID := "updated"
+		employeeID := "2"
 
 		requestUpdateInboundOrders := domain.RequestUpdateInboundOrders{
 			OrderDate:   &orderDate,
@@ -758,6 +874,7 @@ func TestUpdate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/inboundOrders/:id", inboundOrders.Update())
 
 		requestBody, _ := json.Marshal(requestUpdateInboundOrders)
@@ -7,9 +7,10 @@ import (
 	"strconv"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos"
-	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/locality"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/export"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
 
 	"github.com/gin-gonic/gin"
@@ -47,13 +48,8 @@ func (handler *LocalityHandler) Get() gin.HandlerFunc {
 		}
 
 		ctx := c.Request.Context()
-		if localityFound, err := handler.localityService.Get(&ctx, id); err != nil {
-			switch err {
-			case errors2.ErrNotFound:
-				web.Error(c, http.StatusNotFound, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, err.Error())
-			}
+		if localityFound, err := handler.localityService.Get(ctx, id); err != nil {
+			_ = c.Error(err)
 			return
 		} else {
 			web.Response(c, http.StatusOK, localityFound)
@@ -63,31 +59,93 @@ func (handler *LocalityHandler) Get() gin.HandlerFunc {
 	}
 }
 
-// GetAll is the handler to search for all localities.
+// GetAll is the handler to search for all localities, optionally filtered by province or country.
 //
 //	@Summary		List Localities
 //	@Tags			Localities
 //	@Description	Get the details of all localities on the database.
 //	@Accept			json
-//	@Produce		json
-//	@Success		200	{object}	web.response{data=[]domain.Locality}
-//	@Failure		500	{object}	web.errorResponse
+//	@Produce		json,text/csv,application/x-ndjson
+//	@Param			province_id	query		string	false	"ID of the Province to filter by"
+//	@Param			country_id	query		string	false	"ID of the Country to filter by"
+//	@Param			limit		query		int		false	"Page size when not filtering by province or country"
+//	@Param			offset		query		int		false	"Number of localities to skip"
+//	@Param			sort		query		string	false	"Field to sort by"
+//	@Param			order		query		string	false	"asc or desc"
+//	@Param			format		query		string	false	"json, csv or ndjson, or use the Accept header"
+//	@Success		200			{object}	web.response{data=[]domain.Locality}
+//	@Failure		400			{object}	web.errorResponse
+//	@Failure		404			{object}	web.errorResponse
+//	@Failure		500			{object}	web.errorResponse
 //	@Router			/api/v1/localities [get]
 func (handler *LocalityHandler) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
 
 		ctx := c.Request.Context()
-		if localities, err := handler.localityService.GetAll(&ctx); err != nil {
-			web.Error(c, http.StatusInternalServerError, err.Error())
+
+		format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
 			return
-		} else {
-			if len(localities) == 0 {
-				web.Success(c, http.StatusNoContent, localities)
+		}
+
+		var localities []domain.Locality
+		var total int
+		var options *listing.Options
+		switch {
+		case c.Query("province_id") != "":
+			provinceID, convErr := strconv.Atoi(c.Query("province_id"))
+			if convErr != nil {
+				web.Error(c, http.StatusBadRequest, fmt.Sprintf("Invalid province_id on request: %s", c.Request.RequestURI))
+				return
+			}
+			localities, err = handler.localityService.GetAllByProvinceID(ctx, provinceID)
+		case c.Query("country_id") != "":
+			countryID, convErr := strconv.Atoi(c.Query("country_id"))
+			if convErr != nil {
+				web.Error(c, http.StatusBadRequest, fmt.Sprintf("Invalid country_id on request: %s", c.Request.RequestURI))
 				return
 			}
-			web.Success(c, http.StatusOK, localities)
+			localities, err = handler.localityService.GetAllByCountryID(ctx, countryID)
+		default:
+			parsed, parseErr := listing.Parse(c.Request.URL.Query(), locality.Fields)
+			if parseErr != nil {
+				web.Error(c, http.StatusBadRequest, parseErr.Error())
+				return
+			}
+			if format != export.JSON {
+				web.Export(c, format, domain.Locality{}, func(write func(row interface{}) error) error {
+					return handler.localityService.Each(ctx, parsed.Unpaged(), func(row domain.Locality) error {
+						return write(row)
+					})
+				})
+				return
+			}
+			options = &parsed
+			localities, total, err = handler.localityService.GetPage(ctx, parsed)
+		}
+
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		if format != export.JSON {
+			web.Export(c, format, domain.Locality{}, func(write func(row interface{}) error) error {
+				return export.Rows(localities, write)
+			})
 			return
 		}
+
+		if len(localities) == 0 {
+			web.Success(c, http.StatusNoContent, localities)
+			return
+		}
+		if options != nil {
+			web.Page(c, http.StatusOK, localities, total, *options)
+			return
+		}
+		web.Success(c, http.StatusOK, localities)
 	}
 }
 
@@ -100,6 +158,8 @@ func (handler *LocalityHandler) GetAll() gin.HandlerFunc {
 //	@Produce		json
 //	@Param			Seller	body		domain.Locality	true	"Locality to Create"
 //	@Success		201		{object}	domain.Locality
+//	@Failure		404		{object}	web.errorResponse
+//	@Failure		409		{object}	web.errorResponse
 //	@Failure		422		{object}	web.errorResponse
 //	@Failure		500		{object}	web.errorResponse
 //	@Router			/api/v1/localities [post]
@@ -108,20 +168,14 @@ func (handler *LocalityHandler) Create() gin.HandlerFunc {
 
 		var createLocalityRequest domain.Locality
 		if err := c.ShouldBindJSON(&createLocalityRequest); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, err.Error())
+			web.ValidationError(c, err)
 			return
 		}
 
 		ctx := c.Request.Context()
-		if createdLocality, err := handler.localityService.Create(&ctx, createLocalityRequest); err != nil {
-			switch err {
-			case errors2.ErrConflict:
-				web.Error(c, http.StatusConflict, err.Error())
-				return
-			default:
-				web.Error(c, http.StatusInternalServerError, err.Error())
-				return
-			}
+		if createdLocality, err := handler.localityService.Create(ctx, createLocalityRequest); err != nil {
+			_ = c.Error(err)
+			return
 		} else {
 			web.Response(c, http.StatusCreated, createdLocality)
 			return
@@ -154,20 +208,13 @@ func (handler *LocalityHandler) Update() gin.HandlerFunc {
 
 		var updateLocalityRequest dtos.UpdateLocalityRequestDTO
 		if err := c.ShouldBindJSON(&updateLocalityRequest); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, err.Error())
+			web.ValidationError(c, err)
 			return
 		}
 
 		ctx := c.Request.Context()
-		if updatedLocality, err := handler.localityService.Update(&ctx, id, updateLocalityRequest); err != nil {
-			switch err {
-			case errors2.ErrNotFound:
-				web.Error(c, http.StatusNotFound, err.Error())
-			case errors2.ErrConflict:
-				web.Error(c, http.StatusConflict, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, err.Error())
-			}
+		if updatedLocality, err := handler.localityService.Update(ctx, id, updateLocalityRequest); err != nil {
+			_ = c.Error(err)
 			return
 		} else {
 			web.Response(c, http.StatusOK, updatedLocality)
@@ -199,13 +246,8 @@ func (handler *LocalityHandler) Delete() gin.HandlerFunc {
 		}
 
 		ctx := c.Request.Context()
-		if err := handler.localityService.Delete(&ctx, id); err != nil {
-			switch err {
-			case errors2.ErrNotFound:
-				web.Error(c, http.StatusNotFound, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, err.Error())
-			}
+		if err := handler.localityService.Delete(ctx, id); err != nil {
+			_ = c.Error(err)
 			return
 		} else {
 			web.Response(c, http.StatusNoContent, nil)
@@ -214,63 +256,72 @@ func (handler *LocalityHandler) Delete() gin.HandlerFunc {
 	}
 }
 
-// CountSellers is the handler search for a locality and return the number of sellers
+// CountSellers is the handler to report the number of sellers of a locality, or of every locality when no id is given
 //
 //	@Summary		CountSellers
 //	@Tags			Localities
-//	@Description	search for a locality and return the number of sellers.
-//	@Accept			json
-//	@Produce		json
-//	@Param			id		path		string						true	"ID of Locality to be searched"
+//	@Description	Report the number of sellers of a locality, or of every locality when no id is given.
+//	@Produce		json,text/csv,application/x-ndjson
+//	@Param			id		path		string	false	"ID of Locality to be searched"
+//	@Param			id		query		string	false	"ID of Locality to be searched"
+//	@Param			order	query		string	false	"Sort the report by number of sellers (asc or desc)"
+//	@Param			format	query		string	false	"json, csv or ndjson, or use the Accept header"
 //	@Success		200		{object}	web.response{data=dtos.GetNumberOfSellersResponseDTO}
+//	@Success		200		{object}	web.response{data=[]dtos.GetNumberOfSellersResponseDTO}
 //	@Failure		400		{object}	web.errorResponse
 //	@Failure		404		{object}	web.errorResponse
-//	@Failure		422		{object}	web.errorResponse
 //	@Failure		500		{object}	web.errorResponse
-//	@Router			/api/v1/localities/count [get]
+//	@Router			/api/v1/localities/{id}/reportSellers [get]
+//	@Router			/api/v1/localities/reportSellers [get]
 func (handler *LocalityHandler) CountSellers() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		id, err := getIdFromUri(c)
+		ctx := c.Request.Context()
+
+		format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
 		if err != nil {
 			web.Error(c, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		ctx := c.Request.Context()
+		if c.Param("id") == "" && c.Query("id") == "" {
+			order := c.Query("order")
+			if order != "" && order != locality.OrderAsc && order != locality.OrderDesc {
+				web.Error(c, http.StatusBadRequest, fmt.Sprintf("Invalid order on request: %s", c.Request.RequestURI))
+				return
+			}
 
-		localityFound, err := handler.localityService.Get(&ctx, id)
-		if err != nil {
-			switch err {
-			case errors2.ErrNotFound:
-				web.Error(c, http.StatusNotFound, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, err.Error())
+			reports, err := handler.localityService.CountAllSellers(ctx, order)
+			if err != nil {
+				_ = c.Error(err)
+				return
 			}
+
+			web.Report(c, format, reports, dtos.GetNumberOfSellersResponseDTO{})
 			return
 		}
 
-		count, err := handler.localityService.CountSellers(&ctx, id)
+		id, err := getIdFromUri(c)
 		if err != nil {
-			web.Error(c, http.StatusInternalServerError, err.Error())
+			web.Error(c, http.StatusBadRequest, err.Error())
 			return
 		}
 
-		response := dtos.GetNumberOfSellersResponseDTO{
-			LocalityID:   localityFound.ID,
-			LocalityName: localityFound.LocalityName,
-			SellersCount: count,
+		report, err := handler.localityService.CountSellers(ctx, id)
+		if err != nil {
+			_ = c.Error(err)
+			return
 		}
 
-		web.Success(c, http.StatusOK, response)
-		return
-
+		web.Report(c, format, report, dtos.GetNumberOfSellersResponseDTO{})
 	}
-
 }
 
 func getIdFromUri(c *gin.Context) (id int, err error) {
 
-	value, _ := c.Params.Get("id")
+	value, ok := c.Params.Get("id")
+	if !ok {
+		value = c.Query("id")
+	}
 	id, err = strconv.Atoi(value)
 
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/localities"
 	handlers "github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/localities"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
@@ -70,13 +71,14 @@ func TestGet(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			localityServiceMock := mocks.NewMockLocalityService(t)
-			localityServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(test.expectedLocality, test.expectedGetError)
+			localityServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(test.expectedLocality, test.expectedGetError)
 
 			localityHandler := localities.NewLocalityHandler(localityServiceMock)
 
 			//Configurar o servidor
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(middlewares.Errors())
 			r.GET("/api/v1/localities/:id", localityHandler.Get())
 
 			//Definir request e response
@@ -118,60 +120,72 @@ func TestGetAll(t *testing.T) {
 	}
 
 	tests := []struct {
-		name                 string
-		expectedGetAllResult []domain.Locality
-		expectedGetAllError  error
-		expectedGetAllCalls  int
-		expectedResponse     []domain.Locality
-		expectedCode         int
+		name                  string
+		query                 string
+		expectedGetPageResult []domain.Locality
+		expectedGetPageTotal  int
+		expectedGetPageError  error
+		expectedGetPageCalls  int
+		expectedResponse      []domain.Locality
+		expectedCode          int
 	}{
 		{
-			name:                 "Successfully get all expectedLocalities",
-			expectedGetAllResult: validLocalities,
-			expectedGetAllError:  nil,
-			expectedGetAllCalls:  1,
-			expectedResponse:     validLocalities,
-			expectedCode:         http.StatusOK,
+			name:                  "Successfully get all expectedLocalities",
+			expectedGetPageResult: validLocalities,
+			expectedGetPageTotal:  len(validLocalities),
+			expectedGetPageError:  nil,
+			expectedGetPageCalls:  1,
+			expectedResponse:      validLocalities,
+			expectedCode:          http.StatusOK,
+		},
+		{
+			name:                  "Success empty database",
+			expectedGetPageResult: []domain.Locality{},
+			expectedGetPageError:  nil,
+			expectedGetPageCalls:  1,
+			expectedResponse:      []domain.Locality{},
+			expectedCode:          http.StatusNoContent,
 		},
 		{
-			name:                 "Success empty database",
-			expectedGetAllResult: []domain.Locality{},
-			expectedGetAllError:  nil,
-			expectedGetAllCalls:  1,
-			expectedResponse:     []domain.Locality{},
-			expectedCode:         http.StatusNoContent,
+			name:                  "Error getting all expectedLocalities",
+			expectedGetPageResult: []domain.Locality{},
+			expectedGetPageError:  assert.AnError,
+			expectedGetPageCalls:  1,
+			expectedResponse:      []domain.Locality{},
+			expectedCode:          http.StatusInternalServerError,
 		},
 		{
-			name:                 "Error getting all expectedLocalities",
-			expectedGetAllResult: []domain.Locality{},
-			expectedGetAllError:  assert.AnError,
-			expectedGetAllCalls:  1,
-			expectedResponse:     []domain.Locality{},
-			expectedCode:         http.StatusInternalServerError,
+			name:                 "Error invalid page size",
+			query:                "?limit=-1",
+			expectedGetPageCalls: 0,
+			expectedCode:         http.StatusBadRequest,
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			localityServiceMock := mocks.NewMockLocalityService(t)
-			localityServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(test.expectedGetAllResult, test.expectedGetAllError)
+			if test.expectedGetPageCalls > 0 {
+				localityServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(test.expectedGetPageResult, test.expectedGetPageTotal, test.expectedGetPageError)
+			}
 
 			localityHandler := localities.NewLocalityHandler(localityServiceMock)
 
 			//Configurar o servidor
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(middlewares.Errors())
 			r.GET("/api/v1/expectedLocalities", localityHandler.GetAll())
 
 			//Definir request e response
-			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s", "/api/v1/expectedLocalities"), nil)
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", "/api/v1/expectedLocalities", test.query), nil)
 			res := httptest.NewRecorder()
 
 			//Executar request
 			r.ServeHTTP(res, req)
 
 			//Validar resultado
-			localityServiceMock.AssertNumberOfCalls(t, "GetAll", test.expectedGetAllCalls)
+			localityServiceMock.AssertNumberOfCalls(t, "GetPage", test.expectedGetPageCalls)
 			assert.Equal(t, test.expectedCode, res.Code)
 
 			// Só testa o body em caso de sucesso
@@ -192,6 +206,90 @@ func TestGetAll(t *testing.T) {
 	}
 }
 
+func TestGetAllFiltered(t *testing.T) {
+
+	localitiesSerialized, _ := os.ReadFile("../../../../test/resources/valid_localities.json")
+	var validLocalities []domain.Locality
+	if err := json.Unmarshal(localitiesSerialized, &validLocalities); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                  string
+		query                 string
+		expectedServiceMethod string
+		expectedServiceResult []domain.Locality
+		expectedServiceError  error
+		expectedServiceCalls  int
+		expectedCode          int
+	}{
+		{
+			name:                  "Successfully filter by province",
+			query:                 "?province_id=1",
+			expectedServiceMethod: "GetAllByProvinceID",
+			expectedServiceResult: validLocalities[:1],
+			expectedServiceCalls:  1,
+			expectedCode:          http.StatusOK,
+		},
+		{
+			name:                  "Successfully filter by country",
+			query:                 "?country_id=2",
+			expectedServiceMethod: "GetAllByCountryID",
+			expectedServiceResult: validLocalities[1:],
+			expectedServiceCalls:  1,
+			expectedCode:          http.StatusOK,
+		},
+		{
+			name:                  "Error province not found",
+			query:                 "?province_id=999",
+			expectedServiceMethod: "GetAllByProvinceID",
+			expectedServiceResult: []domain.Locality{},
+			expectedServiceError:  errors.ErrNotFound,
+			expectedServiceCalls:  1,
+			expectedCode:          http.StatusNotFound,
+		},
+		{
+			name:                  "Error invalid country id",
+			query:                 "?country_id=xyz",
+			expectedServiceMethod: "GetAllByCountryID",
+			expectedCode:          http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			localityServiceMock := mocks.NewMockLocalityService(t)
+			localityServiceMock.On(test.expectedServiceMethod, mock.Anything, mock.AnythingOfType("int")).Return(test.expectedServiceResult, test.expectedServiceError)
+
+			localityHandler := localities.NewLocalityHandler(localityServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.GET("/api/v1/localities", localityHandler.GetAll())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/localities"+test.query, nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			localityServiceMock.AssertNumberOfCalls(t, test.expectedServiceMethod, test.expectedServiceCalls)
+			localityServiceMock.AssertNotCalled(t, "GetPage", mock.Anything, mock.Anything)
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			if test.expectedCode == http.StatusOK {
+				body, _ := io.ReadAll(res.Body)
+				var responseDTO struct {
+					Data []domain.Locality `json:"data"`
+				}
+				json.Unmarshal(body, &responseDTO)
+
+				assert.Equal(t, test.expectedServiceResult, responseDTO.Data)
+			}
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 
 	tests := []struct {
@@ -234,13 +332,14 @@ func TestDelete(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			localityServiceMock := mocks.NewMockLocalityService(t)
-			localityServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(test.expectedDeleteError)
+			localityServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(test.expectedDeleteError)
 
 			localityHandler := localities.NewLocalityHandler(localityServiceMock)
 
 			//Configurar o servidor
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(middlewares.Errors())
 			r.DELETE("/api/v1/localities/:id", localityHandler.Delete())
 
 			//Definir request e response
@@ -313,13 +412,14 @@ func TestCreate(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			localityServiceMock := mocks.NewMockLocalityService(t)
-			localityServiceMock.On("Create", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("domain.Locality")).Return(test.expectedCreateResult, test.expectedCreateError)
+			localityServiceMock.On("Create", mock.Anything, mock.AnythingOfType("domain.Locality")).Return(test.expectedCreateResult, test.expectedCreateError)
 
 			localityHandler := handlers.NewLocalityHandler(localityServiceMock)
 
 			//Configurar o servidor
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(middlewares.Errors())
 			r.POST("/api/v1/localities", localityHandler.Create())
 
 			requestBody, _ := json.Marshal(test.createLocalityRequest)
@@ -434,13 +534,14 @@ func TestUpdate(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			localityServiceMock := mocks.NewMockLocalityService(t)
-			localityServiceMock.On("Update", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int"), mock.AnythingOfType("dtos.UpdateLocalityRequestDTO")).Return(test.expectedUpdateResult, test.expectedUpdateError)
+			localityServiceMock.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("dtos.UpdateLocalityRequestDTO")).Return(test.expectedUpdateResult, test.expectedUpdateError)
 
 			localityHandler := localities.NewLocalityHandler(localityServiceMock)
 
 			//Configurar o servidor
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(middlewares.Errors())
 			r.PATCH("/api/v1/localities/:id", localityHandler.Update())
 
 			requestBody, _ := json.Marshal(test.updateLocalityRequest)
@@ -487,11 +588,8 @@ func TestGetNumberOfSellers(t *testing.T) {
 
 	tests := []struct {
 		name                       string
-		id                         int
-		expectedGetResult          domain.Locality
-		expectedGetError           error
-		expectedGetCalls           int
-		expectedCountSellersResult int
+		url                        string
+		expectedCountSellersResult dtos.GetNumberOfSellersResponseDTO
 		expectedCountSellersError  error
 		expectedCountSellersCalls  int
 		expectedResponse           dtos.GetNumberOfSellersResponseDTO
@@ -499,74 +597,59 @@ func TestGetNumberOfSellers(t *testing.T) {
 	}{
 		{
 			name:                       "Valid Locality",
-			id:                         validLocality.ID,
-			expectedGetResult:          validLocality,
-			expectedGetError:           nil,
-			expectedGetCalls:           1,
-			expectedCountSellersResult: 1,
-			expectedCountSellersError:  nil,
+			url:                        fmt.Sprintf("/api/v1/localities/%d/reportSellers", validLocality.ID),
+			expectedCountSellersResult: expectedResponse,
 			expectedCountSellersCalls:  1,
 			expectedResponse:           expectedResponse,
 			expectedCode:               http.StatusOK,
 		},
 		{
-			name:                       "Error Locality not found",
-			id:                         validLocality.ID,
-			expectedGetResult:          domain.Locality{},
-			expectedGetError:           errors.ErrNotFound,
-			expectedGetCalls:           1,
-			expectedCountSellersResult: 0,
-			expectedCountSellersError:  nil,
-			expectedCountSellersCalls:  0,
-			expectedResponse:           dtos.GetNumberOfSellersResponseDTO{},
-			expectedCode:               http.StatusNotFound,
+			name:                       "Valid Locality on query",
+			url:                        fmt.Sprintf("/api/v1/localities/reportSellers?id=%d", validLocality.ID),
+			expectedCountSellersResult: expectedResponse,
+			expectedCountSellersCalls:  1,
+			expectedResponse:           expectedResponse,
+			expectedCode:               http.StatusOK,
 		},
 		{
-			name:                       "Internal error finding Locality",
-			id:                         validLocality.ID,
-			expectedGetResult:          domain.Locality{},
-			expectedGetError:           assert.AnError,
-			expectedGetCalls:           1,
-			expectedCountSellersResult: 0,
-			expectedCountSellersError:  nil,
-			expectedCountSellersCalls:  0,
-			expectedResponse:           dtos.GetNumberOfSellersResponseDTO{},
-			expectedCode:               http.StatusInternalServerError,
+			name:                       "Error Locality not found",
+			url:                        "/api/v1/localities/999/reportSellers",
+			expectedCountSellersResult: dtos.GetNumberOfSellersResponseDTO{},
+			expectedCountSellersError:  errors.ErrNotFound,
+			expectedCountSellersCalls:  1,
+			expectedCode:               http.StatusNotFound,
 		},
 		{
 			name:                       "Error counting sellers",
-			id:                         validLocality.ID,
-			expectedGetResult:          validLocality,
-			expectedGetError:           nil,
-			expectedGetCalls:           1,
-			expectedCountSellersResult: 0,
+			url:                        fmt.Sprintf("/api/v1/localities/%d/reportSellers", validLocality.ID),
+			expectedCountSellersResult: dtos.GetNumberOfSellersResponseDTO{},
 			expectedCountSellersError:  assert.AnError,
 			expectedCountSellersCalls:  1,
-			expectedResponse:           expectedResponse,
 			expectedCode:               http.StatusInternalServerError,
 		},
-		//{
-		//	name:         "Invalid ID",
-		//	id:           "xyz",
-		//	expectedCode: http.StatusBadRequest,
-		//},
+		{
+			name:         "Invalid ID",
+			url:          "/api/v1/localities/reportSellers?id=xyz",
+			expectedCode: http.StatusBadRequest,
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			localityServiceMock := mocks.NewMockLocalityService(t)
-			localityServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(test.expectedGetResult, test.expectedGetError)
-			localityServiceMock.On("CountSellers", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(test.expectedCountSellersResult, test.expectedCountSellersError)
+			localityServiceMock.On("CountSellers", mock.Anything, mock.AnythingOfType("int")).Return(test.expectedCountSellersResult, test.expectedCountSellersError)
 
 			localityHandler := localities.NewLocalityHandler(localityServiceMock)
 
 			//Configurar o servidor
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(middlewares.Errors())
 			r.GET("/api/v1/localities/:id/reportSellers", localityHandler.CountSellers())
+			r.GET("/api/v1/localities/reportSellers", localityHandler.CountSellers())
 
 			//Definir request e response
-			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/%d/%s", "/api/v1/localities", test.id, "reportSellers"), nil)
+			req := httptest.NewRequest(http.MethodGet, test.url, nil)
 			res := httptest.NewRecorder()
 
 			//Executar request
@@ -594,3 +677,85 @@ func TestGetNumberOfSellers(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNumberOfSellersOfEveryLocality(t *testing.T) {
+	reports := []dtos.GetNumberOfSellersResponseDTO{
+		{LocalityID: 6700, LocalityName: "Lujan", SellersCount: 3},
+		{LocalityID: 7600, LocalityName: "Rio de Janeiro", SellersCount: 1},
+	}
+
+	tests := []struct {
+		name                          string
+		query                         string
+		expectedOrder                 string
+		expectedCountAllSellersResult []dtos.GetNumberOfSellersResponseDTO
+		expectedCountAllSellersError  error
+		expectedCountAllSellersCalls  int
+		expectedCode                  int
+	}{
+		{
+			name:                          "Report of every locality",
+			query:                         "",
+			expectedOrder:                 "",
+			expectedCountAllSellersResult: reports,
+			expectedCountAllSellersCalls:  1,
+			expectedCode:                  http.StatusOK,
+		},
+		{
+			name:                          "Report sorted by number of sellers",
+			query:                         "?order=desc",
+			expectedOrder:                 "desc",
+			expectedCountAllSellersResult: reports,
+			expectedCountAllSellersCalls:  1,
+			expectedCode:                  http.StatusOK,
+		},
+		{
+			name:                          "Error counting sellers",
+			query:                         "",
+			expectedOrder:                 "",
+			expectedCountAllSellersResult: []dtos.GetNumberOfSellersResponseDTO{},
+			expectedCountAllSellersError:  assert.AnError,
+			expectedCountAllSellersCalls:  1,
+			expectedCode:                  http.StatusInternalServerError,
+		},
+		{
+			name:          "Error invalid order",
+			query:         "?order=sideways",
+			expectedOrder: "sideways",
+			expectedCode:  http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			localityServiceMock := mocks.NewMockLocalityService(t)
+			localityServiceMock.On("CountAllSellers", mock.Anything, test.expectedOrder).Return(test.expectedCountAllSellersResult, test.expectedCountAllSellersError)
+
+			localityHandler := localities.NewLocalityHandler(localityServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.GET("/api/v1/localities/reportSellers", localityHandler.CountSellers())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/localities/reportSellers"+test.query, nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			localityServiceMock.AssertNumberOfCalls(t, "CountAllSellers", test.expectedCountAllSellersCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			if test.expectedCode == http.StatusOK {
+				body, _ := io.ReadAll(res.Body)
+
+				var response struct {
+					Data []dtos.GetNumberOfSellersResponseDTO `json:"data"`
+				}
+				json.Unmarshal(body, &response)
+
+				assert.Equal(t, test.expectedCountAllSellersResult, response.Data)
+			}
+		})
+	}
+}
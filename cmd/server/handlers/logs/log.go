@@ -0,0 +1,81 @@
+package logs
+
+import (
+	"net/http"
+
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/log"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/logs"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LogHandler struct {
+	logService logs.LogService
+}
+
+func NewLogHandler(logService logs.LogService) *LogHandler {
+	return &LogHandler{
+		logService,
+	}
+}
+
+// GetAll is the handler to search for the request logs.
+//
+//	@Summary		List Logs
+//	@Tags			Logs
+//	@Description	Get the request logs, newest first, optionally filtered by level, status range and date window.
+//	@Produce		json
+//	@Param			level		query		string	false	"Info, Warning or Error"
+//	@Param			status_from	query		int		false	"Minimum HTTP status"
+//	@Param			status_to	query		int		false	"Maximum HTTP status"
+//	@Param			from		query		string	false	"Start of the window, RFC 3339"
+//	@Param			to			query		string	false	"End of the window, RFC 3339"
+//	@Param			limit		query		int		false	"Page size"
+//	@Param			offset		query		int		false	"Number of logs to skip"
+//	@Param			sort		query		string	false	"Field to sort by, insert_date by default"
+//	@Param			order		query		string	false	"asc or desc, desc by default"
+//	@Success		200			{object}	web.response{data=[]domain.Log}
+//	@Failure		400			{object}	web.errorResponse
+//	@Failure		500			{object}	web.errorResponse
+//	@Router			/api/v1/logs [get]
+func (handler *LogHandler) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		var filter dtos.GetLogsRequestDTO
+		if err := c.ShouldBindQuery(&filter); err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if !filter.From.IsZero() && !filter.To.IsZero() && filter.To.Before(filter.From) {
+			web.Error(c, http.StatusBadRequest, "to must not be before from")
+			return
+		}
+
+		options, err := listing.Parse(c.Request.URL.Query(), logs.Fields)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		// Logs list newest first unless a sort or order is asked for
+		if c.Query("sort") == "" && c.Query("order") == "" {
+			options.Sort = logs.Fields["insert_date"]
+			options.Desc = true
+		}
+
+		ctx := c.Request.Context()
+		found, total, err := handler.logService.GetPage(ctx, filter, options)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		if len(found) == 0 {
+			web.Success(c, http.StatusNoContent, found)
+			return
+		}
+		web.Page(c, http.StatusOK, found, total, options)
+	}
+}
@@ -0,0 +1,139 @@
+package logs_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/logs"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/log"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/logs/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAll(t *testing.T) {
+
+	validLogs := []domain.Log{
+		{
+			ID:         1,
+			Method:     "GET",
+			Label:      "/api/v1/sections/:id",
+			Level:      domain.LogLevelWarning,
+			Message:    "section not found",
+			Status:     404,
+			InsertDate: time.Date(2023, 7, 5, 16, 0, 0, 0, time.UTC),
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		query                string
+		expectedFilter       dtos.GetLogsRequestDTO
+		expectedOptions      listing.Options
+		expectedGetAllResult []domain.Log
+		expectedGetAllError  error
+		expectedGetAllCalls  int
+		expectedCode         int
+	}{
+		{
+			name:                 "Success filtering logs",
+			query:                "?level=Warning&status_from=400&status_to=499&from=2023-07-05T00:00:00Z&to=2023-07-06T00:00:00Z",
+			expectedFilter:       dtos.GetLogsRequestDTO{Level: domain.LogLevelWarning, StatusFrom: 400, StatusTo: 499, From: time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC), To: time.Date(2023, 7, 6, 0, 0, 0, 0, time.UTC)},
+			expectedOptions:      listing.Options{Limit: listing.DefaultLimit, Sort: "logs.insert_date", Desc: true},
+			expectedGetAllResult: validLogs,
+			expectedGetAllCalls:  1,
+			expectedCode:         http.StatusOK,
+		},
+		{
+			name:                 "Success paging and sorting logs",
+			query:                "?limit=1&offset=1&sort=status&order=asc",
+			expectedOptions:      listing.Options{Limit: 1, Offset: 1, Sort: "logs.status"},
+			expectedGetAllResult: validLogs,
+			expectedGetAllCalls:  1,
+			expectedCode:         http.StatusOK,
+		},
+		{
+			name:                 "Success with no logs",
+			expectedOptions:      listing.Options{Limit: listing.DefaultLimit},
+			expectedGetAllResult: []domain.Log{},
+			expectedGetAllCalls:  1,
+			expectedCode:         http.StatusNoContent,
+		},
+		{
+			name:                 "Error connecting db",
+			expectedOptions:      listing.Options{Limit: listing.DefaultLimit},
+			expectedGetAllResult: []domain.Log{},
+			expectedGetAllError:  assert.AnError,
+			expectedGetAllCalls:  1,
+			expectedCode:         http.StatusInternalServerError,
+		},
+		{
+			name:         "Error invalid limit",
+			query:        "?limit=0",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Error invalid level",
+			query:        "?level=Debug",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Error inverted status range",
+			query:        "?status_from=500&status_to=400",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Error inverted date window",
+			query:        "?from=2023-07-06T00:00:00Z&to=2023-07-05T00:00:00Z",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Error invalid date",
+			query:        "?from=yesterday",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logServiceMock := mocks.NewMockLogService(t)
+			logServiceMock.On("GetPage", mock.Anything, test.expectedFilter, mock.MatchedBy(func(options listing.Options) bool {
+				return options.Limit == test.expectedOptions.Limit && options.Offset == test.expectedOptions.Offset &&
+					(test.expectedOptions.Sort == "" || options.Sort == test.expectedOptions.Sort && options.Desc == test.expectedOptions.Desc)
+			})).Return(test.expectedGetAllResult, len(test.expectedGetAllResult), test.expectedGetAllError)
+
+			logHandler := logs.NewLogHandler(logServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.GET("/api/v1/logs", logHandler.GetAll())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/logs"+test.query, nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			logServiceMock.AssertNumberOfCalls(t, "GetPage", test.expectedGetAllCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			if test.expectedCode == http.StatusOK {
+				body, _ := io.ReadAll(res.Body)
+				var response struct {
+					Data []domain.Log `json:"data"`
+				}
+				json.Unmarshal(body, &response)
+
+				assert.Equal(t, validLogs, response.Data)
+			}
+		})
+	}
+}
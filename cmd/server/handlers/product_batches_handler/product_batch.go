@@ -3,20 +3,38 @@ package productbatcheshandler
 import (
 	"errors"
 	"net/http"
+	"net/url"
 	"strconv"
+	"time"
 
 	dto "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/productbatchesdto"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	productbatches "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/productbatches"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/export"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
 	"github.com/gin-gonic/gin"
 )
 
 var (
-	ErrInvalidID              = errors.New("Invalid ID").Error()
-	ErrSectionProductsReports = errors.New("error returning product reports by Section").Error()
+	ErrInvalidID           = errors.New("Invalid ID").Error()
+	ErrInvalidDueDate      = errors.New("due_date_from and due_date_to must be dates in the format 2006-01-02").Error()
+	ErrInvalidDays         = errors.New("days must be a non-negative integer").Error()
+	ErrInvalidReportFilter = errors.New("warehouse_id and product_type_id must be positive integers").Error()
 )
 
+// defaultExpiringDays is how far ahead the expiring batches report looks when days is not informed.
+const defaultExpiringDays = 7
+
+// expiringRow is a batch of the expiring batches report exported as CSV or NDJSON, whose rows can't nest,
+// so each one repeats its warehouse and section.
+type expiringRow struct {
+	WarehouseID   int `json:"warehouse_id"`
+	SectionID     int `json:"section_id"`
+	SectionNumber int `json:"section_number"`
+	domain.ExpiringBatch
+}
+
 type ProductBatches struct {
 	productBatchesService productbatches.IService
 }
@@ -32,22 +50,39 @@ func NewProductBatches(p productbatches.IService) *ProductBatches {
 //
 //	@Summary		SectionProductsReports / SectionProductsReportsBySection
 //	@Tags			ProductBatch
-//	@Description	Get all products by section / Section Products Reports By Section
+//	@Description	Count the batches and sum the product units stored in each section, with its warehouse
 //	@Accept			json
-//	@Produce		json
-//	@Param			id	path		int	false "ID of a Products Reports to search"
-//	@Success		200	{object}	[]domain.ProductBySection
+//	@Produce		json,text/csv,application/x-ndjson
+//	@Param			id				path		int	false	"ID of a Section to report"
+//	@Param			warehouse_id	query		int	false	"Only sections of the warehouse"
+//	@Param			product_type_id	query		int	false	"Only sections of the product type"
+//	@Param			format	query		string	false	"json, csv or ndjson, or use the Accept header"
+//	@Success		200				{object}	[]domain.ProductBySection
+//	@Failure		400				{object}	web.errorResponse
+//	@Failure		404				{object}	web.errorResponse
 //	@Router			/api/v1/sections/reportProducts/{id} [get]
+//	@Router			/api/v1/sections/reportProducts [get]
 func (p *ProductBatches) Get() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
 		idParam := c.Param("id")
 		if idParam == "" {
-			result, err := p.productBatchesService.SectionProductsReports()
+			filter, err := parseReportFilter(c)
+			if err != nil {
+				web.Error(c, http.StatusBadRequest, err.Error())
+				return
+			}
+			result, err := p.productBatchesService.SectionProductsReports(c.Request.Context(), filter)
 			if err != nil {
-				web.Error(c, http.StatusInternalServerError, ErrSectionProductsReports)
+				_ = c.Error(err)
 				return
 			}
-			web.Success(c, http.StatusOK, result)
+			web.Report(c, format, result, domain.ProductBySection{})
 			return
 		}
 
@@ -56,16 +91,12 @@ func (p *ProductBatches) Get() gin.HandlerFunc {
 			web.Error(c, http.StatusBadRequest, ErrInvalidID)
 			return
 		}
-		sectionProductsReportsBySection, err := p.productBatchesService.SectionProductsReportsBySection(int(sectionID))
+		sectionProductsReportsBySection, err := p.productBatchesService.SectionProductsReportsBySection(c.Request.Context(), int(sectionID))
 		if err != nil {
-			if errors.Is(err, productbatches.ErrNotFoundSection) {
-				web.Error(c, http.StatusNotFound, err.Error())
-				return
-			}
-			web.Error(c, http.StatusInternalServerError, err.Error())
+			_ = c.Error(err)
 			return
 		}
-		web.Success(c, http.StatusOK, sectionProductsReportsBySection)
+		web.Report(c, format, sectionProductsReportsBySection, domain.ProductBySection{})
 	}
 }
 
@@ -79,12 +110,14 @@ func (p *ProductBatches) Get() gin.HandlerFunc {
 // @Produce		json
 // @Param			ProductBatch	body		productbatchesdto.CreateProductBatchesDTO	true	"ProductBatch to Create"
 // @Success		201		{object}	web.response
+// @Failure		409		{object}	web.errorResponse
+// @Failure		422		{object}	web.errorResponse
 // @Router			/api/v1/productBatches [post]
 func (p *ProductBatches) Create() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req dto.CreateProductBatchesDTO
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, err.Error())
+			web.ValidationError(c, err)
 			return
 		}
 		productBatchReq := domain.ProductBatches{
@@ -101,16 +134,313 @@ func (p *ProductBatches) Create() gin.HandlerFunc {
 		}
 
 		ctx := c.Request.Context()
-		productBatchRes, err := p.productBatchesService.Save(&ctx, productBatchReq)
+		productBatchRes, err := p.productBatchesService.Save(ctx, productBatchReq)
+
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusCreated, productBatchRes)
+	}
+}
 
+// Method GetAll
+// ListProductBatches godoc
+//
+//	@Summary		List ProductBatches
+//	@Tags			ProductBatch
+//	@Description	getAll productBatches, filtered by any field and by a due date range
+//	@Accept			json
+//	@Produce		json,text/csv,application/x-ndjson
+//	@Param			limit			query		int		false	"Page size"
+//	@Param			offset			query		int		false	"Number of productBatches to skip"
+//	@Param			sort			query		string	false	"Field to sort by"
+//	@Param			order			query		string	false	"asc or desc"
+//	@Param			format			query		string	false	"json, csv or ndjson, or use the Accept header"
+//	@Param			section_id		query		int		false	"Section of the batches"
+//	@Param			product_id		query		int		false	"Product of the batches"
+//	@Param			due_date_from	query		string	false	"First due date, inclusive"
+//	@Param			due_date_to		query		string	false	"Last due date, inclusive"
+//	@Success		200				{object}	web.response
+//	@Router			/api/v1/productBatches [get]
+func (p *ProductBatches) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		options, err := parseOptions(c.Request.URL.Query())
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if format != export.JSON {
+			web.Export(c, format, domain.ProductBatches{}, func(write func(row interface{}) error) error {
+				return p.productBatchesService.Each(c.Request.Context(), options.Unpaged(), func(row domain.ProductBatches) error {
+					return write(row)
+				})
+			})
+			return
+		}
+
+		productBatches, total, err := p.productBatchesService.GetPage(c.Request.Context(), options)
 		if err != nil {
-			if errors.Is(err, productbatches.ErrConflict) {
-				web.Error(c, http.StatusConflict, err.Error())
+			_ = c.Error(err)
+			return
+		}
+		if len(*productBatches) == 0 {
+			web.Error(c, http.StatusNoContent, "There are no product batches stored")
+			return
+		}
+		web.Page(c, http.StatusOK, productBatches, total, options)
+	}
+}
+
+// Method GetBySection
+// ListSectionProductBatches godoc
+//
+//	@Summary		List ProductBatches of a Section
+//	@Tags			ProductBatch
+//	@Description	getAll productBatches stored in a section, with the description of their product
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		int		true	"ID of the Section"
+//	@Param			limit			query		int		false	"Page size"
+//	@Param			offset			query		int		false	"Number of productBatches to skip"
+//	@Param			due_date_from	query		string	false	"First due date, inclusive"
+//	@Param			due_date_to		query		string	false	"Last due date, inclusive"
+//	@Success		200				{object}	web.response
+//	@Router			/api/v1/sections/{id}/productBatches [get]
+func (p *ProductBatches) GetBySection() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sectionID, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, ErrInvalidID)
+			return
+		}
+
+		options, err := parseOptions(c.Request.URL.Query())
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		productBatches, total, err := p.productBatchesService.GetPageBySection(c.Request.Context(), sectionID, options)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		web.Page(c, http.StatusOK, productBatches, total, options)
+	}
+}
+
+// Method GetByID
+// GetProductBatch godoc
+//
+//	@Summary		Get ProductBatch
+//	@Tags			ProductBatch
+//	@Description	Get the details of a ProductBatch
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"ID of ProductBatch to be searched"
+//	@Success		200	{object}	web.response
+//	@Router			/api/v1/productBatches/{id} [get]
+func (p *ProductBatches) GetByID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, ErrInvalidID)
+			return
+		}
+
+		productBatch, err := p.productBatchesService.Get(c.Request.Context(), id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, productBatch)
+	}
+}
+
+// Method Update
+// UpdateProductBatch godoc
+//
+//	@Summary		Update ProductBatch
+//	@Tags			ProductBatch
+//	@Description	Update the current quantity and temperature of a ProductBatch
+//	@Accept			json
+//	@Produce		json
+//	@Param			id				path		int										true	"ID of ProductBatch to be updated"
+//	@Param			ProductBatch	body		productbatchesdto.UpdateProductBatchesDTO	true	"Updated ProductBatch quantity and temperature"
+//	@Success		200				{object}	web.response
+//	@Router			/api/v1/productBatches/{id} [patch]
+func (p *ProductBatches) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, ErrInvalidID)
+			return
+		}
+
+		var req dto.UpdateProductBatchesDTO
+		if err := c.ShouldBindJSON(&req); err != nil {
+			web.ValidationError(c, err)
+			return
+		}
+
+		productBatch, err := p.productBatchesService.Update(c.Request.Context(), id, req.CurrentQuantity, req.CurrentTemperature)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, productBatch)
+	}
+}
+
+// Method Delete
+// DeleteProductBatch godoc
+//
+//	@Summary		Delete ProductBatch
+//	@Tags			ProductBatch
+//	@Description	Delete ProductBatch, releasing its quantity from the section capacity
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int	true	"ID of a ProductBatch to be excluded"
+//	@Success		204	{object}	web.response
+//	@Router			/api/v1/productBatches/{id} [delete]
+func (p *ProductBatches) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, ErrInvalidID)
+			return
+		}
+
+		err = p.productBatchesService.Delete(c.Request.Context(), id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusNoContent, nil)
+	}
+}
+
+// Method ReportExpiring
+// ReportExpiringProductBatches godoc
+//
+//	@Summary		Expiring ProductBatches report
+//	@Tags			ProductBatch
+//	@Description	List the batches with stock due from today to the informed number of days ahead, grouped by warehouse and section
+//	@Accept			json
+//	@Produce		json,text/csv,application/x-ndjson
+//	@Param			days	query		int	false	"Number of days ahead, 7 by default"
+//	@Param			format	query		string	false	"json, csv or ndjson, or use the Accept header; exports have one row per batch"
+//	@Success		200		{object}	[]domain.ExpiringBatchesByWarehouse
+//	@Failure		400		{object}	web.errorResponse
+//	@Router			/api/v1/productBatches/reportExpiring [get]
+func (p *ProductBatches) ReportExpiring() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		days := defaultExpiringDays
+		if daysParam := c.Query("days"); daysParam != "" {
+			days, err = strconv.Atoi(daysParam)
+			if err != nil || days < 0 {
+				web.Error(c, http.StatusBadRequest, ErrInvalidDays)
 				return
 			}
-			web.Error(c, http.StatusInternalServerError, err.Error())
+		}
+
+		report, err := p.productBatchesService.ExpiringReport(c.Request.Context(), days)
+		if err != nil {
+			_ = c.Error(err)
 			return
 		}
-		web.Success(c, http.StatusCreated, productBatchRes)
+		if format == export.JSON {
+			web.Success(c, http.StatusOK, report)
+			return
+		}
+
+		rows := make([]expiringRow, 0)
+		for _, warehouse := range report {
+			for _, section := range warehouse.Sections {
+				for _, batch := range section.Batches {
+					rows = append(rows, expiringRow{WarehouseID: warehouse.WarehouseID, SectionID: section.SectionID, SectionNumber: section.SectionNumber, ExpiringBatch: batch})
+				}
+			}
+		}
+		web.Report(c, format, rows, expiringRow{})
 	}
 }
+
+// Method Pick
+// PickProductBatches godoc
+//
+//	@Summary		Pick ProductBatches
+//	@Tags			ProductBatch
+//	@Description	Take a quantity of a product from its unexpired batches, first expired first out
+//	@Accept			json
+//	@Produce		json
+//	@Param			Pick	body		productbatchesdto.PickProductBatchesDTO	true	"Product and quantity to pick"
+//	@Success		200		{object}	[]domain.BatchPick
+//	@Failure		404		{object}	web.errorResponse
+//	@Failure		409		{object}	web.errorResponse
+//	@Failure		422		{object}	web.errorResponse
+//	@Router			/api/v1/productBatches/pick [post]
+func (p *ProductBatches) Pick() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req dto.PickProductBatchesDTO
+		if err := c.ShouldBindJSON(&req); err != nil {
+			web.ValidationError(c, err)
+			return
+		}
+
+		picks, err := p.productBatchesService.Pick(c.Request.Context(), req.ProductID, req.Quantity)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, picks)
+	}
+}
+
+// parseReportFilter reads the optional warehouse and product type of the section products report.
+func parseReportFilter(c *gin.Context) (productbatches.SectionReportFilter, error) {
+	var filter productbatches.SectionReportFilter
+	for param, value := range map[string]*int{"warehouse_id": &filter.WarehouseID, "product_type_id": &filter.ProductTypeID} {
+		raw := c.Query(param)
+		if raw == "" {
+			continue
+		}
+		id, err := strconv.Atoi(raw)
+		if err != nil || id <= 0 {
+			return productbatches.SectionReportFilter{}, errors.New(ErrInvalidReportFilter)
+		}
+		*value = id
+	}
+	return filter, nil
+}
+
+// parseOptions reads the listing options of a product batch list, including the due date range.
+func parseOptions(values url.Values) (listing.Options, error) {
+	options, err := listing.Parse(values, productbatches.Fields)
+	if err != nil {
+		return listing.Options{}, err
+	}
+
+	dueDates := listing.Range(values, "due_date", productbatches.Fields["due_date"])
+	for _, filter := range dueDates {
+		if _, err := time.Parse("2006-01-02", filter.Value); err != nil {
+			return listing.Options{}, errors.New(ErrInvalidDueDate)
+		}
+	}
+	options.Filters = append(options.Filters, dueDates...)
+
+	return options, nil
+}
@@ -10,10 +10,12 @@ import (
 	"testing"
 
 	productbatcheshandler "github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/product_batches_handler"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/productbatchesdto"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/productbatches"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/productbatches/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -51,7 +53,7 @@ func TestCreate(t *testing.T) {
 	t.Run("CREATE - StatusUnprocessableEntity", func(t *testing.T) {
 		r, mockService, handler := InitServerWithGetSections(t)
 
-		mockService.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("domain.ProductBatches")).Return(&domain.ProductBatches{}, errors.New("error"))
+		mockService.On("Save", mock.Anything, mock.AnythingOfType("domain.ProductBatches")).Return(&domain.ProductBatches{}, errors.New("error"))
 		r.POST("/api/v1/product-batch", handler.Create())
 
 		req := httptest.NewRequest(http.MethodPost, "/api/v1/product-batch", nil)
@@ -62,7 +64,7 @@ func TestCreate(t *testing.T) {
 	})
 	t.Run("CREATE - OK -  When data entry is successful, a 201 code will be returned along with the inserted object", func(t *testing.T) {
 		r, mockService, handler := InitServerWithGetSections(t)
-		mockService.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("domain.ProductBatches")).Return(&expectedProductBatch, nil)
+		mockService.On("Save", mock.Anything, mock.AnythingOfType("domain.ProductBatches")).Return(&expectedProductBatch, nil)
 
 		r.POST("/api/v1/product-batch", handler.Create())
 
@@ -87,7 +89,7 @@ func TestCreate(t *testing.T) {
 	})
 	t.Run("CREATE - Create_Conflict", func(t *testing.T) {
 		r, mockService, handler := InitServerWithGetSections(t)
-		mockService.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("domain.ProductBatches")).Return(&domain.ProductBatches{}, productbatches.ErrConflict)
+		mockService.On("Save", mock.Anything, mock.AnythingOfType("domain.ProductBatches")).Return((*domain.ProductBatches)(nil), productbatches.ErrConflict)
 		r.POST("/api/v1/product-batch", handler.Create())
 
 		requestBody, _ := json.Marshal(payload)
@@ -99,9 +101,39 @@ func TestCreate(t *testing.T) {
 		r.ServeHTTP(response, request)
 		assert.Equal(t, http.StatusConflict, response.Code)
 	})
+	t.Run("CREATE - Capacity_Exceeded", func(t *testing.T) {
+		r, mockService, handler := InitServerWithGetSections(t)
+		mockService.On("Save", mock.Anything, mock.AnythingOfType("domain.ProductBatches")).Return((*domain.ProductBatches)(nil), productbatches.ErrCapacityExceeded)
+		r.POST("/api/v1/product-batch", handler.Create())
+
+		requestBody, _ := json.Marshal(payload)
+		req := bytes.NewReader(requestBody)
+
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/product-batch", req)
+		response := httptest.NewRecorder()
+
+		r.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusConflict, response.Code)
+	})
+	t.Run("CREATE - Placement_Rule_Violated", func(t *testing.T) {
+		for _, err := range []error{productbatches.ErrProductTypeMismatch, productbatches.ErrSectionTooWarm, productbatches.ErrInvalidProduct, productbatches.ErrInvalidSection} {
+			r, mockService, handler := InitServerWithGetSections(t)
+			mockService.On("Save", mock.Anything, mock.AnythingOfType("domain.ProductBatches")).Return((*domain.ProductBatches)(nil), err)
+			r.POST("/api/v1/product-batch", handler.Create())
+
+			requestBody, _ := json.Marshal(payload)
+			req := bytes.NewReader(requestBody)
+
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/product-batch", req)
+			response := httptest.NewRecorder()
+
+			r.ServeHTTP(response, request)
+			assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		}
+	})
 	t.Run("CREATE - Create_Internal_Server_Error -  return status code 500", func(t *testing.T) {
 		r, mockService, handler := InitServerWithGetSections(t)
-		mockService.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("domain.ProductBatches")).Return(&domain.ProductBatches{}, errors.New("error"))
+		mockService.On("Save", mock.Anything, mock.AnythingOfType("domain.ProductBatches")).Return(&domain.ProductBatches{}, errors.New("error"))
 		r.POST("/api/v1/product-batch", handler.Create())
 
 		requestBody, _ := json.Marshal(payload)
@@ -120,7 +152,7 @@ func TestGet(t *testing.T) {
 	t.Run("GET - SectionProductsReports - StatusInternalServerError", func(t *testing.T) {
 		server, mockService, handler := InitServerWithGetSections(t)
 
-		mockService.On("SectionProductsReports").Return([]domain.ProductBySection{}, assert.AnError)
+		mockService.On("SectionProductsReports", mock.Anything, productbatches.SectionReportFilter{}).Return([]domain.ProductBySection{}, assert.AnError)
 		server.GET("/api/v1/product-batches/sections/report-products", handler.Get())
 
 		request := httptest.NewRequest(http.MethodGet, "/api/v1/product-batches/sections/report-products", nil)
@@ -143,7 +175,7 @@ func TestGet(t *testing.T) {
 			},
 		}
 		server, mockService, handler := InitServerWithGetSections(t)
-		mockService.On("SectionProductsReports", mock.Anything).Return(expectedReportProducts, nil)
+		mockService.On("SectionProductsReports", mock.Anything, productbatches.SectionReportFilter{}).Return(expectedReportProducts, nil)
 
 		server.GET("/api/v1/product-batches/sections/report-products", handler.Get())
 
@@ -154,6 +186,28 @@ func TestGet(t *testing.T) {
 		assert.Equal(t, http.StatusOK, response.Code)
 
 	})
+	t.Run("GET - SectionProductsReports - Filtered", func(t *testing.T) {
+		server, mockService, handler := InitServerWithGetSections(t)
+		mockService.On("SectionProductsReports", mock.Anything, productbatches.SectionReportFilter{WarehouseID: 1, ProductTypeID: 3}).Return([]domain.ProductBySection{}, nil)
+		server.GET("/api/v1/product-batches/sections/report-products", handler.Get())
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/product-batches/sections/report-products?warehouse_id=1&product_type_id=3", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+	t.Run("GET - SectionProductsReports - Invalid filter", func(t *testing.T) {
+		server, mockService, handler := InitServerWithGetSections(t)
+		server.GET("/api/v1/product-batches/sections/report-products", handler.Get())
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/product-batches/sections/report-products?warehouse_id=abc", nil)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		mockService.AssertNotCalled(t, "SectionProductsReports", mock.Anything, mock.Anything)
+	})
 	t.Run("GET - StatusBadRequest - Invalid ID", func(t *testing.T) {
 		server, _, handler := InitServerWithGetSections(t)
 		server.GET("/api/v1/product-batches/sections/report-products/:id", handler.Get())
@@ -168,7 +222,7 @@ func TestGet(t *testing.T) {
 	t.Run("GET - Not Found", func(t *testing.T) {
 		server, mockService, handler := InitServerWithGetSections(t)
 		server.GET("/api/v1/product-batches/sections/report-products/:id", handler.Get())
-		mockService.On("SectionProductsReportsBySection", mock.AnythingOfType("int")).Return([]domain.ProductBySection{}, productbatches.ErrNotFoundSection)
+		mockService.On("SectionProductsReportsBySection", mock.Anything, mock.AnythingOfType("int")).Return([]domain.ProductBySection{}, productbatches.ErrNotFoundSection)
 
 		request := httptest.NewRequest(http.MethodGet, "/api/v1/product-batches/sections/report-products/10", nil)
 		response := httptest.NewRecorder()
@@ -180,7 +234,7 @@ func TestGet(t *testing.T) {
 	t.Run("READ - Internal Server Error SectionProductsReportsBySection", func(t *testing.T) {
 		server, mockService, handler := InitServerWithGetSections(t)
 
-		mockService.On("SectionProductsReportsBySection", mock.AnythingOfType("int")).Return([]domain.ProductBySection{}, assert.AnError)
+		mockService.On("SectionProductsReportsBySection", mock.Anything, mock.AnythingOfType("int")).Return([]domain.ProductBySection{}, assert.AnError)
 		server.GET("/api/v1/product-batches/sections/report-products/:id", handler.Get())
 
 		request := httptest.NewRequest(http.MethodGet, "/api/v1/product-batches/sections/report-products/2", nil)
@@ -199,7 +253,7 @@ func TestGet(t *testing.T) {
 			}
 	
 		server, mockService, handler := InitServerWithGetSections(t)
-		mockService.On("SectionProductsReportsBySection", mock.AnythingOfType("int")).Return(expectedReportProductsBySection, nil)
+		mockService.On("SectionProductsReportsBySection", mock.Anything, mock.AnythingOfType("int")).Return(expectedReportProductsBySection, nil)
 
 		server.GET("/api/v1/product-batches/sections/report-products/:id", handler.Get())
 
@@ -215,6 +269,267 @@ func TestGet(t *testing.T) {
 	})
 }
 
+func TestGetAll(t *testing.T) {
+	t.Run("GETALL - OK filtered by due date", func(t *testing.T) {
+		r, mockService, handler := InitServerWithGetSections(t)
+		productBatches := []domain.ProductBatches{expectedProductBatch}
+		mockService.On("GetPage", mock.Anything, mock.MatchedBy(func(options listing.Options) bool {
+			return len(options.Filters) == 2 && options.Filters[1].Operator == listing.OperatorFrom
+		})).Return(&productBatches, 1, nil)
+		r.GET("/api/v1/productBatches", handler.GetAll())
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/productBatches?section_id=789&due_date_from=2023-07-01", nil)
+		response := httptest.NewRecorder()
+
+		r.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+	t.Run("GETALL - Invalid due date", func(t *testing.T) {
+		r, mockService, handler := InitServerWithGetSections(t)
+		r.GET("/api/v1/productBatches", handler.GetAll())
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/productBatches?due_date_to=tomorrow", nil)
+		response := httptest.NewRecorder()
+
+		r.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		mockService.AssertNotCalled(t, "GetPage", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetBySection(t *testing.T) {
+	t.Run("GETBYSECTION - OK", func(t *testing.T) {
+		r, mockService, handler := InitServerWithGetSections(t)
+		productBatches := []domain.SectionProductBatch{{ProductBatches: expectedProductBatch, ProductDescription: "Product 1"}}
+		mockService.On("GetPageBySection", mock.Anything, 789, mock.AnythingOfType("listing.Options")).Return(&productBatches, 1, nil)
+		r.GET("/api/v1/sections/:id/productBatches", handler.GetBySection())
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/sections/789/productBatches", nil)
+		response := httptest.NewRecorder()
+
+		r.ServeHTTP(response, request)
+
+		var body struct {
+			Data []domain.SectionProductBatch `json:"data"`
+		}
+		json.Unmarshal(response.Body.Bytes(), &body)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, productBatches, body.Data)
+	})
+	t.Run("GETBYSECTION - Section Not Found", func(t *testing.T) {
+		r, mockService, handler := InitServerWithGetSections(t)
+		mockService.On("GetPageBySection", mock.Anything, 99, mock.AnythingOfType("listing.Options")).Return((*[]domain.SectionProductBatch)(nil), 0, productbatches.ErrNotFoundSection)
+		r.GET("/api/v1/sections/:id/productBatches", handler.GetBySection())
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/sections/99/productBatches", nil)
+		response := httptest.NewRecorder()
+
+		r.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
+
+func TestGetByID(t *testing.T) {
+	t.Run("GETBYID - OK", func(t *testing.T) {
+		r, mockService, handler := InitServerWithGetSections(t)
+		mockService.On("Get", mock.Anything, 1).Return(&expectedProductBatch, nil)
+		r.GET("/api/v1/productBatches/:id", handler.GetByID())
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/productBatches/1", nil)
+		response := httptest.NewRecorder()
+
+		r.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+	t.Run("GETBYID - Not Found", func(t *testing.T) {
+		r, mockService, handler := InitServerWithGetSections(t)
+		mockService.On("Get", mock.Anything, 99).Return((*domain.ProductBatches)(nil), productbatches.ErrNotFound)
+		r.GET("/api/v1/productBatches/:id", handler.GetByID())
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/productBatches/99", nil)
+		response := httptest.NewRecorder()
+
+		r.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+	t.Run("GETBYID - Invalid ID", func(t *testing.T) {
+		r, _, handler := InitServerWithGetSections(t)
+		r.GET("/api/v1/productBatches/:id", handler.GetByID())
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/productBatches/abc", nil)
+		response := httptest.NewRecorder()
+
+		r.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	t.Run("UPDATE - OK", func(t *testing.T) {
+		r, mockService, handler := InitServerWithGetSections(t)
+		mockService.On("Update", mock.Anything, 1, mock.AnythingOfType("*int"), mock.AnythingOfType("*float64")).Return(&expectedProductBatch, nil)
+		r.PATCH("/api/v1/productBatches/:id", handler.Update())
+
+		request := httptest.NewRequest(http.MethodPatch, "/api/v1/productBatches/1", bytes.NewBufferString(`{"current_quantity": 20, "current_temperature": -18}`))
+		response := httptest.NewRecorder()
+
+		r.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+	t.Run("UPDATE - Negative quantity", func(t *testing.T) {
+		r, mockService, handler := InitServerWithGetSections(t)
+		r.PATCH("/api/v1/productBatches/:id", handler.Update())
+
+		request := httptest.NewRequest(http.MethodPatch, "/api/v1/productBatches/1", bytes.NewBufferString(`{"current_quantity": -1}`))
+		response := httptest.NewRecorder()
+
+		r.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		mockService.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("UPDATE - Capacity Exceeded", func(t *testing.T) {
+		r, mockService, handler := InitServerWithGetSections(t)
+		mockService.On("Update", mock.Anything, 1, mock.AnythingOfType("*int"), mock.AnythingOfType("*float64")).Return((*domain.ProductBatches)(nil), productbatches.ErrCapacityExceeded)
+		r.PATCH("/api/v1/productBatches/:id", handler.Update())
+
+		request := httptest.NewRequest(http.MethodPatch, "/api/v1/productBatches/1", bytes.NewBufferString(`{"current_quantity": 5000}`))
+		response := httptest.NewRecorder()
+
+		r.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusConflict, response.Code)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("DELETE - OK", func(t *testing.T) {
+		r, mockService, handler := InitServerWithGetSections(t)
+		mockService.On("Delete", mock.Anything, 1).Return(nil)
+		r.DELETE("/api/v1/productBatches/:id", handler.Delete())
+
+		request := httptest.NewRequest(http.MethodDelete, "/api/v1/productBatches/1", nil)
+		response := httptest.NewRecorder()
+
+		r.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusNoContent, response.Code)
+	})
+	t.Run("DELETE - Not Found", func(t *testing.T) {
+		r, mockService, handler := InitServerWithGetSections(t)
+		mockService.On("Delete", mock.Anything, 99).Return(productbatches.ErrNotFound)
+		r.DELETE("/api/v1/productBatches/:id", handler.Delete())
+
+		request := httptest.NewRequest(http.MethodDelete, "/api/v1/productBatches/99", nil)
+		response := httptest.NewRecorder()
+
+		r.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+}
+
+func TestReportExpiring(t *testing.T) {
+	t.Run("EXPIRING - Default days", func(t *testing.T) {
+		r, mockService, handler := InitServerWithGetSections(t)
+		mockService.On("ExpiringReport", mock.Anything, 7).Return([]domain.ExpiringBatchesByWarehouse{{WarehouseID: 1}}, nil)
+		r.GET("/api/v1/productBatches/reportExpiring", handler.ReportExpiring())
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/productBatches/reportExpiring", nil)
+		response := httptest.NewRecorder()
+
+		r.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+	t.Run("EXPIRING - Informed days", func(t *testing.T) {
+		r, mockService, handler := InitServerWithGetSections(t)
+		mockService.On("ExpiringReport", mock.Anything, 30).Return([]domain.ExpiringBatchesByWarehouse{}, nil)
+		r.GET("/api/v1/productBatches/reportExpiring", handler.ReportExpiring())
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/productBatches/reportExpiring?days=30", nil)
+		response := httptest.NewRecorder()
+
+		r.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+	t.Run("EXPIRING - Invalid days", func(t *testing.T) {
+		r, mockService, handler := InitServerWithGetSections(t)
+		r.GET("/api/v1/productBatches/reportExpiring", handler.ReportExpiring())
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/productBatches/reportExpiring?days=-1", nil)
+		response := httptest.NewRecorder()
+
+		r.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		mockService.AssertNotCalled(t, "ExpiringReport", mock.Anything, mock.Anything)
+	})
+	t.Run("EXPIRING - CSV has one row per batch", func(t *testing.T) {
+		r, mockService, handler := InitServerWithGetSections(t)
+		mockService.On("ExpiringReport", mock.Anything, 7).Return([]domain.ExpiringBatchesByWarehouse{
+			{WarehouseID: 1, Sections: []domain.ExpiringBatchesBySection{
+				{SectionID: 2, SectionNumber: 20, Batches: []domain.ExpiringBatch{
+					{ID: 5, BatchNumber: 50, ProductID: 3, CurrentQuantity: 10, DueDate: "2023-05-02"},
+					{ID: 6, BatchNumber: 60, ProductID: 4, CurrentQuantity: 5, DueDate: "2023-05-03"},
+				}},
+			}},
+		}, nil)
+		r.GET("/api/v1/productBatches/reportExpiring", handler.ReportExpiring())
+
+		request := httptest.NewRequest(http.MethodGet, "/api/v1/productBatches/reportExpiring", nil)
+		request.Header.Set("Accept", "text/csv")
+		response := httptest.NewRecorder()
+
+		r.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, "warehouse_id,section_id,section_number,id,batch_number,product_id,current_quantity,due_date\n"+
+			"1,2,20,5,50,3,10,2023-05-02\n"+
+			"1,2,20,6,60,4,5,2023-05-03\n", response.Body.String())
+	})
+}
+
+func TestPick(t *testing.T) {
+	t.Run("PICK - OK", func(t *testing.T) {
+		r, mockService, handler := InitServerWithGetSections(t)
+		mockService.On("Pick", mock.Anything, 456, 5).Return([]domain.BatchPick{{ProductBatchID: 1, Quantity: 5, RemainingQuantity: 5}}, nil)
+		r.POST("/api/v1/productBatches/pick", handler.Pick())
+
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/productBatches/pick", bytes.NewBufferString(`{"product_id": 456, "quantity": 5}`))
+		response := httptest.NewRecorder()
+
+		r.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+	t.Run("PICK - Invalid quantity", func(t *testing.T) {
+		r, mockService, handler := InitServerWithGetSections(t)
+		r.POST("/api/v1/productBatches/pick", handler.Pick())
+
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/productBatches/pick", bytes.NewBufferString(`{"product_id": 456, "quantity": 0}`))
+		response := httptest.NewRecorder()
+
+		r.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusUnprocessableEntity, response.Code)
+		mockService.AssertNotCalled(t, "Pick", mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("PICK - Product Not Found", func(t *testing.T) {
+		r, mockService, handler := InitServerWithGetSections(t)
+		mockService.On("Pick", mock.Anything, 99, 5).Return([]domain.BatchPick(nil), productbatches.ErrNotFoundProduct)
+		r.POST("/api/v1/productBatches/pick", handler.Pick())
+
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/productBatches/pick", bytes.NewBufferString(`{"product_id": 99, "quantity": 5}`))
+		response := httptest.NewRecorder()
+
+		r.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+	t.Run("PICK - Insufficient Stock", func(t *testing.T) {
+		r, mockService, handler := InitServerWithGetSections(t)
+		mockService.On("Pick", mock.Anything, 456, 500).Return([]domain.BatchPick(nil), productbatches.ErrInsufficientStock)
+		r.POST("/api/v1/productBatches/pick", handler.Pick())
+
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/productBatches/pick", bytes.NewBufferString(`{"product_id": 456, "quantity": 500}`))
+		response := httptest.NewRecorder()
+
+		r.ServeHTTP(response, request)
+		assert.Equal(t, http.StatusConflict, response.Code)
+	})
+}
+
 func InitServerWithGetSections(t *testing.T) (*gin.Engine, *mocks.ProductBatchServiceMock, *productbatcheshandler.ProductBatches) {
 	t.Helper()
 	server := createServer()
@@ -226,5 +541,6 @@ func createServer() *gin.Engine {
 	//Configurar o servidor
 	gin.SetMode(gin.TestMode)
 	server := gin.Default()
+	server.Use(middlewares.Errors())
 	return server
 }
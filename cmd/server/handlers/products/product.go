@@ -1,41 +1,43 @@
 package products
 
 import (
-	"fmt"
 	"net/http"
 	"strconv"
 
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/product"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/export"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
 	"github.com/gin-gonic/gin"
 )
 
 type RequestCreateProduct struct {
-	Description    string  `json:"description"`
-	ExpirationRate int     `json:"expiration_rate"`
-	FreezingRate   int     `json:"freezing_rate"`
-	Height         float32 `json:"height"`
-	Length         float32 `json:"length"`
-	Netweight      float32 `json:"netweight"`
-	ProductCode    string  `json:"product_code"`
-	RecomFreezTemp float32 `json:"recommended_freezing_temperature"`
-	Width          float32 `json:"width"`
-	ProductTypeID  int     `json:"product_type_id"`
-	SellerID       int     `json:"seller_id"`
+	Description    string  `json:"description" binding:"required,max=255"`
+	ExpirationRate int     `json:"expiration_rate" binding:"required,gt=0"`
+	FreezingRate   int     `json:"freezing_rate" binding:"required,gt=0"`
+	Height         float32 `json:"height" binding:"required,gt=0"`
+	Length         float32 `json:"length" binding:"required,gt=0"`
+	Netweight      float32 `json:"netweight" binding:"required,gt=0"`
+	ProductCode    string  `json:"product_code" binding:"required,max=255"`
+	RecomFreezTemp float32 `json:"recommended_freezing_temperature" binding:"required"`
+	Width          float32 `json:"width" binding:"required,gt=0"`
+	ProductTypeID  int     `json:"product_type_id" binding:"required,gt=0"`
+	SellerID       int     `json:"seller_id" binding:"required,gt=0"`
 }
 
 type RequestUpdateProduct struct {
-	Description    *string  `json:"description"`
-	ExpirationRate *int     `json:"expiration_rate"`
-	FreezingRate   *int     `json:"freezing_rate"`
-	Height         *float32 `json:"height"`
-	Length         *float32 `json:"length"`
-	Netweight      *float32 `json:"netweight"`
-	ProductCode    *string  `json:"product_code"`
+	Description    *string  `json:"description" binding:"omitempty,min=1,max=255"`
+	ExpirationRate *int     `json:"expiration_rate" binding:"omitempty,gt=0"`
+	FreezingRate   *int     `json:"freezing_rate" binding:"omitempty,gt=0"`
+	Height         *float32 `json:"height" binding:"omitempty,gt=0"`
+	Length         *float32 `json:"length" binding:"omitempty,gt=0"`
+	Netweight      *float32 `json:"netweight" binding:"omitempty,gt=0"`
+	ProductCode    *string  `json:"product_code" binding:"omitempty,min=1,max=255"`
 	RecomFreezTemp *float32 `json:"recommended_freezing_temperature"`
-	Width          *float32 `json:"width"`
-	ProductTypeID  *int     `json:"product_type_id"`
-	SellerID       *int     `json:"seller_id"`
+	Width          *float32 `json:"width" binding:"omitempty,gt=0"`
+	ProductTypeID  *int     `json:"product_type_id" binding:"omitempty,gt=0"`
+	SellerID       *int     `json:"seller_id" binding:"omitempty,gt=0"`
 }
 
 type Product struct {
@@ -55,21 +57,48 @@ func NewProduct(p product.Service) *Product {
 //	@Tags			Products
 //	@Description	getAll products
 //	@Accept			json
-//	@Produce		json
-//	@Success		200	{object}	web.response
+//	@Produce		json,text/csv,application/x-ndjson
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			offset	query		int		false	"Number of products to skip"
+//	@Param			sort	query		string	false	"Field to sort by"
+//	@Param			order	query		string	false	"asc or desc"
+//	@Param			format	query		string	false	"json, csv or ndjson, or use the Accept header"
+//	@Param			include_deleted	query		bool	false	"List deleted records too"
+//	@Success		200		{object}	web.response
 //	@Router			/api/v1/products [get]
 func (p *Product) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		options, err := listing.ParseSoftDeleted(c.Request.URL.Query(), product.Fields, "deleted_at")
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if format != export.JSON {
+			web.Export(c, format, domain.Product{}, func(write func(row interface{}) error) error {
+				return p.productService.Each(c.Request.Context(), options.Unpaged(), func(row domain.Product) error {
+					return write(row)
+				})
+			})
+			return
+		}
+
 		ctx := c.Request.Context()
-		products, err := p.productService.GetAll(&ctx)
+		products, total, err := p.productService.GetPage(ctx, options)
 		if err != nil {
-			web.Error(c, http.StatusInternalServerError, err.Error())
+			_ = c.Error(err)
 			return
 		}
 		if len(*products) == 0 {
 			web.Success(c, http.StatusNoContent, nil)
+			return
 		}
-		web.Success(c, http.StatusOK, products)
+		web.Page(c, http.StatusOK, products, total, options)
 	}
 }
 
@@ -92,14 +121,9 @@ func (p *Product) Get() gin.HandlerFunc {
 			return
 		}
 		ctx := c.Request.Context()
-		productResponse, err := p.productService.Get(&ctx, int(id))
+		productResponse, err := p.productService.Get(ctx, int(id))
 		if err != nil {
-			switch err {
-			case product.ErrNotFound:
-				web.Error(c, http.StatusNotFound, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, fmt.Sprintf("error getting product %s", err.Error()))
-			}
+			_ = c.Error(err)
 			return
 		}
 		web.Success(c, http.StatusOK, productResponse)
@@ -121,74 +145,15 @@ func (p *Product) Create() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req RequestCreateProduct
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, err.Error())
-			return
-		}
-
-		if req.Description == "" {
-			web.Error(c, http.StatusUnprocessableEntity, "The field Description is required.")
+			web.ValidationError(c, err)
 			return
 		}
 
-		if req.ExpirationRate == 0 {
-			web.Error(c, http.StatusUnprocessableEntity, "The field ExpirationRate is required.")
-			return
-		}
-
-		if req.FreezingRate == 0 {
-			web.Error(c, http.StatusUnprocessableEntity, "The field FreezingRate is required.")
-			return
-		}
-
-		if req.Height == 0 {
-			web.Error(c, http.StatusUnprocessableEntity, "The field Height is required.")
-			return
-		}
-
-		if req.Length == 0 {
-			web.Error(c, http.StatusUnprocessableEntity, "The field Length is required.")
-			return
-		}
-
-		if req.Netweight == 0 {
-			web.Error(c, http.StatusUnprocessableEntity, "The field Netweight is required.")
-			return
-		}
-
-		if req.ProductCode == "" {
-			web.Error(c, http.StatusUnprocessableEntity, "The field ProductCode is required.")
-			return
-		}
-
-		if req.RecomFreezTemp == 0 {
-			web.Error(c, http.StatusUnprocessableEntity, "The field RecomFreezTemp is required.")
-			return
-		}
-
-		if req.Width == 0 {
-			web.Error(c, http.StatusUnprocessableEntity, "The field Width is required.")
-			return
-		}
-
-		if req.ProductTypeID == 0 {
-			web.Error(c, http.StatusUnprocessableEntity, "The field ProductTypeID is required.")
-			return
-		}
-
-		if req.SellerID == 0 {
-			web.Error(c, http.StatusUnprocessableEntity, "The field SellerID is required.")
-			return
-		}
 		ctx := c.Request.Context()
-		productResponse, err := p.productService.Save(&ctx, req.Description, req.ExpirationRate, req.FreezingRate, req.Height,
+		productResponse, err := p.productService.Save(ctx, req.Description, req.ExpirationRate, req.FreezingRate, req.Height,
 			req.Length, req.Netweight, req.ProductCode, req.RecomFreezTemp, req.Width, req.ProductTypeID, req.SellerID)
 		if err != nil {
-			switch err {
-			case product.ErrConflict:
-				web.Error(c, http.StatusConflict, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, fmt.Sprintf("error saving request %s", err.Error()))
-			}
+			_ = c.Error(err)
 			return
 		}
 		web.Success(c, http.StatusCreated, productResponse)
@@ -216,21 +181,14 @@ func (p *Product) Update() gin.HandlerFunc {
 		}
 		var req RequestUpdateProduct
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, err.Error())
+			web.ValidationError(c, err)
 			return
 		}
 		ctx := c.Request.Context()
-		productResponse, err := p.productService.Update(&ctx, req.Description, req.ExpirationRate, req.FreezingRate, req.Height,
+		productResponse, err := p.productService.Update(ctx, req.Description, req.ExpirationRate, req.FreezingRate, req.Height,
 			req.Length, req.Netweight, req.ProductCode, req.RecomFreezTemp, req.Width, req.ProductTypeID, req.SellerID, id)
 		if err != nil {
-			switch err {
-			case product.ErrNotFound:
-				web.Error(c, http.StatusNotFound, err.Error())
-			case product.ErrConflict:
-				web.Error(c, http.StatusConflict, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, fmt.Sprintf("error updating product %s", err.Error()))
-			}
+			_ = c.Error(err)
 			return
 		}
 		web.Success(c, http.StatusOK, productResponse)
@@ -245,8 +203,11 @@ func (p *Product) Update() gin.HandlerFunc {
 //	@Description	Delete Product
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		string	true	"ID of a Product to be excluded"
-//	@Success		204	{object}	web.response
+//	@Param			id		path		string	true	"ID of a Product to be excluded"
+//	@Param			dry_run	query		bool	false	"Only preview the records referencing the product"
+//	@Success		200		{object}	domain.DeletePreview
+//	@Success		204		{object}	web.response
+//	@Failure		409		{object}	web.errorResponse
 //	@Router			/api/v1/products/{id} [delete]
 func (p *Product) Delete() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -256,17 +217,55 @@ func (p *Product) Delete() gin.HandlerFunc {
 			return
 		}
 		ctx := c.Request.Context()
-		err = p.productService.Delete(&ctx, int(id))
+
+		dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
 		if err != nil {
-			switch err {
-			case product.ErrNotFound:
-				web.Error(c, http.StatusNotFound, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, fmt.Sprintf("error deleting product %s", err.Error()))
+			web.Error(c, http.StatusBadRequest, "parameter dry_run must be a boolean")
+			return
+		}
+		if dryRun {
+			preview, err := p.productService.DeletePreview(ctx, id)
+			if err != nil {
+				_ = c.Error(err)
+				return
 			}
+			web.Success(c, http.StatusOK, preview)
+			return
+		}
+
+		err = p.productService.Delete(ctx, int(id))
+		if err != nil {
+			_ = c.Error(err)
 			return
 		}
 
 		web.Success(c, http.StatusNoContent, nil)
 	}
 }
+
+// Method Restore
+// RestoreProducts godoc
+//
+//	@Summary		Restore Product
+//	@Tags			Products
+//	@Description	Restore a deleted Product
+//	@Produce		json
+//	@Param			id	path		string	true	"ID of the deleted Product"
+//	@Success		200	{object}	web.response
+//	@Router			/api/v1/products/{id}/restore [post]
+func (p *Product) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "Invalid ID: %s", err.Error())
+			return
+		}
+		ctx := c.Request.Context()
+		productResponse, err := p.productService.Restore(ctx, id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+		web.Success(c, http.StatusOK, productResponse)
+	}
+}
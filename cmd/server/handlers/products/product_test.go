@@ -8,13 +8,17 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/products"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
 
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/product"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/product/product_mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -41,12 +45,13 @@ func TestGet(t *testing.T) {
 
 		//Configurar o mock do service
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(productFound, nil)
+		productServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(productFound, nil)
 		handler := products.NewProduct(productServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/products/:id", handler.Get())
 
 		//Definir request e response
@@ -76,12 +81,13 @@ func TestGet(t *testing.T) {
 
 		//Configurar o mock do service
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(&domain.Product{}, product.ErrNotFound)
+		productServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(&domain.Product{}, product.ErrNotFound)
 		handler := products.NewProduct(productServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/products/:id", handler.Get())
 
 		//Definir request e response
@@ -99,12 +105,13 @@ func TestGet(t *testing.T) {
 
 		//Configurar o mock do service
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(&domain.Product{}, product.ErrNotFound)
+		productServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(&domain.Product{}, product.ErrNotFound)
 		handler := products.NewProduct(productServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/products/:id", handler.Get())
 
 		//Definir request e response
@@ -122,13 +129,14 @@ func TestGet(t *testing.T) {
 
 		//Configurar o mock do service
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Get", mock.AnythingOfType("*context.Context"),
+		productServiceMock.On("Get", mock.Anything,
 			mock.AnythingOfType("int")).Return(&domain.Product{}, assert.AnError)
 		handler := products.NewProduct(productServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/products/:id", handler.Get())
 
 		//Definir request e response
@@ -178,12 +186,13 @@ func TestCreate(t *testing.T) {
 
 		//Configurar o mock do service
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("string"), mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("string"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("int"), mock.AnythingOfType("int")).Return(expectedProduct, nil)
+		productServiceMock.On("Save", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("string"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("int"), mock.AnythingOfType("int")).Return(expectedProduct, nil)
 		handler := products.NewProduct(productServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/products", handler.Create())
 
 		requestBody, _ := json.Marshal(createProductRequestDTO)
@@ -228,11 +237,12 @@ func TestCreate(t *testing.T) {
 		}
 
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Create", mock.AnythingOfType("*context.Context")).Return(createProductRequestDTO, product.ErrConflict)
+		productServiceMock.On("Create", mock.Anything).Return(createProductRequestDTO, product.ErrConflict)
 		handler := products.NewProduct(productServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/products", handler.Create())
 
 		req := httptest.NewRequest(http.MethodPost, "/api/v1/products", nil)
@@ -247,7 +257,7 @@ func TestCreate(t *testing.T) {
 		createProductRequestDTO := buildProductRequestDTO("", 2, 2, 2.2, 2.2, 2.2, "2222", 2.2, 2.2, 2, 2)
 
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("string"), mock.AnythingOfType("int"),
+		productServiceMock.On("Save", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"),
 			mock.AnythingOfType("string"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int")).Return(&domain.Product{}, product.ErrConflict)
@@ -256,6 +266,7 @@ func TestCreate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/products", handler.Create())
 
 		requestBody, _ := json.Marshal(createProductRequestDTO)
@@ -276,7 +287,7 @@ func TestCreate(t *testing.T) {
 		createProductRequestDTO := buildProductRequestDTO("teste", 0, 2, 2.2, 2.2, 2.2, "22222", 2.2, 2.2, 2, 2)
 
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("string"), mock.AnythingOfType("int"),
+		productServiceMock.On("Save", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"),
 			mock.AnythingOfType("string"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int")).Return(&domain.Product{}, product.ErrConflict)
@@ -285,6 +296,7 @@ func TestCreate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/products", handler.Create())
 
 		requestBody, _ := json.Marshal(createProductRequestDTO)
@@ -305,7 +317,7 @@ func TestCreate(t *testing.T) {
 		createProductRequestDTO := buildProductRequestDTO("teste", 2, 0, 2.2, 2.2, 2.2, "22222", 2.2, 2.2, 2, 2)
 
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("string"), mock.AnythingOfType("int"),
+		productServiceMock.On("Save", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"),
 			mock.AnythingOfType("string"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int")).Return(&domain.Product{}, product.ErrConflict)
@@ -314,6 +326,7 @@ func TestCreate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/products", handler.Create())
 
 		requestBody, _ := json.Marshal(createProductRequestDTO)
@@ -334,7 +347,7 @@ func TestCreate(t *testing.T) {
 		createProductRequestDTO := buildProductRequestDTO("teste", 2, 2, 0, 2.2, 2.2, "22222", 2.2, 2.2, 2, 2)
 
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("string"), mock.AnythingOfType("int"),
+		productServiceMock.On("Save", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"),
 			mock.AnythingOfType("string"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int")).Return(&domain.Product{}, product.ErrConflict)
@@ -343,6 +356,7 @@ func TestCreate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/products", handler.Create())
 
 		requestBody, _ := json.Marshal(createProductRequestDTO)
@@ -363,7 +377,7 @@ func TestCreate(t *testing.T) {
 		createProductRequestDTO := buildProductRequestDTO("teste", 2, 2, 2.2, 0, 2.2, "22222", 2.2, 2.2, 2, 2)
 
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("string"), mock.AnythingOfType("int"),
+		productServiceMock.On("Save", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"),
 			mock.AnythingOfType("string"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int")).Return(&domain.Product{}, product.ErrConflict)
@@ -372,6 +386,7 @@ func TestCreate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/products", handler.Create())
 
 		requestBody, _ := json.Marshal(createProductRequestDTO)
@@ -392,7 +407,7 @@ func TestCreate(t *testing.T) {
 		createProductRequestDTO := buildProductRequestDTO("teste", 2, 2, 2.2, 2.2, 0, "22222", 2.2, 2.2, 2, 2)
 
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("string"), mock.AnythingOfType("int"),
+		productServiceMock.On("Save", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"),
 			mock.AnythingOfType("string"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int")).Return(&domain.Product{}, product.ErrConflict)
@@ -401,6 +416,7 @@ func TestCreate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/products", handler.Create())
 
 		requestBody, _ := json.Marshal(createProductRequestDTO)
@@ -421,7 +437,7 @@ func TestCreate(t *testing.T) {
 		createProductRequestDTO := buildProductRequestDTO("teste", 2, 2, 2.2, 2.2, 2.2, "", 2.2, 2.2, 2, 2)
 
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("string"), mock.AnythingOfType("int"),
+		productServiceMock.On("Save", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"),
 			mock.AnythingOfType("string"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int")).Return(&domain.Product{}, product.ErrConflict)
@@ -430,6 +446,7 @@ func TestCreate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/products", handler.Create())
 
 		requestBody, _ := json.Marshal(createProductRequestDTO)
@@ -450,7 +467,7 @@ func TestCreate(t *testing.T) {
 		createProductRequestDTO := buildProductRequestDTO("teste", 2, 2, 2.2, 2.2, 2.2, "teste", 0, 2.2, 2, 2)
 
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("string"), mock.AnythingOfType("int"),
+		productServiceMock.On("Save", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"),
 			mock.AnythingOfType("string"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int")).Return(&domain.Product{}, product.ErrConflict)
@@ -459,6 +476,7 @@ func TestCreate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/products", handler.Create())
 
 		requestBody, _ := json.Marshal(createProductRequestDTO)
@@ -479,7 +497,7 @@ func TestCreate(t *testing.T) {
 		createProductRequestDTO := buildProductRequestDTO("teste", 2, 2, 2.2, 2.2, 2.2, "teste", 2.2, 0, 2, 2)
 
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("string"), mock.AnythingOfType("int"),
+		productServiceMock.On("Save", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"),
 			mock.AnythingOfType("string"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int")).Return(&domain.Product{}, product.ErrConflict)
@@ -488,6 +506,7 @@ func TestCreate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/products", handler.Create())
 
 		requestBody, _ := json.Marshal(createProductRequestDTO)
@@ -508,7 +527,7 @@ func TestCreate(t *testing.T) {
 		createProductRequestDTO := buildProductRequestDTO("teste", 2, 2, 2.2, 2.2, 2.2, "teste", 2.2, 2.2, 0, 2)
 
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("string"), mock.AnythingOfType("int"),
+		productServiceMock.On("Save", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"),
 			mock.AnythingOfType("string"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int")).Return(&domain.Product{}, product.ErrConflict)
@@ -517,6 +536,7 @@ func TestCreate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/products", handler.Create())
 
 		requestBody, _ := json.Marshal(createProductRequestDTO)
@@ -537,7 +557,7 @@ func TestCreate(t *testing.T) {
 		createProductRequestDTO := buildProductRequestDTO("teste", 2, 2, 2.2, 2.2, 2.2, "teste", 2.2, 2.2, 2, 0)
 
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("string"), mock.AnythingOfType("int"),
+		productServiceMock.On("Save", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"),
 			mock.AnythingOfType("string"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int")).Return(&domain.Product{}, product.ErrConflict)
@@ -546,6 +566,7 @@ func TestCreate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/products", handler.Create())
 
 		requestBody, _ := json.Marshal(createProductRequestDTO)
@@ -578,7 +599,7 @@ func TestCreate(t *testing.T) {
 		}
 
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("string"), mock.AnythingOfType("int"),
+		productServiceMock.On("Save", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"),
 			mock.AnythingOfType("string"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int")).Return(&domain.Product{}, product.ErrConflict)
@@ -587,6 +608,7 @@ func TestCreate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/products", handler.Create())
 
 		requestBody, _ := json.Marshal(createProductRequestDTO)
@@ -620,7 +642,7 @@ func TestCreate(t *testing.T) {
 		}
 
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("string"), mock.AnythingOfType("int"),
+		productServiceMock.On("Save", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"),
 			mock.AnythingOfType("string"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("int"),
 			mock.AnythingOfType("int")).Return(&domain.Product{}, errors.New("error"))
@@ -629,6 +651,7 @@ func TestCreate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/products", handler.Create())
 
 		requestBody, _ := json.Marshal(createProductRequestDTO)
@@ -680,12 +703,13 @@ func TestGetAll(t *testing.T) {
 		}
 		//Configurar o mock do service
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(productsFounds, nil)
+		productServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(productsFounds, len(*productsFounds), nil)
 		handler := products.NewProduct(productServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/products", handler.GetAll())
 
 		//Definir request e response
@@ -714,12 +738,13 @@ func TestGetAll(t *testing.T) {
 		productsFounds := &[]domain.Product{}
 		//Configurar o mock do service
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(productsFounds, nil)
+		productServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(productsFounds, len(*productsFounds), nil)
 		handler := products.NewProduct(productServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/products", handler.GetAll())
 
 		//Definir request e response
@@ -737,12 +762,13 @@ func TestGetAll(t *testing.T) {
 		productsFounds := &[]domain.Product{}
 		//Configurar o mock do service
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(productsFounds, assert.AnError)
+		productServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(productsFounds, 0, assert.AnError)
 		handler := products.NewProduct(productServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/products", handler.GetAll())
 
 		//Definir request e response
@@ -755,6 +781,95 @@ func TestGetAll(t *testing.T) {
 		//Validar resultado
 		assert.Equal(t, http.StatusInternalServerError, res.Code)
 	})
+
+	t.Run("getAll_invalid_query", func(t *testing.T) {
+		serviceMock := new(product_mocks.ProductServiceMock)
+		handler := products.NewProduct(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/products", handler.GetAll())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products?limit=0", nil)
+		res := httptest.NewRecorder()
+
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		serviceMock.AssertNotCalled(t, "GetPage", mock.Anything, mock.Anything)
+	})
+
+	t.Run("getAll_export", func(t *testing.T) {
+		deletedAt := "2023-05-01 10:00:00"
+		rows := []domain.Product{
+			{ID: 1, Description: "Milk", ExpirationRate: 1, FreezingRate: 2, Height: 1.5, Length: 2, Netweight: 1, ProductCode: "P1", RecomFreezTemp: -4, Width: 1, ProductTypeID: 1, SellerID: 3},
+			{ID: 2, Description: "Cheese, brie", ExpirationRate: 1, FreezingRate: 2, Height: 1, Length: 1, Netweight: 0.5, ProductCode: "P2", RecomFreezTemp: 2, Width: 1, ProductTypeID: 1, SellerID: 3, DeletedAt: &deletedAt},
+		}
+		options, _ := listing.ParseSoftDeleted(url.Values{"seller_id": {"3"}, "include_deleted": {"true"}}, product.Fields, "deleted_at")
+
+		tests := []struct {
+			name            string
+			url             string
+			accept          string
+			wantContentType string
+			wantBody        string
+		}{
+			{
+				name:            "CSV from the format parameter, without paging",
+				url:             "/api/v1/products?format=csv&seller_id=3&include_deleted=true&limit=1",
+				wantContentType: "text/csv; charset=utf-8",
+				wantBody: "id,description,expiration_rate,freezing_rate,height,length,net_weight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id,deleted_at\n" +
+					"1,Milk,1,2,1.5,2,1,P1,-4,1,1,3,\n" +
+					"2,\"Cheese, brie\",1,2,1,1,0.5,P2,2,1,1,3,2023-05-01 10:00:00\n",
+			},
+			{
+				name:            "NDJSON from the Accept header",
+				url:             "/api/v1/products?seller_id=3&include_deleted=true",
+				accept:          "application/x-ndjson",
+				wantContentType: "application/x-ndjson",
+				wantBody: `{"id":1,"description":"Milk","expiration_rate":1,"freezing_rate":2,"height":1.5,"length":2,"net_weight":1,"product_code":"P1","recommended_freezing_temperature":-4,"width":1,"product_type_id":1,"seller_id":3}` + "\n" +
+					`{"id":2,"description":"Cheese, brie","expiration_rate":1,"freezing_rate":2,"height":1,"length":1,"net_weight":0.5,"product_code":"P2","recommended_freezing_temperature":2,"width":1,"product_type_id":1,"seller_id":3,"deleted_at":"2023-05-01 10:00:00"}` + "\n",
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				productServiceMock := new(product_mocks.ProductServiceMock)
+				productServiceMock.On("Each", mock.Anything, options.Unpaged()).Return(rows, nil)
+				handler := products.NewProduct(productServiceMock)
+
+				r := createServer()
+				r.GET("/api/v1/products", handler.GetAll())
+
+				req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+				req.Header.Set("Accept", tt.accept)
+				res := httptest.NewRecorder()
+
+				r.ServeHTTP(res, req)
+
+				assert.Equal(t, http.StatusOK, res.Code)
+				assert.Equal(t, tt.wantContentType, res.Header().Get("Content-Type"))
+				assert.Equal(t, tt.wantBody, res.Body.String())
+				productServiceMock.AssertNotCalled(t, "GetPage", mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("getAll_invalid_format", func(t *testing.T) {
+		serviceMock := new(product_mocks.ProductServiceMock)
+		handler := products.NewProduct(serviceMock)
+
+		r := createServer()
+		r.GET("/api/v1/products", handler.GetAll())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products?format=xlsx", nil)
+		res := httptest.NewRecorder()
+
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.JSONEq(t, `{"code":"bad_request","message":"format must be json, csv or ndjson"}`, res.Body.String())
+	})
 }
 
 func TestDelete(t *testing.T) {
@@ -763,12 +878,13 @@ func TestDelete(t *testing.T) {
 
 		//Configurar o mock do service
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(product.ErrNotFound)
+		productServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(product.ErrNotFound)
 		handler := products.NewProduct(productServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.DELETE("/api/v1/products/:id", handler.Delete())
 
 		//Definir request e response
@@ -786,12 +902,13 @@ func TestDelete(t *testing.T) {
 
 		//Configurar o mock do service
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(nil)
+		productServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(nil)
 		handler := products.NewProduct(productServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.DELETE("/api/v1/products/:id", handler.Delete())
 
 		//Definir request e response
@@ -809,12 +926,13 @@ func TestDelete(t *testing.T) {
 
 		//Configurar o mock do service
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(nil)
+		productServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(nil)
 		handler := products.NewProduct(productServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.DELETE("/api/v1/products/:id", handler.Delete())
 
 		//Definir request e response
@@ -832,12 +950,13 @@ func TestDelete(t *testing.T) {
 
 		//Configurar o mock do service
 		productServiceMock := new(product_mocks.ProductServiceMock)
-		productServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(assert.AnError)
+		productServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(assert.AnError)
 		handler := products.NewProduct(productServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.DELETE("/api/v1/products/:id", handler.Delete())
 
 		//Definir request e response
@@ -896,6 +1015,7 @@ func TestUpdate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/products/:id", handler.Update())
 
 		requestBody, _ := json.Marshal(updateProductRequest)
@@ -964,6 +1084,7 @@ func TestUpdate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/products/:id", handler.Update())
 
 		requestBody, _ := json.Marshal(updateProductRequest)
@@ -1032,6 +1153,7 @@ func TestUpdate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/products/:id", handler.Update())
 
 		requestBody, _ := json.Marshal(updateProductRequest)
@@ -1100,6 +1222,7 @@ func TestUpdate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/products/:id", handler.Update())
 
 		requestBody, _ := json.Marshal(updateProductRequest)
@@ -1184,6 +1307,7 @@ func TestUpdate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/products/:id", handler.Update())
 
 		requestBody, _ := json.Marshal(updateProductRequest)
@@ -1268,5 +1392,132 @@ func createServer() *gin.Engine {
 	//Configurar o servidor
 	gin.SetMode(gin.TestMode)
 	server := gin.Default()
+	server.Use(middlewares.Errors())
 	return server
 }
+
+func TestDeleteDependents(t *testing.T) {
+	t.Run("delete_has_dependents", func(t *testing.T) {
+		serviceMock := new(product_mocks.ProductServiceMock)
+		serviceMock.On("Delete", mock.Anything, 1).Return(&database.DependentsError{Dependents: map[string]int{"product_batches": 1}})
+		handler := products.NewProduct(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.DELETE("/api/v1/products/:id", handler.Delete())
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/products/1", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusConflict, res.Code)
+		assert.JSONEq(t, `{"code":"conflict","message":"it is referenced by other records: product_batches (1)","details":{"product_batches":1}}`, res.Body.String())
+	})
+
+	t.Run("delete_dry_run", func(t *testing.T) {
+		serviceMock := new(product_mocks.ProductServiceMock)
+		serviceMock.On("DeletePreview", mock.Anything, 1).Return(&domain.DeletePreview{ID: 1, Dependents: map[string]int{"product_batches": 1}, Deletable: false}, nil)
+		handler := products.NewProduct(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.DELETE("/api/v1/products/:id", handler.Delete())
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/products/1?dry_run=true", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"data":{"id":1,"dependents":{"product_batches":1},"deletable":false}}`, res.Body.String())
+	})
+
+	t.Run("delete_dry_run_non_existent", func(t *testing.T) {
+		serviceMock := new(product_mocks.ProductServiceMock)
+		serviceMock.On("DeletePreview", mock.Anything, 1).Return((*domain.DeletePreview)(nil), product.ErrNotFound)
+		handler := products.NewProduct(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.DELETE("/api/v1/products/:id", handler.Delete())
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/products/1?dry_run=true", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("delete_invalid_dry_run", func(t *testing.T) {
+		serviceMock := new(product_mocks.ProductServiceMock)
+		handler := products.NewProduct(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.DELETE("/api/v1/products/:id", handler.Delete())
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/products/1?dry_run=maybe", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
+
+func TestRestore(t *testing.T) {
+	t.Run("restore_ok", func(t *testing.T) {
+		serviceMock := new(product_mocks.ProductServiceMock)
+		serviceMock.On("Restore", mock.Anything, 1).Return(&domain.Product{ID: 1, ProductCode: "P1", SellerID: 2}, nil)
+		handler := products.NewProduct(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/products/:id/restore", handler.Restore())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/products/1/restore", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"data":{"id":1,"description":"","expiration_rate":0,"freezing_rate":0,"height":0,"length":0,"net_weight":0,"product_code":"P1","recommended_freezing_temperature":0,"width":0,"product_type_id":0,"seller_id":2}}`, res.Body.String())
+	})
+
+	t.Run("restore_not_deleted", func(t *testing.T) {
+		serviceMock := new(product_mocks.ProductServiceMock)
+		serviceMock.On("Restore", mock.Anything, 1).Return((*domain.Product)(nil), product.ErrNotFound)
+		handler := products.NewProduct(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/products/:id/restore", handler.Restore())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/products/1/restore", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("restore_invalid_id", func(t *testing.T) {
+		serviceMock := new(product_mocks.ProductServiceMock)
+		serviceMock.On("Restore", mock.Anything, 1).Return((*domain.Product)(nil), product.ErrNotFound)
+		handler := products.NewProduct(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/products/:id/restore", handler.Restore())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/products/xyz/restore", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		serviceMock.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+}
@@ -1,30 +1,33 @@
 package productsRecords
 
 import (
-	"fmt"
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/product"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/productRecord"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/export"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
 	"github.com/gin-gonic/gin"
 )
 
 type RequestCreateProductRecord struct {
-	LastUpdateDate string  `json:"last_update_date"`
-	PurchasePrice  float32 `json:"purchase_price"`
-	SalePrice      float32 `json:"sale_price"`
-	ProductId      int     `json:"product_id"`
+	LastUpdateDate string  `json:"last_update_date" binding:"required,date"`
+	PurchasePrice  float32 `json:"purchase_price" binding:"required,gt=0"`
+	SalePrice      float32 `json:"sale_price" binding:"required,gt=0"`
+	ProductId      int     `json:"product_id" binding:"required,gt=0"`
 }
 
 type RequestUpdateProductRecord struct {
-	LastUpdateDate *string  `json:"last_update_date"`
-	PurchasePrice  *float32 `json:"purchase_price"`
-	SalePrice      *float32 `json:"sale_price"`
-	ProductId      *int     `json:"product_id"`
+	LastUpdateDate *string  `json:"last_update_date" binding:"omitempty,date"`
+	PurchasePrice  *float32 `json:"purchase_price" binding:"omitempty,gt=0"`
+	SalePrice      *float32 `json:"sale_price" binding:"omitempty,gt=0"`
+	ProductId      *int     `json:"product_id" binding:"omitempty,gt=0"`
 }
 
 type ProductRecord struct {
@@ -45,21 +48,47 @@ func NewProductRecord(p productRecord.Service, ps product.Service) *ProductRecor
 //	@Tags			ProductsRecords
 //	@LastUpdateDate	getAll productsRecords
 //	@Accept			json
-//	@Produce		json
-//	@Success		200	{object}	web.response
+//	@Produce		json,text/csv,application/x-ndjson
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			offset	query		int		false	"Number of productsRecords to skip"
+//	@Param			sort	query		string	false	"Field to sort by"
+//	@Param			order	query		string	false	"asc or desc"
+//	@Param			format	query		string	false	"json, csv or ndjson, or use the Accept header"
+//	@Success		200		{object}	web.response
 //	@Router			/api/v1/productsRecords [get]
 func (p *ProductRecord) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		options, err := listing.Parse(c.Request.URL.Query(), productRecord.Fields)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if format != export.JSON {
+			web.Export(c, format, domain.ProductRecord{}, func(write func(row interface{}) error) error {
+				return p.productRecordService.Each(c.Request.Context(), options.Unpaged(), func(row domain.ProductRecord) error {
+					return write(row)
+				})
+			})
+			return
+		}
+
 		ctx := c.Request.Context()
-		productsRecords, err := p.productRecordService.GetAll(&ctx)
+		productsRecords, total, err := p.productRecordService.GetPage(ctx, options)
 		if err != nil {
-			web.Error(c, http.StatusInternalServerError, err.Error())
+			_ = c.Error(err)
 			return
 		}
 		if len(*productsRecords) == 0 {
 			web.Success(c, http.StatusNoContent, nil)
+			return
 		}
-		web.Success(c, http.StatusOK, productsRecords)
+		web.Page(c, http.StatusOK, productsRecords, total, options)
 	}
 }
 
@@ -82,14 +111,9 @@ func (p *ProductRecord) Get() gin.HandlerFunc {
 			return
 		}
 		ctx := c.Request.Context()
-		productRecordResponse, err := p.productRecordService.Get(&ctx, int(id))
+		productRecordResponse, err := p.productRecordService.Get(ctx, int(id))
 		if err != nil {
-			switch err {
-			case productRecord.ErrNotFound:
-				web.Error(c, http.StatusNotFound, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, fmt.Sprintf("error getting productRecord %s", err.Error()))
-			}
+			_ = c.Error(err)
 			return
 		}
 		web.Success(c, http.StatusOK, productRecordResponse)
@@ -111,39 +135,14 @@ func (p *ProductRecord) Create() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req RequestCreateProductRecord
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, err.Error())
-			return
-		}
-
-		if req.LastUpdateDate == "" {
-			web.Error(c, http.StatusUnprocessableEntity, "The field LastUpdateDate is required.")
-			return
-		}
-
-		if req.PurchasePrice == 0 {
-			web.Error(c, http.StatusUnprocessableEntity, "The field PurchasePrice is required.")
-			return
-		}
-
-		if req.SalePrice == 0 {
-			web.Error(c, http.StatusUnprocessableEntity, "The field SalePrice is required.")
-			return
-		}
-
-		if req.ProductId == 0 {
-			web.Error(c, http.StatusUnprocessableEntity, "The field ProductId is required.")
+			web.ValidationError(c, err)
 			return
 		}
 
 		ctx := c.Request.Context()
-		productRecordResponse, err := p.productRecordService.Save(&ctx, req.LastUpdateDate, req.PurchasePrice, req.SalePrice, req.ProductId)
+		productRecordResponse, err := p.productRecordService.Save(ctx, req.LastUpdateDate, req.PurchasePrice, req.SalePrice, req.ProductId)
 		if err != nil {
-			switch err {
-			case productRecord.ErrConflict:
-				web.Error(c, http.StatusConflict, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, fmt.Sprintf("error saving request %s", err.Error()))
-			}
+			_ = c.Error(err)
 			return
 		}
 		web.Success(c, http.StatusCreated, productRecordResponse)
@@ -171,20 +170,13 @@ func (p *ProductRecord) Update() gin.HandlerFunc {
 		}
 		var req RequestUpdateProductRecord
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, err.Error())
+			web.ValidationError(c, err)
 			return
 		}
 		ctx := c.Request.Context()
-		productRecordResponse, err := p.productRecordService.Update(&ctx, req.LastUpdateDate, req.PurchasePrice, req.SalePrice, req.ProductId, id)
+		productRecordResponse, err := p.productRecordService.Update(ctx, req.LastUpdateDate, req.PurchasePrice, req.SalePrice, req.ProductId, id)
 		if err != nil {
-			switch err {
-			case productRecord.ErrNotFound:
-				web.Error(c, http.StatusNotFound, err.Error())
-			case productRecord.ErrConflict:
-				web.Error(c, http.StatusConflict, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, fmt.Sprintf("error updating productRecord %s", err.Error()))
-			}
+			_ = c.Error(err)
 			return
 		}
 		web.Success(c, http.StatusOK, productRecordResponse)
@@ -210,14 +202,9 @@ func (p *ProductRecord) Delete() gin.HandlerFunc {
 			return
 		}
 		ctx := c.Request.Context()
-		err = p.productRecordService.Delete(&ctx, int(id))
+		err = p.productRecordService.Delete(ctx, int(id))
 		if err != nil {
-			switch err {
-			case productRecord.ErrNotFound:
-				web.Error(c, http.StatusNotFound, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, fmt.Sprintf("error deleting productRecord %s", err.Error()))
-			}
+			_ = c.Error(err)
 			return
 		}
 
@@ -229,6 +216,11 @@ func (p *ProductRecord) NumberRecords() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		queryIds := c.Query("id")
 		ctx := c.Request.Context()
+		format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
 		var responses = []dtos.GetNumberOfRecordsResponseDTO{}
 
 		if queryIds != "" {
@@ -241,16 +233,16 @@ func (p *ProductRecord) NumberRecords() gin.HandlerFunc {
 					web.Error(c, http.StatusBadRequest, "Invalid ID: %s", err.Error())
 					return
 				}
-				productReceived, err := p.productService.Get(&ctx, id)
-				if err != nil && err != product.ErrNotFound {
-					web.Error(c, http.StatusInternalServerError, err.Error())
+				productReceived, err := p.productService.Get(ctx, id)
+				if err != nil && !errors.Is(err, product.ErrNotFound) {
+					_ = c.Error(err)
 					return
 				}
 				if productReceived != nil {
 
-					count, err := p.productRecordService.NumberRecords(&ctx, id)
+					count, err := p.productRecordService.NumberRecords(ctx, id)
 					if err != nil {
-						web.Error(c, http.StatusInternalServerError, err.Error())
+						_ = c.Error(err)
 						return
 					}
 
@@ -265,15 +257,15 @@ func (p *ProductRecord) NumberRecords() gin.HandlerFunc {
 				}
 			}
 		} else {
-			products, err := p.productService.GetAll(&ctx)
-			if err != nil && err != product.ErrNotFound {
-				web.Error(c, http.StatusInternalServerError, err.Error())
+			products, err := p.productService.GetAll(ctx)
+			if err != nil && !errors.Is(err, product.ErrNotFound) {
+				_ = c.Error(err)
 				return
 			}
 			for _, product := range *products {
-				count, err := p.productRecordService.NumberRecords(&ctx, product.ID)
+				count, err := p.productRecordService.NumberRecords(ctx, product.ID)
 				if err != nil {
-					web.Error(c, http.StatusInternalServerError, err.Error())
+					_ = c.Error(err)
 					return
 				}
 				productResponse := dtos.GetNumberOfRecordsResponseDTO{
@@ -286,7 +278,7 @@ func (p *ProductRecord) NumberRecords() gin.HandlerFunc {
 			}
 		}
 
-		web.Success(c, http.StatusOK, responses)
+		web.Report(c, format, responses, dtos.GetNumberOfRecordsResponseDTO{})
 		return
 
 	}
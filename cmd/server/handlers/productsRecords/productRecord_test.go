@@ -12,6 +12,7 @@ import (
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/products"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/productsRecords"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	mocks2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/product/product_mocks"
@@ -28,7 +29,7 @@ func TestGet(t *testing.T) {
 		// Definir resultado da consulta
 		productRecordFound := &domain.ProductRecord{
 			ID:             1,
-			LastUpdateDate: "Test",
+			LastUpdateDate: "2023-07-05",
 			PurchasePrice:  1.1,
 			SalePrice:      1.1,
 			ProductId:      1,
@@ -36,13 +37,14 @@ func TestGet(t *testing.T) {
 
 		//Configurar o mock do service
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(productRecordFound, nil)
+		productRecordServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(productRecordFound, nil)
 		productServiceMock := new(mocks2.ProductServiceMock)
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/productsRecords/:id", handler.Get())
 
 		//Definir request e response
@@ -72,12 +74,13 @@ func TestGet(t *testing.T) {
 
 		//Configurar o mock do service
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(&domain.ProductRecord{}, productRecord.ErrNotFound)
+		productRecordServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(&domain.ProductRecord{}, productRecord.ErrNotFound)
 		productServiceMock := new(mocks2.ProductServiceMock)
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/productsRecords/:id", handler.Get())
 
 		//Definir request e response
@@ -95,12 +98,13 @@ func TestGet(t *testing.T) {
 
 		//Configurar o mock do service
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(&domain.ProductRecord{}, productRecord.ErrNotFound)
+		productRecordServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(&domain.ProductRecord{}, productRecord.ErrNotFound)
 		productServiceMock := new(mocks2.ProductServiceMock)
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/productsRecords/:id", handler.Get())
 
 		//Definir request e response
@@ -118,13 +122,14 @@ func TestGet(t *testing.T) {
 
 		//Configurar o mock do service
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("Get", mock.AnythingOfType("*context.Context"),
+		productRecordServiceMock.On("Get", mock.Anything,
 			mock.AnythingOfType("int")).Return(&domain.ProductRecord{}, assert.AnError)
 		productServiceMock := new(mocks2.ProductServiceMock)
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/productsRecords/:id", handler.Get())
 
 		//Definir request e response
@@ -146,13 +151,13 @@ func TestCreate(t *testing.T) {
 		// Definir resultado da consulta
 		expectedProductRecord := &domain.ProductRecord{
 			ID:             1,
-			LastUpdateDate: "Test",
+			LastUpdateDate: "2023-07-05",
 			PurchasePrice:  1.1,
 			SalePrice:      1.1,
 			ProductId:      1,
 		}
 		createProductRecordRequestDTO := productsRecords.RequestCreateProductRecord{
-			LastUpdateDate: "Test",
+			LastUpdateDate: "2023-07-05",
 			PurchasePrice:  1.1,
 			SalePrice:      1.1,
 			ProductId:      1,
@@ -160,12 +165,13 @@ func TestCreate(t *testing.T) {
 
 		//Configurar o mock do service
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("string"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("int")).Return(expectedProductRecord, nil)
+		productRecordServiceMock.On("Save", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("float32"), mock.AnythingOfType("float32"), mock.AnythingOfType("int")).Return(expectedProductRecord, nil)
 		productServiceMock := new(mocks2.ProductServiceMock)
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/productsRecords", handler.Create())
 
 		requestBody, _ := json.Marshal(createProductRecordRequestDTO)
@@ -196,18 +202,19 @@ func TestCreate(t *testing.T) {
 
 	t.Run("create_fail", func(t *testing.T) {
 		createProductRecordRequestDTO := productsRecords.RequestCreateProductRecord{
-			LastUpdateDate: "Test",
+			LastUpdateDate: "2023-07-05",
 			PurchasePrice:  1.1,
 			SalePrice:      1.1,
 			ProductId:      1,
 		}
 
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("Create", mock.AnythingOfType("*context.Context")).Return(createProductRecordRequestDTO, productRecord.ErrConflict)
+		productRecordServiceMock.On("Create", mock.Anything).Return(createProductRecordRequestDTO, productRecord.ErrConflict)
 		productServiceMock := new(mocks2.ProductServiceMock)
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/productsRecords", handler.Create())
 
 		req := httptest.NewRequest(http.MethodPost, "/api/v1/productsRecords", nil)
@@ -222,13 +229,14 @@ func TestCreate(t *testing.T) {
 		createProductRecordRequestDTO := buildProductRecordRequestDTO("", 2.2, 2.2, 2)
 
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("string"), mock.AnythingOfType("float32"),
+		productRecordServiceMock.On("Save", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("float32"),
 			mock.AnythingOfType("float32"), mock.AnythingOfType("int")).Return(&domain.ProductRecord{}, productRecord.ErrConflict)
 		productServiceMock := new(mocks2.ProductServiceMock)
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/productsRecords", handler.Create())
 
 		requestBody, _ := json.Marshal(createProductRecordRequestDTO)
@@ -249,13 +257,14 @@ func TestCreate(t *testing.T) {
 		createProductRecordRequestDTO := buildProductRecordRequestDTO("teste", 0, 2.2, 2)
 
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("string"), mock.AnythingOfType("float32"),
+		productRecordServiceMock.On("Save", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("float32"),
 			mock.AnythingOfType("float32"), mock.AnythingOfType("int")).Return(&domain.ProductRecord{}, productRecord.ErrConflict)
 		productServiceMock := new(mocks2.ProductServiceMock)
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/productsRecords", handler.Create())
 
 		requestBody, _ := json.Marshal(createProductRecordRequestDTO)
@@ -276,13 +285,14 @@ func TestCreate(t *testing.T) {
 		createProductRecordRequestDTO := buildProductRecordRequestDTO("teste", 2.2, 0, 2)
 
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("string"), mock.AnythingOfType("float32"),
+		productRecordServiceMock.On("Save", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("float32"),
 			mock.AnythingOfType("float32"), mock.AnythingOfType("int")).Return(&domain.ProductRecord{}, productRecord.ErrConflict)
 		productServiceMock := new(mocks2.ProductServiceMock)
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/productsRecords", handler.Create())
 
 		requestBody, _ := json.Marshal(createProductRecordRequestDTO)
@@ -303,13 +313,14 @@ func TestCreate(t *testing.T) {
 		createProductRecordRequestDTO := buildProductRecordRequestDTO("teste", 2.2, 2.2, 0)
 
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("string"), mock.AnythingOfType("float32"),
+		productRecordServiceMock.On("Save", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("float32"),
 			mock.AnythingOfType("float32"), mock.AnythingOfType("int")).Return(&domain.ProductRecord{}, productRecord.ErrConflict)
 		productServiceMock := new(mocks2.ProductServiceMock)
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/productsRecords", handler.Create())
 
 		requestBody, _ := json.Marshal(createProductRecordRequestDTO)
@@ -328,20 +339,21 @@ func TestCreate(t *testing.T) {
 	t.Run("create_conflict", func(t *testing.T) {
 		// Definir resultado da consulta
 		createProductRecordRequestDTO := productsRecords.RequestCreateProductRecord{
-			LastUpdateDate: "Test",
+			LastUpdateDate: "2023-07-05",
 			PurchasePrice:  1.1,
 			SalePrice:      1.1,
 			ProductId:      1,
 		}
 
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("string"), mock.AnythingOfType("float32"),
+		productRecordServiceMock.On("Save", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("float32"),
 			mock.AnythingOfType("float32"), mock.AnythingOfType("int")).Return(&domain.ProductRecord{}, productRecord.ErrConflict)
 		productServiceMock := new(mocks2.ProductServiceMock)
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/productsRecords", handler.Create())
 
 		requestBody, _ := json.Marshal(createProductRecordRequestDTO)
@@ -361,20 +373,21 @@ func TestCreate(t *testing.T) {
 	t.Run("create_internal_server_error", func(t *testing.T) {
 		// Definir resultado da consulta
 		createProductRecordRequestDTO := productsRecords.RequestCreateProductRecord{
-			LastUpdateDate: "Test",
+			LastUpdateDate: "2023-07-05",
 			PurchasePrice:  1.1,
 			SalePrice:      1.1,
 			ProductId:      1,
 		}
 
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("string"), mock.AnythingOfType("float32"),
+		productRecordServiceMock.On("Save", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("float32"),
 			mock.AnythingOfType("float32"), mock.AnythingOfType("int")).Return(&domain.ProductRecord{}, errors.New("error"))
 		productServiceMock := new(mocks2.ProductServiceMock)
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/productsRecords", handler.Create())
 
 		requestBody, _ := json.Marshal(createProductRecordRequestDTO)
@@ -398,13 +411,13 @@ func TestGetAll(t *testing.T) {
 		productsRecordsFounds := &[]domain.ProductRecord{
 			{
 				ID:             1,
-				LastUpdateDate: "Test",
+				LastUpdateDate: "2023-07-05",
 				PurchasePrice:  1.1,
 				SalePrice:      1.1,
 				ProductId:      1,
 			},
 			{
-				LastUpdateDate: "Test",
+				LastUpdateDate: "2023-07-05",
 				PurchasePrice:  1.1,
 				SalePrice:      1.1,
 				ProductId:      1,
@@ -412,12 +425,13 @@ func TestGetAll(t *testing.T) {
 		}
 		//Configurar o mock do service
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(productsRecordsFounds, nil)
+		productRecordServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(productsRecordsFounds, len(*productsRecordsFounds), nil)
 		productServiceMock := new(mocks2.ProductServiceMock)
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/productsRecords", handler.GetAll())
 
 		//Definir request e response
@@ -446,12 +460,13 @@ func TestGetAll(t *testing.T) {
 		productsRecordsFounds := &[]domain.ProductRecord{}
 		//Configurar o mock do service
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(productsRecordsFounds, nil)
+		productRecordServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(productsRecordsFounds, len(*productsRecordsFounds), nil)
 		productServiceMock := new(mocks2.ProductServiceMock)
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/productsRecords", handler.GetAll())
 
 		//Definir request e response
@@ -469,12 +484,13 @@ func TestGetAll(t *testing.T) {
 		productsRecordsFounds := &[]domain.ProductRecord{}
 		//Configurar o mock do service
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(productsRecordsFounds, assert.AnError)
+		productRecordServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(productsRecordsFounds, 0, assert.AnError)
 		productServiceMock := new(mocks2.ProductServiceMock)
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/productsRecords", handler.GetAll())
 
 		//Definir request e response
@@ -487,6 +503,24 @@ func TestGetAll(t *testing.T) {
 		//Validar resultado
 		assert.Equal(t, http.StatusInternalServerError, res.Code)
 	})
+
+	t.Run("getAll_invalid_query", func(t *testing.T) {
+		serviceMock := new(mocks.ProductRecordServiceMock)
+		handler := productsRecords.NewProductRecord(serviceMock, new(mocks2.ProductServiceMock))
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/productsRecords", handler.GetAll())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/productsRecords?limit=0", nil)
+		res := httptest.NewRecorder()
+
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		serviceMock.AssertNotCalled(t, "GetPage", mock.Anything, mock.Anything)
+	})
 }
 
 func TestDelete(t *testing.T) {
@@ -495,12 +529,13 @@ func TestDelete(t *testing.T) {
 
 		//Configurar o mock do service
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(productRecord.ErrNotFound)
+		productRecordServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(productRecord.ErrNotFound)
 		productServiceMock := new(mocks2.ProductServiceMock)
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.DELETE("/api/v1/productsRecords/:id", handler.Delete())
 
 		//Definir request e response
@@ -518,12 +553,13 @@ func TestDelete(t *testing.T) {
 
 		//Configurar o mock do service
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(nil)
+		productRecordServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(nil)
 		productServiceMock := new(mocks2.ProductServiceMock)
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.DELETE("/api/v1/productsRecords/:id", handler.Delete())
 
 		//Definir request e response
@@ -541,12 +577,13 @@ func TestDelete(t *testing.T) {
 
 		//Configurar o mock do service
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(nil)
+		productRecordServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(nil)
 		productServiceMock := new(mocks2.ProductServiceMock)
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.DELETE("/api/v1/productsRecords/:id", handler.Delete())
 
 		//Definir request e response
@@ -564,12 +601,13 @@ func TestDelete(t *testing.T) {
 
 		//Configurar o mock do service
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(assert.AnError)
+		productRecordServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(assert.AnError)
 		productServiceMock := new(mocks2.ProductServiceMock)
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.DELETE("/api/v1/productsRecords/:id", handler.Delete())
 
 		//Definir request e response'
@@ -587,7 +625,7 @@ func TestDelete(t *testing.T) {
 func TestUpdate(t *testing.T) {
 
 	t.Run("update_non_existent", func(t *testing.T) {
-		lastUpdateDate := "teste2"
+		lastUpdateDate := "2023-07-06"
 		var purchasePrice float32 = 2.2
 		var salePrice float32 = 2.2
 		productID := 2
@@ -612,6 +650,7 @@ func TestUpdate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/productsRecords/:id", handler.Update())
 
 		requestBody, _ := json.Marshal(updateProductRecordRequest)
@@ -639,7 +678,7 @@ func TestUpdate(t *testing.T) {
 
 	t.Run("update_conflict", func(t *testing.T) {
 
-		lastUpdateDate := "teste2"
+		lastUpdateDate := "2023-07-06"
 		var purchasePrice float32 = 2.2
 		var salePrice float32 = 2.2
 		productID := 2
@@ -664,6 +703,7 @@ func TestUpdate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/productsRecords/:id", handler.Update())
 
 		requestBody, _ := json.Marshal(updateProductRecordRequest)
@@ -691,7 +731,7 @@ func TestUpdate(t *testing.T) {
 
 	t.Run("update_internal_server_error", func(t *testing.T) {
 
-		lastUpdateDate := "teste2"
+		lastUpdateDate := "2023-07-06"
 		var purchasePrice float32 = 2.2
 		var salePrice float32 = 2.2
 		productID := 2
@@ -716,6 +756,7 @@ func TestUpdate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/productsRecords/:id", handler.Update())
 
 		requestBody, _ := json.Marshal(updateProductRecordRequest)
@@ -742,7 +783,7 @@ func TestUpdate(t *testing.T) {
 	})
 	t.Run("update_id_conversion_error", func(t *testing.T) {
 
-		lastUpdateDate := "teste2"
+		lastUpdateDate := "2023-07-06"
 		var purchasePrice float32 = 2.2
 		var salePrice float32 = 2.2
 		productID := 2
@@ -767,6 +808,7 @@ func TestUpdate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/productsRecords/:id", handler.Update())
 
 		requestBody, _ := json.Marshal(updateProductRecordRequest)
@@ -796,7 +838,7 @@ func TestUpdate(t *testing.T) {
 
 		//productID := 1
 
-		lastUpdateDate := "teste2"
+		lastUpdateDate := "2023-07-06"
 		var purchasePrice float32 = 2.2
 		var salePrice float32 = 2.2
 		productID := 2
@@ -809,7 +851,7 @@ func TestUpdate(t *testing.T) {
 		}
 		updatedProductRecord := &domain.ProductRecord{
 			ID:             1,
-			LastUpdateDate: "Teste2",
+			LastUpdateDate: "2023-07-06",
 			PurchasePrice:  2,
 			SalePrice:      2,
 			ProductId:      2,
@@ -828,6 +870,7 @@ func TestUpdate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/productsRecords/:id", handler.Update())
 
 		requestBody, _ := json.Marshal(updateProductRecordRequest)
@@ -930,17 +973,18 @@ func TestNumberRecords(t *testing.T) {
 		}
 		//Configurar o mock do service
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("NumberRecords", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(1, nil).Return(1, nil)
+		productRecordServiceMock.On("NumberRecords", mock.Anything, mock.AnythingOfType("int")).Return(1, nil).Return(1, nil)
 
 		productServiceMock := new(mocks2.ProductServiceMock)
 
-		productServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(product1Found, nil).Once()
-		productServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(product2Found, nil).Once()
+		productServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(product1Found, nil).Once()
+		productServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(product2Found, nil).Once()
 
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/productsRecords", handler.NumberRecords())
 
 		//Definir request e response
@@ -1012,16 +1056,17 @@ func TestNumberRecords(t *testing.T) {
 
 		//Configurar o mock do service
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("NumberRecords", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(1, nil).Return(1, nil)
+		productRecordServiceMock.On("NumberRecords", mock.Anything, mock.AnythingOfType("int")).Return(1, nil).Return(1, nil)
 
 		productServiceMock := new(mocks2.ProductServiceMock)
 
-		productServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(productsFound, nil)
+		productServiceMock.On("GetAll", mock.Anything).Return(productsFound, nil)
 
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/productsRecords", handler.NumberRecords())
 
 		//Definir request e response
@@ -1057,6 +1102,7 @@ func TestNumberRecords(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/productsRecords", handler.NumberRecords())
 
 		//Definir request e response
@@ -1085,12 +1131,13 @@ func TestNumberRecords(t *testing.T) {
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
 
 		productServiceMock := new(mocks2.ProductServiceMock)
-		productServiceMock.On("Get", mock.AnythingOfType("*context.Context"),
+		productServiceMock.On("Get", mock.Anything,
 			mock.AnythingOfType("int")).Return(&domain.Product{}, assert.AnError)
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/productsRecords", handler.NumberRecords())
 
 		//Definir request e response
@@ -1130,15 +1177,16 @@ func TestNumberRecords(t *testing.T) {
 		}
 		//Configurar o mock do service
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("NumberRecords", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(1, assert.AnError)
+		productRecordServiceMock.On("NumberRecords", mock.Anything, mock.AnythingOfType("int")).Return(1, assert.AnError)
 
 		productServiceMock := new(mocks2.ProductServiceMock)
-		productServiceMock.On("Get", mock.AnythingOfType("*context.Context"),
+		productServiceMock.On("Get", mock.Anything,
 			mock.AnythingOfType("int")).Return(productFound, nil)
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/productsRecords", handler.NumberRecords())
 
 		//Definir request e response
@@ -1168,12 +1216,13 @@ func TestNumberRecords(t *testing.T) {
 
 		productServiceMock := new(mocks2.ProductServiceMock)
 
-		productServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(&[]domain.Product{}, assert.AnError)
+		productServiceMock.On("GetAll", mock.Anything).Return(&[]domain.Product{}, assert.AnError)
 
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/productsRecords", handler.NumberRecords())
 
 		//Definir request e response
@@ -1231,16 +1280,17 @@ func TestNumberRecords(t *testing.T) {
 
 		//Configurar o mock do service
 		productRecordServiceMock := new(mocks.ProductRecordServiceMock)
-		productRecordServiceMock.On("NumberRecords", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(0, assert.AnError)
+		productRecordServiceMock.On("NumberRecords", mock.Anything, mock.AnythingOfType("int")).Return(0, assert.AnError)
 
 		productServiceMock := new(mocks2.ProductServiceMock)
 
-		productServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(productsFound, nil)
+		productServiceMock.On("GetAll", mock.Anything).Return(productsFound, nil)
 
 		handler := productsRecords.NewProductRecord(productRecordServiceMock, productServiceMock)
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/productsRecords", handler.NumberRecords())
 
 		//Definir request e response
@@ -1303,5 +1353,6 @@ func createServer() *gin.Engine {
 	//Configurar o servidor
 	gin.SetMode(gin.TestMode)
 	server := gin.Default()
+	server.Use(middlewares.Errors())
 	return server
 }
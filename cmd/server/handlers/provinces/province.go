@@ -0,0 +1,221 @@
+package provinces
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/province"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProvinceHandler struct {
+	provinceService province.ProvinceService
+}
+
+func NewProvinceHandler(provinceService province.ProvinceService) *ProvinceHandler {
+	return &ProvinceHandler{
+		provinceService,
+	}
+}
+
+// Get is the handler to search for a province and return their details.
+//
+//	@Summary		Get Province
+//	@Tags			Provinces
+//	@Description	Get the details of a Province
+//	@Produce		json
+//	@Param			id	path		string	true	"ID of Province to be searched"
+//	@Success		200	{object}	domain.Province
+//	@Failure		400	{object}	web.errorResponse
+//	@Failure		404	{object}	web.errorResponse
+//	@Failure		500	{object}	web.errorResponse
+//	@Router			/api/v1/provinces/{id} [get]
+func (handler *ProvinceHandler) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		id, err := getIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ctx := c.Request.Context()
+		if provinceFound, err := handler.provinceService.Get(ctx, id); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Response(c, http.StatusOK, provinceFound)
+			return
+		}
+	}
+}
+
+// GetAll is the handler to search for all provinces, optionally filtered by country.
+//
+//	@Summary		List Provinces
+//	@Tags			Provinces
+//	@Description	Get the details of all provinces on the database.
+//	@Produce		json
+//	@Param			country_id	query		string	false	"ID of the Country to filter by"
+//	@Success		200			{object}	web.response{data=[]domain.Province}
+//	@Failure		400			{object}	web.errorResponse
+//	@Failure		404			{object}	web.errorResponse
+//	@Failure		500			{object}	web.errorResponse
+//	@Router			/api/v1/provinces [get]
+func (handler *ProvinceHandler) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		ctx := c.Request.Context()
+
+		var provinces []domain.Province
+		var err error
+		if c.Query("country_id") != "" {
+			countryID, convErr := strconv.Atoi(c.Query("country_id"))
+			if convErr != nil {
+				web.Error(c, http.StatusBadRequest, fmt.Sprintf("Invalid country_id on request: %s", c.Request.RequestURI))
+				return
+			}
+			provinces, err = handler.provinceService.GetAllByCountryID(ctx, countryID)
+		} else {
+			provinces, err = handler.provinceService.GetAll(ctx)
+		}
+
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		if len(provinces) == 0 {
+			web.Success(c, http.StatusNoContent, provinces)
+			return
+		}
+		web.Success(c, http.StatusOK, provinces)
+	}
+}
+
+// Create is the handler to create a province.
+//
+//	@Summary		Create Province
+//	@Tags			Provinces
+//	@Description	Save a province on the database.
+//	@Accept			json
+//	@Produce		json
+//	@Param			Province	body		domain.Province	true	"Province to Create"
+//	@Success		201		{object}	domain.Province
+//	@Failure		404		{object}	web.errorResponse
+//	@Failure		409		{object}	web.errorResponse
+//	@Failure		422		{object}	web.errorResponse
+//	@Failure		500		{object}	web.errorResponse
+//	@Router			/api/v1/provinces [post]
+func (handler *ProvinceHandler) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		var createProvinceRequest domain.Province
+		if err := c.ShouldBindJSON(&createProvinceRequest); err != nil {
+			web.ValidationError(c, err)
+			return
+		}
+
+		ctx := c.Request.Context()
+		if createdProvince, err := handler.provinceService.Create(ctx, createProvinceRequest); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Response(c, http.StatusCreated, createdProvince)
+			return
+		}
+	}
+}
+
+// Update is the handler to update a province details.
+//
+//	@Summary		Update Province
+//	@Tags			Provinces
+//	@Description	Update the details of a Province
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"ID of Province to be updated"
+//	@Param			Province	body		dtos.UpdateProvinceRequestDTO	true	"Updated Province details"
+//	@Success		200		{object}	domain.Province
+//	@Failure		400		{object}	web.errorResponse
+//	@Failure		404		{object}	web.errorResponse
+//	@Failure		422		{object}	web.errorResponse
+//	@Failure		500		{object}	web.errorResponse
+//	@Router			/api/v1/provinces/{id} [patch]
+func (handler *ProvinceHandler) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		id, err := getIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var updateProvinceRequest dtos.UpdateProvinceRequestDTO
+		if err := c.ShouldBindJSON(&updateProvinceRequest); err != nil {
+			web.ValidationError(c, err)
+			return
+		}
+
+		ctx := c.Request.Context()
+		if updatedProvince, err := handler.provinceService.Update(ctx, id, updateProvinceRequest); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Response(c, http.StatusOK, updatedProvince)
+			return
+		}
+	}
+}
+
+// Delete is the handler to delete a province.
+//
+//	@Summary		Delete Province
+//	@Tags			Provinces
+//	@Description	Delete Provinces
+//	@Produce		json
+//	@Param			id	path	string	true	"ID of a Province to be excluded"
+//	@Success		204
+//	@Failure		400	{object}	web.errorResponse
+//	@Failure		404	{object}	web.errorResponse
+//	@Failure		500	{object}	web.errorResponse
+//	@Router			/api/v1/provinces/{id} [delete]
+func (handler *ProvinceHandler) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		id, err := getIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ctx := c.Request.Context()
+		if err := handler.provinceService.Delete(ctx, id); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Response(c, http.StatusNoContent, nil)
+			return
+		}
+	}
+}
+
+func getIdFromUri(c *gin.Context) (id int, err error) {
+
+	value, _ := c.Params.Get("id")
+	id, err = strconv.Atoi(value)
+
+	if err != nil {
+		err = errors.New(fmt.Sprintf("Invalid id on request: %s", c.Request.RequestURI))
+		return
+	}
+
+	return
+
+}
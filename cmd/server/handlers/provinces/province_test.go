@@ -0,0 +1,450 @@
+package provinces_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/provinces"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/province/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+
+	provinceSerialized, _ := os.ReadFile("../../../../test/resources/valid_province.json")
+	var validProvince domain.Province
+	if err := json.Unmarshal(provinceSerialized, &validProvince); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name             string
+		id               string
+		expectedGetCalls int
+		expectedGetError error
+		expectedProvince domain.Province
+		expectedCode     int
+	}{
+		{
+			name:             "Success finding province",
+			id:               "1",
+			expectedGetCalls: 1,
+			expectedProvince: validProvince,
+			expectedCode:     http.StatusOK,
+		},
+		{
+			name:             "Error finding province",
+			id:               "999",
+			expectedGetCalls: 1,
+			expectedGetError: errors.ErrNotFound,
+			expectedCode:     http.StatusNotFound,
+		},
+		{
+			name:             "Error connecting db",
+			id:               "1",
+			expectedGetCalls: 1,
+			expectedGetError: assert.AnError,
+			expectedCode:     http.StatusInternalServerError,
+		},
+		{
+			name:         "Error invalid id",
+			id:           "xyz",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			provinceServiceMock := mocks.NewMockProvinceService(t)
+			provinceServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(test.expectedProvince, test.expectedGetError)
+
+			provinceHandler := provinces.NewProvinceHandler(provinceServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.GET("/api/v1/provinces/:id", provinceHandler.Get())
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/%s", "/api/v1/provinces", test.id), nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			provinceServiceMock.AssertNumberOfCalls(t, "Get", test.expectedGetCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			if test.expectedCode == http.StatusOK {
+				body, _ := io.ReadAll(res.Body)
+				var response domain.Province
+				json.Unmarshal(body, &response)
+
+				assert.Equal(t, test.expectedProvince, response)
+			}
+		})
+	}
+}
+
+func TestGetAll(t *testing.T) {
+
+	provincesSerialized, _ := os.ReadFile("../../../../test/resources/valid_provinces.json")
+	var validProvinces []domain.Province
+	if err := json.Unmarshal(provincesSerialized, &validProvinces); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                 string
+		expectedGetAllResult []domain.Province
+		expectedGetAllError  error
+		expectedCode         int
+	}{
+		{
+			name:                 "Successfully get all provinces",
+			expectedGetAllResult: validProvinces,
+			expectedCode:         http.StatusOK,
+		},
+		{
+			name:                 "Success empty database",
+			expectedGetAllResult: []domain.Province{},
+			expectedCode:         http.StatusNoContent,
+		},
+		{
+			name:                 "Error getting all provinces",
+			expectedGetAllResult: []domain.Province{},
+			expectedGetAllError:  assert.AnError,
+			expectedCode:         http.StatusInternalServerError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			provinceServiceMock := mocks.NewMockProvinceService(t)
+			provinceServiceMock.On("GetAll", mock.Anything).Return(test.expectedGetAllResult, test.expectedGetAllError)
+
+			provinceHandler := provinces.NewProvinceHandler(provinceServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.GET("/api/v1/provinces", provinceHandler.GetAll())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/provinces", nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			provinceServiceMock.AssertNumberOfCalls(t, "GetAll", 1)
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			if test.expectedCode == http.StatusOK {
+				body, _ := io.ReadAll(res.Body)
+				var responseDTO struct {
+					Data []domain.Province `json:"data"`
+				}
+				json.Unmarshal(body, &responseDTO)
+
+				assert.Equal(t, test.expectedGetAllResult, responseDTO.Data)
+			}
+		})
+	}
+}
+
+func TestGetAllByCountryID(t *testing.T) {
+
+	provincesSerialized, _ := os.ReadFile("../../../../test/resources/valid_provinces.json")
+	var validProvinces []domain.Province
+	if err := json.Unmarshal(provincesSerialized, &validProvinces); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                 string
+		query                string
+		expectedGetAllResult []domain.Province
+		expectedGetAllError  error
+		expectedGetAllCalls  int
+		expectedCode         int
+	}{
+		{
+			name:                 "Successfully filter by country",
+			query:                "?country_id=1",
+			expectedGetAllResult: validProvinces[:1],
+			expectedGetAllCalls:  1,
+			expectedCode:         http.StatusOK,
+		},
+		{
+			name:                 "Error country not found",
+			query:                "?country_id=999",
+			expectedGetAllResult: []domain.Province{},
+			expectedGetAllError:  errors.ErrNotFound,
+			expectedGetAllCalls:  1,
+			expectedCode:         http.StatusNotFound,
+		},
+		{
+			name:         "Error invalid country id",
+			query:        "?country_id=xyz",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			provinceServiceMock := mocks.NewMockProvinceService(t)
+			provinceServiceMock.On("GetAllByCountryID", mock.Anything, mock.AnythingOfType("int")).Return(test.expectedGetAllResult, test.expectedGetAllError)
+
+			provinceHandler := provinces.NewProvinceHandler(provinceServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.GET("/api/v1/provinces", provinceHandler.GetAll())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/provinces"+test.query, nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			provinceServiceMock.AssertNumberOfCalls(t, "GetAllByCountryID", test.expectedGetAllCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			if test.expectedCode == http.StatusOK {
+				body, _ := io.ReadAll(res.Body)
+				var responseDTO struct {
+					Data []domain.Province `json:"data"`
+				}
+				json.Unmarshal(body, &responseDTO)
+
+				assert.Equal(t, test.expectedGetAllResult, responseDTO.Data)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+
+	provinceSerialized, _ := os.ReadFile("../../../../test/resources/valid_province.json")
+	var validProvince domain.Province
+	if err := json.Unmarshal(provinceSerialized, &validProvince); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                 string
+		request              interface{}
+		expectedCreateResult domain.Province
+		expectedCreateError  error
+		expectedCreateCalls  int
+		expectedCode         int
+	}{
+		{
+			name:                 "Successfully creating province",
+			request:              domain.Province{ProvinceName: validProvince.ProvinceName, CountryID: validProvince.CountryID},
+			expectedCreateResult: validProvince,
+			expectedCreateCalls:  1,
+			expectedCode:         http.StatusCreated,
+		},
+		{
+			name:                "Error province country not found",
+			request:             domain.Province{ProvinceName: validProvince.ProvinceName, CountryID: validProvince.CountryID},
+			expectedCreateError: errors.ErrNotFound,
+			expectedCreateCalls: 1,
+			expectedCode:        http.StatusNotFound,
+		},
+		{
+			name:                "Error duplicated province",
+			request:             domain.Province{ProvinceName: validProvince.ProvinceName, CountryID: validProvince.CountryID},
+			expectedCreateError: errors.ErrConflict,
+			expectedCreateCalls: 1,
+			expectedCode:        http.StatusConflict,
+		},
+		{
+			name:                "Error creating province",
+			request:             domain.Province{ProvinceName: validProvince.ProvinceName, CountryID: validProvince.CountryID},
+			expectedCreateError: assert.AnError,
+			expectedCreateCalls: 1,
+			expectedCode:        http.StatusInternalServerError,
+		},
+		{
+			name:         "Error invalid province",
+			request:      domain.Province{},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			provinceServiceMock := mocks.NewMockProvinceService(t)
+			provinceServiceMock.On("Create", mock.Anything, mock.AnythingOfType("domain.Province")).Return(test.expectedCreateResult, test.expectedCreateError)
+
+			provinceHandler := provinces.NewProvinceHandler(provinceServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.POST("/api/v1/provinces", provinceHandler.Create())
+
+			requestBody, _ := json.Marshal(test.request)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/provinces", bytes.NewReader(requestBody))
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			provinceServiceMock.AssertNumberOfCalls(t, "Create", test.expectedCreateCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			if test.expectedCode == http.StatusCreated {
+				body, _ := io.ReadAll(res.Body)
+				var response domain.Province
+				json.Unmarshal(body, &response)
+
+				assert.Equal(t, test.expectedCreateResult, response)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+
+	newProvinceName := "Cordoba"
+	updatedProvince := domain.Province{ID: 1, ProvinceName: newProvinceName, CountryID: 1}
+
+	tests := []struct {
+		name                 string
+		id                   string
+		request              interface{}
+		expectedUpdateResult domain.Province
+		expectedUpdateError  error
+		expectedUpdateCalls  int
+		expectedCode         int
+	}{
+		{
+			name:                 "Successfully updating province",
+			id:                   "1",
+			request:              dtos.UpdateProvinceRequestDTO{ProvinceName: &newProvinceName},
+			expectedUpdateResult: updatedProvince,
+			expectedUpdateCalls:  1,
+			expectedCode:         http.StatusOK,
+		},
+		{
+			name:                "Error updating inexisting province",
+			id:                  "999",
+			request:             dtos.UpdateProvinceRequestDTO{ProvinceName: &newProvinceName},
+			expectedUpdateError: errors.ErrNotFound,
+			expectedUpdateCalls: 1,
+			expectedCode:        http.StatusNotFound,
+		},
+		{
+			name:         "Error invalid update request",
+			id:           "1",
+			request:      interface{}(""),
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:         "Error invalid id",
+			id:           "xyz",
+			request:      dtos.UpdateProvinceRequestDTO{ProvinceName: &newProvinceName},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			provinceServiceMock := mocks.NewMockProvinceService(t)
+			provinceServiceMock.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("dtos.UpdateProvinceRequestDTO")).Return(test.expectedUpdateResult, test.expectedUpdateError)
+
+			provinceHandler := provinces.NewProvinceHandler(provinceServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.PATCH("/api/v1/provinces/:id", provinceHandler.Update())
+
+			requestBody, _ := json.Marshal(test.request)
+			req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("%s/%s", "/api/v1/provinces", test.id), bytes.NewReader(requestBody))
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			provinceServiceMock.AssertNumberOfCalls(t, "Update", test.expectedUpdateCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			if test.expectedCode == http.StatusOK {
+				body, _ := io.ReadAll(res.Body)
+				var response domain.Province
+				json.Unmarshal(body, &response)
+
+				assert.Equal(t, test.expectedUpdateResult, response)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+
+	tests := []struct {
+		name                string
+		id                  string
+		expectedDeleteError error
+		expectedDeleteCalls int
+		expectedCode        int
+	}{
+		{
+			name:                "Successfully deleting province",
+			id:                  "1",
+			expectedDeleteCalls: 1,
+			expectedCode:        http.StatusNoContent,
+		},
+		{
+			name:                "Error deleting inexisting province",
+			id:                  "999",
+			expectedDeleteError: errors.ErrNotFound,
+			expectedDeleteCalls: 1,
+			expectedCode:        http.StatusNotFound,
+		},
+		{
+			name:                "Error deleting province",
+			id:                  "1",
+			expectedDeleteError: assert.AnError,
+			expectedDeleteCalls: 1,
+			expectedCode:        http.StatusInternalServerError,
+		},
+		{
+			name:         "Error invalid id",
+			id:           "xyz",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			provinceServiceMock := mocks.NewMockProvinceService(t)
+			provinceServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(test.expectedDeleteError)
+
+			provinceHandler := provinces.NewProvinceHandler(provinceServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.DELETE("/api/v1/provinces/:id", provinceHandler.Delete())
+
+			req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%s", "/api/v1/provinces", test.id), nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			provinceServiceMock.AssertNumberOfCalls(t, "Delete", test.expectedDeleteCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package purchase_orders
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/purchase_order"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/purchaseOrder"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrderDetailHandler struct {
+	orderDetailService purchaseOrder.OrderDetailService
+}
+
+func NewOrderDetailHandler(orderDetailService purchaseOrder.OrderDetailService) *OrderDetailHandler {
+	return &OrderDetailHandler{
+		orderDetailService,
+	}
+}
+
+// Get is the handler to search for an order detail of a purchaseOrder.
+//
+//	@Summary		Get OrderDetail
+//	@Tags			PurchaseOrders
+//	@Description	Get the details of a line item of a PurchaseOrder
+//	@Produce		json
+//	@Param			id			path		string	true	"ID of the PurchaseOrder"
+//	@Param			detailId	path		string	true	"ID of the OrderDetail to be searched"
+//	@Success		200			{object}	domain.OrderDetail
+//	@Failure		400			{object}	web.errorResponse
+//	@Failure		404			{object}	web.errorResponse
+//	@Failure		500			{object}	web.errorResponse
+//	@Router			/api/v1/purchase-orders/{id}/details/{detailId} [get]
+func (handler *OrderDetailHandler) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		purchaseOrderID, err := getIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		id, err := getDetailIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ctx := c.Request.Context()
+		if orderDetail, err := handler.orderDetailService.Get(ctx, purchaseOrderID, id); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Response(c, http.StatusOK, orderDetail)
+			return
+		}
+	}
+}
+
+// GetAll is the handler to search for all order details of a purchaseOrder.
+//
+//	@Summary		List OrderDetails
+//	@Tags			PurchaseOrders
+//	@Description	Get all line items of a PurchaseOrder.
+//	@Produce		json
+//	@Param			id	path		string	true	"ID of the PurchaseOrder"
+//	@Success		200	{object}	web.response{data=[]domain.OrderDetail}
+//	@Failure		400	{object}	web.errorResponse
+//	@Failure		404	{object}	web.errorResponse
+//	@Failure		500	{object}	web.errorResponse
+//	@Router			/api/v1/purchase-orders/{id}/details [get]
+func (handler *OrderDetailHandler) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		purchaseOrderID, err := getIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ctx := c.Request.Context()
+		if orderDetails, err := handler.orderDetailService.GetAll(ctx, purchaseOrderID); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			if len(orderDetails) == 0 {
+				web.Success(c, http.StatusNoContent, orderDetails)
+				return
+			}
+			web.Success(c, http.StatusOK, orderDetails)
+			return
+		}
+	}
+}
+
+// Create is the handler to add an order detail to a purchaseOrder.
+//
+//	@Summary		Create OrderDetail
+//	@Tags			PurchaseOrders
+//	@Description	Save a line item of a PurchaseOrder on the database.
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string								true	"ID of the PurchaseOrder"
+//	@Param			OrderDetail	body		dtos.CreateOrderDetailRequestDTO	true	"OrderDetail to Create"
+//	@Success		201			{object}	domain.OrderDetail
+//	@Failure		400			{object}	web.errorResponse
+//	@Failure		404			{object}	web.errorResponse
+//	@Failure		422			{object}	web.errorResponse
+//	@Failure		500			{object}	web.errorResponse
+//	@Router			/api/v1/purchase-orders/{id}/details [post]
+func (handler *OrderDetailHandler) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		purchaseOrderID, err := getIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var createOrderDetailRequestDTO dtos.CreateOrderDetailRequestDTO
+		if err := c.ShouldBindJSON(&createOrderDetailRequestDTO); err != nil {
+			web.ValidationError(c, err)
+			return
+		}
+
+		ctx := c.Request.Context()
+		if createdOrderDetail, err := handler.orderDetailService.Create(ctx, purchaseOrderID, createOrderDetailRequestDTO.ToDomain()); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Response(c, http.StatusCreated, createdOrderDetail)
+			return
+		}
+	}
+}
+
+// Update is the handler to update an order detail of a purchaseOrder.
+//
+//	@Summary		Update OrderDetail
+//	@Tags			PurchaseOrders
+//	@Description	Update a line item of a PurchaseOrder
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string								true	"ID of the PurchaseOrder"
+//	@Param			detailId	path		string								true	"ID of the OrderDetail to be updated"
+//	@Param			OrderDetail	body		dtos.UpdateOrderDetailRequestDTO	true	"Updated OrderDetail"
+//	@Success		200			{object}	domain.OrderDetail
+//	@Failure		400			{object}	web.errorResponse
+//	@Failure		404			{object}	web.errorResponse
+//	@Failure		422			{object}	web.errorResponse
+//	@Failure		500			{object}	web.errorResponse
+//	@Router			/api/v1/purchase-orders/{id}/details/{detailId} [patch]
+func (handler *OrderDetailHandler) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		purchaseOrderID, err := getIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		id, err := getDetailIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var updateOrderDetailRequest dtos.UpdateOrderDetailRequestDTO
+		if err := c.ShouldBindJSON(&updateOrderDetailRequest); err != nil {
+			web.ValidationError(c, err)
+			return
+		}
+
+		ctx := c.Request.Context()
+		if updatedOrderDetail, err := handler.orderDetailService.Update(ctx, purchaseOrderID, id, updateOrderDetailRequest); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Response(c, http.StatusOK, updatedOrderDetail)
+			return
+		}
+	}
+}
+
+// Delete is the handler to remove an order detail from a purchaseOrder.
+//
+//	@Summary		Delete OrderDetail
+//	@Tags			PurchaseOrders
+//	@Description	Delete a line item of a PurchaseOrder
+//	@Produce		json
+//	@Param			id			path	string	true	"ID of the PurchaseOrder"
+//	@Param			detailId	path	string	true	"ID of the OrderDetail to be excluded"
+//	@Success		204
+//	@Failure		400	{object}	web.errorResponse
+//	@Failure		404	{object}	web.errorResponse
+//	@Failure		500	{object}	web.errorResponse
+//	@Router			/api/v1/purchase-orders/{id}/details/{detailId} [delete]
+func (handler *OrderDetailHandler) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		purchaseOrderID, err := getIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		id, err := getDetailIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ctx := c.Request.Context()
+		if err := handler.orderDetailService.Delete(ctx, purchaseOrderID, id); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Response(c, http.StatusNoContent, nil)
+			return
+		}
+	}
+}
+
+func getDetailIdFromUri(c *gin.Context) (id int, err error) {
+
+	value, _ := c.Params.Get("detailId")
+	id, err = strconv.Atoi(value)
+
+	if err != nil {
+		err = errors.New(fmt.Sprintf("Invalid detail id on request: %s", c.Request.RequestURI))
+		return
+	}
+
+	return
+
+}
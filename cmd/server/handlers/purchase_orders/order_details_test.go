@@ -0,0 +1,434 @@
+package purchase_orders_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/purchase_orders"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/purchase_order"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/purchaseOrder/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestGetOrderDetail(t *testing.T) {
+
+	orderDetailSerialized, _ := os.ReadFile("../../../../test/resources/valid_order_detail.json")
+	var validOrderDetail domain.OrderDetail
+	if err := json.Unmarshal(orderDetailSerialized, &validOrderDetail); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                string
+		url                 string
+		expectedGetCalls    int
+		expectedGetError    error
+		expectedOrderDetail domain.OrderDetail
+		expectedCode        int
+	}{
+		{
+			name:                "Success finding order detail",
+			url:                 "/api/v1/purchase-orders/1/details/1",
+			expectedGetCalls:    1,
+			expectedOrderDetail: validOrderDetail,
+			expectedCode:        http.StatusOK,
+		},
+		{
+			name:                "Error finding order detail",
+			url:                 "/api/v1/purchase-orders/1/details/999",
+			expectedGetCalls:    1,
+			expectedGetError:    errors.ErrNotFound,
+			expectedOrderDetail: domain.OrderDetail{},
+			expectedCode:        http.StatusNotFound,
+		},
+		{
+			name:                "Error connecting db",
+			url:                 "/api/v1/purchase-orders/1/details/1",
+			expectedGetCalls:    1,
+			expectedGetError:    assert.AnError,
+			expectedOrderDetail: domain.OrderDetail{},
+			expectedCode:        http.StatusInternalServerError,
+		},
+		{
+			name:         "Error invalid purchaseOrder id",
+			url:          "/api/v1/purchase-orders/xyz/details/1",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Error invalid detail id",
+			url:          "/api/v1/purchase-orders/1/details/xyz",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			orderDetailServiceMock := mocks.NewMockOrderDetailService(t)
+			orderDetailServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("int")).Return(test.expectedOrderDetail, test.expectedGetError)
+
+			orderDetailHandler := purchase_orders.NewOrderDetailHandler(orderDetailServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.GET("/api/v1/purchase-orders/:id/details/:detailId", orderDetailHandler.Get())
+
+			req := httptest.NewRequest(http.MethodGet, test.url, nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			orderDetailServiceMock.AssertNumberOfCalls(t, "Get", test.expectedGetCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			if test.expectedCode == http.StatusOK {
+				body, _ := io.ReadAll(res.Body)
+
+				var response domain.OrderDetail
+				json.Unmarshal(body, &response)
+
+				assert.Equal(t, test.expectedOrderDetail, response)
+			}
+		})
+	}
+}
+
+func TestGetAllOrderDetails(t *testing.T) {
+
+	orderDetailsSerialized, _ := os.ReadFile("../../../../test/resources/valid_order_details.json")
+	var validOrderDetails []domain.OrderDetail
+	if err := json.Unmarshal(orderDetailsSerialized, &validOrderDetails); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                 string
+		id                   string
+		expectedGetAllResult []domain.OrderDetail
+		expectedGetAllError  error
+		expectedGetAllCalls  int
+		expectedCode         int
+	}{
+		{
+			name:                 "Successfully get all",
+			id:                   "1",
+			expectedGetAllResult: validOrderDetails,
+			expectedGetAllCalls:  1,
+			expectedCode:         http.StatusOK,
+		},
+		{
+			name:                 "Success purchaseOrder without details",
+			id:                   "1",
+			expectedGetAllResult: []domain.OrderDetail{},
+			expectedGetAllCalls:  1,
+			expectedCode:         http.StatusNoContent,
+		},
+		{
+			name:                 "Error purchaseOrder not found",
+			id:                   "999",
+			expectedGetAllResult: []domain.OrderDetail{},
+			expectedGetAllError:  errors.ErrNotFound,
+			expectedGetAllCalls:  1,
+			expectedCode:         http.StatusNotFound,
+		},
+		{
+			name:                 "Error getting all",
+			id:                   "1",
+			expectedGetAllResult: []domain.OrderDetail{},
+			expectedGetAllError:  assert.AnError,
+			expectedGetAllCalls:  1,
+			expectedCode:         http.StatusInternalServerError,
+		},
+		{
+			name:         "Error invalid id",
+			id:           "xyz",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			orderDetailServiceMock := mocks.NewMockOrderDetailService(t)
+			orderDetailServiceMock.On("GetAll", mock.Anything, mock.AnythingOfType("int")).Return(test.expectedGetAllResult, test.expectedGetAllError)
+
+			orderDetailHandler := purchase_orders.NewOrderDetailHandler(orderDetailServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.GET("/api/v1/purchase-orders/:id/details", orderDetailHandler.GetAll())
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/purchase-orders/%s/details", test.id), nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			orderDetailServiceMock.AssertNumberOfCalls(t, "GetAll", test.expectedGetAllCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			if test.expectedCode == http.StatusOK {
+				body, _ := io.ReadAll(res.Body)
+				var responseDTO struct {
+					Data []domain.OrderDetail `json:"data"`
+				}
+				json.Unmarshal(body, &responseDTO)
+
+				assert.Equal(t, test.expectedGetAllResult, responseDTO.Data)
+			}
+		})
+	}
+}
+
+func TestCreateOrderDetail(t *testing.T) {
+
+	orderDetailSerialized, _ := os.ReadFile("../../../../test/resources/valid_order_detail.json")
+	var orderDetail domain.OrderDetail
+	if err := json.Unmarshal(orderDetailSerialized, &orderDetail); err != nil {
+		t.Fatal(err)
+	}
+
+	createOrderDetailRequest := dtos.CreateOrderDetailRequestDTO{
+		CleanLinessStatus: orderDetail.CleanLinessStatus,
+		Quantity:          orderDetail.Quantity,
+		Temperature:       orderDetail.Temperature,
+		ProductRecordID:   orderDetail.ProductRecordID,
+	}
+
+	tests := []struct {
+		name                 string
+		request              interface{}
+		expectedCreateResult domain.OrderDetail
+		expectedCreateError  error
+		expectedCreateCalls  int
+		expectedCode         int
+	}{
+		{
+			name:                 "Successfully creating order detail",
+			request:              createOrderDetailRequest,
+			expectedCreateResult: orderDetail,
+			expectedCreateCalls:  1,
+			expectedCode:         http.StatusCreated,
+		},
+		{
+			name:                 "Error purchaseOrder not found",
+			request:              createOrderDetailRequest,
+			expectedCreateResult: domain.OrderDetail{},
+			expectedCreateError:  errors.ErrNotFound,
+			expectedCreateCalls:  1,
+			expectedCode:         http.StatusNotFound,
+		},
+		{
+			name:                 "Error creating order detail",
+			request:              createOrderDetailRequest,
+			expectedCreateResult: domain.OrderDetail{},
+			expectedCreateError:  assert.AnError,
+			expectedCreateCalls:  1,
+			expectedCode:         http.StatusInternalServerError,
+		},
+		{
+			name:         "Error invalid order detail",
+			request:      dtos.CreateOrderDetailRequestDTO{},
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			orderDetailServiceMock := mocks.NewMockOrderDetailService(t)
+			orderDetailServiceMock.On("Create", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("domain.OrderDetail")).Return(test.expectedCreateResult, test.expectedCreateError)
+
+			orderDetailHandler := purchase_orders.NewOrderDetailHandler(orderDetailServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.POST("/api/v1/purchase-orders/:id/details", orderDetailHandler.Create())
+
+			requestBody, _ := json.Marshal(test.request)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/purchase-orders/1/details", bytes.NewReader(requestBody))
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			orderDetailServiceMock.AssertNumberOfCalls(t, "Create", test.expectedCreateCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			if test.expectedCode == http.StatusCreated {
+				body, _ := io.ReadAll(res.Body)
+
+				var response domain.OrderDetail
+				json.Unmarshal(body, &response)
+
+				assert.Equal(t, test.expectedCreateResult, response)
+			}
+		})
+	}
+}
+
+func TestUpdateOrderDetail(t *testing.T) {
+
+	newQuantity := 30
+	updateOrderDetailRequest := dtos.UpdateOrderDetailRequestDTO{
+		Quantity: &newQuantity,
+	}
+
+	orderDetailUpdated := domain.OrderDetail{
+		ID:                1,
+		CleanLinessStatus: "Clean",
+		Quantity:          newQuantity,
+		Temperature:       -18,
+		ProductRecordID:   1,
+		PurchaseOrderID:   1,
+	}
+
+	tests := []struct {
+		name                 string
+		url                  string
+		request              interface{}
+		expectedUpdateResult domain.OrderDetail
+		expectedUpdateError  error
+		expectedUpdateCalls  int
+		expectedCode         int
+	}{
+		{
+			name:                 "Successfully updating order detail",
+			url:                  "/api/v1/purchase-orders/1/details/1",
+			request:              updateOrderDetailRequest,
+			expectedUpdateResult: orderDetailUpdated,
+			expectedUpdateCalls:  1,
+			expectedCode:         http.StatusOK,
+		},
+		{
+			name:                 "Error updating inexisting order detail",
+			url:                  "/api/v1/purchase-orders/1/details/1",
+			request:              updateOrderDetailRequest,
+			expectedUpdateResult: domain.OrderDetail{},
+			expectedUpdateError:  errors.ErrNotFound,
+			expectedUpdateCalls:  1,
+			expectedCode:         http.StatusNotFound,
+		},
+		{
+			name:                 "Error updating order detail",
+			url:                  "/api/v1/purchase-orders/1/details/1",
+			request:              updateOrderDetailRequest,
+			expectedUpdateResult: domain.OrderDetail{},
+			expectedUpdateError:  assert.AnError,
+			expectedUpdateCalls:  1,
+			expectedCode:         http.StatusInternalServerError,
+		},
+		{
+			name:         "Error invalid order detail update request",
+			url:          "/api/v1/purchase-orders/1/details/1",
+			request:      interface{}(""),
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:         "Error invalid detail id",
+			url:          "/api/v1/purchase-orders/1/details/xyz",
+			request:      updateOrderDetailRequest,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			orderDetailServiceMock := mocks.NewMockOrderDetailService(t)
+			orderDetailServiceMock.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.AnythingOfType("dtos.UpdateOrderDetailRequestDTO")).Return(test.expectedUpdateResult, test.expectedUpdateError)
+
+			orderDetailHandler := purchase_orders.NewOrderDetailHandler(orderDetailServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.PATCH("/api/v1/purchase-orders/:id/details/:detailId", orderDetailHandler.Update())
+
+			requestBody, _ := json.Marshal(test.request)
+
+			req := httptest.NewRequest(http.MethodPatch, test.url, bytes.NewReader(requestBody))
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			orderDetailServiceMock.AssertNumberOfCalls(t, "Update", test.expectedUpdateCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			if test.expectedCode == http.StatusOK {
+				body, _ := io.ReadAll(res.Body)
+				var response domain.OrderDetail
+				json.Unmarshal(body, &response)
+
+				assert.Equal(t, test.expectedUpdateResult, response)
+			}
+		})
+	}
+}
+
+func TestDeleteOrderDetail(t *testing.T) {
+
+	tests := []struct {
+		name                string
+		url                 string
+		expectedDeleteError error
+		expectedDeleteCalls int
+		expectedCode        int
+	}{
+		{
+			name:                "Successfully deleting order detail",
+			url:                 "/api/v1/purchase-orders/1/details/1",
+			expectedDeleteCalls: 1,
+			expectedCode:        http.StatusNoContent,
+		},
+		{
+			name:                "Error deleting inexisting order detail",
+			url:                 "/api/v1/purchase-orders/1/details/1",
+			expectedDeleteError: errors.ErrNotFound,
+			expectedDeleteCalls: 1,
+			expectedCode:        http.StatusNotFound,
+		},
+		{
+			name:                "Error deleting order detail",
+			url:                 "/api/v1/purchase-orders/1/details/1",
+			expectedDeleteError: assert.AnError,
+			expectedDeleteCalls: 1,
+			expectedCode:        http.StatusInternalServerError,
+		},
+		{
+			name:         "Error invalid purchaseOrder id",
+			url:          "/api/v1/purchase-orders/xyz/details/1",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			orderDetailServiceMock := mocks.NewMockOrderDetailService(t)
+			orderDetailServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("int")).Return(test.expectedDeleteError)
+
+			orderDetailHandler := purchase_orders.NewOrderDetailHandler(orderDetailServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.DELETE("/api/v1/purchase-orders/:id/details/:detailId", orderDetailHandler.Delete())
+
+			req := httptest.NewRequest(http.MethodDelete, test.url, nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			orderDetailServiceMock.AssertNumberOfCalls(t, "Delete", test.expectedDeleteCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+		})
+	}
+}
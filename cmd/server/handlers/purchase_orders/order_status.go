@@ -0,0 +1,183 @@
+package purchase_orders
+
+import (
+	"net/http"
+
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/purchase_order"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/purchaseOrder"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
+
+	"github.com/gin-gonic/gin"
+)
+
+type OrderStatusHandler struct {
+	orderStatusService purchaseOrder.OrderStatusService
+}
+
+func NewOrderStatusHandler(orderStatusService purchaseOrder.OrderStatusService) *OrderStatusHandler {
+	return &OrderStatusHandler{
+		orderStatusService,
+	}
+}
+
+// Get is the handler to search for an order status.
+//
+//	@Summary		Get OrderStatus
+//	@Tags			OrderStatuses
+//	@Description	Get the details of an OrderStatus
+//	@Produce		json
+//	@Param			id	path		string	true	"ID of OrderStatus to be searched"
+//	@Success		200	{object}	domain.OrderStatus
+//	@Failure		400	{object}	web.errorResponse
+//	@Failure		404	{object}	web.errorResponse
+//	@Failure		500	{object}	web.errorResponse
+//	@Router			/api/v1/order-statuses/{id} [get]
+func (handler *OrderStatusHandler) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		id, err := getIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ctx := c.Request.Context()
+		if orderStatus, err := handler.orderStatusService.Get(ctx, id); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Response(c, http.StatusOK, orderStatus)
+			return
+		}
+	}
+}
+
+// GetAll is the handler to search for all order statuses.
+//
+//	@Summary		List OrderStatuses
+//	@Tags			OrderStatuses
+//	@Description	Get all the statuses a PurchaseOrder can be in.
+//	@Produce		json
+//	@Success		200	{object}	web.response{data=[]domain.OrderStatus}
+//	@Failure		500	{object}	web.errorResponse
+//	@Router			/api/v1/order-statuses [get]
+func (handler *OrderStatusHandler) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		ctx := c.Request.Context()
+		if orderStatuses, err := handler.orderStatusService.GetAll(ctx); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Success(c, http.StatusOK, orderStatuses)
+			return
+		}
+	}
+}
+
+// Create is the handler to create an order status.
+//
+//	@Summary		Create OrderStatus
+//	@Tags			OrderStatuses
+//	@Description	Save an OrderStatus on the database. Only the statuses of the lifecycle have transitions.
+//	@Accept			json
+//	@Produce		json
+//	@Param			OrderStatus	body		dtos.CreateOrderStatusRequestDTO	true	"OrderStatus to Create"
+//	@Success		201			{object}	domain.OrderStatus
+//	@Failure		409			{object}	web.errorResponse
+//	@Failure		422			{object}	web.errorResponse
+//	@Failure		500			{object}	web.errorResponse
+//	@Router			/api/v1/order-statuses [post]
+func (handler *OrderStatusHandler) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		var createOrderStatusRequestDTO dtos.CreateOrderStatusRequestDTO
+		if err := c.ShouldBindJSON(&createOrderStatusRequestDTO); err != nil {
+			web.ValidationError(c, err)
+			return
+		}
+
+		ctx := c.Request.Context()
+		if createdOrderStatus, err := handler.orderStatusService.Create(ctx, createOrderStatusRequestDTO.ToDomain()); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Response(c, http.StatusCreated, createdOrderStatus)
+			return
+		}
+	}
+}
+
+// Update is the handler to update the description of an order status.
+//
+//	@Summary		Update OrderStatus
+//	@Tags			OrderStatuses
+//	@Description	Update the description of an OrderStatus, its code can't change.
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string								true	"ID of OrderStatus to be updated"
+//	@Param			OrderStatus	body		dtos.UpdateOrderStatusRequestDTO	true	"Updated OrderStatus details"
+//	@Success		200			{object}	domain.OrderStatus
+//	@Failure		400			{object}	web.errorResponse
+//	@Failure		404			{object}	web.errorResponse
+//	@Failure		422			{object}	web.errorResponse
+//	@Failure		500			{object}	web.errorResponse
+//	@Router			/api/v1/order-statuses/{id} [patch]
+func (handler *OrderStatusHandler) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		id, err := getIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var updateOrderStatusRequest dtos.UpdateOrderStatusRequestDTO
+		if err := c.ShouldBindJSON(&updateOrderStatusRequest); err != nil {
+			web.ValidationError(c, err)
+			return
+		}
+
+		ctx := c.Request.Context()
+		if updatedOrderStatus, err := handler.orderStatusService.Update(ctx, id, updateOrderStatusRequest); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Response(c, http.StatusOK, updatedOrderStatus)
+			return
+		}
+	}
+}
+
+// Delete is the handler to delete an order status.
+//
+//	@Summary		Delete OrderStatus
+//	@Tags			OrderStatuses
+//	@Description	Delete an OrderStatus no PurchaseOrder or transition refers to.
+//	@Produce		json
+//	@Param			id	path	string	true	"ID of an OrderStatus to be excluded"
+//	@Success		204
+//	@Failure		400	{object}	web.errorResponse
+//	@Failure		404	{object}	web.errorResponse
+//	@Failure		409	{object}	web.errorResponse
+//	@Failure		500	{object}	web.errorResponse
+//	@Router			/api/v1/order-statuses/{id} [delete]
+func (handler *OrderStatusHandler) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		id, err := getIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ctx := c.Request.Context()
+		if err := handler.orderStatusService.Delete(ctx, id); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Response(c, http.StatusNoContent, nil)
+			return
+		}
+	}
+}
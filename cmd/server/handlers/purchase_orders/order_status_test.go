@@ -0,0 +1,141 @@
+package purchase_orders_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/purchase_orders"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/purchaseOrder"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/purchaseOrder/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateOrderStatus(t *testing.T) {
+
+	created := domain.OrderStatus{ID: 6, Code: "returned", Description: "Returned"}
+
+	tests := []struct {
+		name                string
+		body                string
+		expectedCreateCalls int
+		expectedCreateError error
+		expectedCode        int
+	}{
+		{
+			name:                "Success creating order status",
+			body:                `{"code": "returned", "description": "Returned"}`,
+			expectedCreateCalls: 1,
+			expectedCode:        http.StatusCreated,
+		},
+		{
+			name:                "Error duplicated code",
+			body:                `{"code": "created", "description": "Created again"}`,
+			expectedCreateCalls: 1,
+			expectedCreateError: errors.Conflict("order status", "code"),
+			expectedCode:        http.StatusConflict,
+		},
+		{
+			name:         "Error missing code",
+			body:         `{"description": "Returned"}`,
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			orderStatusServiceMock := mocks.NewMockOrderStatusService(t)
+			orderStatusServiceMock.On("Create", mock.Anything, mock.AnythingOfType("domain.OrderStatus")).Return(created, test.expectedCreateError)
+
+			orderStatusHandler := purchase_orders.NewOrderStatusHandler(orderStatusServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.POST("/api/v1/order-statuses", orderStatusHandler.Create())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/order-statuses", bytes.NewBufferString(test.body))
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			orderStatusServiceMock.AssertNumberOfCalls(t, "Create", test.expectedCreateCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			if test.expectedCode == http.StatusCreated {
+				var response domain.OrderStatus
+				json.Unmarshal(res.Body.Bytes(), &response)
+
+				assert.Equal(t, created, response)
+			}
+		})
+	}
+}
+
+func TestDeleteOrderStatus(t *testing.T) {
+
+	inUse := errors.Conflict("order status", "")
+	inUse.Message = "order status can't be changed, it is referenced by other records"
+
+	tests := []struct {
+		name                string
+		url                 string
+		expectedDeleteCalls int
+		expectedDeleteError error
+		expectedCode        int
+	}{
+		{
+			name:                "Success deleting order status",
+			url:                 "/api/v1/order-statuses/6",
+			expectedDeleteCalls: 1,
+			expectedCode:        http.StatusNoContent,
+		},
+		{
+			name:                "Error order status in use",
+			url:                 "/api/v1/order-statuses/1",
+			expectedDeleteCalls: 1,
+			expectedDeleteError: inUse,
+			expectedCode:        http.StatusConflict,
+		},
+		{
+			name:                "Error order status not found",
+			url:                 "/api/v1/order-statuses/999",
+			expectedDeleteCalls: 1,
+			expectedDeleteError: purchaseOrder.ErrOrderStatusNotFound,
+			expectedCode:        http.StatusNotFound,
+		},
+		{
+			name:         "Error invalid id",
+			url:          "/api/v1/order-statuses/xyz",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			orderStatusServiceMock := mocks.NewMockOrderStatusService(t)
+			orderStatusServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(test.expectedDeleteError)
+
+			orderStatusHandler := purchase_orders.NewOrderStatusHandler(orderStatusServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.DELETE("/api/v1/order-statuses/:id", orderStatusHandler.Delete())
+
+			req := httptest.NewRequest(http.MethodDelete, test.url, nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			orderStatusServiceMock.AssertNumberOfCalls(t, "Delete", test.expectedDeleteCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+		})
+	}
+}
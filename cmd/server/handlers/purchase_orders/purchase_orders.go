@@ -7,8 +7,10 @@ import (
 	"strconv"
 
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/purchase_order"
-	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/purchaseOrder"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/export"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
 
 	"github.com/gin-gonic/gin"
@@ -46,13 +48,8 @@ func (handler *PurchaseOrderHandler) Get() gin.HandlerFunc {
 		}
 
 		ctx := c.Request.Context()
-		if purchaseOrder, err := handler.purchaseOrderService.Get(&ctx, id); err != nil {
-			switch err {
-			case errors2.ErrNotFound:
-				web.Error(c, http.StatusNotFound, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, err.Error())
-			}
+		if purchaseOrder, err := handler.purchaseOrderService.Get(ctx, id); err != nil {
+			_ = c.Error(err)
 			return
 		} else {
 			web.Response(c, http.StatusOK, purchaseOrder)
@@ -68,23 +65,48 @@ func (handler *PurchaseOrderHandler) Get() gin.HandlerFunc {
 //	@Tags			PurchaseOrders
 //	@Description	Get the details of all purchase-orders on the database.
 //	@Accept			json
-//	@Produce		json
-//	@Success		200	{object}	web.response{data=[]domain.PurchaseOrder}
-//	@Failure		500	{object}	web.errorResponse
+//	@Produce		json,text/csv,application/x-ndjson
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			offset	query		int		false	"Number of purchase-orders to skip"
+//	@Param			sort	query		string	false	"Field to sort by"
+//	@Param			order	query		string	false	"asc or desc"
+//	@Param			format	query		string	false	"json, csv or ndjson, or use the Accept header"
+//	@Success		200		{object}	web.response{data=[]domain.PurchaseOrder}
+//	@Failure		400		{object}	web.errorResponse
+//	@Failure		500		{object}	web.errorResponse
 //	@Router			/api/v1/purchase-orders [get]
 func (handler *PurchaseOrderHandler) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		options, err := listing.Parse(c.Request.URL.Query(), purchaseOrder.Fields)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if format != export.JSON {
+			web.Export(c, format, domain.PurchaseOrder{}, func(write func(row interface{}) error) error {
+				return handler.purchaseOrderService.Each(c.Request.Context(), options.Unpaged(), func(row domain.PurchaseOrder) error {
+					return write(row)
+				})
+			})
+			return
+		}
 
 		ctx := c.Request.Context()
-		if purchaseOrders, err := handler.purchaseOrderService.GetAll(&ctx); err != nil {
-			web.Error(c, http.StatusInternalServerError, err.Error())
+		if purchaseOrders, total, err := handler.purchaseOrderService.GetPage(ctx, options); err != nil {
+			_ = c.Error(err)
 			return
 		} else {
 			if len(purchaseOrders) == 0 {
 				web.Success(c, http.StatusNoContent, purchaseOrders)
 				return
 			}
-			web.Success(c, http.StatusOK, purchaseOrders)
+			web.Page(c, http.StatusOK, purchaseOrders, total, options)
 			return
 		}
 	}
@@ -94,7 +116,7 @@ func (handler *PurchaseOrderHandler) GetAll() gin.HandlerFunc {
 //
 //	@Summary		Create PurchaseOrder
 //	@Tags			PurchaseOrders
-//	@Description	Save a purchaseOrder on the database.
+//	@Description	Save a purchaseOrder on the database, in the created status.
 //	@Accept			json
 //	@Produce		json
 //	@Param			Seller	body		domain.PurchaseOrder	true	"PurchaseOrder to Create"
@@ -107,22 +129,16 @@ func (handler *PurchaseOrderHandler) Create() gin.HandlerFunc {
 
 		var createPurchaseOrderRequestDTO dtos.CreatePurchaseOrderRequestDTO
 		if err := c.ShouldBindJSON(&createPurchaseOrderRequestDTO); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, err.Error())
+			web.ValidationError(c, err)
 			return
 		}
 
 		createPurchaseOrderRequest := createPurchaseOrderRequestDTO.ToDomain()
 
 		ctx := c.Request.Context()
-		if createdPurchaseOrder, err := handler.purchaseOrderService.Create(&ctx, createPurchaseOrderRequest); err != nil {
-			switch err {
-			case errors2.ErrConflict:
-				web.Error(c, http.StatusConflict, err.Error())
-				return
-			default:
-				web.Error(c, http.StatusInternalServerError, err.Error())
-				return
-			}
+		if createdPurchaseOrder, err := handler.purchaseOrderService.Create(ctx, createPurchaseOrderRequest); err != nil {
+			_ = c.Error(err)
+			return
 		} else {
 			web.Response(c, http.StatusCreated, createdPurchaseOrder)
 			return
@@ -134,7 +150,7 @@ func (handler *PurchaseOrderHandler) Create() gin.HandlerFunc {
 //
 //	@Summary		Update PurchaseOrder
 //	@Tags			PurchaseOrders
-//	@Description	Update the details of a PurchaseOrder
+//	@Description	Update the details of a PurchaseOrder, its status only changes through transitions
 //	@Accept			json
 //	@Produce		json
 //	@Param			id		path		string						true	"ID of PurchaseOrder to be updated"
@@ -142,6 +158,7 @@ func (handler *PurchaseOrderHandler) Create() gin.HandlerFunc {
 //	@Success		200		{object}	domain.PurchaseOrder
 //	@Failure		400		{object}	web.errorResponse
 //	@Failure		404		{object}	web.errorResponse
+//	@Failure		422		{object}	web.errorResponse
 //	@Failure		500		{object}	web.errorResponse
 //	@Router			/api/v1/purchase-orders/{id} [patch]
 func (handler *PurchaseOrderHandler) Update() gin.HandlerFunc {
@@ -155,20 +172,13 @@ func (handler *PurchaseOrderHandler) Update() gin.HandlerFunc {
 
 		var updatePurchaseOrderRequest dtos.UpdatePurchaseOrderRequestDTO
 		if err := c.ShouldBindJSON(&updatePurchaseOrderRequest); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, err.Error())
+			web.ValidationError(c, err)
 			return
 		}
 
 		ctx := c.Request.Context()
-		if updatedPurchaseOrder, err := handler.purchaseOrderService.Update(&ctx, id, updatePurchaseOrderRequest); err != nil {
-			switch err {
-			case errors2.ErrNotFound:
-				web.Error(c, http.StatusNotFound, err.Error())
-			case errors2.ErrConflict:
-				web.Error(c, http.StatusConflict, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, err.Error())
-			}
+		if updatedPurchaseOrder, err := handler.purchaseOrderService.Update(ctx, id, updatePurchaseOrderRequest); err != nil {
+			_ = c.Error(err)
 			return
 		} else {
 			web.Response(c, http.StatusOK, updatedPurchaseOrder)
@@ -200,13 +210,8 @@ func (handler *PurchaseOrderHandler) Delete() gin.HandlerFunc {
 		}
 
 		ctx := c.Request.Context()
-		if err := handler.purchaseOrderService.Delete(&ctx, id); err != nil {
-			switch err {
-			case errors2.ErrNotFound:
-				web.Error(c, http.StatusNotFound, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, err.Error())
-			}
+		if err := handler.purchaseOrderService.Delete(ctx, id); err != nil {
+			_ = c.Error(err)
 			return
 		} else {
 			web.Response(c, http.StatusNoContent, nil)
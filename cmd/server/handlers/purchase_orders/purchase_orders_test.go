@@ -2,13 +2,16 @@ package purchase_orders_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/purchase_orders"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/purchase_order"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/purchaseOrder/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -69,13 +72,14 @@ func TestGet(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			purchaseOrderServiceMock := mocks.NewMockPurchaseOrderService(t)
-			purchaseOrderServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(test.expectedPurchaseOrder, test.expectedGetError)
+			purchaseOrderServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(test.expectedPurchaseOrder, test.expectedGetError)
 
 			purchaseOrderHandler := purchase_orders.NewPurchaseOrderHandler(purchaseOrderServiceMock)
 
 			//Configurar o servidor
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(middlewares.Errors())
 			r.GET("/api/v1/puchase-orders/:id", purchaseOrderHandler.Get())
 
 			//Definir request e response
@@ -117,60 +121,69 @@ func TestGetAll(t *testing.T) {
 	}
 
 	tests := []struct {
-		name                 string
-		expectedGetAllResult []domain.PurchaseOrder
-		expectedGetAllError  error
-		expectedGetAllCalls  int
-		expectedResponse     []domain.PurchaseOrder
-		expectedCode         int
+		name                  string
+		query                 string
+		expectedGetPageResult []domain.PurchaseOrder
+		expectedGetPageError  error
+		expectedGetPageCalls  int
+		expectedResponse      []domain.PurchaseOrder
+		expectedCode          int
 	}{
 		{
-			name:                 "Successfully get all",
-			expectedGetAllResult: validPurchaseOrders,
-			expectedGetAllError:  nil,
-			expectedGetAllCalls:  1,
-			expectedResponse:     validPurchaseOrders,
-			expectedCode:         http.StatusOK,
+			name:                  "Successfully get all",
+			expectedGetPageResult: validPurchaseOrders,
+			expectedGetPageError:  nil,
+			expectedGetPageCalls:  1,
+			expectedResponse:      validPurchaseOrders,
+			expectedCode:          http.StatusOK,
 		},
 		{
-			name:                 "Success empty database",
-			expectedGetAllResult: []domain.PurchaseOrder{},
-			expectedGetAllError:  nil,
-			expectedGetAllCalls:  1,
-			expectedResponse:     []domain.PurchaseOrder{},
-			expectedCode:         http.StatusNoContent,
+			name:                  "Success empty database",
+			expectedGetPageResult: []domain.PurchaseOrder{},
+			expectedGetPageError:  nil,
+			expectedGetPageCalls:  1,
+			expectedResponse:      []domain.PurchaseOrder{},
+			expectedCode:          http.StatusNoContent,
 		},
 		{
-			name:                 "Error getting all",
-			expectedGetAllResult: []domain.PurchaseOrder{},
-			expectedGetAllError:  assert.AnError,
-			expectedGetAllCalls:  1,
-			expectedResponse:     []domain.PurchaseOrder{},
-			expectedCode:         http.StatusInternalServerError,
+			name:                  "Error getting all",
+			expectedGetPageResult: []domain.PurchaseOrder{},
+			expectedGetPageError:  assert.AnError,
+			expectedGetPageCalls:  1,
+			expectedResponse:      []domain.PurchaseOrder{},
+			expectedCode:          http.StatusInternalServerError,
+		},
+		{
+			name:         "Error invalid query",
+			query:        "?limit=0",
+			expectedCode: http.StatusBadRequest,
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			purchaseOrderServiceMock := mocks.NewMockPurchaseOrderService(t)
-			purchaseOrderServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(test.expectedGetAllResult, test.expectedGetAllError)
+			if test.expectedGetPageCalls > 0 {
+				purchaseOrderServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(test.expectedGetPageResult, len(test.expectedGetPageResult), test.expectedGetPageError)
+			}
 
 			purchaseOrderHandler := purchase_orders.NewPurchaseOrderHandler(purchaseOrderServiceMock)
 
 			//Configurar o servidor
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(middlewares.Errors())
 			r.GET("/api/v1/purchase-orders", purchaseOrderHandler.GetAll())
 
 			//Definir request e response
-			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s", "/api/v1/purchase-orders"), nil)
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/purchase-orders"+test.query, nil)
 			res := httptest.NewRecorder()
 
 			//Executar request
 			r.ServeHTTP(res, req)
 
 			//Validar resultado
-			purchaseOrderServiceMock.AssertNumberOfCalls(t, "GetAll", test.expectedGetAllCalls)
+			purchaseOrderServiceMock.AssertNumberOfCalls(t, "GetPage", test.expectedGetPageCalls)
 			assert.Equal(t, test.expectedCode, res.Code)
 
 			// Só testa o body em caso de sucesso
@@ -191,6 +204,86 @@ func TestGetAll(t *testing.T) {
 	}
 }
 
+func TestGetAllExport(t *testing.T) {
+	purchaseOrders := []domain.PurchaseOrder{
+		{ID: 1, OrderNumber: "PO-1", OrderDate: "2023-04-01", TrackingCode: "TR1", BuyerID: 2, CarrierID: 3, OrderStatusID: 1, WarehouseID: 4, ProductRecordID: 5},
+		{ID: 2, OrderNumber: "PO-2", OrderDate: "2023-04-02", TrackingCode: "TR2", BuyerID: 2, CarrierID: 3, OrderStatusID: 2, WarehouseID: 4, ProductRecordID: 6},
+	}
+
+	tests := []struct {
+		name             string
+		query            string
+		eachErr          error
+		expectedEachCall int
+		expectedCode     int
+		expectedBody     string
+	}{
+		{
+			name:             "Successfully export as NDJSON",
+			query:            "?format=ndjson&buyer_id=2",
+			expectedEachCall: 1,
+			expectedCode:     http.StatusOK,
+			expectedBody: `{"id":1,"order_number":"PO-1","order_date":"2023-04-01","tracking_code":"TR1","buyer_id":2,"carrier_id":3,"order_status_id":1,"warehouse_id":4,"product_record_id":5}` + "\n" +
+				`{"id":2,"order_number":"PO-2","order_date":"2023-04-02","tracking_code":"TR2","buyer_id":2,"carrier_id":3,"order_status_id":2,"warehouse_id":4,"product_record_id":6}` + "\n",
+		},
+		{
+			name:             "Error reading the rows",
+			query:            "?format=ndjson",
+			eachErr:          assert.AnError,
+			expectedEachCall: 1,
+			expectedCode:     http.StatusInternalServerError,
+			expectedBody:     `{"code":"internal_server_error","message":"Internal Server Error"}`,
+		},
+		{
+			name:         "Error invalid format",
+			query:        "?format=xml",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"code":"bad_request","message":"format must be json, csv or ndjson"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			purchaseOrderServiceMock := mocks.NewMockPurchaseOrderService(t)
+			if test.expectedEachCall > 0 {
+				purchaseOrderServiceMock.On("Each", mock.Anything, mock.AnythingOfType("listing.Options"), mock.Anything).Return(
+					func(ctx context.Context, options listing.Options, fn func(domain.PurchaseOrder) error) error {
+						if test.eachErr != nil {
+							return test.eachErr
+						}
+						for _, purchaseOrder := range purchaseOrders {
+							if err := fn(purchaseOrder); err != nil {
+								return err
+							}
+						}
+						return nil
+					})
+			}
+
+			purchaseOrderHandler := purchase_orders.NewPurchaseOrderHandler(purchaseOrderServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.GET("/api/v1/purchase-orders", purchaseOrderHandler.GetAll())
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/purchase-orders"+test.query, nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			purchaseOrderServiceMock.AssertNumberOfCalls(t, "Each", test.expectedEachCall)
+			assert.Equal(t, test.expectedCode, res.Code)
+			if test.expectedCode == http.StatusOK {
+				assert.Equal(t, `attachment; filename="purchase-orders.ndjson"`, res.Header().Get("Content-Disposition"))
+				assert.Equal(t, test.expectedBody, res.Body.String())
+			} else {
+				assert.JSONEq(t, test.expectedBody, res.Body.String())
+			}
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 
 	tests := []struct {
@@ -233,13 +326,14 @@ func TestDelete(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			purchaseOrderServiceMock := mocks.NewMockPurchaseOrderService(t)
-			purchaseOrderServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(test.expectedDeleteError)
+			purchaseOrderServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(test.expectedDeleteError)
 
 			purchaseOrderHandler := purchase_orders.NewPurchaseOrderHandler(purchaseOrderServiceMock)
 
 			//Configurar o servidor
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(middlewares.Errors())
 			r.DELETE("/api/v1/puchase-orders/:id", purchaseOrderHandler.Delete())
 
 			//Definir request e response
@@ -312,13 +406,14 @@ func TestCreate(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			purchaseOrderServiceMock := mocks.NewMockPurchaseOrderService(t)
-			purchaseOrderServiceMock.On("Create", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("domain.PurchaseOrder")).Return(test.expectedCreateResult, test.expectedCreateError)
+			purchaseOrderServiceMock.On("Create", mock.Anything, mock.AnythingOfType("domain.PurchaseOrder")).Return(test.expectedCreateResult, test.expectedCreateError)
 
 			purchaseOrderHandler := purchase_orders.NewPurchaseOrderHandler(purchaseOrderServiceMock)
 
 			//Configurar o servidor
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(middlewares.Errors())
 			r.POST("/api/v1/puchase-orders", purchaseOrderHandler.Create())
 
 			requestBody, _ := json.Marshal(test.createPurchaseOrderRequest)
@@ -453,13 +548,14 @@ func TestUpdate(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			purchaseOrderServiceMock := mocks.NewMockPurchaseOrderService(t)
-			purchaseOrderServiceMock.On("Update", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int"), mock.AnythingOfType("dtos.UpdatePurchaseOrderRequestDTO")).Return(test.expectedUpdateResult, test.expectedUpdateError)
+			purchaseOrderServiceMock.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("dtos.UpdatePurchaseOrderRequestDTO")).Return(test.expectedUpdateResult, test.expectedUpdateError)
 
 			purchaseOrderHandler := purchase_orders.NewPurchaseOrderHandler(purchaseOrderServiceMock)
 
 			//Configurar o servidor
 			gin.SetMode(gin.TestMode)
 			r := gin.Default()
+			r.Use(middlewares.Errors())
 			r.PATCH("/api/v1/puchase-orders/:id", purchaseOrderHandler.Update())
 
 			requestBody, _ := json.Marshal(test.updatePurchaseOrderRequest)
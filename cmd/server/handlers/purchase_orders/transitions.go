@@ -0,0 +1,95 @@
+package purchase_orders
+
+import (
+	"net/http"
+
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/purchase_order"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/purchaseOrder"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TransitionHandler struct {
+	transitionService purchaseOrder.TransitionService
+}
+
+func NewTransitionHandler(transitionService purchaseOrder.TransitionService) *TransitionHandler {
+	return &TransitionHandler{
+		transitionService,
+	}
+}
+
+// GetAll is the handler to search for the status history of a purchaseOrder.
+//
+//	@Summary		List PurchaseOrder transitions
+//	@Tags			PurchaseOrders
+//	@Description	Get the status transitions of a PurchaseOrder, oldest first.
+//	@Produce		json
+//	@Param			id	path		string	true	"ID of the PurchaseOrder"
+//	@Success		200	{object}	web.response{data=[]domain.PurchaseOrderTransition}
+//	@Failure		400	{object}	web.errorResponse
+//	@Failure		404	{object}	web.errorResponse
+//	@Failure		500	{object}	web.errorResponse
+//	@Router			/api/v1/purchase-orders/{id}/transitions [get]
+func (handler *TransitionHandler) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		purchaseOrderID, err := getIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ctx := c.Request.Context()
+		if transitions, err := handler.transitionService.GetAll(ctx, purchaseOrderID); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Success(c, http.StatusOK, transitions)
+			return
+		}
+	}
+}
+
+// Create is the handler to move a purchaseOrder to another status.
+//
+//	@Summary		Create PurchaseOrder transition
+//	@Tags			PurchaseOrders
+//	@Description	Move a PurchaseOrder to the order status with the given code: created → picked → shipped → delivered, or cancelled before delivery.
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string							true	"ID of the PurchaseOrder"
+//	@Param			Transition	body		dtos.CreateTransitionRequestDTO	true	"Code of the new status"
+//	@Success		201			{object}	domain.PurchaseOrderTransition
+//	@Failure		400			{object}	web.errorResponse
+//	@Failure		404			{object}	web.errorResponse
+//	@Failure		409			{object}	web.errorResponse
+//	@Failure		422			{object}	web.errorResponse
+//	@Failure		500			{object}	web.errorResponse
+//	@Router			/api/v1/purchase-orders/{id}/transitions [post]
+func (handler *TransitionHandler) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		purchaseOrderID, err := getIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var createTransitionRequestDTO dtos.CreateTransitionRequestDTO
+		if err := c.ShouldBindJSON(&createTransitionRequestDTO); err != nil {
+			web.ValidationError(c, err)
+			return
+		}
+
+		ctx := c.Request.Context()
+		if transition, err := handler.transitionService.Create(ctx, purchaseOrderID, createTransitionRequestDTO.Status); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Response(c, http.StatusCreated, transition)
+			return
+		}
+	}
+}
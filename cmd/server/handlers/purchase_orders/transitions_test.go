@@ -0,0 +1,185 @@
+package purchase_orders_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/purchase_orders"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/purchaseOrder"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/purchaseOrder/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetAllTransitions(t *testing.T) {
+
+	transitions := []domain.PurchaseOrderTransition{
+		{ID: 1, PurchaseOrderID: 1, FromStatusID: 1, FromStatus: domain.OrderStatusCreated, ToStatusID: 2, ToStatus: domain.OrderStatusPicked, CreatedAt: "2023-07-02 15:00:00"},
+	}
+
+	tests := []struct {
+		name                string
+		url                 string
+		expectedGetAllCalls int
+		expectedGetAllError error
+		expectedCode        int
+	}{
+		{
+			name:                "Success listing transitions",
+			url:                 "/api/v1/purchase-orders/1/transitions",
+			expectedGetAllCalls: 1,
+			expectedCode:        http.StatusOK,
+		},
+		{
+			name:                "Error purchaseOrder not found",
+			url:                 "/api/v1/purchase-orders/999/transitions",
+			expectedGetAllCalls: 1,
+			expectedGetAllError: purchaseOrder.ErrNotFound,
+			expectedCode:        http.StatusNotFound,
+		},
+		{
+			name:         "Error invalid purchaseOrder id",
+			url:          "/api/v1/purchase-orders/xyz/transitions",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			transitionServiceMock := mocks.NewMockTransitionService(t)
+			transitionServiceMock.On("GetAll", mock.Anything, mock.AnythingOfType("int")).Return(transitions, test.expectedGetAllError)
+
+			transitionHandler := purchase_orders.NewTransitionHandler(transitionServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.GET("/api/v1/purchase-orders/:id/transitions", transitionHandler.GetAll())
+
+			req := httptest.NewRequest(http.MethodGet, test.url, nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			transitionServiceMock.AssertNumberOfCalls(t, "GetAll", test.expectedGetAllCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			if test.expectedCode == http.StatusOK {
+				var response struct {
+					Data []domain.PurchaseOrderTransition `json:"data"`
+				}
+				json.Unmarshal(res.Body.Bytes(), &response)
+
+				assert.Equal(t, transitions, response.Data)
+			}
+		})
+	}
+}
+
+func TestCreateTransition(t *testing.T) {
+
+	transition := domain.PurchaseOrderTransition{ID: 2, PurchaseOrderID: 1, FromStatusID: 2, FromStatus: domain.OrderStatusPicked, ToStatusID: 3, ToStatus: domain.OrderStatusShipped, CreatedAt: "2023-07-03 09:00:00"}
+
+	invalidTransition := errors.Conflict("purchase order", "order_status_id")
+	invalidTransition.Message = "purchase order can't go from delivered to shipped"
+
+	tests := []struct {
+		name                string
+		url                 string
+		body                string
+		expectedCreateCalls int
+		expectedCreateError error
+		expectedCode        int
+		expectedMessage     string
+	}{
+		{
+			name:                "Success moving the purchaseOrder",
+			url:                 "/api/v1/purchase-orders/1/transitions",
+			body:                `{"status": "shipped"}`,
+			expectedCreateCalls: 1,
+			expectedCode:        http.StatusCreated,
+		},
+		{
+			name:                "Error transition not allowed",
+			url:                 "/api/v1/purchase-orders/1/transitions",
+			body:                `{"status": "shipped"}`,
+			expectedCreateCalls: 1,
+			expectedCreateError: invalidTransition,
+			expectedCode:        http.StatusConflict,
+			expectedMessage:     "purchase order can't go from delivered to shipped",
+		},
+		{
+			name:                "Error status does not exist",
+			url:                 "/api/v1/purchase-orders/1/transitions",
+			body:                `{"status": "lost"}`,
+			expectedCreateCalls: 1,
+			expectedCreateError: errors.Validation("purchase order transition", "status", "order status lost does not exist"),
+			expectedCode:        http.StatusUnprocessableEntity,
+			expectedMessage:     "order status lost does not exist",
+		},
+		{
+			name:                "Error purchaseOrder not found",
+			url:                 "/api/v1/purchase-orders/999/transitions",
+			body:                `{"status": "shipped"}`,
+			expectedCreateCalls: 1,
+			expectedCreateError: purchaseOrder.ErrNotFound,
+			expectedCode:        http.StatusNotFound,
+		},
+		{
+			name:         "Error missing status",
+			url:          "/api/v1/purchase-orders/1/transitions",
+			body:         `{}`,
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:         "Error invalid purchaseOrder id",
+			url:          "/api/v1/purchase-orders/xyz/transitions",
+			body:         `{"status": "shipped"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			transitionServiceMock := mocks.NewMockTransitionService(t)
+			transitionServiceMock.On("Create", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("string")).Return(transition, test.expectedCreateError)
+
+			transitionHandler := purchase_orders.NewTransitionHandler(transitionServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.POST("/api/v1/purchase-orders/:id/transitions", transitionHandler.Create())
+
+			req := httptest.NewRequest(http.MethodPost, test.url, bytes.NewBufferString(test.body))
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			transitionServiceMock.AssertNumberOfCalls(t, "Create", test.expectedCreateCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			if test.expectedCode == http.StatusCreated {
+				var response domain.PurchaseOrderTransition
+				json.Unmarshal(res.Body.Bytes(), &response)
+
+				assert.Equal(t, transition, response)
+				transitionServiceMock.AssertCalled(t, "Create", mock.Anything, 1, domain.OrderStatusShipped)
+			}
+			if test.expectedMessage != "" {
+				var response struct {
+					Message string `json:"message"`
+				}
+				json.Unmarshal(res.Body.Bytes(), &response)
+
+				assert.Equal(t, test.expectedMessage, response.Message)
+			}
+		})
+	}
+}
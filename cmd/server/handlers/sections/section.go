@@ -1,13 +1,15 @@
 package sections
 
 import (
-	"fmt"
 	"net/http"
 	"strconv"
 
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/sections"
 
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/section"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/export"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
 	"github.com/gin-gonic/gin"
 )
@@ -29,22 +31,47 @@ func NewSection(s section.Service) *Section {
 //	@Tags			Sections
 //	@Description	getAll sections
 //	@Accept			json
-//	@Produce		json
-//	@Success		200	{object}	web.response
+//	@Produce		json,text/csv,application/x-ndjson
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			offset	query		int		false	"Number of sections to skip"
+//	@Param			sort	query		string	false	"Field to sort by"
+//	@Param			order	query		string	false	"asc or desc"
+//	@Param			format	query		string	false	"json, csv or ndjson, or use the Accept header"
+//	@Success		200		{object}	web.response
 //	@Router			/api/v1/sections [get]
 func (s *Section) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		options, err := listing.Parse(c.Request.URL.Query(), section.Fields)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if format != export.JSON {
+			web.Export(c, format, domain.Section{}, func(write func(row interface{}) error) error {
+				return s.sectionService.Each(c.Request.Context(), options.Unpaged(), func(row domain.Section) error {
+					return write(row)
+				})
+			})
+			return
+		}
+
 		ctx := c.Request.Context()
-		sections, err := s.sectionService.GetAll(&ctx)
+		sections, total, err := s.sectionService.GetPage(ctx, options)
 		if err != nil {
-			web.Error(c, http.StatusInternalServerError, err.Error())
+			_ = c.Error(err)
 			return
 		}
 		if len(*sections) == 0 {
-			web.Error(c, http.StatusNoContent, "There are no sellers stored")
+			web.Error(c, http.StatusNoContent, "There are no sections stored")
 			return
 		}
-		web.Success(c, http.StatusOK, sections)
+		web.Page(c, http.StatusOK, sections, total, options)
 	}
 }
 
@@ -68,14 +95,9 @@ func (s *Section) Get() gin.HandlerFunc {
 		}
 
 		ctx := c.Request.Context()
-		sectionResponse, err := s.sectionService.Get(&ctx, int(id))
+		sectionResponse, err := s.sectionService.Get(ctx, int(id))
 		if err != nil {
-			switch err {
-			case section.ErrNotFound:
-				web.Error(c, http.StatusNotFound, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, fmt.Sprintf("error getting section %s", err.Error()))
-			}
+			_ = c.Error(err)
 			return
 		}
 		web.Success(c, http.StatusOK, sectionResponse)
@@ -97,60 +119,15 @@ func (s *Section) Create() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var req dtos.CreateSectionRequestDTO
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, err.Error())
-			return
-		}
-
-		if req.SectionNumber == 0 {
-			web.Error(c, http.StatusUnprocessableEntity, "The field SectionNumber is required.")
-			return
-		}
-
-		if req.CurrentTemperature == 0 {
-			web.Error(c, http.StatusUnprocessableEntity, "The field CurrentTemperature is required.")
-			return
-		}
-
-		if req.MinimumTemperature == 0 {
-			web.Error(c, http.StatusUnprocessableEntity, "The field MinimumTemperature is required.")
-			return
-		}
-
-		if req.CurrentCapacity == 0 {
-			web.Error(c, http.StatusUnprocessableEntity, "The field CurrentCapacity is required.")
-			return
-		}
-
-		if req.MaximumCapacity == 0 {
-			web.Error(c, http.StatusUnprocessableEntity, "The field MaximumCapacity is required.")
-			return
-		}
-
-		if req.MinimumCapacity == 0 {
-			web.Error(c, http.StatusUnprocessableEntity, "The field MinimumCapacity is required.")
-			return
-		}
-
-		if req.WarehouseID == 0 {
-			web.Error(c, http.StatusUnprocessableEntity, "The field WarehouseID is required.")
-			return
-		}
-
-		if req.ProductTypeID == 0 {
-			web.Error(c, http.StatusUnprocessableEntity, "The field ProductTypeID is required.")
+			web.ValidationError(c, err)
 			return
 		}
 
 		ctx := c.Request.Context()
-		sectionResponse, err := s.sectionService.Save(&ctx, req.SectionNumber, req.CurrentTemperature, req.MinimumTemperature, req.CurrentCapacity,
+		sectionResponse, err := s.sectionService.Save(ctx, req.SectionNumber, req.CurrentTemperature, req.MinimumTemperature, req.CurrentCapacity,
 			req.MinimumCapacity, req.MaximumCapacity, req.WarehouseID, req.ProductTypeID)
 		if err != nil {
-			switch err {
-			case section.ErrConflict:
-				web.Error(c, http.StatusConflict, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, fmt.Sprintf("error saving request %s", err.Error()))
-			}
+			_ = c.Error(err)
 			return
 		}
 		web.Success(c, http.StatusCreated, sectionResponse)
@@ -178,20 +155,13 @@ func (s *Section) Update() gin.HandlerFunc {
 		}
 		var req dtos.UpdateSectionRequestDTO
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, err.Error())
+			web.ValidationError(c, err)
 			return
 		}
 		sectionResponse, err := s.sectionService.Update(c, req.SectionNumber, req.CurrentTemperature, req.MinimumTemperature, req.CurrentCapacity,
 			req.MinimumCapacity, req.MaximumCapacity, req.WarehouseID, req.ProductTypeID, id)
 		if err != nil {
-			switch err {
-			case section.ErrNotFound:
-				web.Error(c, http.StatusNotFound, err.Error())
-			case section.ErrConflict:
-				web.Error(c, http.StatusConflict, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, fmt.Sprintf("error updating section %s", err.Error()))
-			}
+			_ = c.Error(err)
 			return
 		}
 		web.Success(c, http.StatusOK, sectionResponse)
@@ -206,8 +176,11 @@ func (s *Section) Update() gin.HandlerFunc {
 //	@Description	Delete Section
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		string	true	"ID of a Section to be excluded"
-//	@Success		204	{object}	web.response
+//	@Param			id		path		string	true	"ID of a Section to be excluded"
+//	@Param			dry_run	query		bool	false	"Only preview the records referencing the section"
+//	@Success		200		{object}	domain.DeletePreview
+//	@Success		204		{object}	web.response
+//	@Failure		409		{object}	web.errorResponse
 //	@Router			/api/v1/sections/{id} [delete]
 func (s *Section) Delete() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -217,14 +190,25 @@ func (s *Section) Delete() gin.HandlerFunc {
 			return
 		}
 		ctx := c.Request.Context()
-		err = s.sectionService.Delete(&ctx, int(id))
+
+		dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
 		if err != nil {
-			switch err {
-			case section.ErrNotFound:
-				web.Error(c, http.StatusNotFound, err.Error())
-			default:
-				web.Error(c, http.StatusInternalServerError, fmt.Sprintf("error deleting section %s", err.Error()))
+			web.Error(c, http.StatusBadRequest, "parameter dry_run must be a boolean")
+			return
+		}
+		if dryRun {
+			preview, err := s.sectionService.DeletePreview(ctx, id)
+			if err != nil {
+				_ = c.Error(err)
+				return
 			}
+			web.Success(c, http.StatusOK, preview)
+			return
+		}
+
+		err = s.sectionService.Delete(ctx, int(id))
+		if err != nil {
+			_ = c.Error(err)
 			return
 		}
 
@@ -9,9 +9,11 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/sections"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/sections"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/section"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/section/section_mocks"
@@ -74,7 +76,7 @@ func TestGetAll(t *testing.T) {
 
 		//Configurar o mock do service
 		sectionServiceMock := new(section_mocks.SectionServiceMock)
-		sectionServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(expectedSections, nil)
+		sectionServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(expectedSections, len(*expectedSections), nil)
 		handler := sections.NewSection(sectionServiceMock)
 
 		//Configurar o servidor
@@ -107,7 +109,7 @@ func TestGetAll(t *testing.T) {
 
 		//Configurar o mock do service
 		sectionServiceMock := new(section_mocks.SectionServiceMock)
-		sectionServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(expectedSections, nil)
+		sectionServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(expectedSections, len(*expectedSections), nil)
 		handler := sections.NewSection(sectionServiceMock)
 
 		//Configurar o servidor
@@ -128,12 +130,13 @@ func TestGetAll(t *testing.T) {
 	t.Run("READ - ServerInternalError - Should return error 500 when server side error occurs with the database.", func(t *testing.T) {
 		//Definir resultado da consulta
 		sectionServiceMock := new(section_mocks.SectionServiceMock)
-		sectionServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(&[]domain.Section{}, assert.AnError)
+		sectionServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(&[]domain.Section{}, 0, assert.AnError)
 		handler := sections.NewSection(sectionServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/sections", handler.GetAll())
 
 		//Definir request e response
@@ -146,6 +149,24 @@ func TestGetAll(t *testing.T) {
 		//Validar resultado
 		assert.Equal(t, http.StatusInternalServerError, response.Code)
 	})
+
+	t.Run("getAll_invalid_query", func(t *testing.T) {
+		serviceMock := new(section_mocks.SectionServiceMock)
+		handler := sections.NewSection(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/sections", handler.GetAll())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/sections?limit=0", nil)
+		res := httptest.NewRecorder()
+
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		serviceMock.AssertNotCalled(t, "GetPage", mock.Anything, mock.Anything)
+	})
 }
 
 func TestGet(t *testing.T) {
@@ -163,7 +184,7 @@ func TestGet(t *testing.T) {
 			WarehouseID:        2,
 			ProductTypeID:      2,
 		}
-		mockService.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(expectedSection, nil)
+		mockService.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(expectedSection, nil)
 		server.GET("/api/v1/sections/:id", handler.Get())
 
 		//Definir request e response
@@ -182,7 +203,7 @@ func TestGet(t *testing.T) {
 	t.Run("READ - find_by_id_non_existent - Should return error 404 when not finding a section by id", func(t *testing.T) {
 		server, mockService, handler := InitServerWithGetSections(t)
 
-		mockService.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(&domain.Section{}, section.ErrNotFound)
+		mockService.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(&domain.Section{}, section.ErrNotFound)
 		server.GET("/api/v1/sections/:id", handler.Get())
 
 		//Definir request e response
@@ -209,7 +230,7 @@ func TestGet(t *testing.T) {
 	t.Run("READ - should return error 500 when an internal server error occurs when looking up a section by id", func(t *testing.T) {
 		server, mockService, handler := InitServerWithGetSections(t)
 
-		mockService.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(&domain.Section{}, assert.AnError)
+		mockService.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(&domain.Section{}, assert.AnError)
 		//Dúvida sobre o retorno section.ErrNotFound ao invés de um invalid id personalizado...
 		server.GET("/api/v1/sections/:id", handler.Get())
 
@@ -226,7 +247,7 @@ func TestDelete(t *testing.T) {
 	t.Run("DELETE - OK - When the deletion is successful, a 204 code is returned.", func(t *testing.T) {
 		server, mockService, handler := InitServerWithGetSections(t)
 
-		mockService.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(nil)
+		mockService.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(nil)
 
 		server.DELETE("/api/v1/sections/:id", handler.Delete())
 
@@ -240,7 +261,7 @@ func TestDelete(t *testing.T) {
 	t.Run("DELETE - Delete_non_existent - Should return status 404 when deleting a section that does not exist.", func(t *testing.T) {
 		server, mockService, handler := InitServerWithGetSections(t)
 
-		mockService.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(section.ErrNotFound)
+		mockService.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(section.ErrNotFound)
 
 		server.DELETE("/api/v1/sections/:id", handler.Delete())
 
@@ -254,7 +275,7 @@ func TestDelete(t *testing.T) {
 	t.Run("DELETE - ID invalid - Should return error 400 when trying to delete a section with invalid ID.", func(t *testing.T) {
 		server, mockService, handler := InitServerWithGetSections(t)
 
-		mockService.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(nil)
+		mockService.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(nil)
 
 		server.DELETE("/api/v1/sections/:id", handler.Delete())
 
@@ -269,7 +290,7 @@ func TestDelete(t *testing.T) {
 	t.Run("DELETE - Server Internal Error - Should return error 500 when an internal server error occurs while deleting a section.", func(t *testing.T) {
 		server, mockService, handler := InitServerWithGetSections(t)
 
-		mockService.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(assert.AnError)
+		mockService.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(assert.AnError)
 
 		server.DELETE("/api/v1/sections/:id", handler.Delete())
 
@@ -285,7 +306,7 @@ func TestDelete(t *testing.T) {
 func TestCreate(t *testing.T) {
 	t.Run("CREATE - OK - When data entry is successful, a 201 code will be returned along with the inserted object", func(t *testing.T) {
 		server, mockService, handler := InitServerWithGetSections(t)
-		mockService.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.AnythingOfType("int")).Return(expectedSection, nil)
+		mockService.On("Save", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.AnythingOfType("int"), mock.AnythingOfType("int")).Return(expectedSection, nil)
 		server.POST("/api/v1/sections", handler.Create())
 
 		requestBody, _ := json.Marshal(requestSection)
@@ -311,7 +332,7 @@ func TestCreate(t *testing.T) {
 	t.Run("CREATE - StatusUnprocessableEntity", func(t *testing.T) {
 		server, mockService, handler := InitServerWithGetSections(t)
 		mockService.On("Save",
-			mock.AnythingOfType("*context.Context"),
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
@@ -333,7 +354,7 @@ func TestCreate(t *testing.T) {
 	t.Run("CREATE - Create_Conflict - If the section_number already exists, it will return a 409 Conflict error.", func(t *testing.T) {
 		server, mockService, handler := InitServerWithGetSections(t)
 		mockService.On("Save",
-			mock.AnythingOfType("*context.Context"),
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
@@ -358,7 +379,7 @@ func TestCreate(t *testing.T) {
 	t.Run("CREATE - Create_Internal_Server_Error -  return status code 500", func(t *testing.T) {
 		server, mockService, handler := InitServerWithGetSections(t)
 		mockService.On("Save",
-			mock.AnythingOfType("*context.Context"),
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
@@ -393,7 +414,7 @@ func TestCreate(t *testing.T) {
 
 		server, mockService, handler := InitServerWithGetSections(t)
 		mockService.On("Save",
-			mock.AnythingOfType("*context.Context"),
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
@@ -418,7 +439,7 @@ func TestCreate(t *testing.T) {
 		requestSection := dtos.CreateSectionRequestDTO{SectionNumber: 10, CurrentTemperature: 0, MinimumTemperature: 10, CurrentCapacity: 10, MinimumCapacity: 10, MaximumCapacity: 10, WarehouseID: 10, ProductTypeID: 10}
 		server, mockService, handler := InitServerWithGetSections(t)
 		mockService.On("Save",
-			mock.AnythingOfType("*context.Context"),
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
@@ -443,7 +464,7 @@ func TestCreate(t *testing.T) {
 		requestSection := dtos.CreateSectionRequestDTO{SectionNumber: 10, CurrentTemperature: 10, CurrentCapacity: 10, MinimumCapacity: 10, MaximumCapacity: 10, WarehouseID: 10, ProductTypeID: 10}
 		server, mockService, handler := InitServerWithGetSections(t)
 		mockService.On("Save",
-			mock.AnythingOfType("*context.Context"),
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
@@ -468,7 +489,7 @@ func TestCreate(t *testing.T) {
 		requestSection := dtos.CreateSectionRequestDTO{10, 10, 10, 0, 10, 10, 10, 10}
 		server, mockService, handler := InitServerWithGetSections(t)
 		mockService.On("Save",
-			mock.AnythingOfType("*context.Context"),
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
@@ -493,7 +514,7 @@ func TestCreate(t *testing.T) {
 		requestSection := dtos.CreateSectionRequestDTO{10, 10, 10, 10, 0, 10, 10, 10}
 		server, mockService, handler := InitServerWithGetSections(t)
 		mockService.On("Save",
-			mock.AnythingOfType("*context.Context"),
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
@@ -518,7 +539,7 @@ func TestCreate(t *testing.T) {
 		requestSection := dtos.CreateSectionRequestDTO{10, 10, 10, 10, 10, 0, 10, 10}
 		server, mockService, handler := InitServerWithGetSections(t)
 		mockService.On("Save",
-			mock.AnythingOfType("*context.Context"),
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
@@ -543,7 +564,7 @@ func TestCreate(t *testing.T) {
 		requestSection := dtos.CreateSectionRequestDTO{10, 10, 10, 10, 10, 10, 0, 10}
 		server, mockService, handler := InitServerWithGetSections(t)
 		mockService.On("Save",
-			mock.AnythingOfType("*context.Context"),
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
@@ -568,7 +589,7 @@ func TestCreate(t *testing.T) {
 		requestSection := dtos.CreateSectionRequestDTO{10, 10, 10, 10, 10, 10, 10, 0}
 		server, mockService, handler := InitServerWithGetSections(t)
 		mockService.On("Save",
-			mock.AnythingOfType("*context.Context"),
+			mock.Anything,
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
 			mock.AnythingOfType("int"),
@@ -795,5 +816,77 @@ func createServer() *gin.Engine {
 	//Configurar o servidor
 	gin.SetMode(gin.TestMode)
 	server := gin.Default()
+	server.Use(middlewares.Errors())
 	return server
 }
+
+func TestDeleteDependents(t *testing.T) {
+	t.Run("delete_has_dependents", func(t *testing.T) {
+		serviceMock := new(section_mocks.SectionServiceMock)
+		serviceMock.On("Delete", mock.Anything, 1).Return(&database.DependentsError{Dependents: map[string]int{"product_batches": 2}})
+		handler := sections.NewSection(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.DELETE("/api/v1/sections/:id", handler.Delete())
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/sections/1", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusConflict, res.Code)
+		assert.JSONEq(t, `{"code":"conflict","message":"it is referenced by other records: product_batches (2)","details":{"product_batches":2}}`, res.Body.String())
+	})
+
+	t.Run("delete_dry_run", func(t *testing.T) {
+		serviceMock := new(section_mocks.SectionServiceMock)
+		serviceMock.On("DeletePreview", mock.Anything, 1).Return(&domain.DeletePreview{ID: 1, Dependents: map[string]int{"product_batches": 2}, Deletable: false}, nil)
+		handler := sections.NewSection(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.DELETE("/api/v1/sections/:id", handler.Delete())
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/sections/1?dry_run=true", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"data":{"id":1,"dependents":{"product_batches":2},"deletable":false}}`, res.Body.String())
+	})
+
+	t.Run("delete_dry_run_non_existent", func(t *testing.T) {
+		serviceMock := new(section_mocks.SectionServiceMock)
+		serviceMock.On("DeletePreview", mock.Anything, 1).Return((*domain.DeletePreview)(nil), section.ErrNotFound)
+		handler := sections.NewSection(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.DELETE("/api/v1/sections/:id", handler.Delete())
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/sections/1?dry_run=true", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("delete_invalid_dry_run", func(t *testing.T) {
+		serviceMock := new(section_mocks.SectionServiceMock)
+		handler := sections.NewSection(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.DELETE("/api/v1/sections/:id", handler.Delete())
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/sections/1?dry_run=maybe", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
@@ -1,13 +1,14 @@
 package sellers
 
 import (
-	"errors"
 	"net/http"
 	"strconv"
 
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/sellers"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/seller"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/export"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
 	"github.com/gin-gonic/gin"
 )
@@ -29,16 +30,41 @@ func NewSeller(s seller.Service) *Seller {
 //	@Tags			Sellers
 //	@Description	getAll sellers
 //	@Accept			json
-//	@Produce		json
-//	@Success		200	{object}	web.response
+//	@Produce		json,text/csv,application/x-ndjson
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			offset	query		int		false	"Number of sellers to skip"
+//	@Param			sort	query		string	false	"Field to sort by"
+//	@Param			order	query		string	false	"asc or desc"
+//	@Param			format	query		string	false	"json, csv or ndjson, or use the Accept header"
+//	@Param			include_deleted	query		bool	false	"List deleted records too"
+//	@Success		200		{object}	web.response
 //	@Router			/api/v1/sellers [get]
 func (s *Seller) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx := c.Request.Context()
+		options, err := listing.ParseSoftDeleted(c.Request.URL.Query(), seller.Fields, "sellers.deleted_at")
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "Invalid query: %s", err.Error())
+			return
+		}
 
-		sellers, err := s.sellerService.GetAll(&ctx)
+		format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if format != export.JSON {
+			web.Export(c, format, domain.Seller{}, func(write func(row interface{}) error) error {
+				return s.sellerService.Each(c.Request.Context(), options.Unpaged(), func(row domain.Seller) error {
+					return write(row)
+				})
+			})
+			return
+		}
+
+		ctx := c.Request.Context()
+		sellers, total, err := s.sellerService.GetPage(ctx, options)
 		if err != nil {
-			web.Error(c, http.StatusInternalServerError, "Failed to get sellers: %s", err.Error())
+			_ = c.Error(err)
 			return
 		}
 
@@ -47,7 +73,7 @@ func (s *Seller) GetAll() gin.HandlerFunc {
 			return
 		}
 
-		web.Success(c, http.StatusOK, *sellers)
+		web.Page(c, http.StatusOK, *sellers, total, options)
 	}
 }
 
@@ -66,7 +92,7 @@ func (s *Seller) Create() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		createSellerRequestDTO := new(dtos.CreateSellerRequestDTO)
 		if err := c.ShouldBindJSON(createSellerRequestDTO); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, "Error to read request: %s", err.Error())
+			web.ValidationError(c, err)
 			return
 		}
 
@@ -79,16 +105,10 @@ func (s *Seller) Create() gin.HandlerFunc {
 		}
 
 		ctx := c.Request.Context()
-		sellerDomain, err := s.sellerService.Save(&ctx, *sellerDomain)
+		sellerDomain, err := s.sellerService.Save(ctx, *sellerDomain)
 		if err != nil {
-			switch err {
-			case seller.ErrConflict:
-				web.Error(c, http.StatusConflict, err.Error())
-				return
-			default:
-				web.Error(c, http.StatusInternalServerError, "Error to save request: %s", err.Error())
-				return
-			}
+			_ = c.Error(err)
+			return
 		}
 
 		web.Success(c, http.StatusCreated, *sellerDomain)
@@ -115,14 +135,10 @@ func (s *Seller) Get() gin.HandlerFunc {
 		}
 
 		ctx := c.Request.Context()
-		sellerResult, err := s.sellerService.Get(&ctx, int(id))
+		sellerResult, err := s.sellerService.Get(ctx, int(id))
 
 		if err != nil {
-			if errors.Is(err, seller.ErrNotFound) {
-				web.Error(c, http.StatusNotFound, "Seller not found: %s", err.Error())
-				return
-			}
-			web.Error(c, http.StatusInternalServerError, "Error to process the request, try again: %s", err.Error())
+			_ = c.Error(err)
 			return
 		}
 
@@ -152,23 +168,16 @@ func (s *Seller) Update() gin.HandlerFunc {
 
 		updateSellerRequestDTO := new(dtos.UpdateSellerRequestDTO)
 
-		if err := c.Bind(&updateSellerRequestDTO); err != nil {
-			web.Error(c, http.StatusBadRequest, "Error to read request: %s", err.Error())
+		if err := c.ShouldBindJSON(updateSellerRequestDTO); err != nil {
+			web.ValidationError(c, err)
 			return
 		}
 
 		ctx := c.Request.Context()
-		sellerUpdated, err := s.sellerService.Update(&ctx, int(id), updateSellerRequestDTO)
+		sellerUpdated, err := s.sellerService.Update(ctx, int(id), updateSellerRequestDTO)
 		if err != nil {
-			switch err {
-			case seller.ErrConflict:
-				web.Error(c, http.StatusConflict, err.Error())
-				return
-			case seller.ErrNotFound:
-				web.Error(c, http.StatusNotFound, err.Error())
-				return
-			}
-
+			_ = c.Error(err)
+			return
 		}
 
 		web.Success(c, http.StatusOK, sellerUpdated)
@@ -183,8 +192,11 @@ func (s *Seller) Update() gin.HandlerFunc {
 //	@Description	Delete Sellers
 //	@Accept			json
 //	@Produce		json
-//	@Param			id	path		string	true	"ID of a Sellers to be excluded"
-//	@Success		204	{object}	web.response
+//	@Param			id		path		string	true	"ID of a Sellers to be excluded"
+//	@Param			dry_run	query		bool	false	"Only preview the records referencing the seller"
+//	@Success		200		{object}	domain.DeletePreview
+//	@Success		204		{object}	web.response
+//	@Failure		409		{object}	web.errorResponse
 //	@Router			/api/v1/sellers/{id} [delete]
 func (s *Seller) Delete() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -194,11 +206,55 @@ func (s *Seller) Delete() gin.HandlerFunc {
 			return
 		}
 		ctx := c.Request.Context()
-		err = s.sellerService.Delete(&ctx, int(id))
+
+		dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "parameter dry_run must be a boolean")
+			return
+		}
+		if dryRun {
+			preview, err := s.sellerService.DeletePreview(ctx, id)
+			if err != nil {
+				_ = c.Error(err)
+				return
+			}
+			web.Success(c, http.StatusOK, preview)
+			return
+		}
+
+		err = s.sellerService.Delete(ctx, int(id))
 		if err != nil {
-			web.Error(c, http.StatusNotFound, "Error to delete: %s", err.Error())
+			_ = c.Error(err)
 			return
 		}
 		web.Success(c, http.StatusNoContent, nil)
 	}
 }
+
+// RestoreSellers godoc
+//
+//	@Summary		Restore Sellers
+//	@Tags			Sellers
+//	@Description	Restore a deleted seller
+//	@Produce		json
+//	@Param			id	path		string	true	"ID of the deleted seller"
+//	@Success		200	{object}	web.response
+//	@Router			/api/v1/sellers/{id}/restore [post]
+func (s *Seller) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "Invalid ID: %s", err.Error())
+			return
+		}
+
+		ctx := c.Request.Context()
+		sellerResult, err := s.sellerService.Restore(ctx, id)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		web.Success(c, http.StatusOK, *sellerResult)
+	}
+}
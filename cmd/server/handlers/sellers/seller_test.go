@@ -10,13 +10,17 @@ import (
 	"testing"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/sellers"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
 
 	"github.com/stretchr/testify/assert"
 
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/sellers"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/seller"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/seller/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/validation"
 	"github.com/gin-gonic/gin"
 	_ "github.com/stretchr/testify"
 	"github.com/stretchr/testify/mock"
@@ -31,18 +35,19 @@ func TestGet(t *testing.T) {
 			CID:         1,
 			CompanyName: "Test",
 			Address:     "Test",
-			Telephone:   "Test",
+			Telephone:   "11938473322",
 			LocalityID:  "123",
 		}
 
 		//Configurar o mock do service
 		sellerServiceMock := new(mocks.SellerServiceMock)
-		sellerServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(sellerFound, nil)
+		sellerServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(sellerFound, nil)
 		handler := sellers.NewSeller(sellerServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/sellers/:id", handler.Get())
 
 		//Definir request e response
@@ -72,12 +77,13 @@ func TestGet(t *testing.T) {
 	t.Run("get_find_by_id_non_existent", func(t *testing.T) {
 		//Configurar o mock do service
 		sellerServiceMock := new(mocks.SellerServiceMock)
-		sellerServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(&domain.Seller{}, seller.ErrNotFound)
+		sellerServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(&domain.Seller{}, seller.ErrNotFound)
 		handler := sellers.NewSeller(sellerServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/sellers/:id", handler.Get())
 
 		//Definir request e response
@@ -95,12 +101,13 @@ func TestGet(t *testing.T) {
 	t.Run("get_invalid_id", func(t *testing.T) {
 		//Configurar o mock do service
 		sellerServiceMock := new(mocks.SellerServiceMock)
-		sellerServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(&domain.Seller{}, seller.ErrNotFound)
+		sellerServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(&domain.Seller{}, seller.ErrNotFound)
 		handler := sellers.NewSeller(sellerServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/sellers/:id", handler.Get())
 
 		//Definir request e response
@@ -118,12 +125,13 @@ func TestGet(t *testing.T) {
 	t.Run("get_internal_server_error", func(t *testing.T) {
 		//Configurar o mock do service
 		sellerServiceMock := new(mocks.SellerServiceMock)
-		sellerServiceMock.On("Get", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(&domain.Seller{}, assert.AnError)
+		sellerServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(&domain.Seller{}, assert.AnError)
 		handler := sellers.NewSeller(sellerServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/sellers/:id", handler.Get())
 
 		//Definir request e response
@@ -152,14 +160,14 @@ func TestCreate(t *testing.T) {
 			CID:         1,
 			CompanyName: "Test",
 			Address:     "Test",
-			Telephone:   "Test",
+			Telephone:   "11938473322",
 			LocalityID:  "123",
 		}
 
 		newCID := 1
 		newCompanyName := "Test"
 		newAddress := "Test"
-		newTelephone := "Test"
+		newTelephone := "11938473322"
 		newLocalityID := "123"
 
 		createSellerRequestDTO := dtos.CreateSellerRequestDTO{
@@ -172,12 +180,13 @@ func TestCreate(t *testing.T) {
 
 		//Configurar o mock do service
 		sellerServiceMock := new(mocks.SellerServiceMock)
-		sellerServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("domain.Seller")).Return(expectedSeller, nil)
+		sellerServiceMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Seller")).Return(expectedSeller, nil)
 		handler := sellers.NewSeller(sellerServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/sellers", handler.Create())
 
 		requestBody, _ := json.Marshal(createSellerRequestDTO)
@@ -210,7 +219,7 @@ func TestCreate(t *testing.T) {
 	t.Run("create_bad_request", func(t *testing.T) {
 		newCompanyName := "Test"
 		newAddress := "Test"
-		newTelephone := "Test"
+		newTelephone := "11938473322"
 		newLocalityID := "123"
 
 		createSellerRequestDTO := dtos.CreateSellerRequestDTO{
@@ -221,12 +230,13 @@ func TestCreate(t *testing.T) {
 		}
 		//Configurar o mock do service
 		sellerServiceMock := new(mocks.SellerServiceMock)
-		// sellerServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("domain.Seller")).Return(expectedSeller, nil)
+		// sellerServiceMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Seller")).Return(expectedSeller, nil)
 		handler := sellers.NewSeller(sellerServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/sellers", handler.Create())
 
 		requestBody, _ := json.Marshal(createSellerRequestDTO)
@@ -239,12 +249,14 @@ func TestCreate(t *testing.T) {
 		//Executar request
 		r.ServeHTTP(res, req)
 		type expectedMensageResponseDTO struct {
-			Code    string `json:"code"`
-			Message string `json:"message"`
+			Code    string                  `json:"code"`
+			Message string                  `json:"message"`
+			Details []validation.FieldError `json:"details"`
 		}
 		expectedMensageResponse := expectedMensageResponseDTO{
 			Code:    "unprocessable_entity",
-			Message: "Error to read request: Key: 'CreateSellerRequestDTO.CID' Error:Field validation for 'CID' failed on the 'required' tag",
+			Message: "invalid request body",
+			Details: []validation.FieldError{{Field: "cid", Rule: "required", Message: "is required"}},
 		}
 
 		//Parsear response
@@ -265,7 +277,7 @@ func TestCreate(t *testing.T) {
 		newCID := 1
 		newCompanyName := "Test"
 		newAddress := "Test"
-		newTelephone := "Test"
+		newTelephone := "11938473322"
 		newLocalityID := "123"
 
 		createSellerRequestDTO := dtos.CreateSellerRequestDTO{
@@ -278,12 +290,13 @@ func TestCreate(t *testing.T) {
 
 		//Configurar o mock do service
 		sellerServiceMock := new(mocks.SellerServiceMock)
-		sellerServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("domain.Seller")).Return(&domain.Seller{}, seller.ErrConflict)
+		sellerServiceMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Seller")).Return(&domain.Seller{}, seller.ErrConflict)
 		handler := sellers.NewSeller(sellerServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/sellers", handler.Create())
 
 		requestBody, _ := json.Marshal(createSellerRequestDTO)
@@ -314,7 +327,7 @@ func TestCreate(t *testing.T) {
 		newCID := 1
 		newCompanyName := "Test"
 		newAddress := "Test"
-		newTelephone := "Test"
+		newTelephone := "11938473322"
 		newLocalityID := "123"
 
 		createSellerRequestDTO := dtos.CreateSellerRequestDTO{
@@ -327,12 +340,13 @@ func TestCreate(t *testing.T) {
 
 		//Configurar o mock do service
 		sellerServiceMock := new(mocks.SellerServiceMock)
-		sellerServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("domain.Seller")).Return(&domain.Seller{}, errors.New("error"))
+		sellerServiceMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Seller")).Return(&domain.Seller{}, errors.New("error"))
 		handler := sellers.NewSeller(sellerServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/sellers", handler.Create())
 
 		requestBody, _ := json.Marshal(createSellerRequestDTO)
@@ -362,7 +376,7 @@ func TestCreate(t *testing.T) {
 
 		newCID := 1
 		newAddress := "Test"
-		newTelephone := "Test"
+		newTelephone := "11938473322"
 		newLocalityID := "123"
 
 		createSellerRequestDTO := dtos.CreateSellerRequestDTO{
@@ -379,6 +393,7 @@ func TestCreate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/sellers", handler.Create())
 
 		requestBody, _ := json.Marshal(createSellerRequestDTO)
@@ -400,7 +415,7 @@ func TestCreate(t *testing.T) {
 
 		newCompanyName := "Test"
 		newAddress := "Test"
-		newTelephone := "Test"
+		newTelephone := "11938473322"
 		newLocalityID := "123"
 
 		createSellerRequestDTO := dtos.CreateSellerRequestDTO{
@@ -417,6 +432,7 @@ func TestCreate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/sellers", handler.Create())
 
 		requestBody, _ := json.Marshal(createSellerRequestDTO)
@@ -438,7 +454,7 @@ func TestCreate(t *testing.T) {
 
 		newCID := 1
 		newCompanyName := "Test"
-		newTelephone := "Test"
+		newTelephone := "11938473322"
 		newLocalityID := "123"
 
 		createSellerRequestDTO := dtos.CreateSellerRequestDTO{
@@ -455,6 +471,7 @@ func TestCreate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/sellers", handler.Create())
 
 		requestBody, _ := json.Marshal(createSellerRequestDTO)
@@ -493,6 +510,7 @@ func TestCreate(t *testing.T) {
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/sellers", handler.Create())
 
 		requestBody, _ := json.Marshal(createSellerRequestDTO)
@@ -521,7 +539,7 @@ func TestGetAll(t *testing.T) {
 				CID:         1,
 				CompanyName: "Test",
 				Address:     "Test",
-				Telephone:   "Test",
+				Telephone:   "11938473322",
 				LocalityID:  "123",
 			},
 			{
@@ -529,18 +547,19 @@ func TestGetAll(t *testing.T) {
 				CID:         1,
 				CompanyName: "Test",
 				Address:     "Test",
-				Telephone:   "Test",
+				Telephone:   "11938473322",
 				LocalityID:  "123",
 			},
 		}
 		//Configurar o mock do service
 		sellerServiceMock := new(mocks.SellerServiceMock)
-		sellerServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(sellersFounds, nil)
+		sellerServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(sellersFounds, len(*sellersFounds), nil)
 		handler := sellers.NewSeller(sellerServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/sellers", handler.GetAll())
 
 		//Definir request e response
@@ -569,12 +588,13 @@ func TestGetAll(t *testing.T) {
 		sellersFounds := &[]domain.Seller{}
 		//Configurar o mock do service
 		sellerServiceMock := new(mocks.SellerServiceMock)
-		sellerServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(sellersFounds, nil)
+		sellerServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(sellersFounds, len(*sellersFounds), nil)
 		handler := sellers.NewSeller(sellerServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/sellers", handler.GetAll())
 
 		//Definir request e response
@@ -592,12 +612,13 @@ func TestGetAll(t *testing.T) {
 		sellersFounds := &[]domain.Seller{}
 		//Configurar o mock do service
 		sellerServiceMock := new(mocks.SellerServiceMock)
-		sellerServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(sellersFounds, assert.AnError)
+		sellerServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(sellersFounds, 0, assert.AnError)
 		handler := sellers.NewSeller(sellerServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/sellers", handler.GetAll())
 
 		//Definir request e response
@@ -610,6 +631,84 @@ func TestGetAll(t *testing.T) {
 		//Validar resultado
 		assert.Equal(t, http.StatusInternalServerError, res.Code)
 	})
+
+	t.Run("GetAll_invalid_query", func(t *testing.T) {
+		sellerServiceMock := new(mocks.SellerServiceMock)
+		handler := sellers.NewSeller(sellerServiceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/sellers", handler.GetAll())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/sellers?sort=unknown", nil)
+		res := httptest.NewRecorder()
+
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		sellerServiceMock.AssertNotCalled(t, "GetPage", mock.Anything, mock.Anything)
+	})
+
+	t.Run("GetAll_excludes_deleted", func(t *testing.T) {
+		sellersFounds := &[]domain.Seller{{ID: 1}}
+		sellerServiceMock := new(mocks.SellerServiceMock)
+		sellerServiceMock.On("GetPage", mock.Anything, mock.MatchedBy(func(options listing.Options) bool {
+			return len(options.Filters) == 1 && options.Filters[0].Column == "sellers.deleted_at" && options.Filters[0].Operator == listing.OperatorIsNull
+		})).Return(sellersFounds, 1, nil)
+		handler := sellers.NewSeller(sellerServiceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/sellers", handler.GetAll())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/sellers", nil)
+		res := httptest.NewRecorder()
+
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+	})
+
+	t.Run("GetAll_include_deleted", func(t *testing.T) {
+		sellersFounds := &[]domain.Seller{{ID: 1}}
+		sellerServiceMock := new(mocks.SellerServiceMock)
+		sellerServiceMock.On("GetPage", mock.Anything, mock.MatchedBy(func(options listing.Options) bool {
+			return len(options.Filters) == 0
+		})).Return(sellersFounds, 1, nil)
+		handler := sellers.NewSeller(sellerServiceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/sellers", handler.GetAll())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/sellers?include_deleted=true", nil)
+		res := httptest.NewRecorder()
+
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+	})
+
+	t.Run("GetAll_invalid_include_deleted", func(t *testing.T) {
+		sellerServiceMock := new(mocks.SellerServiceMock)
+		handler := sellers.NewSeller(sellerServiceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/sellers", handler.GetAll())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/sellers?include_deleted=maybe", nil)
+		res := httptest.NewRecorder()
+
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		sellerServiceMock.AssertNotCalled(t, "GetPage", mock.Anything, mock.Anything)
+	})
 }
 
 func TestDelete(t *testing.T) {
@@ -617,12 +716,13 @@ func TestDelete(t *testing.T) {
 
 		//Configurar o mock do service
 		sellerServiceMock := new(mocks.SellerServiceMock)
-		sellerServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(nil)
+		sellerServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(nil)
 		handler := sellers.NewSeller(sellerServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.DELETE("/api/v1/sellers/:id", handler.Delete())
 
 		//Definir request e response
@@ -640,12 +740,13 @@ func TestDelete(t *testing.T) {
 
 		//Configurar o mock do service
 		sellerServiceMock := new(mocks.SellerServiceMock)
-		sellerServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(seller.ErrNotFound)
+		sellerServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(seller.ErrNotFound)
 		handler := sellers.NewSeller(sellerServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.DELETE("/api/v1/sellers/:id", handler.Delete())
 
 		//Definir request e response
@@ -663,12 +764,13 @@ func TestDelete(t *testing.T) {
 
 		//Configurar o mock do service
 		sellerServiceMock := new(mocks.SellerServiceMock)
-		sellerServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(nil)
+		sellerServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(nil)
 		handler := sellers.NewSeller(sellerServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.DELETE("/api/v1/sellers/:id", handler.Delete())
 
 		//Definir request e response
@@ -688,7 +790,7 @@ func TestUpdate(t *testing.T) {
 	t.Run("update_update_ok", func(t *testing.T) {
 		companyName := "Test"
 		address := "Test"
-		telephone := "Test"
+		telephone := "11938473322"
 		localityID := "123"
 
 		//(Poderia utilizar dessa maneira também) -> experirationRate := func (i int) int{return i } (2)
@@ -701,13 +803,14 @@ func TestUpdate(t *testing.T) {
 
 		//Configurar o mock do service
 		sellerServiceMock := new(mocks.SellerServiceMock)
-		sellerServiceMock.On("Update", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int"),
+		sellerServiceMock.On("Update", mock.Anything, mock.AnythingOfType("int"),
 			mock.AnythingOfType("*dtos.UpdateSellerRequestDTO")).Return(&domain.Seller{}, nil)
 		handler := sellers.NewSeller(sellerServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/sellers/:id", handler.Update())
 
 		requestBody, _ := json.Marshal(updateSellerRequestDTO)
@@ -727,7 +830,7 @@ func TestUpdate(t *testing.T) {
 	t.Run("update_non_existent", func(t *testing.T) {
 		companyName := "Test"
 		address := "Test"
-		telephone := "Test"
+		telephone := "11938473322"
 		localityID := "123"
 
 		//(Poderia utilizar dessa maneira também) -> experirationRate := func (i int) int{return i } (2)
@@ -740,12 +843,13 @@ func TestUpdate(t *testing.T) {
 
 		//Configurar o mock do service
 		sellerServiceMock := new(mocks.SellerServiceMock)
-		sellerServiceMock.On("Update", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int"), mock.AnythingOfType("*dtos.UpdateSellerRequestDTO")).Return(&domain.Seller{}, seller.ErrNotFound)
+		sellerServiceMock.On("Update", mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("*dtos.UpdateSellerRequestDTO")).Return(&domain.Seller{}, seller.ErrNotFound)
 		handler := sellers.NewSeller(sellerServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/sellers/:id", handler.Update())
 
 		requestBody, _ := json.Marshal(updateSellerRequestDTO)
@@ -766,7 +870,7 @@ func TestUpdate(t *testing.T) {
 
 		companyName := "Test"
 		address := "Test"
-		telephone := "Test"
+		telephone := "11938473322"
 		localityID := "123"
 
 		//(Poderia utilizar dessa maneira também) -> experirationRate := func (i int) int{return i } (2)
@@ -779,12 +883,13 @@ func TestUpdate(t *testing.T) {
 
 		//Configurar o mock do service
 		sellerServiceMock := new(mocks.SellerServiceMock)
-		sellerServiceMock.On("Update", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("domain.Seller")).Return(&domain.Seller{}, errors.New("error"))
+		sellerServiceMock.On("Update", mock.Anything, mock.AnythingOfType("domain.Seller")).Return(&domain.Seller{}, errors.New("error"))
 		handler := sellers.NewSeller(sellerServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/sellers/:id", handler.Update())
 
 		requestBody, _ := json.Marshal(updateSellerRequestDTO)
@@ -813,7 +918,7 @@ func TestUpdate(t *testing.T) {
 	t.Run("update_conflict", func(t *testing.T) {
 		companyName := "Test"
 		address := "Test"
-		telephone := "Test"
+		telephone := "11938473322"
 		localityID := "123"
 
 		//(Poderia utilizar dessa maneira também) -> experirationRate := func (i int) int{return i } (2)
@@ -826,13 +931,14 @@ func TestUpdate(t *testing.T) {
 
 		//Configurar o mock do service
 		sellerServiceMock := new(mocks.SellerServiceMock)
-		sellerServiceMock.On("Update", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int"),
+		sellerServiceMock.On("Update", mock.Anything, mock.AnythingOfType("int"),
 			mock.AnythingOfType("*dtos.UpdateSellerRequestDTO")).Return(&domain.Seller{}, seller.ErrConflict)
 		handler := sellers.NewSeller(sellerServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/sellers/:id", handler.Update())
 
 		requestBody, _ := json.Marshal(updateSellerRequestDTO)
@@ -850,3 +956,129 @@ func TestUpdate(t *testing.T) {
 	})
 
 }
+
+func TestDeleteDependents(t *testing.T) {
+	t.Run("delete_has_dependents", func(t *testing.T) {
+		serviceMock := new(mocks.SellerServiceMock)
+		serviceMock.On("Delete", mock.Anything, 1).Return(&database.DependentsError{Dependents: map[string]int{"products": 4}})
+		handler := sellers.NewSeller(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.DELETE("/api/v1/sellers/:id", handler.Delete())
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/sellers/1", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusConflict, res.Code)
+		assert.JSONEq(t, `{"code":"conflict","message":"it is referenced by other records: products (4)","details":{"products":4}}`, res.Body.String())
+	})
+
+	t.Run("delete_dry_run", func(t *testing.T) {
+		serviceMock := new(mocks.SellerServiceMock)
+		serviceMock.On("DeletePreview", mock.Anything, 1).Return(&domain.DeletePreview{ID: 1, Dependents: map[string]int{"products": 4}, Deletable: false}, nil)
+		handler := sellers.NewSeller(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.DELETE("/api/v1/sellers/:id", handler.Delete())
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/sellers/1?dry_run=true", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"data":{"id":1,"dependents":{"products":4},"deletable":false}}`, res.Body.String())
+	})
+
+	t.Run("delete_dry_run_non_existent", func(t *testing.T) {
+		serviceMock := new(mocks.SellerServiceMock)
+		serviceMock.On("DeletePreview", mock.Anything, 1).Return((*domain.DeletePreview)(nil), seller.ErrNotFound)
+		handler := sellers.NewSeller(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.DELETE("/api/v1/sellers/:id", handler.Delete())
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/sellers/1?dry_run=true", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("delete_invalid_dry_run", func(t *testing.T) {
+		serviceMock := new(mocks.SellerServiceMock)
+		handler := sellers.NewSeller(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.DELETE("/api/v1/sellers/:id", handler.Delete())
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/sellers/1?dry_run=maybe", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
+
+func TestRestore(t *testing.T) {
+	t.Run("restore_ok", func(t *testing.T) {
+		serviceMock := new(mocks.SellerServiceMock)
+		serviceMock.On("Restore", mock.Anything, 1).Return(&domain.Seller{ID: 1, CID: 10, CompanyName: "Meli"}, nil)
+		handler := sellers.NewSeller(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/sellers/:id/restore", handler.Restore())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/sellers/1/restore", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"data":{"id":1,"cid":10,"company_name":"Meli","address":"","telephone":"","locality_id":""}}`, res.Body.String())
+	})
+
+	t.Run("restore_not_deleted", func(t *testing.T) {
+		serviceMock := new(mocks.SellerServiceMock)
+		serviceMock.On("Restore", mock.Anything, 1).Return((*domain.Seller)(nil), seller.ErrNotFound)
+		handler := sellers.NewSeller(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/sellers/:id/restore", handler.Restore())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/sellers/1/restore", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("restore_invalid_id", func(t *testing.T) {
+		serviceMock := new(mocks.SellerServiceMock)
+		serviceMock.On("Restore", mock.Anything, 1).Return((*domain.Seller)(nil), seller.ErrNotFound)
+		handler := sellers.NewSeller(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/sellers/:id/restore", handler.Restore())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/sellers/xyz/restore", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		serviceMock.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,195 @@
+package users
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/user"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/user"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserHandler struct {
+	userService user.UserService
+}
+
+func NewUserHandler(userService user.UserService) *UserHandler {
+	return &UserHandler{
+		userService,
+	}
+}
+
+// Login is the handler to authenticate a user and issue an access token.
+//
+//	@Summary		Login
+//	@Tags			Auth
+//	@Description	Check the credentials of a User and return a signed token to be sent as "Authorization: Bearer <token>".
+//	@Accept			json
+//	@Produce		json
+//	@Param			Credentials	body		dtos.LoginRequestDTO	true	"Username and password"
+//	@Success		200			{object}	dtos.LoginResponseDTO
+//	@Failure		401			{object}	web.errorResponse
+//	@Failure		422			{object}	web.errorResponse
+//	@Failure		500			{object}	web.errorResponse
+//	@Router			/api/v1/auth/login [post]
+func (handler *UserHandler) Login() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		var loginRequest dtos.LoginRequestDTO
+		if err := c.ShouldBindJSON(&loginRequest); err != nil {
+			web.ValidationError(c, err)
+			return
+		}
+
+		ctx := c.Request.Context()
+		if loginResponse, err := handler.userService.Login(ctx, loginRequest); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Response(c, http.StatusOK, loginResponse)
+			return
+		}
+	}
+}
+
+// Get is the handler to search for a user and return their details.
+//
+//	@Summary		Get User
+//	@Tags			Users
+//	@Description	Get the username and roles of a User
+//	@Produce		json
+//	@Param			id	path		string	true	"ID of User to be searched"
+//	@Success		200	{object}	domain.User
+//	@Failure		400	{object}	web.errorResponse
+//	@Failure		404	{object}	web.errorResponse
+//	@Failure		500	{object}	web.errorResponse
+//	@Router			/api/v1/users/{id} [get]
+func (handler *UserHandler) Get() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		id, err := getIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ctx := c.Request.Context()
+		if userFound, err := handler.userService.Get(ctx, id); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Response(c, http.StatusOK, userFound)
+			return
+		}
+	}
+}
+
+// GetAll is the handler to search for all users.
+//
+//	@Summary		List Users
+//	@Tags			Users
+//	@Description	Get the username and roles of all users on the database.
+//	@Produce		json
+//	@Success		200	{object}	web.response{data=[]domain.User}
+//	@Failure		500	{object}	web.errorResponse
+//	@Router			/api/v1/users [get]
+func (handler *UserHandler) GetAll() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		ctx := c.Request.Context()
+		if users, err := handler.userService.GetAll(ctx); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			if len(users) == 0 {
+				web.Success(c, http.StatusNoContent, users)
+				return
+			}
+			web.Success(c, http.StatusOK, users)
+			return
+		}
+	}
+}
+
+// Create is the handler to register a user.
+//
+//	@Summary		Create User
+//	@Tags			Users
+//	@Description	Save a user with a hashed password and its roles on the database.
+//	@Accept			json
+//	@Produce		json
+//	@Param			User	body		dtos.CreateUserRequestDTO	true	"User to Create"
+//	@Success		201		{object}	domain.User
+//	@Failure		409		{object}	web.errorResponse
+//	@Failure		422		{object}	web.errorResponse
+//	@Failure		500		{object}	web.errorResponse
+//	@Router			/api/v1/users [post]
+func (handler *UserHandler) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		var createUserRequest dtos.CreateUserRequestDTO
+		if err := c.ShouldBindJSON(&createUserRequest); err != nil {
+			web.ValidationError(c, err)
+			return
+		}
+
+		ctx := c.Request.Context()
+		if createdUser, err := handler.userService.Create(ctx, createUserRequest.ToDomain()); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Response(c, http.StatusCreated, createdUser)
+			return
+		}
+	}
+}
+
+// Delete is the handler to delete a user.
+//
+//	@Summary		Delete User
+//	@Tags			Users
+//	@Description	Delete a User and its roles
+//	@Produce		json
+//	@Param			id	path	string	true	"ID of a User to be excluded"
+//	@Success		204
+//	@Failure		400	{object}	web.errorResponse
+//	@Failure		404	{object}	web.errorResponse
+//	@Failure		500	{object}	web.errorResponse
+//	@Router			/api/v1/users/{id} [delete]
+func (handler *UserHandler) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		id, err := getIdFromUri(c)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		ctx := c.Request.Context()
+		if err := handler.userService.Delete(ctx, id); err != nil {
+			_ = c.Error(err)
+			return
+		} else {
+			web.Response(c, http.StatusNoContent, nil)
+			return
+		}
+	}
+}
+
+func getIdFromUri(c *gin.Context) (id int, err error) {
+
+	value, _ := c.Params.Get("id")
+	id, err = strconv.Atoi(value)
+
+	if err != nil {
+		err = errors.New(fmt.Sprintf("Invalid id on request: %s", c.Request.RequestURI))
+		return
+	}
+
+	return
+
+}
@@ -0,0 +1,291 @@
+package users_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/users"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/user"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/user/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLogin(t *testing.T) {
+
+	loginResponse := dtos.LoginResponseDTO{
+		Token:     "header.payload.signature",
+		ExpiresAt: time.Date(2023, 7, 6, 16, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name               string
+		body               string
+		expectedLoginCalls int
+		expectedLoginError error
+		expectedCode       int
+	}{
+		{
+			name:               "Success login",
+			body:               `{"username": "user1", "password": "password1"}`,
+			expectedLoginCalls: 1,
+			expectedCode:       http.StatusOK,
+		},
+		{
+			name:               "Error invalid credentials",
+			body:               `{"username": "user1", "password": "wrong"}`,
+			expectedLoginCalls: 1,
+			expectedLoginError: errors.ErrUnauthorized,
+			expectedCode:       http.StatusUnauthorized,
+		},
+		{
+			name:               "Error connecting db",
+			body:               `{"username": "user1", "password": "password1"}`,
+			expectedLoginCalls: 1,
+			expectedLoginError: assert.AnError,
+			expectedCode:       http.StatusInternalServerError,
+		},
+		{
+			name:         "Error missing password",
+			body:         `{"username": "user1"}`,
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			userServiceMock := mocks.NewMockUserService(t)
+			userServiceMock.On("Login", mock.Anything, mock.AnythingOfType("dtos.LoginRequestDTO")).Return(loginResponse, test.expectedLoginError)
+
+			userHandler := users.NewUserHandler(userServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.POST("/api/v1/auth/login", userHandler.Login())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewBufferString(test.body))
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			userServiceMock.AssertNumberOfCalls(t, "Login", test.expectedLoginCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			if test.expectedCode == http.StatusOK {
+				body, _ := io.ReadAll(res.Body)
+				var response dtos.LoginResponseDTO
+				json.Unmarshal(body, &response)
+
+				assert.Equal(t, loginResponse, response)
+			}
+		})
+	}
+}
+
+func TestGet(t *testing.T) {
+
+	userSerialized, _ := os.ReadFile("../../../../test/resources/valid_user.json")
+	var validUser domain.User
+	if err := json.Unmarshal(userSerialized, &validUser); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name             string
+		id               string
+		expectedGetCalls int
+		expectedGetError error
+		expectedCode     int
+	}{
+		{
+			name:             "Success finding user",
+			id:               "1",
+			expectedGetCalls: 1,
+			expectedCode:     http.StatusOK,
+		},
+		{
+			name:             "Error finding user",
+			id:               "999",
+			expectedGetCalls: 1,
+			expectedGetError: errors.ErrNotFound,
+			expectedCode:     http.StatusNotFound,
+		},
+		{
+			name:         "Error invalid id",
+			id:           "xyz",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			userServiceMock := mocks.NewMockUserService(t)
+			userServiceMock.On("Get", mock.Anything, mock.AnythingOfType("int")).Return(validUser, test.expectedGetError)
+
+			userHandler := users.NewUserHandler(userServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.GET("/api/v1/users/:id", userHandler.Get())
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s/%s", "/api/v1/users", test.id), nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			userServiceMock.AssertNumberOfCalls(t, "Get", test.expectedGetCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+
+			if test.expectedCode == http.StatusOK {
+				body, _ := io.ReadAll(res.Body)
+				assert.NotContains(t, string(body), "passoword")
+
+				var response domain.User
+				json.Unmarshal(body, &response)
+
+				assert.Equal(t, validUser, response)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+
+	userSerialized, _ := os.ReadFile("../../../../test/resources/valid_user.json")
+	var validUser domain.User
+	if err := json.Unmarshal(userSerialized, &validUser); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                string
+		body                string
+		expectedCreateCalls int
+		expectedCreateError error
+		expectedCode        int
+	}{
+		{
+			name:                "Success creating user",
+			body:                `{"username": "user1", "password": "password1", "roles": ["admin"]}`,
+			expectedCreateCalls: 1,
+			expectedCode:        http.StatusCreated,
+		},
+		{
+			name:                "Error username already exists",
+			body:                `{"username": "user1", "password": "password1", "roles": ["admin"]}`,
+			expectedCreateCalls: 1,
+			expectedCreateError: errors.ErrConflict,
+			expectedCode:        http.StatusConflict,
+		},
+		{
+			name:                "Error unknown role",
+			body:                `{"username": "user1", "password": "password1", "roles": ["guest"]}`,
+			expectedCreateCalls: 1,
+			expectedCreateError: errors.ErrInvalidRole,
+			expectedCode:        http.StatusUnprocessableEntity,
+		},
+		{
+			name:         "Error short password",
+			body:         `{"username": "user1", "password": "short", "roles": ["admin"]}`,
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:         "Error password longer than bcrypt accepts",
+			body:         `{"username": "user1", "password": "` + strings.Repeat("a", 73) + `", "roles": ["admin"]}`,
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:         "Error missing roles",
+			body:         `{"username": "user1", "password": "password1", "roles": []}`,
+			expectedCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			userServiceMock := mocks.NewMockUserService(t)
+			userServiceMock.On("Create", mock.Anything, mock.AnythingOfType("domain.User")).Return(validUser, test.expectedCreateError)
+
+			userHandler := users.NewUserHandler(userServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.POST("/api/v1/users", userHandler.Create())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/users", bytes.NewBufferString(test.body))
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			userServiceMock.AssertNumberOfCalls(t, "Create", test.expectedCreateCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+
+	tests := []struct {
+		name                string
+		id                  string
+		expectedDeleteCalls int
+		expectedDeleteError error
+		expectedCode        int
+	}{
+		{
+			name:                "Success deleting user",
+			id:                  "1",
+			expectedDeleteCalls: 1,
+			expectedCode:        http.StatusNoContent,
+		},
+		{
+			name:                "Error user not found",
+			id:                  "999",
+			expectedDeleteCalls: 1,
+			expectedDeleteError: errors.ErrNotFound,
+			expectedCode:        http.StatusNotFound,
+		},
+		{
+			name:         "Error invalid id",
+			id:           "xyz",
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			userServiceMock := mocks.NewMockUserService(t)
+			userServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(test.expectedDeleteError)
+
+			userHandler := users.NewUserHandler(userServiceMock)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			r.Use(middlewares.Errors())
+			r.DELETE("/api/v1/users/:id", userHandler.Delete())
+
+			req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("%s/%s", "/api/v1/users", test.id), nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			userServiceMock.AssertNumberOfCalls(t, "Delete", test.expectedDeleteCalls)
+			assert.Equal(t, test.expectedCode, res.Code)
+		})
+	}
+}
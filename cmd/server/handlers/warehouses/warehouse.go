@@ -1,16 +1,23 @@
 package warehouses
 
 import (
-	"errors"
 	"net/http"
 	"strconv"
 
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/warehousesdto"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/employee"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/section"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/warehouse"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/export"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
 	"github.com/gin-gonic/gin"
 )
 
+// defaultSummaryDays is how far back the warehouse summary counts inbound orders when days is not informed.
+const defaultSummaryDays = 30
+
 type Warehouse struct {
 	warehouseService warehouse.Service
 }
@@ -39,10 +46,10 @@ func (w *Warehouse) Get() gin.HandlerFunc {
 			return
 		}
 
-		result, err := w.warehouseService.GetOne(&ctx, warehouseId)
+		result, err := w.warehouseService.GetOne(ctx, warehouseId)
 
 		if err != nil {
-			web.Error(c, http.StatusNotFound, err.Error())
+			_ = c.Error(err)
 			return
 		}
 
@@ -55,15 +62,41 @@ func (w *Warehouse) Get() gin.HandlerFunc {
 //	@Summary		List warehouses
 //	@Tags			Warehouses
 //	@Description	get warehouses
-//	@Produce		json
-//	@Success		200	{object}	[]domain.Warehouse
+//	@Produce		json,text/csv,application/x-ndjson
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			offset	query		int		false	"Number of warehouses to skip"
+//	@Param			sort	query		string	false	"Field to sort by"
+//	@Param			order	query		string	false	"asc or desc"
+//	@Param			format	query		string	false	"json, csv or ndjson, or use the Accept header"
+//	@Param			include_deleted	query		bool	false	"List deleted records too"
+//	@Success		200		{object}	[]domain.Warehouse
 //	@Router			/api/v1/warehouses [get]
 func (w *Warehouse) GetAll() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		options, err := listing.ParseSoftDeleted(c.Request.URL.Query(), warehouse.Fields, "deleted_at")
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "Invalid query: %s", err.Error())
+			return
+		}
+
+		format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		if format != export.JSON {
+			web.Export(c, format, domain.Warehouse{}, func(write func(row interface{}) error) error {
+				return w.warehouseService.Each(c.Request.Context(), options.Unpaged(), func(row domain.Warehouse) error {
+					return write(row)
+				})
+			})
+			return
+		}
+
 		ctx := c.Request.Context()
-		warehouses, err := w.warehouseService.GetAll(&ctx)
+		warehouses, total, err := w.warehouseService.GetPage(ctx, options)
 		if err != nil {
-			web.Error(c, http.StatusInternalServerError, "Failed to get warehouses: %s", err.Error())
+			_ = c.Error(err)
 			return
 		}
 
@@ -72,7 +105,7 @@ func (w *Warehouse) GetAll() gin.HandlerFunc {
 			return
 		}
 
-		web.Success(c, http.StatusOK, *warehouses)
+		web.Page(c, http.StatusOK, *warehouses, total, options)
 	}
 }
 
@@ -83,25 +116,21 @@ func (w *Warehouse) GetAll() gin.HandlerFunc {
 //	@Description	Create warehouses
 //	@Accept			json
 //	@Produce		json
-//	@Param			Warehouse	body		dtos.WarehouseRequestDTO	true	"warehouses to create"
+//	@Param			Warehouse	body		dtos.CreateWarehouseRequestDTO	true	"warehouses to create"
 //	@Success		200			{object}	domain.Warehouse
 //	@Router			/api/v1/warehouses [post]
 func (w *Warehouse) Create() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		var req dtos.WarehouseRequestDTO
+		var req dtos.CreateWarehouseRequestDTO
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, "JSON format may be wrong")
+			web.ValidationError(c, err)
 			return
 		}
 		ctx := c.Request.Context()
-		if err := WarehouseFullRequestValidator(c, req); err != nil {
-			web.Error(c, http.StatusBadRequest, err.Error())
-			return
-		}
-		result, e := w.warehouseService.Create(&ctx, req)
+		result, e := w.warehouseService.Create(ctx, dtos.WarehouseRequestDTO(req))
 
 		if e != nil {
-			web.Error(c, http.StatusConflict, e.Error())
+			_ = c.Error(e)
 			return
 		}
 		web.Success(c, http.StatusCreated, result)
@@ -129,14 +158,155 @@ func (w *Warehouse) Update() gin.HandlerFunc {
 		}
 
 		if err := c.ShouldBindJSON(&req); err != nil {
-			web.Error(c, http.StatusUnprocessableEntity, "JSON format may be wrong")
+			web.ValidationError(c, err)
+			return
+		}
+
+		result, err := w.warehouseService.Update(ctx, warehouseId, req)
+
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		web.Success(c, http.StatusOK, result)
+	}
+}
+
+// GetWarehouseInventory godoc
+//
+//	@Summary		Warehouse inventory
+//	@Tags			Warehouses
+//	@Description	get the sections of a warehouse with their batches, quantities and capacity utilisation
+//	@Produce		json
+//	@Param			id	path		int	true	"Warehouse ID"
+//	@Success		200	{object}	domain.WarehouseInventory
+//	@Router			/api/v1/warehouses/{id}/inventory [get]
+func (w *Warehouse) GetInventory() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		warehouseId, e := strconv.Atoi(c.Param("id"))
+		ctx := c.Request.Context()
+		if e != nil {
+			web.Error(c, http.StatusBadRequest, "parameter id must be a integer")
+			return
+		}
+
+		result, err := w.warehouseService.GetInventory(ctx, warehouseId)
+
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		web.Success(c, http.StatusOK, result)
+	}
+}
+
+// GetWarehouseEmployees godoc
+//
+//	@Summary		List warehouse employees
+//	@Tags			Warehouses
+//	@Description	get the employees of a warehouse
+//	@Produce		json
+//	@Param			id		path		int		true	"Warehouse ID"
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			offset	query		int		false	"Number of employees to skip"
+//	@Param			sort	query		string	false	"Field to sort by"
+//	@Param			order	query		string	false	"asc or desc"
+//	@Param			include_deleted	query		bool	false	"List deleted records too"
+//	@Success		200		{object}	[]domain.Employee
+//	@Router			/api/v1/warehouses/{id}/employees [get]
+func (w *Warehouse) GetEmployees() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		warehouseId, e := strconv.Atoi(c.Param("id"))
+		if e != nil {
+			web.Error(c, http.StatusBadRequest, "parameter id must be a integer")
+			return
+		}
+
+		options, err := listing.ParseSoftDeleted(c.Request.URL.Query(), employee.Fields, "deleted_at")
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "Invalid query: %s", err.Error())
+			return
+		}
+
+		employees, total, err := w.warehouseService.GetEmployees(c.Request.Context(), warehouseId, options)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		web.Page(c, http.StatusOK, *employees, total, options)
+	}
+}
+
+// GetWarehouseSections godoc
+//
+//	@Summary		List warehouse sections
+//	@Tags			Warehouses
+//	@Description	get the sections of a warehouse
+//	@Produce		json
+//	@Param			id		path		int		true	"Warehouse ID"
+//	@Param			limit	query		int		false	"Page size"
+//	@Param			offset	query		int		false	"Number of sections to skip"
+//	@Param			sort	query		string	false	"Field to sort by"
+//	@Param			order	query		string	false	"asc or desc"
+//	@Success		200		{object}	[]domain.Section
+//	@Router			/api/v1/warehouses/{id}/sections [get]
+func (w *Warehouse) GetSections() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		warehouseId, e := strconv.Atoi(c.Param("id"))
+		if e != nil {
+			web.Error(c, http.StatusBadRequest, "parameter id must be a integer")
 			return
 		}
 
-		result, err := w.warehouseService.Update(&ctx, warehouseId, req)
+		options, err := listing.Parse(c.Request.URL.Query(), section.Fields)
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "Invalid query: %s", err.Error())
+			return
+		}
 
+		sections, total, err := w.warehouseService.GetSections(c.Request.Context(), warehouseId, options)
 		if err != nil {
-			web.Error(c, http.StatusNotFound, err.Error())
+			_ = c.Error(err)
+			return
+		}
+
+		web.Page(c, http.StatusOK, *sections, total, options)
+	}
+}
+
+// GetWarehouseSummary godoc
+//
+//	@Summary		Warehouse summary
+//	@Tags			Warehouses
+//	@Description	count the employees and the inbound orders of the last days of a warehouse, with the fill rate of its sections
+//	@Produce		json
+//	@Param			id		path		int	true	"Warehouse ID"
+//	@Param			days	query		int	false	"Number of days of inbound orders, 30 by default"
+//	@Success		200		{object}	domain.WarehouseSummary
+//	@Router			/api/v1/warehouses/{id}/summary [get]
+func (w *Warehouse) GetSummary() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		warehouseId, e := strconv.Atoi(c.Param("id"))
+		if e != nil {
+			web.Error(c, http.StatusBadRequest, "parameter id must be a integer")
+			return
+		}
+
+		days := defaultSummaryDays
+		if daysParam := c.Query("days"); daysParam != "" {
+			days, e = strconv.Atoi(daysParam)
+			if e != nil || days < 0 {
+				web.Error(c, http.StatusBadRequest, "parameter days must be a non-negative integer")
+				return
+			}
+		}
+
+		result, err := w.warehouseService.GetSummary(c.Request.Context(), warehouseId, days)
+		if err != nil {
+			_ = c.Error(err)
 			return
 		}
 
@@ -147,8 +317,11 @@ func (w *Warehouse) Update() gin.HandlerFunc {
 // @Summary		Delete warehouses
 // @Tags			Warehouses
 // @Description	delete warehouses by id
-// @Param			id	path	int	true	"Warehouse ID"
+// @Param			id		path	int		true	"Warehouse ID"
+// @Param			dry_run	query	bool	false	"Only preview the records referencing the warehouse"
+// @Success		200		{object}	domain.DeletePreview
 // @Success		204
+// @Failure		409		{object}	web.errorResponse
 // @Router			/api/v1/warehouses/{id} [delete]
 func (w *Warehouse) Delete() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -159,10 +332,25 @@ func (w *Warehouse) Delete() gin.HandlerFunc {
 			return
 		}
 
-		err := w.warehouseService.Delete(&ctx, warehouseId)
+		dryRun, err := strconv.ParseBool(c.DefaultQuery("dry_run", "false"))
+		if err != nil {
+			web.Error(c, http.StatusBadRequest, "parameter dry_run must be a boolean")
+			return
+		}
+		if dryRun {
+			preview, err := w.warehouseService.DeletePreview(ctx, warehouseId)
+			if err != nil {
+				_ = c.Error(err)
+				return
+			}
+			web.Success(c, http.StatusOK, preview)
+			return
+		}
+
+		err = w.warehouseService.Delete(ctx, warehouseId)
 
 		if err != nil {
-			web.Error(c, http.StatusNotFound, err.Error())
+			_ = c.Error(err)
 			return
 		}
 
@@ -170,22 +358,27 @@ func (w *Warehouse) Delete() gin.HandlerFunc {
 	}
 }
 
-func WarehouseFullRequestValidator(c *gin.Context, req dtos.WarehouseRequestDTO) error {
-	if req.Address == "" {
-		return errors.New("field address is required")
-	}
-	if req.Telephone == "" {
-		return errors.New("field telephone is required")
-	}
-	if req.WarehouseCode == "" {
-		return errors.New("field warehouse_code is required")
-	}
-	if req.MinimumCapacity == 0 {
-		return errors.New("field minimum_capacity is required")
-	}
-	if req.MinimumTemperature == 0 {
-		return errors.New("field minimum_temperature is required")
-	}
+// @Summary		Restore warehouses
+// @Tags			Warehouses
+// @Description	restore a deleted warehouse
+// @Param			id	path	int	true	"Warehouse ID"
+// @Success		200	{object}	domain.Warehouse
+// @Failure		404	{object}	web.errorResponse
+// @Router			/api/v1/warehouses/{id}/restore [post]
+func (w *Warehouse) Restore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		warehouseId, e := strconv.Atoi(c.Param("id"))
+		if e != nil {
+			web.Error(c, http.StatusBadRequest, "parameter id must be a integer")
+			return
+		}
 
-	return nil
+		result, err := w.warehouseService.Restore(c.Request.Context(), warehouseId)
+		if err != nil {
+			_ = c.Error(err)
+			return
+		}
+
+		web.Success(c, http.StatusOK, result)
+	}
 }
@@ -10,9 +10,11 @@ import (
 	"testing"
 
 	warehouse_handler "github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/warehouses"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/warehouse"
 
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/warehousesdto"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/warehouse/mocks"
 	"github.com/gin-gonic/gin"
@@ -33,12 +35,13 @@ func TestGet(t *testing.T) {
 
 		//Configurar o mock do service
 		warehouseServiceMock := new(mocks.WarehouseServiceMock)
-		warehouseServiceMock.On("GetOne", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(warehouseFound, nil)
+		warehouseServiceMock.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(warehouseFound, nil)
 		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
 
 		//Configurar o servidor
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/warehouses/:id", handler.Get())
 
 		//Definir request e response
@@ -67,11 +70,12 @@ func TestGet(t *testing.T) {
 
 	t.Run("find_by_id_non_existent", func(t *testing.T) {
 		warehouseServiceMock := new(mocks.WarehouseServiceMock)
-		warehouseServiceMock.On("GetOne", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(&domain.Warehouse{}, warehouse.ErrNotFound)
+		warehouseServiceMock.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(&domain.Warehouse{}, warehouse.ErrNotFound)
 		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/warehouses/:id", handler.Get())
 
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/warehouses/1", nil)
@@ -84,11 +88,12 @@ func TestGet(t *testing.T) {
 	t.Run("get_invalid_id", func(t *testing.T) {
 
 		warehouseServiceMock := new(mocks.WarehouseServiceMock)
-		warehouseServiceMock.On("GetOne", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(&domain.Warehouse{}, assert.AnError)
+		warehouseServiceMock.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(&domain.Warehouse{}, assert.AnError)
 		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/warehouses/:id", handler.Get())
 
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/warehouses/xyz", nil)
@@ -102,11 +107,12 @@ func TestGet(t *testing.T) {
 	t.Run("not_found_error", func(t *testing.T) {
 
 		warehouseServiceMock := new(mocks.WarehouseServiceMock)
-		warehouseServiceMock.On("GetOne", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(&domain.Warehouse{}, assert.AnError)
+		warehouseServiceMock.On("GetOne", mock.Anything, mock.AnythingOfType("int")).Return(&domain.Warehouse{}, warehouse.ErrNotFound)
 		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/warehouses/:id", handler.Get())
 
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/warehouses/1", nil)
@@ -126,6 +132,7 @@ func TestCreate(t *testing.T) {
 			WarehouseCode:      "CX-2281-TCD",
 			MinimumCapacity:    12,
 			MinimumTemperature: 18,
+			LocalityID:         1,
 		}
 		createWarehouseRequestDTO := dtos.WarehouseRequestDTO{
 			Address:            "Rua Teste",
@@ -133,13 +140,15 @@ func TestCreate(t *testing.T) {
 			WarehouseCode:      "CX-2281-TCD",
 			MinimumCapacity:    12,
 			MinimumTemperature: 18,
+			LocalityID:         1,
 		}
 		warehouseServiceMock := new(mocks.WarehouseServiceMock)
-		warehouseServiceMock.On("Create", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("dtos.WarehouseRequestDTO")).Return(expectedWarehouse, nil)
+		warehouseServiceMock.On("Create", mock.Anything, mock.AnythingOfType("dtos.WarehouseRequestDTO")).Return(expectedWarehouse, nil)
 		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/warehouses", handler.Create())
 
 		requestBody, _ := json.Marshal(createWarehouseRequestDTO)
@@ -160,25 +169,6 @@ func TestCreate(t *testing.T) {
 		assert.Equal(t, http.StatusCreated, res.Code)
 		assert.Equal(t, *expectedWarehouse, *actualWarehouse)
 	})
-	t.Run("create_fail", func(t *testing.T) {
-		createWarehouseRequestDTO := dtos.WarehouseRequestDTO{
-			Address:            "Rua Teste",
-			Telephone:          "11938473125",
-			MinimumCapacity:    12,
-			MinimumTemperature: 18,
-		}
-
-		warehouseServiceMock := new(mocks.WarehouseServiceMock)
-		warehouseServiceMock.On("Create", mock.AnythingOfType("*context.Context")).Return(createWarehouseRequestDTO, warehouse.ErrUnprocessableEntity)
-		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
-		gin.SetMode(gin.TestMode)
-		r := gin.Default()
-		r.POST("/api/v1/warehouses", handler.Create())
-		req := httptest.NewRequest(http.MethodPost, "/api/v1/warehouses", nil)
-		res := httptest.NewRecorder()
-		r.ServeHTTP(res, req)
-		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
-	})
 	t.Run("create_conflit", func(t *testing.T) {
 		expectedWarehouse := &domain.Warehouse{}
 		createWarehouseRequestDTO := dtos.WarehouseRequestDTO{
@@ -187,13 +177,15 @@ func TestCreate(t *testing.T) {
 			WarehouseCode:      "CX-2281-TCD",
 			MinimumCapacity:    12,
 			MinimumTemperature: 18,
+			LocalityID:         1,
 		}
 		warehouseServiceMock := new(mocks.WarehouseServiceMock)
-		warehouseServiceMock.On("Create", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("dtos.WarehouseRequestDTO")).Return(expectedWarehouse, warehouse.ErrConflict)
+		warehouseServiceMock.On("Create", mock.Anything, mock.AnythingOfType("dtos.WarehouseRequestDTO")).Return(expectedWarehouse, warehouse.ErrConflict)
 		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/warehouses", handler.Create())
 
 		requestBody, _ := json.Marshal(createWarehouseRequestDTO)
@@ -212,12 +204,14 @@ func TestCreate(t *testing.T) {
 			WarehouseCode:      "CX-2281-TCD",
 			MinimumCapacity:    12,
 			MinimumTemperature: 18,
+			LocalityID:         1,
 		}
 
 		warehouseServiceMock := new(mocks.WarehouseServiceMock)
 		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/warehouses", handler.Create())
 
 		requestBody, _ := json.Marshal(createWarehouseRequestDTO)
@@ -226,7 +220,7 @@ func TestCreate(t *testing.T) {
 		res := httptest.NewRecorder()
 		r.ServeHTTP(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
 	})
 
 	t.Run("create_fail_telephone_nil", func(t *testing.T) {
@@ -236,12 +230,14 @@ func TestCreate(t *testing.T) {
 			WarehouseCode:      "CX-2281-TCD",
 			MinimumCapacity:    12,
 			MinimumTemperature: 18,
+			LocalityID:         1,
 		}
 
 		warehouseServiceMock := new(mocks.WarehouseServiceMock)
 		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/warehouses", handler.Create())
 
 		requestBody, _ := json.Marshal(createWarehouseRequestDTO)
@@ -250,7 +246,7 @@ func TestCreate(t *testing.T) {
 		res := httptest.NewRecorder()
 		r.ServeHTTP(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
 	})
 
 	t.Run("create_fail_warehouse_code_nil", func(t *testing.T) {
@@ -260,12 +256,14 @@ func TestCreate(t *testing.T) {
 			Telephone:          "11938473125",
 			MinimumCapacity:    12,
 			MinimumTemperature: 18,
+			LocalityID:         1,
 		}
 
 		warehouseServiceMock := new(mocks.WarehouseServiceMock)
 		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/warehouses", handler.Create())
 
 		requestBody, _ := json.Marshal(createWarehouseRequestDTO)
@@ -274,7 +272,7 @@ func TestCreate(t *testing.T) {
 		res := httptest.NewRecorder()
 		r.ServeHTTP(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
 	})
 	t.Run("create_fail_minimum_capacity_nil", func(t *testing.T) {
 
@@ -283,12 +281,14 @@ func TestCreate(t *testing.T) {
 			Telephone:          "11938473125",
 			WarehouseCode:      "CX-2281-TCD",
 			MinimumTemperature: 18,
+			LocalityID:         1,
 		}
 
 		warehouseServiceMock := new(mocks.WarehouseServiceMock)
 		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/warehouses", handler.Create())
 
 		requestBody, _ := json.Marshal(createWarehouseRequestDTO)
@@ -297,7 +297,7 @@ func TestCreate(t *testing.T) {
 		res := httptest.NewRecorder()
 		r.ServeHTTP(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
 	})
 	t.Run("create_fail_minimum_temperature_nil", func(t *testing.T) {
 
@@ -312,6 +312,7 @@ func TestCreate(t *testing.T) {
 		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/warehouses", handler.Create())
 
 		requestBody, _ := json.Marshal(createWarehouseRequestDTO)
@@ -320,7 +321,7 @@ func TestCreate(t *testing.T) {
 		res := httptest.NewRecorder()
 		r.ServeHTTP(res, req)
 
-		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
 	})
 	t.Run("create_internal_server_error", func(t *testing.T) {
 
@@ -330,13 +331,15 @@ func TestCreate(t *testing.T) {
 			WarehouseCode:      "CX-2281-TCD",
 			MinimumCapacity:    12,
 			MinimumTemperature: 18,
+			LocalityID:         1,
 		}
 		warehouseServiceMock := new(mocks.WarehouseServiceMock)
-		warehouseServiceMock.On("Save", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("domain.Warehouse")).Return(&domain.Warehouse{}, errors.New("error"))
+		warehouseServiceMock.On("Save", mock.Anything, mock.AnythingOfType("domain.Warehouse")).Return(&domain.Warehouse{}, errors.New("error"))
 		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.POST("/api/v1/Warehouses", handler.Create())
 
 		requestBody, _ := json.Marshal(createWarehouseRequestDTO)
@@ -378,11 +381,12 @@ func TestGetAll(t *testing.T) {
 			},
 		}
 		warehouseServiceMock := new(mocks.WarehouseServiceMock)
-		warehouseServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(warehousesFounds, nil)
+		warehouseServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(warehousesFounds, len(*warehousesFounds), nil)
 		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/warehouses", handler.GetAll())
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/warehouses", nil)
 		res := httptest.NewRecorder()
@@ -403,10 +407,11 @@ func TestGetAll(t *testing.T) {
 	t.Run("empty_database", func(t *testing.T) {
 		warehousesFounds := &[]domain.Warehouse{}
 		warehouseServiceMock := new(mocks.WarehouseServiceMock)
-		warehouseServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(warehousesFounds, nil)
+		warehouseServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(warehousesFounds, len(*warehousesFounds), nil)
 		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/warehouses", handler.GetAll())
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/warehouses", nil)
 		res := httptest.NewRecorder()
@@ -418,11 +423,12 @@ func TestGetAll(t *testing.T) {
 	t.Run("internal_server_error", func(t *testing.T) {
 		warehousesFounds := &[]domain.Warehouse{}
 		warehouseServiceMock := new(mocks.WarehouseServiceMock)
-		warehouseServiceMock.On("GetAll", mock.AnythingOfType("*context.Context")).Return(warehousesFounds, assert.AnError)
+		warehouseServiceMock.On("GetPage", mock.Anything, mock.AnythingOfType("listing.Options")).Return(warehousesFounds, 0, assert.AnError)
 		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.GET("/api/v1/warehouses", handler.GetAll())
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/warehouses", nil)
 		res := httptest.NewRecorder()
@@ -431,17 +437,36 @@ func TestGetAll(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, res.Code)
 
 	})
+
+	t.Run("getAll_invalid_query", func(t *testing.T) {
+		serviceMock := new(mocks.WarehouseServiceMock)
+		handler := warehouse_handler.NewWarehouse(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/warehouses", handler.GetAll())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/warehouses?limit=0", nil)
+		res := httptest.NewRecorder()
+
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		serviceMock.AssertNotCalled(t, "GetPage", mock.Anything, mock.Anything)
+	})
 }
 
 func TestDelete(t *testing.T) {
 	t.Run("delete_delete_ok", func(t *testing.T) {
 
 		warehouseServiceMock := new(mocks.WarehouseServiceMock)
-		warehouseServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(nil)
+		warehouseServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(nil)
 		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.DELETE("/api/v1/warehouses/:id", handler.Delete())
 
 		req := httptest.NewRequest(http.MethodDelete, "/api/v1/warehouses/1", nil)
@@ -454,10 +479,11 @@ func TestDelete(t *testing.T) {
 
 	t.Run("delete_non_existent", func(t *testing.T) {
 		warehouseServiceMock := new(mocks.WarehouseServiceMock)
-		warehouseServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(warehouse.ErrNotFound, nil)
+		warehouseServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(warehouse.ErrNotFound, nil)
 		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.DELETE("/api/v1/warehouses/:id", handler.Delete())
 		req := httptest.NewRequest(http.MethodDelete, "/api/v1/warehouses/1", nil)
 		res := httptest.NewRecorder()
@@ -468,11 +494,12 @@ func TestDelete(t *testing.T) {
 	t.Run("delete_error_parsing_id", func(t *testing.T) {
 
 		WarehouseServiceMock := new(mocks.WarehouseServiceMock)
-		WarehouseServiceMock.On("Delete", mock.AnythingOfType("*context.Context"), mock.AnythingOfType("int")).Return(nil)
+		WarehouseServiceMock.On("Delete", mock.Anything, mock.AnythingOfType("int")).Return(nil)
 		handler := warehouse_handler.NewWarehouse(WarehouseServiceMock)
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.DELETE("/api/v1/warehouses/:id", handler.Delete())
 		req := httptest.NewRequest(http.MethodDelete, "/api/v1/warehouses/xyz", nil)
 		res := httptest.NewRecorder()
@@ -511,6 +538,7 @@ func TestUpdate(t *testing.T) {
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/warehouses/:id", handler.Update())
 
 		requestBody, _ := json.Marshal(updateWarehouseRequest)
@@ -543,6 +571,7 @@ func TestUpdate(t *testing.T) {
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/warehouses/:id", handler.Update())
 
 		req := httptest.NewRequest(http.MethodPatch, "/api/v1/warehouses/1", nil)
@@ -576,6 +605,7 @@ func TestUpdate(t *testing.T) {
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/warehouses/:id", handler.Update())
 
 		requestBody, _ := json.Marshal(updateWarehouseRequest)
@@ -609,6 +639,7 @@ func TestUpdate(t *testing.T) {
 
 		gin.SetMode(gin.TestMode)
 		r := gin.Default()
+		r.Use(middlewares.Errors())
 		r.PATCH("/api/v1/warehouses/:id", handler.Update())
 
 		requestBody, _ := json.Marshal(updateWarehouseRequest)
@@ -623,3 +654,301 @@ func TestUpdate(t *testing.T) {
 	})
 
 }
+
+func TestLocality(t *testing.T) {
+	t.Run("create_fail_locality_id_nil", func(t *testing.T) {
+		createWarehouseRequestDTO := dtos.WarehouseRequestDTO{
+			Address:            "Rua Teste",
+			Telephone:          "11938473125",
+			WarehouseCode:      "CX-2281-TCD",
+			MinimumCapacity:    12,
+			MinimumTemperature: 18,
+		}
+
+		warehouseServiceMock := new(mocks.WarehouseServiceMock)
+		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/warehouses", handler.Create())
+
+		requestBody, _ := json.Marshal(createWarehouseRequestDTO)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/warehouses", bytes.NewReader(requestBody))
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+	})
+	t.Run("update_locality_not_found", func(t *testing.T) {
+		warehouseServiceMock := new(mocks.WarehouseServiceMock)
+		warehouseServiceMock.On("Update", mock.Anything, mock.Anything).Return((*domain.Warehouse)(nil), warehouse.ErrLocalityNotFound)
+		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.PATCH("/api/v1/warehouses/:id", handler.Update())
+
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/warehouses/1", bytes.NewBufferString(`{"locality_id": 99}`))
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+	})
+}
+
+func TestGetInventory(t *testing.T) {
+	t.Run("inventory_ok", func(t *testing.T) {
+		expectedInventory := &domain.WarehouseInventory{
+			Warehouse:       domain.Warehouse{ID: 1, WarehouseCode: "CX-2281-TCD", LocalityID: 1},
+			SectionsCount:   1,
+			MaximumCapacity: 100,
+			Sections:        []domain.SectionInventory{{SectionID: 1, MaximumCapacity: 100, Batches: []domain.InventoryBatch{}}},
+		}
+		warehouseServiceMock := new(mocks.WarehouseServiceMock)
+		warehouseServiceMock.On("GetInventory", mock.Anything, 1).Return(expectedInventory, nil)
+		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/warehouses/:id/inventory", handler.GetInventory())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/warehouses/1/inventory", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		var responseDTO struct {
+			Data *domain.WarehouseInventory `json:"data"`
+		}
+		json.Unmarshal(res.Body.Bytes(), &responseDTO)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, *expectedInventory, *responseDTO.Data)
+	})
+	t.Run("inventory_not_found", func(t *testing.T) {
+		warehouseServiceMock := new(mocks.WarehouseServiceMock)
+		warehouseServiceMock.On("GetInventory", mock.Anything, 99).Return((*domain.WarehouseInventory)(nil), warehouse.ErrNotFound)
+		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/warehouses/:id/inventory", handler.GetInventory())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/warehouses/99/inventory", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+	t.Run("inventory_invalid_id", func(t *testing.T) {
+		warehouseServiceMock := new(mocks.WarehouseServiceMock)
+		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/warehouses/:id/inventory", handler.GetInventory())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/warehouses/a/inventory", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
+
+func TestWarehouseViews(t *testing.T) {
+	t.Run("employees_ok", func(t *testing.T) {
+		warehouseServiceMock := new(mocks.WarehouseServiceMock)
+		warehouseServiceMock.On("GetEmployees", mock.Anything, 1, mock.AnythingOfType("listing.Options")).Return(&[]domain.Employee{{ID: 1, WarehouseID: 1}}, 1, nil)
+		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/warehouses/:id/employees", handler.GetEmployees())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/warehouses/1/employees", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Contains(t, res.Body.String(), `"total":1`)
+	})
+	t.Run("sections_not_found", func(t *testing.T) {
+		warehouseServiceMock := new(mocks.WarehouseServiceMock)
+		warehouseServiceMock.On("GetSections", mock.Anything, 99, mock.AnythingOfType("listing.Options")).Return((*[]domain.Section)(nil), 0, warehouse.ErrNotFound)
+		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/warehouses/:id/sections", handler.GetSections())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/warehouses/99/sections", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+	t.Run("summary_default_days", func(t *testing.T) {
+		warehouseServiceMock := new(mocks.WarehouseServiceMock)
+		warehouseServiceMock.On("GetSummary", mock.Anything, 1, 30).Return(&domain.WarehouseSummary{WarehouseID: 1, Days: 30}, nil)
+		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/warehouses/:id/summary", handler.GetSummary())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/warehouses/1/summary", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+	})
+	t.Run("summary_invalid_days", func(t *testing.T) {
+		warehouseServiceMock := new(mocks.WarehouseServiceMock)
+		handler := warehouse_handler.NewWarehouse(warehouseServiceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/warehouses/:id/summary", handler.GetSummary())
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/warehouses/1/summary?days=x", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		warehouseServiceMock.AssertNotCalled(t, "GetSummary", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeleteDependents(t *testing.T) {
+	t.Run("delete_has_dependents", func(t *testing.T) {
+		serviceMock := new(mocks.WarehouseServiceMock)
+		serviceMock.On("Delete", mock.Anything, 1).Return(&database.DependentsError{Dependents: map[string]int{"sections": 2, "employees": 3}})
+		handler := warehouse_handler.NewWarehouse(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.DELETE("/api/v1/warehouses/:id", handler.Delete())
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/warehouses/1", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusConflict, res.Code)
+		assert.JSONEq(t, `{"code":"conflict","message":"it is referenced by other records: employees (3), sections (2)","details":{"employees":3,"sections":2}}`, res.Body.String())
+	})
+
+	t.Run("delete_dry_run", func(t *testing.T) {
+		serviceMock := new(mocks.WarehouseServiceMock)
+		serviceMock.On("DeletePreview", mock.Anything, 1).Return(&domain.DeletePreview{ID: 1, Dependents: map[string]int{"sections": 2, "employees": 3}, Deletable: false}, nil)
+		handler := warehouse_handler.NewWarehouse(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.DELETE("/api/v1/warehouses/:id", handler.Delete())
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/warehouses/1?dry_run=true", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"data":{"id":1,"dependents":{"employees":3,"sections":2},"deletable":false}}`, res.Body.String())
+	})
+
+	t.Run("delete_dry_run_non_existent", func(t *testing.T) {
+		serviceMock := new(mocks.WarehouseServiceMock)
+		serviceMock.On("DeletePreview", mock.Anything, 1).Return((*domain.DeletePreview)(nil), warehouse.ErrNotFound)
+		handler := warehouse_handler.NewWarehouse(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.DELETE("/api/v1/warehouses/:id", handler.Delete())
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/warehouses/1?dry_run=true", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("delete_invalid_dry_run", func(t *testing.T) {
+		serviceMock := new(mocks.WarehouseServiceMock)
+		handler := warehouse_handler.NewWarehouse(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.DELETE("/api/v1/warehouses/:id", handler.Delete())
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/v1/warehouses/1?dry_run=maybe", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+	})
+}
+
+func TestRestore(t *testing.T) {
+	t.Run("restore_ok", func(t *testing.T) {
+		serviceMock := new(mocks.WarehouseServiceMock)
+		serviceMock.On("Restore", mock.Anything, 1).Return(&domain.Warehouse{ID: 1, WarehouseCode: "W1", LocalityID: 3}, nil)
+		handler := warehouse_handler.NewWarehouse(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/warehouses/:id/restore", handler.Restore())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/warehouses/1/restore", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"data":{"id":1,"address":"","telephone":"","warehouse_code":"W1","minimum_capacity":0,"minimum_temperature":0,"locality_id":3}}`, res.Body.String())
+	})
+
+	t.Run("restore_not_deleted", func(t *testing.T) {
+		serviceMock := new(mocks.WarehouseServiceMock)
+		serviceMock.On("Restore", mock.Anything, 1).Return((*domain.Warehouse)(nil), warehouse.ErrNotFound)
+		handler := warehouse_handler.NewWarehouse(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/warehouses/:id/restore", handler.Restore())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/warehouses/1/restore", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusNotFound, res.Code)
+	})
+
+	t.Run("restore_invalid_id", func(t *testing.T) {
+		serviceMock := new(mocks.WarehouseServiceMock)
+		serviceMock.On("Restore", mock.Anything, 1).Return((*domain.Warehouse)(nil), warehouse.ErrNotFound)
+		handler := warehouse_handler.NewWarehouse(serviceMock)
+
+		gin.SetMode(gin.TestMode)
+		r := gin.Default()
+		r.Use(middlewares.Errors())
+		r.POST("/api/v1/warehouses/:id/restore", handler.Restore())
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/warehouses/xyz/restore", nil)
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		serviceMock.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+}
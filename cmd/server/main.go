@@ -1,12 +1,21 @@
 package main
 
 import (
-	"database/sql"
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/routes"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/docs"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/config"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/logs"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/auth"
 	"github.com/gin-gonic/gin"
-	_ "github.com/go-sql-driver/mysql"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
@@ -24,21 +33,64 @@ import (
 
 // @host	localhost:8080
 func main() {
-	// NO MODIFICAR
-	db, err := sql.Open("mysql", "meli_sprint_user:Meli_Sprint#123@/melisprint")
-	if err != nil {
+	if err := run(); err != nil {
 		panic(err)
 	}
+}
+
+// run serves the API until SIGINT or SIGTERM, then drains the in-flight requests
+// within the configured deadline before flushing the logs and closing the database.
+func run() error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := config.Load(os.Getenv("CONFIG_FILE"))
+	if err != nil {
+		return err
+	}
+
+	db, err := database.Open(ctx, cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
 
 	eng := gin.Default()
 
-	docs.SwaggerInfo.Host = "localhost:8080"
+	docs.SwaggerInfo.Host = cfg.Swagger.Host
 	eng.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	router := routes.NewRouter(eng, db)
+	tokens := auth.NewJWT([]byte(cfg.Auth.JWTSecret), cfg.Auth.TokenTTL)
+
+	logger := logs.NewLogger(logs.NewLogRepository(db), 1024, 100, time.Second)
+	defer logger.Close()
+
+	router := routes.NewRouter(eng, db, tokens, logger, cfg.Server.RequestTimeout, cfg.Server.StreamTimeout)
 	router.MapRoutes()
 
-	if err := eng.Run(); err != nil {
-		panic(err)
+	server := &http.Server{
+		Addr:         cfg.Server.Address,
+		Handler:      eng,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
 	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Printf("shutting down, draining requests for up to %s", cfg.Server.ShutdownTimeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	return server.Shutdown(shutdownCtx)
 }
@@ -0,0 +1,70 @@
+package middlewares
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/auth"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClaimsKey is the gin context key holding the auth.Claims of the authenticated user.
+const ClaimsKey = "claims"
+
+const bearerPrefix = "Bearer "
+
+// Authenticate rejects requests without a valid "Authorization: Bearer <token>" header
+// and stores the claims of the token on the context for the next handlers.
+func Authenticate(tokens *auth.JWT) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		header := c.GetHeader("Authorization")
+		if !strings.HasPrefix(header, bearerPrefix) {
+			web.Error(c, http.StatusUnauthorized, "missing bearer token")
+			c.Abort()
+			return
+		}
+
+		claims, err := tokens.Parse(strings.TrimPrefix(header, bearerPrefix))
+		if err != nil {
+			web.Error(c, http.StatusUnauthorized, err.Error())
+			c.Abort()
+			return
+		}
+
+		c.Set(ClaimsKey, claims)
+		c.Next()
+	}
+}
+
+// Authorize rejects requests whose roles may not use the HTTP method on the requested resource.
+// The resource is the first path segment after prefix, e.g. "inbound-orders" for /api/v1/inbound-orders/:id.
+// It must run after Authenticate.
+func Authorize(prefix string, permissions auth.Permissions) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		value, _ := c.Get(ClaimsKey)
+		claims, ok := value.(auth.Claims)
+		if !ok {
+			web.Error(c, http.StatusUnauthorized, "missing bearer token")
+			c.Abort()
+			return
+		}
+
+		resource := resourceOf(prefix, c.FullPath())
+		if !permissions.Allows(claims.Roles, resource, c.Request.Method) {
+			web.Error(c, http.StatusForbidden, "%s %s is not allowed for roles %v", c.Request.Method, resource, claims.Roles)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func resourceOf(prefix string, path string) string {
+	path = strings.Trim(strings.TrimPrefix(path, prefix), "/")
+	return strings.SplitN(path, "/", 2)[0]
+}
@@ -0,0 +1,118 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/auth"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthenticateAndAuthorize(t *testing.T) {
+	tokens := auth.NewJWT([]byte("secret"), time.Hour)
+	permissions := auth.Permissions{
+		"admin": {
+			auth.Any: {auth.Any},
+		},
+		"analyst": {
+			auth.Any: {http.MethodGet},
+		},
+		"operator": {
+			"inbound-orders": {http.MethodPost},
+		},
+	}
+
+	sign := func(roles ...string) string {
+		token, _, err := tokens.Sign(1, "user1", roles)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return "Bearer " + token
+	}
+	otherToken, _, _ := auth.NewJWT([]byte("another secret"), time.Hour).Sign(1, "user1", []string{"admin"})
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		authorization string
+		expectedCode  int
+	}{
+		{
+			name:          "Admin creates a section",
+			method:        http.MethodPost,
+			path:          "/api/v1/sections/",
+			authorization: sign("admin"),
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:          "Analyst reads an inbound order",
+			method:        http.MethodGet,
+			path:          "/api/v1/inbound-orders/1",
+			authorization: sign("analyst"),
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:          "Analyst cannot create a section",
+			method:        http.MethodPost,
+			path:          "/api/v1/sections/",
+			authorization: sign("analyst"),
+			expectedCode:  http.StatusForbidden,
+		},
+		{
+			name:          "Operator creates an inbound order",
+			method:        http.MethodPost,
+			path:          "/api/v1/inbound-orders",
+			authorization: sign("operator"),
+			expectedCode:  http.StatusOK,
+		},
+		{
+			name:          "Operator cannot read an inbound order",
+			method:        http.MethodGet,
+			path:          "/api/v1/inbound-orders/1",
+			authorization: sign("operator"),
+			expectedCode:  http.StatusForbidden,
+		},
+		{
+			name:         "Error missing token",
+			method:       http.MethodGet,
+			path:         "/api/v1/inbound-orders/1",
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:          "Error token signed with another secret",
+			method:        http.MethodGet,
+			path:          "/api/v1/inbound-orders/1",
+			authorization: "Bearer " + otherToken,
+			expectedCode:  http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			r := gin.Default()
+			rg := r.Group("/api/v1")
+			rg.Use(middlewares.Authenticate(tokens), middlewares.Authorize(rg.BasePath(), permissions))
+
+			ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+			rg.POST("/sections/", ok)
+			rg.POST("/inbound-orders", ok)
+			rg.GET("/inbound-orders/:id", ok)
+
+			req := httptest.NewRequest(test.method, test.path, nil)
+			if test.authorization != "" {
+				req.Header.Set("Authorization", test.authorization)
+			}
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			assert.Equal(t, test.expectedCode, res.Code)
+		})
+	}
+}
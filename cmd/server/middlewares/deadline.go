@@ -0,0 +1,46 @@
+package middlewares
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/export"
+	"github.com/gin-gonic/gin"
+)
+
+// Deadline bounds every request to timeout, and the streaming ones, bulk imports and exports, to streamTimeout
+// instead, so a large upload is not rolled back and a large export is not cut off for taking longer than a regular request.
+// Repositories run their queries with the request context,
+// so the database work of slow requests and of clients that disconnect is cancelled.
+// A zero timeout leaves the request context untouched.
+func Deadline(timeout time.Duration, streamTimeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := timeout
+		if streaming(c) {
+			limit = streamTimeout
+		}
+
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), limit)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// streaming tells the requests whose body can take long to read or write, the bulk imports and the lists
+// and reports answered as CSV or NDJSON.
+func streaming(c *gin.Context) bool {
+	if strings.HasSuffix(c.FullPath(), "/import") {
+		return true
+	}
+
+	format, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+	return err == nil && format != export.JSON
+}
@@ -0,0 +1,105 @@
+package middlewares_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadline(t *testing.T) {
+	tests := []struct {
+		name          string
+		path          string
+		query         string
+		timeout       time.Duration
+		streamTimeout time.Duration
+		work          time.Duration
+		wantDeadline  bool
+		wantErr       error
+	}{
+		{
+			name:         "Fast request keeps running",
+			timeout:      time.Second,
+			wantDeadline: true,
+		},
+		{
+			name:         "Slow request is cancelled",
+			timeout:      10 * time.Millisecond,
+			work:         time.Second,
+			wantDeadline: true,
+			wantErr:      context.DeadlineExceeded,
+		},
+		{
+			name:    "Zero timeout has no deadline",
+			timeout: 0,
+		},
+		{
+			name:          "Import is bounded by the stream timeout",
+			path:          "/api/v1/sections/import",
+			timeout:       10 * time.Millisecond,
+			streamTimeout: time.Second,
+			work:          50 * time.Millisecond,
+			wantDeadline:  true,
+		},
+		{
+			name:          "Export is bounded by the stream timeout",
+			path:          "/api/v1/sections",
+			query:         "?format=csv",
+			timeout:       10 * time.Millisecond,
+			streamTimeout: time.Second,
+			work:          50 * time.Millisecond,
+			wantDeadline:  true,
+		},
+		{
+			name:    "Import without stream timeout has no deadline",
+			path:    "/api/v1/sections/import",
+			timeout: 10 * time.Millisecond,
+			work:    50 * time.Millisecond,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				hasDeadline bool
+				err         error
+			)
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			path := test.path
+			if path == "" {
+				path = "/api/v1/sections"
+			}
+
+			r.Use(middlewares.Deadline(test.timeout, test.streamTimeout))
+			r.GET(path, func(c *gin.Context) {
+				ctx := c.Request.Context()
+				_, hasDeadline = ctx.Deadline()
+				if test.work > 0 {
+					select {
+					case <-ctx.Done():
+						err = ctx.Err()
+					case <-time.After(test.work):
+					}
+				}
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, path+test.query, nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			assert.Equal(t, test.wantDeadline, hasDeadline)
+			assert.True(t, errors.Is(err, test.wantErr))
+		})
+	}
+}
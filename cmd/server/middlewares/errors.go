@@ -0,0 +1,68 @@
+package middlewares
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errorDetails tells which entity and field a domain error is about.
+type errorDetails struct {
+	Entity string `json:"entity,omitempty"`
+	Field  string `json:"field,omitempty"`
+}
+
+// kindStatus is the response status of each kind of domain error.
+var kindStatus = map[errors2.Kind]int{
+	errors2.KindNotFound:     http.StatusNotFound,
+	errors2.KindConflict:     http.StatusConflict,
+	errors2.KindValidation:   http.StatusUnprocessableEntity,
+	errors2.KindForeignKey:   http.StatusUnprocessableEntity,
+	errors2.KindUnauthorized: http.StatusUnauthorized,
+}
+
+// Errors writes the response of handlers that only added an error to the context with c.Error.
+// Domain errors get the status of their kind along with their entity and field, a delete blocked by
+// dependents is a 409 detailing them, a missing row is a 404 and anything else a 500 whose message
+// does not expose the cause.
+func Errors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		c.Next()
+
+		if c.Writer.Written() || len(c.Errors) == 0 {
+			return
+		}
+
+		err := c.Errors.Last().Err
+
+		var domainErr *errors2.Error
+		var dependentsErr *database.DependentsError
+		switch {
+		case errors.As(err, &domainErr):
+			status, ok := kindStatus[domainErr.Kind]
+			if !ok {
+				status = http.StatusInternalServerError
+			}
+			var details interface{}
+			if domainErr.Entity != "" || domainErr.Field != "" {
+				details = errorDetails{Entity: domainErr.Entity, Field: domainErr.Field}
+			}
+			web.ErrorWithDetails(c, status, details, "%s", domainErr.Error())
+		case errors.As(err, &dependentsErr):
+			web.ErrorWithDetails(c, http.StatusConflict, dependentsErr.Dependents, "%s", dependentsErr.Error())
+		case errors.Is(err, sql.ErrNoRows):
+			web.Error(c, http.StatusNotFound, "record not found")
+		default:
+			web.Error(c, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+			// The request logger reports the last error, which has to stay the cause rather than the generic message.
+			_ = c.Error(err)
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package middlewares_test
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
+	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             error
+		expectedCode    int
+		expectedBody    string
+		expectedMessage string
+	}{
+		{
+			name:            "Not found",
+			err:             errors2.NotFound("seller"),
+			expectedCode:    http.StatusNotFound,
+			expectedBody:    `{"code":"not_found","message":"seller not found","details":{"entity":"seller"}}`,
+			expectedMessage: "seller not found",
+		},
+		{
+			name:            "Wrapped conflict",
+			err:             fmt.Errorf("saving: %w", errors2.Conflict("seller", "cid")),
+			expectedCode:    http.StatusConflict,
+			expectedBody:    `{"code":"conflict","message":"seller with this cid already exists","details":{"entity":"seller","field":"cid"}}`,
+			expectedMessage: "seller with this cid already exists",
+		},
+		{
+			name:            "Validation",
+			err:             errors2.Validation("seller", "cid", "cid must be positive"),
+			expectedCode:    http.StatusUnprocessableEntity,
+			expectedBody:    `{"code":"unprocessable_entity","message":"cid must be positive","details":{"entity":"seller","field":"cid"}}`,
+			expectedMessage: "cid must be positive",
+		},
+		{
+			name:            "Message with a percent sign",
+			err:             errors2.Validation("section", "current_capacity", "current_capacity can't be over 100%"),
+			expectedCode:    http.StatusUnprocessableEntity,
+			expectedBody:    `{"code":"unprocessable_entity","message":"current_capacity can't be over 100%","details":{"entity":"section","field":"current_capacity"}}`,
+			expectedMessage: "current_capacity can't be over 100%",
+		},
+		{
+			name:            "Foreign key",
+			err:             errors2.ForeignKey("purchase order", "buyer_id"),
+			expectedCode:    http.StatusUnprocessableEntity,
+			expectedBody:    `{"code":"unprocessable_entity","message":"buyer_id references a record that does not exist","details":{"entity":"purchase order","field":"buyer_id"}}`,
+			expectedMessage: "buyer_id references a record that does not exist",
+		},
+		{
+			name:            "Unauthorized",
+			err:             errors2.ErrUnauthorized,
+			expectedCode:    http.StatusUnauthorized,
+			expectedBody:    `{"code":"unauthorized","message":"invalid username or password","details":{"entity":"user"}}`,
+			expectedMessage: "invalid username or password",
+		},
+		{
+			name:            "Dependents",
+			err:             &database.DependentsError{Dependents: map[string]int{"products": 2}},
+			expectedCode:    http.StatusConflict,
+			expectedBody:    `{"code":"conflict","message":"it is referenced by other records: products (2)","details":{"products":2}}`,
+			expectedMessage: "it is referenced by other records: products (2)",
+		},
+		{
+			name:            "Kind only",
+			err:             errors2.ErrNotFound,
+			expectedCode:    http.StatusNotFound,
+			expectedBody:    `{"code":"not_found","message":"record not found"}`,
+			expectedMessage: "record not found",
+		},
+		{
+			name:            "Missing row",
+			err:             sql.ErrNoRows,
+			expectedCode:    http.StatusNotFound,
+			expectedBody:    `{"code":"not_found","message":"record not found"}`,
+			expectedMessage: "record not found",
+		},
+		{
+			name:            "Unexpected error hides the cause",
+			err:             sql.ErrConnDone,
+			expectedCode:    http.StatusInternalServerError,
+			expectedBody:    `{"code":"internal_server_error","message":"Internal Server Error"}`,
+			expectedMessage: sql.ErrConnDone.Error(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logger := &entryLoggerStub{}
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.Use(middlewares.RequestLogger(logger), middlewares.Errors())
+			r.GET("/api/v1/sellers/:id", func(c *gin.Context) { _ = c.Error(test.err) })
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/sellers/1", nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			assert.Equal(t, test.expectedCode, res.Code)
+			assert.JSONEq(t, test.expectedBody, res.Body.String())
+			assert.Len(t, logger.entries, 1)
+			assert.Equal(t, test.expectedMessage, logger.entries[0].Message)
+		})
+	}
+
+	t.Run("Responses written by the handler are kept", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		r := gin.New()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/sellers/:id", func(c *gin.Context) { web.Error(c, http.StatusBadRequest, "invalid id") })
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/sellers/x", nil)
+		res := httptest.NewRecorder()
+
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusBadRequest, res.Code)
+		assert.JSONEq(t, `{"code":"bad_request","message":"invalid id"}`, res.Body.String())
+	})
+
+	t.Run("No error", func(t *testing.T) {
+		gin.SetMode(gin.TestMode)
+		r := gin.New()
+		r.Use(middlewares.Errors())
+		r.GET("/api/v1/sellers/:id", func(c *gin.Context) { web.Success(c, http.StatusOK, domain.Seller{ID: 1}) })
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/sellers/1", nil)
+		res := httptest.NewRecorder()
+
+		r.ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusOK, res.Code)
+	})
+}
@@ -0,0 +1,70 @@
+package middlewares
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxLogFieldLength is the size of the VARCHAR columns of the logs table.
+const maxLogFieldLength = 255
+
+// EntryLogger receives the log entry of each request. Implementations must not block.
+type EntryLogger interface {
+	Log(entry domain.Log)
+}
+
+// RequestLogger records the method, route, status and error message of every request once it is handled.
+func RequestLogger(logger EntryLogger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+
+		c.Next()
+
+		label := c.FullPath()
+		if label == "" {
+			label = c.Request.URL.Path
+		}
+
+		status := c.Writer.Status()
+		message := http.StatusText(status)
+		if len(c.Errors) > 0 {
+			message = c.Errors.Last().Error()
+		}
+
+		logger.Log(domain.Log{
+			Method:     c.Request.Method,
+			Label:      truncate(label),
+			Level:      levelOf(status),
+			Message:    truncate(message),
+			Status:     status,
+			InsertDate: time.Now(),
+		})
+	}
+}
+
+func levelOf(status int) string {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return domain.LogLevelError
+	case status >= http.StatusBadRequest:
+		return domain.LogLevelWarning
+	default:
+		return domain.LogLevelInfo
+	}
+}
+
+// truncate cuts value to maxLogFieldLength characters, the columns count characters rather than bytes and a
+// multi-byte character is never split.
+func truncate(value string) string {
+	count := 0
+	for i := range value {
+		if count == maxLogFieldLength {
+			return value[:i]
+		}
+		count++
+	}
+	return value
+}
@@ -0,0 +1,117 @@
+package middlewares_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/web"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type entryLoggerStub struct {
+	entries []domain.Log
+}
+
+func (s *entryLoggerStub) Log(entry domain.Log) {
+	s.entries = append(s.entries, entry)
+}
+
+func TestRequestLogger(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		expected domain.Log
+	}{
+		{
+			name:   "Successful request",
+			method: http.MethodGet,
+			path:   "/api/v1/sections/1",
+			expected: domain.Log{
+				Method:  http.MethodGet,
+				Label:   "/api/v1/sections/:id",
+				Level:   domain.LogLevelInfo,
+				Message: "OK",
+				Status:  http.StatusOK,
+			},
+		},
+		{
+			name:   "Client error keeps the error message",
+			method: http.MethodPost,
+			path:   "/api/v1/sections/",
+			expected: domain.Log{
+				Method:  http.MethodPost,
+				Label:   "/api/v1/sections/",
+				Level:   domain.LogLevelWarning,
+				Message: "section already exists",
+				Status:  http.StatusConflict,
+			},
+		},
+		{
+			name:   "Server error is truncated to the column size",
+			method: http.MethodDelete,
+			path:   "/api/v1/sections/1",
+			expected: domain.Log{
+				Method:  http.MethodDelete,
+				Label:   "/api/v1/sections/:id",
+				Level:   domain.LogLevelError,
+				Message: strings.Repeat("x", 255),
+				Status:  http.StatusInternalServerError,
+			},
+		},
+		{
+			name:   "Truncation keeps whole multi-byte characters",
+			method: http.MethodPatch,
+			path:   "/api/v1/sections/1",
+			expected: domain.Log{
+				Method:  http.MethodPatch,
+				Label:   "/api/v1/sections/:id",
+				Level:   domain.LogLevelError,
+				Message: strings.Repeat("ã", 255),
+				Status:  http.StatusInternalServerError,
+			},
+		},
+		{
+			name:   "Unmatched route uses the request path",
+			method: http.MethodGet,
+			path:   "/api/v1/unknown",
+			expected: domain.Log{
+				Method:  http.MethodGet,
+				Label:   "/api/v1/unknown",
+				Level:   domain.LogLevelWarning,
+				Message: "Not Found",
+				Status:  http.StatusNotFound,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			logger := &entryLoggerStub{}
+
+			gin.SetMode(gin.TestMode)
+			r := gin.New()
+			r.Use(middlewares.RequestLogger(logger))
+			r.GET("/api/v1/sections/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+			r.POST("/api/v1/sections/", func(c *gin.Context) { web.Error(c, http.StatusConflict, "section already exists") })
+			r.DELETE("/api/v1/sections/:id", func(c *gin.Context) { web.Error(c, http.StatusInternalServerError, strings.Repeat("x", 300)) })
+			r.PATCH("/api/v1/sections/:id", func(c *gin.Context) { web.Error(c, http.StatusInternalServerError, strings.Repeat("ã", 300)) })
+
+			req := httptest.NewRequest(test.method, test.path, nil)
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, req)
+
+			assert.Len(t, logger.entries, 1)
+			got := logger.entries[0]
+			assert.False(t, got.InsertDate.IsZero())
+			got.InsertDate = test.expected.InsertDate
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
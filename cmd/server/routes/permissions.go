@@ -0,0 +1,27 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/auth"
+)
+
+// rolePermissions lists, for each role on the roles table, the methods it may use on each
+// resource of the API. Resources are the first path segment after /api/v1.
+var rolePermissions = auth.Permissions{
+	"admin": {
+		auth.Any: {auth.Any},
+	},
+	"analyst": {
+		"users":  {},
+		auth.Any: {http.MethodGet},
+	},
+	"employee": {
+		"users":  {},
+		"logs":   {},
+		auth.Any: {http.MethodGet},
+	},
+	"operator": {
+		"inbound-orders": {http.MethodPost},
+	},
+}
@@ -2,14 +2,29 @@ package routes
 
 import (
 	"database/sql"
+	"time"
 
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/health"
+	logHandlers "github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/logs"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/users"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/middlewares"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/logs"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/user"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/auth"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/countries"
 	handlers "github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/localities"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/provinces"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/purchase_orders"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/country"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/locality"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/province"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/purchaseOrder"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/buyers"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/carriers"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/imports"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/inbound_orders"
 	productbatcheshandler "github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/product_batches_handler"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/products"
@@ -18,13 +33,10 @@ import (
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/sellers"
 	warehouse2 "github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/warehouses"
 
-	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/buyer"
 	carrier "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/carriers"
 	inbound_order "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/inbound_order"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/productRecord"
 	prodBatches "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/productbatches"
-	"github.com/gin-gonic/gin/binding"
-	"github.com/go-playground/validator/v10"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/employees"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/buyer"
@@ -41,18 +53,36 @@ type Router interface {
 }
 
 type router struct {
-	eng *gin.Engine
-	rg  *gin.RouterGroup
-	db  *sql.DB
+	eng    *gin.Engine
+	rg     *gin.RouterGroup
+	db     *sql.DB
+	tokens *auth.JWT
+	logger *logs.Logger
+	// requestTimeout bounds every request and streamTimeout the streaming ones, see middlewares.Deadline.
+	requestTimeout time.Duration
+	streamTimeout  time.Duration
 }
 
-func NewRouter(eng *gin.Engine, db *sql.DB) Router {
-	return &router{eng: eng, db: db}
+func NewRouter(eng *gin.Engine, db *sql.DB, tokens *auth.JWT, logger *logs.Logger, requestTimeout time.Duration, streamTimeout time.Duration) Router {
+	return &router{eng: eng, db: db, tokens: tokens, logger: logger, requestTimeout: requestTimeout, streamTimeout: streamTimeout}
 }
 
 func (r *router) MapRoutes() {
+	// Probes are mapped before the request logger so they don't flood the logs table.
+	r.buildHealthRoutes()
+
+	r.eng.Use(
+		middlewares.RequestLogger(r.logger),
+		middlewares.Deadline(r.requestTimeout, r.streamTimeout),
+		middlewares.Errors(),
+	)
+
+	r.buildAuthRoutes()
 	r.setGroup()
 
+	r.buildUserRoutes()
+	r.buildLogRoutes()
+
 	r.buildSellerRoutes()
 	r.buildProductRoutes()
 	r.buildSectionRoutes()
@@ -64,12 +94,59 @@ func (r *router) MapRoutes() {
 	r.buildCarriersRoutes()
 	r.buildProductRecordsRoutes()
 	r.buildPurchaseOrderRoutes()
+	r.buildCountryRoutes()
+	r.buildProvinceRoutes()
 	r.buildLocalityRoutes()
 
 }
 
+// setGroup creates the /api/v1 group, where every route requires a token
+// whose roles are allowed by rolePermissions.
 func (r *router) setGroup() {
 	r.rg = r.eng.Group("/api/v1")
+	r.rg.Use(
+		middlewares.Authenticate(r.tokens),
+		middlewares.Authorize(r.rg.BasePath(), rolePermissions),
+	)
+}
+
+func (r *router) buildHealthRoutes() {
+	healthHandler := health.NewHealthHandler(map[string]health.Check{
+		"database": r.db.PingContext,
+	})
+
+	r.eng.GET("/healthz", healthHandler.Liveness())
+	r.eng.GET("/readyz", healthHandler.Readiness())
+}
+
+// buildAuthRoutes maps the public login route, outside of the authenticated group.
+func (r *router) buildAuthRoutes() {
+	userRepository := user.NewUserRepository(r.db)
+	userService := user.NewUserService(userRepository, r.tokens, database.NewUnitOfWork(r.db))
+	userHandler := users.NewUserHandler(userService)
+
+	authRoutes := r.eng.Group("/api/v1/auth/")
+	authRoutes.POST("login", userHandler.Login())
+}
+
+func (r *router) buildUserRoutes() {
+	userRepository := user.NewUserRepository(r.db)
+	userService := user.NewUserService(userRepository, r.tokens, database.NewUnitOfWork(r.db))
+	userHandler := users.NewUserHandler(userService)
+
+	userRoutes := r.rg.Group("/users/")
+	userRoutes.GET(":id", userHandler.Get())
+	userRoutes.GET("", userHandler.GetAll())
+	userRoutes.POST("", userHandler.Create())
+	userRoutes.DELETE(":id", userHandler.Delete())
+}
+
+func (r *router) buildLogRoutes() {
+	logRepository := logs.NewLogRepository(r.db)
+	logService := logs.NewLogService(logRepository)
+	logHandler := logHandlers.NewLogHandler(logService)
+
+	r.rg.GET("/logs", logHandler.GetAll())
 }
 
 func (r *router) buildSellerRoutes() {
@@ -81,16 +158,20 @@ func (r *router) buildSellerRoutes() {
 	r.rg.GET("/sellers/:id", handler.Get())
 	r.rg.PATCH("/sellers/:id", handler.Update())
 	r.rg.DELETE("/sellers/:id", handler.Delete())
+	r.rg.POST("/sellers/:id/restore", handler.Restore())
 }
 
 func (r *router) buildProductRoutes() {
 	repo := product.NewRepository(r.db)
 	service := product.NewService(repo)
 	handler := products.NewProduct(service)
+	importHandler := imports.NewImportHandler(database.NewUnitOfWork(r.db))
 	r.rg.POST("/products", handler.Create())
+	r.rg.POST("/products/import", importHandler.Products(service))
 	r.rg.GET("/products", handler.GetAll())
 	r.rg.GET("/products/:id", handler.Get())
 	r.rg.DELETE("/products/:id", handler.Delete())
+	r.rg.POST("/products/:id/restore", handler.Restore())
 	r.rg.PATCH("/products/:id", handler.Update())
 }
 
@@ -98,7 +179,9 @@ func (r *router) buildSectionRoutes() {
 	repo := section.NewRepository(r.db)
 	service := section.NewService(repo)
 	handler := sections.NewSection(service)
+	importHandler := imports.NewImportHandler(database.NewUnitOfWork(r.db))
 	r.rg.POST("/sections", handler.Create())
+	r.rg.POST("/sections/import", importHandler.Sections(service))
 	r.rg.GET("/sections", handler.GetAll())
 	r.rg.GET("/sections/:id", handler.Get())
 	r.rg.DELETE("/sections/:id", handler.Delete())
@@ -108,33 +191,53 @@ func (r *router) buildProductBatchesRoutes() {
 	repo := prodBatches.NewRepository(r.db)
 	productRepo := product.NewRepository(r.db)
 	sectionRepo := section.NewRepository(r.db)
-	service := prodBatches.NewService(repo, productRepo, sectionRepo)
+	service := prodBatches.NewService(repo, productRepo, sectionRepo, database.NewUnitOfWork(r.db))
 	handler := productbatcheshandler.NewProductBatches(service)
 	r.rg.POST("/productBatches", handler.Create())
+	r.rg.GET("/productBatches", handler.GetAll())
+	r.rg.GET("/productBatches/reportExpiring", handler.ReportExpiring())
+	r.rg.POST("/productBatches/pick", handler.Pick())
+	r.rg.GET("/productBatches/:id", handler.GetByID())
+	r.rg.PATCH("/productBatches/:id", handler.Update())
+	r.rg.DELETE("/productBatches/:id", handler.Delete())
+	r.rg.GET("/sections/:id/productBatches", handler.GetBySection())
 	r.rg.GET("sections/reportProducts/:id", handler.Get())
+	r.rg.GET("sections/reportProducts", handler.Get())
 }
 
 func (r *router) buildWarehouseRoutes() {
 	repository := warehouse.NewRepository(r.db)
-	service := warehouse.NewService(repository)
+	localityRepository := locality.NewLocalityRepository(r.db)
+	employeeRepository := employee.NewRepository(r.db)
+	sectionRepository := section.NewRepository(r.db)
+	inboundOrderRepository := inbound_order.NewRepository(r.db)
+	service := warehouse.NewService(repository, localityRepository, employeeRepository, sectionRepository, inboundOrderRepository)
 	handler := warehouse2.NewWarehouse(service)
 	r.rg.POST("/warehouses", handler.Create())
 	r.rg.GET("/warehouses", handler.GetAll())
 	r.rg.GET("/warehouses/:id", handler.Get())
+	r.rg.GET("/warehouses/:id/inventory", handler.GetInventory())
+	r.rg.GET("/warehouses/:id/employees", handler.GetEmployees())
+	r.rg.GET("/warehouses/:id/sections", handler.GetSections())
+	r.rg.GET("/warehouses/:id/summary", handler.GetSummary())
 	r.rg.PATCH("/warehouses/:id", handler.Update())
 	r.rg.DELETE("/warehouses/:id", handler.Delete())
+	r.rg.POST("/warehouses/:id/restore", handler.Restore())
 }
 
 func (r *router) buildEmployeeRoutes() {
 	repo := employee.NewRepository(r.db)
 	service := employee.NewService(repo)
 	handler := employees.NewEmployee(service)
+	importHandler := imports.NewImportHandler(database.NewUnitOfWork(r.db))
 
 	r.rg.POST("/employees", handler.Save())
+	r.rg.POST("/employees/import", importHandler.Employees(service))
 	r.rg.GET("/employees", handler.GetAll())
 	r.rg.GET("/employees/:id", handler.Get())
 	r.rg.PATCH("/employees/:id", handler.Update())
 	r.rg.DELETE("/employees/:id", handler.Delete())
+	r.rg.POST("/employees/:id/restore", handler.Restore())
 
 }
 
@@ -143,27 +246,55 @@ func (r *router) buildBuyerRoutes() {
 	buyerService := buyer.NewService(buyerRepository)
 
 	purchaseOrdersRepository := purchaseOrder.NewPurchaseOrderRepository(r.db)
-	purchaseOrderService := purchaseOrder.NewPurchaseOrderService(purchaseOrdersRepository, buyerRepository)
+	orderDetailRepository := purchaseOrder.NewOrderDetailRepository(r.db)
+	orderStatusRepository := purchaseOrder.NewOrderStatusRepository(r.db)
+	purchaseOrderService := purchaseOrder.NewPurchaseOrderService(purchaseOrdersRepository, orderDetailRepository, buyerRepository, orderStatusRepository, database.NewUnitOfWork(r.db))
 
 	buyerHandler := buyers.NewBuyerHandler(buyerService, purchaseOrderService)
 
-	// Create custom validation
-	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		v.RegisterStructValidation(dtos.UpdateBuyerRequestValidation, dtos.UpdateBuyerRequestDTO{})
-	}
-
 	buyerRoutes := r.rg.Group("/buyers/")
 	buyerRoutes.GET(":id", buyerHandler.Get())
 	buyerRoutes.GET("", buyerHandler.GetAll())
 	buyerRoutes.POST("", buyerHandler.Create())
 	buyerRoutes.PATCH(":id", buyerHandler.Update())
 	buyerRoutes.DELETE(":id", buyerHandler.Delete())
+	buyerRoutes.POST(":id/restore", buyerHandler.Restore())
 	buyerRoutes.GET(":id/report-purchase-orders", buyerHandler.CountPurchaseOrders())
+	buyerRoutes.GET("report-purchase-orders", buyerHandler.CountPurchaseOrders())
+}
+
+func (r *router) buildCountryRoutes() {
+	countryRepository := country.NewCountryRepository(r.db)
+	countryService := country.NewCountryService(countryRepository)
+	countryHandler := countries.NewCountryHandler(countryService)
+
+	countryRoutes := r.rg.Group("/countries/")
+	countryRoutes.GET(":id", countryHandler.Get())
+	countryRoutes.GET("", countryHandler.GetAll())
+	countryRoutes.POST("", countryHandler.Create())
+	countryRoutes.PATCH(":id", countryHandler.Update())
+	countryRoutes.DELETE(":id", countryHandler.Delete())
+}
+
+func (r *router) buildProvinceRoutes() {
+	provinceRepository := province.NewProvinceRepository(r.db)
+	countryRepository := country.NewCountryRepository(r.db)
+	provinceService := province.NewProvinceService(provinceRepository, countryRepository)
+	provinceHandler := provinces.NewProvinceHandler(provinceService)
+
+	provinceRoutes := r.rg.Group("/provinces/")
+	provinceRoutes.GET(":id", provinceHandler.Get())
+	provinceRoutes.GET("", provinceHandler.GetAll())
+	provinceRoutes.POST("", provinceHandler.Create())
+	provinceRoutes.PATCH(":id", provinceHandler.Update())
+	provinceRoutes.DELETE(":id", provinceHandler.Delete())
 }
 
 func (r *router) buildLocalityRoutes() {
 	localityRepository := locality.NewLocalityRepository(r.db)
-	localityService := locality.NewLocalityService(localityRepository)
+	provinceRepository := province.NewProvinceRepository(r.db)
+	countryRepository := country.NewCountryRepository(r.db)
+	localityService := locality.NewLocalityService(localityRepository, provinceRepository, countryRepository, database.NewUnitOfWork(r.db))
 	localityHandler := handlers.NewLocalityHandler(localityService)
 
 	localityRoutes := r.rg.Group("/localities/")
@@ -173,20 +304,50 @@ func (r *router) buildLocalityRoutes() {
 	localityRoutes.PATCH(":id", localityHandler.Update())
 	localityRoutes.DELETE(":id", localityHandler.Delete())
 	localityRoutes.GET(":id/reportSellers", localityHandler.CountSellers())
+	localityRoutes.GET("reportSellers", localityHandler.CountSellers())
 }
 
 func (r *router) buildPurchaseOrderRoutes() {
+	unitOfWork := database.NewUnitOfWork(r.db)
+
 	purchaseOrderRepository := purchaseOrder.NewPurchaseOrderRepository(r.db)
+	orderDetailRepository := purchaseOrder.NewOrderDetailRepository(r.db)
+	orderStatusRepository := purchaseOrder.NewOrderStatusRepository(r.db)
 	buyerRepository := buyer.NewBuyerRepository(r.db)
-	purchaseOrderService := purchaseOrder.NewPurchaseOrderService(purchaseOrderRepository, buyerRepository)
+	purchaseOrderService := purchaseOrder.NewPurchaseOrderService(purchaseOrderRepository, orderDetailRepository, buyerRepository, orderStatusRepository, unitOfWork)
 	purchaseOrderHandler := purchase_orders.NewPurchaseOrderHandler(purchaseOrderService)
 
+	orderDetailService := purchaseOrder.NewOrderDetailService(orderDetailRepository, purchaseOrderRepository, unitOfWork)
+	orderDetailHandler := purchase_orders.NewOrderDetailHandler(orderDetailService)
+
+	transitionRepository := purchaseOrder.NewTransitionRepository(r.db)
+	transitionService := purchaseOrder.NewTransitionService(transitionRepository, purchaseOrderRepository, orderStatusRepository, unitOfWork)
+	transitionHandler := purchase_orders.NewTransitionHandler(transitionService)
+
+	orderStatusHandler := purchase_orders.NewOrderStatusHandler(purchaseOrder.NewOrderStatusService(orderStatusRepository))
+
 	purchaseOrderRoutes := r.rg.Group("/purchase-orders/")
 	purchaseOrderRoutes.GET(":id", purchaseOrderHandler.Get())
 	purchaseOrderRoutes.GET("", purchaseOrderHandler.GetAll())
 	purchaseOrderRoutes.POST("", purchaseOrderHandler.Create())
 	purchaseOrderRoutes.PATCH(":id", purchaseOrderHandler.Update())
 	purchaseOrderRoutes.DELETE(":id", purchaseOrderHandler.Delete())
+
+	purchaseOrderRoutes.GET(":id/details", orderDetailHandler.GetAll())
+	purchaseOrderRoutes.GET(":id/details/:detailId", orderDetailHandler.Get())
+	purchaseOrderRoutes.POST(":id/details", orderDetailHandler.Create())
+	purchaseOrderRoutes.PATCH(":id/details/:detailId", orderDetailHandler.Update())
+	purchaseOrderRoutes.DELETE(":id/details/:detailId", orderDetailHandler.Delete())
+
+	purchaseOrderRoutes.GET(":id/transitions", transitionHandler.GetAll())
+	purchaseOrderRoutes.POST(":id/transitions", transitionHandler.Create())
+
+	orderStatusRoutes := r.rg.Group("/order-statuses/")
+	orderStatusRoutes.GET(":id", orderStatusHandler.Get())
+	orderStatusRoutes.GET("", orderStatusHandler.GetAll())
+	orderStatusRoutes.POST("", orderStatusHandler.Create())
+	orderStatusRoutes.PATCH(":id", orderStatusHandler.Update())
+	orderStatusRoutes.DELETE(":id", orderStatusHandler.Delete())
 }
 
 func (r *router) buildProductRecordsRoutes() {
@@ -211,13 +372,17 @@ func (r *router) buildCarriersRoutes() {
 	handler := carriers.NewCarrier(service)
 	r.rg.POST("/carriers", handler.Create())
 	r.rg.GET("/carriers", handler.GetAll())
+	r.rg.DELETE("/carriers/:id", handler.Delete())
+	r.rg.POST("/carriers/:id/restore", handler.Restore())
 	r.rg.GET("/localities/reportCarries", handler.GetReportCarriersByLocalities())
 }
 
 func (r *router) buildInboundOrdersRoutes() {
 	repo := inbound_order.NewRepository(r.db)
 	repoEmployee := employee.NewRepository(r.db)
-	service := inbound_order.NewService(repo, repoEmployee)
+	repoProductBatch := prodBatches.NewRepository(r.db)
+	repoSection := section.NewRepository(r.db)
+	service := inbound_order.NewService(repo, repoEmployee, repoProductBatch, repoSection, database.NewUnitOfWork(r.db))
 	handler := inbound_orders.NewInboundOrders(service)
 
 	r.rg.POST("/inbound-orders", handler.Save())
@@ -72,6 +72,7 @@ CREATE TABLE IF NOT EXISTS `melisprint`.`sellers` (
   `address` VARCHAR(255) NOT NULL,
   `telephone` VARCHAR(255) NOT NULL,
   `locality_id` INT NOT NULL,
+  `deleted_at` DATETIME NULL,
   PRIMARY KEY (`id`),
   INDEX `locality_id_idx` (`locality_id` ASC) VISIBLE,
   UNIQUE INDEX `cid_UNIQUE` (`cid` ASC) VISIBLE,
@@ -109,6 +110,7 @@ CREATE TABLE IF NOT EXISTS `melisprint`.`products` (
   `freezing_rate` DECIMAL(19,2) NOT NULL,
   `product_type_id` INT NOT NULL,
   `seller_id` INT NULL,
+  `deleted_at` DATETIME NULL,
   PRIMARY KEY (`id`),
   INDEX `seller_id_idx` (`seller_id` ASC) VISIBLE,
   INDEX `product_type_id_idx` (`product_type_id` ASC) VISIBLE,
@@ -137,6 +139,7 @@ CREATE TABLE IF NOT EXISTS `melisprint`.`warehouses` (
   `minimun_capacity` INT NOT NULL,
   `minimun_temperature` DECIMAL(19,2) NOT NULL,
   `locality_id` INT NOT NULL,
+  `deleted_at` DATETIME NULL,
   PRIMARY KEY (`id`),
   INDEX `locality_id_idx` (`locality_id` ASC) VISIBLE,
   UNIQUE INDEX `warehouse_code_UNIQUE` (`warehouse_code` ASC) VISIBLE,
@@ -236,6 +239,7 @@ CREATE TABLE IF NOT EXISTS `melisprint`.`buyers` (
   `card_number_id` VARCHAR(255) NOT NULL,
   `first_name` VARCHAR(255) NOT NULL,
   `last_name` VARCHAR(255) NOT NULL,
+  `deleted_at` DATETIME NULL,
   PRIMARY KEY (`id`),
   UNIQUE INDEX `card_number_id_UNIQUE` (`card_number_id` ASC) VISIBLE)
 ENGINE = InnoDB;
@@ -251,6 +255,7 @@ CREATE TABLE IF NOT EXISTS `melisprint`.`carriers` (
   `address` VARCHAR(255) NOT NULL,
   `telephone` VARCHAR(255) NOT NULL,
   `locality_id` INT NOT NULL,
+  `deleted_at` DATETIME NULL,
   PRIMARY KEY (`id`),
   INDEX `locality_id_idx` (`locality_id` ASC) VISIBLE,
   CONSTRAINT `fk_locality_carrier`
@@ -266,8 +271,10 @@ ENGINE = InnoDB;
 -- -----------------------------------------------------
 CREATE TABLE IF NOT EXISTS `melisprint`.`order_status` (
   `id` INT NOT NULL AUTO_INCREMENT,
+  `code` VARCHAR(45) NOT NULL,
   `description` VARCHAR(255) NOT NULL,
-  PRIMARY KEY (`id`))
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `code_UNIQUE` (`code` ASC) VISIBLE)
 ENGINE = InnoDB;
 
 
@@ -344,6 +351,37 @@ CREATE TABLE IF NOT EXISTS `melisprint`.`order_details` (
 ENGINE = InnoDB;
 
 
+-- -----------------------------------------------------
+-- Table `melisprint`.`purchase_order_transitions`
+-- -----------------------------------------------------
+CREATE TABLE IF NOT EXISTS `melisprint`.`purchase_order_transitions` (
+  `id` INT NOT NULL AUTO_INCREMENT,
+  `purchase_order_id` INT NOT NULL,
+  `from_status_id` INT NOT NULL,
+  `to_status_id` INT NOT NULL,
+  `created_at` DATETIME NOT NULL,
+  PRIMARY KEY (`id`),
+  INDEX `purchase_order_id_idx` (`purchase_order_id` ASC) VISIBLE,
+  INDEX `from_status_id_idx` (`from_status_id` ASC) VISIBLE,
+  INDEX `to_status_id_idx` (`to_status_id` ASC) VISIBLE,
+  CONSTRAINT `fk_purchase_order_transitions`
+    FOREIGN KEY (`purchase_order_id`)
+    REFERENCES `melisprint`.`purchase_orders` (`id`)
+    ON DELETE CASCADE
+    ON UPDATE NO ACTION,
+  CONSTRAINT `fk_from_status_transitions`
+    FOREIGN KEY (`from_status_id`)
+    REFERENCES `melisprint`.`order_status` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION,
+  CONSTRAINT `fk_to_status_transitions`
+    FOREIGN KEY (`to_status_id`)
+    REFERENCES `melisprint`.`order_status` (`id`)
+    ON DELETE NO ACTION
+    ON UPDATE NO ACTION)
+ENGINE = InnoDB;
+
+
 -- -----------------------------------------------------
 -- Table `melisprint`.`employees`
 -- -----------------------------------------------------
@@ -353,6 +391,7 @@ CREATE TABLE IF NOT EXISTS `melisprint`.`employees` (
   `first_name` VARCHAR(255) NOT NULL,
   `last_name` VARCHAR(255) NOT NULL,
   `warehouse_id` INT NOT NULL,
+  `deleted_at` DATETIME NULL,
   PRIMARY KEY (`id`),
   INDEX `warehouse_id_idx` (`warehouse_id` ASC) VISIBLE,
   UNIQUE INDEX `card_number_id_UNIQUE` (`card_number_id` ASC) VISIBLE,
@@ -374,6 +413,7 @@ CREATE TABLE IF NOT EXISTS `melisprint`.`inbound_orders` (
   `employee_id` INT NOT NULL,
   `product_batch_id` INT NOT NULL,
   `warehouse_id` INT NOT NULL,
+  `quantity` INT NOT NULL DEFAULT 0,
   PRIMARY KEY (`id`),
   INDEX `employee_id_idx` (`employee_id` ASC) VISIBLE,
   INDEX `product_batch_id_idx` (`product_batch_id` ASC) VISIBLE,
@@ -414,7 +454,8 @@ CREATE TABLE IF NOT EXISTS `melisprint`.`users` (
   `id` INT NOT NULL AUTO_INCREMENT,
   `passoword` VARCHAR(255) NOT NULL,
   `username` VARCHAR(255) NOT NULL,
-  PRIMARY KEY (`id`))
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `username_UNIQUE` (`username` ASC) VISIBLE)
 ENGINE = InnoDB;
 
 
@@ -495,12 +536,17 @@ INSERT INTO `melisprint`.`buyers` (`card_number_id`, `first_name`, `last_name`)
 INSERT INTO `melisprint`.`carriers` (`cid`, `company_name`, `address`, `telephone`, `locality_id`) VALUES ('111111', 'Carrier 1', 'Carrier Address 1', '111111111', 1);
 INSERT INTO `melisprint`.`carriers` (`cid`, `company_name`, `address`, `telephone`, `locality_id`) VALUES ('222222', 'Carrier 2', 'Carrier Address 2', '222222222', 2);
 
-INSERT INTO `melisprint`.`order_status` (`description`) VALUES ('Pending');
-INSERT INTO `melisprint`.`order_status` (`description`) VALUES ('Processing');
+INSERT INTO `melisprint`.`order_status` (`code`, `description`) VALUES ('created', 'Created');
+INSERT INTO `melisprint`.`order_status` (`code`, `description`) VALUES ('picked', 'Picked');
+INSERT INTO `melisprint`.`order_status` (`code`, `description`) VALUES ('shipped', 'Shipped');
+INSERT INTO `melisprint`.`order_status` (`code`, `description`) VALUES ('delivered', 'Delivered');
+INSERT INTO `melisprint`.`order_status` (`code`, `description`) VALUES ('cancelled', 'Cancelled');
 
 INSERT INTO `melisprint`.`purchase_orders` (`order_number`, `order_date`, `tracking_code`, `buyer_id`, `carrier_id`, `order_status_id`, `warehouse_id`, `product_record_id`) VALUES ('PO001', '2023-07-01 10:00:00', 'TRACK001', 1, 1, 1, 1, 1);
 INSERT INTO `melisprint`.`purchase_orders` (`order_number`, `order_date`, `tracking_code`, `buyer_id`, `carrier_id`, `order_status_id`, `warehouse_id`, `product_record_id`) VALUES ('PO002', '2023-07-02 11:00:00', 'TRACK002', 2, 2, 2, 2, 2);
 
+INSERT INTO `melisprint`.`purchase_order_transitions` (`purchase_order_id`, `from_status_id`, `to_status_id`, `created_at`) VALUES (2, 1, 2, '2023-07-02 15:00:00');
+
 INSERT INTO `melisprint`.`order_details` (`clean_liness_status`, `quantity`, `temperature`, `product_record_id`, `purchase_order_id`) VALUES ('Clean', 10, -18, 1, 1);
 INSERT INTO `melisprint`.`order_details` (`clean_liness_status`, `quantity`, `temperature`, `product_record_id`, `purchase_order_id`) VALUES ('Not clean', 20, -15, 2, 2);
 
@@ -512,9 +558,12 @@ INSERT INTO `melisprint`.`inbound_orders` (`order_date`, `order_number`, `employ
 
 INSERT INTO `melisprint`.`roles` (`description`, `rol_name`) VALUES ('Administrator', 'admin');
 INSERT INTO `melisprint`.`roles` (`description`, `rol_name`) VALUES ('Employee', 'employee');
+INSERT INTO `melisprint`.`roles` (`description`, `rol_name`) VALUES ('Read-only analyst', 'analyst');
+INSERT INTO `melisprint`.`roles` (`description`, `rol_name`) VALUES ('Warehouse operator', 'operator');
 
-INSERT INTO `melisprint`.`users` (`passoword`, `username`) VALUES ('password1', 'user1');
-INSERT INTO `melisprint`.`users` (`passoword`, `username`) VALUES ('password2', 'user2');
+-- Passwords are bcrypt hashes of 'password1' and 'password2'
+INSERT INTO `melisprint`.`users` (`passoword`, `username`) VALUES ('$2a$10$9Nyxsq9YaXzRkdiMTNUpyOti8w9wq4i06P3mpB4PU.iGXaHoAo6OW', 'user1');
+INSERT INTO `melisprint`.`users` (`passoword`, `username`) VALUES ('$2a$10$QcPj.dqQrJbw.jDDmG8Fyu/j5N7ZjXuJH2Thj6LtxVxHshsK3vw2u', 'user2');
 
 INSERT INTO `melisprint`.`user_rol` (`usuario_id`, `rol_id`) VALUES (1, 1);
 INSERT INTO `melisprint`.`user_rol` (`usuario_id`, `rol_id`) VALUES (2, 2);
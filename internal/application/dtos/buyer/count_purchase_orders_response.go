@@ -1,7 +1,26 @@
 package dtos
 
+// GetNumberOfPurchaseOrdersByBuyerResponseDTO counts the purchase orders of a buyer, in total and
+// broken down by order status and by carrier.
 type GetNumberOfPurchaseOrdersByBuyerResponseDTO struct {
-	BuyerID int `json:"buyer_id"`
-	//LocalityName string `json:"locality_name"`
-	PurchaseOrdersCount int `json:"purchase_orders_count"`
+	BuyerID             int                              `json:"buyer_id"`
+	CardNumberID        string                           `json:"card_number_id"`
+	FirstName           string                           `json:"first_name"`
+	LastName            string                           `json:"last_name"`
+	PurchaseOrdersCount int                              `json:"purchase_orders_count"`
+	ByOrderStatus       []PurchaseOrdersByOrderStatusDTO `json:"by_order_status"`
+	ByCarrier           []PurchaseOrdersByCarrierDTO     `json:"by_carrier"`
+}
+
+type PurchaseOrdersByOrderStatusDTO struct {
+	OrderStatusID       int    `json:"order_status_id"`
+	OrderStatus         string `json:"order_status"`
+	PurchaseOrdersCount int    `json:"purchase_orders_count"`
+}
+
+// PurchaseOrdersByCarrierDTO has no carrier for the orders that have none yet.
+type PurchaseOrdersByCarrierDTO struct {
+	CarrierID           *int    `json:"carrier_id"`
+	CompanyName         *string `json:"company_name"`
+	PurchaseOrdersCount int     `json:"purchase_orders_count"`
 }
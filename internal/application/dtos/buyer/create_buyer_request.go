@@ -3,9 +3,9 @@ package dtos
 import "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 
 type CreateBuyerRequestDTO struct {
-	CardNumberID string `json:"card_number_id" binding:"required"`
-	FirstName    string `json:"first_name" binding:"required"`
-	LastName     string `json:"last_name" binding:"required"`
+	CardNumberID string `json:"card_number_id" binding:"required,cid"`
+	FirstName    string `json:"first_name" binding:"required,max=255"`
+	LastName     string `json:"last_name" binding:"required,max=255"`
 }
 
 func (dto *CreateBuyerRequestDTO) ToDomain() *domain.Buyer {
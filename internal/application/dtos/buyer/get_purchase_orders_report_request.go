@@ -0,0 +1,8 @@
+package dtos
+
+// GetPurchaseOrdersReportRequestDTO holds the optional order date range of the purchase orders report.
+// Dates are in the format 2006-01-02 and both ends are inclusive.
+type GetPurchaseOrdersReportRequestDTO struct {
+	OrderDateFrom string `form:"order_date_from" binding:"omitempty,date"`
+	OrderDateTo   string `form:"order_date_to" binding:"omitempty,date"`
+}
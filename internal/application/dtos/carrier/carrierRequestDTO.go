@@ -1,9 +1,9 @@
 package dtos
 
 type CarrierRequestDTO struct {
-	CID         string `json:"cid"`
-	CompanyName string `json:"company_name"`
-	Address     string `json:"address"`
-	Telephone   string `json:"telephone"`
-	LocalityId  int    `json:"locality_id"`
+	CID         string `json:"cid" binding:"required,cid"`
+	CompanyName string `json:"company_name" binding:"required,max=255"`
+	Address     string `json:"address" binding:"required,max=255"`
+	Telephone   string `json:"telephone" binding:"required,phone"`
+	LocalityId  int    `json:"locality_id" binding:"required,gt=0"`
 }
@@ -0,0 +1,20 @@
+package dtos
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+type HealthResponseDTO struct {
+	Status string `json:"status"`
+}
+
+type ReadinessResponseDTO struct {
+	Status       string                         `json:"status"`
+	Dependencies map[string]DependencyStatusDTO `json:"dependencies"`
+}
+
+type DependencyStatusDTO struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
@@ -0,0 +1,13 @@
+package dtos
+
+import "time"
+
+// GetLogsRequestDTO holds the optional query filters of the logs listing.
+// Dates are RFC 3339 timestamps and both ends of each range are inclusive.
+type GetLogsRequestDTO struct {
+	Level      string    `form:"level" binding:"omitempty,oneof=Info Warning Error"`
+	StatusFrom int       `form:"status_from" binding:"omitempty,min=100,max=599"`
+	StatusTo   int       `form:"status_to" binding:"omitempty,min=100,max=599,gtefield=StatusFrom"`
+	From       time.Time `form:"from"`
+	To         time.Time `form:"to"`
+}
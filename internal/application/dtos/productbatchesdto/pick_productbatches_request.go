@@ -0,0 +1,6 @@
+package productbatchesdto
+
+type PickProductBatchesDTO struct {
+	ProductID int `json:"product_id" binding:"required,gt=0"`
+	Quantity  int `json:"quantity" binding:"required,min=1"`
+}
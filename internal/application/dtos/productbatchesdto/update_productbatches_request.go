@@ -0,0 +1,6 @@
+package productbatchesdto
+
+type UpdateProductBatchesDTO struct {
+	CurrentQuantity    *int     `json:"current_quantity" binding:"omitempty,min=0"`
+	CurrentTemperature *float64 `json:"current_temperature"`
+}
@@ -0,0 +1,21 @@
+package dtos
+
+import (
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+)
+
+type CreateOrderDetailRequestDTO struct {
+	CleanLinessStatus string  `json:"clean_liness_status"  binding:"required,max=255"`
+	Quantity          int     `json:"quantity"  binding:"required,gt=0"`
+	Temperature       float64 `json:"temperature"`
+	ProductRecordID   int     `json:"product_record_id"  binding:"required,gt=0"`
+}
+
+func (dto *CreateOrderDetailRequestDTO) ToDomain() domain.OrderDetail {
+	return domain.OrderDetail{
+		CleanLinessStatus: dto.CleanLinessStatus,
+		Quantity:          dto.Quantity,
+		Temperature:       dto.Temperature,
+		ProductRecordID:   dto.ProductRecordID,
+	}
+}
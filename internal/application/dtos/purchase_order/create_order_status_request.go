@@ -0,0 +1,17 @@
+package dtos
+
+import (
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+)
+
+type CreateOrderStatusRequestDTO struct {
+	Code        string `json:"code"  binding:"required,max=45"`
+	Description string `json:"description"  binding:"required,max=255"`
+}
+
+func (dto *CreateOrderStatusRequestDTO) ToDomain() domain.OrderStatus {
+	return domain.OrderStatus{
+		Code:        dto.Code,
+		Description: dto.Description,
+	}
+}
@@ -4,26 +4,33 @@ import (
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 )
 
+// CreatePurchaseOrderRequestDTO has no order status, new orders always start in the created status.
 type CreatePurchaseOrderRequestDTO struct {
-	OrderNumber     string `json:"order_number"  binding:"required"`
-	OrderDate       string `json:"order_date"  binding:"required"`
-	TrackingCode    string `json:"tracking_code"  binding:"required"`
-	BuyerID         int    `json:"buyer_id"  binding:"required"`
-	CarrierID       int    `json:"carrier_id"  binding:"required"`
-	OrderStatusID   int    `json:"order_status_id"  binding:"required"`
-	WarehouseID     int    `json:"warehouse_id"  binding:"required"`
-	ProductRecordID int    `json:"product_record_id"  binding:"required"`
+	OrderNumber     string `json:"order_number"  binding:"required,max=255"`
+	OrderDate       string `json:"order_date"  binding:"required,date"`
+	TrackingCode    string `json:"tracking_code"  binding:"required,max=255"`
+	BuyerID         int    `json:"buyer_id"  binding:"required,gt=0"`
+	CarrierID       int    `json:"carrier_id"  binding:"required,gt=0"`
+	WarehouseID     int    `json:"warehouse_id"  binding:"required,gt=0"`
+	ProductRecordID int    `json:"product_record_id"  binding:"required,gt=0"`
+
+	Details []CreateOrderDetailRequestDTO `json:"details" binding:"omitempty,dive"`
 }
 
 func (dto *CreatePurchaseOrderRequestDTO) ToDomain() domain.PurchaseOrder {
+	var details []domain.OrderDetail
+	for _, detail := range dto.Details {
+		details = append(details, detail.ToDomain())
+	}
+
 	return domain.PurchaseOrder{
 		OrderNumber:     dto.OrderNumber,
 		OrderDate:       dto.OrderDate,
 		TrackingCode:    dto.TrackingCode,
 		BuyerID:         dto.BuyerID,
 		CarrierID:       dto.CarrierID,
-		OrderStatusID:   dto.OrderStatusID,
 		WarehouseID:     dto.WarehouseID,
 		ProductRecordID: dto.ProductRecordID,
+		Details:         details,
 	}
 }
@@ -0,0 +1,6 @@
+package dtos
+
+// CreateTransitionRequestDTO moves a purchase order to the order status with the code Status.
+type CreateTransitionRequestDTO struct {
+	Status string `json:"status"  binding:"required,max=45"`
+}
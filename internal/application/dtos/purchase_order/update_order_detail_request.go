@@ -0,0 +1,8 @@
+package dtos
+
+type UpdateOrderDetailRequestDTO struct {
+	CleanLinessStatus *string  `json:"clean_liness_status" binding:"omitempty,min=1,max=255"`
+	Quantity          *int     `json:"quantity" binding:"omitempty,gt=0"`
+	Temperature       *float64 `json:"temperature"`
+	ProductRecordID   *int     `json:"product_record_id" binding:"omitempty,gt=0"`
+}
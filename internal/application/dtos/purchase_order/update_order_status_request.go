@@ -0,0 +1,6 @@
+package dtos
+
+// UpdateOrderStatusRequestDTO has no code, the code of a status is what the purchase order lifecycle refers to.
+type UpdateOrderStatusRequestDTO struct {
+	Description *string `json:"description" binding:"omitempty,min=1,max=255"`
+}
@@ -1,12 +1,12 @@
 package dtos
 
 type UpdatePurchaseOrderRequestDTO struct {
-	OrderNumber     *string `json:"order_number"`
-	OrderDate       *string `json:"order_date"`
-	TrackingCode    *string `json:"tracking_code"`
-	BuyerID         *int    `json:"buyer_id"`
-	CarrierID       *int    `json:"carrier_id"`
-	OrderStatusID   *int    `json:"order_status_id"`
-	WarehouseID     *int    `json:"warehouse_id"`
-	ProductRecordID *int    `json:"product_record_id"`
+	OrderNumber     *string `json:"order_number" binding:"omitempty,min=1,max=255"`
+	OrderDate       *string `json:"order_date" binding:"omitempty,date"`
+	TrackingCode    *string `json:"tracking_code" binding:"omitempty,min=1,max=255"`
+	BuyerID         *int    `json:"buyer_id" binding:"omitempty,gt=0"`
+	CarrierID       *int    `json:"carrier_id" binding:"omitempty,gt=0"`
+	OrderStatusID   *int    `json:"order_status_id" binding:"omitempty,gt=0"`
+	WarehouseID     *int    `json:"warehouse_id" binding:"omitempty,gt=0"`
+	ProductRecordID *int    `json:"product_record_id" binding:"omitempty,gt=0"`
 }
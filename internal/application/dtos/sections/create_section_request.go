@@ -1,12 +1,12 @@
 package sections
 
 type CreateSectionRequestDTO struct {
-	SectionNumber      int `json:"section_number"`
-	CurrentTemperature int `json:"current_temperature"`
-	MinimumTemperature int `json:"minimum_temperature"`
-	CurrentCapacity    int `json:"current_capacity"`
-	MinimumCapacity    int `json:"minimum_capacity"`
-	MaximumCapacity    int `json:"maximum_capacity"`
-	WarehouseID        int `json:"warehouse_id"`
-	ProductTypeID      int `json:"product_type_id"`
+	SectionNumber      int `json:"section_number" binding:"required,gt=0"`
+	CurrentTemperature int `json:"current_temperature" binding:"required"`
+	MinimumTemperature int `json:"minimum_temperature" binding:"required"`
+	CurrentCapacity    int `json:"current_capacity" binding:"required,gt=0,ltefield=MaximumCapacity"`
+	MinimumCapacity    int `json:"minimum_capacity" binding:"required,gt=0"`
+	MaximumCapacity    int `json:"maximum_capacity" binding:"required,gtefield=MinimumCapacity"`
+	WarehouseID        int `json:"warehouse_id" binding:"required,gt=0"`
+	ProductTypeID      int `json:"product_type_id" binding:"required,gt=0"`
 }
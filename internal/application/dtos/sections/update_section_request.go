@@ -1,12 +1,12 @@
 package sections
 
 type UpdateSectionRequestDTO struct {
-	SectionNumber      *int `json:"section_number"`
+	SectionNumber      *int `json:"section_number" binding:"omitempty,gt=0"`
 	CurrentTemperature *int `json:"current_temperature"`
 	MinimumTemperature *int `json:"minimum_temperature"`
-	CurrentCapacity    *int `json:"current_capacity"`
-	MinimumCapacity    *int `json:"minimum_capacity"`
-	MaximumCapacity    *int `json:"maximum_capacity"`
-	WarehouseID        *int `json:"warehouse_id"`
-	ProductTypeID      *int `json:"product_type_id"`
+	CurrentCapacity    *int `json:"current_capacity" binding:"omitempty,gt=0"`
+	MinimumCapacity    *int `json:"minimum_capacity" binding:"omitempty,gt=0"`
+	MaximumCapacity    *int `json:"maximum_capacity" binding:"omitempty,gt=0"`
+	WarehouseID        *int `json:"warehouse_id" binding:"omitempty,gt=0"`
+	ProductTypeID      *int `json:"product_type_id" binding:"omitempty,gt=0"`
 }
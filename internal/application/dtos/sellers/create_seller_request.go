@@ -1,9 +1,9 @@
 package dtos
 
 type CreateSellerRequestDTO struct {
-	CID         int    `json:"cid" binding:"required"`
-	CompanyName string `json:"company_name" binding:"required"`
-	Address     string `json:"address" binding:"required"`
-	Telephone   string `json:"telephone" binding:"required"`
-	LocalityID  string `json:"locality_id" binding:"required"`
+	CID         int    `json:"cid" binding:"required,gt=0"`
+	CompanyName string `json:"company_name" binding:"required,max=255"`
+	Address     string `json:"address" binding:"required,max=255"`
+	Telephone   string `json:"telephone" binding:"required,phone"`
+	LocalityID  string `json:"locality_id" binding:"required,numeric"`
 }
@@ -1,9 +1,9 @@
 package dtos
 
 type UpdateSellerRequestDTO struct {
-	CID         *int    `json:"cid"`
-	CompanyName *string `json:"company_name"`
-	Address     *string `json:"address"`
-	Telephone   *string `json:"telephone"`
-	LocalityID  *string `json:"locality_id"`
+	CID         *int    `json:"cid" binding:"omitempty,gt=0"`
+	CompanyName *string `json:"company_name" binding:"omitempty,min=1,max=255"`
+	Address     *string `json:"address" binding:"omitempty,min=1,max=255"`
+	Telephone   *string `json:"telephone" binding:"omitempty,phone"`
+	LocalityID  *string `json:"locality_id" binding:"omitempty,numeric"`
 }
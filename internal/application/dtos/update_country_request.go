@@ -0,0 +1,5 @@
+package dtos
+
+type UpdateCountryRequestDTO struct {
+	CountryName *string `json:"country_name" binding:"omitempty,min=1,max=255"`
+}
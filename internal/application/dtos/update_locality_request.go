@@ -1,7 +1,9 @@
 package dtos
 
 type UpdateLocalityRequestDTO struct {
-	CountryName  *string `json:"country_name"`
-	ProvinceName *string `json:"province_name"`
-	LocalityName *string `json:"locality_name"`
+	CountryName  *string `json:"country_name" binding:"omitempty,min=1,max=255"`
+	ProvinceName *string `json:"province_name" binding:"omitempty,min=1,max=255"`
+	LocalityName *string `json:"locality_name" binding:"omitempty,min=1,max=255"`
+	ProvinceID   *int    `json:"province_id" binding:"omitempty,gt=0"`
+	CountryID    *int    `json:"country_id" binding:"omitempty,gt=0"`
 }
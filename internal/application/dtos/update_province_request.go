@@ -0,0 +1,6 @@
+package dtos
+
+type UpdateProvinceRequestDTO struct {
+	ProvinceName *string `json:"province_name" binding:"omitempty,min=1,max=255"`
+	CountryID    *int    `json:"country_id" binding:"omitempty,gt=0"`
+}
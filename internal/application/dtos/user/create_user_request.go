@@ -0,0 +1,17 @@
+package dtos
+
+import "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+
+type CreateUserRequestDTO struct {
+	Username string   `json:"username" binding:"required,max=255"`
+	Password string   `json:"password" binding:"required,min=8,max=72"`
+	Roles    []string `json:"roles" binding:"required,min=1,dive,required"`
+}
+
+func (dto *CreateUserRequestDTO) ToDomain() domain.User {
+	return domain.User{
+		Username: dto.Username,
+		Password: dto.Password,
+		Roles:    dto.Roles,
+	}
+}
@@ -0,0 +1,6 @@
+package dtos
+
+type LoginRequestDTO struct {
+	Username string `json:"username" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
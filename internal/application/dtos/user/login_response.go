@@ -0,0 +1,8 @@
+package dtos
+
+import "time"
+
+type LoginResponseDTO struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -1,9 +1,21 @@
 package dtos
 
+// CreateWarehouseRequestDTO has the fields of WarehouseRequestDTO, all of them required.
+type CreateWarehouseRequestDTO struct {
+	Address            string `json:"address" binding:"required,max=255"`
+	Telephone          string `json:"telephone" binding:"required,phone"`
+	WarehouseCode      string `json:"warehouse_code" binding:"required,cid"`
+	MinimumCapacity    int    `json:"minimum_capacity" binding:"required,gt=0"`
+	MinimumTemperature int    `json:"minimum_temperature" binding:"required"`
+	LocalityID         int    `json:"locality_id" binding:"required,gt=0"`
+}
+
+// WarehouseRequestDTO updates the fields that are not left empty.
 type WarehouseRequestDTO struct {
-	Address            string `json:"address"`
-	Telephone          string `json:"telephone"`
-	WarehouseCode      string `json:"warehouse_code"`
-	MinimumCapacity    int    `json:"minimum_capacity"`
+	Address            string `json:"address" binding:"omitempty,max=255"`
+	Telephone          string `json:"telephone" binding:"omitempty,phone"`
+	WarehouseCode      string `json:"warehouse_code" binding:"omitempty,cid"`
+	MinimumCapacity    int    `json:"minimum_capacity" binding:"omitempty,gt=0"`
 	MinimumTemperature int    `json:"minimum_temperature"`
+	LocalityID         int    `json:"locality_id" binding:"omitempty,gt=0"`
 }
@@ -1,9 +1,105 @@
 package errors
 
-import "errors"
+import (
+	"fmt"
+)
+
+// Kind classifies an Error, it is what the response status is chosen from.
+type Kind string
+
+const (
+	KindNotFound   Kind = "not_found"
+	KindConflict   Kind = "conflict"
+	KindValidation Kind = "validation"
+	// KindForeignKey is a record referencing another one that does not exist.
+	KindForeignKey Kind = "foreign_key"
+	// KindUnauthorized is a request whose credentials are wrong.
+	KindUnauthorized Kind = "unauthorized"
+)
+
+// Error is a domain error of an Entity, such as "seller", optionally about one of its fields.
+type Error struct {
+	Kind    Kind
+	Entity  string
+	Field   string
+	Message string
+	// Err is the cause, such as the driver error a repository translated.
+	Err error
+}
+
+func (e *Error) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+
+	subject := e.Entity
+	if subject == "" {
+		subject = "record"
+	}
+
+	switch e.Kind {
+	case KindNotFound:
+		return fmt.Sprintf("%s not found", subject)
+	case KindConflict:
+		if e.Field != "" {
+			return fmt.Sprintf("%s with this %s already exists", subject, e.Field)
+		}
+		return fmt.Sprintf("%s already exists", subject)
+	case KindForeignKey:
+		if e.Field != "" {
+			return fmt.Sprintf("%s references a record that does not exist", e.Field)
+		}
+		return fmt.Sprintf("%s references a record that does not exist", subject)
+	default:
+		if e.Field != "" {
+			return fmt.Sprintf("%s is invalid", e.Field)
+		}
+		return fmt.Sprintf("%s is invalid", subject)
+	}
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches an Error of the same kind whose entity and field, when set, are e's too.
+// So errors.Is(err, ErrNotFound) holds for any not found error and errors.Is(err, seller.ErrNotFound) only for sellers.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+
+	return t.Kind == e.Kind &&
+		(t.Entity == "" || t.Entity == e.Entity) &&
+		(t.Field == "" || t.Field == e.Field)
+}
+
+// NotFound is the error of an entity that does not exist.
+func NotFound(entity string) *Error {
+	return &Error{Kind: KindNotFound, Entity: entity}
+}
+
+// Conflict is the error of an entity whose field, if any, is already taken by another one.
+func Conflict(entity, field string) *Error {
+	return &Error{Kind: KindConflict, Entity: entity, Field: field}
+}
+
+// Validation is the error of an entity with an invalid field.
+func Validation(entity, field, message string) *Error {
+	return &Error{Kind: KindValidation, Entity: entity, Field: field, Message: message}
+}
+
+// ForeignKey is the error of an entity whose field references a record that does not exist.
+func ForeignKey(entity, field string) *Error {
+	return &Error{Kind: KindForeignKey, Entity: entity, Field: field}
+}
 
 // Errors
 var (
-	ErrNotFound = errors.New("locality not found")
-	ErrConflict = errors.New("ID already exists")
+	// ErrNotFound and ErrConflict match every error of their kind, whatever the entity.
+	ErrNotFound     = &Error{Kind: KindNotFound}
+	ErrConflict     = &Error{Kind: KindConflict}
+	ErrUnauthorized = &Error{Kind: KindUnauthorized, Entity: "user", Message: "invalid username or password"}
+	ErrInvalidRole  = Validation("user", "roles", "role does not exist")
 )
@@ -0,0 +1,44 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError(t *testing.T) {
+	t.Run("Default messages", func(t *testing.T) {
+		assert.EqualError(t, NotFound("seller"), "seller not found")
+		assert.EqualError(t, ErrNotFound, "record not found")
+		assert.EqualError(t, Conflict("seller", "cid"), "seller with this cid already exists")
+		assert.EqualError(t, Conflict("seller", ""), "seller already exists")
+		assert.EqualError(t, ForeignKey("purchase order", "buyer_id"), "buyer_id references a record that does not exist")
+		assert.EqualError(t, Validation("seller", "cid", "cid is required"), "cid is required")
+		assert.EqualError(t, &Error{Kind: KindValidation, Field: "cid"}, "cid is invalid")
+	})
+
+	t.Run("Matches by kind, entity and field", func(t *testing.T) {
+		err := fmt.Errorf("saving: %w", Conflict("seller", "cid"))
+
+		assert.True(t, errors.Is(err, ErrConflict))
+		assert.True(t, errors.Is(err, Conflict("seller", "")))
+		assert.True(t, errors.Is(err, Conflict("seller", "cid")))
+		assert.False(t, errors.Is(err, Conflict("seller", "address")))
+		assert.False(t, errors.Is(err, Conflict("product", "")))
+		assert.False(t, errors.Is(err, ErrNotFound))
+		assert.False(t, errors.Is(ErrNotFound, NotFound("seller")))
+	})
+
+	t.Run("Unwraps the cause", func(t *testing.T) {
+		cause := errors.New("duplicate entry")
+		err := &Error{Kind: KindConflict, Entity: "seller", Err: cause}
+
+		assert.True(t, errors.Is(err, cause))
+
+		var target *Error
+		assert.True(t, errors.As(fmt.Errorf("wrapped: %w", err), &target))
+		assert.Equal(t, "seller", target.Entity)
+	})
+}
@@ -3,6 +3,7 @@ package mocks
 import (
 	"context"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -20,6 +21,23 @@ func (repository *BuyerRepositoryMock) GetAll(ctx context.Context) ([]domain.Buy
 	return args.Get(0).([]domain.Buyer), args.Error(1)
 }
 
+func (repository *BuyerRepositoryMock) GetPage(ctx context.Context, options listing.Options) ([]domain.Buyer, int, error) {
+	args := repository.Called(ctx, options)
+
+	return args.Get(0).([]domain.Buyer), args.Int(1), args.Error(2)
+}
+
+func (repository *BuyerRepositoryMock) Each(ctx context.Context, options listing.Options, fn func(domain.Buyer) error) error {
+	args := repository.Called(ctx, options)
+
+	for _, buyer := range args.Get(0).([]domain.Buyer) {
+		if err := fn(buyer); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
 func (repository *BuyerRepositoryMock) Get(ctx context.Context, id int) (domain.Buyer, error) {
 	args := repository.Called(ctx, id)
 
@@ -49,3 +67,9 @@ func (repository *BuyerRepositoryMock) Delete(ctx context.Context, id int) error
 
 	return args.Error(0)
 }
+
+func (repository *BuyerRepositoryMock) Restore(ctx context.Context, id int) error {
+	args := repository.Called(ctx, id)
+
+	return args.Error(0)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/buyer"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -15,32 +16,55 @@ func NewBuyerServiceMock() *BuyerServiceMock {
 	return &BuyerServiceMock{}
 }
 
-func (service *BuyerServiceMock) Get(ctx *context.Context, id int) (*domain.Buyer, error) {
+func (service *BuyerServiceMock) Get(ctx context.Context, id int) (*domain.Buyer, error) {
 	args := service.Called(ctx, id)
 
 	return args.Get(0).(*domain.Buyer), args.Error(1)
 }
 
-func (service *BuyerServiceMock) GetAll(ctx *context.Context) (*[]domain.Buyer, error) {
+func (service *BuyerServiceMock) GetAll(ctx context.Context) (*[]domain.Buyer, error) {
 	args := service.Called(ctx)
 
 	return args.Get(0).(*[]domain.Buyer), args.Error(1)
 }
 
-func (service *BuyerServiceMock) Create(ctx *context.Context, createBuyerRequest *dtos.CreateBuyerRequestDTO) (*domain.Buyer, error) {
+func (service *BuyerServiceMock) GetPage(ctx context.Context, options listing.Options) (*[]domain.Buyer, int, error) {
+	args := service.Called(ctx, options)
+
+	return args.Get(0).(*[]domain.Buyer), args.Int(1), args.Error(2)
+}
+
+func (service *BuyerServiceMock) Each(ctx context.Context, options listing.Options, fn func(domain.Buyer) error) error {
+	args := service.Called(ctx, options)
+
+	for _, buyer := range args.Get(0).([]domain.Buyer) {
+		if err := fn(buyer); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (service *BuyerServiceMock) Create(ctx context.Context, createBuyerRequest *dtos.CreateBuyerRequestDTO) (*domain.Buyer, error) {
 	args := service.Called(ctx, createBuyerRequest)
 
 	return args.Get(0).(*domain.Buyer), args.Error(1)
 }
 
-func (service *BuyerServiceMock) Update(ctx *context.Context, id int, updateBuyerRequest *dtos.UpdateBuyerRequestDTO) (*domain.Buyer, error) {
+func (service *BuyerServiceMock) Update(ctx context.Context, id int, updateBuyerRequest *dtos.UpdateBuyerRequestDTO) (*domain.Buyer, error) {
 	args := service.Called(ctx, updateBuyerRequest)
 
 	return args.Get(0).(*domain.Buyer), args.Error(1)
 }
 
-func (service *BuyerServiceMock) Delete(ctx *context.Context, id int) error {
+func (service *BuyerServiceMock) Delete(ctx context.Context, id int) error {
 	args := service.Called(ctx, id)
 
 	return args.Error(0)
 }
+
+func (service *BuyerServiceMock) Restore(ctx context.Context, id int) (*domain.Buyer, error) {
+	args := service.Called(ctx, id)
+
+	return args.Get(0).(*domain.Buyer), args.Error(1)
+}
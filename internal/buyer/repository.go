@@ -4,28 +4,43 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 )
 
 // BuyerRepository encapsulates the storage of a buyer.
 type BuyerRepository interface {
 	GetAll(ctx context.Context) ([]domain.Buyer, error)
+	GetPage(ctx context.Context, options listing.Options) ([]domain.Buyer, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.Buyer) error) error
 	Get(ctx context.Context, id int) (domain.Buyer, error)
 	CardNumberExists(ctx context.Context, cardNumberID string) bool
 	Save(ctx context.Context, b domain.Buyer) (int, error)
 	Update(ctx context.Context, b domain.Buyer) error
 	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) error
 }
 
 const (
-	GetAllBuyers    = "SELECT buyers.id, buyers.card_number_id, buyers.first_name, buyers.last_name FROM buyers"
-	GetBuyerByID    = "SELECT buyers.id, buyers.card_number_id, buyers.first_name, buyers.last_name FROM buyers WHERE id = ?"
-	ExistsBuyerByID = "SELECT buyers.card_number_id FROM buyers WHERE cardNumberID=?"
-	SaveBuyer       = "INSERT INTO buyers(card_number_id,first_name,last_name) VALUES (?,?,?)"
-	UpdateBuyer     = "UPDATE buyers SET card_number_id=?,first_name=?,last_name=?  WHERE id=?"
-	DeleteBuyerByID = "DELETE FROM buyers WHERE id = ?"
+	GetAllBuyers     = "SELECT buyers.id, buyers.card_number_id, buyers.first_name, buyers.last_name, buyers.deleted_at FROM buyers"
+	CountBuyers      = "SELECT COUNT(*) FROM buyers"
+	GetBuyerByID     = "SELECT buyers.id, buyers.card_number_id, buyers.first_name, buyers.last_name, buyers.deleted_at FROM buyers WHERE id = ? AND deleted_at IS NULL"
+	ExistsBuyerByID  = "SELECT buyers.card_number_id FROM buyers WHERE cardNumberID=?"
+	SaveBuyer        = "INSERT INTO buyers(card_number_id,first_name,last_name) VALUES (?,?,?)"
+	UpdateBuyer      = "UPDATE buyers SET card_number_id=?,first_name=?,last_name=?  WHERE id=?"
+	DeleteBuyerByID  = "UPDATE buyers SET deleted_at = NOW() WHERE id = ? AND deleted_at IS NULL"
+	RestoreBuyerByID = "UPDATE buyers SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL"
 )
 
+// Fields are the buyer fields list requests can sort and filter by.
+var Fields = listing.Fields{
+	"id":             "buyers.id",
+	"card_number_id": "buyers.card_number_id",
+	"first_name":     "buyers.first_name",
+	"last_name":      "buyers.last_name",
+}
+
 type buyerRepository struct {
 	db *sql.DB
 }
@@ -39,47 +54,86 @@ func NewBuyerRepository(db *sql.DB) BuyerRepository {
 func (r *buyerRepository) GetAll(ctx context.Context) ([]domain.Buyer, error) {
 	buyers := make([]domain.Buyer, 0)
 
-	rows, err := r.db.Query(GetAllBuyers)
+	rows, err := r.db.QueryContext(ctx, GetAllBuyers+" WHERE buyers.deleted_at IS NULL")
 	if err != nil {
 		return nil, err
 	}
 
 	for rows.Next() {
 		b := domain.Buyer{}
-		_ = rows.Scan(&b.ID, &b.CardNumberID, &b.FirstName, &b.LastName)
+		_ = rows.Scan(&b.ID, &b.CardNumberID, &b.FirstName, &b.LastName, &b.DeletedAt)
 		buyers = append(buyers, b)
 	}
 
 	return buyers, nil
 }
 
+func (r *buyerRepository) GetPage(ctx context.Context, options listing.Options) ([]domain.Buyer, int, error) {
+	var total int
+	query, args := options.CountQuery(CountBuyers)
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	buyers := make([]domain.Buyer, 0)
+	err := r.Each(ctx, options, func(b domain.Buyer) error {
+		buyers = append(buyers, b)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return buyers, total, nil
+}
+
+func (r *buyerRepository) Each(ctx context.Context, options listing.Options, fn func(domain.Buyer) error) error {
+	query, args := options.Query(GetAllBuyers)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		b := domain.Buyer{}
+		if err := rows.Scan(&b.ID, &b.CardNumberID, &b.FirstName, &b.LastName, &b.DeletedAt); err != nil {
+			return err
+		}
+		if err := fn(b); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 func (r *buyerRepository) Get(ctx context.Context, id int) (domain.Buyer, error) {
-	row := r.db.QueryRow(GetBuyerByID, id)
+	row := r.db.QueryRowContext(ctx, GetBuyerByID, id)
 	b := domain.Buyer{}
-	err := row.Scan(&b.ID, &b.CardNumberID, &b.FirstName, &b.LastName)
+	err := row.Scan(&b.ID, &b.CardNumberID, &b.FirstName, &b.LastName, &b.DeletedAt)
 	if err != nil {
-		return domain.Buyer{}, err
+		return domain.Buyer{}, database.TranslateError(err, "buyer")
 	}
 
 	return b, nil
 }
 
 func (r *buyerRepository) CardNumberExists(ctx context.Context, cardNumberID string) bool {
-	row := r.db.QueryRow(ExistsBuyerByID, cardNumberID)
+	row := r.db.QueryRowContext(ctx, ExistsBuyerByID, cardNumberID)
 	var foundId string
 	err := row.Scan(&foundId)
 	return err == nil
 }
 
 func (r *buyerRepository) Save(ctx context.Context, b domain.Buyer) (int, error) {
-	stmt, err := r.db.Prepare(SaveBuyer)
+	stmt, err := r.db.PrepareContext(ctx, SaveBuyer)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(&b.CardNumberID, &b.FirstName, &b.LastName)
+	res, err := stmt.ExecContext(ctx, &b.CardNumberID, &b.FirstName, &b.LastName)
 	if err != nil {
-		return 0, err
+		return 0, database.TranslateError(err, "buyer")
 	}
 
 	id, err := res.LastInsertId()
@@ -91,14 +145,14 @@ func (r *buyerRepository) Save(ctx context.Context, b domain.Buyer) (int, error)
 }
 
 func (r *buyerRepository) Update(ctx context.Context, b domain.Buyer) error {
-	stmt, err := r.db.Prepare(UpdateBuyer)
+	stmt, err := r.db.PrepareContext(ctx, UpdateBuyer)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(&b.CardNumberID, &b.FirstName, &b.LastName, &b.ID)
+	res, err := stmt.ExecContext(ctx, &b.CardNumberID, &b.FirstName, &b.LastName, &b.ID)
 	if err != nil {
-		return err
+		return database.TranslateError(err, "buyer")
 	}
 
 	_, err = res.RowsAffected()
@@ -110,16 +164,39 @@ func (r *buyerRepository) Update(ctx context.Context, b domain.Buyer) error {
 }
 
 func (r *buyerRepository) Delete(ctx context.Context, id int) error {
-	stmt, err := r.db.Prepare(DeleteBuyerByID)
+	stmt, err := r.db.PrepareContext(ctx, DeleteBuyerByID)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return database.TranslateError(err, "buyer")
+	}
+
+	affect, err := res.RowsAffected()
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(id)
+	if affect < 1 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *buyerRepository) Restore(ctx context.Context, id int) error {
+	stmt, err := r.db.PrepareContext(ctx, RestoreBuyerByID)
 	if err != nil {
 		return err
 	}
 
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return database.TranslateError(err, "buyer")
+	}
+
 	affect, err := res.RowsAffected()
 	if err != nil {
 		return err
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/assert"
 	"os"
 	"regexp"
@@ -53,9 +54,9 @@ func Test_buyerRepository_GetAll(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			r := NewBuyerRepository(tt.fields.db)
 
-			rows := sqlmock.NewRows([]string{"cardNumberID", "card_number_id", "first_name", "last_name"})
+			rows := sqlmock.NewRows([]string{"cardNumberID", "card_number_id", "first_name", "last_name", "deleted_at"})
 			for _, buyer := range validBuyers {
-				rows.AddRow(buyer.ID, buyer.CardNumberID, buyer.FirstName, buyer.LastName)
+				rows.AddRow(buyer.ID, buyer.CardNumberID, buyer.FirstName, buyer.LastName, nil)
 			}
 			if tt.wantErr {
 				rows.RowError(0, sql.ErrNoRows)
@@ -86,6 +87,55 @@ func Test_buyerRepository_GetAll(t *testing.T) {
 	})
 }
 
+func Test_buyerRepository_GetPage(t *testing.T) {
+	validBuyersSerialized, _ := os.ReadFile("../../test/resources/valid_buyers.json")
+	var validBuyers []domain.Buyer
+	if err := json.Unmarshal(validBuyersSerialized, &validBuyers); err != nil {
+		t.Fatal(err)
+	}
+
+	options := listing.Options{
+		Limit:   10,
+		Offset:  10,
+		Sort:    "buyers.last_name",
+		Filters: []listing.Filter{{Column: "buyers.first_name", Value: "Test"}},
+	}
+
+	t.Run("Successfully get a page of buyers", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		r := NewBuyerRepository(db)
+
+		rows := sqlmock.NewRows([]string{"id", "card_number_id", "first_name", "last_name", "deleted_at"})
+		for _, buyer := range validBuyers {
+			rows.AddRow(buyer.ID, buyer.CardNumberID, buyer.FirstName, buyer.LastName, nil)
+		}
+
+		mock.ExpectQuery(regexp.QuoteMeta(CountBuyers + " WHERE buyers.first_name = ?")).
+			WithArgs("Test").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+		mock.ExpectQuery(regexp.QuoteMeta(GetAllBuyers+" WHERE buyers.first_name = ? ORDER BY buyers.last_name ASC LIMIT ? OFFSET ?")).
+			WithArgs("Test", 10, 10).
+			WillReturnRows(rows)
+
+		got, total, err := r.GetPage(context.TODO(), options)
+
+		assert.NoError(t, err)
+		assert.Equal(t, validBuyers, got)
+		assert.Equal(t, 12, total)
+	})
+
+	t.Run("Error counting buyers", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		r := NewBuyerRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(CountBuyers)).WillReturnError(sql.ErrConnDone)
+
+		_, _, err := r.GetPage(context.TODO(), options)
+
+		assert.Equal(t, true, err != nil)
+	})
+}
+
 func Test_buyerRepository_Get(t *testing.T) {
 	type fields struct {
 		db *sql.DB
@@ -138,10 +188,10 @@ func Test_buyerRepository_Get(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			r := NewBuyerRepository(tt.fields.db)
 
-			rows := sqlmock.NewRows([]string{"cardNumberID", "card_number_id", "first_name", "last_name"}).
-				AddRow(tt.want.ID, tt.want.CardNumberID, tt.want.FirstName, tt.want.LastName)
+			rows := sqlmock.NewRows([]string{"cardNumberID", "card_number_id", "first_name", "last_name", "deleted_at"}).
+				AddRow(tt.want.ID, tt.want.CardNumberID, tt.want.FirstName, tt.want.LastName, nil)
 
-			mock.ExpectQuery(GetBuyerByID).
+			mock.ExpectQuery(regexp.QuoteMeta(GetBuyerByID)).
 				WithArgs(tt.want.ID).
 				WillReturnRows(rows)
 
@@ -500,3 +550,34 @@ func Test_buyerRepository_Delete(t *testing.T) {
 		assert.Equal(t, true, err != nil)
 	})
 }
+
+func Test_buyerRepository_Restore(t *testing.T) {
+	t.Run("Successfully restore buyer", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		r := NewBuyerRepository(db)
+
+		mock.ExpectPrepare(regexp.QuoteMeta(RestoreBuyerByID))
+		mock.ExpectExec(regexp.QuoteMeta(RestoreBuyerByID)).
+			WithArgs(6700).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := r.Restore(context.TODO(), 6700)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("No deleted buyer to restore", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		r := NewBuyerRepository(db)
+
+		mock.ExpectPrepare(regexp.QuoteMeta(RestoreBuyerByID))
+		mock.ExpectExec(regexp.QuoteMeta(RestoreBuyerByID)).
+			WithArgs(6700).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := r.Restore(context.TODO(), 6700)
+
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
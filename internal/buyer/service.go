@@ -2,25 +2,28 @@ package buyer
 
 import (
 	"context"
-	"database/sql"
-	"errors"
-	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/buyer"
 
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/buyer"
+	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 )
 
 // Errors
 var (
-	ErrNotFound             = errors.New("buyer not found")
-	ErrCardNumberDuplicated = errors.New("Credit card already exists for another user")
+	ErrNotFound             = errors2.NotFound("buyer")
+	ErrCardNumberDuplicated = &errors2.Error{Kind: errors2.KindConflict, Entity: "buyer", Field: "card_number_id", Message: "Credit card already exists for another user"}
 )
 
 type Service interface {
-	Get(ctx *context.Context, id int) (*domain.Buyer, error)
-	GetAll(ctx *context.Context) (*[]domain.Buyer, error)
-	Create(ctx *context.Context, createBuyerRequest *dtos.CreateBuyerRequestDTO) (*domain.Buyer, error)
-	Update(ctx *context.Context, id int, updateBuyerRequest *dtos.UpdateBuyerRequestDTO) (*domain.Buyer, error)
-	Delete(ctx *context.Context, id int) error
+	Get(ctx context.Context, id int) (*domain.Buyer, error)
+	GetAll(ctx context.Context) (*[]domain.Buyer, error)
+	GetPage(ctx context.Context, options listing.Options) (*[]domain.Buyer, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.Buyer) error) error
+	Create(ctx context.Context, createBuyerRequest *dtos.CreateBuyerRequestDTO) (*domain.Buyer, error)
+	Update(ctx context.Context, id int, updateBuyerRequest *dtos.UpdateBuyerRequestDTO) (*domain.Buyer, error)
+	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) (*domain.Buyer, error)
 }
 
 type service struct {
@@ -33,24 +36,19 @@ func NewService(repository BuyerRepository) Service {
 	}
 }
 
-func (service *service) Get(ctx *context.Context, id int) (*domain.Buyer, error) {
-	buyer, err := service.repository.Get(*ctx, id)
+func (service *service) Get(ctx context.Context, id int) (*domain.Buyer, error) {
+	buyer, err := service.repository.Get(ctx, id)
 	if err != nil {
-		switch err {
-		case sql.ErrNoRows:
-			return &domain.Buyer{}, ErrNotFound
-		default:
-			return &domain.Buyer{}, err
-		}
+		return &domain.Buyer{}, err
 	}
 
 	return &buyer, nil
 }
 
-func (service *service) GetAll(ctx *context.Context) (*[]domain.Buyer, error) {
+func (service *service) GetAll(ctx context.Context) (*[]domain.Buyer, error) {
 	buyers := make([]domain.Buyer, 0)
 
-	buyers, err := service.repository.GetAll(*ctx)
+	buyers, err := service.repository.GetAll(ctx)
 	if err != nil {
 		return &buyers, err
 	}
@@ -59,15 +57,28 @@ func (service *service) GetAll(ctx *context.Context) (*[]domain.Buyer, error) {
 
 }
 
-func (service *service) Create(ctx *context.Context, createBuyerRequest *dtos.CreateBuyerRequestDTO) (*domain.Buyer, error) {
+func (service *service) GetPage(ctx context.Context, options listing.Options) (*[]domain.Buyer, int, error) {
+	buyers, total, err := service.repository.GetPage(ctx, options)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &buyers, total, nil
+}
+
+func (service *service) Each(ctx context.Context, options listing.Options, fn func(domain.Buyer) error) error {
+	return service.repository.Each(ctx, options, fn)
+}
+
+func (service *service) Create(ctx context.Context, createBuyerRequest *dtos.CreateBuyerRequestDTO) (*domain.Buyer, error) {
 	buyer := createBuyerRequest.ToDomain()
 
-	cardNumberAlreadyExists := service.repository.CardNumberExists(*ctx, createBuyerRequest.CardNumberID)
+	cardNumberAlreadyExists := service.repository.CardNumberExists(ctx, createBuyerRequest.CardNumberID)
 	if cardNumberAlreadyExists {
 		return &domain.Buyer{}, ErrCardNumberDuplicated
 	}
 
-	id, err := service.repository.Save(*ctx, *buyer)
+	id, err := service.repository.Save(ctx, *buyer)
 	if err != nil {
 		return &domain.Buyer{}, err
 	}
@@ -77,7 +88,7 @@ func (service *service) Create(ctx *context.Context, createBuyerRequest *dtos.Cr
 	return buyer, nil
 }
 
-func (service *service) Update(ctx *context.Context, id int, updateBuyerRequest *dtos.UpdateBuyerRequestDTO) (*domain.Buyer, error) {
+func (service *service) Update(ctx context.Context, id int, updateBuyerRequest *dtos.UpdateBuyerRequestDTO) (*domain.Buyer, error) {
 	// Busca o buyer pelo ID
 	buyer, err := service.Get(ctx, id)
 	if err != nil {
@@ -86,7 +97,7 @@ func (service *service) Update(ctx *context.Context, id int, updateBuyerRequest
 
 	// Sobrescreve os dados do buyer, se houver alteração no request
 	if updateBuyerRequest.CardNumberID != nil {
-		cardNumberAlreadyExists := service.repository.CardNumberExists(*ctx, *updateBuyerRequest.CardNumberID)
+		cardNumberAlreadyExists := service.repository.CardNumberExists(ctx, *updateBuyerRequest.CardNumberID)
 		if cardNumberAlreadyExists {
 			return &domain.Buyer{}, ErrCardNumberDuplicated
 		}
@@ -102,7 +113,7 @@ func (service *service) Update(ctx *context.Context, id int, updateBuyerRequest
 		buyer.LastName = *updateBuyerRequest.LastName
 	}
 
-	if err := service.repository.Update(*ctx, *buyer); err != nil {
+	if err := service.repository.Update(ctx, *buyer); err != nil {
 		return &domain.Buyer{}, err
 	}
 
@@ -110,11 +121,19 @@ func (service *service) Update(ctx *context.Context, id int, updateBuyerRequest
 
 }
 
-func (service *service) Delete(ctx *context.Context, id int) error {
+func (service *service) Delete(ctx context.Context, id int) error {
 	// Busca o buyer pelo ID
 	if _, err := service.Get(ctx, id); err != nil {
 		return err
 	}
 
-	return service.repository.Delete(*ctx, id)
+	return service.repository.Delete(ctx, id)
+}
+
+func (service *service) Restore(ctx context.Context, id int) (*domain.Buyer, error) {
+	if err := service.repository.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return service.Get(ctx, id)
 }
@@ -2,11 +2,11 @@ package buyer_test
 
 import (
 	"context"
-	"database/sql"
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/buyer"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/buyer"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/buyer/mocks"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"testing"
@@ -45,7 +45,7 @@ func TestGet(t *testing.T) {
 			name:              "Buyer not found in db",
 			id:                1,
 			expectedGetResult: domain.Buyer{},
-			expectedGetError:  sql.ErrNoRows,
+			expectedGetError:  buyer.ErrNotFound,
 			expectedGetCalls:  1,
 			expectedBuyer:     &domain.Buyer{},
 			expectedError:     buyer.ErrNotFound,
@@ -69,7 +69,7 @@ func TestGet(t *testing.T) {
 			buyerRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(test.expectedGetResult, test.expectedGetError)
 
 			service := buyer.NewService(buyerRepositoryMock)
-			buyerReceived, err := service.Get(&ctx, test.id)
+			buyerReceived, err := service.Get(ctx, test.id)
 
 			assert.Equal(t, *test.expectedBuyer, *buyerReceived)
 			assert.Equal(t, test.expectedError, err)
@@ -132,7 +132,7 @@ func TestGetAll(t *testing.T) {
 			buyerRepositoryMock.On("GetAll", ctx).Return(test.expectedGetAllResult, test.expectedGetAllError)
 
 			service := buyer.NewService(buyerRepositoryMock)
-			buyerReceived, err := service.GetAll(&ctx)
+			buyerReceived, err := service.GetAll(ctx)
 
 			assert.Equal(t, *test.expectedBuyers, *buyerReceived)
 			assert.Equal(t, test.expectedError, err)
@@ -142,6 +142,66 @@ func TestGetAll(t *testing.T) {
 	}
 }
 
+func TestGetPage(t *testing.T) {
+
+	expectedBuyers := []domain.Buyer{
+		{
+			ID:           1,
+			CardNumberID: "123",
+			FirstName:    "Test",
+			LastName:     "Test",
+		},
+	}
+	options := listing.Options{Limit: 1}
+
+	tests := []struct {
+		name string
+		//Mocking buyerRepository.GetPage
+		expectedGetPageResult []domain.Buyer
+		expectedGetPageTotal  int
+		expectedGetPageError  error
+		//Asserting function
+		expectedBuyers *[]domain.Buyer
+		expectedTotal  int
+		expectedError  error
+	}{
+		{
+			name:                  "Successfully get a page of buyers from db",
+			expectedGetPageResult: expectedBuyers,
+			expectedGetPageTotal:  2,
+			expectedGetPageError:  nil,
+			expectedBuyers:        &expectedBuyers,
+			expectedTotal:         2,
+			expectedError:         nil,
+		},
+		{
+			name:                  "Error connecting db",
+			expectedGetPageResult: []domain.Buyer{},
+			expectedGetPageError:  assert.AnError,
+			expectedBuyers:        nil,
+			expectedError:         assert.AnError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			buyerRepositoryMock := mocks.NewBuyerRepositoryMock()
+			buyerRepositoryMock.On("GetPage", ctx, options).Return(test.expectedGetPageResult, test.expectedGetPageTotal, test.expectedGetPageError)
+
+			service := buyer.NewService(buyerRepositoryMock)
+			buyersReceived, total, err := service.GetPage(ctx, options)
+
+			assert.Equal(t, test.expectedBuyers, buyersReceived)
+			assert.Equal(t, test.expectedTotal, total)
+			assert.Equal(t, test.expectedError, err)
+
+			buyerRepositoryMock.AssertNumberOfCalls(t, "GetPage", 1)
+		})
+	}
+}
+
 func TestCreate(t *testing.T) {
 
 	createBuyerRequest := &dtos.CreateBuyerRequestDTO{
@@ -228,7 +288,7 @@ func TestCreate(t *testing.T) {
 			)
 
 			service := buyer.NewService(buyerRepositoryMock)
-			createdBuyer, err := service.Create(&ctx, test.createBuyerRequest)
+			createdBuyer, err := service.Create(ctx, test.createBuyerRequest)
 
 			assert.Equal(t, *test.expectedBuyer, *createdBuyer)
 			assert.Equal(t, test.expectedError, err)
@@ -286,7 +346,7 @@ func TestDelete(t *testing.T) {
 			buyerRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(test.expectedDeleteError)
 
 			service := buyer.NewService(buyerRepositoryMock)
-			err := service.Delete(&ctx, test.id)
+			err := service.Delete(ctx, test.id)
 
 			assert.Equal(t, test.expectedError, err)
 			buyerRepositoryMock.On("Get", test.expectedGetCalls)
@@ -295,6 +355,50 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestRestore(t *testing.T) {
+	tests := []struct {
+		name                 string
+		id                   int
+		expectedRestoreError error
+		expectedGetCalls     int
+		expectedBuyer        *domain.Buyer
+		expectedError        error
+	}{
+		{
+			name:                 "Successfully restoring buyer",
+			id:                   1,
+			expectedRestoreError: nil,
+			expectedGetCalls:     1,
+			expectedBuyer:        &domain.Buyer{ID: 1},
+			expectedError:        nil,
+		},
+		{
+			name:                 "Error restoring buyer not deleted",
+			id:                   1,
+			expectedRestoreError: buyer.ErrNotFound,
+			expectedGetCalls:     0,
+			expectedBuyer:        nil,
+			expectedError:        buyer.ErrNotFound,
+		},
+	}
+
+	ctx := context.TODO()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buyerRepositoryMock := mocks.NewBuyerRepositoryMock()
+			buyerRepositoryMock.On("Restore", ctx, test.id).Return(test.expectedRestoreError)
+			buyerRepositoryMock.On("Get", ctx, test.id).Return(domain.Buyer{ID: test.id}, nil)
+
+			service := buyer.NewService(buyerRepositoryMock)
+			restored, err := service.Restore(ctx, test.id)
+
+			assert.Equal(t, test.expectedError, err)
+			assert.Equal(t, test.expectedBuyer, restored)
+			buyerRepositoryMock.AssertNumberOfCalls(t, "Get", test.expectedGetCalls)
+		})
+	}
+}
+
 func TestUpdate(t *testing.T) {
 
 	originalBuyer := domain.Buyer{
@@ -350,7 +454,7 @@ func TestUpdate(t *testing.T) {
 			id:                 originalBuyer.ID,
 			updateBuyerRequest: updateBuyerRequest,
 			expectedGetResult:  domain.Buyer{},
-			expectedGetError:   sql.ErrNoRows,
+			expectedGetError:   buyer.ErrNotFound,
 			expectedGetCalls:   1,
 			//expectedExistsResult: false,
 			//expectedExistsCalls:  0,
@@ -399,7 +503,7 @@ func TestUpdate(t *testing.T) {
 			buyerRepositoryMock.On("CardNumberExists", ctx, mock.AnythingOfType("string")).Return(test.expectedExistsResult)
 			buyerRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.Buyer")).Return(test.expectedUpdateError)
 
-			newBuyer, err := buyerService.Update(&ctx, test.id, test.updateBuyerRequest)
+			newBuyer, err := buyerService.Update(ctx, test.id, test.updateBuyerRequest)
 
 			assert.Equal(t, test.expectedBuyer, newBuyer)
 			assert.Equal(t, test.expectedError, err)
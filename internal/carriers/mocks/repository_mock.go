@@ -5,6 +5,7 @@ import (
 
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/carrier"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -22,6 +23,29 @@ func (repository *CarrierRepositoryMock) GetAll(ctx context.Context) ([]domain.C
 	return args.Get(0).([]domain.Carrier), args.Error(1)
 }
 
+func (repository *CarrierRepositoryMock) GetPage(ctx context.Context, options listing.Options) ([]domain.Carrier, int, error) {
+	args := repository.Called(ctx, options)
+
+	return args.Get(0).([]domain.Carrier), args.Int(1), args.Error(2)
+}
+
+func (repository *CarrierRepositoryMock) Each(ctx context.Context, options listing.Options, fn func(domain.Carrier) error) error {
+	args := repository.Called(ctx, options)
+
+	for _, carrier := range args.Get(0).([]domain.Carrier) {
+		if err := fn(carrier); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (repository *CarrierRepositoryMock) Get(ctx context.Context, id int) (domain.Carrier, error) {
+	args := repository.Called(ctx, id)
+
+	return args.Get(0).(domain.Carrier), args.Error(1)
+}
+
 func (repository *CarrierRepositoryMock) Exists(ctx context.Context, cid string) bool {
 	args := repository.Called(ctx, cid)
 
@@ -34,6 +58,18 @@ func (repository *CarrierRepositoryMock) Save(ctx context.Context, carrier domai
 	return args.Get(0).(int), args.Error(1)
 }
 
+func (repository *CarrierRepositoryMock) Delete(ctx context.Context, id int) error {
+	args := repository.Called(ctx, id)
+
+	return args.Error(0)
+}
+
+func (repository *CarrierRepositoryMock) Restore(ctx context.Context, id int) error {
+	args := repository.Called(ctx, id)
+
+	return args.Error(0)
+}
+
 func (repository *CarrierRepositoryMock) GetLocalityById(ctx context.Context, localityId int) (domain.Locality, error) {
 	args := repository.Called(ctx, localityId)
 
@@ -46,8 +82,8 @@ func (repository *CarrierRepositoryMock) GetCountCarriersByLocalityId(ctx contex
 	return args.Get(0).(int), args.Error(1)
 }
 
-func (repository *CarrierRepositoryMock) GetCountAndDataByLocality(ctx context.Context) ([]dtos.DataLocalityAndCarrier, error) {
-	args := repository.Called(ctx)
+func (repository *CarrierRepositoryMock) GetCountAndDataByLocality(ctx context.Context, options listing.Options) ([]dtos.DataLocalityAndCarrier, int, error) {
+	args := repository.Called(ctx, options)
 
-	return args.Get(0).([]dtos.DataLocalityAndCarrier), args.Error(1)
+	return args.Get(0).([]dtos.DataLocalityAndCarrier), args.Int(1), args.Error(2)
 }
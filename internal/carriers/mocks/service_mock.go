@@ -5,6 +5,7 @@ import (
 
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/carrier"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -12,39 +13,68 @@ type CarrierServiceMock struct {
 	mock.Mock
 }
 
-func (service *CarrierServiceMock) Exists(ctx *context.Context, cid string) bool {
+func (service *CarrierServiceMock) Exists(ctx context.Context, cid string) bool {
 	args := service.Called(ctx, cid)
 
 	return args.Get(0).(bool)
 }
 
-func (service *CarrierServiceMock) GetAll(ctx *context.Context) (*[]domain.Carrier, error) {
+func (service *CarrierServiceMock) GetAll(ctx context.Context) (*[]domain.Carrier, error) {
 	args := service.Called(ctx)
 
 	return args.Get(0).(*[]domain.Carrier), args.Error(1)
 }
 
-func (service *CarrierServiceMock) Create(ctx *context.Context, carrier dtos.CarrierRequestDTO) (*domain.Carrier, error) {
+func (service *CarrierServiceMock) GetPage(ctx context.Context, options listing.Options) (*[]domain.Carrier, int, error) {
+	args := service.Called(ctx, options)
+
+	return args.Get(0).(*[]domain.Carrier), args.Int(1), args.Error(2)
+}
+
+func (service *CarrierServiceMock) Each(ctx context.Context, options listing.Options, fn func(domain.Carrier) error) error {
+	args := service.Called(ctx, options)
+
+	for _, carrier := range args.Get(0).([]domain.Carrier) {
+		if err := fn(carrier); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (service *CarrierServiceMock) Create(ctx context.Context, carrier dtos.CarrierRequestDTO) (*domain.Carrier, error) {
 	args := service.Called(ctx, carrier)
 
 	return args.Get(0).(*domain.Carrier), args.Error(1)
 }
 
-func (service *CarrierServiceMock) GetLocalityById(ctx *context.Context, localityId int) (*domain.Locality, error) {
+func (service *CarrierServiceMock) Delete(ctx context.Context, id int) error {
+	args := service.Called(ctx, id)
+
+	return args.Error(0)
+}
+
+func (service *CarrierServiceMock) Restore(ctx context.Context, id int) (*domain.Carrier, error) {
+	args := service.Called(ctx, id)
+
+	return args.Get(0).(*domain.Carrier), args.Error(1)
+}
+
+func (service *CarrierServiceMock) GetLocalityById(ctx context.Context, localityId int) (*domain.Locality, error) {
 	args := service.Called(ctx)
 
 	return args.Get(0).(*domain.Locality), args.Error(1)
 }
 
-func (service *CarrierServiceMock) GetCountCarriersByLocalityId(ctx *context.Context, localityId int) (*int, error) {
+func (service *CarrierServiceMock) GetCountCarriersByLocalityId(ctx context.Context, localityId int) (*int, error) {
 	args := service.Called(ctx)
 
 	r0, r1 := args.Get(0).(int), args.Error(1)
 	return &r0, r1
 }
 
-func (service *CarrierServiceMock) GetCountAndDataByLocality(ctx *context.Context) (*[]dtos.DataLocalityAndCarrier, error) {
-	args := service.Called(ctx)
+func (service *CarrierServiceMock) GetCountAndDataByLocality(ctx context.Context, options listing.Options) (*[]dtos.DataLocalityAndCarrier, int, error) {
+	args := service.Called(ctx, options)
 
-	return args.Get(0).(*[]dtos.DataLocalityAndCarrier), args.Error(1)
+	return args.Get(0).(*[]dtos.DataLocalityAndCarrier), args.Int(1), args.Error(2)
 }
@@ -5,16 +5,40 @@ import (
 	"database/sql"
 
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/carrier"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 )
 
 type Repository interface {
 	GetAll(ctx context.Context) ([]domain.Carrier, error)
+	GetPage(ctx context.Context, options listing.Options) ([]domain.Carrier, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.Carrier) error) error
+	Get(ctx context.Context, id int) (domain.Carrier, error)
 	Exists(ctx context.Context, cid string) bool
 	Save(ctx context.Context, w domain.Carrier) (int, error)
+	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) error
 	GetLocalityById(ctx context.Context, localityId int) (domain.Locality, error)
 	GetCountCarriersByLocalityId(ctx context.Context, localityId int) (int, error)
-	GetCountAndDataByLocality(ctx context.Context) ([]dtos.DataLocalityAndCarrier, error)
+	GetCountAndDataByLocality(ctx context.Context, options listing.Options) ([]dtos.DataLocalityAndCarrier, int, error)
+}
+
+// Fields are the carrier fields list requests can sort and filter by.
+var Fields = listing.Fields{
+	"id":           "id",
+	"cid":          "cid",
+	"company_name": "company_name",
+	"address":      "address",
+	"telephone":    "telephone",
+	"locality_id":  "locality_id",
+}
+
+// ReportFields are the locality fields the carriers report can sort and filter by.
+var ReportFields = listing.Fields{
+	"id":            "l.id",
+	"locality_name": "l.locality_name",
+	"province_id":   "l.province_id",
 }
 
 type repository struct {
@@ -28,8 +52,8 @@ func NewRepository(db *sql.DB) Repository {
 }
 
 func (r *repository) GetAll(ctx context.Context) ([]domain.Carrier, error) {
-	query := "SELECT id, cid, company_name, address, telephone, locality_id FROM carriers"
-	rows, err := r.db.Query(query)
+	query := "SELECT id, cid, company_name, address, telephone, locality_id, deleted_at FROM carriers WHERE deleted_at IS NULL"
+	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -38,30 +62,81 @@ func (r *repository) GetAll(ctx context.Context) ([]domain.Carrier, error) {
 
 	for rows.Next() {
 		c := domain.Carrier{}
-		_ = rows.Scan(&c.ID, &c.CID, &c.CompanyName, &c.Address, &c.Telephone, &c.LocalityId)
+		_ = rows.Scan(&c.ID, &c.CID, &c.CompanyName, &c.Address, &c.Telephone, &c.LocalityId, &c.DeletedAt)
 		carriers = append(carriers, c)
 	}
 
 	return carriers, nil
 }
 
+func (r *repository) GetPage(ctx context.Context, options listing.Options) ([]domain.Carrier, int, error) {
+	var total int
+	query, args := options.CountQuery("SELECT COUNT(*) FROM carriers")
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	carriers := make([]domain.Carrier, 0)
+	err := r.Each(ctx, options, func(c domain.Carrier) error {
+		carriers = append(carriers, c)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return carriers, total, nil
+}
+
+func (r *repository) Each(ctx context.Context, options listing.Options, fn func(domain.Carrier) error) error {
+	query, args := options.Query("SELECT id, cid, company_name, address, telephone, locality_id, deleted_at FROM carriers")
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		c := domain.Carrier{}
+		if err := rows.Scan(&c.ID, &c.CID, &c.CompanyName, &c.Address, &c.Telephone, &c.LocalityId, &c.DeletedAt); err != nil {
+			return err
+		}
+		if err := fn(c); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *repository) Get(ctx context.Context, id int) (domain.Carrier, error) {
+	query := "SELECT id, cid, company_name, address, telephone, locality_id, deleted_at FROM carriers WHERE id=? AND deleted_at IS NULL"
+	row := r.db.QueryRowContext(ctx, query, id)
+	c := domain.Carrier{}
+	err := row.Scan(&c.ID, &c.CID, &c.CompanyName, &c.Address, &c.Telephone, &c.LocalityId, &c.DeletedAt)
+	if err != nil {
+		return domain.Carrier{}, database.TranslateError(err, "carrier")
+	}
+
+	return c, nil
+}
+
 func (r *repository) Exists(ctx context.Context, cid string) bool {
 	query := "SELECT cid FROM carriers WHERE cid=?"
-	row := r.db.QueryRow(query, cid)
+	row := r.db.QueryRowContext(ctx, query, cid)
 	err := row.Scan(&cid)
 	return err == nil
 }
 
 func (r *repository) Save(ctx context.Context, c domain.Carrier) (int, error) {
 	query := "INSERT INTO carriers(cid, company_name, address, telephone, locality_id) VALUES (?,?,?,?,?)"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.db.PrepareContext(ctx, query)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(&c.CID, &c.CompanyName, &c.Address, &c.Telephone, &c.LocalityId)
+	res, err := stmt.ExecContext(ctx, &c.CID, &c.CompanyName, &c.Address, &c.Telephone, &c.LocalityId)
 	if err != nil {
-		return 0, err
+		return 0, database.TranslateError(err, "carrier")
 	}
 
 	id, err := res.LastInsertId()
@@ -71,20 +146,28 @@ func (r *repository) Save(ctx context.Context, c domain.Carrier) (int, error) {
 	return int(id), nil
 }
 
+func (r *repository) Delete(ctx context.Context, id int) error {
+	return r.setDeletedAt(ctx, "UPDATE carriers SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL", id)
+}
+
+func (r *repository) Restore(ctx context.Context, id int) error {
+	return r.setDeletedAt(ctx, "UPDATE carriers SET deleted_at=NULL WHERE id=? AND deleted_at IS NOT NULL", id)
+}
+
 func (r *repository) GetLocalityById(ctx context.Context, localityId int) (domain.Locality, error) {
 	query := "SELECT localities.id, localities.province_name, localities.locality_name FROM localities WHERE id = ?"
-	row := r.db.QueryRow(query, localityId)
+	row := r.db.QueryRowContext(ctx, query, localityId)
 	l := domain.Locality{}
 	err := row.Scan(&l.ID, &l.ProvinceName, &l.LocalityName)
 	if err != nil {
-		return domain.Locality{}, err
+		return domain.Locality{}, database.TranslateError(err, "locality")
 	}
 	return l, nil
 }
 
 func (r *repository) GetCountCarriersByLocalityId(ctx context.Context, localityId int) (int, error) {
-	query := "SELECT COUNT(id) FROM carriers WHERE locality_id = ?"
-	rows, err := r.db.Query(query, localityId)
+	query := "SELECT COUNT(id) FROM carriers WHERE locality_id = ? AND deleted_at IS NULL"
+	rows, err := r.db.QueryContext(ctx, query, localityId)
 	if err != nil {
 		return 0, err
 	}
@@ -98,21 +181,50 @@ func (r *repository) GetCountCarriersByLocalityId(ctx context.Context, localityI
 	return count, nil
 }
 
-func (r *repository) GetCountAndDataByLocality(ctx context.Context) ([]dtos.DataLocalityAndCarrier, error) {
-	query := "SELECT l.id, l.locality_name, (SELECT count(id) FROM carriers c where c.locality_id = l.id) AS count_carrier FROM localities l LIMIT 10"
-	rows, err := r.db.Query(query)
+func (r *repository) GetCountAndDataByLocality(ctx context.Context, options listing.Options) ([]dtos.DataLocalityAndCarrier, int, error) {
+	var total int
+	query, args := options.CountQuery("SELECT COUNT(*) FROM localities l")
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query, args = options.Query("SELECT l.id, l.locality_name, (SELECT count(id) FROM carriers c where c.locality_id = l.id AND c.deleted_at IS NULL) AS count_carrier FROM localities l")
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
+	defer rows.Close()
+
 	data := []dtos.DataLocalityAndCarrier{}
 	for rows.Next() {
 		d := dtos.DataLocalityAndCarrier{}
-		_ = rows.Scan(&d.Id, &d.LocalityName, &d.CountCarrier)
+		if err := rows.Scan(&d.Id, &d.LocalityName, &d.CountCarrier); err != nil {
+			return nil, 0, err
+		}
 		data = append(data, d)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return data, total, nil
+}
+
+// setDeletedAt runs query, a soft delete or restore of the carrier id, and returns ErrNotFound when it changes nothing.
+func (r *repository) setDeletedAt(ctx context.Context, query string, id int) error {
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return database.TranslateError(err, "carrier")
+	}
+
+	affect, err := res.RowsAffected()
 	if err != nil {
-		return []dtos.DataLocalityAndCarrier{}, nil
+		return err
+	}
+
+	if affect < 1 {
+		return ErrNotFound
 	}
 
-	return data, nil
+	return nil
 }
@@ -3,6 +3,7 @@ package carriers_test
 import (
 	"context"
 	"database/sql"
+	"net/url"
 	"regexp"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/carrier"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/carriers"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -42,12 +44,12 @@ func TestRepositoryGetAll(t *testing.T) {
 		}
 		r := carriers.NewRepository(fields{db}.db)
 
-		rows := sqlmock.NewRows([]string{"id", "cid", "company_name", "address", "telephone", "locality_id"})
+		rows := sqlmock.NewRows([]string{"id", "cid", "company_name", "address", "telephone", "locality_id", "deleted_at"})
 
 		for _, expectedCarrier := range expectedCarriers {
-			rows.AddRow(expectedCarrier.ID, expectedCarrier.CID, expectedCarrier.CompanyName, expectedCarrier.Address, expectedCarrier.Telephone, expectedCarrier.LocalityId)
+			rows.AddRow(expectedCarrier.ID, expectedCarrier.CID, expectedCarrier.CompanyName, expectedCarrier.Address, expectedCarrier.Telephone, expectedCarrier.LocalityId, nil)
 		}
-		mock.ExpectQuery("SELECT id, cid, company_name, address, telephone, locality_id FROM carriers").WillReturnRows(rows)
+		mock.ExpectQuery("SELECT id, cid, company_name, address, telephone, locality_id, deleted_at FROM carriers WHERE deleted_at IS NULL").WillReturnRows(rows)
 
 		carriersReceived, err := r.GetAll(ctx)
 
@@ -59,7 +61,7 @@ func TestRepositoryGetAll(t *testing.T) {
 
 		r := carriers.NewRepository(fields{db}.db)
 
-		mock.ExpectQuery("SELECT id, cid, company_name, address, telephone, locality_id FROM carriers").
+		mock.ExpectQuery("SELECT id, cid, company_name, address, telephone, locality_id, deleted_at FROM carriers WHERE deleted_at IS NULL").
 			WithArgs().
 			WillReturnError(sql.ErrNoRows)
 
@@ -69,6 +71,38 @@ func TestRepositoryGetAll(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 }
+func TestRepositoryGet(t *testing.T) {
+	query := "SELECT id, cid, company_name, address, telephone, locality_id, deleted_at FROM carriers WHERE id=? AND deleted_at IS NULL"
+
+	t.Run("get_ok", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		expectedCarrier := domain.Carrier{ID: 1, CID: "CID#1", CompanyName: "some name", Address: "corrientes 800", Telephone: "4567-4567", LocalityId: 6700}
+
+		r := carriers.NewRepository(db)
+
+		rows := sqlmock.NewRows([]string{"id", "cid", "company_name", "address", "telephone", "locality_id", "deleted_at"}).
+			AddRow(expectedCarrier.ID, expectedCarrier.CID, expectedCarrier.CompanyName, expectedCarrier.Address, expectedCarrier.Telephone, expectedCarrier.LocalityId, nil)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1).WillReturnRows(rows)
+
+		carrierReceived, err := r.Get(context.TODO(), 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedCarrier, carrierReceived)
+	})
+
+	t.Run("get_deleted", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+
+		r := carriers.NewRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(1).WillReturnError(sql.ErrNoRows)
+
+		_, err := r.Get(context.TODO(), 1)
+
+		assert.ErrorIs(t, err, carriers.ErrNotFound)
+	})
+}
+
 func TestRepositoryExists(t *testing.T) {
 	type fields struct {
 		db *sql.DB
@@ -301,7 +335,7 @@ func TestRepositoryCountCarriersByLocalityId(t *testing.T) {
 		for _, expectedCarrier := range expectedCarriers {
 			rows.AddRow(expectedCarrier.ID)
 		}
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(id) FROM carriers WHERE locality_id = ?")).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(id) FROM carriers WHERE locality_id = ? AND deleted_at IS NULL")).WillReturnRows(rows)
 
 		carriersReceived, err := r.GetCountCarriersByLocalityId(ctx, 6701)
 
@@ -338,7 +372,7 @@ func TestRepositoryCountCarriersByLocalityId(t *testing.T) {
 		for _, expectedCarrier := range expectedCarriers {
 			rows.AddRow(expectedCarrier.ID)
 		}
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(id) FROM carriers WHERE locality_id = ?")).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(id) FROM carriers WHERE locality_id = ? AND deleted_at IS NULL")).WillReturnRows(rows)
 
 		carriersReceived, err := r.GetCountCarriersByLocalityId(ctx, 6701)
 
@@ -350,7 +384,7 @@ func TestRepositoryCountCarriersByLocalityId(t *testing.T) {
 		r := carriers.NewRepository(fields{db}.db)
 
 		rows := sqlmock.NewRows([]string{"COUNT(id)"})
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(id) FROM carriers WHERE locality_id = ?")).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(id) FROM carriers WHERE locality_id = ? AND deleted_at IS NULL")).WillReturnRows(rows)
 
 		count, error := r.GetCountCarriersByLocalityId(ctx, 0)
 
@@ -359,15 +393,58 @@ func TestRepositoryCountCarriersByLocalityId(t *testing.T) {
 	})
 }
 
+func TestRepositoryGetPage(t *testing.T) {
+	options, _ := listing.Parse(url.Values{"locality_id": {"1"}, "limit": {"1"}}, carriers.Fields)
+
+	t.Run("get_page_ok", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		expectedCarriers := []domain.Carrier{{ID: 1, CID: "CID#1", CompanyName: "Teste", Address: "Rua", Telephone: "123", LocalityId: 1}}
+
+		r := carriers.NewRepository(db)
+
+		rows := sqlmock.NewRows([]string{"id", "cid", "company_name", "address", "telephone", "locality_id", "deleted_at"})
+		for _, c := range expectedCarriers {
+			rows.AddRow(c.ID, c.CID, c.CompanyName, c.Address, c.Telephone, c.LocalityId, nil)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM carriers WHERE locality_id = ?")).
+			WithArgs("1").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectQuery(regexp.QuoteMeta("FROM carriers WHERE locality_id = ? ORDER BY id ASC LIMIT ? OFFSET ?")).
+			WithArgs("1", 1, 0).
+			WillReturnRows(rows)
+
+		carriersReceived, total, err := r.GetPage(context.TODO(), options)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedCarriers, carriersReceived)
+		assert.Equal(t, 2, total)
+	})
+
+	t.Run("get_page_error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+
+		r := carriers.NewRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM carriers")).
+			WillReturnError(sql.ErrConnDone)
+
+		carriersReceived, _, err := r.GetPage(context.TODO(), options)
+
+		assert.Nil(t, carriersReceived)
+		assert.NotNil(t, err)
+	})
+}
+
 func TestRepositoryCountAndDataByLocality(t *testing.T) {
 	type fields struct {
 		db *sql.DB
 	}
 
-	db, mock, _ := sqlmock.New()
+	options, _ := listing.Parse(url.Values{"limit": {"10"}}, carriers.ReportFields)
 	ctx := context.TODO()
 
 	t.Run("get_datas_success", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
 		actualDatas := []dtos.DataLocalityAndCarrier{
 			{
 				Id:           12,
@@ -382,26 +459,119 @@ func TestRepositoryCountAndDataByLocality(t *testing.T) {
 		for _, actualData := range actualDatas {
 			rows.AddRow(actualData.Id, actualData.LocalityName, actualData.CountCarrier)
 		}
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT l.id, l.locality_name, (SELECT count(id) FROM carriers c where c.locality_id = l.id) AS count_carrier FROM localities l LIMIT 10")).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM localities l")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT l.id, l.locality_name, (SELECT count(id) FROM carriers c where c.locality_id = l.id AND c.deleted_at IS NULL) AS count_carrier FROM localities l ORDER BY l.id ASC LIMIT ? OFFSET ?")).
+			WithArgs(10, 0).
+			WillReturnRows(rows)
 
-		datasReceived, err := r.GetCountAndDataByLocality(ctx)
+		datasReceived, total, err := r.GetCountAndDataByLocality(ctx, options)
 
 		assert.Equal(t, actualDatas, datasReceived)
+		assert.Equal(t, 1, total)
 		assert.Nil(t, err)
 	})
 
-	t.Run("get_datas_fail", func(t *testing.T) {
-
+	t.Run("get_datas_empty", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
 		actualDatas := []dtos.DataLocalityAndCarrier{}
 		r := carriers.NewRepository(fields{db}.db)
 
 		rows := sqlmock.NewRows([]string{"id", "locality_name", "count_carrier"})
 
-		mock.ExpectQuery(regexp.QuoteMeta("SELECT l.id, l.locality_name, (SELECT count(id) FROM carriers c where c.locality_id = l.id) AS count_carrier FROM localities l LIMIT 10")).WillReturnRows(rows)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM localities l")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(regexp.QuoteMeta("FROM localities l ORDER BY l.id ASC LIMIT ? OFFSET ?")).
+			WithArgs(10, 0).
+			WillReturnRows(rows)
 
-		datasReceived, err := r.GetCountAndDataByLocality(ctx)
+		datasReceived, total, err := r.GetCountAndDataByLocality(ctx, options)
 
 		assert.Equal(t, actualDatas, datasReceived)
+		assert.Equal(t, 0, total)
+		assert.Nil(t, err)
+	})
+
+	t.Run("get_datas_fail", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		r := carriers.NewRepository(fields{db}.db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM localities l")).
+			WillReturnError(sql.ErrConnDone)
+
+		datasReceived, _, err := r.GetCountAndDataByLocality(ctx, options)
+
+		assert.Nil(t, datasReceived)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestRepositoryDelete(t *testing.T) {
+	query := "UPDATE carriers SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"
+
+	t.Run("delete_ok", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+
+		r := carriers.NewRepository(db)
+
+		mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := r.Delete(context.TODO(), 1)
+
+		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("delete_not_found", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+
+		r := carriers.NewRepository(db)
+
+		mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := r.Delete(context.TODO(), 1)
+
+		assert.Equal(t, carriers.ErrNotFound, err)
+	})
+
+	t.Run("delete_error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+
+		r := carriers.NewRepository(db)
+
+		mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(1).WillReturnError(sql.ErrConnDone)
+
+		err := r.Delete(context.TODO(), 1)
+
+		assert.Equal(t, sql.ErrConnDone, err)
+	})
+}
+
+func TestRepositoryRestore(t *testing.T) {
+	query := "UPDATE carriers SET deleted_at=NULL WHERE id=? AND deleted_at IS NOT NULL"
+
+	t.Run("restore_ok", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+
+		r := carriers.NewRepository(db)
+
+		mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := r.Restore(context.TODO(), 1)
+
 		assert.Nil(t, err)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("restore_not_deleted", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+
+		r := carriers.NewRepository(db)
+
+		mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := r.Restore(context.TODO(), 1)
+
+		assert.Equal(t, carriers.ErrNotFound, err)
 	})
 }
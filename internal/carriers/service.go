@@ -2,26 +2,29 @@ package carriers
 
 import (
 	"context"
-	"errors"
 
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/carrier"
+	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 )
 
 var (
-	ErrNotFound            = errors.New("carriers not found")
-	ErrConflict            = errors.New("a carriers with this cid already exists")
-	ErrUnprocessableEntity = errors.New("all fields are required")
-	ErrInternalServerError = errors.New("error connecting to server")
-	ErrLocalityNotFound    = errors.New("locality not found")
+	ErrNotFound         = &errors2.Error{Kind: errors2.KindNotFound, Entity: "carrier", Message: "carriers not found"}
+	ErrConflict         = &errors2.Error{Kind: errors2.KindConflict, Entity: "carrier", Field: "cid", Message: "a carriers with this cid already exists"}
+	ErrLocalityNotFound = &errors2.Error{Kind: errors2.KindNotFound, Entity: "locality", Message: "locality not found"}
 )
 
 type Service interface {
-	Create(c *context.Context, dto dtos.CarrierRequestDTO) (*domain.Carrier, error)
-	GetAll(c *context.Context) (*[]domain.Carrier, error)
-	GetLocalityById(c *context.Context, localityId int) (*domain.Locality, error)
-	GetCountCarriersByLocalityId(c *context.Context, localityId int) (*int, error)
-	GetCountAndDataByLocality(c *context.Context) (*[]dtos.DataLocalityAndCarrier, error)
+	Create(ctx context.Context, dto dtos.CarrierRequestDTO) (*domain.Carrier, error)
+	GetAll(ctx context.Context) (*[]domain.Carrier, error)
+	GetPage(ctx context.Context, options listing.Options) (*[]domain.Carrier, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.Carrier) error) error
+	GetLocalityById(ctx context.Context, localityId int) (*domain.Locality, error)
+	GetCountCarriersByLocalityId(ctx context.Context, localityId int) (*int, error)
+	GetCountAndDataByLocality(ctx context.Context, options listing.Options) (*[]dtos.DataLocalityAndCarrier, int, error)
+	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) (*domain.Carrier, error)
 }
 
 type service struct {
@@ -32,9 +35,9 @@ func NewService(r Repository) Service {
 	return &service{repository: r}
 }
 
-func (s *service) Create(c *context.Context, dto dtos.CarrierRequestDTO) (*domain.Carrier, error) {
+func (s *service) Create(ctx context.Context, dto dtos.CarrierRequestDTO) (*domain.Carrier, error) {
 
-	exists := s.repository.Exists(*c, dto.CID)
+	exists := s.repository.Exists(ctx, dto.CID)
 	if exists {
 		return nil, ErrConflict
 	}
@@ -48,7 +51,7 @@ func (s *service) Create(c *context.Context, dto dtos.CarrierRequestDTO) (*domai
 		LocalityId:  dto.LocalityId,
 	}
 
-	id, err := s.repository.Save(*c, formatter)
+	id, err := s.repository.Save(ctx, formatter)
 
 	if err != nil {
 		return nil, err
@@ -59,8 +62,8 @@ func (s *service) Create(c *context.Context, dto dtos.CarrierRequestDTO) (*domai
 	return &formatter, nil
 }
 
-func (s *service) GetAll(c *context.Context) (*[]domain.Carrier, error) {
-	carriers, err := s.repository.GetAll(*c)
+func (s *service) GetAll(ctx context.Context) (*[]domain.Carrier, error) {
+	carriers, err := s.repository.GetAll(ctx)
 
 	if err != nil {
 		return nil, err
@@ -69,29 +72,60 @@ func (s *service) GetAll(c *context.Context) (*[]domain.Carrier, error) {
 	return &carriers, nil
 }
 
-func (s *service) GetLocalityById(c *context.Context, localityId int) (*domain.Locality, error) {
-	result, err := s.repository.GetLocalityById(*c, localityId)
+func (s *service) GetPage(ctx context.Context, options listing.Options) (*[]domain.Carrier, int, error) {
+	carriers, total, err := s.repository.GetPage(ctx, options)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &carriers, total, nil
+}
+
+func (s *service) Each(ctx context.Context, options listing.Options, fn func(domain.Carrier) error) error {
+	return s.repository.Each(ctx, options, fn)
+}
+
+func (s *service) GetLocalityById(ctx context.Context, localityId int) (*domain.Locality, error) {
+	result, err := s.repository.GetLocalityById(ctx, localityId)
 	if err != nil {
-		return &domain.Locality{}, ErrLocalityNotFound
+		return &domain.Locality{}, err
 	}
 
 	return &result, nil
 }
 
-func (s *service) GetCountCarriersByLocalityId(c *context.Context, localityId int) (*int, error) {
-	count, err := s.repository.GetCountCarriersByLocalityId(*c, localityId)
+func (s *service) GetCountCarriersByLocalityId(ctx context.Context, localityId int) (*int, error) {
+	count, err := s.repository.GetCountCarriersByLocalityId(ctx, localityId)
 	if err != nil {
-		return nil, ErrNotFound
+		return nil, err
 	}
 
 	return &count, nil
 }
 
-func (s *service) GetCountAndDataByLocality(c *context.Context) (*[]dtos.DataLocalityAndCarrier, error) {
-	dataAndCount, err := s.repository.GetCountAndDataByLocality(*c)
+func (s *service) GetCountAndDataByLocality(ctx context.Context, options listing.Options) (*[]dtos.DataLocalityAndCarrier, int, error) {
+	dataAndCount, total, err := s.repository.GetCountAndDataByLocality(ctx, options)
 
 	if err != nil {
+		return nil, 0, err
+	}
+	return &dataAndCount, total, nil
+}
+
+func (s *service) Delete(ctx context.Context, id int) error {
+	return s.repository.Delete(ctx, id)
+}
+
+func (s *service) Restore(ctx context.Context, id int) (*domain.Carrier, error) {
+	if err := s.repository.Restore(ctx, id); err != nil {
 		return nil, err
 	}
-	return &dataAndCount, nil
+
+	carrier, err := s.repository.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &carrier, nil
 }
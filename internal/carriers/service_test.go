@@ -9,6 +9,7 @@ import (
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/carriers"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/carriers/mocks"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -39,7 +40,7 @@ func TestGetAll(t *testing.T) {
 		carrieRepositoryMock.On("GetAll", ctx).Return(*expectedCarriers, nil)
 
 		service := carriers.NewService(carrieRepositoryMock)
-		carriersReceived, err := service.GetAll(&ctx)
+		carriersReceived, err := service.GetAll(ctx)
 
 		assert.Equal(t, *expectedCarriers, *carriersReceived)
 		assert.Equal(t, nil, err)
@@ -52,7 +53,7 @@ func TestGetAll(t *testing.T) {
 		carrieRepositoryMock.On("GetAll", ctx).Return([]domain.Carrier{}, errors.New("error"))
 
 		service := carriers.NewService(carrieRepositoryMock)
-		warehousesReceived, err := service.GetAll(&ctx)
+		warehousesReceived, err := service.GetAll(ctx)
 
 		assert.Nil(t, warehousesReceived)
 		assert.Equal(t, errors.New("error"), err)
@@ -60,6 +61,38 @@ func TestGetAll(t *testing.T) {
 
 }
 
+func TestGetPage(t *testing.T) {
+	t.Run("find_page", func(t *testing.T) {
+		expectedCarriers := []domain.Carrier{{ID: 1, CID: "CID#1", CompanyName: "Teste", LocalityId: 1}}
+		options := listing.Options{Limit: 1}
+		ctx := context.TODO()
+
+		carrieRepositoryMock := new(mocks.CarrierRepositoryMock)
+		carrieRepositoryMock.On("GetPage", ctx, options).Return(expectedCarriers, 3, nil)
+
+		service := carriers.NewService(carrieRepositoryMock)
+		carriersReceived, total, err := service.GetPage(ctx, options)
+
+		assert.Equal(t, expectedCarriers, *carriersReceived)
+		assert.Equal(t, 3, total)
+		assert.Equal(t, nil, err)
+	})
+
+	t.Run("find_page_error", func(t *testing.T) {
+		options := listing.Options{Limit: 1}
+		ctx := context.TODO()
+
+		carrieRepositoryMock := new(mocks.CarrierRepositoryMock)
+		carrieRepositoryMock.On("GetPage", ctx, options).Return([]domain.Carrier{}, 0, errors.New("error"))
+
+		service := carriers.NewService(carrieRepositoryMock)
+		carriersReceived, _, err := service.GetPage(ctx, options)
+
+		assert.Nil(t, carriersReceived)
+		assert.Equal(t, errors.New("error"), err)
+	})
+}
+
 func TestCreate(t *testing.T) {
 	t.Run("create_conflict", func(t *testing.T) {
 
@@ -77,7 +110,7 @@ func TestCreate(t *testing.T) {
 		carrieRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(true)
 
 		service := carriers.NewService(carrieRepositoryMock)
-		carrieSaved, err := service.Create(&ctx, createCarrierRequestDTO)
+		carrieSaved, err := service.Create(ctx, createCarrierRequestDTO)
 
 		assert.Equal(t, carriers.ErrConflict, err)
 		assert.Nil(t, carrieSaved)
@@ -101,9 +134,9 @@ func TestCreate(t *testing.T) {
 		carrieRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.Carrier")).Return(0, errors.New("error connecting to server"))
 		service := carriers.NewService(carrieRepositoryMock)
 
-		carrieSaved, err := service.Create(&ctx, createCarrierRequestDTO)
+		carrieSaved, err := service.Create(ctx, createCarrierRequestDTO)
 
-		assert.Equal(t, carriers.ErrInternalServerError, err)
+		assert.Equal(t, errors.New("error connecting to server"), err)
 		assert.Nil(t, carrieSaved)
 
 	})
@@ -125,9 +158,9 @@ func TestCreate(t *testing.T) {
 		carrieRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.Carrie")).Return(0, errors.New("a carriers with this cid already exists"))
 		service := carriers.NewService(carrieRepositoryMock)
 
-		carrieSaved, err := service.Create(&ctx, createCarrierRequestDTO)
+		carrieSaved, err := service.Create(ctx, createCarrierRequestDTO)
 
-		assert.Equal(t, errors.New("a carriers with this cid already exists"), err)
+		assert.Equal(t, carriers.ErrConflict, err)
 		assert.Nil(t, carrieSaved)
 
 	})
@@ -156,7 +189,7 @@ func TestCreate(t *testing.T) {
 		carrieRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.Carrier")).Return(1, nil)
 		service := carriers.NewService(carrieRepositoryMock)
 
-		carrieSaved, err := service.Create(&ctx, createCarrierRequestDTO)
+		carrieSaved, err := service.Create(ctx, createCarrierRequestDTO)
 
 		assert.Equal(t, carrieSaved, expectedCarrier)
 		assert.Nil(t, err)
@@ -179,7 +212,7 @@ func TestCreate(t *testing.T) {
 		carrieRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.Carrier")).Return(0, errors.New("error connecting to server"))
 		service := carriers.NewService(carrieRepositoryMock)
 
-		carrieSaved, err := service.Create(&ctx, createCarrierRequestDTO)
+		carrieSaved, err := service.Create(ctx, createCarrierRequestDTO)
 
 		assert.Nil(t, carrieSaved)
 		assert.Equal(t, errors.New("error connecting to server"), err)
@@ -199,7 +232,7 @@ func TestGetLocalityById(t *testing.T) {
 		carrieRepositoryMock.On("GetLocalityById", ctx, mock.AnythingOfType("int")).Return(*localityExpected, nil)
 
 		service := carriers.NewService(carrieRepositoryMock)
-		locality, err := service.GetLocalityById(&ctx, 1)
+		locality, err := service.GetLocalityById(ctx, 1)
 		assert.Equal(t, *localityExpected, *locality)
 		assert.Equal(t, nil, err)
 	})
@@ -208,11 +241,11 @@ func TestGetLocalityById(t *testing.T) {
 
 		ctx := context.TODO()
 		carrieRepositoryMock := new(mocks.CarrierRepositoryMock)
-		carrieRepositoryMock.On("GetLocalityById", ctx, mock.AnythingOfType("int")).Return(domain.Locality{}, errors.New("locality not found"))
+		carrieRepositoryMock.On("GetLocalityById", ctx, mock.AnythingOfType("int")).Return(domain.Locality{}, carriers.ErrLocalityNotFound)
 
 		service := carriers.NewService(carrieRepositoryMock)
 
-		idReceived, err := service.GetLocalityById(&ctx, 1)
+		idReceived, err := service.GetLocalityById(ctx, 1)
 
 		assert.NotNil(t, idReceived)
 		assert.Equal(t, carriers.ErrLocalityNotFound, err)
@@ -229,7 +262,7 @@ func TestGetCountCarriersByLocalityId(t *testing.T) {
 		carrieRepositoryMock.On("GetCountCarriersByLocalityId", ctx, mock.AnythingOfType("int")).Return(1, nil)
 
 		service := carriers.NewService(carrieRepositoryMock)
-		int, err := service.GetCountCarriersByLocalityId(&ctx, 1)
+		int, err := service.GetCountCarriersByLocalityId(ctx, 1)
 		assert.Equal(t, *int, intExpected)
 		assert.Equal(t, nil, err)
 	})
@@ -242,10 +275,10 @@ func TestGetCountCarriersByLocalityId(t *testing.T) {
 
 		service := carriers.NewService(carrieRepositoryMock)
 
-		idReceived, err := service.GetCountCarriersByLocalityId(&ctx, 1)
+		idReceived, err := service.GetCountCarriersByLocalityId(ctx, 1)
 
 		assert.Nil(t, idReceived)
-		assert.Equal(t, carriers.ErrNotFound, err)
+		assert.Equal(t, errors.New("carriers not found"), err)
 
 	})
 }
@@ -266,24 +299,84 @@ func TestGetCountAndDataByLocality(t *testing.T) {
 		}
 		ctx := context.TODO()
 		carrieRepositoryMock := new(mocks.CarrierRepositoryMock)
-		carrieRepositoryMock.On("GetCountAndDataByLocality", ctx).Return(responsesFounds, nil)
+		options := listing.Options{Limit: 2}
+		carrieRepositoryMock.On("GetCountAndDataByLocality", ctx, options).Return(responsesFounds, 7, nil)
 
 		service := carriers.NewService(carrieRepositoryMock)
-		returnExpected, err := service.GetCountAndDataByLocality(&ctx)
+		returnExpected, total, err := service.GetCountAndDataByLocality(ctx, options)
 		assert.Equal(t, *returnExpected, responsesFounds)
+		assert.Equal(t, 7, total)
 		assert.Equal(t, nil, err)
 	})
 	t.Run("find_non_existent", func(t *testing.T) {
 		responsesFounds := []dtos.DataLocalityAndCarrier{}
 		ctx := context.TODO()
 		carrieRepositoryMock := new(mocks.CarrierRepositoryMock)
-		carrieRepositoryMock.On("GetCountAndDataByLocality", ctx).Return(responsesFounds, errors.New("carriers not found"))
+		options := listing.Options{Limit: 2}
+		carrieRepositoryMock.On("GetCountAndDataByLocality", ctx, options).Return(responsesFounds, 0, errors.New("carriers not found"))
 
 		service := carriers.NewService(carrieRepositoryMock)
 
-		count, err := service.GetCountAndDataByLocality(&ctx)
+		count, _, err := service.GetCountAndDataByLocality(ctx, options)
 
 		assert.Nil(t, count)
 		assert.Equal(t, errors.New("carriers not found"), err)
 	})
 }
+
+func TestDelete(t *testing.T) {
+	t.Run("delete_ok", func(t *testing.T) {
+		ctx := context.TODO()
+
+		carrieRepositoryMock := new(mocks.CarrierRepositoryMock)
+		carrieRepositoryMock.On("Delete", ctx, 1).Return(nil)
+
+		service := carriers.NewService(carrieRepositoryMock)
+		err := service.Delete(ctx, 1)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("delete_not_found", func(t *testing.T) {
+		ctx := context.TODO()
+
+		carrieRepositoryMock := new(mocks.CarrierRepositoryMock)
+		carrieRepositoryMock.On("Delete", ctx, 1).Return(carriers.ErrNotFound)
+
+		service := carriers.NewService(carrieRepositoryMock)
+		err := service.Delete(ctx, 1)
+
+		assert.Equal(t, carriers.ErrNotFound, err)
+	})
+}
+
+func TestRestore(t *testing.T) {
+	t.Run("restore_ok", func(t *testing.T) {
+		expectedCarrier := domain.Carrier{ID: 1, CID: "CID#1", CompanyName: "some name", Address: "corrientes 800", Telephone: "4567-4567", LocalityId: 6700}
+		ctx := context.TODO()
+
+		carrieRepositoryMock := new(mocks.CarrierRepositoryMock)
+		carrieRepositoryMock.On("Restore", ctx, 1).Return(nil)
+		carrieRepositoryMock.On("Get", ctx, 1).Return(expectedCarrier, nil)
+
+		service := carriers.NewService(carrieRepositoryMock)
+		carrierReceived, err := service.Restore(ctx, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &expectedCarrier, carrierReceived)
+	})
+
+	t.Run("restore_not_deleted", func(t *testing.T) {
+		ctx := context.TODO()
+
+		carrieRepositoryMock := new(mocks.CarrierRepositoryMock)
+		carrieRepositoryMock.On("Restore", ctx, 1).Return(carriers.ErrNotFound)
+
+		service := carriers.NewService(carrieRepositoryMock)
+		carrierReceived, err := service.Restore(ctx, 1)
+
+		assert.Nil(t, carrierReceived)
+		assert.Equal(t, carriers.ErrNotFound, err)
+		carrieRepositoryMock.AssertNotCalled(t, "Get", ctx, 1)
+	})
+}
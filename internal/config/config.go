@@ -0,0 +1,210 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the settings needed to bootstrap the server.
+// Values are taken from the defaults, then the optional YAML file, then the environment variables.
+type Config struct {
+	Server   ServerConfig   `yaml:"server"`
+	Database DatabaseConfig `yaml:"database"`
+	Auth     AuthConfig     `yaml:"auth"`
+	Swagger  SwaggerConfig  `yaml:"swagger"`
+}
+
+type ServerConfig struct {
+	Address      string        `yaml:"address"`
+	ReadTimeout  time.Duration `yaml:"read_timeout"`
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout"`
+	// RequestTimeout bounds each request, cancelling its database work when exceeded. Zero disables it.
+	RequestTimeout time.Duration `yaml:"request_timeout"`
+	// StreamTimeout bounds the streaming requests, bulk imports and exports, instead of RequestTimeout. Zero disables it.
+	StreamTimeout time.Duration `yaml:"stream_timeout"`
+	// ShutdownTimeout is how long in-flight requests may take to finish after SIGINT or SIGTERM.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+}
+
+type DatabaseConfig struct {
+	DSN             string        `yaml:"dsn"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+	PingAttempts    int           `yaml:"ping_attempts"`
+	PingBackoff     time.Duration `yaml:"ping_backoff"`
+}
+
+type AuthConfig struct {
+	JWTSecret string        `yaml:"jwt_secret"`
+	TokenTTL  time.Duration `yaml:"token_ttl"`
+}
+
+type SwaggerConfig struct {
+	Host string `yaml:"host"`
+}
+
+// Default returns the configuration used for local development, which only lacks the JWT secret.
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Address:         ":8080",
+			ReadTimeout:     10 * time.Second,
+			WriteTimeout:    30 * time.Second,
+			IdleTimeout:     60 * time.Second,
+			RequestTimeout:  20 * time.Second,
+			StreamTimeout:   10 * time.Minute,
+			ShutdownTimeout: 15 * time.Second,
+		},
+		Database: DatabaseConfig{
+			DSN:             "meli_sprint_user:Meli_Sprint#123@/melisprint",
+			MaxOpenConns:    25,
+			MaxIdleConns:    25,
+			ConnMaxLifetime: 5 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+			PingAttempts:    5,
+			PingBackoff:     time.Second,
+		},
+		Auth: AuthConfig{
+			TokenTTL: 24 * time.Hour,
+		},
+		Swagger: SwaggerConfig{
+			Host: "localhost:8080",
+		},
+	}
+}
+
+// Load builds the configuration from the defaults, the YAML file at path when it is not empty,
+// and the environment variables, in that order of precedence, and validates the result.
+func Load(path string) (Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return Config{}, fmt.Errorf("reading config file: %w", err)
+		}
+		if err := yaml.Unmarshal(content, &cfg); err != nil {
+			return Config{}, fmt.Errorf("parsing config file %s: %w", path, err)
+		}
+	}
+
+	env := environment{}
+	env.string("SERVER_ADDRESS", &cfg.Server.Address)
+	env.duration("SERVER_READ_TIMEOUT", &cfg.Server.ReadTimeout)
+	env.duration("SERVER_WRITE_TIMEOUT", &cfg.Server.WriteTimeout)
+	env.duration("SERVER_IDLE_TIMEOUT", &cfg.Server.IdleTimeout)
+	env.duration("SERVER_REQUEST_TIMEOUT", &cfg.Server.RequestTimeout)
+	env.duration("SERVER_STREAM_TIMEOUT", &cfg.Server.StreamTimeout)
+	env.duration("SERVER_SHUTDOWN_TIMEOUT", &cfg.Server.ShutdownTimeout)
+	env.string("DB_DSN", &cfg.Database.DSN)
+	env.int("DB_MAX_OPEN_CONNS", &cfg.Database.MaxOpenConns)
+	env.int("DB_MAX_IDLE_CONNS", &cfg.Database.MaxIdleConns)
+	env.duration("DB_CONN_MAX_LIFETIME", &cfg.Database.ConnMaxLifetime)
+	env.duration("DB_CONN_MAX_IDLE_TIME", &cfg.Database.ConnMaxIdleTime)
+	env.int("DB_PING_ATTEMPTS", &cfg.Database.PingAttempts)
+	env.duration("DB_PING_BACKOFF", &cfg.Database.PingBackoff)
+	env.string("JWT_SECRET", &cfg.Auth.JWTSecret)
+	env.duration("JWT_TTL", &cfg.Auth.TokenTTL)
+	env.string("SWAGGER_HOST", &cfg.Swagger.Host)
+	if len(env.problems) > 0 {
+		return Config{}, fmt.Errorf("invalid environment: %s", strings.Join(env.problems, "; "))
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+// Validate reports every setting that would prevent the server from starting.
+func (cfg Config) Validate() error {
+	problems := make([]string, 0)
+
+	if cfg.Server.Address == "" {
+		problems = append(problems, "server address is required")
+	}
+	if cfg.Server.ReadTimeout < 0 || cfg.Server.WriteTimeout < 0 || cfg.Server.IdleTimeout < 0 || cfg.Server.RequestTimeout < 0 || cfg.Server.StreamTimeout < 0 || cfg.Server.ShutdownTimeout < 0 {
+		problems = append(problems, "server timeouts must not be negative")
+	}
+	if cfg.Database.DSN == "" {
+		problems = append(problems, "database dsn is required")
+	}
+	if cfg.Database.MaxOpenConns < 0 || cfg.Database.MaxIdleConns < 0 {
+		problems = append(problems, "database connection limits must not be negative")
+	}
+	if cfg.Database.MaxOpenConns > 0 && cfg.Database.MaxIdleConns > cfg.Database.MaxOpenConns {
+		problems = append(problems, "database max idle connections must not exceed max open connections")
+	}
+	if cfg.Database.ConnMaxLifetime < 0 || cfg.Database.ConnMaxIdleTime < 0 {
+		problems = append(problems, "database connection lifetimes must not be negative")
+	}
+	if cfg.Database.PingAttempts < 1 {
+		problems = append(problems, "database ping attempts must be at least 1")
+	}
+	if cfg.Database.PingBackoff < 0 {
+		problems = append(problems, "database ping backoff must not be negative")
+	}
+	if cfg.Auth.JWTSecret == "" {
+		problems = append(problems, "jwt secret is required")
+	}
+	if cfg.Auth.TokenTTL <= 0 {
+		problems = append(problems, "jwt token ttl must be positive")
+	}
+	if cfg.Swagger.Host == "" {
+		problems = append(problems, "swagger host is required")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid config: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// environment overrides settings with the variables that are set, collecting parse errors.
+type environment struct {
+	problems []string
+}
+
+func (e *environment) string(key string, target *string) {
+	if value, ok := os.LookupEnv(key); ok {
+		*target = value
+	}
+}
+
+func (e *environment) int(key string, target *int) {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		e.problems = append(e.problems, fmt.Sprintf("%s must be an integer", key))
+		return
+	}
+	*target = parsed
+}
+
+func (e *environment) duration(key string, target *time.Duration) {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		e.problems = append(e.problems, fmt.Sprintf("%s must be a duration such as 5s or 1m", key))
+		return
+	}
+	*target = parsed
+}
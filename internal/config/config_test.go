@@ -0,0 +1,169 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	validFile := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(validFile, []byte(`
+server:
+  address: ":9090"
+  read_timeout: 5s
+  request_timeout: 3s
+database:
+  dsn: "user:pass@tcp(db:3306)/melisprint"
+  max_open_conns: 10
+  max_idle_conns: 5
+  conn_max_lifetime: 1m
+auth:
+  jwt_secret: "file secret"
+swagger:
+  host: "api.example.com"
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	malformedFile := filepath.Join(dir, "malformed.yaml")
+	if err := os.WriteFile(malformedFile, []byte("server: ["), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fromFile := Default()
+	fromFile.Server.Address = ":9090"
+	fromFile.Server.ReadTimeout = 5 * time.Second
+	fromFile.Server.RequestTimeout = 3 * time.Second
+	fromFile.Database.DSN = "user:pass@tcp(db:3306)/melisprint"
+	fromFile.Database.MaxOpenConns = 10
+	fromFile.Database.MaxIdleConns = 5
+	fromFile.Database.ConnMaxLifetime = time.Minute
+	fromFile.Auth.JWTSecret = "file secret"
+	fromFile.Swagger.Host = "api.example.com"
+
+	envOverFile := fromFile
+	envOverFile.Server.Address = ":7070"
+	envOverFile.Server.RequestTimeout = 0
+	envOverFile.Server.StreamTimeout = time.Hour
+	envOverFile.Database.MaxOpenConns = 50
+	envOverFile.Database.PingBackoff = 250 * time.Millisecond
+	envOverFile.Auth.JWTSecret = "env secret"
+
+	defaults := Default()
+	defaults.Auth.JWTSecret = "env secret"
+
+	tests := []struct {
+		name    string
+		path    string
+		env     map[string]string
+		want    Config
+		wantErr bool
+	}{
+		{
+			name: "Defaults with the secret from env",
+			env:  map[string]string{"JWT_SECRET": "env secret"},
+			want: defaults,
+		},
+		{
+			name: "Values from the YAML file",
+			path: validFile,
+			want: fromFile,
+		},
+		{
+			name: "Env overrides the YAML file",
+			path: validFile,
+			env: map[string]string{
+				"SERVER_ADDRESS":         ":7070",
+				"SERVER_REQUEST_TIMEOUT": "0s",
+				"SERVER_STREAM_TIMEOUT":  "1h",
+				"DB_MAX_OPEN_CONNS":      "50",
+				"DB_PING_BACKOFF":        "250ms",
+				"JWT_SECRET":             "env secret",
+			},
+			want: envOverFile,
+		},
+		{
+			name:    "Error missing secret",
+			wantErr: true,
+		},
+		{
+			name:    "Error missing file",
+			path:    filepath.Join(dir, "missing.yaml"),
+			env:     map[string]string{"JWT_SECRET": "env secret"},
+			wantErr: true,
+		},
+		{
+			name:    "Error malformed file",
+			path:    malformedFile,
+			env:     map[string]string{"JWT_SECRET": "env secret"},
+			wantErr: true,
+		},
+		{
+			name:    "Error malformed env value",
+			env:     map[string]string{"JWT_SECRET": "env secret", "DB_MAX_OPEN_CONNS": "many"},
+			wantErr: true,
+		},
+		{
+			name:    "Error malformed env duration",
+			env:     map[string]string{"JWT_SECRET": "env secret", "SERVER_READ_TIMEOUT": "10"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("JWT_SECRET", "")
+			os.Unsetenv("JWT_SECRET")
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			got, err := Load(tt.path)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	valid := Default()
+	valid.Auth.JWTSecret = "secret"
+
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr bool
+	}{
+		{name: "Valid config", modify: func(cfg *Config) {}},
+		{name: "Unlimited connections", modify: func(cfg *Config) { cfg.Database.MaxOpenConns = 0 }},
+		{name: "Error empty address", modify: func(cfg *Config) { cfg.Server.Address = "" }, wantErr: true},
+		{name: "Error negative timeout", modify: func(cfg *Config) { cfg.Server.WriteTimeout = -time.Second }, wantErr: true},
+		{name: "Error negative request timeout", modify: func(cfg *Config) { cfg.Server.RequestTimeout = -time.Second }, wantErr: true},
+		{name: "Error negative stream timeout", modify: func(cfg *Config) { cfg.Server.StreamTimeout = -time.Second }, wantErr: true},
+		{name: "Error negative shutdown timeout", modify: func(cfg *Config) { cfg.Server.ShutdownTimeout = -time.Second }, wantErr: true},
+		{name: "Error empty dsn", modify: func(cfg *Config) { cfg.Database.DSN = "" }, wantErr: true},
+		{name: "Error more idle than open connections", modify: func(cfg *Config) { cfg.Database.MaxIdleConns = 30 }, wantErr: true},
+		{name: "Error negative lifetime", modify: func(cfg *Config) { cfg.Database.ConnMaxLifetime = -time.Second }, wantErr: true},
+		{name: "Error no ping attempts", modify: func(cfg *Config) { cfg.Database.PingAttempts = 0 }, wantErr: true},
+		{name: "Error zero token ttl", modify: func(cfg *Config) { cfg.Auth.TokenTTL = 0 }, wantErr: true},
+		{name: "Error empty swagger host", modify: func(cfg *Config) { cfg.Swagger.Host = "" }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.modify(&cfg)
+
+			err := cfg.Validate()
+
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package country
+
+import (
+	"context"
+	"database/sql"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+)
+
+type CountryRepository interface {
+	GetAll(ctx context.Context) ([]domain.Country, error)
+	Get(ctx context.Context, id int) (domain.Country, error)
+	GetByName(ctx context.Context, countryName string) (domain.Country, error)
+	Exists(ctx context.Context, id int) bool
+	Save(ctx context.Context, country domain.Country) (int, error)
+	Update(ctx context.Context, country domain.Country) error
+	Delete(ctx context.Context, id int) error
+	Dependents(ctx context.Context, id int) (map[string]int, error)
+}
+
+const (
+	GetAllCountries   = "SELECT countries.id, countries.country_name FROM countries"
+	GetCountryByID    = "SELECT countries.id, countries.country_name FROM countries WHERE id = ?"
+	GetCountryByName  = "SELECT countries.id, countries.country_name FROM countries WHERE country_name = ?"
+	ExistsCountryByID = "SELECT id FROM countries WHERE id=?"
+	SaveCountry       = "INSERT INTO countries(country_name) VALUES (?)"
+	UpdateCountry     = "UPDATE countries SET country_name=? WHERE id=?"
+	DeleteCountryByID = "DELETE FROM countries WHERE id = ?"
+)
+
+var dependents = []database.Dependent{
+	{Table: "provinces", Column: "country_id"},
+}
+
+type countryRepository struct {
+	db *sql.DB
+}
+
+func NewCountryRepository(db *sql.DB) CountryRepository {
+	return &countryRepository{
+		db: db,
+	}
+}
+
+func (r *countryRepository) GetAll(ctx context.Context) ([]domain.Country, error) {
+	countries := make([]domain.Country, 0)
+
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, GetAllCountries)
+	if err != nil {
+		return countries, err
+	}
+
+	for rows.Next() {
+		country := domain.Country{}
+		err := rows.Scan(&country.ID, &country.CountryName)
+		if err != nil {
+			return countries, err
+		}
+
+		countries = append(countries, country)
+	}
+
+	return countries, rows.Err()
+}
+
+func (r *countryRepository) Get(ctx context.Context, id int) (domain.Country, error) {
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, GetCountryByID, id)
+	return scanCountry(row)
+}
+
+func (r *countryRepository) GetByName(ctx context.Context, countryName string) (domain.Country, error) {
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, GetCountryByName, countryName)
+	return scanCountry(row)
+}
+
+func (r *countryRepository) Exists(ctx context.Context, id int) bool {
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, ExistsCountryByID, id)
+	var foundId int
+	err := row.Scan(&foundId)
+	return err == nil
+}
+
+func (r *countryRepository) Save(ctx context.Context, country domain.Country) (int, error) {
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, SaveCountry)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := stmt.ExecContext(ctx, &country.CountryName)
+	if err != nil {
+		return 0, database.TranslateError(err, "country")
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
+func (r *countryRepository) Update(ctx context.Context, country domain.Country) error {
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, UpdateCountry)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, &country.CountryName, &country.ID)
+	if err != nil {
+		return database.TranslateError(err, "country")
+	}
+
+	_, err = res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *countryRepository) Delete(ctx context.Context, id int) error {
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, DeleteCountryByID)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return database.TranslateError(err, "country")
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affect < 1 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *countryRepository) Dependents(ctx context.Context, id int) (map[string]int, error) {
+	return database.CountDependents(ctx, database.Conn(ctx, r.db), id, dependents)
+}
+
+func scanCountry(row *sql.Row) (domain.Country, error) {
+	country := domain.Country{}
+	err := row.Scan(&country.ID, &country.CountryName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Country{}, ErrNotFound
+		}
+		return domain.Country{}, err
+	}
+
+	return country, nil
+}
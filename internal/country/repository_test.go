@@ -0,0 +1,246 @@
+package country
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"regexp"
+	"testing"
+)
+
+func Test_countryRepository_GetAll(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	validCountriesSerialized, _ := os.ReadFile("../../test/resources/valid_countries.json")
+	var validCountries []domain.Country
+	if err := json.Unmarshal(validCountriesSerialized, &validCountries); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		want    []domain.Country
+		wantErr bool
+	}{
+		{
+			name:    "Successfully get countries",
+			want:    validCountries,
+			wantErr: false,
+		},
+		{
+			name:    "Error getting countries",
+			want:    []domain.Country{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewCountryRepository(db)
+
+			rows := sqlmock.NewRows([]string{"id", "country_name"})
+			for _, country := range validCountries {
+				rows.AddRow(country.ID, country.CountryName)
+			}
+			if tt.wantErr {
+				rows.RowError(0, sql.ErrNoRows)
+			}
+
+			mock.ExpectQuery(regexp.QuoteMeta(GetAllCountries)).
+				WillReturnRows(rows)
+
+			got, err := r.GetAll(ctx)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func Test_countryRepository_Get(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	countrySerialized, _ := os.ReadFile("../../test/resources/valid_country.json")
+	var validCountry domain.Country
+	if err := json.Unmarshal(countrySerialized, &validCountry); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		queryErr error
+		want     domain.Country
+		wantErr  error
+	}{
+		{
+			name:    "Successfully get country",
+			want:    validCountry,
+			wantErr: nil,
+		},
+		{
+			name:     "Error nonexistent country",
+			queryErr: sql.ErrNoRows,
+			want:     domain.Country{},
+			wantErr:  ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewCountryRepository(db)
+
+			expected := mock.ExpectQuery(regexp.QuoteMeta(GetCountryByID)).
+				WithArgs(validCountry.ID)
+			if tt.queryErr != nil {
+				expected.WillReturnError(tt.queryErr)
+			} else {
+				expected.WillReturnRows(sqlmock.NewRows([]string{"id", "country_name"}).AddRow(tt.want.ID, tt.want.CountryName))
+			}
+
+			got, err := r.Get(ctx, validCountry.ID)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_countryRepository_GetByName(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	r := NewCountryRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(GetCountryByName)).
+		WithArgs("Argentina").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "country_name"}).AddRow(1, "Argentina"))
+
+	got, err := r.GetByName(ctx, "Argentina")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Country{ID: 1, CountryName: "Argentina"}, got)
+}
+
+func Test_countryRepository_Exists(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	r := NewCountryRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(ExistsCountryByID)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(ExistsCountryByID)).
+		WithArgs(999).
+		WillReturnError(sql.ErrNoRows)
+
+	assert.True(t, r.Exists(ctx, 1))
+	assert.False(t, r.Exists(ctx, 999))
+}
+
+func Test_countryRepository_Save(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	r := NewCountryRepository(db)
+
+	mock.ExpectPrepare(regexp.QuoteMeta(SaveCountry))
+	mock.ExpectExec(regexp.QuoteMeta(SaveCountry)).
+		WithArgs("Argentina").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	got, err := r.Save(ctx, domain.Country{CountryName: "Argentina"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, got)
+}
+
+func Test_countryRepository_Update(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	r := NewCountryRepository(db)
+
+	mock.ExpectPrepare(regexp.QuoteMeta(UpdateCountry))
+	mock.ExpectExec(regexp.QuoteMeta(UpdateCountry)).
+		WithArgs("Argentina", 1).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := r.Update(ctx, domain.Country{ID: 1, CountryName: "Argentina"})
+
+	assert.NoError(t, err)
+}
+
+func Test_countryRepository_Delete(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantErr      error
+	}{
+		{
+			name:         "Successfully delete country",
+			rowsAffected: 1,
+			wantErr:      nil,
+		},
+		{
+			name:         "No country to delete",
+			rowsAffected: 0,
+			wantErr:      ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewCountryRepository(db)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(DeleteCountryByID))
+			mock.ExpectExec(regexp.QuoteMeta(DeleteCountryByID)).
+				WithArgs(1).
+				WillReturnResult(sqlmock.NewResult(1, tt.rowsAffected))
+
+			err := r.Delete(ctx, 1)
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_countryRepository_Delete_Referenced(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+	r := NewCountryRepository(db)
+
+	mock.ExpectPrepare(regexp.QuoteMeta(DeleteCountryByID))
+	mock.ExpectExec(regexp.QuoteMeta(DeleteCountryByID)).
+		WithArgs(1).
+		WillReturnError(&mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row: a foreign key constraint fails"})
+
+	err := r.Delete(ctx, 1)
+
+	assert.ErrorIs(t, err, errors.ErrConflict)
+}
+
+func Test_countryRepository_Dependents(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+	r := NewCountryRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM provinces WHERE country_id=?")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	dependents, err := r.Dependents(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"provinces": 2}, dependents)
+}
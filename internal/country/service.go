@@ -0,0 +1,104 @@
+package country
+
+import (
+	"context"
+	"errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos"
+	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+)
+
+// Errors
+var (
+	ErrNotFound = errors2.NotFound("country")
+	ErrConflict = errors2.Conflict("country", "country_name")
+)
+
+type CountryService interface {
+	Get(ctx context.Context, id int) (domain.Country, error)
+	GetAll(ctx context.Context) ([]domain.Country, error)
+	Create(ctx context.Context, country domain.Country) (domain.Country, error)
+	Update(ctx context.Context, id int, updateCountryRequest dtos.UpdateCountryRequestDTO) (domain.Country, error)
+	Delete(ctx context.Context, id int) error
+}
+
+type countryService struct {
+	countryRepository CountryRepository
+}
+
+func NewCountryService(r CountryRepository) CountryService {
+	return &countryService{
+		countryRepository: r,
+	}
+}
+
+func (service *countryService) Get(ctx context.Context, id int) (domain.Country, error) {
+	country, err := service.countryRepository.Get(ctx, id)
+	if err != nil {
+		return domain.Country{}, err
+	}
+
+	return country, nil
+}
+
+func (service *countryService) GetAll(ctx context.Context) ([]domain.Country, error) {
+	countries, err := service.countryRepository.GetAll(ctx)
+	if err != nil {
+		return []domain.Country{}, err
+	}
+
+	return countries, nil
+}
+
+func (service *countryService) Create(ctx context.Context, country domain.Country) (domain.Country, error) {
+	if _, err := service.countryRepository.GetByName(ctx, country.CountryName); err == nil {
+		return domain.Country{}, ErrConflict
+	} else if !errors.Is(err, errors2.ErrNotFound) {
+		return domain.Country{}, err
+	}
+
+	id, err := service.countryRepository.Save(ctx, country)
+	if err != nil {
+		return domain.Country{}, err
+	}
+
+	country.ID = id
+
+	return country, nil
+}
+
+func (service *countryService) Update(ctx context.Context, id int, updateCountryRequest dtos.UpdateCountryRequestDTO) (domain.Country, error) {
+	existingCountry, err := service.countryRepository.Get(ctx, id)
+	if err != nil {
+		return domain.Country{}, err
+	}
+
+	if updateCountryRequest.CountryName != nil {
+		existingCountry.CountryName = *updateCountryRequest.CountryName
+	}
+
+	if foundCountry, err := service.countryRepository.GetByName(ctx, existingCountry.CountryName); err == nil && foundCountry.ID != id {
+		return domain.Country{}, ErrConflict
+	} else if err != nil && !errors.Is(err, errors2.ErrNotFound) {
+		return domain.Country{}, err
+	}
+
+	if err = service.countryRepository.Update(ctx, existingCountry); err != nil {
+		return domain.Country{}, err
+	}
+
+	return existingCountry, nil
+}
+
+func (service *countryService) Delete(ctx context.Context, id int) error {
+	dependents, err := service.countryRepository.Dependents(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := database.CheckDependents(dependents); err != nil {
+		return err
+	}
+
+	return service.countryRepository.Delete(ctx, id)
+}
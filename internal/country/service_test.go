@@ -0,0 +1,253 @@
+package country
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/country/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"os"
+	"testing"
+)
+
+func Test_countryService_GetAll(t *testing.T) {
+	ctx := context.TODO()
+
+	var expectedCountries []domain.Country
+	expectedCountriesSerialized, _ := os.ReadFile("../../test/resources/valid_countries.json")
+	if err := json.Unmarshal(expectedCountriesSerialized, &expectedCountries); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                 string
+		expectedGetAllResult []domain.Country
+		expectedGetAllError  error
+		want                 []domain.Country
+		wantErr              error
+	}{
+		{
+			name:                 "Successfully get all countries",
+			expectedGetAllResult: expectedCountries,
+			want:                 expectedCountries,
+		},
+		{
+			name:                 "Error getting all",
+			expectedGetAllResult: []domain.Country{},
+			expectedGetAllError:  assert.AnError,
+			want:                 []domain.Country{},
+			wantErr:              assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			countryRepositoryMock := mocks.NewMockCountryRepository(t)
+			countryRepositoryMock.On("GetAll", ctx).Return(tt.expectedGetAllResult, tt.expectedGetAllError)
+
+			service := NewCountryService(countryRepositoryMock)
+			got, err := service.GetAll(ctx)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_countryService_Get(t *testing.T) {
+	ctx := context.TODO()
+
+	var expectedCountry domain.Country
+	expectedCountrySerialized, _ := os.ReadFile("../../test/resources/valid_country.json")
+	if err := json.Unmarshal(expectedCountrySerialized, &expectedCountry); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name              string
+		expectedGetResult domain.Country
+		expectedGetError  error
+		want              domain.Country
+		wantErr           error
+	}{
+		{
+			name:              "Successfully get country",
+			expectedGetResult: expectedCountry,
+			want:              expectedCountry,
+		},
+		{
+			name:              "Country not found",
+			expectedGetResult: domain.Country{},
+			expectedGetError:  ErrNotFound,
+			want:              domain.Country{},
+			wantErr:           ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			countryRepositoryMock := mocks.NewMockCountryRepository(t)
+			countryRepositoryMock.On("Get", ctx, expectedCountry.ID).Return(tt.expectedGetResult, tt.expectedGetError)
+
+			service := NewCountryService(countryRepositoryMock)
+			got, err := service.Get(ctx, expectedCountry.ID)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_countryService_Create(t *testing.T) {
+	ctx := context.TODO()
+
+	var expectedCountry domain.Country
+	expectedCountrySerialized, _ := os.ReadFile("../../test/resources/valid_country.json")
+	if err := json.Unmarshal(expectedCountrySerialized, &expectedCountry); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                 string
+		expectedGetByNameErr error
+		expectedSaveResult   int
+		expectedSaveError    error
+		expectedSaveCalls    int
+		want                 domain.Country
+		wantErr              error
+	}{
+		{
+			name:                 "Successfully create country",
+			expectedGetByNameErr: ErrNotFound,
+			expectedSaveResult:   expectedCountry.ID,
+			expectedSaveCalls:    1,
+			want:                 expectedCountry,
+		},
+		{
+			name:                 "Error duplicated country name",
+			expectedGetByNameErr: nil,
+			expectedSaveCalls:    0,
+			want:                 domain.Country{},
+			wantErr:              ErrConflict,
+		},
+		{
+			name:                 "Error saving country",
+			expectedGetByNameErr: ErrNotFound,
+			expectedSaveError:    assert.AnError,
+			expectedSaveCalls:    1,
+			want:                 domain.Country{},
+			wantErr:              assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			countryRepositoryMock := mocks.NewMockCountryRepository(t)
+			countryRepositoryMock.On("GetByName", ctx, expectedCountry.CountryName).Return(expectedCountry, tt.expectedGetByNameErr)
+			countryRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.Country")).Return(tt.expectedSaveResult, tt.expectedSaveError)
+
+			service := NewCountryService(countryRepositoryMock)
+			got, err := service.Create(ctx, domain.Country{CountryName: expectedCountry.CountryName})
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+
+			countryRepositoryMock.AssertNumberOfCalls(t, "Save", tt.expectedSaveCalls)
+		})
+	}
+}
+
+func Test_countryService_Update(t *testing.T) {
+	ctx := context.TODO()
+
+	var originalCountry domain.Country
+	originalCountrySerialized, _ := os.ReadFile("../../test/resources/valid_country.json")
+	if err := json.Unmarshal(originalCountrySerialized, &originalCountry); err != nil {
+		t.Fatal(err)
+	}
+
+	newCountryName := "Uruguay"
+	updatedCountry := domain.Country{ID: originalCountry.ID, CountryName: newCountryName}
+
+	tests := []struct {
+		name                string
+		expectedGetResult   domain.Country
+		expectedGetError    error
+		expectedGetByName   domain.Country
+		expectedUpdateError error
+		expectedUpdateCalls int
+		want                domain.Country
+		wantErr             error
+	}{
+		{
+			name:                "Successfully update country",
+			expectedGetResult:   originalCountry,
+			expectedUpdateCalls: 1,
+			want:                updatedCountry,
+		},
+		{
+			name:              "Error country not found",
+			expectedGetResult: domain.Country{},
+			expectedGetError:  ErrNotFound,
+			want:              domain.Country{},
+			wantErr:           ErrNotFound,
+		},
+		{
+			name:                "Error duplicated country name",
+			expectedGetResult:   originalCountry,
+			expectedGetByName:   domain.Country{ID: 2, CountryName: newCountryName},
+			expectedUpdateCalls: 0,
+			want:                domain.Country{},
+			wantErr:             ErrConflict,
+		},
+		{
+			name:                "Error updating country",
+			expectedGetResult:   originalCountry,
+			expectedUpdateError: assert.AnError,
+			expectedUpdateCalls: 1,
+			want:                domain.Country{},
+			wantErr:             assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			countryRepositoryMock := mocks.NewMockCountryRepository(t)
+			countryRepositoryMock.On("Get", ctx, originalCountry.ID).Return(tt.expectedGetResult, tt.expectedGetError)
+			getByNameError := error(ErrNotFound)
+			if tt.expectedGetByName.ID != 0 {
+				getByNameError = nil
+			}
+			countryRepositoryMock.On("GetByName", ctx, newCountryName).Return(tt.expectedGetByName, getByNameError)
+			countryRepositoryMock.On("Update", ctx, updatedCountry).Return(tt.expectedUpdateError)
+
+			service := NewCountryService(countryRepositoryMock)
+			got, err := service.Update(ctx, originalCountry.ID, dtos.UpdateCountryRequestDTO{CountryName: &newCountryName})
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+
+			countryRepositoryMock.AssertNumberOfCalls(t, "Update", tt.expectedUpdateCalls)
+		})
+	}
+}
+
+func Test_countryService_Delete(t *testing.T) {
+	ctx := context.TODO()
+
+	countryRepositoryMock := mocks.NewMockCountryRepository(t)
+	countryRepositoryMock.On("Dependents", ctx, 1).Return(map[string]int{"provinces": 0}, nil)
+	countryRepositoryMock.On("Dependents", ctx, 999).Return(map[string]int{"provinces": 0}, nil)
+	countryRepositoryMock.On("Dependents", ctx, 2).Return(map[string]int{"provinces": 3}, nil)
+	countryRepositoryMock.On("Delete", ctx, 1).Return(nil)
+	countryRepositoryMock.On("Delete", ctx, 999).Return(ErrNotFound)
+
+	service := NewCountryService(countryRepositoryMock)
+
+	assert.NoError(t, service.Delete(ctx, 1))
+	assert.Equal(t, ErrNotFound, service.Delete(ctx, 999))
+
+	err := service.Delete(ctx, 2)
+	assert.ErrorIs(t, err, database.ErrHasDependents)
+	assert.Equal(t, &database.DependentsError{Dependents: map[string]int{"provinces": 3}}, err)
+	countryRepositoryMock.AssertNotCalled(t, "Delete", ctx, 2)
+}
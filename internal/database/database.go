@@ -0,0 +1,60 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/config"
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// Open opens the MySQL pool described by cfg, applies its pool limits
+// and waits until the database answers a ping.
+func Open(ctx context.Context, cfg config.DatabaseConfig) (*sql.DB, error) {
+	db, err := sql.Open("mysql", cfg.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	Configure(db, cfg)
+
+	if err := Ping(ctx, db, cfg.PingAttempts, cfg.PingBackoff); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// Configure applies the pool limits of cfg to db.
+func Configure(db *sql.DB, cfg config.DatabaseConfig) {
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+}
+
+// Ping pings db up to attempts times, doubling the wait between attempts starting at backoff.
+func Ping(ctx context.Context, db *sql.DB, attempts int, backoff time.Duration) error {
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = db.PingContext(ctx); err == nil {
+			return nil
+		}
+
+		if attempt == attempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	return fmt.Errorf("database unreachable after %d attempts: %w", attempts, err)
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPing(t *testing.T) {
+	tests := []struct {
+		name         string
+		failedPings  int
+		attempts     int
+		wantErr      bool
+		expectedWait time.Duration
+	}{
+		{
+			name:     "Database answers the first ping",
+			attempts: 3,
+		},
+		{
+			name:         "Database answers after retrying with backoff",
+			failedPings:  2,
+			attempts:     3,
+			expectedWait: 3 * time.Millisecond,
+		},
+		{
+			name:        "Error database unreachable",
+			failedPings: 3,
+			attempts:    3,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New(sqlmock.MonitorPingsOption(true))
+
+			for i := 0; i < tt.failedPings; i++ {
+				mock.ExpectPing().WillReturnError(assert.AnError)
+			}
+			if !tt.wantErr {
+				mock.ExpectPing()
+			}
+
+			start := time.Now()
+			err := Ping(context.TODO(), db, tt.attempts, time.Millisecond)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.GreaterOrEqual(t, time.Since(start), tt.expectedWait)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPing_StopsWhenContextIsDone(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	mock.ExpectPing().WillReturnError(assert.AnError)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := Ping(ctx, db, 3, time.Hour)
+
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestConfigure(t *testing.T) {
+	db, _, _ := sqlmock.New()
+
+	Configure(db, config.DatabaseConfig{MaxOpenConns: 7, MaxIdleConns: 3})
+
+	assert.Equal(t, 7, db.Stats().MaxOpenConnections)
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrHasDependents is matched by DependentsError.
+var ErrHasDependents = errors.New("it is referenced by other records")
+
+// Dependent is a column of Table referencing the id of the row being deleted.
+// When SoftDeleted is set the soft-deleted rows of Table, those with deleted_at set, are not counted.
+type Dependent struct {
+	Table       string
+	Column      string
+	SoftDeleted bool
+}
+
+// DependentsError is returned when deleting a row other tables still reference.
+type DependentsError struct {
+	// Dependents counts the referencing rows by table, only tables with at least one are kept.
+	Dependents map[string]int
+}
+
+func (e *DependentsError) Error() string {
+	tables := make([]string, 0, len(e.Dependents))
+	for table := range e.Dependents {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	blocking := make([]string, len(tables))
+	for i, table := range tables {
+		blocking[i] = fmt.Sprintf("%s (%d)", table, e.Dependents[table])
+	}
+
+	return fmt.Sprintf("%s: %s", ErrHasDependents.Error(), strings.Join(blocking, ", "))
+}
+
+func (e *DependentsError) Is(target error) bool {
+	return target == ErrHasDependents
+}
+
+// CountDependents counts, for each of dependents, the rows referencing id, keyed by table.
+// Tables referencing the row through several columns add up their counts.
+func CountDependents(ctx context.Context, db Executor, id int, dependents []Dependent) (map[string]int, error) {
+	counts := make(map[string]int, len(dependents))
+	for _, dependent := range dependents {
+		query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s=?", dependent.Table, dependent.Column)
+		if dependent.SoftDeleted {
+			query += " AND deleted_at IS NULL"
+		}
+
+		var count int
+		if err := db.QueryRowContext(ctx, query, id).Scan(&count); err != nil {
+			return nil, err
+		}
+		counts[dependent.Table] += count
+	}
+
+	return counts, nil
+}
+
+// CheckDependents returns a DependentsError listing the tables of counts with referencing rows, if any.
+func CheckDependents(counts map[string]int) error {
+	blocking := map[string]int{}
+	for table, count := range counts {
+		if count > 0 {
+			blocking[table] = count
+		}
+	}
+
+	if len(blocking) == 0 {
+		return nil
+	}
+
+	return &DependentsError{Dependents: blocking}
+}
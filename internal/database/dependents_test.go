@@ -0,0 +1,59 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountDependents(t *testing.T) {
+	dependents := []Dependent{
+		{Table: "purchase_orders", Column: "warehouse_id"},
+		{Table: "order_details", Column: "origin_warehouse_id"},
+		{Table: "order_details", Column: "target_warehouse_id"},
+		{Table: "employees", Column: "warehouse_id", SoftDeleted: true},
+	}
+
+	t.Run("Counts by table", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM purchase_orders WHERE warehouse_id=?")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM order_details WHERE origin_warehouse_id=?")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM order_details WHERE target_warehouse_id=?")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM employees WHERE warehouse_id=? AND deleted_at IS NULL")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		counts, err := CountDependents(context.TODO(), db, 1, dependents)
+
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]int{"purchase_orders": 0, "order_details": 5, "employees": 1}, counts)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Query error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM purchase_orders WHERE warehouse_id=?")).WithArgs(1).WillReturnError(sql.ErrConnDone)
+
+		counts, err := CountDependents(context.TODO(), db, 1, dependents)
+
+		assert.Equal(t, sql.ErrConnDone, err)
+		assert.Nil(t, counts)
+	})
+}
+
+func TestCheckDependents(t *testing.T) {
+	t.Run("No dependents", func(t *testing.T) {
+		assert.Nil(t, CheckDependents(map[string]int{"sections": 0}))
+	})
+
+	t.Run("Lists the blocking tables", func(t *testing.T) {
+		err := CheckDependents(map[string]int{"sections": 2, "employees": 1, "inbound_orders": 0})
+
+		assert.True(t, errors.Is(err, ErrHasDependents))
+		assert.Equal(t, &DependentsError{Dependents: map[string]int{"sections": 2, "employees": 1}}, err)
+		assert.EqualError(t, err, "it is referenced by other records: employees (1), sections (2)")
+	})
+}
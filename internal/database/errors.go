@@ -0,0 +1,80 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// MySQL error numbers translated by TranslateError.
+const (
+	mysqlDuplicateEntry    = 1062
+	mysqlRowIsReferenced   = 1451
+	mysqlNoReferencedRow   = 1452
+	mysqlUniqueIndexSuffix = "_UNIQUE"
+)
+
+// TranslateError turns an error of a query on entity into a domain error: sql.ErrNoRows into a not found one,
+// duplicate keys into a conflict on the column of the unique index and foreign key violations into an error
+// on the referencing column. The original error is kept as the cause, others are returned as they are.
+func TranslateError(err error, entity string) error {
+	if err == nil {
+		return nil
+	}
+
+	var translated *errors2.Error
+	var mysqlErr *mysql.MySQLError
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		translated = errors2.NotFound(entity)
+	case !errors.As(err, &mysqlErr):
+		return err
+	case mysqlErr.Number == mysqlDuplicateEntry:
+		translated = errors2.Conflict(entity, duplicateKeyColumn(mysqlErr.Message))
+	case mysqlErr.Number == mysqlNoReferencedRow:
+		translated = errors2.ForeignKey(entity, foreignKeyColumn(mysqlErr.Message))
+	case mysqlErr.Number == mysqlRowIsReferenced:
+		translated = errors2.Conflict(entity, "")
+		translated.Message = fmt.Sprintf("%s can't be changed, %s", entity, ErrHasDependents)
+	default:
+		return err
+	}
+
+	translated.Err = err
+	return translated
+}
+
+// duplicateKeyColumn reads the column of the unique index out of messages such as
+// "Duplicate entry 'X' for key 'sellers.cid_UNIQUE'", the table prefix is only there from MySQL 8.
+func duplicateKeyColumn(message string) string {
+	key := between(message, "for key '", "'")
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		key = key[i+1:]
+	}
+	return strings.TrimSuffix(key, mysqlUniqueIndexSuffix)
+}
+
+// foreignKeyColumn reads the referencing column out of messages such as
+// "Cannot add or update a child row: a foreign key constraint fails (... FOREIGN KEY (`buyer_id`) REFERENCES ...)".
+func foreignKeyColumn(message string) string {
+	return between(message, "FOREIGN KEY (`", "`)")
+}
+
+func between(message, prefix, suffix string) string {
+	start := strings.Index(message, prefix)
+	if start < 0 {
+		return ""
+	}
+	rest := message[start+len(prefix):]
+
+	end := strings.Index(rest, suffix)
+	if end < 0 {
+		return ""
+	}
+	return rest[:end]
+}
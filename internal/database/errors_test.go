@@ -0,0 +1,82 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslateError(t *testing.T) {
+	duplicate := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1' for key 'sellers.cid_UNIQUE'"}
+	noParent := &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails (`melisprint`.`purchase_orders`, CONSTRAINT `fk_purchase_orders_buyers1` FOREIGN KEY (`buyer_id`) REFERENCES `buyers` (`id`))"}
+	referenced := &mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row: a foreign key constraint fails"}
+	other := &mysql.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded"}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{
+			name:     "No error",
+			err:      nil,
+			expected: nil,
+		},
+		{
+			name:     "No rows",
+			err:      sql.ErrNoRows,
+			expected: &errors2.Error{Kind: errors2.KindNotFound, Entity: "seller", Err: sql.ErrNoRows},
+		},
+		{
+			name:     "Duplicate entry",
+			err:      duplicate,
+			expected: &errors2.Error{Kind: errors2.KindConflict, Entity: "seller", Field: "cid", Err: duplicate},
+		},
+		{
+			name:     "Duplicate entry without table prefix",
+			err:      &mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1' for key 'cid_UNIQUE'"},
+			expected: &errors2.Error{Kind: errors2.KindConflict, Entity: "seller", Field: "cid", Err: &mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1' for key 'cid_UNIQUE'"}},
+		},
+		{
+			name:     "Missing referenced row",
+			err:      fmt.Errorf("saving: %w", noParent),
+			expected: &errors2.Error{Kind: errors2.KindForeignKey, Entity: "seller", Field: "buyer_id", Err: fmt.Errorf("saving: %w", noParent)},
+		},
+		{
+			name:     "Referenced row",
+			err:      referenced,
+			expected: &errors2.Error{Kind: errors2.KindConflict, Entity: "seller", Message: "seller can't be changed, it is referenced by other records", Err: referenced},
+		},
+		{
+			name:     "Other driver error",
+			err:      other,
+			expected: other,
+		},
+		{
+			name:     "Other error",
+			err:      sql.ErrConnDone,
+			expected: sql.ErrConnDone,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, TranslateError(test.err, "seller"))
+		})
+	}
+
+	t.Run("Matches the domain sentinels", func(t *testing.T) {
+		err := TranslateError(duplicate, "seller")
+
+		assert.ErrorIs(t, err, errors2.ErrConflict)
+		assert.ErrorIs(t, err, errors2.Conflict("seller", "cid"))
+		assert.NotErrorIs(t, err, errors2.Conflict("product", ""))
+		assert.ErrorIs(t, err, duplicate)
+		assert.EqualError(t, err, "seller with this cid already exists")
+	})
+}
@@ -0,0 +1,24 @@
+package mocks
+
+import (
+	"context"
+)
+
+// UnitOfWorkMock runs the work it receives directly, without a database transaction.
+// When BeginErr is set, Do fails with it before running the work, as if the transaction could not start.
+type UnitOfWorkMock struct {
+	BeginErr error
+	Calls    int
+}
+
+func NewUnitOfWorkMock() *UnitOfWorkMock {
+	return &UnitOfWorkMock{}
+}
+
+func (m *UnitOfWorkMock) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.Calls++
+	if m.BeginErr != nil {
+		return m.BeginErr
+	}
+	return fn(ctx)
+}
@@ -0,0 +1,110 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitOfWork_Do(t *testing.T) {
+	const insert = "INSERT INTO sections (section_number) VALUES (?)"
+
+	tests := []struct {
+		name    string
+		expect  func(mock sqlmock.Sqlmock)
+		work    func(ctx context.Context, db *sql.DB) error
+		wantErr error
+	}{
+		{
+			name: "Commits when the work succeeds",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(insert).WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			work: func(ctx context.Context, db *sql.DB) error {
+				_, err := Conn(ctx, db).ExecContext(ctx, insert, 1)
+				return err
+			},
+		},
+		{
+			name: "Rolls back when the work fails",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(insert).WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectRollback()
+			},
+			work: func(ctx context.Context, db *sql.DB) error {
+				if _, err := Conn(ctx, db).ExecContext(ctx, insert, 1); err != nil {
+					return err
+				}
+				return assert.AnError
+			},
+			wantErr: assert.AnError,
+		},
+		{
+			name: "Nested calls join the outer transaction",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(insert).WithArgs(1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(insert).WithArgs(2).WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectCommit()
+			},
+			work: func(ctx context.Context, db *sql.DB) error {
+				if _, err := Conn(ctx, db).ExecContext(ctx, insert, 1); err != nil {
+					return err
+				}
+				return NewUnitOfWork(db).Do(ctx, func(ctx context.Context) error {
+					_, err := Conn(ctx, db).ExecContext(ctx, insert, 2)
+					return err
+				})
+			},
+		},
+		{
+			name: "Error beginning the transaction",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin().WillReturnError(sql.ErrConnDone)
+			},
+			work: func(ctx context.Context, db *sql.DB) error {
+				t.Fatal("work must not run without a transaction")
+				return nil
+			},
+			wantErr: sql.ErrConnDone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			tt.expect(mock)
+
+			err := NewUnitOfWork(db).Do(context.TODO(), func(ctx context.Context) error {
+				return tt.work(ctx, db)
+			})
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUnitOfWork_Do_RollsBackOnPanic(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = NewUnitOfWork(db).Do(context.TODO(), func(ctx context.Context) error {
+			panic("boom")
+		})
+	})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestConn(t *testing.T) {
+	db, _, _ := sqlmock.New()
+
+	assert.Equal(t, Executor(db), Conn(context.TODO(), db))
+}
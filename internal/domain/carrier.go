@@ -1,10 +1,11 @@
 package domain
 
 type Carrier struct {
-	ID          int    `json:"id"`
-	CID         string `json:"cid"`
-	CompanyName string `json:"company_name"`
-	Address     string `json:"address"`
-	Telephone   string `json:"telephone"`
-	LocalityId  int    `json:"locality_id"`
+	ID          int     `json:"id"`
+	CID         string  `json:"cid"`
+	CompanyName string  `json:"company_name"`
+	Address     string  `json:"address"`
+	Telephone   string  `json:"telephone"`
+	LocalityId  int     `json:"locality_id"`
+	DeletedAt   *string `json:"deleted_at,omitempty"`
 }
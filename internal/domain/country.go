@@ -0,0 +1,6 @@
+package domain
+
+type Country struct {
+	ID          int    `json:"id"`
+	CountryName string `json:"country_name" binding:"required"`
+}
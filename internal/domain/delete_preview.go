@@ -0,0 +1,9 @@
+package domain
+
+// DeletePreview is what deleting a record would affect: the number of records
+// of other tables referencing it, by table. It can be deleted only when none do.
+type DeletePreview struct {
+	ID         int            `json:"id"`
+	Dependents map[string]int `json:"dependents"`
+	Deletable  bool           `json:"deletable"`
+}
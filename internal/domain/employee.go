@@ -1,25 +1,26 @@
 package domain
 
 type Employee struct {
-	ID           int    `json:"id"`
-	CardNumberID string `json:"card_number_id"`
-	FirstName    string `json:"first_name"`
-	LastName     string `json:"last_name"`
-	WarehouseID  int    `json:"warehouse_id"`
+	ID           int     `json:"id"`
+	CardNumberID string  `json:"card_number_id"`
+	FirstName    string  `json:"first_name"`
+	LastName     string  `json:"last_name"`
+	WarehouseID  int     `json:"warehouse_id"`
+	DeletedAt    *string `json:"deleted_at,omitempty"`
 }
 
 type RequestCreateEmployee struct {
-	CardNumberID string `json:"card_number_id"`
-	FirstName    string `json:"first_name"`
-	LastName     string `json:"last_name"`
-	WarehouseID  int    `json:"warehouse_id"`
+	CardNumberID string `json:"card_number_id" binding:"required,cid"`
+	FirstName    string `json:"first_name" binding:"required,max=255"`
+	LastName     string `json:"last_name" binding:"required,max=255"`
+	WarehouseID  int    `json:"warehouse_id" binding:"required,gt=0"`
 }
 
 type RequestUpdateEmployee struct {
-	CardNumberID *string `json:"card_number_id"`
-	FirstName    *string `json:"first_name"`
-	LastName     *string `json:"last_name"`
-	WarehouseID  *int    `json:"warehouse_id"`
+	CardNumberID *string `json:"card_number_id" binding:"omitempty,cid"`
+	FirstName    *string `json:"first_name" binding:"omitempty,min=1,max=255"`
+	LastName     *string `json:"last_name" binding:"omitempty,min=1,max=255"`
+	WarehouseID  *int    `json:"warehouse_id" binding:"omitempty,gt=0"`
 }
 
 type EmployeeInboundOrdersCount struct {
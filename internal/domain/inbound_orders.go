@@ -1,26 +1,38 @@
 package domain
 
 type InboundOrders struct {
-	ID             int    `json:"id"`
-	OrderDate      string `json:"order_date"`
-	OrderNumber    string `json:"order_number"`
-	EmployeeID     string `json:"employee_id"`
-	ProductBatchID string `json:"product_batch_id"`
-	WarehouseID    string `json:"warehouse_id"`
+	ID             int            `json:"id"`
+	OrderDate      string         `json:"order_date"`
+	OrderNumber    string         `json:"order_number"`
+	EmployeeID     string         `json:"employee_id"`
+	ProductBatchID string         `json:"product_batch_id"`
+	WarehouseID    string         `json:"warehouse_id"`
+	Quantity       int            `json:"quantity"`
+	Stock          *StockPosition `json:"stock,omitempty"`
+}
+
+// StockPosition is the stock of the product batch and section an inbound order was received into.
+type StockPosition struct {
+	ProductBatchID  int `json:"product_batch_id"`
+	CurrentQuantity int `json:"current_quantity"`
+	SectionID       int `json:"section_id"`
+	CurrentCapacity int `json:"current_capacity"`
+	MaximumCapacity int `json:"maximum_capacity"`
 }
 
 type RequestCreateInboundOrders struct {
-	OrderDate      string `json:"order_date"`
-	OrderNumber    string `json:"order_number"`
-	EmployeeID     string `json:"employee_id"`
-	ProductBatchID string `json:"product_batch_id"`
-	WarehouseID    string `json:"warehouse_id"`
+	OrderDate      string `json:"order_date" binding:"required,date"`
+	OrderNumber    string `json:"order_number" binding:"required,max=255"`
+	EmployeeID     string `json:"employee_id" binding:"required,numeric"`
+	ProductBatchID string `json:"product_batch_id" binding:"required,numeric"`
+	WarehouseID    string `json:"warehouse_id" binding:"required,numeric"`
+	Quantity       int    `json:"quantity" binding:"required,gt=0"`
 }
 
 type RequestUpdateInboundOrders struct {
-	OrderDate      *string `json:"order_date"`
-	OrderNumber    *string `json:"order_number"`
-	EmployeeID     *string `json:"employee_id"`
-	ProductBatchID *string `json:"product_batch_id"`
-	WarehouseID    *string `json:"warehouse_id"`
+	OrderDate      *string `json:"order_date" binding:"omitempty,date"`
+	OrderNumber    *string `json:"order_number" binding:"omitempty,min=1,max=255"`
+	EmployeeID     *string `json:"employee_id" binding:"omitempty,numeric"`
+	ProductBatchID *string `json:"product_batch_id" binding:"omitempty,numeric"`
+	WarehouseID    *string `json:"warehouse_id" binding:"omitempty,numeric"`
 }
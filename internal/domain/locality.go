@@ -2,7 +2,9 @@ package domain
 
 type Locality struct {
 	ID           int    `json:"id" binding:"required"`
-	CountryName  string `json:"country_name" binding:"required"`
-	ProvinceName string `json:"province_name" binding:"required"`
+	CountryName  string `json:"country_name" binding:"required_without_all=ProvinceID CountryID"`
+	ProvinceName string `json:"province_name" binding:"required_without=ProvinceID"`
 	LocalityName string `json:"locality_name" binding:"required"`
+	ProvinceID   int    `json:"province_id"`
+	CountryID    int    `json:"country_id"`
 }
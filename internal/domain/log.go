@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+const (
+	LogLevelInfo    = "Info"
+	LogLevelWarning = "Warning"
+	LogLevelError   = "Error"
+)
+
+type Log struct {
+	ID         int       `json:"id"`
+	Method     string    `json:"method"`
+	Label      string    `json:"label"`
+	Level      string    `json:"level"`
+	Message    string    `json:"message"`
+	Status     int       `json:"status"`
+	InsertDate time.Time `json:"insert_date"`
+}
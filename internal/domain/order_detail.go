@@ -0,0 +1,10 @@
+package domain
+
+type OrderDetail struct {
+	ID                int     `json:"id" extensions:"x-order=0"`
+	CleanLinessStatus string  `json:"clean_liness_status" extensions:"x-order=1"`
+	Quantity          int     `json:"quantity" extensions:"x-order=2"`
+	Temperature       float64 `json:"temperature" extensions:"x-order=3"`
+	ProductRecordID   int     `json:"product_record_id" extensions:"x-order=4"`
+	PurchaseOrderID   int     `json:"purchase_order_id" extensions:"x-order=5"`
+}
@@ -0,0 +1,45 @@
+package domain
+
+// Codes of the order statuses of the purchase order lifecycle.
+const (
+	OrderStatusCreated   = "created"
+	OrderStatusPicked    = "picked"
+	OrderStatusShipped   = "shipped"
+	OrderStatusDelivered = "delivered"
+	OrderStatusCancelled = "cancelled"
+)
+
+// orderStatusTransitions are the statuses a purchase order can move to from each status.
+// Delivered and cancelled orders, like those in a status outside the lifecycle, can't move anymore.
+var orderStatusTransitions = map[string][]string{
+	OrderStatusCreated: {OrderStatusPicked, OrderStatusCancelled},
+	OrderStatusPicked:  {OrderStatusShipped, OrderStatusCancelled},
+	OrderStatusShipped: {OrderStatusDelivered, OrderStatusCancelled},
+}
+
+type OrderStatus struct {
+	ID          int    `json:"id" extensions:"x-order=0"`
+	Code        string `json:"code" extensions:"x-order=1"`
+	Description string `json:"description" extensions:"x-order=2"`
+}
+
+// CanTransitionTo tells whether a purchase order in status s can move to status to.
+func (s OrderStatus) CanTransitionTo(to OrderStatus) bool {
+	for _, code := range orderStatusTransitions[s.Code] {
+		if code == to.Code {
+			return true
+		}
+	}
+	return false
+}
+
+// PurchaseOrderTransition is a change of the status of a purchase order, kept as its history.
+type PurchaseOrderTransition struct {
+	ID              int    `json:"id" extensions:"x-order=0"`
+	PurchaseOrderID int    `json:"purchase_order_id" extensions:"x-order=1"`
+	FromStatusID    int    `json:"from_status_id" extensions:"x-order=2"`
+	FromStatus      string `json:"from_status" extensions:"x-order=3"`
+	ToStatusID      int    `json:"to_status_id" extensions:"x-order=4"`
+	ToStatus        string `json:"to_status" extensions:"x-order=5"`
+	CreatedAt       string `json:"created_at" extensions:"x-order=6" format:"2006-01-02 15:04:05"`
+}
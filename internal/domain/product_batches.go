@@ -13,3 +13,45 @@ type ProductBatches struct {
 	ProductID          int     `json:"product_id"`
 	SectionID          int     `json:"section_id"`
 }
+
+// SectionProductBatch is a product batch stored in a section, with the description of its product.
+type SectionProductBatch struct {
+	ProductBatches
+	ProductDescription string `json:"product_description"`
+}
+
+// ExpiringBatch is a product batch due within the days of an expiring batches report.
+// The section and warehouse are only used to group the report.
+type ExpiringBatch struct {
+	ID              int    `json:"id"`
+	BatchNumber     int    `json:"batch_number"`
+	ProductID       int    `json:"product_id"`
+	CurrentQuantity int    `json:"current_quantity"`
+	DueDate         string `json:"due_date"`
+	SectionID       int    `json:"-"`
+	SectionNumber   int    `json:"-"`
+	WarehouseID     int    `json:"-"`
+}
+
+// ExpiringBatchesBySection are the expiring batches stored in a section, first due first.
+type ExpiringBatchesBySection struct {
+	SectionID     int             `json:"section_id"`
+	SectionNumber int             `json:"section_number"`
+	Batches       []ExpiringBatch `json:"batches"`
+}
+
+// ExpiringBatchesByWarehouse are the sections of a warehouse holding expiring batches.
+type ExpiringBatchesByWarehouse struct {
+	WarehouseID int                        `json:"warehouse_id"`
+	Sections    []ExpiringBatchesBySection `json:"sections"`
+}
+
+// BatchPick is the quantity to pick from a batch to fulfil a picking request.
+type BatchPick struct {
+	ProductBatchID    int    `json:"product_batch_id"`
+	BatchNumber       int    `json:"batch_number"`
+	SectionID         int    `json:"section_id"`
+	DueDate           string `json:"due_date"`
+	Quantity          int    `json:"quantity"`
+	RemainingQuantity int    `json:"remaining_quantity"`
+}
@@ -12,8 +12,12 @@ type Section struct {
 	ProductTypeID      int `json:"product_type_id"`
 }
 
+// ProductBySection is the number of batches and of product units stored in a section.
 type ProductBySection struct {
 	SectionID     int    `json:"section_id"`
 	SectionNumber string `json:"section_number"`
+	WarehouseID   int    `json:"warehouse_id"`
+	WarehouseCode string `json:"warehouse_code"`
+	BatchesCount  int    `json:"batches_count"`
 	ProductsCount int    `json:"products_count"`
 }
@@ -1,10 +1,11 @@
 package domain
 
 type Seller struct {
-	ID          int    `json:"id"`
-	CID         int    `json:"cid"`
-	CompanyName string `json:"company_name"`
-	Address     string `json:"address"`
-	Telephone   string `json:"telephone"`
-	LocalityID  string `json:"locality_id"`
+	ID          int     `json:"id"`
+	CID         int     `json:"cid"`
+	CompanyName string  `json:"company_name"`
+	Address     string  `json:"address"`
+	Telephone   string  `json:"telephone"`
+	LocalityID  string  `json:"locality_id"`
+	DeletedAt   *string `json:"deleted_at,omitempty"`
 }
@@ -0,0 +1,8 @@
+package domain
+
+type User struct {
+	ID       int      `json:"id"`
+	Username string   `json:"username"`
+	Password string   `json:"-"`
+	Roles    []string `json:"roles"`
+}
@@ -1,10 +1,71 @@
 package domain
 
 type Warehouse struct {
-	ID                 int    `json:"id"`
-	Address            string `json:"address"`
-	Telephone          string `json:"telephone"`
-	WarehouseCode      string `json:"warehouse_code"`
-	MinimumCapacity    int    `json:"minimum_capacity"`
-	MinimumTemperature int    `json:"minimum_temperature"`
+	ID                 int     `json:"id"`
+	Address            string  `json:"address"`
+	Telephone          string  `json:"telephone"`
+	WarehouseCode      string  `json:"warehouse_code"`
+	MinimumCapacity    int     `json:"minimum_capacity"`
+	MinimumTemperature int     `json:"minimum_temperature"`
+	LocalityID         int     `json:"locality_id"`
+	DeletedAt          *string `json:"deleted_at,omitempty"`
+}
+
+// WarehouseInventory is the stock of a warehouse: its sections, the batches they hold and how full they are.
+// CapacityUtilization is the current capacity over the maximum capacity, from 0 to 1.
+type WarehouseInventory struct {
+	Warehouse
+	SectionsCount       int                `json:"sections_count"`
+	BatchesCount        int                `json:"batches_count"`
+	ProductsCount       int                `json:"products_count"`
+	CurrentCapacity     int                `json:"current_capacity"`
+	MaximumCapacity     int                `json:"maximum_capacity"`
+	CapacityUtilization float64            `json:"capacity_utilization"`
+	Sections            []SectionInventory `json:"sections"`
+}
+
+// SectionInventory is the stock of a section of a warehouse inventory.
+type SectionInventory struct {
+	SectionID           int              `json:"section_id"`
+	SectionNumber       int              `json:"section_number"`
+	ProductTypeID       int              `json:"product_type_id"`
+	CurrentCapacity     int              `json:"current_capacity"`
+	MaximumCapacity     int              `json:"maximum_capacity"`
+	CapacityUtilization float64          `json:"capacity_utilization"`
+	BatchesCount        int              `json:"batches_count"`
+	ProductsCount       int              `json:"products_count"`
+	Batches             []InventoryBatch `json:"batches"`
+}
+
+// InventoryBatch is a product batch of a section inventory.
+type InventoryBatch struct {
+	ID              int    `json:"id"`
+	BatchNumber     int    `json:"batch_number"`
+	ProductID       int    `json:"product_id"`
+	CurrentQuantity int    `json:"current_quantity"`
+	DueDate         string `json:"due_date"`
+}
+
+// WarehouseSummary is the staff, recent inbound orders and section fill rates of a warehouse.
+// InboundOrdersCount counts the inbound orders of the last Days days; fill rates go from 0 to 1.
+type WarehouseSummary struct {
+	WarehouseID        int               `json:"warehouse_id"`
+	WarehouseCode      string            `json:"warehouse_code"`
+	EmployeesCount     int               `json:"employees_count"`
+	Days               int               `json:"days"`
+	InboundOrdersCount int               `json:"inbound_orders_count"`
+	SectionsCount      int               `json:"sections_count"`
+	CurrentCapacity    int               `json:"current_capacity"`
+	MaximumCapacity    int               `json:"maximum_capacity"`
+	FillRate           float64           `json:"fill_rate"`
+	Sections           []SectionFillRate `json:"sections"`
+}
+
+// SectionFillRate is how full a section of a warehouse summary is.
+type SectionFillRate struct {
+	SectionID       int     `json:"section_id"`
+	SectionNumber   int     `json:"section_number"`
+	CurrentCapacity int     `json:"current_capacity"`
+	MaximumCapacity int     `json:"maximum_capacity"`
+	FillRate        float64 `json:"fill_rate"`
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -21,6 +22,23 @@ func (repository *EmployeeRepositoryMock) GetAll(ctx context.Context) ([]domain.
 	return args.Get(0).([]domain.Employee), args.Error(1)
 }
 
+func (repository *EmployeeRepositoryMock) GetPage(ctx context.Context, options listing.Options) ([]domain.Employee, int, error) {
+	args := repository.Called(ctx, options)
+
+	return args.Get(0).([]domain.Employee), args.Int(1), args.Error(2)
+}
+
+func (repository *EmployeeRepositoryMock) Each(ctx context.Context, options listing.Options, fn func(domain.Employee) error) error {
+	args := repository.Called(ctx, options)
+
+	for _, employee := range args.Get(0).([]domain.Employee) {
+		if err := fn(employee); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
 func (repository *EmployeeRepositoryMock) Get(ctx context.Context, id int) (domain.Employee, error) {
 	args := repository.Called(ctx, id)
 
@@ -51,3 +69,15 @@ func (repository *EmployeeRepositoryMock) Delete(ctx context.Context, id int) er
 	return args.Error(0)
 
 }
+
+func (repository *EmployeeRepositoryMock) Restore(ctx context.Context, id int) error {
+	args := repository.Called(ctx, id)
+
+	return args.Error(0)
+}
+
+func (repository *EmployeeRepositoryMock) CountByWarehouse(ctx context.Context, warehouseID int) (int, error) {
+	args := repository.Called(ctx, warehouseID)
+
+	return args.Int(0), args.Error(1)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -15,29 +16,52 @@ func NewEmployeeServiceMock() *EmployeeServiceMock {
 	return &EmployeeServiceMock{}
 }
 
-func (service *EmployeeServiceMock) Get(ctx *context.Context, id int) (*domain.Employee, error) {
+func (service *EmployeeServiceMock) Get(ctx context.Context, id int) (*domain.Employee, error) {
 	args := service.Called(ctx, id)
 
 	return args.Get(0).(*domain.Employee), args.Error(1)
 }
 
-func (service *EmployeeServiceMock) GetAll(ctx *context.Context) (*[]domain.Employee, error) {
+func (service *EmployeeServiceMock) GetAll(ctx context.Context) (*[]domain.Employee, error) {
 	args := service.Called(ctx)
 
 	return args.Get(0).(*[]domain.Employee), args.Error(1)
 }
 
-func (service *EmployeeServiceMock) Save(ctx *context.Context, employee domain.Employee) (*domain.Employee, error) {
+func (service *EmployeeServiceMock) GetPage(ctx context.Context, options listing.Options) (*[]domain.Employee, int, error) {
+	args := service.Called(ctx, options)
+
+	return args.Get(0).(*[]domain.Employee), args.Int(1), args.Error(2)
+}
+
+func (service *EmployeeServiceMock) Each(ctx context.Context, options listing.Options, fn func(domain.Employee) error) error {
+	args := service.Called(ctx, options)
+
+	for _, employee := range args.Get(0).([]domain.Employee) {
+		if err := fn(employee); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (service *EmployeeServiceMock) Save(ctx context.Context, employee domain.Employee) (*domain.Employee, error) {
 	args := service.Called(ctx, employee)
 	return args.Get(0).(*domain.Employee), args.Error(1)
 }
 
-func (service *EmployeeServiceMock) Update(ctx *context.Context, id int, reqUpdateEmployee *domain.RequestUpdateEmployee) (*domain.Employee, error) {
+func (service *EmployeeServiceMock) Update(ctx context.Context, id int, reqUpdateEmployee *domain.RequestUpdateEmployee) (*domain.Employee, error) {
 	args := service.Called(ctx, id, reqUpdateEmployee)
 	return args.Get(0).(*domain.Employee), args.Error(1)
 }
 
-func (service *EmployeeServiceMock) Delete(ctx *context.Context, id int) error {
+func (service *EmployeeServiceMock) Delete(ctx context.Context, id int) error {
 	args := service.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (service *EmployeeServiceMock) Restore(ctx context.Context, id int) (*domain.Employee, error) {
+	args := service.Called(ctx, id)
+
+	return args.Get(0).(*domain.Employee), args.Error(1)
+}
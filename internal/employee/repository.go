@@ -4,17 +4,32 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 )
 
 // Repository encapsulates the storage of a employee.
 type Repository interface {
 	GetAll(ctx context.Context) ([]domain.Employee, error)
+	GetPage(ctx context.Context, options listing.Options) ([]domain.Employee, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.Employee) error) error
 	Get(ctx context.Context, id int) (domain.Employee, error)
 	Exists(ctx context.Context, cardNumberID string) bool
 	Save(ctx context.Context, e domain.Employee) (int, error)
 	Update(ctx context.Context, e domain.Employee) error
 	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) error
+	CountByWarehouse(ctx context.Context, warehouseID int) (int, error)
+}
+
+// Fields are the employee fields list requests can sort and filter by.
+var Fields = listing.Fields{
+	"id":             "id",
+	"card_number_id": "card_number_id",
+	"first_name":     "first_name",
+	"last_name":      "last_name",
+	"warehouse_id":   "warehouse_id",
 }
 
 type repository struct {
@@ -28,8 +43,8 @@ func NewRepository(db *sql.DB) Repository {
 }
 
 func (r *repository) GetAll(ctx context.Context) ([]domain.Employee, error) {
-	query := "SELECT * FROM employees"
-	rows, err := r.db.Query(query)
+	query := "SELECT id, card_number_id, first_name, last_name, warehouse_id, deleted_at FROM employees WHERE deleted_at IS NULL"
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -38,20 +53,59 @@ func (r *repository) GetAll(ctx context.Context) ([]domain.Employee, error) {
 
 	for rows.Next() {
 		e := domain.Employee{}
-		_ = rows.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID)
+		_ = rows.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID, &e.DeletedAt)
 		employees = append(employees, e)
 	}
 
 	return employees, nil
 }
 
+func (r *repository) GetPage(ctx context.Context, options listing.Options) ([]domain.Employee, int, error) {
+	var total int
+	query, args := options.CountQuery("SELECT COUNT(*) FROM employees")
+	if err := database.Conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	employees := make([]domain.Employee, 0)
+	err := r.Each(ctx, options, func(e domain.Employee) error {
+		employees = append(employees, e)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return employees, total, nil
+}
+
+func (r *repository) Each(ctx context.Context, options listing.Options, fn func(domain.Employee) error) error {
+	query, args := options.Query("SELECT id, card_number_id, first_name, last_name, warehouse_id, deleted_at FROM employees")
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		e := domain.Employee{}
+		if err := rows.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID, &e.DeletedAt); err != nil {
+			return err
+		}
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 func (r *repository) Get(ctx context.Context, id int) (domain.Employee, error) {
-	query := "SELECT * FROM employees WHERE id=?;"
-	row := r.db.QueryRow(query, id)
+	query := "SELECT id, card_number_id, first_name, last_name, warehouse_id, deleted_at FROM employees WHERE id=? AND deleted_at IS NULL;"
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, query, id)
 	e := domain.Employee{}
-	err := row.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID)
+	err := row.Scan(&e.ID, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID, &e.DeletedAt)
 	if err != nil {
-		return domain.Employee{}, err
+		return domain.Employee{}, database.TranslateError(err, "employee")
 	}
 
 	return e, nil
@@ -59,21 +113,21 @@ func (r *repository) Get(ctx context.Context, id int) (domain.Employee, error) {
 
 func (r *repository) Exists(ctx context.Context, cardNumberID string) bool {
 	query := "SELECT card_number_id FROM employees WHERE card_number_id=?;"
-	row := r.db.QueryRow(query, cardNumberID)
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, query, cardNumberID)
 	err := row.Scan(&cardNumberID)
 	return err == nil
 }
 
 func (r *repository) Save(ctx context.Context, e domain.Employee) (int, error) {
 	query := "INSERT INTO employees(card_number_id,first_name,last_name,warehouse_id) VALUES (?,?,?,?)"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, query)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(&e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID)
+	res, err := stmt.ExecContext(ctx, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID)
 	if err != nil {
-		return 0, err
+		return 0, database.TranslateError(err, "employee")
 	}
 
 	id, err := res.LastInsertId()
@@ -86,14 +140,14 @@ func (r *repository) Save(ctx context.Context, e domain.Employee) (int, error) {
 
 func (r *repository) Update(ctx context.Context, e domain.Employee) error {
 	query := "UPDATE employees SET card_number_id=?, first_name=?, last_name=?, warehouse_id=?  WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, query)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(&e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID, &e.ID)
+	res, err := stmt.ExecContext(ctx, &e.CardNumberID, &e.FirstName, &e.LastName, &e.WarehouseID, &e.ID)
 	if err != nil {
-		return err
+		return database.TranslateError(err, "employee")
 	}
 
 	_, err = res.RowsAffected()
@@ -105,17 +159,41 @@ func (r *repository) Update(ctx context.Context, e domain.Employee) error {
 }
 
 func (r *repository) Delete(ctx context.Context, id int) error {
-	query := "DELETE FROM employees WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	query := "UPDATE employees SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, query)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(id)
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return database.TranslateError(err, "employee")
+	}
+
+	affect, err := res.RowsAffected()
 	if err != nil {
 		return err
 	}
 
+	if affect < 1 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) Restore(ctx context.Context, id int) error {
+	query := "UPDATE employees SET deleted_at=NULL WHERE id=? AND deleted_at IS NOT NULL"
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return database.TranslateError(err, "employee")
+	}
+
 	affect, err := res.RowsAffected()
 	if err != nil {
 		return err
@@ -127,3 +205,13 @@ func (r *repository) Delete(ctx context.Context, id int) error {
 
 	return nil
 }
+
+// CountByWarehouse counts the employees working in the warehouse, deleted ones excluded.
+func (r *repository) CountByWarehouse(ctx context.Context, warehouseID int) (int, error) {
+	var count int
+	query := "SELECT COUNT(*) FROM employees WHERE warehouse_id=? AND deleted_at IS NULL"
+	if err := database.Conn(ctx, r.db).QueryRowContext(ctx, query, warehouseID).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
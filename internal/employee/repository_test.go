@@ -3,12 +3,14 @@ package employee_test
 import (
 	"context"
 	"database/sql"
+	"net/url"
 	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/employee"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -32,11 +34,11 @@ func TestRepositoryGet(t *testing.T) {
 
 		r := employee.NewRepository(fields{db}.db)
 
-		rows := sqlmock.NewRows([]string{"id", "card_number_id", "first_name", "last_name", "warehouse_id"}).
+		rows := sqlmock.NewRows([]string{"id", "card_number_id", "first_name", "last_name", "warehouse_id", "deleted_at"}).
 			AddRow(expectedEmployee.ID, expectedEmployee.CardNumberID, expectedEmployee.FirstName, expectedEmployee.LastName,
-				expectedEmployee.WarehouseID)
+				expectedEmployee.WarehouseID, nil)
 
-		mock.ExpectQuery("SELECT *. FROM employees WHERE id=?").
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, card_number_id, first_name, last_name, warehouse_id, deleted_at FROM employees WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(expectedEmployee.ID).
 			WillReturnRows(rows)
 
@@ -58,11 +60,11 @@ func TestRepositoryGet(t *testing.T) {
 
 		r := employee.NewRepository(fields{db}.db)
 
-		rows := sqlmock.NewRows([]string{"id", "card_number_id", "first_name", "last_name", "warehouse_id"}).
+		rows := sqlmock.NewRows([]string{"id", "card_number_id", "first_name", "last_name", "warehouse_id", "deleted_at"}).
 			AddRow(expectedEmployee.ID, expectedEmployee.CardNumberID, expectedEmployee.FirstName, expectedEmployee.LastName,
-				expectedEmployee.WarehouseID)
+				expectedEmployee.WarehouseID, nil)
 
-		mock.ExpectQuery("SELECT * FROM employees WHERE id=?").
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, card_number_id, first_name, last_name, warehouse_id, deleted_at FROM employees WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(expectedEmployee.ID).
 			WillReturnRows(rows)
 
@@ -102,13 +104,13 @@ func TestRepositoryGetAll(t *testing.T) {
 
 		r := employee.NewRepository(fields{db}.db)
 
-		rows := sqlmock.NewRows([]string{"id", "card_number_id", "first_name", "last_name", "warehouse_id"})
+		rows := sqlmock.NewRows([]string{"id", "card_number_id", "first_name", "last_name", "warehouse_id", "deleted_at"})
 
 		for _, expectedEmployee := range expectedEmployees {
 			rows.AddRow(expectedEmployee.ID, expectedEmployee.CardNumberID, expectedEmployee.FirstName, expectedEmployee.LastName,
-				expectedEmployee.WarehouseID)
+				expectedEmployee.WarehouseID, nil)
 		}
-		mock.ExpectQuery("SELECT *. FROM employees").
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, card_number_id, first_name, last_name, warehouse_id, deleted_at FROM employees WHERE deleted_at IS NULL")).
 			WillReturnRows(rows)
 
 		employeeReceived, err := r.GetAll(ctx)
@@ -121,7 +123,7 @@ func TestRepositoryGetAll(t *testing.T) {
 
 		r := employee.NewRepository(fields{db}.db)
 
-		mock.ExpectQuery("SELECT *. FROM employees").
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, card_number_id, first_name, last_name, warehouse_id, deleted_at FROM employees WHERE deleted_at IS NULL")).
 			WithArgs().
 			WillReturnError(sql.ErrNoRows)
 
@@ -132,6 +134,50 @@ func TestRepositoryGetAll(t *testing.T) {
 	})
 }
 
+func TestRepositoryGetPage(t *testing.T) {
+	options, _ := listing.Parse(url.Values{"warehouse_id": {"2"}, "sort": {"last_name"}}, employee.Fields)
+
+	t.Run("get_page_ok", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		expectedEmployees := []domain.Employee{{ID: 2, CardNumberID: "234", FirstName: "Joao", LastName: "Silva", WarehouseID: 2}}
+
+		r := employee.NewRepository(db)
+
+		rows := sqlmock.NewRows([]string{"id", "card_number_id", "first_name", "last_name", "warehouse_id", "deleted_at"})
+		for _, e := range expectedEmployees {
+			rows.AddRow(e.ID, e.CardNumberID, e.FirstName, e.LastName, e.WarehouseID, nil)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM employees WHERE warehouse_id = ?")).
+			WithArgs("2").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, card_number_id, first_name, last_name, warehouse_id, deleted_at FROM employees WHERE warehouse_id = ? ORDER BY last_name ASC, id ASC LIMIT ? OFFSET ?")).
+			WithArgs("2", listing.DefaultLimit, 0).
+			WillReturnRows(rows)
+
+		employeesReceived, total, err := r.GetPage(context.TODO(), options)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedEmployees, employeesReceived)
+		assert.Equal(t, 1, total)
+	})
+
+	t.Run("get_page_error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+
+		r := employee.NewRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM employees")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, card_number_id, first_name, last_name, warehouse_id, deleted_at FROM employees")).
+			WillReturnError(sql.ErrConnDone)
+
+		employeesReceived, _, err := r.GetPage(context.TODO(), options)
+
+		assert.Nil(t, employeesReceived)
+		assert.NotNil(t, err)
+	})
+}
+
 func TestRepositoryExists(t *testing.T) {
 	type fields struct {
 		db *sql.DB
@@ -210,8 +256,8 @@ func TestRepositoryDelete(t *testing.T) {
 		r := employee.NewRepository(fields{db}.db)
 
 		rowsAffected := int64(1)
-		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM employees WHERE id=?"))
-		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM employees WHERE id=?")).
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE employees SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE employees SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(expectedEmployee.ID).
 			WillReturnResult(sqlmock.NewResult(1, rowsAffected))
 
@@ -232,8 +278,8 @@ func TestRepositoryDelete(t *testing.T) {
 
 		r := employee.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM employees WHERE id=?"))
-		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM employees WHERE id=?")).
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE employees SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE employees SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(expectedEmployee.ID).
 			WillReturnError(sql.ErrNoRows)
 
@@ -254,8 +300,8 @@ func TestRepositoryDelete(t *testing.T) {
 
 		r := employee.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM employees WHERE id=?"))
-		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM employees WHERE id=?")).
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE employees SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE employees SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(expectedEmployee.ID).
 			WillReturnResult(sqlmock.NewErrorResult(sql.ErrNoRows))
 
@@ -276,8 +322,8 @@ func TestRepositoryDelete(t *testing.T) {
 
 		r := employee.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM employees WHERE id=?")).WillReturnError(sql.ErrConnDone)
-		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM employees WHERE id=?")).
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE employees SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL")).WillReturnError(sql.ErrConnDone)
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE employees SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(expectedEmployee.ID).
 			WillReturnResult(sqlmock.NewErrorResult(sql.ErrNoRows))
 
@@ -297,8 +343,8 @@ func TestRepositoryDelete(t *testing.T) {
 		}
 
 		r := employee.NewRepository(fields{db}.db)
-		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM employees WHERE id=?"))
-		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM employees WHERE id=?")).
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE employees SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE employees SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(1).
 			WillReturnResult(sqlmock.NewResult(1, 0))
 
@@ -308,6 +354,32 @@ func TestRepositoryDelete(t *testing.T) {
 	})
 }
 
+func TestRepositoryRestore(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("UPDATE employees SET deleted_at=NULL WHERE id=? AND deleted_at IS NOT NULL")
+
+	t.Run("restore_ok", func(t *testing.T) {
+		r := employee.NewRepository(db)
+		mock.ExpectPrepare(query)
+		mock.ExpectExec(query).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := r.Restore(ctx, 1)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("restore_error_not_deleted", func(t *testing.T) {
+		r := employee.NewRepository(db)
+		mock.ExpectPrepare(query)
+		mock.ExpectExec(query).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := r.Restore(ctx, 1)
+
+		assert.Equal(t, employee.ErrNotFound, err)
+	})
+}
+
 func TestRepositoryUpdate(t *testing.T) {
 	type fields struct {
 		db *sql.DB
@@ -504,3 +576,28 @@ func TestRepositorySave(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 }
+
+func TestRepositoryCountByWarehouse(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("SELECT COUNT(*) FROM employees WHERE warehouse_id=? AND deleted_at IS NULL")
+
+	t.Run("count_ok", func(t *testing.T) {
+		r := employee.NewRepository(db)
+		mock.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+		count, err := r.CountByWarehouse(ctx, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 4, count)
+	})
+	t.Run("count_error", func(t *testing.T) {
+		r := employee.NewRepository(db)
+		mock.ExpectQuery(query).WithArgs(1).WillReturnError(sql.ErrConnDone)
+
+		count, err := r.CountByWarehouse(ctx, 1)
+
+		assert.Equal(t, sql.ErrConnDone, err)
+		assert.Equal(t, 0, count)
+	})
+}
@@ -2,24 +2,27 @@ package employee
 
 import (
 	"context"
-	"errors"
 
+	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 )
 
 // Errors
 var (
-	ErrNotFound            = errors.New("employee not found")
-	ErrConflict            = errors.New("409 Conflict: Employee with CardNumberID already exists")
-	ErrUnprocessableEntity = errors.New("all fields are required")
+	ErrNotFound = errors2.NotFound("employee")
+	ErrConflict = &errors2.Error{Kind: errors2.KindConflict, Entity: "employee", Field: "card_number_id", Message: "409 Conflict: Employee with CardNumberID already exists"}
 )
 
 type Service interface {
-	Get(ctx *context.Context, id int) (*domain.Employee, error)
-	GetAll(ctx *context.Context) (*[]domain.Employee, error)
-	Save(ctx *context.Context, employee domain.Employee) (*domain.Employee, error)
-	Update(ctx *context.Context, id int, reqUpdateEmployee *domain.RequestUpdateEmployee) (*domain.Employee, error)
-	Delete(ctx *context.Context, id int) error
+	Get(ctx context.Context, id int) (*domain.Employee, error)
+	GetAll(ctx context.Context) (*[]domain.Employee, error)
+	GetPage(ctx context.Context, options listing.Options) (*[]domain.Employee, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.Employee) error) error
+	Save(ctx context.Context, employee domain.Employee) (*domain.Employee, error)
+	Update(ctx context.Context, id int, reqUpdateEmployee *domain.RequestUpdateEmployee) (*domain.Employee, error)
+	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) (*domain.Employee, error)
 }
 
 type service struct {
@@ -32,33 +35,45 @@ func NewService(r Repository) Service {
 	}
 }
 
-func (s *service) Get(ctx *context.Context, id int) (*domain.Employee, error) {
-	employees, err := s.repository.Get(*ctx, id)
+func (s *service) Get(ctx context.Context, id int) (*domain.Employee, error) {
+	employees, err := s.repository.Get(ctx, id)
 	if err != nil {
-		return nil, ErrNotFound
+		return nil, err
 	}
 	return &employees, nil
 }
 
-func (s *service) GetAll(ctx *context.Context) (*[]domain.Employee, error) {
+func (s *service) GetAll(ctx context.Context) (*[]domain.Employee, error) {
 	employees := []domain.Employee{}
 
-	employees, err := s.repository.GetAll(*ctx)
+	employees, err := s.repository.GetAll(ctx)
 	if err != nil {
 		return nil, err
 	}
 	return &employees, nil
 }
 
-func (s *service) Save(ctx *context.Context, employee domain.Employee) (*domain.Employee, error) {
+func (s *service) GetPage(ctx context.Context, options listing.Options) (*[]domain.Employee, int, error) {
+	employees, total, err := s.repository.GetPage(ctx, options)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &employees, total, nil
+}
+
+func (s *service) Each(ctx context.Context, options listing.Options, fn func(domain.Employee) error) error {
+	return s.repository.Each(ctx, options, fn)
+}
+
+func (s *service) Save(ctx context.Context, employee domain.Employee) (*domain.Employee, error) {
 
-	existingEmployee := s.repository.Exists(*ctx, employee.CardNumberID)
+	existingEmployee := s.repository.Exists(ctx, employee.CardNumberID)
 
 	if existingEmployee {
 		return nil, ErrConflict
 	}
 
-	id, err := s.repository.Save(*ctx, employee)
+	id, err := s.repository.Save(ctx, employee)
 	if err != nil {
 		return nil, err
 	}
@@ -68,14 +83,14 @@ func (s *service) Save(ctx *context.Context, employee domain.Employee) (*domain.
 	return &employee, nil
 }
 
-func (s *service) Update(ctx *context.Context, id int, reqUpdateEmployee *domain.RequestUpdateEmployee) (*domain.Employee, error) {
-	existingEmployee, err := s.repository.Get(*ctx, id)
+func (s *service) Update(ctx context.Context, id int, reqUpdateEmployee *domain.RequestUpdateEmployee) (*domain.Employee, error) {
+	existingEmployee, err := s.repository.Get(ctx, id)
 	if err != nil {
-		return nil, ErrNotFound
+		return nil, err
 	}
 
 	if reqUpdateEmployee.CardNumberID != nil {
-		existingEmployeeSearch := s.repository.Exists(*ctx, *reqUpdateEmployee.CardNumberID)
+		existingEmployeeSearch := s.repository.Exists(ctx, *reqUpdateEmployee.CardNumberID)
 		if existingEmployeeSearch && *reqUpdateEmployee.CardNumberID != existingEmployee.CardNumberID {
 			return nil, ErrConflict
 		}
@@ -92,7 +107,7 @@ func (s *service) Update(ctx *context.Context, id int, reqUpdateEmployee *domain
 		existingEmployee.WarehouseID = *reqUpdateEmployee.WarehouseID
 	}
 
-	err = s.repository.Update(*ctx, existingEmployee)
+	err = s.repository.Update(ctx, existingEmployee)
 	if err != nil {
 		return nil, err
 	}
@@ -100,15 +115,23 @@ func (s *service) Update(ctx *context.Context, id int, reqUpdateEmployee *domain
 	return &existingEmployee, nil
 }
 
-func (s *service) Delete(ctx *context.Context, id int) error {
-	_, err := s.repository.Get(*ctx, id)
+func (s *service) Delete(ctx context.Context, id int) error {
+	_, err := s.repository.Get(ctx, id)
 	if err != nil {
-		return ErrNotFound
+		return err
 	}
 
-	err = s.repository.Delete(*ctx, id)
+	err = s.repository.Delete(ctx, id)
 	if err != nil {
 		return err
 	}
 	return nil
 }
+
+func (s *service) Restore(ctx context.Context, id int) (*domain.Employee, error) {
+	if err := s.repository.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, id)
+}
@@ -2,13 +2,13 @@ package employee_test
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"testing"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/employee"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/employee/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -30,7 +30,7 @@ func TestGet(t *testing.T) {
 		employeeRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(*expectedEmployee, nil)
 		service := employee.NewService(employeeRepositoryMock)
 
-		employeeReceived, err := service.Get(&ctx, 1)
+		employeeReceived, err := service.Get(ctx, 1)
 
 		assert.Equal(t, *expectedEmployee, *employeeReceived)
 		assert.Equal(t, nil, err)
@@ -41,10 +41,10 @@ func TestGet(t *testing.T) {
 		ctx := context.TODO()
 
 		employeeRepositoryMock := mocks.NewEmployeeRepositoryMock()
-		employeeRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.Employee{}, sql.ErrNoRows)
+		employeeRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.Employee{}, employee.ErrNotFound)
 		service := employee.NewService(employeeRepositoryMock)
 
-		employeeReceived, err := service.Get(&ctx, 1)
+		employeeReceived, err := service.Get(ctx, 1)
 
 		assert.Nil(t, employeeReceived)
 		assert.Equal(t, employee.ErrNotFound, err)
@@ -76,7 +76,7 @@ func TestGetAll(t *testing.T) {
 		employeeRepositoryMock.On("GetAll", ctx).Return(*expectedEmployee, nil)
 		service := employee.NewService(employeeRepositoryMock)
 
-		employeeReceived, err := service.GetAll(&ctx)
+		employeeReceived, err := service.GetAll(ctx)
 
 		assert.Equal(t, *expectedEmployee, *employeeReceived)
 		assert.Equal(t, nil, err)
@@ -90,7 +90,7 @@ func TestGetAll(t *testing.T) {
 		employeeRepositoryMock.On("GetAll", ctx).Return([]domain.Employee{}, errors.New("error"))
 		service := employee.NewService(employeeRepositoryMock)
 
-		employeeReceived, err := service.GetAll(&ctx)
+		employeeReceived, err := service.GetAll(ctx)
 
 		assert.Nil(t, employeeReceived)
 		assert.Equal(t, errors.New("error"), err)
@@ -98,6 +98,38 @@ func TestGetAll(t *testing.T) {
 
 }
 
+func TestGetPage(t *testing.T) {
+	t.Run("find_page", func(t *testing.T) {
+		expectedEmployees := []domain.Employee{{ID: 1, CardNumberID: "123", FirstName: "Maria", LastName: "Silva", WarehouseID: 1}}
+		options := listing.Options{Limit: 1}
+		ctx := context.TODO()
+
+		employeeRepositoryMock := mocks.NewEmployeeRepositoryMock()
+		employeeRepositoryMock.On("GetPage", ctx, options).Return(expectedEmployees, 2, nil)
+		service := employee.NewService(employeeRepositoryMock)
+
+		employeesReceived, total, err := service.GetPage(ctx, options)
+
+		assert.Equal(t, expectedEmployees, *employeesReceived)
+		assert.Equal(t, 2, total)
+		assert.Equal(t, nil, err)
+	})
+
+	t.Run("find_page_error", func(t *testing.T) {
+		options := listing.Options{Limit: 1}
+		ctx := context.TODO()
+
+		employeeRepositoryMock := mocks.NewEmployeeRepositoryMock()
+		employeeRepositoryMock.On("GetPage", ctx, options).Return([]domain.Employee{}, 0, errors.New("error"))
+		service := employee.NewService(employeeRepositoryMock)
+
+		employeesReceived, _, err := service.GetPage(ctx, options)
+
+		assert.Nil(t, employeesReceived)
+		assert.NotNil(t, err)
+	})
+}
+
 func TestDelete(t *testing.T) {
 	t.Run("delete_ok", func(t *testing.T) {
 		employeeToDelete := &domain.Employee{
@@ -116,7 +148,7 @@ func TestDelete(t *testing.T) {
 
 		service := employee.NewService(employeeRepositoryMock)
 
-		err := service.Delete(&ctx, 1)
+		err := service.Delete(ctx, 1)
 
 		assert.Equal(t, nil, err)
 	})
@@ -130,7 +162,7 @@ func TestDelete(t *testing.T) {
 		employeeRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(employee.ErrNotFound)
 		service := employee.NewService(employeeRepositoryMock)
 
-		err := service.Delete(&ctx, 1)
+		err := service.Delete(ctx, 1)
 
 		assert.Equal(t, employee.ErrNotFound, err)
 	})
@@ -143,13 +175,52 @@ func TestDelete(t *testing.T) {
 		//employeeRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(employee.ErrNotFound)
 		service := employee.NewService(employeeRepositoryMock)
 
-		err := service.Delete(&ctx, 1)
+		err := service.Delete(ctx, 1)
 
 		assert.Equal(t, employee.ErrNotFound, err)
 	})
 
 }
 
+func TestRestore(t *testing.T) {
+	t.Run("restore_ok", func(t *testing.T) {
+		restoredEmployee := domain.Employee{
+			ID:           1,
+			CardNumberID: "123",
+			FirstName:    "Maria",
+			LastName:     "Silva",
+			WarehouseID:  1,
+		}
+
+		ctx := context.TODO()
+
+		employeeRepositoryMock := mocks.NewEmployeeRepositoryMock()
+		employeeRepositoryMock.On("Restore", ctx, 1).Return(nil)
+		employeeRepositoryMock.On("Get", ctx, 1).Return(restoredEmployee, nil)
+
+		service := employee.NewService(employeeRepositoryMock)
+
+		employeeReceived, err := service.Restore(ctx, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &restoredEmployee, employeeReceived)
+	})
+
+	t.Run("restore_not_deleted", func(t *testing.T) {
+
+		ctx := context.TODO()
+
+		employeeRepositoryMock := mocks.NewEmployeeRepositoryMock()
+		employeeRepositoryMock.On("Restore", ctx, 1).Return(employee.ErrNotFound)
+		service := employee.NewService(employeeRepositoryMock)
+
+		employeeReceived, err := service.Restore(ctx, 1)
+
+		assert.Nil(t, employeeReceived)
+		assert.Equal(t, employee.ErrNotFound, err)
+	})
+}
+
 func TestCreate(t *testing.T) {
 	t.Run("create_conflict", func(t *testing.T) {
 		employeeCreated := &domain.Employee{
@@ -167,7 +238,7 @@ func TestCreate(t *testing.T) {
 
 		service := employee.NewService(employeeRepositoryMock)
 
-		employeeSaved, err := service.Save(&ctx, *employeeCreated)
+		employeeSaved, err := service.Save(ctx, *employeeCreated)
 
 		assert.Equal(t, employee.ErrConflict, err)
 		assert.Nil(t, employeeSaved)
@@ -190,7 +261,7 @@ func TestCreate(t *testing.T) {
 
 		service := employee.NewService(employeeRepositoryMock)
 
-		employeeSaved, err := service.Save(&ctx, *employeeCreated)
+		employeeSaved, err := service.Save(ctx, *employeeCreated)
 
 		assert.Equal(t, errors.New("error"), err)
 		assert.Nil(t, employeeSaved)
@@ -214,7 +285,7 @@ func TestCreate(t *testing.T) {
 
 		service := employee.NewService(employeeRepositoryMock)
 
-		employeeSaved, err := service.Save(&ctx, *expectedEmployeeCreate)
+		employeeSaved, err := service.Save(ctx, *expectedEmployeeCreate)
 
 		assert.Equal(t, employeeSaved, expectedEmployeeCreate)
 		assert.Nil(t, err)
@@ -260,7 +331,7 @@ func TestUpdate(t *testing.T) {
 
 		service := employee.NewService(employeeRepositoryMock)
 
-		UpdateEmployees, err := service.Update(&ctx, 1, updateEmployeeRequest)
+		UpdateEmployees, err := service.Update(ctx, 1, updateEmployeeRequest)
 
 		assert.Equal(t, *UpdateEmployees, *expectedEmployee)
 		assert.Nil(t, err)
@@ -287,7 +358,7 @@ func TestUpdate(t *testing.T) {
 
 		service := employee.NewService(employeeRepositoryMock)
 
-		_, err := service.Update(&ctx, 1, updateEmployeeRequest)
+		_, err := service.Update(ctx, 1, updateEmployeeRequest)
 
 		assert.Equal(t, employee.ErrNotFound, err)
 	})
@@ -314,7 +385,7 @@ func TestUpdate(t *testing.T) {
 		employeeRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.Employee")).Return(assert.AnError)
 
 		service := employee.NewService(employeeRepositoryMock)
-		UpdateEmployees, err := service.Update(&ctx, 1, updateEmployeeRequest)
+		UpdateEmployees, err := service.Update(ctx, 1, updateEmployeeRequest)
 
 		assert.Nil(t, UpdateEmployees)
 		assert.Error(t, err)
@@ -340,7 +411,7 @@ func TestUpdate(t *testing.T) {
 		employeeRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(true)
 
 		service := employee.NewService(employeeRepositoryMock)
-		UpdateEmployees, err := service.Update(&ctx, 1, updateEmployeeRequest)
+		UpdateEmployees, err := service.Update(ctx, 1, updateEmployeeRequest)
 
 		assert.Nil(t, UpdateEmployees)
 		assert.Error(t, err)
@@ -366,7 +437,7 @@ func TestUpdate(t *testing.T) {
 		employeeRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(true)
 
 		service := employee.NewService(employeeRepositoryMock)
-		UpdateEmployees, err := service.Update(&ctx, 1, updateEmployeeRequest)
+		UpdateEmployees, err := service.Update(ctx, 1, updateEmployeeRequest)
 
 		assert.Nil(t, UpdateEmployees)
 		assert.Error(t, err)
@@ -393,7 +464,7 @@ func TestUpdate(t *testing.T) {
 		employeeRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.Employee")).Return(assert.AnError)
 
 		service := employee.NewService(employeeRepositoryMock)
-		UpdateEmployees, err := service.Update(&ctx, 1, updateEmployeeRequest)
+		UpdateEmployees, err := service.Update(ctx, 1, updateEmployeeRequest)
 
 		assert.Nil(t, UpdateEmployees)
 		assert.Error(t, err)
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -21,6 +22,23 @@ func (repository *InboundOrdersRepositoryMock) GetAll(ctx context.Context) ([]do
 	return args.Get(0).([]domain.InboundOrders), args.Error(1)
 }
 
+func (repository *InboundOrdersRepositoryMock) GetPage(ctx context.Context, options listing.Options) ([]domain.InboundOrders, int, error) {
+	args := repository.Called(ctx, options)
+
+	return args.Get(0).([]domain.InboundOrders), args.Int(1), args.Error(2)
+}
+
+func (repository *InboundOrdersRepositoryMock) Each(ctx context.Context, options listing.Options, fn func(domain.InboundOrders) error) error {
+	args := repository.Called(ctx, options)
+
+	for _, inboundOrder := range args.Get(0).([]domain.InboundOrders) {
+		if err := fn(inboundOrder); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
 func (repository *InboundOrdersRepositoryMock) Get(ctx context.Context, id int) (domain.InboundOrders, error) {
 	args := repository.Called(ctx, id)
 
@@ -51,3 +69,9 @@ func (repository *InboundOrdersRepositoryMock) Delete(ctx context.Context, id in
 	return args.Error(0)
 
 }
+
+func (repository *InboundOrdersRepositoryMock) CountByWarehouseSince(ctx context.Context, warehouseID int, days int) (int, error) {
+	args := repository.Called(ctx, warehouseID, days)
+
+	return args.Int(0), args.Error(1)
+}
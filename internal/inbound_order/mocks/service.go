@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -15,39 +16,56 @@ func NewInboundOrdersServiceMock() *InboundOrdersServiceMock {
 	return &InboundOrdersServiceMock{}
 }
 
-func (service *InboundOrdersServiceMock) Get(ctx *context.Context, id int) (*domain.InboundOrders, error) {
+func (service *InboundOrdersServiceMock) Get(ctx context.Context, id int) (*domain.InboundOrders, error) {
 	args := service.Called(ctx, id)
 
 	return args.Get(0).(*domain.InboundOrders), args.Error(1)
 }
 
-func (service *InboundOrdersServiceMock) GetAll(ctx *context.Context) (*[]domain.InboundOrders, error) {
+func (service *InboundOrdersServiceMock) GetAll(ctx context.Context) (*[]domain.InboundOrders, error) {
 	args := service.Called(ctx)
 
 	return args.Get(0).(*[]domain.InboundOrders), args.Error(1)
 }
 
-func (service *InboundOrdersServiceMock) Save(ctx *context.Context, inboundOrders domain.InboundOrders) (*domain.InboundOrders, error) {
+func (service *InboundOrdersServiceMock) GetPage(ctx context.Context, options listing.Options) (*[]domain.InboundOrders, int, error) {
+	args := service.Called(ctx, options)
+
+	return args.Get(0).(*[]domain.InboundOrders), args.Int(1), args.Error(2)
+}
+
+func (service *InboundOrdersServiceMock) Each(ctx context.Context, options listing.Options, fn func(domain.InboundOrders) error) error {
+	args := service.Called(ctx, options)
+
+	for _, inboundOrder := range args.Get(0).([]domain.InboundOrders) {
+		if err := fn(inboundOrder); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (service *InboundOrdersServiceMock) Save(ctx context.Context, inboundOrders domain.InboundOrders) (*domain.InboundOrders, error) {
 	args := service.Called(ctx, inboundOrders)
 	return args.Get(0).(*domain.InboundOrders), args.Error(1)
 }
 
-func (service *InboundOrdersServiceMock) Update(ctx *context.Context, id int, reqUpdateInboundOrders *domain.RequestUpdateInboundOrders) (*domain.InboundOrders, error) {
+func (service *InboundOrdersServiceMock) Update(ctx context.Context, id int, reqUpdateInboundOrders *domain.RequestUpdateInboundOrders) (*domain.InboundOrders, error) {
 	args := service.Called(ctx, id, reqUpdateInboundOrders)
 	return args.Get(0).(*domain.InboundOrders), args.Error(1)
 }
 
-func (service *InboundOrdersServiceMock) Delete(ctx *context.Context, id int) error {
+func (service *InboundOrdersServiceMock) Delete(ctx context.Context, id int) error {
 	args := service.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (service *InboundOrdersServiceMock) CountInboundOrders(ctx *context.Context) ([]domain.EmployeeInboundOrdersCount, error) {
+func (service *InboundOrdersServiceMock) CountInboundOrders(ctx context.Context) ([]domain.EmployeeInboundOrdersCount, error) {
 	args := service.Called(ctx)
 	return args.Get(0).([]domain.EmployeeInboundOrdersCount), args.Error(1)
 }
 
-func (service *InboundOrdersServiceMock) CountInboundOrdersByID(ctx *context.Context, employeeID int) (domain.EmployeeInboundOrdersCount, error) {
+func (service *InboundOrdersServiceMock) CountInboundOrdersByID(ctx context.Context, employeeID int) (domain.EmployeeInboundOrdersCount, error) {
 	args := service.Called(ctx, employeeID)
 	return args.Get(0).(domain.EmployeeInboundOrdersCount), args.Error(1)
 }
@@ -3,18 +3,33 @@ package inbound_order
 import (
 	"context"
 	"database/sql"
-	"fmt"
 
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 )
 
 type Repository interface {
 	GetAll(ctx context.Context) ([]domain.InboundOrders, error)
+	GetPage(ctx context.Context, options listing.Options) ([]domain.InboundOrders, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.InboundOrders) error) error
 	Get(ctx context.Context, id int) (domain.InboundOrders, error)
 	Exists(ctx context.Context, id string) bool
 	Save(ctx context.Context, e domain.InboundOrders) (int, error)
 	Update(ctx context.Context, e domain.InboundOrders) error
 	Delete(ctx context.Context, id int) error
+	CountByWarehouseSince(ctx context.Context, warehouseID int, days int) (int, error)
+}
+
+// Fields are the inbound order fields list requests can sort and filter by.
+var Fields = listing.Fields{
+	"id":               "id",
+	"order_date":       "order_date",
+	"order_number":     "order_number",
+	"employee_id":      "employee_id",
+	"product_batch_id": "product_batch_id",
+	"warehouse_id":     "warehouse_id",
+	"quantity":         "quantity",
 }
 
 type repository struct {
@@ -28,8 +43,8 @@ func NewRepository(db *sql.DB) Repository {
 }
 
 func (r *repository) GetAll(ctx context.Context) ([]domain.InboundOrders, error) {
-	query := "SELECT * FROM inbound_orders"
-	rows, err := r.db.Query(query)
+	query := "SELECT id, order_date, order_number, employee_id, product_batch_id, warehouse_id, quantity FROM inbound_orders"
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -38,20 +53,59 @@ func (r *repository) GetAll(ctx context.Context) ([]domain.InboundOrders, error)
 
 	for rows.Next() {
 		i := domain.InboundOrders{}
-		_ = rows.Scan(&i.ID, &i.OrderDate, &i.OrderNumber, &i.EmployeeID, &i.ProductBatchID, &i.WarehouseID)
+		_ = rows.Scan(&i.ID, &i.OrderDate, &i.OrderNumber, &i.EmployeeID, &i.ProductBatchID, &i.WarehouseID, &i.Quantity)
 		inboundOrders = append(inboundOrders, i)
 	}
 
 	return inboundOrders, nil
 }
 
+func (r *repository) GetPage(ctx context.Context, options listing.Options) ([]domain.InboundOrders, int, error) {
+	var total int
+	query, args := options.CountQuery("SELECT COUNT(*) FROM inbound_orders")
+	if err := database.Conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	inboundOrders := make([]domain.InboundOrders, 0)
+	err := r.Each(ctx, options, func(i domain.InboundOrders) error {
+		inboundOrders = append(inboundOrders, i)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return inboundOrders, total, nil
+}
+
+func (r *repository) Each(ctx context.Context, options listing.Options, fn func(domain.InboundOrders) error) error {
+	query, args := options.Query("SELECT id, order_date, order_number, employee_id, product_batch_id, warehouse_id, quantity FROM inbound_orders")
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		i := domain.InboundOrders{}
+		if err := rows.Scan(&i.ID, &i.OrderDate, &i.OrderNumber, &i.EmployeeID, &i.ProductBatchID, &i.WarehouseID, &i.Quantity); err != nil {
+			return err
+		}
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 func (r *repository) Get(ctx context.Context, id int) (domain.InboundOrders, error) {
-	query := "SELECT * FROM inbound_orders WHERE id=?;"
-	row := r.db.QueryRow(query, id)
+	query := "SELECT id, order_date, order_number, employee_id, product_batch_id, warehouse_id, quantity FROM inbound_orders WHERE id=?;"
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, query, id)
 	i := domain.InboundOrders{}
-	err := row.Scan(&i.ID, &i.OrderDate, &i.OrderNumber, &i.EmployeeID, &i.ProductBatchID, &i.WarehouseID)
+	err := row.Scan(&i.ID, &i.OrderDate, &i.OrderNumber, &i.EmployeeID, &i.ProductBatchID, &i.WarehouseID, &i.Quantity)
 	if err != nil {
-		return domain.InboundOrders{}, err
+		return domain.InboundOrders{}, database.TranslateError(err, "inbound order")
 	}
 
 	return i, nil
@@ -59,22 +113,21 @@ func (r *repository) Get(ctx context.Context, id int) (domain.InboundOrders, err
 
 func (r *repository) Exists(ctx context.Context, id string) bool {
 	query := "SELECT id FROM inbound_orders WHERE id=?;"
-	row := r.db.QueryRow(query, id)
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, query, id)
 	err := row.Scan(&id)
 	return err == nil
 }
 
 func (r *repository) Save(ctx context.Context, i domain.InboundOrders) (int, error) {
-	fmt.Println(i)
-	query := "INSERT INTO inbound_orders(order_date, order_number, employee_id, product_batch_id, warehouse_id) VALUES (?,?,?,?,?)"
-	stmt, err := r.db.Prepare(query)
+	query := "INSERT INTO inbound_orders(order_date, order_number, employee_id, product_batch_id, warehouse_id, quantity) VALUES (?,?,?,?,?,?)"
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, query)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(&i.OrderDate, &i.OrderNumber, &i.EmployeeID, &i.ProductBatchID, &i.WarehouseID)
+	res, err := stmt.ExecContext(ctx, &i.OrderDate, &i.OrderNumber, &i.EmployeeID, &i.ProductBatchID, &i.WarehouseID, &i.Quantity)
 	if err != nil {
-		return 0, err
+		return 0, database.TranslateError(err, "inbound order")
 	}
 
 	id, err := res.LastInsertId()
@@ -87,14 +140,14 @@ func (r *repository) Save(ctx context.Context, i domain.InboundOrders) (int, err
 
 func (r *repository) Update(ctx context.Context, i domain.InboundOrders) error {
 	query := "UPDATE inbound_orders SET order_date=?, order_number=?, employee_id=?, product_batch_id=?, warehouse_id=?  WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, query)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(&i.OrderDate, &i.OrderNumber, &i.EmployeeID, &i.ProductBatchID, &i.WarehouseID, &i.ID)
+	res, err := stmt.ExecContext(ctx, &i.OrderDate, &i.OrderNumber, &i.EmployeeID, &i.ProductBatchID, &i.WarehouseID, &i.ID)
 	if err != nil {
-		return err
+		return database.TranslateError(err, "inbound order")
 	}
 
 	_, err = res.RowsAffected()
@@ -107,14 +160,14 @@ func (r *repository) Update(ctx context.Context, i domain.InboundOrders) error {
 
 func (r *repository) Delete(ctx context.Context, id int) error {
 	query := "DELETE FROM inbound_orders WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, query)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(id)
+	res, err := stmt.ExecContext(ctx, id)
 	if err != nil {
-		return err
+		return database.TranslateError(err, "inbound order")
 	}
 
 	affect, err := res.RowsAffected()
@@ -128,3 +181,13 @@ func (r *repository) Delete(ctx context.Context, id int) error {
 
 	return nil
 }
+
+// CountByWarehouseSince counts the inbound orders received by the warehouse from days ago until now.
+func (r *repository) CountByWarehouseSince(ctx context.Context, warehouseID int, days int) (int, error) {
+	var count int
+	query := "SELECT COUNT(*) FROM inbound_orders WHERE warehouse_id=? AND order_date >= DATE_SUB(CURDATE(), INTERVAL ? DAY)"
+	if err := database.Conn(ctx, r.db).QueryRowContext(ctx, query, warehouseID, days).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -3,12 +3,14 @@ package inbound_order_test
 import (
 	"context"
 	"database/sql"
+	"net/url"
 	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	inbound_order "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/inbound_order"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -28,16 +30,17 @@ func TestRepositoryGet(t *testing.T) {
 			EmployeeID:     "teste",
 			ProductBatchID: "teste",
 			WarehouseID:    "teste",
+			Quantity:       10,
 		}
 
 		r := inbound_order.NewRepository(fields{db}.db)
 
-		rows := sqlmock.NewRows([]string{"id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id"}).
+		rows := sqlmock.NewRows([]string{"id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id", "quantity"}).
 			AddRow(expectedInboundOrders.ID, expectedInboundOrders.OrderDate, expectedInboundOrders.OrderNumber,
-				expectedInboundOrders.EmployeeID, expectedInboundOrders.ProductBatchID, expectedInboundOrders.WarehouseID)
+				expectedInboundOrders.EmployeeID, expectedInboundOrders.ProductBatchID, expectedInboundOrders.WarehouseID, expectedInboundOrders.Quantity)
 
 		// id, order_date, order_number, employee_id, product_batch_id, warehouse_id
-		mock.ExpectQuery("SELECT *. FROM inbound_orders WHERE id=?").
+		mock.ExpectQuery("SELECT (.+) FROM inbound_orders WHERE id=?").
 			WithArgs(expectedInboundOrders.ID).
 			WillReturnRows(rows)
 
@@ -60,11 +63,11 @@ func TestRepositoryGet(t *testing.T) {
 
 		r := inbound_order.NewRepository(fields{db}.db)
 
-		rows := sqlmock.NewRows([]string{"id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id"}).
+		rows := sqlmock.NewRows([]string{"id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id", "quantity"}).
 			AddRow(expectedInboundOrders.ID, expectedInboundOrders.OrderDate, expectedInboundOrders.OrderNumber,
-				expectedInboundOrders.EmployeeID, expectedInboundOrders.ProductBatchID, expectedInboundOrders.WarehouseID)
+				expectedInboundOrders.EmployeeID, expectedInboundOrders.ProductBatchID, expectedInboundOrders.WarehouseID, expectedInboundOrders.Quantity)
 
-		mock.ExpectQuery("SELECT * FROM inbound_orders WHERE id=?").
+		mock.ExpectQuery("SELECT (.+) FROM inbound_orders WHERE id=?").
 			WithArgs(expectedInboundOrders.ID).
 			WillReturnRows(rows)
 
@@ -106,13 +109,13 @@ func TestRepositoryGetAll(t *testing.T) {
 
 		r := inbound_order.NewRepository(fields{db}.db)
 
-		rows := sqlmock.NewRows([]string{"id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id"})
+		rows := sqlmock.NewRows([]string{"id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id", "quantity"})
 
 		for _, expectedInboundOrders := range expectedInboundOrdersList {
 			rows.AddRow(expectedInboundOrders.ID, expectedInboundOrders.OrderDate, expectedInboundOrders.OrderNumber,
-				expectedInboundOrders.EmployeeID, expectedInboundOrders.ProductBatchID, expectedInboundOrders.WarehouseID)
+				expectedInboundOrders.EmployeeID, expectedInboundOrders.ProductBatchID, expectedInboundOrders.WarehouseID, expectedInboundOrders.Quantity)
 		}
-		mock.ExpectQuery("SELECT *. FROM inbound_orders").
+		mock.ExpectQuery("SELECT (.+) FROM inbound_orders").
 			WillReturnRows(rows)
 
 		inboundOrdersReceived, err := r.GetAll(ctx)
@@ -125,7 +128,7 @@ func TestRepositoryGetAll(t *testing.T) {
 
 		r := inbound_order.NewRepository(fields{db}.db)
 
-		mock.ExpectQuery("SELECT * FROM inbound_orders").
+		mock.ExpectQuery("SELECT (.+) FROM inbound_orders").
 			WithArgs().
 			WillReturnError(sql.ErrNoRows)
 
@@ -136,6 +139,59 @@ func TestRepositoryGetAll(t *testing.T) {
 	})
 }
 
+func TestRepositoryGetPage(t *testing.T) {
+	ctx := context.TODO()
+	options, _ := listing.Parse(url.Values{"offset": {"10"}, "sort": {"order_date"}, "warehouse_id": {"3"}}, inbound_order.Fields)
+
+	t.Run("get_page_ok", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		r := inbound_order.NewRepository(db)
+
+		expectedInboundOrdersList := []domain.InboundOrders{
+			{
+				ID:             11,
+				OrderDate:      "teste",
+				OrderNumber:    "teste",
+				EmployeeID:     "teste",
+				ProductBatchID: "teste",
+				WarehouseID:    "3",
+			},
+		}
+
+		rows := sqlmock.NewRows([]string{"id", "order_date", "order_number", "employee_id", "product_batch_id", "warehouse_id", "quantity"})
+		for _, expectedInboundOrders := range expectedInboundOrdersList {
+			rows.AddRow(expectedInboundOrders.ID, expectedInboundOrders.OrderDate, expectedInboundOrders.OrderNumber,
+				expectedInboundOrders.EmployeeID, expectedInboundOrders.ProductBatchID, expectedInboundOrders.WarehouseID, expectedInboundOrders.Quantity)
+		}
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM inbound_orders WHERE warehouse_id = ?")).
+			WithArgs("3").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(11))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, order_date, order_number, employee_id, product_batch_id, warehouse_id, quantity FROM inbound_orders WHERE warehouse_id = ? ORDER BY order_date ASC, id ASC LIMIT ? OFFSET ?")).
+			WithArgs("3", listing.DefaultLimit, 10).
+			WillReturnRows(rows)
+
+		inboundOrdersReceived, total, err := r.GetPage(ctx, options)
+
+		assert.Equal(t, expectedInboundOrdersList, inboundOrdersReceived)
+		assert.Equal(t, 11, total)
+		assert.Nil(t, err)
+	})
+
+	t.Run("get_page_error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		r := inbound_order.NewRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM inbound_orders")).
+			WillReturnError(sql.ErrConnDone)
+
+		inboundOrdersReceived, _, err := r.GetPage(ctx, options)
+
+		assert.Nil(t, inboundOrdersReceived)
+		assert.NotNil(t, err)
+	})
+}
+
 func TestRepositoryExists(t *testing.T) {
 	type fields struct {
 		db *sql.DB
@@ -442,14 +498,15 @@ func TestRepositorySave(t *testing.T) {
 			EmployeeID:     "teste",
 			ProductBatchID: "teste",
 			WarehouseID:    "teste",
+			Quantity:       10,
 		}
 
 		r := inbound_order.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO inbound_orders(order_date, order_number, employee_id, product_batch_id, warehouse_id) VALUES (?,?,?,?,?)"))
-		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO inbound_orders(order_date, order_number, employee_id, product_batch_id, warehouse_id) VALUES (?,?,?,?,?)")).
+		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO inbound_orders(order_date, order_number, employee_id, product_batch_id, warehouse_id, quantity) VALUES (?,?,?,?,?,?)"))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO inbound_orders(order_date, order_number, employee_id, product_batch_id, warehouse_id, quantity) VALUES (?,?,?,?,?,?)")).
 			WithArgs(expectedInboundOrders.OrderDate, expectedInboundOrders.OrderNumber,
-				expectedInboundOrders.EmployeeID, expectedInboundOrders.ProductBatchID, expectedInboundOrders.WarehouseID).
+				expectedInboundOrders.EmployeeID, expectedInboundOrders.ProductBatchID, expectedInboundOrders.WarehouseID, expectedInboundOrders.Quantity).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		id, err := r.Save(ctx, expectedInboundOrders)
@@ -470,10 +527,10 @@ func TestRepositorySave(t *testing.T) {
 
 		r := inbound_order.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO inbound_orders(order_date, order_number, employee_id, product_batch_id, warehouse_id) VALUES (?,?,?,?,?)"))
-		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO inbound_orders(order_date, order_number, employee_id, product_batch_id, warehouse_id) VALUES (?,?,?,?,?)")).
+		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO inbound_orders(order_date, order_number, employee_id, product_batch_id, warehouse_id, quantity) VALUES (?,?,?,?,?,?)"))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO inbound_orders(order_date, order_number, employee_id, product_batch_id, warehouse_id, quantity) VALUES (?,?,?,?,?,?)")).
 			WithArgs(expectedInboundOrders.OrderDate, expectedInboundOrders.OrderNumber,
-				expectedInboundOrders.EmployeeID, expectedInboundOrders.ProductBatchID, expectedInboundOrders.WarehouseID).
+				expectedInboundOrders.EmployeeID, expectedInboundOrders.ProductBatchID, expectedInboundOrders.WarehouseID, expectedInboundOrders.Quantity).
 			WillReturnError(sql.ErrNoRows)
 
 		_, err := r.Save(ctx, expectedInboundOrders)
@@ -494,10 +551,10 @@ func TestRepositorySave(t *testing.T) {
 
 		r := inbound_order.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO inbound_orders(order_date, order_number, employee_id, product_batch_id, warehouse_id) VALUES (?,?,?,?,?)"))
-		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO inbound_orders(order_date, order_number, employee_id, product_batch_id, warehouse_id) VALUES (?,?,?,?,?)")).
+		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO inbound_orders(order_date, order_number, employee_id, product_batch_id, warehouse_id, quantity) VALUES (?,?,?,?,?,?)"))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO inbound_orders(order_date, order_number, employee_id, product_batch_id, warehouse_id, quantity) VALUES (?,?,?,?,?,?)")).
 			WithArgs(expectedInboundOrders.OrderDate, expectedInboundOrders.OrderNumber,
-				expectedInboundOrders.EmployeeID, expectedInboundOrders.ProductBatchID, expectedInboundOrders.WarehouseID).
+				expectedInboundOrders.EmployeeID, expectedInboundOrders.ProductBatchID, expectedInboundOrders.WarehouseID, expectedInboundOrders.Quantity).
 			WillReturnResult(sqlmock.NewErrorResult(sql.ErrNoRows))
 		_, err := r.Save(ctx, expectedInboundOrders)
 
@@ -517,13 +574,37 @@ func TestRepositorySave(t *testing.T) {
 
 		r := inbound_order.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO inbound_orders(order_date, order_number, employee_id, product_batch_id, warehouse_id) VALUES (?,?,?,?,?)")).WillReturnError(sql.ErrConnDone)
-		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO inbound_orders(order_date, order_number, employee_id, product_batch_id, warehouse_id) VALUES (?,?,?,?,?)")).
+		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO inbound_orders(order_date, order_number, employee_id, product_batch_id, warehouse_id, quantity) VALUES (?,?,?,?,?,?)")).WillReturnError(sql.ErrConnDone)
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO inbound_orders(order_date, order_number, employee_id, product_batch_id, warehouse_id, quantity) VALUES (?,?,?,?,?,?)")).
 			WithArgs(expectedInboundOrders.OrderDate, expectedInboundOrders.OrderNumber,
-				expectedInboundOrders.EmployeeID, expectedInboundOrders.ProductBatchID, expectedInboundOrders.WarehouseID).
+				expectedInboundOrders.EmployeeID, expectedInboundOrders.ProductBatchID, expectedInboundOrders.WarehouseID, expectedInboundOrders.Quantity).
 			WillReturnResult(sqlmock.NewErrorResult(sql.ErrNoRows))
 		_, err := r.Save(ctx, expectedInboundOrders)
 
 		assert.NotNil(t, err)
 	})
 }
+
+func TestRepositoryCountByWarehouseSince(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("SELECT COUNT(*) FROM inbound_orders WHERE warehouse_id=? AND order_date >= DATE_SUB(CURDATE(), INTERVAL ? DAY)")
+
+	t.Run("count_ok", func(t *testing.T) {
+		r := inbound_order.NewRepository(db)
+		mock.ExpectQuery(query).WithArgs(1, 30).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		count, err := r.CountByWarehouseSince(ctx, 1, 30)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 3, count)
+	})
+	t.Run("count_error", func(t *testing.T) {
+		r := inbound_order.NewRepository(db)
+		mock.ExpectQuery(query).WithArgs(1, 30).WillReturnError(sql.ErrConnDone)
+
+		_, err := r.CountByWarehouseSince(ctx, 1, 30)
+
+		assert.Equal(t, sql.ErrConnDone, err)
+	})
+}
@@ -5,127 +5,304 @@ import (
 	"errors"
 	"strconv"
 
+	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/employee"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/productbatches"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/section"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 )
 
 // Errors
 var (
-	ErrNotFound            = errors.New("inbound orders not found")
-	ErrConflict            = errors.New("409 Conflict: inbound orders already exists")
-	ErrUnprocessableEntity = errors.New("all fields are required")
+	ErrNotFound             = &errors2.Error{Kind: errors2.KindNotFound, Entity: "inbound order", Message: "inbound orders not found"}
+	ErrConflict             = &errors2.Error{Kind: errors2.KindConflict, Entity: "inbound order", Field: "order_number", Message: "409 Conflict: inbound orders already exists"}
+	ErrEmployeeNotFound     = errors2.Validation("inbound order", "employee_id", "employee does not exist")
+	ErrProductBatchNotFound = errors2.Validation("inbound order", "product_batch_id", "product batch does not exist")
+	ErrWarehouseMismatch    = errors2.Validation("inbound order", "warehouse_id", "warehouse_id is not the warehouse of the product batch section")
+	ErrCapacityExceeded     = &errors2.Error{Kind: errors2.KindConflict, Entity: "inbound order", Field: "quantity", Message: "inbound order exceeds the section maximum capacity"}
+	ErrStockConsumed        = &errors2.Error{Kind: errors2.KindConflict, Entity: "inbound order", Field: "quantity", Message: "the quantity received by the inbound order was already taken from the product batch"}
 )
 
 type Service interface {
-	Get(ctx *context.Context, id int) (*domain.InboundOrders, error)
-	GetAll(ctx *context.Context) (*[]domain.InboundOrders, error)
-	Save(ctx *context.Context, inboundOrders domain.InboundOrders) (*domain.InboundOrders, error)
-	Update(ctx *context.Context, id int, reqUpdateInboundOrders *domain.RequestUpdateInboundOrders) (*domain.InboundOrders, error)
-	Delete(ctx *context.Context, id int) error
-	CountInboundOrders(ctx *context.Context) ([]domain.EmployeeInboundOrdersCount, error)
-	CountInboundOrdersByID(ctx *context.Context, employeeID int) (domain.EmployeeInboundOrdersCount, error)
+	Get(ctx context.Context, id int) (*domain.InboundOrders, error)
+	GetAll(ctx context.Context) (*[]domain.InboundOrders, error)
+	GetPage(ctx context.Context, options listing.Options) (*[]domain.InboundOrders, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.InboundOrders) error) error
+	Save(ctx context.Context, inboundOrders domain.InboundOrders) (*domain.InboundOrders, error)
+	Update(ctx context.Context, id int, reqUpdateInboundOrders *domain.RequestUpdateInboundOrders) (*domain.InboundOrders, error)
+	Delete(ctx context.Context, id int) error
+	CountInboundOrders(ctx context.Context) ([]domain.EmployeeInboundOrdersCount, error)
+	CountInboundOrdersByID(ctx context.Context, employeeID int) (domain.EmployeeInboundOrdersCount, error)
 }
 
 type service struct {
 	inboundOrdersRepository Repository
 	employeeRepository      employee.Repository
+	productBatchRepository  productbatches.IRepository
+	sectionRepository       section.Repository
+	unitOfWork              database.UnitOfWork
 }
 
-func NewService(r Repository, employeeRepository employee.Repository) Service {
+func NewService(r Repository, employeeRepository employee.Repository, productBatchRepository productbatches.IRepository, sectionRepository section.Repository, unitOfWork database.UnitOfWork) Service {
 	return &service{
 		inboundOrdersRepository: r,
 		employeeRepository:      employeeRepository,
+		productBatchRepository:  productBatchRepository,
+		sectionRepository:       sectionRepository,
+		unitOfWork:              unitOfWork,
 	}
 }
 
-func (s *service) Get(ctx *context.Context, id int) (*domain.InboundOrders, error) {
-	inboundOrders, err := s.inboundOrdersRepository.Get(*ctx, id)
+func (s *service) Get(ctx context.Context, id int) (*domain.InboundOrders, error) {
+	inboundOrders, err := s.inboundOrdersRepository.Get(ctx, id)
 	if err != nil {
-		return nil, ErrNotFound
+		return nil, err
 	}
 	return &inboundOrders, nil
 }
 
-func (s *service) GetAll(ctx *context.Context) (*[]domain.InboundOrders, error) {
+func (s *service) GetAll(ctx context.Context) (*[]domain.InboundOrders, error) {
 	inboundOrders := []domain.InboundOrders{}
 
-	inboundOrders, err := s.inboundOrdersRepository.GetAll(*ctx)
+	inboundOrders, err := s.inboundOrdersRepository.GetAll(ctx)
 	if err != nil {
 		return nil, err
 	}
 	return &inboundOrders, nil
 }
 
-func (s *service) Save(ctx *context.Context, inboundOrders domain.InboundOrders) (*domain.InboundOrders, error) {
+func (s *service) GetPage(ctx context.Context, options listing.Options) (*[]domain.InboundOrders, int, error) {
+	inboundOrders, total, err := s.inboundOrdersRepository.GetPage(ctx, options)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &inboundOrders, total, nil
+}
 
-	employeeIDint, _ := strconv.Atoi(inboundOrders.EmployeeID)
-	_, existEmployee := s.employeeRepository.Get(*ctx, employeeIDint)
+func (s *service) Each(ctx context.Context, options listing.Options, fn func(domain.InboundOrders) error) error {
+	return s.inboundOrdersRepository.Each(ctx, options, fn)
+}
 
-	if existEmployee != nil {
-		return nil, ErrConflict
+// Save receives the inbound order: in a single unit of work it checks the employee and that the product
+// batch is in a section of the order's warehouse, adds the quantity to the product batch and to the capacity
+// of its section, and inserts the order with the resulting stock.
+func (s *service) Save(ctx context.Context, inboundOrders domain.InboundOrders) (*domain.InboundOrders, error) {
+	employeeID, err := parseID("employee_id", inboundOrders.EmployeeID)
+	if err != nil {
+		return nil, err
 	}
-
-	id, err := s.inboundOrdersRepository.Save(*ctx, inboundOrders)
+	productBatchID, err := parseID("product_batch_id", inboundOrders.ProductBatchID)
 	if err != nil {
 		return nil, err
 	}
+	warehouseID, err := parseID("warehouse_id", inboundOrders.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		_, err := s.employeeRepository.Get(ctx, employeeID)
+		if errors.Is(err, errors2.ErrNotFound) {
+			return ErrEmployeeNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		productBatch, err := s.receive(ctx, productBatchID, warehouseID, inboundOrders.Quantity)
+		if err != nil {
+			return err
+		}
+
+		id, err := s.inboundOrdersRepository.Save(ctx, inboundOrders)
+		if err != nil {
+			return err
+		}
+
+		inboundOrders.ID = id
 
-	inboundOrders.ID = id
+		productBatch, err = s.productBatchRepository.Get(ctx, productBatch.ID)
+		if err != nil {
+			return err
+		}
+
+		sectionStock, err := s.sectionRepository.Get(ctx, productBatch.SectionID)
+		if err != nil {
+			return err
+		}
+
+		inboundOrders.Stock = &domain.StockPosition{
+			ProductBatchID:  productBatch.ID,
+			CurrentQuantity: productBatch.CurrentQuantity,
+			SectionID:       sectionStock.ID,
+			CurrentCapacity: sectionStock.CurrentCapacity,
+			MaximumCapacity: sectionStock.MaximumCapacity,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	return &inboundOrders, nil
 }
 
-func (s *service) Update(ctx *context.Context, id int, reqUpdateInboundOrders *domain.RequestUpdateInboundOrders) (*domain.InboundOrders, error) {
-	existingInboundOrders, err := s.inboundOrdersRepository.Get(*ctx, id)
+// parseID reads the id an inbound order keeps as text, rejecting anything that is not a number.
+func parseID(field string, value string) (int, error) {
+	id, err := strconv.Atoi(value)
 	if err != nil {
-		return nil, ErrNotFound
+		return 0, errors2.Validation("inbound order", field, field+" must be a number")
 	}
+	return id, nil
+}
 
-	if reqUpdateInboundOrders.OrderDate != nil {
-		existingInboundOrders.OrderDate = *reqUpdateInboundOrders.OrderDate
+// receive checks that the product batch exists in a section of the warehouse and adds the quantity to the
+// product batch and to the capacity of its section.
+func (s *service) receive(ctx context.Context, productBatchID int, warehouseID int, quantity int) (domain.ProductBatches, error) {
+	productBatch, err := s.productBatchRepository.Get(ctx, productBatchID)
+	if errors.Is(err, errors2.ErrNotFound) {
+		return domain.ProductBatches{}, ErrProductBatchNotFound
 	}
-	if reqUpdateInboundOrders.OrderNumber != nil {
-		existingInboundOrders.OrderNumber = *reqUpdateInboundOrders.OrderNumber
-	}
-	if reqUpdateInboundOrders.EmployeeID != nil {
-		existingInboundOrders.EmployeeID = *reqUpdateInboundOrders.EmployeeID
+	if err != nil {
+		return domain.ProductBatches{}, err
 	}
 
-	if reqUpdateInboundOrders.ProductBatchID != nil {
-		existingInboundOrders.ProductBatchID = *reqUpdateInboundOrders.ProductBatchID
+	batchSection, err := s.sectionRepository.Get(ctx, productBatch.SectionID)
+	if err != nil {
+		return domain.ProductBatches{}, err
 	}
-	if reqUpdateInboundOrders.WarehouseID != nil {
-		existingInboundOrders.WarehouseID = *reqUpdateInboundOrders.WarehouseID
+	if batchSection.WarehouseID != warehouseID {
+		return domain.ProductBatches{}, ErrWarehouseMismatch
 	}
 
-	err = s.inboundOrdersRepository.Update(*ctx, existingInboundOrders)
+	err = s.sectionRepository.IncreaseCurrentCapacity(ctx, productBatch.SectionID, quantity)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, section.ErrCapacityExceeded) {
+			return domain.ProductBatches{}, ErrCapacityExceeded
+		}
+		return domain.ProductBatches{}, err
 	}
 
-	return &existingInboundOrders, nil
+	err = s.productBatchRepository.IncreaseCurrentQuantity(ctx, productBatch.ID, quantity)
+	if err != nil {
+		return domain.ProductBatches{}, err
+	}
+
+	return productBatch, nil
 }
 
-func (s *service) Delete(ctx *context.Context, id int) error {
-	_, err := s.inboundOrdersRepository.Get(*ctx, id)
+// release takes back the quantity the inbound order added to its product batch and to the capacity of its
+// section. It fails with ErrStockConsumed when part of that quantity was already taken from the batch.
+func (s *service) release(ctx context.Context, inboundOrders domain.InboundOrders) error {
+	productBatchID, err := parseID("product_batch_id", inboundOrders.ProductBatchID)
 	if err != nil {
-		return ErrNotFound
+		return err
+	}
+
+	productBatch, err := s.productBatchRepository.Get(ctx, productBatchID)
+	if err != nil {
+		return err
+	}
+	if productBatch.CurrentQuantity < inboundOrders.Quantity {
+		return ErrStockConsumed
 	}
 
-	err = s.inboundOrdersRepository.Delete(*ctx, id)
+	err = s.sectionRepository.IncreaseCurrentCapacity(ctx, productBatch.SectionID, -inboundOrders.Quantity)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	return s.productBatchRepository.IncreaseCurrentQuantity(ctx, productBatch.ID, -inboundOrders.Quantity)
+}
+
+// Update changes the inbound order in a single unit of work. When the order moves to another product batch or
+// warehouse, its quantity is released from the old batch and received in the new one with the same checks as Save.
+func (s *service) Update(ctx context.Context, id int, reqUpdateInboundOrders *domain.RequestUpdateInboundOrders) (*domain.InboundOrders, error) {
+	var existingInboundOrders domain.InboundOrders
+
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		existingInboundOrders, err = s.inboundOrdersRepository.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+		originalInboundOrders := existingInboundOrders
+
+		if reqUpdateInboundOrders.OrderDate != nil {
+			existingInboundOrders.OrderDate = *reqUpdateInboundOrders.OrderDate
+		}
+		if reqUpdateInboundOrders.OrderNumber != nil {
+			existingInboundOrders.OrderNumber = *reqUpdateInboundOrders.OrderNumber
+		}
+		if reqUpdateInboundOrders.EmployeeID != nil {
+			existingInboundOrders.EmployeeID = *reqUpdateInboundOrders.EmployeeID
+		}
+
+		if reqUpdateInboundOrders.ProductBatchID != nil {
+			existingInboundOrders.ProductBatchID = *reqUpdateInboundOrders.ProductBatchID
+		}
+		if reqUpdateInboundOrders.WarehouseID != nil {
+			existingInboundOrders.WarehouseID = *reqUpdateInboundOrders.WarehouseID
+		}
+
+		if existingInboundOrders.ProductBatchID != originalInboundOrders.ProductBatchID || existingInboundOrders.WarehouseID != originalInboundOrders.WarehouseID {
+			productBatchID, err := parseID("product_batch_id", existingInboundOrders.ProductBatchID)
+			if err != nil {
+				return err
+			}
+			warehouseID, err := parseID("warehouse_id", existingInboundOrders.WarehouseID)
+			if err != nil {
+				return err
+			}
+
+			err = s.release(ctx, originalInboundOrders)
+			if err != nil {
+				return err
+			}
+
+			_, err = s.receive(ctx, productBatchID, warehouseID, existingInboundOrders.Quantity)
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.inboundOrdersRepository.Update(ctx, existingInboundOrders)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &existingInboundOrders, nil
+}
+
+// Delete removes the inbound order in a single unit of work, releasing its quantity from the product batch
+// and the section it was received in.
+func (s *service) Delete(ctx context.Context, id int) error {
+	return s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		inboundOrders, err := s.inboundOrdersRepository.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		err = s.release(ctx, inboundOrders)
+		if err != nil {
+			return err
+		}
+
+		return s.inboundOrdersRepository.Delete(ctx, id)
+	})
 }
 
-func (s *service) CountInboundOrders(ctx *context.Context) ([]domain.EmployeeInboundOrdersCount, error) {
-	allEmployees, err := s.employeeRepository.GetAll(*ctx)
+func (s *service) CountInboundOrders(ctx context.Context) ([]domain.EmployeeInboundOrdersCount, error) {
+	allEmployees, err := s.employeeRepository.GetAll(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	allInboundOrders, err := s.inboundOrdersRepository.GetAll(*ctx)
+	allInboundOrders, err := s.inboundOrdersRepository.GetAll(ctx)
 
 	if err != nil {
 		return nil, err
@@ -155,13 +332,13 @@ func (s *service) CountInboundOrders(ctx *context.Context) ([]domain.EmployeeInb
 	return inboundOrdersCount, nil
 }
 
-func (s *service) CountInboundOrdersByID(ctx *context.Context, employeeID int) (domain.EmployeeInboundOrdersCount, error) {
-	employee, err := s.employeeRepository.Get(*ctx, employeeID)
+func (s *service) CountInboundOrdersByID(ctx context.Context, employeeID int) (domain.EmployeeInboundOrdersCount, error) {
+	employee, err := s.employeeRepository.Get(ctx, employeeID)
 	if err != nil {
 		return domain.EmployeeInboundOrdersCount{}, err
 	}
 
-	allInboundOrders, err := s.inboundOrdersRepository.GetAll(*ctx)
+	allInboundOrders, err := s.inboundOrdersRepository.GetAll(ctx)
 
 	if err != nil {
 		return domain.EmployeeInboundOrdersCount{}, err
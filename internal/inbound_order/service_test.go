@@ -6,11 +6,18 @@ import (
 	"errors"
 	"testing"
 
+	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	database_mocks "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database/mocks"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/employee"
 	employee_mocks "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/employee/mocks"
 	inbound_order "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/inbound_order"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/inbound_order/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/productbatches"
+	productbatches_mocks "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/productbatches/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/section"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/section/section_mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -33,9 +40,9 @@ func TestGet(t *testing.T) {
 		employeeRepositoryMock := new(employee_mocks.EmployeeRepositoryMock)
 
 		inboundOrdersRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(*expectedInboundOrders, nil)
-		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock)
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock, productbatches_mocks.NewProductBatchesRepositoryMock(), section_mocks.NewSectionRepositoryMock(), database_mocks.NewUnitOfWorkMock())
 
-		inboundOrdersReceived, err := service.Get(&ctx, 1)
+		inboundOrdersReceived, err := service.Get(ctx, 1)
 
 		assert.Equal(t, *expectedInboundOrders, *inboundOrdersReceived)
 		assert.Equal(t, nil, err)
@@ -48,10 +55,10 @@ func TestGet(t *testing.T) {
 		inboundOrdersRepositoryMock := mocks.NewInboundOrdersRepositoryMock()
 		employeeRepositoryMock := new(employee_mocks.EmployeeRepositoryMock)
 
-		inboundOrdersRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.InboundOrders{}, sql.ErrNoRows)
-		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock)
+		inboundOrdersRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.InboundOrders{}, inbound_order.ErrNotFound)
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock, productbatches_mocks.NewProductBatchesRepositoryMock(), section_mocks.NewSectionRepositoryMock(), database_mocks.NewUnitOfWorkMock())
 
-		inboundOrdersReceived, err := service.Get(&ctx, 1)
+		inboundOrdersReceived, err := service.Get(ctx, 1)
 
 		assert.Nil(t, inboundOrdersReceived)
 		assert.Equal(t, inbound_order.ErrNotFound, err)
@@ -85,9 +92,9 @@ func TestGetAll(t *testing.T) {
 		employeeRepositoryMock := new(employee_mocks.EmployeeRepositoryMock)
 
 		inboundOrdersRepositoryMock.On("GetAll", ctx).Return(*expectedInboundOrders, nil)
-		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock)
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock, productbatches_mocks.NewProductBatchesRepositoryMock(), section_mocks.NewSectionRepositoryMock(), database_mocks.NewUnitOfWorkMock())
 
-		inboundOrdersReceived, err := service.GetAll(&ctx)
+		inboundOrdersReceived, err := service.GetAll(ctx)
 
 		assert.Equal(t, *expectedInboundOrders, *inboundOrdersReceived)
 		assert.Equal(t, nil, err)
@@ -101,9 +108,9 @@ func TestGetAll(t *testing.T) {
 		employeeRepositoryMock := new(employee_mocks.EmployeeRepositoryMock)
 
 		inboundOrdersRepositoryMock.On("GetAll", ctx).Return([]domain.InboundOrders{}, errors.New("error"))
-		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock)
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock, productbatches_mocks.NewProductBatchesRepositoryMock(), section_mocks.NewSectionRepositoryMock(), database_mocks.NewUnitOfWorkMock())
 
-		inboundOrdersReceived, err := service.GetAll(&ctx)
+		inboundOrdersReceived, err := service.GetAll(ctx)
 
 		assert.Nil(t, inboundOrdersReceived)
 		assert.Equal(t, errors.New("error"), err)
@@ -111,30 +118,86 @@ func TestGetAll(t *testing.T) {
 
 }
 
+func TestGetPage(t *testing.T) {
+	options := listing.Options{Limit: listing.DefaultLimit}
+
+	t.Run("find_page", func(t *testing.T) {
+		expectedInboundOrders := []domain.InboundOrders{
+			{
+				ID:             1,
+				OrderDate:      "teste",
+				OrderNumber:    "teste",
+				EmployeeID:     "teste",
+				ProductBatchID: "teste",
+				WarehouseID:    "teste",
+			},
+		}
+
+		ctx := context.TODO()
+
+		inboundOrdersRepositoryMock := mocks.NewInboundOrdersRepositoryMock()
+		employeeRepositoryMock := new(employee_mocks.EmployeeRepositoryMock)
+
+		inboundOrdersRepositoryMock.On("GetPage", ctx, options).Return(expectedInboundOrders, 8, nil)
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock, productbatches_mocks.NewProductBatchesRepositoryMock(), section_mocks.NewSectionRepositoryMock(), database_mocks.NewUnitOfWorkMock())
+
+		inboundOrdersReceived, total, err := service.GetPage(ctx, options)
+
+		assert.Equal(t, expectedInboundOrders, *inboundOrdersReceived)
+		assert.Equal(t, 8, total)
+		assert.Equal(t, nil, err)
+	})
+
+	t.Run("unexpected_error", func(t *testing.T) {
+		ctx := context.TODO()
+
+		inboundOrdersRepositoryMock := mocks.NewInboundOrdersRepositoryMock()
+		employeeRepositoryMock := new(employee_mocks.EmployeeRepositoryMock)
+
+		inboundOrdersRepositoryMock.On("GetPage", ctx, options).Return([]domain.InboundOrders{}, 0, errors.New("error"))
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock, productbatches_mocks.NewProductBatchesRepositoryMock(), section_mocks.NewSectionRepositoryMock(), database_mocks.NewUnitOfWorkMock())
+
+		inboundOrdersReceived, _, err := service.GetPage(ctx, options)
+
+		assert.Nil(t, inboundOrdersReceived)
+		assert.Equal(t, errors.New("error"), err)
+	})
+}
+
 func TestDelete(t *testing.T) {
 	t.Run("delete_ok", func(t *testing.T) {
 		inboundOrdersDeleted := &domain.InboundOrders{
 			ID:             1,
 			OrderDate:      "teste",
 			OrderNumber:    "teste",
-			EmployeeID:     "teste",
-			ProductBatchID: "teste",
-			WarehouseID:    "teste",
+			EmployeeID:     "1",
+			ProductBatchID: "1",
+			WarehouseID:    "3",
+			Quantity:       10,
 		}
 
 		ctx := context.TODO()
 
 		inboundOrdersRepositoryMock := mocks.NewInboundOrdersRepositoryMock()
 		employeeRepositoryMock := new(employee_mocks.EmployeeRepositoryMock)
+		productBatchRepositoryMock := productbatches_mocks.NewProductBatchesRepositoryMock()
+		sectionRepositoryMock := section_mocks.NewSectionRepositoryMock()
+		unitOfWork := database_mocks.NewUnitOfWorkMock()
 
 		inboundOrdersRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(*inboundOrdersDeleted, nil)
+		productBatchRepositoryMock.On("Get", ctx, 1).Return(domain.ProductBatches{ID: 1, CurrentQuantity: 100, SectionID: 2}, nil)
+		sectionRepositoryMock.On("IncreaseCurrentCapacity", ctx, 2, -10).Return(nil)
+		productBatchRepositoryMock.On("IncreaseCurrentQuantity", ctx, 1, -10).Return(nil)
 		inboundOrdersRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(nil)
 
-		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock)
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock, productBatchRepositoryMock, sectionRepositoryMock, unitOfWork)
 
-		err := service.Delete(&ctx, 1)
+		err := service.Delete(ctx, 1)
 
 		assert.Equal(t, nil, err)
+		assert.Equal(t, 1, unitOfWork.Calls)
+		sectionRepositoryMock.AssertCalled(t, "IncreaseCurrentCapacity", ctx, 2, -10)
+		productBatchRepositoryMock.AssertCalled(t, "IncreaseCurrentQuantity", ctx, 1, -10)
 	})
 
 	t.Run("delete_non_existent", func(t *testing.T) {
@@ -143,12 +206,17 @@ func TestDelete(t *testing.T) {
 
 		inboundOrdersRepositoryMock := mocks.NewInboundOrdersRepositoryMock()
 		employeeRepositoryMock := new(employee_mocks.EmployeeRepositoryMock)
+		productBatchRepositoryMock := productbatches_mocks.NewProductBatchesRepositoryMock()
+		sectionRepositoryMock := section_mocks.NewSectionRepositoryMock()
 
-		inboundOrdersRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.InboundOrders{}, nil)
+		inboundOrdersRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.InboundOrders{ProductBatchID: "1"}, nil)
+		productBatchRepositoryMock.On("Get", ctx, 1).Return(domain.ProductBatches{ID: 1, SectionID: 2}, nil)
+		sectionRepositoryMock.On("IncreaseCurrentCapacity", ctx, 2, 0).Return(nil)
+		productBatchRepositoryMock.On("IncreaseCurrentQuantity", ctx, 1, 0).Return(nil)
 		inboundOrdersRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(inbound_order.ErrNotFound)
-		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock)
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock, productBatchRepositoryMock, sectionRepositoryMock, database_mocks.NewUnitOfWorkMock())
 
-		err := service.Delete(&ctx, 1)
+		err := service.Delete(ctx, 1)
 
 		assert.Equal(t, inbound_order.ErrNotFound, err)
 	})
@@ -161,13 +229,31 @@ func TestDelete(t *testing.T) {
 
 		inboundOrdersRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.InboundOrders{}, inbound_order.ErrNotFound)
 		//inboundOrdersRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(employee.ErrNotFound)
-		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock)
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock, productbatches_mocks.NewProductBatchesRepositoryMock(), section_mocks.NewSectionRepositoryMock(), database_mocks.NewUnitOfWorkMock())
 
-		err := service.Delete(&ctx, 1)
+		err := service.Delete(ctx, 1)
 
 		assert.Equal(t, inbound_order.ErrNotFound, err)
 	})
 
+	t.Run("delete_stock_consumed", func(t *testing.T) {
+		ctx := context.TODO()
+
+		inboundOrdersRepositoryMock := mocks.NewInboundOrdersRepositoryMock()
+		productBatchRepositoryMock := productbatches_mocks.NewProductBatchesRepositoryMock()
+		sectionRepositoryMock := section_mocks.NewSectionRepositoryMock()
+
+		inboundOrdersRepositoryMock.On("Get", ctx, 1).Return(domain.InboundOrders{ID: 1, ProductBatchID: "1", Quantity: 10}, nil)
+		productBatchRepositoryMock.On("Get", ctx, 1).Return(domain.ProductBatches{ID: 1, CurrentQuantity: 4, SectionID: 2}, nil)
+
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, new(employee_mocks.EmployeeRepositoryMock), productBatchRepositoryMock, sectionRepositoryMock, database_mocks.NewUnitOfWorkMock())
+
+		err := service.Delete(ctx, 1)
+
+		assert.Equal(t, inbound_order.ErrStockConsumed, err)
+		sectionRepositoryMock.AssertNotCalled(t, "IncreaseCurrentCapacity", mock.Anything, mock.Anything, mock.Anything)
+		inboundOrdersRepositoryMock.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
 }
 
 func TestCreate(t *testing.T) {
@@ -188,9 +274,9 @@ func TestCreate(t *testing.T) {
 
 	// 	inboundOrdersRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(true)
 
-	// 	service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock)
+	// 	service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock, productbatches_mocks.NewProductBatchesRepositoryMock(), section_mocks.NewSectionRepositoryMock(), database_mocks.NewUnitOfWorkMock())
 
-	// 	inboundOrdersSaved, err := service.Save(&ctx, *inboundOrdersCreated)
+	// 	inboundOrdersSaved, err := service.Save(ctx, *inboundOrdersCreated)
 
 	// 	assert.Equal(t, inbound_order.ErrConflict, err)
 	// 	assert.Nil(t, inboundOrdersSaved)
@@ -201,54 +287,240 @@ func TestCreate(t *testing.T) {
 			ID:             1,
 			OrderDate:      "teste",
 			OrderNumber:    "teste",
-			EmployeeID:     "teste",
-			ProductBatchID: "teste",
-			WarehouseID:    "teste",
+			EmployeeID:     "1",
+			ProductBatchID: "1",
+			WarehouseID:    "3",
+			Quantity:       10,
 		}
 
 		ctx := context.TODO()
 
 		inboundOrdersRepositoryMock := mocks.NewInboundOrdersRepositoryMock()
 		employeeRepositoryMock := new(employee_mocks.EmployeeRepositoryMock)
-
-		employeeRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.Employee{}, nil)
-		// inboundOrdersRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(false)
+		productBatchRepositoryMock := productbatches_mocks.NewProductBatchesRepositoryMock()
+		sectionRepositoryMock := section_mocks.NewSectionRepositoryMock()
+
+		employeeRepositoryMock.On("Get", ctx, 1).Return(domain.Employee{}, nil)
+		productBatchRepositoryMock.On("Get", ctx, 1).Return(domain.ProductBatches{ID: 1, CurrentQuantity: 100, SectionID: 2}, nil)
+		sectionRepositoryMock.On("Get", ctx, 2).Return(domain.Section{ID: 2, WarehouseID: 3}, nil)
+		sectionRepositoryMock.On("IncreaseCurrentCapacity", ctx, 2, 10).Return(nil)
+		productBatchRepositoryMock.On("IncreaseCurrentQuantity", ctx, 1, 10).Return(nil)
 		inboundOrdersRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.InboundOrders")).Return(0, errors.New("error"))
 
-		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock)
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock, productBatchRepositoryMock, sectionRepositoryMock, database_mocks.NewUnitOfWorkMock())
 
-		inboundOrdersSaved, err := service.Save(&ctx, *inboundOrdersCreated)
+		inboundOrdersSaved, err := service.Save(ctx, *inboundOrdersCreated)
 
 		assert.Equal(t, errors.New("error"), err)
 		assert.Nil(t, inboundOrdersSaved)
 
 	})
 
+	t.Run("create_product_batch_not_found", func(t *testing.T) {
+		inboundOrdersCreated := &domain.InboundOrders{
+			EmployeeID:     "1",
+			ProductBatchID: "99",
+			WarehouseID:    "3",
+			Quantity:       10,
+		}
+
+		ctx := context.TODO()
+
+		inboundOrdersRepositoryMock := mocks.NewInboundOrdersRepositoryMock()
+		employeeRepositoryMock := new(employee_mocks.EmployeeRepositoryMock)
+		productBatchRepositoryMock := productbatches_mocks.NewProductBatchesRepositoryMock()
+		sectionRepositoryMock := section_mocks.NewSectionRepositoryMock()
+
+		employeeRepositoryMock.On("Get", ctx, 1).Return(domain.Employee{}, nil)
+		productBatchRepositoryMock.On("Get", ctx, 99).Return(domain.ProductBatches{}, productbatches.ErrNotFound)
+
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock, productBatchRepositoryMock, sectionRepositoryMock, database_mocks.NewUnitOfWorkMock())
+
+		inboundOrdersSaved, err := service.Save(ctx, *inboundOrdersCreated)
+
+		assert.Equal(t, inbound_order.ErrProductBatchNotFound, err)
+		assert.Nil(t, inboundOrdersSaved)
+		sectionRepositoryMock.AssertNotCalled(t, "IncreaseCurrentCapacity", mock.Anything, mock.Anything, mock.Anything)
+		inboundOrdersRepositoryMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("create_capacity_exceeded", func(t *testing.T) {
+		inboundOrdersCreated := &domain.InboundOrders{
+			EmployeeID:     "1",
+			ProductBatchID: "1",
+			WarehouseID:    "3",
+			Quantity:       500,
+		}
+
+		ctx := context.TODO()
+
+		inboundOrdersRepositoryMock := mocks.NewInboundOrdersRepositoryMock()
+		employeeRepositoryMock := new(employee_mocks.EmployeeRepositoryMock)
+		productBatchRepositoryMock := productbatches_mocks.NewProductBatchesRepositoryMock()
+		sectionRepositoryMock := section_mocks.NewSectionRepositoryMock()
+
+		employeeRepositoryMock.On("Get", ctx, 1).Return(domain.Employee{}, nil)
+		productBatchRepositoryMock.On("Get", ctx, 1).Return(domain.ProductBatches{ID: 1, CurrentQuantity: 100, SectionID: 2}, nil)
+		sectionRepositoryMock.On("Get", ctx, 2).Return(domain.Section{ID: 2, WarehouseID: 3}, nil)
+		sectionRepositoryMock.On("IncreaseCurrentCapacity", ctx, 2, 500).Return(section.ErrCapacityExceeded)
+
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock, productBatchRepositoryMock, sectionRepositoryMock, database_mocks.NewUnitOfWorkMock())
+
+		inboundOrdersSaved, err := service.Save(ctx, *inboundOrdersCreated)
+
+		assert.Equal(t, inbound_order.ErrCapacityExceeded, err)
+		assert.Nil(t, inboundOrdersSaved)
+		productBatchRepositoryMock.AssertNotCalled(t, "IncreaseCurrentQuantity", mock.Anything, mock.Anything, mock.Anything)
+		inboundOrdersRepositoryMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("create_employee_not_found", func(t *testing.T) {
+		inboundOrdersCreated := &domain.InboundOrders{
+			EmployeeID:     "99",
+			ProductBatchID: "1",
+			WarehouseID:    "3",
+			Quantity:       10,
+		}
+
+		ctx := context.TODO()
+
+		inboundOrdersRepositoryMock := mocks.NewInboundOrdersRepositoryMock()
+		employeeRepositoryMock := new(employee_mocks.EmployeeRepositoryMock)
+		productBatchRepositoryMock := productbatches_mocks.NewProductBatchesRepositoryMock()
+
+		employeeRepositoryMock.On("Get", ctx, 99).Return(domain.Employee{}, employee.ErrNotFound)
+
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock, productBatchRepositoryMock, section_mocks.NewSectionRepositoryMock(), database_mocks.NewUnitOfWorkMock())
+
+		inboundOrdersSaved, err := service.Save(ctx, *inboundOrdersCreated)
+
+		assert.Equal(t, inbound_order.ErrEmployeeNotFound, err)
+		assert.Nil(t, inboundOrdersSaved)
+		productBatchRepositoryMock.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+
+	t.Run("create_employee_id_not_a_number", func(t *testing.T) {
+		inboundOrdersCreated := &domain.InboundOrders{
+			EmployeeID:     "abc",
+			ProductBatchID: "1",
+			WarehouseID:    "3",
+			Quantity:       10,
+		}
+
+		ctx := context.TODO()
+
+		employeeRepositoryMock := new(employee_mocks.EmployeeRepositoryMock)
+		unitOfWork := database_mocks.NewUnitOfWorkMock()
+
+		service := inbound_order.NewService(mocks.NewInboundOrdersRepositoryMock(), employeeRepositoryMock, productbatches_mocks.NewProductBatchesRepositoryMock(), section_mocks.NewSectionRepositoryMock(), unitOfWork)
+
+		inboundOrdersSaved, err := service.Save(ctx, *inboundOrdersCreated)
+
+		assert.Equal(t, errors2.Validation("inbound order", "employee_id", "employee_id must be a number"), err)
+		assert.Nil(t, inboundOrdersSaved)
+		assert.Equal(t, 0, unitOfWork.Calls)
+	})
+
+	t.Run("create_product_batch_error", func(t *testing.T) {
+		inboundOrdersCreated := &domain.InboundOrders{
+			EmployeeID:     "1",
+			ProductBatchID: "1",
+			WarehouseID:    "3",
+			Quantity:       10,
+		}
+
+		ctx := context.TODO()
+
+		employeeRepositoryMock := new(employee_mocks.EmployeeRepositoryMock)
+		productBatchRepositoryMock := productbatches_mocks.NewProductBatchesRepositoryMock()
+
+		employeeRepositoryMock.On("Get", ctx, 1).Return(domain.Employee{}, nil)
+		productBatchRepositoryMock.On("Get", ctx, 1).Return(domain.ProductBatches{}, sql.ErrConnDone)
+
+		service := inbound_order.NewService(mocks.NewInboundOrdersRepositoryMock(), employeeRepositoryMock, productBatchRepositoryMock, section_mocks.NewSectionRepositoryMock(), database_mocks.NewUnitOfWorkMock())
+
+		inboundOrdersSaved, err := service.Save(ctx, *inboundOrdersCreated)
+
+		assert.Equal(t, sql.ErrConnDone, err)
+		assert.Nil(t, inboundOrdersSaved)
+	})
+
+	t.Run("create_warehouse_mismatch", func(t *testing.T) {
+		inboundOrdersCreated := &domain.InboundOrders{
+			EmployeeID:     "1",
+			ProductBatchID: "1",
+			WarehouseID:    "4",
+			Quantity:       10,
+		}
+
+		ctx := context.TODO()
+
+		employeeRepositoryMock := new(employee_mocks.EmployeeRepositoryMock)
+		productBatchRepositoryMock := productbatches_mocks.NewProductBatchesRepositoryMock()
+		sectionRepositoryMock := section_mocks.NewSectionRepositoryMock()
+
+		employeeRepositoryMock.On("Get", ctx, 1).Return(domain.Employee{}, nil)
+		productBatchRepositoryMock.On("Get", ctx, 1).Return(domain.ProductBatches{ID: 1, CurrentQuantity: 100, SectionID: 2}, nil)
+		sectionRepositoryMock.On("Get", ctx, 2).Return(domain.Section{ID: 2, WarehouseID: 3}, nil)
+
+		service := inbound_order.NewService(mocks.NewInboundOrdersRepositoryMock(), employeeRepositoryMock, productBatchRepositoryMock, sectionRepositoryMock, database_mocks.NewUnitOfWorkMock())
+
+		inboundOrdersSaved, err := service.Save(ctx, *inboundOrdersCreated)
+
+		assert.Equal(t, inbound_order.ErrWarehouseMismatch, err)
+		assert.Nil(t, inboundOrdersSaved)
+		sectionRepositoryMock.AssertNotCalled(t, "IncreaseCurrentCapacity", mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	t.Run("create_ok", func(t *testing.T) {
+		inboundOrdersCreated := &domain.InboundOrders{
+			OrderDate:      "teste",
+			OrderNumber:    "teste",
+			EmployeeID:     "1",
+			ProductBatchID: "1",
+			WarehouseID:    "3",
+			Quantity:       10,
+		}
 		expectedInboundOrdersCreated := &domain.InboundOrders{
 			ID:             1,
 			OrderDate:      "teste",
 			OrderNumber:    "teste",
-			EmployeeID:     "teste",
-			ProductBatchID: "teste",
-			WarehouseID:    "teste",
+			EmployeeID:     "1",
+			ProductBatchID: "1",
+			WarehouseID:    "3",
+			Quantity:       10,
+			Stock: &domain.StockPosition{
+				ProductBatchID:  1,
+				CurrentQuantity: 110,
+				SectionID:       2,
+				CurrentCapacity: 60,
+				MaximumCapacity: 100,
+			},
 		}
 
 		ctx := context.TODO()
 
 		inboundOrdersRepositoryMock := mocks.NewInboundOrdersRepositoryMock()
 		employeeRepositoryMock := new(employee_mocks.EmployeeRepositoryMock)
+		productBatchRepositoryMock := productbatches_mocks.NewProductBatchesRepositoryMock()
+		sectionRepositoryMock := section_mocks.NewSectionRepositoryMock()
+		unitOfWork := database_mocks.NewUnitOfWorkMock()
 
-		employeeRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.Employee{}, nil)
-		// inboundOrdersRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(false)
-		inboundOrdersRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.InboundOrders")).Return(1, nil)
+		employeeRepositoryMock.On("Get", ctx, 1).Return(domain.Employee{}, nil)
+		productBatchRepositoryMock.On("Get", ctx, 1).Return(domain.ProductBatches{ID: 1, CurrentQuantity: 100, SectionID: 2}, nil).Once()
+		sectionRepositoryMock.On("IncreaseCurrentCapacity", ctx, 2, 10).Return(nil)
+		productBatchRepositoryMock.On("IncreaseCurrentQuantity", ctx, 1, 10).Return(nil)
+		inboundOrdersRepositoryMock.On("Save", ctx, *inboundOrdersCreated).Return(1, nil)
+		productBatchRepositoryMock.On("Get", ctx, 1).Return(domain.ProductBatches{ID: 1, CurrentQuantity: 110, SectionID: 2}, nil).Once()
+		sectionRepositoryMock.On("Get", ctx, 2).Return(domain.Section{ID: 2, CurrentCapacity: 60, MaximumCapacity: 100, WarehouseID: 3}, nil)
 
-		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock)
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock, productBatchRepositoryMock, sectionRepositoryMock, unitOfWork)
 
-		inboundOrdersSaved, err := service.Save(&ctx, *expectedInboundOrdersCreated)
+		inboundOrdersSaved, err := service.Save(ctx, *inboundOrdersCreated)
 
-		assert.Equal(t, inboundOrdersSaved, expectedInboundOrdersCreated)
+		assert.Equal(t, expectedInboundOrdersCreated, inboundOrdersSaved)
 		assert.Nil(t, err)
+		assert.Equal(t, 1, unitOfWork.Calls)
 	})
 }
 
@@ -291,9 +563,9 @@ func TestUpdate(t *testing.T) {
 		inboundOrdersRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(false)
 		inboundOrdersRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.InboundOrders")).Return(nil)
 
-		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock)
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock, productbatches_mocks.NewProductBatchesRepositoryMock(), section_mocks.NewSectionRepositoryMock(), database_mocks.NewUnitOfWorkMock())
 
-		updateInbound_order, err := service.Update(&ctx, 1, &requestUpdateInboundOrders)
+		updateInbound_order, err := service.Update(ctx, 1, &requestUpdateInboundOrders)
 
 		assert.Equal(t, *updateInbound_order, *expectedInboundOrders)
 		assert.Nil(t, err)
@@ -318,9 +590,9 @@ func TestUpdate(t *testing.T) {
 
 		inboundOrdersRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.InboundOrders{}, inbound_order.ErrNotFound)
 
-		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock)
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock, productbatches_mocks.NewProductBatchesRepositoryMock(), section_mocks.NewSectionRepositoryMock(), database_mocks.NewUnitOfWorkMock())
 
-		_, err := service.Update(&ctx, 1, &requestUpdateInboundOrders)
+		_, err := service.Update(ctx, 1, &requestUpdateInboundOrders)
 
 		assert.Equal(t, inbound_order.ErrNotFound, err)
 	})
@@ -346,8 +618,8 @@ func TestUpdate(t *testing.T) {
 		inboundOrdersRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(false)
 		inboundOrdersRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.InboundOrders")).Return(assert.AnError)
 
-		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock)
-		UpdateInboundOrders, err := service.Update(&ctx, 1, requestUpdateInboundOrders)
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock, productbatches_mocks.NewProductBatchesRepositoryMock(), section_mocks.NewSectionRepositoryMock(), database_mocks.NewUnitOfWorkMock())
+		UpdateInboundOrders, err := service.Update(ctx, 1, requestUpdateInboundOrders)
 
 		assert.Nil(t, UpdateInboundOrders)
 		assert.Error(t, err)
@@ -372,8 +644,8 @@ func TestUpdate(t *testing.T) {
 		inboundOrdersRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.InboundOrders{}, employee.ErrNotFound)
 		inboundOrdersRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(true)
 
-		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock)
-		UpdateInboundOrders, err := service.Update(&ctx, 1, requestUpdateInboundOrders)
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock, productbatches_mocks.NewProductBatchesRepositoryMock(), section_mocks.NewSectionRepositoryMock(), database_mocks.NewUnitOfWorkMock())
+		UpdateInboundOrders, err := service.Update(ctx, 1, requestUpdateInboundOrders)
 
 		assert.Nil(t, UpdateInboundOrders)
 		assert.Error(t, err)
@@ -398,8 +670,8 @@ func TestUpdate(t *testing.T) {
 	// 	inboundOrdersRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.InboundOrders{}, nil)
 	// 	inboundOrdersRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(true)
 
-	// 	service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock)
-	// 	UpdateInboundOrders, err := service.Update(&ctx, 1, requestUpdateInboundOrders)
+	// 	service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock, productbatches_mocks.NewProductBatchesRepositoryMock(), section_mocks.NewSectionRepositoryMock(), database_mocks.NewUnitOfWorkMock())
+	// 	UpdateInboundOrders, err := service.Update(ctx, 1, requestUpdateInboundOrders)
 
 	// 	assert.Nil(t, UpdateInboundOrders)
 	// 	assert.Error(t, err)
@@ -425,10 +697,93 @@ func TestUpdate(t *testing.T) {
 		inboundOrdersRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(false)
 		inboundOrdersRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.InboundOrders")).Return(assert.AnError)
 
-		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock)
-		UpdateInboundOrders, err := service.Update(&ctx, 1, requestUpdateInboundOrders)
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, employeeRepositoryMock, productbatches_mocks.NewProductBatchesRepositoryMock(), section_mocks.NewSectionRepositoryMock(), database_mocks.NewUnitOfWorkMock())
+		UpdateInboundOrders, err := service.Update(ctx, 1, requestUpdateInboundOrders)
 
 		assert.Nil(t, UpdateInboundOrders)
 		assert.Error(t, err)
 	})
+
+	t.Run("update_moves_stock", func(t *testing.T) {
+		productBatchID := "5"
+
+		ctx := context.TODO()
+
+		inboundOrdersRepositoryMock := mocks.NewInboundOrdersRepositoryMock()
+		productBatchRepositoryMock := productbatches_mocks.NewProductBatchesRepositoryMock()
+		sectionRepositoryMock := section_mocks.NewSectionRepositoryMock()
+		unitOfWork := database_mocks.NewUnitOfWorkMock()
+
+		expectedInboundOrders := domain.InboundOrders{ID: 1, EmployeeID: "1", ProductBatchID: "5", WarehouseID: "3", Quantity: 10}
+
+		inboundOrdersRepositoryMock.On("Get", ctx, 1).Return(domain.InboundOrders{ID: 1, EmployeeID: "1", ProductBatchID: "1", WarehouseID: "3", Quantity: 10}, nil)
+		productBatchRepositoryMock.On("Get", ctx, 1).Return(domain.ProductBatches{ID: 1, CurrentQuantity: 100, SectionID: 2}, nil)
+		sectionRepositoryMock.On("IncreaseCurrentCapacity", ctx, 2, -10).Return(nil)
+		productBatchRepositoryMock.On("IncreaseCurrentQuantity", ctx, 1, -10).Return(nil)
+		productBatchRepositoryMock.On("Get", ctx, 5).Return(domain.ProductBatches{ID: 5, CurrentQuantity: 20, SectionID: 6}, nil)
+		sectionRepositoryMock.On("Get", ctx, 6).Return(domain.Section{ID: 6, WarehouseID: 3}, nil)
+		sectionRepositoryMock.On("IncreaseCurrentCapacity", ctx, 6, 10).Return(nil)
+		productBatchRepositoryMock.On("IncreaseCurrentQuantity", ctx, 5, 10).Return(nil)
+		inboundOrdersRepositoryMock.On("Update", ctx, expectedInboundOrders).Return(nil)
+
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, new(employee_mocks.EmployeeRepositoryMock), productBatchRepositoryMock, sectionRepositoryMock, unitOfWork)
+		updatedInboundOrders, err := service.Update(ctx, 1, &domain.RequestUpdateInboundOrders{ProductBatchID: &productBatchID})
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedInboundOrders, *updatedInboundOrders)
+		assert.Equal(t, 1, unitOfWork.Calls)
+		sectionRepositoryMock.AssertCalled(t, "IncreaseCurrentCapacity", ctx, 2, -10)
+		productBatchRepositoryMock.AssertCalled(t, "IncreaseCurrentQuantity", ctx, 1, -10)
+		sectionRepositoryMock.AssertCalled(t, "IncreaseCurrentCapacity", ctx, 6, 10)
+		productBatchRepositoryMock.AssertCalled(t, "IncreaseCurrentQuantity", ctx, 5, 10)
+	})
+
+	t.Run("update_warehouse_mismatch", func(t *testing.T) {
+		warehouseID := "4"
+
+		ctx := context.TODO()
+
+		inboundOrdersRepositoryMock := mocks.NewInboundOrdersRepositoryMock()
+		productBatchRepositoryMock := productbatches_mocks.NewProductBatchesRepositoryMock()
+		sectionRepositoryMock := section_mocks.NewSectionRepositoryMock()
+
+		inboundOrdersRepositoryMock.On("Get", ctx, 1).Return(domain.InboundOrders{ID: 1, EmployeeID: "1", ProductBatchID: "1", WarehouseID: "3", Quantity: 10}, nil)
+		productBatchRepositoryMock.On("Get", ctx, 1).Return(domain.ProductBatches{ID: 1, CurrentQuantity: 100, SectionID: 2}, nil)
+		sectionRepositoryMock.On("IncreaseCurrentCapacity", ctx, 2, -10).Return(nil)
+		productBatchRepositoryMock.On("IncreaseCurrentQuantity", ctx, 1, -10).Return(nil)
+		sectionRepositoryMock.On("Get", ctx, 2).Return(domain.Section{ID: 2, WarehouseID: 3}, nil)
+
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, new(employee_mocks.EmployeeRepositoryMock), productBatchRepositoryMock, sectionRepositoryMock, database_mocks.NewUnitOfWorkMock())
+		updatedInboundOrders, err := service.Update(ctx, 1, &domain.RequestUpdateInboundOrders{WarehouseID: &warehouseID})
+
+		assert.Nil(t, updatedInboundOrders)
+		assert.Equal(t, inbound_order.ErrWarehouseMismatch, err)
+		sectionRepositoryMock.AssertNotCalled(t, "IncreaseCurrentCapacity", ctx, 2, 10)
+		inboundOrdersRepositoryMock.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("update_capacity_exceeded", func(t *testing.T) {
+		productBatchID := "5"
+
+		ctx := context.TODO()
+
+		inboundOrdersRepositoryMock := mocks.NewInboundOrdersRepositoryMock()
+		productBatchRepositoryMock := productbatches_mocks.NewProductBatchesRepositoryMock()
+		sectionRepositoryMock := section_mocks.NewSectionRepositoryMock()
+
+		inboundOrdersRepositoryMock.On("Get", ctx, 1).Return(domain.InboundOrders{ID: 1, EmployeeID: "1", ProductBatchID: "1", WarehouseID: "3", Quantity: 10}, nil)
+		productBatchRepositoryMock.On("Get", ctx, 1).Return(domain.ProductBatches{ID: 1, CurrentQuantity: 100, SectionID: 2}, nil)
+		sectionRepositoryMock.On("IncreaseCurrentCapacity", ctx, 2, -10).Return(nil)
+		productBatchRepositoryMock.On("IncreaseCurrentQuantity", ctx, 1, -10).Return(nil)
+		productBatchRepositoryMock.On("Get", ctx, 5).Return(domain.ProductBatches{ID: 5, CurrentQuantity: 20, SectionID: 6}, nil)
+		sectionRepositoryMock.On("Get", ctx, 6).Return(domain.Section{ID: 6, WarehouseID: 3}, nil)
+		sectionRepositoryMock.On("IncreaseCurrentCapacity", ctx, 6, 10).Return(section.ErrCapacityExceeded)
+
+		service := inbound_order.NewService(inboundOrdersRepositoryMock, new(employee_mocks.EmployeeRepositoryMock), productBatchRepositoryMock, sectionRepositoryMock, database_mocks.NewUnitOfWorkMock())
+		updatedInboundOrders, err := service.Update(ctx, 1, &domain.RequestUpdateInboundOrders{ProductBatchID: &productBatchID})
+
+		assert.Nil(t, updatedInboundOrders)
+		assert.Equal(t, inbound_order.ErrCapacityExceeded, err)
+		inboundOrdersRepositoryMock.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
 }
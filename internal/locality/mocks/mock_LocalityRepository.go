@@ -5,7 +5,11 @@ package mocks
 import (
 	context "context"
 
-	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos"
+	domain "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+
+	listing "github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,19 +18,45 @@ type MockLocalityRepository struct {
 	mock.Mock
 }
 
+// CountAllSellers provides a mock function with given fields: ctx, order
+func (_m *MockLocalityRepository) CountAllSellers(ctx context.Context, order string) ([]dtos.GetNumberOfSellersResponseDTO, error) {
+	ret := _m.Called(ctx, order)
+
+	var r0 []dtos.GetNumberOfSellersResponseDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]dtos.GetNumberOfSellersResponseDTO, error)); ok {
+		return rf(ctx, order)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []dtos.GetNumberOfSellersResponseDTO); ok {
+		r0 = rf(ctx, order)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dtos.GetNumberOfSellersResponseDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, order)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CountSellers provides a mock function with given fields: ctx, id
-func (_m *MockLocalityRepository) CountSellers(ctx context.Context, id int) (int, error) {
+func (_m *MockLocalityRepository) CountSellers(ctx context.Context, id int) (dtos.GetNumberOfSellersResponseDTO, error) {
 	ret := _m.Called(ctx, id)
 
-	var r0 int
+	var r0 dtos.GetNumberOfSellersResponseDTO
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int) (dtos.GetNumberOfSellersResponseDTO, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int) dtos.GetNumberOfSellersResponseDTO); ok {
 		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Get(0).(int)
+		r0 = ret.Get(0).(dtos.GetNumberOfSellersResponseDTO)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
@@ -116,23 +146,122 @@ func (_m *MockLocalityRepository) GetAll(ctx context.Context) ([]domain.Locality
 	return r0, r1
 }
 
-// Save provides a mock function with given fields: ctx, locality
-func (_m *MockLocalityRepository) Save(ctx context.Context, locality domain.Locality) (int, error) {
-	ret := _m.Called(ctx, locality)
+// GetAllByCountryID provides a mock function with given fields: ctx, countryID
+func (_m *MockLocalityRepository) GetAllByCountryID(ctx context.Context, countryID int) ([]domain.Locality, error) {
+	ret := _m.Called(ctx, countryID)
+
+	var r0 []domain.Locality
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.Locality, error)); ok {
+		return rf(ctx, countryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.Locality); ok {
+		r0 = rf(ctx, countryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Locality)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, countryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllByProvinceID provides a mock function with given fields: ctx, provinceID
+func (_m *MockLocalityRepository) GetAllByProvinceID(ctx context.Context, provinceID int) ([]domain.Locality, error) {
+	ret := _m.Called(ctx, provinceID)
+
+	var r0 []domain.Locality
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.Locality, error)); ok {
+		return rf(ctx, provinceID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.Locality); ok {
+		r0 = rf(ctx, provinceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Locality)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, provinceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPage provides a mock function with given fields: ctx, options
+func (_m *MockLocalityRepository) GetPage(ctx context.Context, options listing.Options) ([]domain.Locality, int, error) {
+	ret := _m.Called(ctx, options)
+
+	var r0 []domain.Locality
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) ([]domain.Locality, int, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) []domain.Locality); ok {
+		r0 = rf(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Locality)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Options) int); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Options) error); ok {
+		r2 = rf(ctx, options)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Each provides a mock function with given fields: ctx, options, fn
+func (_m *MockLocalityRepository) Each(ctx context.Context, options listing.Options, fn func(domain.Locality) error) error {
+	ret := _m.Called(ctx, options, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options, func(domain.Locality) error) error); ok {
+		r0 = rf(ctx, options, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: ctx, _a1
+func (_m *MockLocalityRepository) Save(ctx context.Context, _a1 domain.Locality) (int, error) {
+	ret := _m.Called(ctx, _a1)
 
 	var r0 int
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, domain.Locality) (int, error)); ok {
-		return rf(ctx, locality)
+		return rf(ctx, _a1)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, domain.Locality) int); ok {
-		r0 = rf(ctx, locality)
+		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, domain.Locality) error); ok {
-		r1 = rf(ctx, locality)
+		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -140,13 +269,13 @@ func (_m *MockLocalityRepository) Save(ctx context.Context, locality domain.Loca
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: ctx, locality
-func (_m *MockLocalityRepository) Update(ctx context.Context, locality domain.Locality) error {
-	ret := _m.Called(ctx, locality)
+// Update provides a mock function with given fields: ctx, _a1
+func (_m *MockLocalityRepository) Update(ctx context.Context, _a1 domain.Locality) error {
+	ret := _m.Called(ctx, _a1)
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, domain.Locality) error); ok {
-		r0 = rf(ctx, locality)
+		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -4,9 +4,11 @@ package mocks
 
 import (
 	context "context"
-	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos"
+	domain "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+
+	listing "github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -16,22 +18,48 @@ type MockLocalityService struct {
 	mock.Mock
 }
 
+// CountAllSellers provides a mock function with given fields: ctx, order
+func (_m *MockLocalityService) CountAllSellers(ctx context.Context, order string) ([]dtos.GetNumberOfSellersResponseDTO, error) {
+	ret := _m.Called(ctx, order)
+
+	var r0 []dtos.GetNumberOfSellersResponseDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]dtos.GetNumberOfSellersResponseDTO, error)); ok {
+		return rf(ctx, order)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []dtos.GetNumberOfSellersResponseDTO); ok {
+		r0 = rf(ctx, order)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dtos.GetNumberOfSellersResponseDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, order)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CountSellers provides a mock function with given fields: ctx, id
-func (_m *MockLocalityService) CountSellers(ctx *context.Context, id int) (int, error) {
+func (_m *MockLocalityService) CountSellers(ctx context.Context, id int) (dtos.GetNumberOfSellersResponseDTO, error) {
 	ret := _m.Called(ctx, id)
 
-	var r0 int
+	var r0 dtos.GetNumberOfSellersResponseDTO
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*context.Context, int) (int, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int) (dtos.GetNumberOfSellersResponseDTO, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(*context.Context, int) int); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int) dtos.GetNumberOfSellersResponseDTO); ok {
 		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Get(0).(int)
+		r0 = ret.Get(0).(dtos.GetNumberOfSellersResponseDTO)
 	}
 
-	if rf, ok := ret.Get(1).(func(*context.Context, int) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
 		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
@@ -41,21 +69,21 @@ func (_m *MockLocalityService) CountSellers(ctx *context.Context, id int) (int,
 }
 
 // Create provides a mock function with given fields: ctx, _a1
-func (_m *MockLocalityService) Create(ctx *context.Context, _a1 domain.Locality) (domain.Locality, error) {
+func (_m *MockLocalityService) Create(ctx context.Context, _a1 domain.Locality) (domain.Locality, error) {
 	ret := _m.Called(ctx, _a1)
 
 	var r0 domain.Locality
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*context.Context, domain.Locality) (domain.Locality, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Locality) (domain.Locality, error)); ok {
 		return rf(ctx, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(*context.Context, domain.Locality) domain.Locality); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Locality) domain.Locality); ok {
 		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Get(0).(domain.Locality)
 	}
 
-	if rf, ok := ret.Get(1).(func(*context.Context, domain.Locality) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Locality) error); ok {
 		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -65,11 +93,11 @@ func (_m *MockLocalityService) Create(ctx *context.Context, _a1 domain.Locality)
 }
 
 // Delete provides a mock function with given fields: ctx, id
-func (_m *MockLocalityService) Delete(ctx *context.Context, id int) error {
+func (_m *MockLocalityService) Delete(ctx context.Context, id int) error {
 	ret := _m.Called(ctx, id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*context.Context, int) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
 		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
@@ -79,21 +107,21 @@ func (_m *MockLocalityService) Delete(ctx *context.Context, id int) error {
 }
 
 // Get provides a mock function with given fields: ctx, id
-func (_m *MockLocalityService) Get(ctx *context.Context, id int) (domain.Locality, error) {
+func (_m *MockLocalityService) Get(ctx context.Context, id int) (domain.Locality, error) {
 	ret := _m.Called(ctx, id)
 
 	var r0 domain.Locality
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*context.Context, int) (domain.Locality, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.Locality, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(*context.Context, int) domain.Locality); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int) domain.Locality); ok {
 		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(domain.Locality)
 	}
 
-	if rf, ok := ret.Get(1).(func(*context.Context, int) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
 		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
@@ -103,15 +131,15 @@ func (_m *MockLocalityService) Get(ctx *context.Context, id int) (domain.Localit
 }
 
 // GetAll provides a mock function with given fields: ctx
-func (_m *MockLocalityService) GetAll(ctx *context.Context) ([]domain.Locality, error) {
+func (_m *MockLocalityService) GetAll(ctx context.Context) ([]domain.Locality, error) {
 	ret := _m.Called(ctx)
 
 	var r0 []domain.Locality
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*context.Context) ([]domain.Locality, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Locality, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(*context.Context) []domain.Locality); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Locality); ok {
 		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
@@ -119,7 +147,7 @@ func (_m *MockLocalityService) GetAll(ctx *context.Context) ([]domain.Locality,
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
 		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
@@ -128,22 +156,121 @@ func (_m *MockLocalityService) GetAll(ctx *context.Context) ([]domain.Locality,
 	return r0, r1
 }
 
+// GetAllByCountryID provides a mock function with given fields: ctx, countryID
+func (_m *MockLocalityService) GetAllByCountryID(ctx context.Context, countryID int) ([]domain.Locality, error) {
+	ret := _m.Called(ctx, countryID)
+
+	var r0 []domain.Locality
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.Locality, error)); ok {
+		return rf(ctx, countryID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.Locality); ok {
+		r0 = rf(ctx, countryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Locality)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, countryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllByProvinceID provides a mock function with given fields: ctx, provinceID
+func (_m *MockLocalityService) GetAllByProvinceID(ctx context.Context, provinceID int) ([]domain.Locality, error) {
+	ret := _m.Called(ctx, provinceID)
+
+	var r0 []domain.Locality
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.Locality, error)); ok {
+		return rf(ctx, provinceID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.Locality); ok {
+		r0 = rf(ctx, provinceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Locality)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, provinceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPage provides a mock function with given fields: ctx, options
+func (_m *MockLocalityService) GetPage(ctx context.Context, options listing.Options) ([]domain.Locality, int, error) {
+	ret := _m.Called(ctx, options)
+
+	var r0 []domain.Locality
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) ([]domain.Locality, int, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) []domain.Locality); ok {
+		r0 = rf(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Locality)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Options) int); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Options) error); ok {
+		r2 = rf(ctx, options)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Each provides a mock function with given fields: ctx, options, fn
+func (_m *MockLocalityService) Each(ctx context.Context, options listing.Options, fn func(domain.Locality) error) error {
+	ret := _m.Called(ctx, options, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options, func(domain.Locality) error) error); ok {
+		r0 = rf(ctx, options, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, id, updateLocalityRequest
-func (_m *MockLocalityService) Update(ctx *context.Context, id int, updateLocalityRequest dtos.UpdateLocalityRequestDTO) (domain.Locality, error) {
+func (_m *MockLocalityService) Update(ctx context.Context, id int, updateLocalityRequest dtos.UpdateLocalityRequestDTO) (domain.Locality, error) {
 	ret := _m.Called(ctx, id, updateLocalityRequest)
 
 	var r0 domain.Locality
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*context.Context, int, dtos.UpdateLocalityRequestDTO) (domain.Locality, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int, dtos.UpdateLocalityRequestDTO) (domain.Locality, error)); ok {
 		return rf(ctx, id, updateLocalityRequest)
 	}
-	if rf, ok := ret.Get(0).(func(*context.Context, int, dtos.UpdateLocalityRequestDTO) domain.Locality); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int, dtos.UpdateLocalityRequestDTO) domain.Locality); ok {
 		r0 = rf(ctx, id, updateLocalityRequest)
 	} else {
 		r0 = ret.Get(0).(domain.Locality)
 	}
 
-	if rf, ok := ret.Get(1).(func(*context.Context, int, dtos.UpdateLocalityRequestDTO) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, int, dtos.UpdateLocalityRequestDTO) error); ok {
 		r1 = rf(ctx, id, updateLocalityRequest)
 	} else {
 		r1 = ret.Error(1)
@@ -3,30 +3,63 @@ package locality
 import (
 	"context"
 	"database/sql"
-	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"fmt"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 )
 
 type LocalityRepository interface {
 	GetAll(ctx context.Context) ([]domain.Locality, error)
+	GetPage(ctx context.Context, options listing.Options) ([]domain.Locality, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.Locality) error) error
+	GetAllByProvinceID(ctx context.Context, provinceID int) ([]domain.Locality, error)
+	GetAllByCountryID(ctx context.Context, countryID int) ([]domain.Locality, error)
 	Get(ctx context.Context, id int) (domain.Locality, error)
 	Exists(ctx context.Context, id int) bool
 	Save(ctx context.Context, locality domain.Locality) (int, error)
 	Update(ctx context.Context, locality domain.Locality) error
 	Delete(ctx context.Context, id int) error
-	CountSellers(ctx context.Context, id int) (int, error)
+	CountSellers(ctx context.Context, id int) (dtos.GetNumberOfSellersResponseDTO, error)
+	CountAllSellers(ctx context.Context, order string) ([]dtos.GetNumberOfSellersResponseDTO, error)
 }
 
 const (
-	GetAllLocalities         = "SELECT localities.id, localities.country_name, localities.province_name, localities.locality_name FROM localities"
-	GetLocalityByID          = "SELECT localities.id, localities.country_name, localities.province_name, localities.locality_name FROM localities WHERE id = ?"
-	ExistsLocalityByID       = "SELECT id FROM localities WHERE id=?"
-	SaveLocality             = "INSERT INTO localities(country_name, province_name, locality_name) VALUES (?,?,?)"
-	UpdateLocality           = "UPDATE localities SET country_name=?, province_name=?, locality_name=?  WHERE id=?"
-	DeleteLocalityByID       = "DELETE FROM localities WHERE id = ?"
-	CountLocalitySellersByID = "SELECT COUNT(*) from localities where id = ?"
+	GetAllLocalities             = "SELECT localities.id, countries.country_name, provinces.province_name, localities.locality_name, provinces.id, countries.id FROM localities INNER JOIN provinces ON localities.province_id = provinces.id INNER JOIN countries ON provinces.country_id = countries.id"
+	CountLocalities              = "SELECT COUNT(*) FROM localities INNER JOIN provinces ON localities.province_id = provinces.id INNER JOIN countries ON provinces.country_id = countries.id"
+	GetAllLocalitiesByProvinceID = GetAllLocalities + " WHERE provinces.id = ?"
+	GetAllLocalitiesByCountryID  = GetAllLocalities + " WHERE countries.id = ?"
+	GetLocalityByID              = GetAllLocalities + " WHERE localities.id = ?"
+	ExistsLocalityByID           = "SELECT id FROM localities WHERE id=?"
+	SaveLocality                 = "INSERT INTO localities(locality_name, province_id) VALUES (?,?)"
+	UpdateLocality               = "UPDATE localities SET locality_name=?, province_id=? WHERE id=?"
+	DeleteLocalityByID           = "DELETE FROM localities WHERE id = ?"
+	CountSellersByLocality       = "SELECT localities.id, localities.locality_name, COUNT(sellers.id) AS sellers_count FROM localities LEFT JOIN sellers ON sellers.locality_id = localities.id AND sellers.deleted_at IS NULL"
+	CountLocalitySellersByID     = CountSellersByLocality + " WHERE localities.id = ? GROUP BY localities.id, localities.locality_name"
+	CountAllLocalitySellers      = CountSellersByLocality + " GROUP BY localities.id, localities.locality_name"
 )
 
+// Orders accepted by CountAllSellers, sorting the report by the number of sellers
+const (
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+)
+
+var sellersCountOrderBy = map[string]string{
+	"":        " ORDER BY localities.id",
+	OrderAsc:  " ORDER BY sellers_count ASC, localities.id",
+	OrderDesc: " ORDER BY sellers_count DESC, localities.id",
+}
+
+// Fields are the locality fields list requests can sort and filter by.
+var Fields = listing.Fields{
+	"id":            "localities.id",
+	"locality_name": "localities.locality_name",
+	"province_name": "provinces.province_name",
+	"country_name":  "countries.country_name",
+}
+
 type localityRepository struct {
 	db *sql.DB
 }
@@ -38,31 +71,49 @@ func NewLocalityRepository(db *sql.DB) LocalityRepository {
 }
 
 func (r *localityRepository) GetAll(ctx context.Context) ([]domain.Locality, error) {
-	localities := make([]domain.Locality, 0)
+	return r.query(ctx, GetAllLocalities)
+}
 
-	rows, err := r.db.Query(GetAllLocalities)
-	if err != nil {
-		return localities, err
+func (r *localityRepository) GetPage(ctx context.Context, options listing.Options) ([]domain.Locality, int, error) {
+	var total int
+	query, args := options.CountQuery(CountLocalities)
+	if err := database.Conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return make([]domain.Locality, 0), 0, err
 	}
 
-	for rows.Next() {
-		locality := domain.Locality{}
-		err := rows.Scan(&locality.ID, &locality.CountryName, &locality.ProvinceName, &locality.LocalityName)
-		if err != nil {
-			return localities, err
-		}
-
+	localities := make([]domain.Locality, 0)
+	err := r.Each(ctx, options, func(locality domain.Locality) error {
 		localities = append(localities, locality)
+		return nil
+	})
+	if err != nil {
+		return localities, 0, err
 	}
 
-	return localities, rows.Err()
+	return localities, total, nil
+}
+
+func (r *localityRepository) Each(ctx context.Context, options listing.Options, fn func(domain.Locality) error) error {
+	query, args := options.Query(GetAllLocalities)
+	return r.each(ctx, fn, query, args...)
+}
+
+func (r *localityRepository) GetAllByProvinceID(ctx context.Context, provinceID int) ([]domain.Locality, error) {
+	return r.query(ctx, GetAllLocalitiesByProvinceID, provinceID)
+}
+
+func (r *localityRepository) GetAllByCountryID(ctx context.Context, countryID int) ([]domain.Locality, error) {
+	return r.query(ctx, GetAllLocalitiesByCountryID, countryID)
 }
 
 func (r *localityRepository) Get(ctx context.Context, id int) (domain.Locality, error) {
-	row := r.db.QueryRow(GetLocalityByID, id)
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, GetLocalityByID, id)
 	locality := domain.Locality{}
-	err := row.Scan(&locality.ID, &locality.CountryName, &locality.ProvinceName, &locality.LocalityName)
+	err := row.Scan(&locality.ID, &locality.CountryName, &locality.ProvinceName, &locality.LocalityName, &locality.ProvinceID, &locality.CountryID)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Locality{}, ErrNotFound
+		}
 		return domain.Locality{}, err
 	}
 
@@ -70,21 +121,21 @@ func (r *localityRepository) Get(ctx context.Context, id int) (domain.Locality,
 }
 
 func (r *localityRepository) Exists(ctx context.Context, id int) bool {
-	row := r.db.QueryRow(ExistsLocalityByID, id)
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, ExistsLocalityByID, id)
 	var foundId int
 	err := row.Scan(&foundId)
 	return err == nil
 }
 
 func (r *localityRepository) Save(ctx context.Context, locality domain.Locality) (int, error) {
-	stmt, err := r.db.Prepare(SaveLocality)
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, SaveLocality)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(&locality.CountryName, &locality.ProvinceName, &locality.LocalityName)
+	res, err := stmt.ExecContext(ctx, &locality.LocalityName, &locality.ProvinceID)
 	if err != nil {
-		return 0, err
+		return 0, database.TranslateError(err, "locality")
 	}
 
 	id, err := res.LastInsertId()
@@ -96,14 +147,14 @@ func (r *localityRepository) Save(ctx context.Context, locality domain.Locality)
 }
 
 func (r *localityRepository) Update(ctx context.Context, locality domain.Locality) error {
-	stmt, err := r.db.Prepare(UpdateLocality)
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, UpdateLocality)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(&locality.CountryName, &locality.ProvinceName, &locality.LocalityName, &locality.ID)
+	res, err := stmt.ExecContext(ctx, &locality.LocalityName, &locality.ProvinceID, &locality.ID)
 	if err != nil {
-		return err
+		return database.TranslateError(err, "locality")
 	}
 
 	_, err = res.RowsAffected()
@@ -115,14 +166,14 @@ func (r *localityRepository) Update(ctx context.Context, locality domain.Localit
 }
 
 func (r *localityRepository) Delete(ctx context.Context, id int) error {
-	stmt, err := r.db.Prepare(DeleteLocalityByID)
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, DeleteLocalityByID)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(id)
+	res, err := stmt.ExecContext(ctx, id)
 	if err != nil {
-		return err
+		return database.TranslateError(err, "locality")
 	}
 
 	affect, err := res.RowsAffected()
@@ -131,16 +182,78 @@ func (r *localityRepository) Delete(ctx context.Context, id int) error {
 	}
 
 	if affect < 1 {
-		return errors.ErrNotFound
+		return ErrNotFound
 	}
 
 	return nil
 }
 
-func (r *localityRepository) CountSellers(ctx context.Context, id int) (int, error) {
-	count := 0
-	row := r.db.QueryRow(CountLocalitySellersByID, id)
-	err := row.Scan(&count)
+func (r *localityRepository) CountSellers(ctx context.Context, id int) (dtos.GetNumberOfSellersResponseDTO, error) {
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, CountLocalitySellersByID, id)
+	report := dtos.GetNumberOfSellersResponseDTO{}
+	err := row.Scan(&report.LocalityID, &report.LocalityName, &report.SellersCount)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return dtos.GetNumberOfSellersResponseDTO{}, ErrNotFound
+		}
+		return dtos.GetNumberOfSellersResponseDTO{}, err
+	}
+
+	return report, nil
+}
+
+func (r *localityRepository) CountAllSellers(ctx context.Context, order string) ([]dtos.GetNumberOfSellersResponseDTO, error) {
+	reports := make([]dtos.GetNumberOfSellersResponseDTO, 0)
+
+	orderBy, ok := sellersCountOrderBy[order]
+	if !ok {
+		return reports, fmt.Errorf("invalid order: %s", order)
+	}
+
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, CountAllLocalitySellers+orderBy)
+	if err != nil {
+		return reports, err
+	}
+
+	for rows.Next() {
+		report := dtos.GetNumberOfSellersResponseDTO{}
+		err := rows.Scan(&report.LocalityID, &report.LocalityName, &report.SellersCount)
+		if err != nil {
+			return reports, err
+		}
+
+		reports = append(reports, report)
+	}
+
+	return reports, rows.Err()
+}
+
+func (r *localityRepository) query(ctx context.Context, query string, args ...interface{}) ([]domain.Locality, error) {
+	localities := make([]domain.Locality, 0)
+	err := r.each(ctx, func(locality domain.Locality) error {
+		localities = append(localities, locality)
+		return nil
+	}, query, args...)
+	return localities, err
+}
+
+// each scans the rows of query one at a time, passing them to fn.
+func (r *localityRepository) each(ctx context.Context, fn func(domain.Locality) error, query string, args ...interface{}) error {
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		locality := domain.Locality{}
+		if err := rows.Scan(&locality.ID, &locality.CountryName, &locality.ProvinceName, &locality.LocalityName, &locality.ProvinceID, &locality.CountryID); err != nil {
+			return err
+		}
+		if err := fn(locality); err != nil {
+			return err
+		}
+	}
 
-	return count, err
+	return rows.Err()
 }
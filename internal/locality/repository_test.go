@@ -5,8 +5,11 @@ import (
 	"database/sql"
 	"encoding/json"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/assert"
+	"net/url"
 	"os"
 	"regexp"
 	"testing"
@@ -64,15 +67,15 @@ func Test_localityRepository_GetAll(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			r := NewLocalityRepository(tt.fields.db)
 
-			rows := sqlmock.NewRows([]string{"id", "country_name", "province_name", "locality_name"})
+			rows := sqlmock.NewRows([]string{"id", "country_name", "province_name", "locality_name", "province_id", "country_id"})
 			for _, locality := range validLocalities {
-				rows.AddRow(locality.ID, locality.CountryName, locality.ProvinceName, locality.LocalityName)
+				rows.AddRow(locality.ID, locality.CountryName, locality.ProvinceName, locality.LocalityName, locality.ProvinceID, locality.CountryID)
 			}
 			if tt.wantErr {
 				rows.RowError(0, sql.ErrNoRows)
 			}
 
-			mock.ExpectQuery(GetAllLocalities).
+			mock.ExpectQuery(regexp.QuoteMeta(GetAllLocalities)).
 				WithArgs().
 				WillReturnRows(rows)
 
@@ -91,15 +94,15 @@ func Test_localityRepository_GetAll(t *testing.T) {
 		r := NewLocalityRepository(db)
 		db.Close()
 
-		rows := sqlmock.NewRows([]string{"id", "country_name", "province_name", "locality_name"})
+		rows := sqlmock.NewRows([]string{"id", "country_name", "province_name", "locality_name", "province_id", "country_id"})
 		for _, locality := range validLocalities {
-			rows.AddRow(locality.ID, locality.CountryName, locality.ProvinceName, locality.LocalityName)
+			rows.AddRow(locality.ID, locality.CountryName, locality.ProvinceName, locality.LocalityName, locality.ProvinceID, locality.CountryID)
 		}
 		//if tt.wantErr {
 		//	rows.RowError(0, sql.ErrNoRows)
 		//}
 
-		mock.ExpectQuery(GetAllLocalities).
+		mock.ExpectQuery(regexp.QuoteMeta(GetAllLocalities)).
 			WithArgs().
 			WillReturnRows(rows)
 
@@ -124,6 +127,54 @@ func Test_localityRepository_GetAll(t *testing.T) {
 	})
 }
 
+func Test_localityRepository_GetPage(t *testing.T) {
+	validLocalitiesSerialized, _ := os.ReadFile("../../test/resources/valid_localities.json")
+	var validLocalities []domain.Locality
+	if err := json.Unmarshal(validLocalitiesSerialized, &validLocalities); err != nil {
+		t.Fatal(err)
+	}
+
+	options, err := listing.Parse(url.Values{"sort": {"province_name"}, "country_name": {"Brasil"}, "limit": {"2"}, "offset": {"2"}}, Fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Successfully get a page of localities", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		r := NewLocalityRepository(db)
+
+		rows := sqlmock.NewRows([]string{"id", "country_name", "province_name", "locality_name", "province_id", "country_id"})
+		for _, locality := range validLocalities {
+			rows.AddRow(locality.ID, locality.CountryName, locality.ProvinceName, locality.LocalityName, locality.ProvinceID, locality.CountryID)
+		}
+
+		mock.ExpectQuery(regexp.QuoteMeta(CountLocalities + " WHERE countries.country_name = ?")).
+			WithArgs("Brasil").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+		mock.ExpectQuery(regexp.QuoteMeta(GetAllLocalities+" WHERE countries.country_name = ? ORDER BY provinces.province_name ASC, localities.id ASC LIMIT ? OFFSET ?")).
+			WithArgs("Brasil", 2, 2).
+			WillReturnRows(rows)
+
+		got, total, err := r.GetPage(context.TODO(), options)
+
+		assert.NoError(t, err)
+		assert.Equal(t, validLocalities, got)
+		assert.Equal(t, 7, total)
+	})
+
+	t.Run("Error counting localities", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		r := NewLocalityRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(CountLocalities)).WillReturnError(sql.ErrConnDone)
+
+		got, _, err := r.GetPage(context.TODO(), options)
+
+		assert.Equal(t, []domain.Locality{}, got)
+		assert.Equal(t, true, err != nil)
+	})
+}
+
 func Test_localityRepository_Get(t *testing.T) {
 	type fields struct {
 		db *sql.DB
@@ -176,10 +227,10 @@ func Test_localityRepository_Get(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			r := NewLocalityRepository(tt.fields.db)
 
-			rows := sqlmock.NewRows([]string{"id", "country_name", "province_name", "locality_name"}).
-				AddRow(tt.want.ID, tt.want.CountryName, tt.want.ProvinceName, tt.want.LocalityName)
+			rows := sqlmock.NewRows([]string{"id", "country_name", "province_name", "locality_name", "province_id", "country_id"}).
+				AddRow(tt.want.ID, tt.want.CountryName, tt.want.ProvinceName, tt.want.LocalityName, tt.want.ProvinceID, tt.want.CountryID)
 
-			mock.ExpectQuery(GetLocalityByID).
+			mock.ExpectQuery(regexp.QuoteMeta(GetLocalityByID)).
 				WithArgs(tt.want.ID).
 				WillReturnRows(rows)
 
@@ -307,9 +358,8 @@ func Test_localityRepository_Save(t *testing.T) {
 			mock.ExpectPrepare(regexp.QuoteMeta(SaveLocality))
 			mock.ExpectExec(regexp.QuoteMeta(SaveLocality)).
 				WithArgs(
-					tt.args.locality.CountryName,
-					tt.args.locality.ProvinceName,
 					tt.args.locality.LocalityName,
+					tt.args.locality.ProvinceID,
 				).
 				WillReturnResult(sqlmock.NewResult(int64(tt.want), 1))
 
@@ -340,9 +390,8 @@ func Test_localityRepository_Save(t *testing.T) {
 		mock.ExpectPrepare(regexp.QuoteMeta(SaveLocality))
 		mock.ExpectExec(regexp.QuoteMeta(SaveLocality)).
 			WithArgs(
-				locality.CountryName,
-				locality.ProvinceName,
 				locality.LocalityName,
+				locality.ProvinceID,
 			).
 			WillReturnError(sql.ErrNoRows)
 
@@ -398,9 +447,8 @@ func Test_localityRepository_Update(t *testing.T) {
 			mock.ExpectPrepare(regexp.QuoteMeta(UpdateLocality))
 			mock.ExpectExec(regexp.QuoteMeta(UpdateLocality)).
 				WithArgs(
-					tt.args.locality.CountryName,
-					tt.args.locality.ProvinceName,
 					tt.args.locality.LocalityName,
+					tt.args.locality.ProvinceID,
 					tt.args.locality.ID,
 				).
 				WillReturnResult(sqlmock.NewResult(1, 1))
@@ -431,9 +479,8 @@ func Test_localityRepository_Update(t *testing.T) {
 		mock.ExpectPrepare(regexp.QuoteMeta(UpdateLocality))
 		mock.ExpectExec(regexp.QuoteMeta(UpdateLocality)).
 			WithArgs(
-				locality.CountryName,
-				locality.ProvinceName,
 				locality.LocalityName,
+				locality.ProvinceID,
 				locality.ID,
 			).
 			WillReturnError(sql.ErrNoRows)
@@ -548,16 +595,18 @@ func Test_localityRepository_GetNumberOfSellers(t *testing.T) {
 		id  int
 	}
 
-	// Para funcionar com * na query
-	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	db, mock, _ := sqlmock.New()
 	ctx := context.TODO()
 
+	report := dtos.GetNumberOfSellersResponseDTO{LocalityID: 6700, LocalityName: "Lujan", SellersCount: 3}
+
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    int
-		wantErr bool
+		name     string
+		fields   fields
+		args     args
+		queryErr error
+		want     dtos.GetNumberOfSellersResponseDTO
+		wantErr  error
 	}{
 		{
 			name: "Successfully get number of sellers",
@@ -568,25 +617,170 @@ func Test_localityRepository_GetNumberOfSellers(t *testing.T) {
 				ctx: ctx,
 				id:  6700,
 			},
-			want:    1,
-			wantErr: false,
+			want:    report,
+			wantErr: nil,
+		},
+		{
+			name: "Error nonexistent locality",
+			fields: fields{
+				db: db,
+			},
+			args: args{
+				ctx: ctx,
+				id:  999,
+			},
+			queryErr: sql.ErrNoRows,
+			want:     dtos.GetNumberOfSellersResponseDTO{},
+			wantErr:  ErrNotFound,
+		},
+		{
+			name: "Error querying number of sellers",
+			fields: fields{
+				db: db,
+			},
+			args: args{
+				ctx: ctx,
+				id:  6700,
+			},
+			queryErr: assert.AnError,
+			want:     dtos.GetNumberOfSellersResponseDTO{},
+			wantErr:  assert.AnError,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := NewLocalityRepository(tt.fields.db)
 
-			rows := sqlmock.NewRows([]string{"count(*)"}).AddRow(tt.want)
-
-			mock.ExpectQuery(CountLocalitySellersByID).
-				WithArgs(tt.args.id).
-				WillReturnRows(rows)
+			expected := mock.ExpectQuery(regexp.QuoteMeta(CountLocalitySellersByID)).
+				WithArgs(tt.args.id)
+			if tt.queryErr != nil {
+				expected.WillReturnError(tt.queryErr)
+			} else {
+				expected.WillReturnRows(sqlmock.NewRows([]string{"id", "locality_name", "sellers_count"}).
+					AddRow(tt.want.LocalityID, tt.want.LocalityName, tt.want.SellersCount))
+			}
 
 			got, err := r.CountSellers(tt.args.ctx, tt.args.id)
 
 			assert.Equal(t, tt.want, got)
-			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_localityRepository_CountAllSellers(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	reports := []dtos.GetNumberOfSellersResponseDTO{
+		{LocalityID: 6700, LocalityName: "Lujan", SellersCount: 3},
+		{LocalityID: 7600, LocalityName: "Rio de Janeiro", SellersCount: 0},
+	}
+
+	tests := []struct {
+		name          string
+		order         string
+		expectedQuery string
+		want          []dtos.GetNumberOfSellersResponseDTO
+		wantErr       bool
+	}{
+		{
+			name:          "Successfully count sellers by locality id",
+			order:         "",
+			expectedQuery: CountAllLocalitySellers + " ORDER BY localities.id",
+			want:          reports,
+		},
+		{
+			name:          "Successfully count sellers sorted descending",
+			order:         OrderDesc,
+			expectedQuery: CountAllLocalitySellers + " ORDER BY sellers_count DESC, localities.id",
+			want:          reports,
+		},
+		{
+			name:          "Successfully count sellers sorted ascending",
+			order:         OrderAsc,
+			expectedQuery: CountAllLocalitySellers + " ORDER BY sellers_count ASC, localities.id",
+			want:          []dtos.GetNumberOfSellersResponseDTO{reports[1], reports[0]},
+		},
+		{
+			name:    "Error invalid order",
+			order:   "sideways",
+			want:    []dtos.GetNumberOfSellersResponseDTO{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewLocalityRepository(db)
+
+			if tt.expectedQuery != "" {
+				rows := sqlmock.NewRows([]string{"id", "locality_name", "sellers_count"})
+				for _, report := range tt.want {
+					rows.AddRow(report.LocalityID, report.LocalityName, report.SellersCount)
+				}
+				mock.ExpectQuery(regexp.QuoteMeta(tt.expectedQuery)).
+					WillReturnRows(rows)
+			}
+
+			got, err := r.CountAllSellers(ctx, tt.order)
 
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
+
+func Test_localityRepository_GetAllByProvinceAndCountry(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	validLocalitiesSerialized, _ := os.ReadFile("../../test/resources/valid_localities.json")
+	var validLocalities []domain.Locality
+	if err := json.Unmarshal(validLocalitiesSerialized, &validLocalities); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewLocalityRepository(db)
+
+	newRows := func(localities []domain.Locality) *sqlmock.Rows {
+		rows := sqlmock.NewRows([]string{"id", "country_name", "province_name", "locality_name", "province_id", "country_id"})
+		for _, locality := range localities {
+			rows.AddRow(locality.ID, locality.CountryName, locality.ProvinceName, locality.LocalityName, locality.ProvinceID, locality.CountryID)
+		}
+		return rows
+	}
+
+	t.Run("Successfully get localities by province", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(GetAllLocalitiesByProvinceID)).
+			WithArgs(1).
+			WillReturnRows(newRows(validLocalities[:1]))
+
+		got, err := r.GetAllByProvinceID(ctx, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, validLocalities[:1], got)
+	})
+
+	t.Run("Successfully get localities by country", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(GetAllLocalitiesByCountryID)).
+			WithArgs(2).
+			WillReturnRows(newRows(validLocalities[1:]))
+
+		got, err := r.GetAllByCountryID(ctx, 2)
+
+		assert.NoError(t, err)
+		assert.Equal(t, validLocalities[1:], got)
+	})
+
+	t.Run("Error querying localities by country", func(t *testing.T) {
+		mock.ExpectQuery(regexp.QuoteMeta(GetAllLocalitiesByCountryID)).
+			WithArgs(2).
+			WillReturnError(assert.AnError)
+
+		got, err := r.GetAllByCountryID(ctx, 2)
+
+		assert.Error(t, err)
+		assert.Equal(t, []domain.Locality{}, got)
+	})
+}
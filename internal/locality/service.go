@@ -2,32 +2,55 @@ package locality
 
 import (
 	"context"
+	"errors"
+
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos"
 	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/country"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/province"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
+)
+
+// Errors
+var (
+	ErrNotFound = errors2.NotFound("locality")
+	ErrConflict = errors2.Conflict("locality", "id")
 )
 
 type LocalityService interface {
-	Get(ctx *context.Context, id int) (domain.Locality, error)
-	GetAll(ctx *context.Context) ([]domain.Locality, error)
-	Create(ctx *context.Context, locality domain.Locality) (domain.Locality, error)
-	Update(ctx *context.Context, id int, updateLocalityRequest dtos.UpdateLocalityRequestDTO) (domain.Locality, error)
-	Delete(ctx *context.Context, id int) error
-	CountSellers(ctx *context.Context, id int) (int, error)
+	Get(ctx context.Context, id int) (domain.Locality, error)
+	GetAll(ctx context.Context) ([]domain.Locality, error)
+	GetPage(ctx context.Context, options listing.Options) ([]domain.Locality, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.Locality) error) error
+	GetAllByProvinceID(ctx context.Context, provinceID int) ([]domain.Locality, error)
+	GetAllByCountryID(ctx context.Context, countryID int) ([]domain.Locality, error)
+	Create(ctx context.Context, locality domain.Locality) (domain.Locality, error)
+	Update(ctx context.Context, id int, updateLocalityRequest dtos.UpdateLocalityRequestDTO) (domain.Locality, error)
+	Delete(ctx context.Context, id int) error
+	CountSellers(ctx context.Context, id int) (dtos.GetNumberOfSellersResponseDTO, error)
+	CountAllSellers(ctx context.Context, order string) ([]dtos.GetNumberOfSellersResponseDTO, error)
 }
 
 type localityService struct {
 	localityRepository LocalityRepository
+	provinceRepository province.ProvinceRepository
+	countryRepository  country.CountryRepository
+	unitOfWork         database.UnitOfWork
 }
 
-func NewLocalityService(r LocalityRepository) LocalityService {
+func NewLocalityService(r LocalityRepository, provinceRepository province.ProvinceRepository, countryRepository country.CountryRepository, unitOfWork database.UnitOfWork) LocalityService {
 	return &localityService{
 		localityRepository: r,
+		provinceRepository: provinceRepository,
+		countryRepository:  countryRepository,
+		unitOfWork:         unitOfWork,
 	}
 }
 
-func (service *localityService) Get(ctx *context.Context, id int) (domain.Locality, error) {
-	locality, err := service.localityRepository.Get(*ctx, id)
+func (service *localityService) Get(ctx context.Context, id int) (domain.Locality, error) {
+	locality, err := service.localityRepository.Get(ctx, id)
 	if err != nil {
 		return domain.Locality{}, err
 	}
@@ -35,10 +58,10 @@ func (service *localityService) Get(ctx *context.Context, id int) (domain.Locali
 	return locality, nil
 }
 
-func (service *localityService) GetAll(ctx *context.Context) ([]domain.Locality, error) {
+func (service *localityService) GetAll(ctx context.Context) ([]domain.Locality, error) {
 	localities := make([]domain.Locality, 0)
 
-	localities, err := service.localityRepository.GetAll(*ctx)
+	localities, err := service.localityRepository.GetAll(ctx)
 	if err != nil {
 		return localities, err
 	}
@@ -46,57 +69,121 @@ func (service *localityService) GetAll(ctx *context.Context) ([]domain.Locality,
 	return localities, nil
 }
 
-func (service *localityService) Create(ctx *context.Context, locality domain.Locality) (domain.Locality, error) {
-	existingLocality := service.localityRepository.Exists(*ctx, locality.ID)
-	if existingLocality {
-		return domain.Locality{}, errors2.ErrConflict
+func (service *localityService) GetPage(ctx context.Context, options listing.Options) ([]domain.Locality, int, error) {
+	localities, total, err := service.localityRepository.GetPage(ctx, options)
+	if err != nil {
+		return localities, 0, err
 	}
 
-	id, err := service.localityRepository.Save(*ctx, locality)
-	if err != nil {
-		return domain.Locality{}, err
+	return localities, total, nil
+}
+
+func (service *localityService) Each(ctx context.Context, options listing.Options, fn func(domain.Locality) error) error {
+	return service.localityRepository.Each(ctx, options, fn)
+}
+
+func (service *localityService) GetAllByProvinceID(ctx context.Context, provinceID int) ([]domain.Locality, error) {
+	if !service.provinceRepository.Exists(ctx, provinceID) {
+		return []domain.Locality{}, province.ErrNotFound
 	}
 
-	locality.ID = id
+	localities, err := service.localityRepository.GetAllByProvinceID(ctx, provinceID)
+	if err != nil {
+		return []domain.Locality{}, err
+	}
 
-	return locality, nil
+	return localities, nil
 }
 
-func (service *localityService) Update(ctx *context.Context, id int, updateLocalityRequest dtos.UpdateLocalityRequestDTO) (domain.Locality, error) {
-	existingLocality, err := service.localityRepository.Get(*ctx, id)
+func (service *localityService) GetAllByCountryID(ctx context.Context, countryID int) ([]domain.Locality, error) {
+	if !service.countryRepository.Exists(ctx, countryID) {
+		return []domain.Locality{}, country.ErrNotFound
+	}
+
+	localities, err := service.localityRepository.GetAllByCountryID(ctx, countryID)
 	if err != nil {
-		return domain.Locality{}, err
+		return []domain.Locality{}, err
 	}
 
-	existingLocalitySearch := service.localityRepository.Exists(*ctx, id)
-	if existingLocalitySearch {
-		return domain.Locality{}, errors2.ErrConflict
+	return localities, nil
+}
+
+// Create saves the locality together with the province and country it creates by name, so a failed
+// save leaves none of them behind.
+func (service *localityService) Create(ctx context.Context, locality domain.Locality) (domain.Locality, error) {
+	existingLocality := service.localityRepository.Exists(ctx, locality.ID)
+	if existingLocality {
+		return domain.Locality{}, ErrConflict
 	}
 
-	if updateLocalityRequest.CountryName != nil {
-		existingLocality.CountryName = *updateLocalityRequest.CountryName
+	err := service.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := service.resolveProvince(ctx, &locality); err != nil {
+			return err
+		}
+
+		id, err := service.localityRepository.Save(ctx, locality)
+		if err != nil {
+			return err
+		}
+
+		locality.ID = id
+
+		return nil
+	})
+	if err != nil {
+		return domain.Locality{}, err
 	}
-	if updateLocalityRequest.ProvinceName != nil {
-		existingLocality.ProvinceName = *updateLocalityRequest.ProvinceName
+
+	return locality, nil
+}
+
+func (service *localityService) Update(ctx context.Context, id int, updateLocalityRequest dtos.UpdateLocalityRequestDTO) (domain.Locality, error) {
+	existingLocality, err := service.localityRepository.Get(ctx, id)
+	if err != nil {
+		return domain.Locality{}, err
 	}
+
 	if updateLocalityRequest.LocalityName != nil {
 		existingLocality.LocalityName = *updateLocalityRequest.LocalityName
 	}
 
-	if err = service.localityRepository.Update(*ctx, existingLocality); err != nil {
+	// A province given by ID wins over names; otherwise any name change is resolved again
+	if updateLocalityRequest.ProvinceID != nil {
+		existingLocality.ProvinceID = *updateLocalityRequest.ProvinceID
+	} else if updateLocalityRequest.ProvinceName != nil || updateLocalityRequest.CountryName != nil || updateLocalityRequest.CountryID != nil {
+		existingLocality.ProvinceID = 0
+		if updateLocalityRequest.ProvinceName != nil {
+			existingLocality.ProvinceName = *updateLocalityRequest.ProvinceName
+		}
+		if updateLocalityRequest.CountryID != nil {
+			existingLocality.CountryID = *updateLocalityRequest.CountryID
+		} else if updateLocalityRequest.CountryName != nil {
+			existingLocality.CountryID = 0
+			existingLocality.CountryName = *updateLocalityRequest.CountryName
+		}
+	}
+
+	err = service.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := service.resolveProvince(ctx, &existingLocality); err != nil {
+			return err
+		}
+
+		return service.localityRepository.Update(ctx, existingLocality)
+	})
+	if err != nil {
 		return domain.Locality{}, err
 	}
 
 	return existingLocality, nil
 }
 
-func (service *localityService) Delete(ctx *context.Context, id int) error {
-	_, err := service.localityRepository.Get(*ctx, id)
+func (service *localityService) Delete(ctx context.Context, id int) error {
+	_, err := service.localityRepository.Get(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	err = service.localityRepository.Delete(*ctx, id)
+	err = service.localityRepository.Delete(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -104,12 +191,78 @@ func (service *localityService) Delete(ctx *context.Context, id int) error {
 	return nil
 }
 
-func (service *localityService) CountSellers(ctx *context.Context, id int) (int, error) {
-	count, err := service.localityRepository.CountSellers(*ctx, id)
+func (service *localityService) CountSellers(ctx context.Context, id int) (dtos.GetNumberOfSellersResponseDTO, error) {
+	report, err := service.localityRepository.CountSellers(ctx, id)
 	if err != nil {
-		return 0, err
+		return dtos.GetNumberOfSellersResponseDTO{}, err
 	}
 
-	return count, nil
+	return report, nil
+}
+
+func (service *localityService) CountAllSellers(ctx context.Context, order string) ([]dtos.GetNumberOfSellersResponseDTO, error) {
+	reports, err := service.localityRepository.CountAllSellers(ctx, order)
+	if err != nil {
+		return []dtos.GetNumberOfSellersResponseDTO{}, err
+	}
+
+	return reports, nil
+}
+
+// resolveProvince fills the province and country of a locality. A province referenced by ID must exist,
+// while a province or country referenced by name is created when it's not found.
+func (service *localityService) resolveProvince(ctx context.Context, locality *domain.Locality) error {
+	if locality.ProvinceID != 0 {
+		foundProvince, err := service.provinceRepository.Get(ctx, locality.ProvinceID)
+		if err != nil {
+			return err
+		}
+
+		foundCountry, err := service.countryRepository.Get(ctx, foundProvince.CountryID)
+		if err != nil {
+			return err
+		}
+
+		locality.ProvinceName = foundProvince.ProvinceName
+		locality.CountryID = foundCountry.ID
+		locality.CountryName = foundCountry.CountryName
+		return nil
+	}
+
+	foundCountry, err := service.resolveCountry(ctx, locality.CountryID, locality.CountryName)
+	if err != nil {
+		return err
+	}
+
+	foundProvince, err := service.provinceRepository.GetByName(ctx, locality.ProvinceName, foundCountry.ID)
+	if errors.Is(err, errors2.ErrNotFound) {
+		foundProvince = domain.Province{ProvinceName: locality.ProvinceName, CountryID: foundCountry.ID}
+		foundProvince.ID, err = service.provinceRepository.Save(ctx, foundProvince)
+	}
+	if err != nil {
+		return err
+	}
+
+	locality.ProvinceID = foundProvince.ID
+	locality.ProvinceName = foundProvince.ProvinceName
+	locality.CountryID = foundCountry.ID
+	locality.CountryName = foundCountry.CountryName
+	return nil
+}
+
+func (service *localityService) resolveCountry(ctx context.Context, countryID int, countryName string) (domain.Country, error) {
+	if countryID != 0 {
+		return service.countryRepository.Get(ctx, countryID)
+	}
+
+	foundCountry, err := service.countryRepository.GetByName(ctx, countryName)
+	if errors.Is(err, errors2.ErrNotFound) {
+		foundCountry = domain.Country{CountryName: countryName}
+		foundCountry.ID, err = service.countryRepository.Save(ctx, foundCountry)
+	}
+	if err != nil {
+		return domain.Country{}, err
+	}
 
+	return foundCountry, nil
 }
@@ -4,9 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos"
-	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/country"
+	countryMocks "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/country/mocks"
+	database_mocks "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database/mocks"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/locality/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/province"
+	provinceMocks "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/province/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"os"
@@ -15,7 +20,7 @@ import (
 
 func Test_localityService_GetAll(t *testing.T) {
 	type args struct {
-		ctx                  *context.Context
+		ctx                  context.Context
 		expectedGetAllResult []domain.Locality
 		expectedGetAllError  error
 		expectedGetAllCalls  int
@@ -38,7 +43,7 @@ func Test_localityService_GetAll(t *testing.T) {
 		{
 			name: "Successfully get all localities",
 			args: args{
-				ctx:                  &ctx,
+				ctx:                  ctx,
 				expectedGetAllResult: expectedLocalities,
 				expectedGetAllError:  nil,
 				expectedGetAllCalls:  1,
@@ -49,7 +54,7 @@ func Test_localityService_GetAll(t *testing.T) {
 		{
 			name: "Error getting all",
 			args: args{
-				ctx:                  &ctx,
+				ctx:                  ctx,
 				expectedGetAllResult: []domain.Locality{},
 				expectedGetAllError:  assert.AnError,
 				expectedGetAllCalls:  1,
@@ -61,9 +66,9 @@ func Test_localityService_GetAll(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			sellerRepositoryMock := mocks.NewMockLocalityRepository(t)
-			sellerRepositoryMock.On("GetAll", *tt.args.ctx).Return(tt.args.expectedGetAllResult, tt.args.expectedGetAllError)
+			sellerRepositoryMock.On("GetAll", tt.args.ctx).Return(tt.args.expectedGetAllResult, tt.args.expectedGetAllError)
 
-			service := NewLocalityService(sellerRepositoryMock)
+			service := NewLocalityService(sellerRepositoryMock, provinceMocks.NewMockProvinceRepository(t), countryMocks.NewMockCountryRepository(t), database_mocks.NewUnitOfWorkMock())
 			got, err := service.GetAll(tt.args.ctx)
 
 			assert.Equal(t, tt.want, got)
@@ -74,9 +79,58 @@ func Test_localityService_GetAll(t *testing.T) {
 	}
 }
 
+func Test_localityService_GetPage(t *testing.T) {
+	ctx := context.TODO()
+	options := listing.Options{Limit: 2}
+
+	var expectedLocalities []domain.Locality
+	expectedLocalitiesSerialized, _ := os.ReadFile("../../test/resources/valid_localities.json")
+	if err := json.Unmarshal(expectedLocalitiesSerialized, &expectedLocalities); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                  string
+		expectedGetPageResult []domain.Locality
+		expectedGetPageTotal  int
+		expectedGetPageError  error
+		want                  []domain.Locality
+		wantTotal             int
+		wantErr               error
+	}{
+		{
+			name:                  "Successfully get a page of localities",
+			expectedGetPageResult: expectedLocalities,
+			expectedGetPageTotal:  9,
+			want:                  expectedLocalities,
+			wantTotal:             9,
+		},
+		{
+			name:                  "Error getting a page",
+			expectedGetPageResult: []domain.Locality{},
+			expectedGetPageError:  assert.AnError,
+			want:                  []domain.Locality{},
+			wantErr:               assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			localityRepositoryMock := mocks.NewMockLocalityRepository(t)
+			localityRepositoryMock.On("GetPage", ctx, options).Return(tt.expectedGetPageResult, tt.expectedGetPageTotal, tt.expectedGetPageError)
+
+			service := NewLocalityService(localityRepositoryMock, provinceMocks.NewMockProvinceRepository(t), countryMocks.NewMockCountryRepository(t), database_mocks.NewUnitOfWorkMock())
+			got, total, err := service.GetPage(ctx, options)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantTotal, total)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
 func Test_localityService_Get(t *testing.T) {
 	type args struct {
-		ctx               *context.Context
+		ctx               context.Context
 		id                int
 		expectedGetResult domain.Locality
 		expectedGetError  error
@@ -100,7 +154,7 @@ func Test_localityService_Get(t *testing.T) {
 		{
 			name: "Successfully get buyer from db",
 			args: args{
-				ctx:               &ctx,
+				ctx:               ctx,
 				id:                expectedLocality.ID,
 				expectedGetResult: expectedLocality,
 				expectedGetError:  nil,
@@ -112,19 +166,19 @@ func Test_localityService_Get(t *testing.T) {
 		{
 			name: "Buyer not found in db",
 			args: args{
-				ctx:               &ctx,
+				ctx:               ctx,
 				id:                expectedLocality.ID,
 				expectedGetResult: domain.Locality{},
-				expectedGetError:  errors.ErrNotFound,
+				expectedGetError:  ErrNotFound,
 				expectedGetCalls:  1,
 			},
 			want:    domain.Locality{},
-			wantErr: errors.ErrNotFound,
+			wantErr: ErrNotFound,
 		},
 		{
 			name: "Error connecting db",
 			args: args{
-				ctx:               &ctx,
+				ctx:               ctx,
 				id:                expectedLocality.ID,
 				expectedGetResult: domain.Locality{},
 				expectedGetError:  assert.AnError,
@@ -137,9 +191,9 @@ func Test_localityService_Get(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			localityRepositoryMock := mocks.NewMockLocalityRepository(t)
-			localityRepositoryMock.On("Get", *tt.args.ctx, mock.AnythingOfType("int")).Return(tt.args.expectedGetResult, tt.args.expectedGetError)
+			localityRepositoryMock.On("Get", tt.args.ctx, mock.AnythingOfType("int")).Return(tt.args.expectedGetResult, tt.args.expectedGetError)
 
-			service := NewLocalityService(localityRepositoryMock)
+			service := NewLocalityService(localityRepositoryMock, provinceMocks.NewMockProvinceRepository(t), countryMocks.NewMockCountryRepository(t), database_mocks.NewUnitOfWorkMock())
 			got, err := service.Get(tt.args.ctx, tt.args.id)
 
 			assert.Equal(t, tt.want, got)
@@ -153,13 +207,14 @@ func Test_localityService_Get(t *testing.T) {
 
 func Test_localityService_Create(t *testing.T) {
 	type args struct {
-		ctx                  *context.Context
+		ctx                  context.Context
 		locality             domain.Locality
 		expectedExistsResult bool
 		expectedExistsCalls  int
 		expectedSaveResult   int
 		expectedSaveError    error
 		expectedSaveCalls    int
+		expectedUnitOfWork   int
 	}
 
 	ctx := context.TODO()
@@ -179,13 +234,14 @@ func Test_localityService_Create(t *testing.T) {
 		{
 			name: "Successfully create locality",
 			args: args{
-				ctx:                  &ctx,
+				ctx:                  ctx,
 				locality:             expectedLocality,
 				expectedExistsResult: false,
 				expectedExistsCalls:  1,
 				expectedSaveResult:   expectedLocality.ID,
 				expectedSaveError:    nil,
 				expectedSaveCalls:    1,
+				expectedUnitOfWork:   1,
 			},
 			want:    expectedLocality,
 			wantErr: nil,
@@ -193,7 +249,7 @@ func Test_localityService_Create(t *testing.T) {
 		{
 			name: "Error duplicated locality",
 			args: args{
-				ctx:                  &ctx,
+				ctx:                  ctx,
 				locality:             expectedLocality,
 				expectedExistsResult: true,
 				expectedExistsCalls:  1,
@@ -202,17 +258,18 @@ func Test_localityService_Create(t *testing.T) {
 				expectedSaveCalls:    0,
 			},
 			want:    domain.Locality{},
-			wantErr: errors.ErrConflict,
+			wantErr: ErrConflict,
 		},
 		{
 			name: "Error saving locality",
 			args: args{
-				ctx:                  &ctx,
+				ctx:                  ctx,
 				locality:             expectedLocality,
 				expectedExistsResult: false,
 				expectedExistsCalls:  1,
 				expectedSaveError:    assert.AnError,
 				expectedSaveCalls:    1,
+				expectedUnitOfWork:   1,
 			},
 			want:    domain.Locality{},
 			wantErr: assert.AnError,
@@ -224,7 +281,7 @@ func Test_localityService_Create(t *testing.T) {
 
 			localityRepositoryMock.On(
 				"Exists",
-				*tt.args.ctx,
+				tt.args.ctx,
 				mock.AnythingOfType("int"),
 			).Return(
 				tt.args.expectedExistsResult,
@@ -232,14 +289,29 @@ func Test_localityService_Create(t *testing.T) {
 
 			localityRepositoryMock.On(
 				"Save",
-				*tt.args.ctx,
+				tt.args.ctx,
 				mock.AnythingOfType("domain.Locality"),
 			).Return(
 				tt.args.expectedSaveResult,
 				tt.args.expectedSaveError,
 			)
 
-			service := NewLocalityService(localityRepositoryMock)
+			provinceRepositoryMock := provinceMocks.NewMockProvinceRepository(t)
+			provinceRepositoryMock.On("Get", tt.args.ctx, expectedLocality.ProvinceID).Return(domain.Province{
+				ID:           expectedLocality.ProvinceID,
+				ProvinceName: expectedLocality.ProvinceName,
+				CountryID:    expectedLocality.CountryID,
+			}, nil)
+
+			countryRepositoryMock := countryMocks.NewMockCountryRepository(t)
+			countryRepositoryMock.On("Get", tt.args.ctx, expectedLocality.CountryID).Return(domain.Country{
+				ID:          expectedLocality.CountryID,
+				CountryName: expectedLocality.CountryName,
+			}, nil)
+
+			unitOfWork := database_mocks.NewUnitOfWorkMock()
+
+			service := NewLocalityService(localityRepositoryMock, provinceRepositoryMock, countryRepositoryMock, unitOfWork)
 			got, err := service.Create(tt.args.ctx, tt.args.locality)
 
 			assert.Equal(t, tt.want, got)
@@ -247,20 +319,19 @@ func Test_localityService_Create(t *testing.T) {
 
 			localityRepositoryMock.AssertNumberOfCalls(t, "Exists", tt.args.expectedExistsCalls)
 			localityRepositoryMock.AssertNumberOfCalls(t, "Save", tt.args.expectedSaveCalls)
+			assert.Equal(t, tt.args.expectedUnitOfWork, unitOfWork.Calls)
 		})
 	}
 }
 
 func Test_localityService_Update(t *testing.T) {
 	type args struct {
-		ctx                   *context.Context
+		ctx                   context.Context
 		id                    int
 		updateLocalityRequest dtos.UpdateLocalityRequestDTO
 		expectedGetResult     domain.Locality
 		expectedGetError      error
 		expectedGetCalls      int
-		expectedExistsResult  bool
-		expectedExistsCalls   int
 		expectedUpdateError   error
 		expectedUpdateCalls   int
 	}
@@ -289,6 +360,8 @@ func Test_localityService_Update(t *testing.T) {
 		CountryName:  newCountryName,
 		ProvinceName: newProvinceName,
 		LocalityName: newLocalityName,
+		ProvinceID:   2,
+		CountryID:    2,
 	}
 
 	tests := []struct {
@@ -300,14 +373,12 @@ func Test_localityService_Update(t *testing.T) {
 		{
 			name: "Successfully updating all fields",
 			args: args{
-				ctx:                   &ctx,
+				ctx:                   ctx,
 				id:                    originalLocality.ID,
 				updateLocalityRequest: updateLocalityRequest,
 				expectedGetResult:     originalLocality,
 				expectedGetError:      nil,
 				expectedGetCalls:      1,
-				expectedExistsResult:  false,
-				expectedExistsCalls:   1,
 				expectedUpdateError:   nil,
 				expectedUpdateCalls:   1,
 			},
@@ -317,42 +388,25 @@ func Test_localityService_Update(t *testing.T) {
 		{
 			name: "Error locality doesn't exists",
 			args: args{
-				ctx:                   &ctx,
+				ctx:                   ctx,
 				id:                    originalLocality.ID,
 				updateLocalityRequest: updateLocalityRequest,
 				expectedGetResult:     domain.Locality{},
-				expectedGetError:      errors.ErrNotFound,
-				expectedGetCalls:      1,
-			},
-			want:    domain.Locality{},
-			wantErr: errors.ErrNotFound,
-		},
-		{
-			name: "Error duplicated locality_id",
-			args: args{
-				ctx:                   &ctx,
-				id:                    originalLocality.ID,
-				updateLocalityRequest: updateLocalityRequest,
-				expectedGetResult:     originalLocality,
-				expectedGetError:      nil,
+				expectedGetError:      ErrNotFound,
 				expectedGetCalls:      1,
-				expectedExistsResult:  true,
-				expectedExistsCalls:   1,
 			},
 			want:    domain.Locality{},
-			wantErr: errors.ErrConflict,
+			wantErr: ErrNotFound,
 		},
 		{
 			name: "Error updating locality",
 			args: args{
-				ctx:                   &ctx,
+				ctx:                   ctx,
 				id:                    originalLocality.ID,
 				updateLocalityRequest: updateLocalityRequest,
 				expectedGetResult:     originalLocality,
 				expectedGetError:      nil,
 				expectedGetCalls:      1,
-				expectedExistsResult:  false,
-				expectedExistsCalls:   1,
 				expectedUpdateError:   assert.AnError,
 				expectedUpdateCalls:   1,
 			},
@@ -363,11 +417,26 @@ func Test_localityService_Update(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			localityRepositoryMock := mocks.NewMockLocalityRepository(t)
-			service := NewLocalityService(localityRepositoryMock)
 
-			localityRepositoryMock.On("Get", *tt.args.ctx, mock.AnythingOfType("int")).Return(tt.args.expectedGetResult, tt.args.expectedGetError)
-			localityRepositoryMock.On("Exists", *tt.args.ctx, mock.AnythingOfType("int")).Return(tt.args.expectedExistsResult)
-			localityRepositoryMock.On("Update", *tt.args.ctx, mock.AnythingOfType("domain.Locality")).Return(tt.args.expectedUpdateError)
+			countryRepositoryMock := countryMocks.NewMockCountryRepository(t)
+			countryRepositoryMock.On("GetByName", tt.args.ctx, newCountryName).Return(domain.Country{
+				ID:          updatedLocality.CountryID,
+				CountryName: newCountryName,
+			}, nil)
+
+			provinceRepositoryMock := provinceMocks.NewMockProvinceRepository(t)
+			provinceRepositoryMock.On("GetByName", tt.args.ctx, newProvinceName, updatedLocality.CountryID).Return(domain.Province{
+				ID:           updatedLocality.ProvinceID,
+				ProvinceName: newProvinceName,
+				CountryID:    updatedLocality.CountryID,
+			}, nil)
+
+			service := NewLocalityService(localityRepositoryMock, provinceRepositoryMock, countryRepositoryMock, database_mocks.NewUnitOfWorkMock())
+
+			localityRepositoryMock.On("Get", tt.args.ctx, mock.AnythingOfType("int")).Return(tt.args.expectedGetResult, tt.args.expectedGetError)
+			// The locality being updated exists, as it does for every row Get finds
+			localityRepositoryMock.On("Exists", tt.args.ctx, mock.AnythingOfType("int")).Return(true).Maybe()
+			localityRepositoryMock.On("Update", tt.args.ctx, mock.AnythingOfType("domain.Locality")).Return(tt.args.expectedUpdateError)
 
 			newBuyer, err := service.Update(tt.args.ctx, tt.args.id, tt.args.updateLocalityRequest)
 
@@ -375,7 +444,7 @@ func Test_localityService_Update(t *testing.T) {
 			assert.Equal(t, tt.wantErr, err)
 
 			localityRepositoryMock.AssertNumberOfCalls(t, "Get", tt.args.expectedGetCalls)
-			localityRepositoryMock.AssertNumberOfCalls(t, "Exists", tt.args.expectedExistsCalls)
+			localityRepositoryMock.AssertNumberOfCalls(t, "Exists", 0)
 			localityRepositoryMock.AssertNumberOfCalls(t, "Update", tt.args.expectedUpdateCalls)
 
 		})
@@ -384,7 +453,7 @@ func Test_localityService_Update(t *testing.T) {
 
 func Test_localityService_Delete(t *testing.T) {
 	type args struct {
-		ctx                 *context.Context
+		ctx                 context.Context
 		id                  int
 		expectedGetResult   domain.Locality
 		expectedGetError    error
@@ -409,7 +478,7 @@ func Test_localityService_Delete(t *testing.T) {
 		{
 			name: "Successfully deleting locality",
 			args: args{
-				ctx:                 &ctx,
+				ctx:                 ctx,
 				id:                  1,
 				expectedGetResult:   expectedLocality,
 				expectedGetError:    nil,
@@ -422,7 +491,7 @@ func Test_localityService_Delete(t *testing.T) {
 		{
 			name: "Error getting locality",
 			args: args{
-				ctx:                 &ctx,
+				ctx:                 ctx,
 				id:                  1,
 				expectedGetResult:   domain.Locality{},
 				expectedGetError:    assert.AnError,
@@ -435,7 +504,7 @@ func Test_localityService_Delete(t *testing.T) {
 		{
 			name: "Error deleting locality",
 			args: args{
-				ctx:                 &ctx,
+				ctx:                 ctx,
 				id:                  1,
 				expectedGetError:    nil,
 				expectedGetCalls:    1,
@@ -451,8 +520,8 @@ func Test_localityService_Delete(t *testing.T) {
 			localityRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(tt.args.expectedGetResult, tt.args.expectedGetError)
 			localityRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(tt.args.expectedDeleteError)
 
-			service := NewLocalityService(localityRepositoryMock)
-			err := service.Delete(&ctx, tt.args.id)
+			service := NewLocalityService(localityRepositoryMock, provinceMocks.NewMockProvinceRepository(t), countryMocks.NewMockCountryRepository(t), database_mocks.NewUnitOfWorkMock())
+			err := service.Delete(ctx, tt.args.id)
 
 			assert.Equal(t, tt.wantErr, err)
 			localityRepositoryMock.AssertNumberOfCalls(t, "Get", tt.args.expectedGetCalls)
@@ -463,43 +532,45 @@ func Test_localityService_Delete(t *testing.T) {
 
 func Test_localityService_CountSellers(t *testing.T) {
 	type args struct {
-		ctx                        *context.Context
+		ctx                        context.Context
 		id                         int
-		expectedCountSellersResult int
+		expectedCountSellersResult dtos.GetNumberOfSellersResponseDTO
 		expectedCountSellersError  error
 		expectedCountSellersCalls  int
 	}
 
 	ctx := context.TODO()
 
+	report := dtos.GetNumberOfSellersResponseDTO{LocalityID: 1, LocalityName: "Lujan", SellersCount: 3}
+
 	tests := []struct {
 		name    string
 		args    args
-		want    int
+		want    dtos.GetNumberOfSellersResponseDTO
 		wantErr error
 	}{
 		{
 			name: "Successfully count sellers",
 			args: args{
-				ctx:                        &ctx,
+				ctx:                        ctx,
 				id:                         1,
-				expectedCountSellersResult: 1,
+				expectedCountSellersResult: report,
 				expectedCountSellersError:  nil,
 				expectedCountSellersCalls:  1,
 			},
-			want:    1,
+			want:    report,
 			wantErr: nil,
 		},
 		{
 			name: "Error count sellers",
 			args: args{
-				ctx:                        &ctx,
+				ctx:                        ctx,
 				id:                         1,
-				expectedCountSellersResult: 0,
+				expectedCountSellersResult: dtos.GetNumberOfSellersResponseDTO{},
 				expectedCountSellersError:  assert.AnError,
 				expectedCountSellersCalls:  1,
 			},
-			want:    0,
+			want:    dtos.GetNumberOfSellersResponseDTO{},
 			wantErr: assert.AnError,
 		},
 	}
@@ -508,7 +579,7 @@ func Test_localityService_CountSellers(t *testing.T) {
 			localityRepositoryMock := mocks.NewMockLocalityRepository(t)
 			localityRepositoryMock.On("CountSellers", ctx, mock.AnythingOfType("int")).Return(tt.args.expectedCountSellersResult, tt.args.expectedCountSellersError)
 
-			service := NewLocalityService(localityRepositoryMock)
+			service := NewLocalityService(localityRepositoryMock, provinceMocks.NewMockProvinceRepository(t), countryMocks.NewMockCountryRepository(t), database_mocks.NewUnitOfWorkMock())
 			got, err := service.CountSellers(tt.args.ctx, tt.args.id)
 
 			assert.Equal(t, tt.want, got)
@@ -519,3 +590,236 @@ func Test_localityService_CountSellers(t *testing.T) {
 		})
 	}
 }
+
+func Test_localityService_CountAllSellers(t *testing.T) {
+	ctx := context.TODO()
+
+	reports := []dtos.GetNumberOfSellersResponseDTO{
+		{LocalityID: 6700, LocalityName: "Lujan", SellersCount: 3},
+		{LocalityID: 7600, LocalityName: "Rio de Janeiro", SellersCount: 1},
+	}
+
+	tests := []struct {
+		name                          string
+		order                         string
+		expectedCountAllSellersResult []dtos.GetNumberOfSellersResponseDTO
+		expectedCountAllSellersError  error
+		want                          []dtos.GetNumberOfSellersResponseDTO
+		wantErr                       error
+	}{
+		{
+			name:                          "Successfully count sellers of every locality",
+			order:                         OrderDesc,
+			expectedCountAllSellersResult: reports,
+			want:                          reports,
+		},
+		{
+			name:                          "Error counting sellers",
+			expectedCountAllSellersResult: []dtos.GetNumberOfSellersResponseDTO{},
+			expectedCountAllSellersError:  assert.AnError,
+			want:                          []dtos.GetNumberOfSellersResponseDTO{},
+			wantErr:                       assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			localityRepositoryMock := mocks.NewMockLocalityRepository(t)
+			localityRepositoryMock.On("CountAllSellers", ctx, tt.order).Return(tt.expectedCountAllSellersResult, tt.expectedCountAllSellersError)
+
+			service := NewLocalityService(localityRepositoryMock, provinceMocks.NewMockProvinceRepository(t), countryMocks.NewMockCountryRepository(t), database_mocks.NewUnitOfWorkMock())
+			got, err := service.CountAllSellers(ctx, tt.order)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+
+			localityRepositoryMock.AssertNumberOfCalls(t, "CountAllSellers", 1)
+		})
+	}
+}
+
+func Test_localityService_CreateResolvingProvince(t *testing.T) {
+	ctx := context.TODO()
+
+	locality := domain.Locality{
+		ID:           7600,
+		CountryName:  "Brasil",
+		ProvinceName: "Rio de Janeiro",
+		LocalityName: "Rio de Janeiro",
+	}
+
+	t.Run("Create country and province by name", func(t *testing.T) {
+		localityRepositoryMock := mocks.NewMockLocalityRepository(t)
+		localityRepositoryMock.On("Exists", ctx, locality.ID).Return(false)
+		localityRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.Locality")).Return(locality.ID, nil)
+
+		countryRepositoryMock := countryMocks.NewMockCountryRepository(t)
+		countryRepositoryMock.On("GetByName", ctx, "Brasil").Return(domain.Country{}, country.ErrNotFound)
+		countryRepositoryMock.On("Save", ctx, domain.Country{CountryName: "Brasil"}).Return(2, nil)
+
+		provinceRepositoryMock := provinceMocks.NewMockProvinceRepository(t)
+		provinceRepositoryMock.On("GetByName", ctx, "Rio de Janeiro", 2).Return(domain.Province{}, province.ErrNotFound)
+		provinceRepositoryMock.On("Save", ctx, domain.Province{ProvinceName: "Rio de Janeiro", CountryID: 2}).Return(3, nil)
+
+		service := NewLocalityService(localityRepositoryMock, provinceRepositoryMock, countryRepositoryMock, database_mocks.NewUnitOfWorkMock())
+		got, err := service.Create(ctx, locality)
+
+		want := locality
+		want.ProvinceID = 3
+		want.CountryID = 2
+
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+		localityRepositoryMock.AssertCalled(t, "Save", ctx, want)
+	})
+
+	t.Run("Reuse existing country given by id", func(t *testing.T) {
+		request := locality
+		request.CountryName = ""
+		request.CountryID = 2
+
+		localityRepositoryMock := mocks.NewMockLocalityRepository(t)
+		localityRepositoryMock.On("Exists", ctx, locality.ID).Return(false)
+		localityRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.Locality")).Return(locality.ID, nil)
+
+		countryRepositoryMock := countryMocks.NewMockCountryRepository(t)
+		countryRepositoryMock.On("Get", ctx, 2).Return(domain.Country{ID: 2, CountryName: "Brasil"}, nil)
+
+		provinceRepositoryMock := provinceMocks.NewMockProvinceRepository(t)
+		provinceRepositoryMock.On("GetByName", ctx, "Rio de Janeiro", 2).Return(domain.Province{ID: 3, ProvinceName: "Rio de Janeiro", CountryID: 2}, nil)
+
+		service := NewLocalityService(localityRepositoryMock, provinceRepositoryMock, countryRepositoryMock, database_mocks.NewUnitOfWorkMock())
+		got, err := service.Create(ctx, request)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, got.ProvinceID)
+		assert.Equal(t, "Brasil", got.CountryName)
+		countryRepositoryMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		provinceRepositoryMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error province id not found", func(t *testing.T) {
+		request := locality
+		request.ProvinceID = 999
+
+		localityRepositoryMock := mocks.NewMockLocalityRepository(t)
+		localityRepositoryMock.On("Exists", ctx, locality.ID).Return(false)
+
+		provinceRepositoryMock := provinceMocks.NewMockProvinceRepository(t)
+		provinceRepositoryMock.On("Get", ctx, 999).Return(domain.Province{}, province.ErrNotFound)
+
+		service := NewLocalityService(localityRepositoryMock, provinceRepositoryMock, countryMocks.NewMockCountryRepository(t), database_mocks.NewUnitOfWorkMock())
+		got, err := service.Create(ctx, request)
+
+		assert.Equal(t, domain.Locality{}, got)
+		assert.Equal(t, province.ErrNotFound, err)
+		localityRepositoryMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func Test_localityService_GetAllByProvinceID(t *testing.T) {
+	ctx := context.TODO()
+
+	var expectedLocalities []domain.Locality
+	expectedLocalitiesSerialized, _ := os.ReadFile("../../test/resources/valid_localities.json")
+	if err := json.Unmarshal(expectedLocalitiesSerialized, &expectedLocalities); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                 string
+		expectedExistsResult bool
+		expectedGetAllResult []domain.Locality
+		expectedGetAllError  error
+		expectedGetAllCalls  int
+		want                 []domain.Locality
+		wantErr              error
+	}{
+		{
+			name:                 "Successfully get localities of province",
+			expectedExistsResult: true,
+			expectedGetAllResult: expectedLocalities[:1],
+			expectedGetAllCalls:  1,
+			want:                 expectedLocalities[:1],
+		},
+		{
+			name:                 "Error province not found",
+			expectedExistsResult: false,
+			want:                 []domain.Locality{},
+			wantErr:              province.ErrNotFound,
+		},
+		{
+			name:                 "Error getting localities",
+			expectedExistsResult: true,
+			expectedGetAllError:  assert.AnError,
+			expectedGetAllCalls:  1,
+			want:                 []domain.Locality{},
+			wantErr:              assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			localityRepositoryMock := mocks.NewMockLocalityRepository(t)
+			localityRepositoryMock.On("GetAllByProvinceID", ctx, 1).Return(tt.expectedGetAllResult, tt.expectedGetAllError)
+
+			provinceRepositoryMock := provinceMocks.NewMockProvinceRepository(t)
+			provinceRepositoryMock.On("Exists", ctx, 1).Return(tt.expectedExistsResult)
+
+			service := NewLocalityService(localityRepositoryMock, provinceRepositoryMock, countryMocks.NewMockCountryRepository(t), database_mocks.NewUnitOfWorkMock())
+			got, err := service.GetAllByProvinceID(ctx, 1)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+			localityRepositoryMock.AssertNumberOfCalls(t, "GetAllByProvinceID", tt.expectedGetAllCalls)
+		})
+	}
+}
+
+func Test_localityService_GetAllByCountryID(t *testing.T) {
+	ctx := context.TODO()
+
+	var expectedLocalities []domain.Locality
+	expectedLocalitiesSerialized, _ := os.ReadFile("../../test/resources/valid_localities.json")
+	if err := json.Unmarshal(expectedLocalitiesSerialized, &expectedLocalities); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                 string
+		expectedExistsResult bool
+		expectedGetAllResult []domain.Locality
+		expectedGetAllError  error
+		expectedGetAllCalls  int
+		want                 []domain.Locality
+		wantErr              error
+	}{
+		{
+			name:                 "Successfully get localities of country",
+			expectedExistsResult: true,
+			expectedGetAllResult: expectedLocalities[1:],
+			expectedGetAllCalls:  1,
+			want:                 expectedLocalities[1:],
+		},
+		{
+			name:                 "Error country not found",
+			expectedExistsResult: false,
+			want:                 []domain.Locality{},
+			wantErr:              country.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			localityRepositoryMock := mocks.NewMockLocalityRepository(t)
+			localityRepositoryMock.On("GetAllByCountryID", ctx, 2).Return(tt.expectedGetAllResult, tt.expectedGetAllError)
+
+			countryRepositoryMock := countryMocks.NewMockCountryRepository(t)
+			countryRepositoryMock.On("Exists", ctx, 2).Return(tt.expectedExistsResult)
+
+			service := NewLocalityService(localityRepositoryMock, provinceMocks.NewMockProvinceRepository(t), countryRepositoryMock, database_mocks.NewUnitOfWorkMock())
+			got, err := service.GetAllByCountryID(ctx, 2)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+			localityRepositoryMock.AssertNumberOfCalls(t, "GetAllByCountryID", tt.expectedGetAllCalls)
+		})
+	}
+}
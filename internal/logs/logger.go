@@ -0,0 +1,115 @@
+package logs
+
+import (
+	"context"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+)
+
+// flushTimeout bounds how long a single batch insert may take.
+const flushTimeout = 5 * time.Second
+
+// Logger buffers log entries in memory and persists them in batches from a background goroutine,
+// so callers never wait on the database. Entries are dropped when the buffer is full.
+type Logger struct {
+	repository    LogRepository
+	entries       chan domain.Log
+	batchSize     int
+	flushInterval time.Duration
+	dropped       uint64
+	mu            sync.RWMutex
+	closed        bool
+	done          chan struct{}
+}
+
+// NewLogger starts the background writer. A batch is written when it reaches batchSize entries
+// or every flushInterval, whichever happens first.
+func NewLogger(r LogRepository, bufferSize int, batchSize int, flushInterval time.Duration) *Logger {
+	logger := &Logger{
+		repository:    r,
+		entries:       make(chan domain.Log, bufferSize),
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+		done:          make(chan struct{}),
+	}
+
+	go logger.run()
+
+	return logger
+}
+
+// Log enqueues the entry without blocking. It is a no-op after Close.
+func (l *Logger) Log(entry domain.Log) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if l.closed {
+		return
+	}
+
+	select {
+	case l.entries <- entry:
+	default:
+		atomic.AddUint64(&l.dropped, 1)
+	}
+}
+
+// Close stops accepting entries and blocks until the buffered ones are persisted.
+func (l *Logger) Close() {
+	l.mu.Lock()
+	if !l.closed {
+		l.closed = true
+		close(l.entries)
+	}
+	l.mu.Unlock()
+
+	<-l.done
+}
+
+func (l *Logger) run() {
+	defer close(l.done)
+
+	ticker := time.NewTicker(l.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]domain.Log, 0, l.batchSize)
+	for {
+		select {
+		case entry, ok := <-l.entries:
+			if !ok {
+				l.flush(batch)
+				return
+			}
+
+			batch = append(batch, entry)
+			if len(batch) >= l.batchSize {
+				batch = l.flush(batch)
+			}
+		case <-ticker.C:
+			batch = l.flush(batch)
+		}
+	}
+}
+
+func (l *Logger) flush(batch []domain.Log) []domain.Log {
+	if dropped := atomic.SwapUint64(&l.dropped, 0); dropped > 0 {
+		log.Printf("logs: buffer full, dropped %d entries", dropped)
+	}
+
+	if len(batch) == 0 {
+		return batch
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+
+	if err := l.repository.SaveAll(ctx, batch); err != nil {
+		log.Printf("logs: could not save %d entries: %v", len(batch), err)
+	}
+
+	return batch[:0]
+}
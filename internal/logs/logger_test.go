@@ -0,0 +1,62 @@
+package logs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/logs/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLogger_FlushesFullBatch(t *testing.T) {
+	saved := make(chan []domain.Log, 1)
+
+	logRepositoryMock := mocks.NewMockLogRepository(t)
+	logRepositoryMock.On("SaveAll", mock.Anything, mock.AnythingOfType("[]domain.Log")).
+		Run(func(args mock.Arguments) {
+			batch := args.Get(1).([]domain.Log)
+			saved <- append([]domain.Log{}, batch...)
+		}).
+		Return(nil)
+
+	logger := NewLogger(logRepositoryMock, 10, 2, time.Hour)
+	defer logger.Close()
+
+	logger.Log(validLogs[0])
+	logger.Log(validLogs[1])
+
+	select {
+	case batch := <-saved:
+		assert.Equal(t, validLogs, batch)
+	case <-time.After(time.Second):
+		t.Fatal("batch was not saved")
+	}
+}
+
+func TestLogger_CloseFlushesPendingEntries(t *testing.T) {
+	logRepositoryMock := mocks.NewMockLogRepository(t)
+	logRepositoryMock.On("SaveAll", mock.Anything, []domain.Log{validLogs[0]}).Return(nil)
+
+	logger := NewLogger(logRepositoryMock, 10, 100, time.Hour)
+	logger.Log(validLogs[0])
+	logger.Close()
+
+	logRepositoryMock.AssertNumberOfCalls(t, "SaveAll", 1)
+
+	// Logging after Close must neither panic nor reach the repository.
+	logger.Log(validLogs[1])
+	logger.Close()
+	logRepositoryMock.AssertNumberOfCalls(t, "SaveAll", 1)
+}
+
+func TestLogger_DropsWhenBufferIsFull(t *testing.T) {
+	logger := &Logger{entries: make(chan domain.Log, 1)}
+
+	logger.Log(validLogs[0])
+	logger.Log(validLogs[1])
+
+	assert.Len(t, logger.entries, 1)
+	assert.Equal(t, uint64(1), logger.dropped)
+}
@@ -0,0 +1,148 @@
+package logs
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/log"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
+)
+
+type LogRepository interface {
+	GetPage(ctx context.Context, filter dtos.GetLogsRequestDTO, options listing.Options) ([]domain.Log, int, error)
+	SaveAll(ctx context.Context, logs []domain.Log) error
+}
+
+const (
+	GetAllLogs   = "SELECT logs.id, logs.method, logs.label, logs.level, logs.message, logs.status, logs.insert_date FROM logs"
+	CountLogs    = "SELECT COUNT(*) FROM logs"
+	SaveLogs     = "INSERT INTO logs(method, label, level, message, status, insert_date) VALUES "
+	saveLogValue = "(?,?,?,?,?,?)"
+)
+
+// Fields are the log fields list requests can sort and filter by, the level and date window have their own filters.
+var Fields = listing.Fields{
+	"id":          "logs.id",
+	"method":      "logs.method",
+	"status":      "logs.status",
+	"insert_date": "logs.insert_date",
+}
+
+type logRepository struct {
+	db *sql.DB
+}
+
+func NewLogRepository(db *sql.DB) LogRepository {
+	return &logRepository{
+		db: db,
+	}
+}
+
+// GetPage returns a page of the logs matching every filter that is set, along with the count of all of them.
+func (r *logRepository) GetPage(ctx context.Context, filter dtos.GetLogsRequestDTO, options listing.Options) ([]domain.Log, int, error) {
+	options.Filters = append(filters(filter), options.Filters...)
+
+	var total int
+	query, args := options.CountQuery(CountLogs)
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return make([]domain.Log, 0), 0, err
+	}
+
+	logs := make([]domain.Log, 0)
+	query, args = options.Query(GetAllLogs)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return logs, 0, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		log := domain.Log{}
+		err := rows.Scan(&log.ID, &log.Method, &log.Label, &log.Level, &log.Message, &log.Status, dateTime{&log.InsertDate})
+		if err != nil {
+			return logs, 0, err
+		}
+
+		logs = append(logs, log)
+	}
+	if err := rows.Err(); err != nil {
+		return logs, 0, err
+	}
+
+	return logs, total, nil
+}
+
+// filters turns the log filters that are set into listing filters, dates compare as DATETIME text in UTC.
+func filters(filter dtos.GetLogsRequestDTO) []listing.Filter {
+	filters := make([]listing.Filter, 0)
+	if filter.Level != "" {
+		filters = append(filters, listing.Filter{Column: "logs.level", Value: filter.Level})
+	}
+	if filter.StatusFrom != 0 {
+		filters = append(filters, listing.Filter{Column: "logs.status", Operator: listing.OperatorFrom, Value: strconv.Itoa(filter.StatusFrom)})
+	}
+	if filter.StatusTo != 0 {
+		filters = append(filters, listing.Filter{Column: "logs.status", Operator: listing.OperatorTo, Value: strconv.Itoa(filter.StatusTo)})
+	}
+	if !filter.From.IsZero() {
+		filters = append(filters, listing.Filter{Column: "logs.insert_date", Operator: listing.OperatorFrom, Value: filter.From.UTC().Format(dateTimeLayout)})
+	}
+	if !filter.To.IsZero() {
+		filters = append(filters, listing.Filter{Column: "logs.insert_date", Operator: listing.OperatorTo, Value: filter.To.UTC().Format(dateTimeLayout)})
+	}
+	return filters
+}
+
+// SaveAll inserts the logs with a single multi-row statement.
+func (r *logRepository) SaveAll(ctx context.Context, logs []domain.Log) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(logs))
+	args := make([]interface{}, 0, len(logs)*6)
+	for _, log := range logs {
+		values = append(values, saveLogValue)
+		args = append(args, log.Method, log.Label, log.Level, log.Message, log.Status, log.InsertDate)
+	}
+
+	_, err := r.db.ExecContext(ctx, SaveLogs+strings.Join(values, ","), args...)
+	return database.TranslateError(err, "log")
+}
+
+// dateTimeLayout is the text format of DATETIME columns when the DSN does not set parseTime.
+const dateTimeLayout = "2006-01-02 15:04:05.999999999"
+
+// dateTime scans a DATETIME column into a time.Time whether or not the driver parses times.
+type dateTime struct {
+	target *time.Time
+}
+
+func (d dateTime) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case time.Time:
+		*d.target = value
+		return nil
+	case []byte:
+		return d.parse(string(value))
+	case string:
+		return d.parse(value)
+	default:
+		return fmt.Errorf("unsupported DATETIME value %T", src)
+	}
+}
+
+func (d dateTime) parse(value string) error {
+	parsed, err := time.Parse(dateTimeLayout, value)
+	if err != nil {
+		return err
+	}
+	*d.target = parsed
+	return nil
+}
@@ -0,0 +1,132 @@
+package logs
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/log"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
+	"github.com/stretchr/testify/assert"
+)
+
+var validLogs = []domain.Log{
+	{
+		ID:         2,
+		Method:     "POST",
+		Label:      "/api/v1/sections/",
+		Level:      domain.LogLevelError,
+		Message:    "Internal Server Error",
+		Status:     500,
+		InsertDate: time.Date(2023, 7, 5, 17, 0, 0, 0, time.UTC),
+	},
+	{
+		ID:         1,
+		Method:     "GET",
+		Label:      "/api/v1/sections/:id",
+		Level:      domain.LogLevelInfo,
+		Message:    "OK",
+		Status:     200,
+		InsertDate: time.Date(2023, 7, 5, 16, 0, 0, 0, time.UTC),
+	},
+}
+
+func Test_logRepository_GetPage(t *testing.T) {
+	from := time.Date(2023, 7, 5, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 7, 6, 0, 0, 0, 0, time.UTC)
+
+	options, err := listing.Parse(url.Values{"limit": {"2"}, "offset": {"4"}}, Fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		filter        dtos.GetLogsRequestDTO
+		expectedWhere string
+		expectedArgs  []driver.Value
+		rowErr        bool
+		want          []domain.Log
+		wantTotal     int
+		wantErr       bool
+	}{
+		{
+			name:      "Successfully get a page of logs",
+			filter:    dtos.GetLogsRequestDTO{},
+			want:      validLogs,
+			wantTotal: 6,
+		},
+		{
+			name:          "Successfully get logs with every filter",
+			filter:        dtos.GetLogsRequestDTO{Level: domain.LogLevelError, StatusFrom: 500, StatusTo: 599, From: from, To: to},
+			expectedWhere: " WHERE logs.level = ? AND logs.status >= ? AND logs.status <= ? AND logs.insert_date >= ? AND logs.insert_date <= ?",
+			expectedArgs:  []driver.Value{domain.LogLevelError, "500", "599", "2023-07-05 00:00:00", "2023-07-06 00:00:00"},
+			want:          validLogs,
+			wantTotal:     6,
+		},
+		{
+			name:    "Error getting logs",
+			filter:  dtos.GetLogsRequestDTO{},
+			rowErr:  true,
+			want:    []domain.Log{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			r := NewLogRepository(db)
+
+			rows := sqlmock.NewRows([]string{"id", "method", "label", "level", "message", "status", "insert_date"})
+			for i, log := range validLogs {
+				// The driver returns DATETIME columns as text unless the DSN sets parseTime.
+				var insertDate interface{} = log.InsertDate
+				if i%2 == 1 {
+					insertDate = []byte(log.InsertDate.Format("2006-01-02 15:04:05.000000"))
+				}
+				rows.AddRow(log.ID, log.Method, log.Label, log.Level, log.Message, log.Status, insertDate)
+			}
+			if tt.rowErr {
+				rows.RowError(0, sql.ErrNoRows)
+			}
+
+			mock.ExpectQuery("^" + regexp.QuoteMeta(CountLogs+tt.expectedWhere) + "$").
+				WithArgs(tt.expectedArgs...).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(6))
+			mock.ExpectQuery("^" + regexp.QuoteMeta(GetAllLogs+tt.expectedWhere+" ORDER BY logs.id ASC LIMIT ? OFFSET ?") + "$").
+				WithArgs(append(tt.expectedArgs, 2, 4)...).
+				WillReturnRows(rows)
+
+			got, total, err := r.GetPage(context.TODO(), tt.filter, options)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantTotal, total)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_logRepository_SaveAll(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	r := NewLogRepository(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(SaveLogs+saveLogValue+","+saveLogValue)).
+		WithArgs(
+			validLogs[0].Method, validLogs[0].Label, validLogs[0].Level, validLogs[0].Message, validLogs[0].Status, validLogs[0].InsertDate,
+			validLogs[1].Method, validLogs[1].Label, validLogs[1].Level, validLogs[1].Message, validLogs[1].Status, validLogs[1].InsertDate,
+		).
+		WillReturnResult(sqlmock.NewResult(2, 2))
+
+	err := r.SaveAll(context.TODO(), validLogs)
+
+	assert.NoError(t, err)
+	assert.NoError(t, r.SaveAll(context.TODO(), nil))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,32 @@
+package logs
+
+import (
+	"context"
+
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/log"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
+)
+
+type LogService interface {
+	GetPage(ctx context.Context, filter dtos.GetLogsRequestDTO, options listing.Options) ([]domain.Log, int, error)
+}
+
+type logService struct {
+	logRepository LogRepository
+}
+
+func NewLogService(r LogRepository) LogService {
+	return &logService{
+		logRepository: r,
+	}
+}
+
+func (service *logService) GetPage(ctx context.Context, filter dtos.GetLogsRequestDTO, options listing.Options) ([]domain.Log, int, error) {
+	logs, total, err := service.logRepository.GetPage(ctx, filter, options)
+	if err != nil {
+		return []domain.Log{}, 0, err
+	}
+
+	return logs, total, nil
+}
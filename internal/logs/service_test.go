@@ -0,0 +1,55 @@
+package logs
+
+import (
+	"context"
+	"testing"
+
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/log"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/logs/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_logService_GetPage(t *testing.T) {
+	ctx := context.TODO()
+	filter := dtos.GetLogsRequestDTO{Level: domain.LogLevelError}
+	options := listing.Options{Limit: listing.DefaultLimit}
+
+	tests := []struct {
+		name                 string
+		expectedGetAllResult []domain.Log
+		expectedGetAllError  error
+		want                 []domain.Log
+		wantTotal            int
+		wantErr              error
+	}{
+		{
+			name:                 "Successfully get all",
+			expectedGetAllResult: validLogs,
+			want:                 validLogs,
+			wantTotal:            len(validLogs),
+			wantErr:              nil,
+		},
+		{
+			name:                 "Error getting all",
+			expectedGetAllResult: []domain.Log{},
+			expectedGetAllError:  assert.AnError,
+			want:                 []domain.Log{},
+			wantErr:              assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logRepositoryMock := mocks.NewMockLogRepository(t)
+			logRepositoryMock.On("GetPage", ctx, filter, options).Return(tt.expectedGetAllResult, tt.wantTotal, tt.expectedGetAllError)
+
+			service := NewLogService(logRepositoryMock)
+			got, total, err := service.GetPage(ctx, filter, options)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantTotal, total)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
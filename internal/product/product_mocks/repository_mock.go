@@ -4,6 +4,7 @@ import (
 	context "context"
 
 	domain "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	listing "github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -26,6 +27,32 @@ func (_m *ProductRepositoryMock) Delete(ctx context.Context, id int) error {
 	return r0
 }
 
+// Dependents provides a mock function with given fields: ctx, id
+func (_m *ProductRepositoryMock) Dependents(ctx context.Context, id int) (map[string]int, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (map[string]int, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) map[string]int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Exists provides a mock function with given fields: ctx, productCode
 func (_m *ProductRepositoryMock) Exists(ctx context.Context, productCode string) bool {
 	ret := _m.Called(ctx, productCode)
@@ -103,6 +130,46 @@ func (_m *ProductRepositoryMock) GetAll(ctx context.Context) ([]domain.Product,
 	return r0, r1
 }
 
+// GetPage provides a mock function with given fields: ctx, options
+func (_m *ProductRepositoryMock) GetPage(ctx context.Context, options listing.Options) ([]domain.Product, int, error) {
+	ret := _m.Called(ctx, options)
+
+	var r0 []domain.Product
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]domain.Product)
+	}
+
+	return r0, ret.Int(1), ret.Error(2)
+}
+
+// Each provides a mock function with given fields: ctx, options, fn
+func (_m *ProductRepositoryMock) Each(ctx context.Context, options listing.Options, fn func(domain.Product) error) error {
+	ret := _m.Called(ctx, options, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options, func(domain.Product) error) error); ok {
+		r0 = rf(ctx, options, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (_m *ProductRepositoryMock) Restore(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Save provides a mock function with given fields: ctx, p
 func (_m *ProductRepositoryMock) Save(ctx context.Context, p domain.Product) (int, error) {
 	ret := _m.Called(ctx, p)
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -11,32 +12,61 @@ type ProductServiceMock struct {
 	mock.Mock
 }
 
-func (service *ProductServiceMock) Get(ctx *context.Context, id int) (*domain.Product, error) {
+func (service *ProductServiceMock) Get(ctx context.Context, id int) (*domain.Product, error) {
 	args := service.Called(ctx, id)
 
 	return args.Get(0).(*domain.Product), args.Error(1)
 }
 
-func (service *ProductServiceMock) GetAll(ctx *context.Context) (*[]domain.Product, error) {
+func (service *ProductServiceMock) GetAll(ctx context.Context) (*[]domain.Product, error) {
 	args := service.Called(ctx)
 
 	return args.Get(0).(*[]domain.Product), args.Error(1)
 }
 
-func (service *ProductServiceMock) Save(ctx *context.Context, description string, expiration_rate, freezing_rate int, height, length, netweight float32, product_code string,
+func (service *ProductServiceMock) GetPage(ctx context.Context, options listing.Options) (*[]domain.Product, int, error) {
+	args := service.Called(ctx, options)
+
+	return args.Get(0).(*[]domain.Product), args.Int(1), args.Error(2)
+}
+
+func (service *ProductServiceMock) Each(ctx context.Context, options listing.Options, fn func(domain.Product) error) error {
+	args := service.Called(ctx, options)
+
+	for _, product := range args.Get(0).([]domain.Product) {
+		if err := fn(product); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (service *ProductServiceMock) Save(ctx context.Context, description string, expiration_rate, freezing_rate int, height, length, netweight float32, product_code string,
 	recommended_freezing_temperature, width float32, product_type_id, seller_id int) (*domain.Product, error) {
 	args := service.Called(ctx, description, expiration_rate, freezing_rate, height, length, netweight, product_code, recommended_freezing_temperature, width, product_type_id, seller_id)
 	return args.Get(0).(*domain.Product), args.Error(1)
 }
 
-func (service *ProductServiceMock) Update(ctx *context.Context, description *string, expiration_rate, freezing_rate *int, height, length, netweight *float32, product_code *string,
+func (service *ProductServiceMock) Update(ctx context.Context, description *string, expiration_rate, freezing_rate *int, height, length, netweight *float32, product_code *string,
 	recommended_freezing_temperature, width *float32, product_type_id, seller_id *int, id int) (*domain.Product, error) {
 	args := service.Called(ctx, description, expiration_rate, freezing_rate, height, length, netweight, product_code, recommended_freezing_temperature, width, product_type_id, seller_id)
 	return args.Get(0).(*domain.Product), args.Error(1)
 }
 
-func (service *ProductServiceMock) Delete(ctx *context.Context, id int) error {
+func (service *ProductServiceMock) Delete(ctx context.Context, id int) error {
 	args := service.Called(ctx, id)
 
 	return args.Error(0)
 }
+
+func (service *ProductServiceMock) Restore(ctx context.Context, id int) (*domain.Product, error) {
+	args := service.Called(ctx, id)
+
+	return args.Get(0).(*domain.Product), args.Error(1)
+}
+
+func (service *ProductServiceMock) DeletePreview(ctx context.Context, id int) (*domain.DeletePreview, error) {
+	args := service.Called(ctx, id)
+
+	return args.Get(0).(*domain.DeletePreview), args.Error(1)
+}
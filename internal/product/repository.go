@@ -4,20 +4,48 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 )
 
 // Repository encapsulates the storage of a Product.
 type Repository interface {
 	GetAll(ctx context.Context) ([]domain.Product, error)
+	GetPage(ctx context.Context, options listing.Options) ([]domain.Product, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.Product) error) error
 	Get(ctx context.Context, id int) (domain.Product, error)
 	Exists(ctx context.Context, productCode string) bool
 	Save(ctx context.Context, p domain.Product) (int, error)
 	Update(ctx context.Context, p domain.Product) error
 	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) error
+	Dependents(ctx context.Context, id int) (map[string]int, error)
 	ExistsByID(ctx context.Context, id int) bool
 }
 
+// Fields are the product fields list requests can sort and filter by.
+var Fields = listing.Fields{
+	"id":                               "id",
+	"description":                      "description",
+	"expiration_rate":                  "expiration_rate",
+	"freezing_rate":                    "freezing_rate",
+	"height":                           "height",
+	"length":                           "length",
+	"net_weight":                       "net_weight",
+	"product_code":                     "product_code",
+	"recommended_freezing_temperature": "recommended_freezing_temperature",
+	"width":                            "width",
+	"product_type_id":                  "product_type_id",
+	"seller_id":                        "seller_id",
+}
+
+// dependents are the columns referencing a product, which block its deletion.
+// Product records are history and keep pointing to the soft-deleted product, so they do not block it.
+var dependents = []database.Dependent{
+	{Table: "product_batches", Column: "product_id"},
+}
+
 type repository struct {
 	db *sql.DB
 }
@@ -29,8 +57,8 @@ func NewRepository(db *sql.DB) Repository {
 }
 
 func (r *repository) GetAll(ctx context.Context) ([]domain.Product, error) {
-	query := "SELECT id, description,expiration_rate,freezing_rate,height,length,net_weight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id FROM products;"
-	rows, err := r.db.Query(query)
+	query := "SELECT id, description,expiration_rate,freezing_rate,height,length,net_weight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id,deleted_at FROM products WHERE deleted_at IS NULL;"
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -39,20 +67,59 @@ func (r *repository) GetAll(ctx context.Context) ([]domain.Product, error) {
 
 	for rows.Next() {
 		p := domain.Product{}
-		_ = rows.Scan(&p.ID, &p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, &p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID)
+		_ = rows.Scan(&p.ID, &p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, &p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID, &p.DeletedAt)
 		products = append(products, p)
 	}
 
 	return products, nil
 }
 
+func (r *repository) GetPage(ctx context.Context, options listing.Options) ([]domain.Product, int, error) {
+	var total int
+	query, args := options.CountQuery("SELECT COUNT(*) FROM products")
+	if err := database.Conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	products := make([]domain.Product, 0)
+	err := r.Each(ctx, options, func(p domain.Product) error {
+		products = append(products, p)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return products, total, nil
+}
+
+func (r *repository) Each(ctx context.Context, options listing.Options, fn func(domain.Product) error) error {
+	query, args := options.Query("SELECT id, description,expiration_rate,freezing_rate,height,length,net_weight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id,deleted_at FROM products")
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p := domain.Product{}
+		if err := rows.Scan(&p.ID, &p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, &p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID, &p.DeletedAt); err != nil {
+			return err
+		}
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 func (r *repository) Get(ctx context.Context, id int) (domain.Product, error) {
-	query := "SELECT id, description,expiration_rate,freezing_rate,height,length,net_weight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id FROM products WHERE id=?;"
-	row := r.db.QueryRow(query, id)
+	query := "SELECT id, description,expiration_rate,freezing_rate,height,length,net_weight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id,deleted_at FROM products WHERE id=? AND deleted_at IS NULL;"
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, query, id)
 	p := domain.Product{}
-	err := row.Scan(&p.ID, &p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, &p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID)
+	err := row.Scan(&p.ID, &p.Description, &p.ExpirationRate, &p.FreezingRate, &p.Height, &p.Length, &p.Netweight, &p.ProductCode, &p.RecomFreezTemp, &p.Width, &p.ProductTypeID, &p.SellerID, &p.DeletedAt)
 	if err != nil {
-		return domain.Product{}, err
+		return domain.Product{}, database.TranslateError(err, "product")
 	}
 
 	return p, nil
@@ -60,28 +127,28 @@ func (r *repository) Get(ctx context.Context, id int) (domain.Product, error) {
 
 func (r *repository) Exists(ctx context.Context, productCode string) bool {
 	query := "SELECT product_code FROM products WHERE product_code=?;"
-	row := r.db.QueryRow(query, productCode)
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, query, productCode)
 	err := row.Scan(&productCode)
 	return err == nil
 }
 
 func (r *repository) ExistsByID(ctx context.Context, id int) bool {
-	query := "SELECT id FROM products WHERE id=?;"
-	row := r.db.QueryRow(query, id)
+	query := "SELECT id FROM products WHERE id=? AND deleted_at IS NULL;"
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, query, id)
 	err := row.Scan(&id)
 	return err == nil
 }
 
 func (r *repository) Save(ctx context.Context, p domain.Product) (int, error) {
 	query := "INSERT INTO products(description, expiration_rate, freezing_rate, height, length, net_weight, product_code, recommended_freezing_temperature,width, product_type_id, seller_id) VALUES (?,?,?,?,?,?,?,?,?,?,?)"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, query)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID)
+	res, err := stmt.ExecContext(ctx, p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID)
 	if err != nil {
-		return 0, err
+		return 0, database.TranslateError(err, "product")
 	}
 
 	id, err := res.LastInsertId()
@@ -94,14 +161,14 @@ func (r *repository) Save(ctx context.Context, p domain.Product) (int, error) {
 
 func (r *repository) Update(ctx context.Context, p domain.Product) error {
 	query := "UPDATE products SET description=?, expiration_rate=?, freezing_rate=?, height=?, length=?, net_weight=?, product_code=?, recommended_freezing_temperature=?, width=?, product_type_id=?, seller_id=?  WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, query)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID, p.ID)
+	res, err := stmt.ExecContext(ctx, p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID, p.ID)
 	if err != nil {
-		return err
+		return database.TranslateError(err, "product")
 	}
 
 	_, err = res.RowsAffected()
@@ -113,17 +180,41 @@ func (r *repository) Update(ctx context.Context, p domain.Product) error {
 }
 
 func (r *repository) Delete(ctx context.Context, id int) error {
-	query := "DELETE FROM products WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	query := "UPDATE products SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, query)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(id)
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return database.TranslateError(err, "product")
+	}
+
+	affect, err := res.RowsAffected()
 	if err != nil {
 		return err
 	}
 
+	if affect < 1 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) Restore(ctx context.Context, id int) error {
+	query := "UPDATE products SET deleted_at=NULL WHERE id=? AND deleted_at IS NOT NULL"
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return database.TranslateError(err, "product")
+	}
+
 	affect, err := res.RowsAffected()
 	if err != nil {
 		return err
@@ -135,3 +226,7 @@ func (r *repository) Delete(ctx context.Context, id int) error {
 
 	return nil
 }
+
+func (r *repository) Dependents(ctx context.Context, id int) (map[string]int, error) {
+	return database.CountDependents(ctx, database.Conn(ctx, r.db), id, dependents)
+}
@@ -3,12 +3,14 @@ package product_test
 import (
 	"context"
 	"database/sql"
+	"net/url"
 	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/product"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -40,12 +42,12 @@ func TestRepositoryGet(t *testing.T) {
 		r := product.NewRepository(fields{db}.db)
 
 		rows := sqlmock.NewRows([]string{"id", "description", "expiration_rate", "freezing_rate", "height", "length", "net_weight",
-			"product_code", "recommended_freezing_temperature", "width", "product_type_id", "seller_id"}).
+			"product_code", "recommended_freezing_temperature", "width", "product_type_id", "seller_id", "deleted_at"}).
 			AddRow(expectedProduct.ID, expectedProduct.Description, expectedProduct.ExpirationRate, expectedProduct.FreezingRate,
 				expectedProduct.Height, expectedProduct.Length, expectedProduct.Netweight, expectedProduct.ProductCode,
-				expectedProduct.RecomFreezTemp, expectedProduct.Width, expectedProduct.ProductTypeID, expectedProduct.SellerID)
+				expectedProduct.RecomFreezTemp, expectedProduct.Width, expectedProduct.ProductTypeID, expectedProduct.SellerID, nil)
 
-		mock.ExpectQuery("SELECT id, description,expiration_rate,freezing_rate,height,length,net_weight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id FROM products WHERE id=?").
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, description,expiration_rate,freezing_rate,height,length,net_weight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id,deleted_at FROM products WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(expectedProduct.ID).
 			WillReturnRows(rows)
 
@@ -75,12 +77,12 @@ func TestRepositoryGet(t *testing.T) {
 		r := product.NewRepository(fields{db}.db)
 
 		rows := sqlmock.NewRows([]string{"id", "description", "expiration_rate", "freezing_rate", "height", "length", "net_weight",
-			"product_code", "recommended_freezing_temperature", "width", "product_type_id", "seller_id"}).
+			"product_code", "recommended_freezing_temperature", "width", "product_type_id", "seller_id", "deleted_at"}).
 			AddRow(expectedProduct.ID, expectedProduct.Description, expectedProduct.ExpirationRate, expectedProduct.FreezingRate,
 				expectedProduct.Height, expectedProduct.Length, expectedProduct.Netweight, expectedProduct.ProductCode,
-				expectedProduct.RecomFreezTemp, expectedProduct.Width, expectedProduct.ProductTypeID, expectedProduct.SellerID)
+				expectedProduct.RecomFreezTemp, expectedProduct.Width, expectedProduct.ProductTypeID, expectedProduct.SellerID, nil)
 
-		mock.ExpectQuery("SELECT id, description,expiration_rate,freezing_rate,height,length,net_weight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id FROM products WHERE id=?").
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, description,expiration_rate,freezing_rate,height,length,net_weight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id,deleted_at FROM products WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(expectedProduct.ID).
 			WillReturnRows(rows)
 
@@ -134,14 +136,14 @@ func TestRepositoryGetAll(t *testing.T) {
 		r := product.NewRepository(fields{db}.db)
 
 		rows := sqlmock.NewRows([]string{"id", "description", "expiration_rate", "freezing_rate", "height", "length", "net_weight",
-			"product_code", "recommended_freezing_temperature", "width", "product_type_id", "seller_id"})
+			"product_code", "recommended_freezing_temperature", "width", "product_type_id", "seller_id", "deleted_at"})
 
 		for _, expectedProduct := range expectedProducts {
 			rows.AddRow(expectedProduct.ID, expectedProduct.Description, expectedProduct.ExpirationRate, expectedProduct.FreezingRate,
 				expectedProduct.Height, expectedProduct.Length, expectedProduct.Netweight, expectedProduct.ProductCode,
-				expectedProduct.RecomFreezTemp, expectedProduct.Width, expectedProduct.ProductTypeID, expectedProduct.SellerID)
+				expectedProduct.RecomFreezTemp, expectedProduct.Width, expectedProduct.ProductTypeID, expectedProduct.SellerID, nil)
 		}
-		mock.ExpectQuery("SELECT id, description,expiration_rate,freezing_rate,height,length,net_weight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id FROM products").
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, description,expiration_rate,freezing_rate,height,length,net_weight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id,deleted_at FROM products WHERE deleted_at IS NULL")).
 			WillReturnRows(rows)
 
 		productsReceived, err := r.GetAll(ctx)
@@ -154,7 +156,7 @@ func TestRepositoryGetAll(t *testing.T) {
 
 		r := product.NewRepository(fields{db}.db)
 
-		mock.ExpectQuery("SELECT id, description,expiration_rate,freezing_rate,height,length,net_weight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id FROM products").
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, description,expiration_rate,freezing_rate,height,length,net_weight,product_code,recommended_freezing_temperature,width,product_type_id,seller_id,deleted_at FROM products WHERE deleted_at IS NULL")).
 			WithArgs().
 			WillReturnError(sql.ErrNoRows)
 
@@ -266,7 +268,7 @@ func TestRepositoryExistsByID(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id"}).
 			AddRow(expectedProduct.ID)
 
-		mock.ExpectQuery("SELECT id FROM products WHERE id=?").
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM products WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(expectedProduct.ID).
 			WillReturnRows(rows)
 
@@ -297,7 +299,7 @@ func TestRepositoryExistsByID(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"id"}).
 			AddRow(expectedProduct.ProductCode)
 
-		mock.ExpectQuery("SELECT id FROM products WHERE id=?").
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM products WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(expectedProduct.ProductCode).
 			WillReturnRows(rows)
 
@@ -335,8 +337,8 @@ func TestRepositoryDelete(t *testing.T) {
 		r := product.NewRepository(fields{db}.db)
 
 		rowsAffected := int64(1)
-		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM products WHERE id=?"))
-		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM products WHERE id=?")).
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE products SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE products SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(expectedProduct.ID).
 			WillReturnResult(sqlmock.NewResult(1, rowsAffected))
 
@@ -364,8 +366,8 @@ func TestRepositoryDelete(t *testing.T) {
 
 		r := product.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM products WHERE id=?"))
-		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM products WHERE id=?")).
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE products SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE products SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(expectedProduct.ID).
 			WillReturnError(sql.ErrNoRows)
 
@@ -393,8 +395,8 @@ func TestRepositoryDelete(t *testing.T) {
 
 		r := product.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM products WHERE id=?"))
-		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM products WHERE id=?")).
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE products SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE products SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(expectedProduct.ID).
 			WillReturnResult(sqlmock.NewErrorResult(sql.ErrNoRows))
 
@@ -422,8 +424,8 @@ func TestRepositoryDelete(t *testing.T) {
 
 		r := product.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM products WHERE id=?"))
-		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM products WHERE id=?")).
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE products SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE products SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(expectedProduct.ID).
 			WillReturnResult(sqlmock.NewResult(0, 0))
 
@@ -451,8 +453,8 @@ func TestRepositoryDelete(t *testing.T) {
 
 		r := product.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM products WHERE id=?")).WillReturnError(sql.ErrConnDone)
-		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM products WHERE id=?")).
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE products SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL")).WillReturnError(sql.ErrConnDone)
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE products SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(expectedProduct.ID).
 			WillReturnResult(sqlmock.NewErrorResult(sql.ErrNoRows))
 
@@ -462,6 +464,31 @@ func TestRepositoryDelete(t *testing.T) {
 	})
 }
 
+func TestRepositoryRestore(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("UPDATE products SET deleted_at=NULL WHERE id=? AND deleted_at IS NOT NULL")
+
+	t.Run("restore_ok", func(t *testing.T) {
+		r := product.NewRepository(db)
+		mock.ExpectPrepare(query)
+		mock.ExpectExec(query).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := r.Restore(ctx, 1)
+
+		assert.Nil(t, err)
+	})
+	t.Run("restore_not_deleted", func(t *testing.T) {
+		r := product.NewRepository(db)
+		mock.ExpectPrepare(query)
+		mock.ExpectExec(query).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := r.Restore(ctx, 1)
+
+		assert.Equal(t, product.ErrNotFound, err)
+	})
+}
+
 func TestRepositoryUpdate(t *testing.T) {
 	type fields struct {
 		db *sql.DB
@@ -722,3 +749,72 @@ func TestRepositorySave(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 }
+
+func TestRepositoryGetPage(t *testing.T) {
+	options, _ := listing.Parse(url.Values{"seller_id": {"1"}, "sort": {"product_code"}, "limit": {"1"}}, product.Fields)
+
+	t.Run("get_page_ok", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		expectedProducts := []domain.Product{
+			{ID: 1, Description: "Test", ProductCode: "Teste", ProductTypeID: 1, SellerID: 1},
+		}
+
+		r := product.NewRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM products WHERE seller_id = ?")).
+			WithArgs("1").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		rows := sqlmock.NewRows([]string{"id", "description", "expiration_rate", "freezing_rate", "height", "length", "net_weight", "product_code", "recommended_freezing_temperature", "width", "product_type_id", "seller_id", "deleted_at"})
+		for _, p := range expectedProducts {
+			rows.AddRow(p.ID, p.Description, p.ExpirationRate, p.FreezingRate, p.Height, p.Length, p.Netweight, p.ProductCode, p.RecomFreezTemp, p.Width, p.ProductTypeID, p.SellerID, nil)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta("FROM products WHERE seller_id = ? ORDER BY product_code ASC, id ASC LIMIT ? OFFSET ?")).
+			WithArgs("1", 1, 0).
+			WillReturnRows(rows)
+
+		productsReceived, total, err := r.GetPage(context.TODO(), options)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedProducts, productsReceived)
+		assert.Equal(t, 3, total)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("get_page_count_error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+
+		r := product.NewRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM products")).
+			WillReturnError(sql.ErrConnDone)
+
+		productsReceived, _, err := r.GetPage(context.TODO(), options)
+
+		assert.Nil(t, productsReceived)
+		assert.Equal(t, sql.ErrConnDone, err)
+	})
+}
+
+func TestRepositoryDependents(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	t.Run("dependents_ok", func(t *testing.T) {
+		r := product.NewRepository(db)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM product_batches WHERE product_id=?")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+		dependents, err := r.Dependents(ctx, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]int{"product_batches": 2}, dependents)
+	})
+	t.Run("dependents_error", func(t *testing.T) {
+		r := product.NewRepository(db)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM product_batches WHERE product_id=?")).WithArgs(1).WillReturnError(sql.ErrConnDone)
+
+		dependents, err := r.Dependents(ctx, 1)
+
+		assert.Equal(t, sql.ErrConnDone, err)
+		assert.Nil(t, dependents)
+	})
+}
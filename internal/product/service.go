@@ -2,25 +2,30 @@ package product
 
 import (
 	"context"
-	"database/sql"
-	"errors"
 
+	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 )
 
 // Errors
 var (
-	ErrNotFound = errors.New("product not found")
-	ErrConflict = errors.New("product with Product Number already exists")
+	ErrNotFound = errors2.NotFound("product")
+	ErrConflict = &errors2.Error{Kind: errors2.KindConflict, Entity: "product", Field: "product_code", Message: "product with Product Number already exists"}
 )
 
 type Service interface {
-	Save(ctx *context.Context, description string, expiration_rate, freezing_rate int, height, length, netweight float32, product_code string,
+	Save(ctx context.Context, description string, expiration_rate, freezing_rate int, height, length, netweight float32, product_code string,
 		recommended_freezing_temperature, width float32, product_type_id, seller_id int) (*domain.Product, error)
-	GetAll(ctx *context.Context) (*[]domain.Product, error)
-	Get(ctx *context.Context, id int) (*domain.Product, error)
-	Delete(ctx *context.Context, id int) error
-	Update(ctx *context.Context, description *string, expiration_rate, freezing_rate *int, height, length, netweight *float32, product_code *string,
+	GetAll(ctx context.Context) (*[]domain.Product, error)
+	GetPage(ctx context.Context, options listing.Options) (*[]domain.Product, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.Product) error) error
+	Get(ctx context.Context, id int) (*domain.Product, error)
+	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) (*domain.Product, error)
+	DeletePreview(ctx context.Context, id int) (*domain.DeletePreview, error)
+	Update(ctx context.Context, description *string, expiration_rate, freezing_rate *int, height, length, netweight *float32, product_code *string,
 		recommended_freezing_temperature, width *float32, product_type_id, seller_id *int, id int) (*domain.Product, error)
 }
 
@@ -34,9 +39,9 @@ func NewService(r Repository) Service {
 	}
 }
 
-func (s *service) Save(ctx *context.Context, description string, expiration_rate, freezing_rate int, height, length, netweight float32, product_code string,
+func (s *service) Save(ctx context.Context, description string, expiration_rate, freezing_rate int, height, length, netweight float32, product_code string,
 	recommended_freezing_temperature, width float32, product_type_id, seller_id int) (*domain.Product, error) {
-	existingProduct := s.productRepository.Exists(*ctx, product_code)
+	existingProduct := s.productRepository.Exists(ctx, product_code)
 
 	if existingProduct {
 		return nil, ErrConflict
@@ -56,13 +61,13 @@ func (s *service) Save(ctx *context.Context, description string, expiration_rate
 		SellerID:       seller_id,
 	}
 
-	productId, err := s.productRepository.Save(*ctx, newProduct)
+	productId, err := s.productRepository.Save(ctx, newProduct)
 	if err != nil {
 		return nil, err
 
 	}
 
-	savedProduct, err := s.productRepository.Get(*ctx, productId)
+	savedProduct, err := s.productRepository.Get(ctx, productId)
 	if err != nil {
 		return nil, err
 	}
@@ -70,8 +75,8 @@ func (s *service) Save(ctx *context.Context, description string, expiration_rate
 	return &savedProduct, nil
 }
 
-func (s *service) GetAll(ctx *context.Context) (*[]domain.Product, error) {
-	products, err := s.productRepository.GetAll(*ctx)
+func (s *service) GetAll(ctx context.Context) (*[]domain.Product, error) {
+	products, err := s.productRepository.GetAll(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -79,36 +84,68 @@ func (s *service) GetAll(ctx *context.Context) (*[]domain.Product, error) {
 	return &products, nil
 }
 
-func (s *service) Get(ctx *context.Context, id int) (*domain.Product, error) {
-	product, err := s.productRepository.Get(*ctx, id)
+func (s *service) GetPage(ctx context.Context, options listing.Options) (*[]domain.Product, int, error) {
+	products, total, err := s.productRepository.GetPage(ctx, options)
 	if err != nil {
-		switch err {
-		case sql.ErrNoRows:
-			return nil, ErrNotFound
-		default:
-			return nil, err
-		}
+		return nil, 0, err
+	}
+
+	return &products, total, nil
+}
+
+func (s *service) Each(ctx context.Context, options listing.Options, fn func(domain.Product) error) error {
+	return s.productRepository.Each(ctx, options, fn)
+}
+
+func (s *service) Get(ctx context.Context, id int) (*domain.Product, error) {
+	product, err := s.productRepository.Get(ctx, id)
+	if err != nil {
+		return nil, err
 	}
 
 	return &product, nil
 }
 
-func (s *service) Delete(ctx *context.Context, id int) error {
-	err := s.productRepository.Delete(*ctx, id)
+func (s *service) Delete(ctx context.Context, id int) error {
+	dependents, err := s.productRepository.Dependents(ctx, id)
 	if err != nil {
-		switch err {
-		case sql.ErrNoRows:
-			return ErrNotFound
-		default:
-			return err
-		}
+		return err
+	}
+	if err := database.CheckDependents(dependents); err != nil {
+		return err
 	}
-	return nil
+
+	return s.productRepository.Delete(ctx, id)
 }
 
-func (s *service) Update(ctx *context.Context, description *string, expiration_rate, freezing_rate *int, height, length, netweight *float32, product_code *string,
+func (s *service) Restore(ctx context.Context, id int) (*domain.Product, error) {
+	if err := s.productRepository.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, id)
+}
+
+func (s *service) DeletePreview(ctx context.Context, id int) (*domain.DeletePreview, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+
+	dependents, err := s.productRepository.Dependents(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.DeletePreview{
+		ID:         id,
+		Dependents: dependents,
+		Deletable:  database.CheckDependents(dependents) == nil,
+	}, nil
+}
+
+func (s *service) Update(ctx context.Context, description *string, expiration_rate, freezing_rate *int, height, length, netweight *float32, product_code *string,
 	recommended_freezing_temperature, width *float32, product_type_id, seller_id *int, id int) (*domain.Product, error) {
-	existingProduct, err := s.productRepository.Get(*ctx, id)
+	existingProduct, err := s.productRepository.Get(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -129,7 +166,7 @@ func (s *service) Update(ctx *context.Context, description *string, expiration_r
 		existingProduct.Netweight = *netweight
 	}
 	if product_code != nil {
-		existingProductSearch := s.productRepository.Exists(*ctx, *product_code)
+		existingProductSearch := s.productRepository.Exists(ctx, *product_code)
 		if existingProductSearch && *product_code != existingProduct.ProductCode {
 			return nil, ErrConflict
 		}
@@ -148,14 +185,9 @@ func (s *service) Update(ctx *context.Context, description *string, expiration_r
 		existingProduct.SellerID = *seller_id
 	}
 
-	err1 := s.productRepository.Update(*ctx, existingProduct)
+	err1 := s.productRepository.Update(ctx, existingProduct)
 	if err1 != nil {
-		switch err1 {
-		case sql.ErrNoRows:
-			return nil, ErrNotFound
-		default:
-			return nil, err1
-		}
+		return nil, err1
 	}
 
 	return &existingProduct, nil
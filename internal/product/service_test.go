@@ -2,15 +2,16 @@ package product_test
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"testing"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/products"
 
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/product"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/product/product_mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -39,7 +40,7 @@ func TestGet(t *testing.T) {
 		productRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(*expectedProduct, nil)
 
 		service := product.NewService(productRepositoryMock)
-		productReceived, err := service.Get(&ctx, 1)
+		productReceived, err := service.Get(ctx, 1)
 
 		assert.Equal(t, *expectedProduct, *productReceived)
 		assert.Equal(t, nil, err)
@@ -50,10 +51,10 @@ func TestGet(t *testing.T) {
 		ctx := context.TODO()
 
 		productRepositoryMock := new(product_mocks.ProductRepositoryMock)
-		productRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.Product{}, sql.ErrNoRows)
+		productRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.Product{}, product.ErrNotFound)
 
 		service := product.NewService(productRepositoryMock)
-		productReceived, err := service.Get(&ctx, 1)
+		productReceived, err := service.Get(ctx, 1)
 
 		assert.Nil(t, productReceived)
 		assert.Equal(t, product.ErrNotFound, err)
@@ -67,7 +68,7 @@ func TestGet(t *testing.T) {
 		productRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.Product{}, errors.New("error"))
 
 		service := product.NewService(productRepositoryMock)
-		productReceived, err := service.Get(&ctx, 1)
+		productReceived, err := service.Get(ctx, 1)
 
 		assert.Nil(t, productReceived)
 		assert.Equal(t, errors.New("error"), err)
@@ -112,7 +113,7 @@ func TestGetAll(t *testing.T) {
 		productRepositoryMock.On("GetAll", ctx).Return(*expectedProducts, nil)
 
 		service := product.NewService(productRepositoryMock)
-		productsReceived, err := service.GetAll(&ctx)
+		productsReceived, err := service.GetAll(ctx)
 
 		assert.Equal(t, *expectedProducts, *productsReceived)
 		assert.Equal(t, nil, err)
@@ -126,7 +127,7 @@ func TestGetAll(t *testing.T) {
 		productRepositoryMock.On("GetAll", ctx).Return([]domain.Product{}, errors.New("error"))
 
 		service := product.NewService(productRepositoryMock)
-		productsReceived, err := service.GetAll(&ctx)
+		productsReceived, err := service.GetAll(ctx)
 
 		assert.Nil(t, productsReceived)
 		assert.Equal(t, errors.New("error"), err)
@@ -134,16 +135,51 @@ func TestGetAll(t *testing.T) {
 
 }
 
+func TestGetPage(t *testing.T) {
+	t.Run("getPage_find_page", func(t *testing.T) {
+		expectedProducts := []domain.Product{{ID: 1, Description: "Test", ProductCode: "Teste"}}
+		options := listing.Options{Limit: 1}
+
+		ctx := context.TODO()
+
+		productRepositoryMock := new(product_mocks.ProductRepositoryMock)
+		productRepositoryMock.On("GetPage", ctx, options).Return(expectedProducts, 5, nil)
+
+		service := product.NewService(productRepositoryMock)
+		productsReceived, total, err := service.GetPage(ctx, options)
+
+		assert.Equal(t, expectedProducts, *productsReceived)
+		assert.Equal(t, 5, total)
+		assert.Nil(t, err)
+	})
+
+	t.Run("getPage_unexpected_error", func(t *testing.T) {
+		options := listing.Options{Limit: 1}
+
+		ctx := context.TODO()
+
+		productRepositoryMock := new(product_mocks.ProductRepositoryMock)
+		productRepositoryMock.On("GetPage", ctx, options).Return(nil, 0, errors.New("error"))
+
+		service := product.NewService(productRepositoryMock)
+		productsReceived, _, err := service.GetPage(ctx, options)
+
+		assert.Nil(t, productsReceived)
+		assert.Equal(t, errors.New("error"), err)
+	})
+}
+
 func TestDelete(t *testing.T) {
 	t.Run("delete_ok", func(t *testing.T) {
 
 		ctx := context.TODO()
 
 		productRepositoryMock := new(product_mocks.ProductRepositoryMock)
+		productRepositoryMock.On("Dependents", ctx, mock.AnythingOfType("int")).Return(map[string]int{}, nil)
 		productRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(nil)
 
 		service := product.NewService(productRepositoryMock)
-		err := service.Delete(&ctx, 1)
+		err := service.Delete(ctx, 1)
 
 		assert.Nil(t, err)
 	})
@@ -153,10 +189,11 @@ func TestDelete(t *testing.T) {
 		ctx := context.TODO()
 
 		productRepositoryMock := new(product_mocks.ProductRepositoryMock)
-		productRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(sql.ErrNoRows)
+		productRepositoryMock.On("Dependents", ctx, mock.AnythingOfType("int")).Return(map[string]int{}, nil)
+		productRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(product.ErrNotFound)
 
 		service := product.NewService(productRepositoryMock)
-		err := service.Delete(&ctx, 1)
+		err := service.Delete(ctx, 1)
 
 		assert.Equal(t, product.ErrNotFound, err)
 	})
@@ -166,13 +203,91 @@ func TestDelete(t *testing.T) {
 		ctx := context.TODO()
 
 		productRepositoryMock := new(product_mocks.ProductRepositoryMock)
+		productRepositoryMock.On("Dependents", ctx, mock.AnythingOfType("int")).Return(map[string]int{}, nil)
 		productRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(errors.New("error"))
 
 		service := product.NewService(productRepositoryMock)
-		err := service.Delete(&ctx, 1)
+		err := service.Delete(ctx, 1)
 
 		assert.Equal(t, errors.New("error"), err)
 	})
+
+	t.Run("delete_has_dependents", func(t *testing.T) {
+
+		ctx := context.TODO()
+
+		productRepositoryMock := new(product_mocks.ProductRepositoryMock)
+		productRepositoryMock.On("Dependents", ctx, 1).Return(map[string]int{"product_batches": 2}, nil)
+
+		service := product.NewService(productRepositoryMock)
+		err := service.Delete(ctx, 1)
+
+		assert.ErrorIs(t, err, database.ErrHasDependents)
+		assert.Equal(t, &database.DependentsError{Dependents: map[string]int{"product_batches": 2}}, err)
+		productRepositoryMock.AssertNotCalled(t, "Delete", ctx, 1)
+	})
+}
+
+func TestDeletePreview(t *testing.T) {
+	t.Run("delete_preview_ok", func(t *testing.T) {
+
+		ctx := context.TODO()
+
+		productRepositoryMock := new(product_mocks.ProductRepositoryMock)
+		productRepositoryMock.On("Get", ctx, 1).Return(domain.Product{ID: 1}, nil)
+		productRepositoryMock.On("Dependents", ctx, 1).Return(map[string]int{"product_batches": 2}, nil)
+
+		service := product.NewService(productRepositoryMock)
+		preview, err := service.DeletePreview(ctx, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &domain.DeletePreview{ID: 1, Dependents: map[string]int{"product_batches": 2}, Deletable: false}, preview)
+	})
+
+	t.Run("delete_preview_non_existent", func(t *testing.T) {
+
+		ctx := context.TODO()
+
+		productRepositoryMock := new(product_mocks.ProductRepositoryMock)
+		productRepositoryMock.On("Get", ctx, 1).Return(domain.Product{}, product.ErrNotFound)
+
+		service := product.NewService(productRepositoryMock)
+		preview, err := service.DeletePreview(ctx, 1)
+
+		assert.Nil(t, preview)
+		assert.Equal(t, product.ErrNotFound, err)
+	})
+}
+
+func TestRestore(t *testing.T) {
+	t.Run("restore_ok", func(t *testing.T) {
+
+		ctx := context.TODO()
+
+		productRepositoryMock := new(product_mocks.ProductRepositoryMock)
+		productRepositoryMock.On("Restore", ctx, 1).Return(nil)
+		productRepositoryMock.On("Get", ctx, 1).Return(domain.Product{ID: 1}, nil)
+
+		service := product.NewService(productRepositoryMock)
+		restored, err := service.Restore(ctx, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &domain.Product{ID: 1}, restored)
+	})
+
+	t.Run("restore_not_deleted", func(t *testing.T) {
+
+		ctx := context.TODO()
+
+		productRepositoryMock := new(product_mocks.ProductRepositoryMock)
+		productRepositoryMock.On("Restore", ctx, 1).Return(product.ErrNotFound)
+
+		service := product.NewService(productRepositoryMock)
+		restored, err := service.Restore(ctx, 1)
+
+		assert.Nil(t, restored)
+		assert.Equal(t, product.ErrNotFound, err)
+	})
 }
 
 func TestCreate(t *testing.T) {
@@ -198,7 +313,7 @@ func TestCreate(t *testing.T) {
 		productRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(true)
 
 		service := product.NewService(productRepositoryMock)
-		productSaved, err := service.Save(&ctx, createProductRequestDTO.Description, createProductRequestDTO.ExpirationRate, createProductRequestDTO.FreezingRate,
+		productSaved, err := service.Save(ctx, createProductRequestDTO.Description, createProductRequestDTO.ExpirationRate, createProductRequestDTO.FreezingRate,
 			createProductRequestDTO.Height, createProductRequestDTO.Length, createProductRequestDTO.Netweight, createProductRequestDTO.ProductCode,
 			createProductRequestDTO.RecomFreezTemp, createProductRequestDTO.Width, createProductRequestDTO.ProductTypeID, createProductRequestDTO.SellerID)
 
@@ -230,7 +345,7 @@ func TestCreate(t *testing.T) {
 		productRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.Product")).Return(0, errors.New("error"))
 
 		service := product.NewService(productRepositoryMock)
-		productSaved, err := service.Save(&ctx, createProductRequestDTO.Description, createProductRequestDTO.ExpirationRate, createProductRequestDTO.FreezingRate,
+		productSaved, err := service.Save(ctx, createProductRequestDTO.Description, createProductRequestDTO.ExpirationRate, createProductRequestDTO.FreezingRate,
 			createProductRequestDTO.Height, createProductRequestDTO.Length, createProductRequestDTO.Netweight, createProductRequestDTO.ProductCode,
 			createProductRequestDTO.RecomFreezTemp, createProductRequestDTO.Width, createProductRequestDTO.ProductTypeID, createProductRequestDTO.SellerID)
 
@@ -263,7 +378,7 @@ func TestCreate(t *testing.T) {
 		productRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.Product{}, errors.New("error"))
 
 		service := product.NewService(productRepositoryMock)
-		productSaved, err := service.Save(&ctx, createProductRequestDTO.Description, createProductRequestDTO.ExpirationRate, createProductRequestDTO.FreezingRate,
+		productSaved, err := service.Save(ctx, createProductRequestDTO.Description, createProductRequestDTO.ExpirationRate, createProductRequestDTO.FreezingRate,
 			createProductRequestDTO.Height, createProductRequestDTO.Length, createProductRequestDTO.Netweight, createProductRequestDTO.ProductCode,
 			createProductRequestDTO.RecomFreezTemp, createProductRequestDTO.Width, createProductRequestDTO.ProductTypeID, createProductRequestDTO.SellerID)
 
@@ -309,7 +424,7 @@ func TestCreate(t *testing.T) {
 		productRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(*expectedProduct, nil)
 
 		service := product.NewService(productRepositoryMock)
-		productSaved, err := service.Save(&ctx, createProductRequestDTO.Description, createProductRequestDTO.ExpirationRate, createProductRequestDTO.FreezingRate,
+		productSaved, err := service.Save(ctx, createProductRequestDTO.Description, createProductRequestDTO.ExpirationRate, createProductRequestDTO.FreezingRate,
 			createProductRequestDTO.Height, createProductRequestDTO.Length, createProductRequestDTO.Netweight, createProductRequestDTO.ProductCode,
 			createProductRequestDTO.RecomFreezTemp, createProductRequestDTO.Width, createProductRequestDTO.ProductTypeID, createProductRequestDTO.SellerID)
 
@@ -357,7 +472,7 @@ func TestUpdate(t *testing.T) {
 		productRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.Product")).Return(nil)
 
 		service := product.NewService(productRepositoryMock)
-		productUpdate, err := service.Update(&ctx, updateProductRequestDTO.Description, updateProductRequestDTO.ExpirationRate, updateProductRequestDTO.FreezingRate,
+		productUpdate, err := service.Update(ctx, updateProductRequestDTO.Description, updateProductRequestDTO.ExpirationRate, updateProductRequestDTO.FreezingRate,
 			updateProductRequestDTO.Height, updateProductRequestDTO.Length, updateProductRequestDTO.Netweight, updateProductRequestDTO.ProductCode,
 			updateProductRequestDTO.RecomFreezTemp, updateProductRequestDTO.Width, updateProductRequestDTO.ProductTypeID, updateProductRequestDTO.SellerID, 1)
 
@@ -399,10 +514,10 @@ func TestUpdate(t *testing.T) {
 		productRepositoryMock := new(product_mocks.ProductRepositoryMock)
 		productRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(*originalProduct, nil)
 		productRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(false)
-		productRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.Product")).Return(sql.ErrNoRows)
+		productRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.Product")).Return(product.ErrNotFound)
 
 		service := product.NewService(productRepositoryMock)
-		productUpdate, err := service.Update(&ctx, updateProductRequestDTO.Description, updateProductRequestDTO.ExpirationRate, updateProductRequestDTO.FreezingRate,
+		productUpdate, err := service.Update(ctx, updateProductRequestDTO.Description, updateProductRequestDTO.ExpirationRate, updateProductRequestDTO.FreezingRate,
 			updateProductRequestDTO.Height, updateProductRequestDTO.Length, updateProductRequestDTO.Netweight, updateProductRequestDTO.ProductCode,
 			updateProductRequestDTO.RecomFreezTemp, updateProductRequestDTO.Width, updateProductRequestDTO.ProductTypeID, updateProductRequestDTO.SellerID, 1)
 
@@ -447,7 +562,7 @@ func TestUpdate(t *testing.T) {
 		productRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.Product")).Return(errors.New("error"))
 
 		service := product.NewService(productRepositoryMock)
-		productUpdate, err := service.Update(&ctx, updateProductRequestDTO.Description, updateProductRequestDTO.ExpirationRate, updateProductRequestDTO.FreezingRate,
+		productUpdate, err := service.Update(ctx, updateProductRequestDTO.Description, updateProductRequestDTO.ExpirationRate, updateProductRequestDTO.FreezingRate,
 			updateProductRequestDTO.Height, updateProductRequestDTO.Length, updateProductRequestDTO.Netweight, updateProductRequestDTO.ProductCode,
 			updateProductRequestDTO.RecomFreezTemp, updateProductRequestDTO.Width, updateProductRequestDTO.ProductTypeID, updateProductRequestDTO.SellerID, 1)
 
@@ -490,7 +605,7 @@ func TestUpdate(t *testing.T) {
 		productRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.Product{}, errors.New("error"))
 
 		service := product.NewService(productRepositoryMock)
-		productUpdate, err := service.Update(&ctx, updateProductRequestDTO.Description, updateProductRequestDTO.ExpirationRate, updateProductRequestDTO.FreezingRate,
+		productUpdate, err := service.Update(ctx, updateProductRequestDTO.Description, updateProductRequestDTO.ExpirationRate, updateProductRequestDTO.FreezingRate,
 			updateProductRequestDTO.Height, updateProductRequestDTO.Length, updateProductRequestDTO.Netweight, updateProductRequestDTO.ProductCode,
 			updateProductRequestDTO.RecomFreezTemp, updateProductRequestDTO.Width, updateProductRequestDTO.ProductTypeID, updateProductRequestDTO.SellerID, 1)
 
@@ -535,7 +650,7 @@ func TestUpdate(t *testing.T) {
 		productRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(true)
 
 		service := product.NewService(productRepositoryMock)
-		productUpdate, err := service.Update(&ctx, updateProductRequestDTO.Description, updateProductRequestDTO.ExpirationRate, updateProductRequestDTO.FreezingRate,
+		productUpdate, err := service.Update(ctx, updateProductRequestDTO.Description, updateProductRequestDTO.ExpirationRate, updateProductRequestDTO.FreezingRate,
 			updateProductRequestDTO.Height, updateProductRequestDTO.Length, updateProductRequestDTO.Netweight, updateProductRequestDTO.ProductCode,
 			updateProductRequestDTO.RecomFreezTemp, updateProductRequestDTO.Width, updateProductRequestDTO.ProductTypeID, updateProductRequestDTO.SellerID, 1)
 
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -114,6 +115,32 @@ func (_m *ProductRecordRepositoryMock) GetAll(ctx context.Context) ([]domain.Pro
 	return r0, r1
 }
 
+// GetPage provides a mock function with given fields: ctx, options
+func (_m *ProductRecordRepositoryMock) GetPage(ctx context.Context, options listing.Options) ([]domain.ProductRecord, int, error) {
+	ret := _m.Called(ctx, options)
+
+	var r0 []domain.ProductRecord
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]domain.ProductRecord)
+	}
+
+	return r0, ret.Int(1), ret.Error(2)
+}
+
+// Each provides a mock function with given fields: ctx, options, fn
+func (_m *ProductRecordRepositoryMock) Each(ctx context.Context, options listing.Options, fn func(domain.ProductRecord) error) error {
+	ret := _m.Called(ctx, options, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options, func(domain.ProductRecord) error) error); ok {
+		r0 = rf(ctx, options, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Save provides a mock function with given fields: ctx, p
 func (_m *ProductRecordRepositoryMock) Save(ctx context.Context, p domain.ProductRecord) (int, error) {
 	ret := _m.Called(ctx, p)
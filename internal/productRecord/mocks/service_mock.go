@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -11,35 +12,52 @@ type ProductRecordServiceMock struct {
 	mock.Mock
 }
 
-func (service *ProductRecordServiceMock) Get(ctx *context.Context, id int) (*domain.ProductRecord, error) {
+func (service *ProductRecordServiceMock) Get(ctx context.Context, id int) (*domain.ProductRecord, error) {
 	args := service.Called(ctx, id)
 
 	return args.Get(0).(*domain.ProductRecord), args.Error(1)
 }
 
-func (service *ProductRecordServiceMock) GetAll(ctx *context.Context) (*[]domain.ProductRecord, error) {
+func (service *ProductRecordServiceMock) GetAll(ctx context.Context) (*[]domain.ProductRecord, error) {
 	args := service.Called(ctx)
 
 	return args.Get(0).(*[]domain.ProductRecord), args.Error(1)
 }
 
-func (service *ProductRecordServiceMock) Save(ctx *context.Context, lastUpdateRate string, purchasePrice, salePrice float32, productId int) (*domain.ProductRecord, error) {
+func (service *ProductRecordServiceMock) GetPage(ctx context.Context, options listing.Options) (*[]domain.ProductRecord, int, error) {
+	args := service.Called(ctx, options)
+
+	return args.Get(0).(*[]domain.ProductRecord), args.Int(1), args.Error(2)
+}
+
+func (service *ProductRecordServiceMock) Each(ctx context.Context, options listing.Options, fn func(domain.ProductRecord) error) error {
+	args := service.Called(ctx, options)
+
+	for _, productRecord := range args.Get(0).([]domain.ProductRecord) {
+		if err := fn(productRecord); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (service *ProductRecordServiceMock) Save(ctx context.Context, lastUpdateRate string, purchasePrice, salePrice float32, productId int) (*domain.ProductRecord, error) {
 	args := service.Called(ctx, lastUpdateRate, purchasePrice, salePrice, productId)
 	return args.Get(0).(*domain.ProductRecord), args.Error(1)
 }
 
-func (service *ProductRecordServiceMock) Update(ctx *context.Context, lastUpdateRate *string, purchasePrice, salePrice *float32, productId *int, id int) (*domain.ProductRecord, error) {
+func (service *ProductRecordServiceMock) Update(ctx context.Context, lastUpdateRate *string, purchasePrice, salePrice *float32, productId *int, id int) (*domain.ProductRecord, error) {
 	args := service.Called(ctx, lastUpdateRate, purchasePrice, salePrice, productId)
 	return args.Get(0).(*domain.ProductRecord), args.Error(1)
 }
 
-func (service *ProductRecordServiceMock) Delete(ctx *context.Context, id int) error {
+func (service *ProductRecordServiceMock) Delete(ctx context.Context, id int) error {
 	args := service.Called(ctx, id)
 
 	return args.Error(0)
 }
 
-func (service *ProductRecordServiceMock) NumberRecords(ctx *context.Context, product_id int) (int, error) {
+func (service *ProductRecordServiceMock) NumberRecords(ctx context.Context, product_id int) (int, error) {
 	args := service.Called(ctx, product_id)
 
 	return args.Get(0).(int), args.Error(1)
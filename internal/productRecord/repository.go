@@ -4,12 +4,16 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 )
 
 // Repository encapsulates the storage of a Product.
 type Repository interface {
 	GetAll(ctx context.Context) ([]domain.ProductRecord, error)
+	GetPage(ctx context.Context, options listing.Options) ([]domain.ProductRecord, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.ProductRecord) error) error
 	Get(ctx context.Context, id int) (domain.ProductRecord, error)
 	Exists(ctx context.Context, productId int) bool
 	Save(ctx context.Context, p domain.ProductRecord) (int, error)
@@ -18,6 +22,15 @@ type Repository interface {
 	NumberRecords(ctx context.Context, id int) (int, error)
 }
 
+// Fields are the product record fields list requests can sort and filter by.
+var Fields = listing.Fields{
+	"id":               "id",
+	"last_update_date": "last_update_date",
+	"purchase_price":   "purchase_price",
+	"sale_price":       "sale_price",
+	"product_id":       "product_id",
+}
+
 type repository struct {
 	db *sql.DB
 }
@@ -30,7 +43,7 @@ func NewRepository(db *sql.DB) Repository {
 
 func (r *repository) GetAll(ctx context.Context) ([]domain.ProductRecord, error) {
 	query := "SELECT id, last_update_date, purchase_price, sale_price, product_id FROM product_records;"
-	rows, err := r.db.Query(query)
+	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -46,13 +59,52 @@ func (r *repository) GetAll(ctx context.Context) ([]domain.ProductRecord, error)
 	return product_records, nil
 }
 
+func (r *repository) GetPage(ctx context.Context, options listing.Options) ([]domain.ProductRecord, int, error) {
+	var total int
+	query, args := options.CountQuery("SELECT COUNT(*) FROM product_records")
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	productRecords := make([]domain.ProductRecord, 0)
+	err := r.Each(ctx, options, func(p domain.ProductRecord) error {
+		productRecords = append(productRecords, p)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return productRecords, total, nil
+}
+
+func (r *repository) Each(ctx context.Context, options listing.Options, fn func(domain.ProductRecord) error) error {
+	query, args := options.Query("SELECT id, last_update_date, purchase_price, sale_price, product_id FROM product_records")
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p := domain.ProductRecord{}
+		if err := rows.Scan(&p.ID, &p.LastUpdateDate, &p.PurchasePrice, &p.SalePrice, &p.ProductId); err != nil {
+			return err
+		}
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 func (r *repository) Get(ctx context.Context, id int) (domain.ProductRecord, error) {
 	query := "SELECT id, last_update_date, purchase_price, sale_price, product_id FROM product_records WHERE id=?;"
-	row := r.db.QueryRow(query, id)
+	row := r.db.QueryRowContext(ctx, query, id)
 	p := domain.ProductRecord{}
 	err := row.Scan(&p.ID, &p.LastUpdateDate, &p.PurchasePrice, &p.SalePrice, &p.ProductId)
 	if err != nil {
-		return domain.ProductRecord{}, err
+		return domain.ProductRecord{}, database.TranslateError(err, "productRecord")
 	}
 
 	return p, nil
@@ -60,14 +112,14 @@ func (r *repository) Get(ctx context.Context, id int) (domain.ProductRecord, err
 
 func (r *repository) Save(ctx context.Context, p domain.ProductRecord) (int, error) {
 	query := "INSERT INTO product_records(last_update_date, purchase_price, sale_price, product_id) VALUES (?,?,?,?)"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.db.PrepareContext(ctx, query)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(p.LastUpdateDate, p.PurchasePrice, p.SalePrice, p.ProductId)
+	res, err := stmt.ExecContext(ctx, p.LastUpdateDate, p.PurchasePrice, p.SalePrice, p.ProductId)
 	if err != nil {
-		return 0, err
+		return 0, database.TranslateError(err, "productRecord")
 	}
 
 	id, err := res.LastInsertId()
@@ -80,21 +132,21 @@ func (r *repository) Save(ctx context.Context, p domain.ProductRecord) (int, err
 
 func (r *repository) Exists(ctx context.Context, id int) bool {
 	query := "SELECT id FROM products_records WHERE id=?;"
-	row := r.db.QueryRow(query, id)
+	row := r.db.QueryRowContext(ctx, query, id)
 	err := row.Scan(&id)
 	return err == nil
 }
 
 func (r *repository) Update(ctx context.Context, p domain.ProductRecord) error {
 	query := "UPDATE product_records SET last_update_date=?, purchase_price=?, sale_price=?, product_id=? WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.db.PrepareContext(ctx, query)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(p.LastUpdateDate, p.PurchasePrice, p.SalePrice, p.ProductId, p.ID)
+	res, err := stmt.ExecContext(ctx, p.LastUpdateDate, p.PurchasePrice, p.SalePrice, p.ProductId, p.ID)
 	if err != nil {
-		return err
+		return database.TranslateError(err, "productRecord")
 	}
 
 	_, err = res.RowsAffected()
@@ -107,14 +159,14 @@ func (r *repository) Update(ctx context.Context, p domain.ProductRecord) error {
 
 func (r *repository) Delete(ctx context.Context, id int) error {
 	query := "DELETE FROM product_records WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := r.db.PrepareContext(ctx, query)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(id)
+	res, err := stmt.ExecContext(ctx, id)
 	if err != nil {
-		return err
+		return database.TranslateError(err, "productRecord")
 	}
 
 	affect, err := res.RowsAffected()
@@ -131,7 +183,7 @@ func (r *repository) Delete(ctx context.Context, id int) error {
 
 func (r *repository) NumberRecords(ctx context.Context, product_id int) (int, error) {
 	count := 0
-	row := r.db.QueryRow("SELECT COUNT(*) from product_records where product_id =?", product_id)
+	row := r.db.QueryRowContext(ctx, "SELECT COUNT(*) from product_records where product_id =?", product_id)
 	err := row.Scan(&count)
 
 	return count, err
@@ -3,12 +3,14 @@ package productRecord_test
 import (
 	"context"
 	"database/sql"
+	"net/url"
 	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/productRecord"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -131,6 +133,58 @@ func TestRepositoryGetAll(t *testing.T) {
 	})
 }
 
+func TestRepositoryGetPage(t *testing.T) {
+	ctx := context.TODO()
+	options, _ := listing.Parse(url.Values{"limit": {"2"}, "sort": {"sale_price"}, "order": {"desc"}, "product_id": {"1"}}, productRecord.Fields)
+
+	t.Run("get_page_ok", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		r := productRecord.NewRepository(db)
+
+		expectedProductsRecords := []domain.ProductRecord{
+			{
+				ID:             1,
+				LastUpdateDate: "Test",
+				PurchasePrice:  1.1,
+				SalePrice:      1.1,
+				ProductId:      1,
+			},
+		}
+
+		rows := sqlmock.NewRows([]string{"id", "last_update_date", "purchase_price", "sale_price", "product_id"})
+		for _, expectedProductRecord := range expectedProductsRecords {
+			rows.AddRow(expectedProductRecord.ID, expectedProductRecord.LastUpdateDate, expectedProductRecord.PurchasePrice, expectedProductRecord.SalePrice,
+				expectedProductRecord.ProductId)
+		}
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM product_records WHERE product_id = ?")).
+			WithArgs("1").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, last_update_date, purchase_price, sale_price, product_id FROM product_records WHERE product_id = ? ORDER BY sale_price DESC, id DESC LIMIT ? OFFSET ?")).
+			WithArgs("1", 2, 0).
+			WillReturnRows(rows)
+
+		productsRecordsReceived, total, err := r.GetPage(ctx, options)
+
+		assert.Equal(t, expectedProductsRecords, productsRecordsReceived)
+		assert.Equal(t, 3, total)
+		assert.Nil(t, err)
+	})
+
+	t.Run("get_page_error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		r := productRecord.NewRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM product_records")).
+			WillReturnError(sql.ErrConnDone)
+
+		productsRecordsReceived, _, err := r.GetPage(ctx, options)
+
+		assert.Nil(t, productsRecordsReceived)
+		assert.NotNil(t, err)
+	})
+}
+
 func TestRepositoryExists(t *testing.T) {
 	type fields struct {
 		db *sql.DB
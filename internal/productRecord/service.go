@@ -2,25 +2,27 @@ package productRecord
 
 import (
 	"context"
-	"database/sql"
-	"errors"
 
+	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 )
 
 // Errors.
 var (
-	ErrNotFound = errors.New("productRecord not found")
-	ErrConflict = errors.New("productRecord with ProductRecord Number already exists")
+	ErrNotFound = errors2.NotFound("productRecord")
+	ErrConflict = &errors2.Error{Kind: errors2.KindConflict, Entity: "productRecord", Field: "id", Message: "productRecord with ProductRecord Number already exists"}
 )
 
 type Service interface {
-	Save(ctx *context.Context, lastUpdateRate string, purchasePrice, salePrice float32, productId int) (*domain.ProductRecord, error)
-	GetAll(ctx *context.Context) (*[]domain.ProductRecord, error)
-	Get(ctx *context.Context, id int) (*domain.ProductRecord, error)
-	Delete(ctx *context.Context, id int) error
-	Update(ctx *context.Context, lastUpdateRate *string, purchasePrice, salePrice *float32, productId *int, id int) (*domain.ProductRecord, error)
-	NumberRecords(ctx *context.Context, id int) (int, error)
+	Save(ctx context.Context, lastUpdateRate string, purchasePrice, salePrice float32, productId int) (*domain.ProductRecord, error)
+	GetAll(ctx context.Context) (*[]domain.ProductRecord, error)
+	GetPage(ctx context.Context, options listing.Options) (*[]domain.ProductRecord, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.ProductRecord) error) error
+	Get(ctx context.Context, id int) (*domain.ProductRecord, error)
+	Delete(ctx context.Context, id int) error
+	Update(ctx context.Context, lastUpdateRate *string, purchasePrice, salePrice *float32, productId *int, id int) (*domain.ProductRecord, error)
+	NumberRecords(ctx context.Context, id int) (int, error)
 }
 
 type service struct {
@@ -33,8 +35,8 @@ func NewService(r Repository) Service {
 	}
 }
 
-func (s *service) GetAll(ctx *context.Context) (*[]domain.ProductRecord, error) {
-	productsRecords, err := s.productRecordsRepository.GetAll(*ctx)
+func (s *service) GetAll(ctx context.Context) (*[]domain.ProductRecord, error) {
+	productsRecords, err := s.productRecordsRepository.GetAll(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -42,21 +44,29 @@ func (s *service) GetAll(ctx *context.Context) (*[]domain.ProductRecord, error)
 	return &productsRecords, nil
 }
 
-func (s *service) Get(ctx *context.Context, id int) (*domain.ProductRecord, error) {
-	productRecord, err := s.productRecordsRepository.Get(*ctx, id)
+func (s *service) GetPage(ctx context.Context, options listing.Options) (*[]domain.ProductRecord, int, error) {
+	productsRecords, total, err := s.productRecordsRepository.GetPage(ctx, options)
 	if err != nil {
-		switch err {
-		case sql.ErrNoRows:
-			return nil, ErrNotFound
-		default:
-			return nil, err
-		}
+		return nil, 0, err
+	}
+
+	return &productsRecords, total, nil
+}
+
+func (s *service) Each(ctx context.Context, options listing.Options, fn func(domain.ProductRecord) error) error {
+	return s.productRecordsRepository.Each(ctx, options, fn)
+}
+
+func (s *service) Get(ctx context.Context, id int) (*domain.ProductRecord, error) {
+	productRecord, err := s.productRecordsRepository.Get(ctx, id)
+	if err != nil {
+		return nil, err
 	}
 
 	return &productRecord, nil
 }
 
-func (s *service) Save(ctx *context.Context, lastUpdateRate string, purchasePrice, salePrice float32, productId int) (*domain.ProductRecord, error) {
+func (s *service) Save(ctx context.Context, lastUpdateRate string, purchasePrice, salePrice float32, productId int) (*domain.ProductRecord, error) {
 	newProductRecord := domain.ProductRecord{
 		LastUpdateDate: lastUpdateRate,
 		PurchasePrice:  purchasePrice,
@@ -64,19 +74,19 @@ func (s *service) Save(ctx *context.Context, lastUpdateRate string, purchasePric
 		ProductId:      productId,
 	}
 
-	existingProductRecord := s.productRecordsRepository.Exists(*ctx, newProductRecord.ID)
+	existingProductRecord := s.productRecordsRepository.Exists(ctx, newProductRecord.ID)
 
 	if existingProductRecord {
 		return nil, ErrConflict
 	}
 
-	productRecordId, err := s.productRecordsRepository.Save(*ctx, newProductRecord)
+	productRecordId, err := s.productRecordsRepository.Save(ctx, newProductRecord)
 	if err != nil {
 		return nil, err
 
 	}
 
-	savedProductRecord, err := s.productRecordsRepository.Get(*ctx, productRecordId)
+	savedProductRecord, err := s.productRecordsRepository.Get(ctx, productRecordId)
 	if err != nil {
 		return nil, err
 	}
@@ -84,8 +94,8 @@ func (s *service) Save(ctx *context.Context, lastUpdateRate string, purchasePric
 	return &savedProductRecord, nil
 }
 
-func (s *service) Update(ctx *context.Context, lastUpdateDate *string, purchasePrice, salePrice *float32, productId *int, id int) (*domain.ProductRecord, error) {
-	existingProductRecord, err := s.productRecordsRepository.Get(*ctx, id)
+func (s *service) Update(ctx context.Context, lastUpdateDate *string, purchasePrice, salePrice *float32, productId *int, id int) (*domain.ProductRecord, error) {
+	existingProductRecord, err := s.productRecordsRepository.Get(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -101,41 +111,27 @@ func (s *service) Update(ctx *context.Context, lastUpdateDate *string, purchaseP
 	}
 
 	if productId != nil {
-		existingProductRecordSearch := s.productRecordsRepository.Exists(*ctx, *productId)
+		existingProductRecordSearch := s.productRecordsRepository.Exists(ctx, *productId)
 		if existingProductRecordSearch && *productId != existingProductRecord.ProductId {
 			return nil, ErrConflict
 		}
 		existingProductRecord.ProductId = *productId
 	}
 
-	err1 := s.productRecordsRepository.Update(*ctx, existingProductRecord)
+	err1 := s.productRecordsRepository.Update(ctx, existingProductRecord)
 	if err1 != nil {
-		switch err1 {
-		case sql.ErrNoRows:
-			return nil, ErrNotFound
-		default:
-			return nil, err1
-		}
+		return nil, err1
 	}
 
 	return &existingProductRecord, nil
 }
 
-func (s *service) Delete(ctx *context.Context, id int) error {
-	err := s.productRecordsRepository.Delete(*ctx, id)
-	if err != nil {
-		switch err {
-		case sql.ErrNoRows:
-			return ErrNotFound
-		default:
-			return err
-		}
-	}
-	return nil
+func (s *service) Delete(ctx context.Context, id int) error {
+	return s.productRecordsRepository.Delete(ctx, id)
 }
 
-func (s *service) NumberRecords(ctx *context.Context, product_id int) (int, error) {
-	count, err := s.productRecordsRepository.NumberRecords(*ctx, product_id)
+func (s *service) NumberRecords(ctx context.Context, product_id int) (int, error) {
+	count, err := s.productRecordsRepository.NumberRecords(ctx, product_id)
 	if err != nil {
 		return 0, err
 	}
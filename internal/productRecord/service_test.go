@@ -2,7 +2,6 @@ package productRecord_test
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/cmd/server/handlers/productsRecords"
@@ -12,6 +11,7 @@ import (
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/productRecord"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/productRecord/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -33,7 +33,7 @@ func TestGet(t *testing.T) {
 		productRecordRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(*expectedProductRecord, nil)
 
 		service := productRecord.NewService(productRecordRepositoryMock)
-		productRecordReceived, err := service.Get(&ctx, 1)
+		productRecordReceived, err := service.Get(ctx, 1)
 
 		assert.Equal(t, *expectedProductRecord, *productRecordReceived)
 		assert.Equal(t, nil, err)
@@ -44,10 +44,10 @@ func TestGet(t *testing.T) {
 		ctx := context.TODO()
 
 		productRecordRepositoryMock := new(mocks.ProductRecordRepositoryMock)
-		productRecordRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.ProductRecord{}, sql.ErrNoRows)
+		productRecordRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.ProductRecord{}, productRecord.ErrNotFound)
 
 		service := productRecord.NewService(productRecordRepositoryMock)
-		productRecordReceived, err := service.Get(&ctx, 1)
+		productRecordReceived, err := service.Get(ctx, 1)
 
 		assert.Nil(t, productRecordReceived)
 		assert.Equal(t, productRecord.ErrNotFound, err)
@@ -61,7 +61,7 @@ func TestGet(t *testing.T) {
 		productRecordRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.ProductRecord{}, errors.New("error"))
 
 		service := productRecord.NewService(productRecordRepositoryMock)
-		productRecordReceived, err := service.Get(&ctx, 1)
+		productRecordReceived, err := service.Get(ctx, 1)
 
 		assert.Nil(t, productRecordReceived)
 		assert.Equal(t, errors.New("error"), err)
@@ -92,7 +92,7 @@ func TestGetAll(t *testing.T) {
 		productRecordRepositoryMock.On("GetAll", ctx).Return(*expectedProductsRecords, nil)
 
 		service := productRecord.NewService(productRecordRepositoryMock)
-		productsRecordsReceived, err := service.GetAll(&ctx)
+		productsRecordsReceived, err := service.GetAll(ctx)
 
 		assert.Equal(t, *expectedProductsRecords, *productsRecordsReceived)
 		assert.Equal(t, nil, err)
@@ -106,7 +106,48 @@ func TestGetAll(t *testing.T) {
 		productRecordRepositoryMock.On("GetAll", ctx).Return([]domain.ProductRecord{}, errors.New("error"))
 
 		service := productRecord.NewService(productRecordRepositoryMock)
-		productsRecordsReceived, err := service.GetAll(&ctx)
+		productsRecordsReceived, err := service.GetAll(ctx)
+
+		assert.Nil(t, productsRecordsReceived)
+		assert.Equal(t, errors.New("error"), err)
+	})
+}
+
+func TestGetPage(t *testing.T) {
+	options := listing.Options{Limit: listing.DefaultLimit}
+
+	t.Run("getPage_find_page", func(t *testing.T) {
+		expectedProductsRecords := []domain.ProductRecord{
+			{
+				ID:             1,
+				LastUpdateDate: "Test",
+				PurchasePrice:  1,
+				SalePrice:      1,
+				ProductId:      1,
+			},
+		}
+
+		ctx := context.TODO()
+
+		productRecordRepositoryMock := new(mocks.ProductRecordRepositoryMock)
+		productRecordRepositoryMock.On("GetPage", ctx, options).Return(expectedProductsRecords, 4, nil)
+
+		service := productRecord.NewService(productRecordRepositoryMock)
+		productsRecordsReceived, total, err := service.GetPage(ctx, options)
+
+		assert.Equal(t, expectedProductsRecords, *productsRecordsReceived)
+		assert.Equal(t, 4, total)
+		assert.Equal(t, nil, err)
+	})
+
+	t.Run("getPage_unexpected_error", func(t *testing.T) {
+		ctx := context.TODO()
+
+		productRecordRepositoryMock := new(mocks.ProductRecordRepositoryMock)
+		productRecordRepositoryMock.On("GetPage", ctx, options).Return([]domain.ProductRecord{}, 0, errors.New("error"))
+
+		service := productRecord.NewService(productRecordRepositoryMock)
+		productsRecordsReceived, _, err := service.GetPage(ctx, options)
 
 		assert.Nil(t, productsRecordsReceived)
 		assert.Equal(t, errors.New("error"), err)
@@ -122,7 +163,7 @@ func TestDelete(t *testing.T) {
 		productRecordRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(nil)
 
 		service := productRecord.NewService(productRecordRepositoryMock)
-		err := service.Delete(&ctx, 1)
+		err := service.Delete(ctx, 1)
 
 		assert.Nil(t, err)
 	})
@@ -132,10 +173,10 @@ func TestDelete(t *testing.T) {
 		ctx := context.TODO()
 
 		productRecordRepositoryMock := new(mocks.ProductRecordRepositoryMock)
-		productRecordRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(sql.ErrNoRows)
+		productRecordRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(productRecord.ErrNotFound)
 
 		service := productRecord.NewService(productRecordRepositoryMock)
-		err := service.Delete(&ctx, 1)
+		err := service.Delete(ctx, 1)
 
 		assert.Equal(t, productRecord.ErrNotFound, err)
 	})
@@ -147,7 +188,7 @@ func TestDelete(t *testing.T) {
 		productRecordRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(errors.New("error"))
 
 		service := productRecord.NewService(productRecordRepositoryMock)
-		err := service.Delete(&ctx, 1)
+		err := service.Delete(ctx, 1)
 
 		assert.Equal(t, errors.New("error"), err)
 	})
@@ -169,7 +210,7 @@ func TestCreate(t *testing.T) {
 		productRecordRepositoryMock.On("Exists", ctx, mock.AnythingOfType("int")).Return(true)
 
 		service := productRecord.NewService(productRecordRepositoryMock)
-		productRecordSaved, err := service.Save(&ctx, createProductRecordRequestDTO.LastUpdateDate, createProductRecordRequestDTO.PurchasePrice, createProductRecordRequestDTO.SalePrice,
+		productRecordSaved, err := service.Save(ctx, createProductRecordRequestDTO.LastUpdateDate, createProductRecordRequestDTO.PurchasePrice, createProductRecordRequestDTO.SalePrice,
 			createProductRecordRequestDTO.ProductId)
 
 		assert.Equal(t, productRecord.ErrConflict, err)
@@ -193,7 +234,7 @@ func TestCreate(t *testing.T) {
 		productRecordRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.ProductRecord")).Return(0, errors.New("error"))
 
 		service := productRecord.NewService(productRecordRepositoryMock)
-		productRecordSaved, err := service.Save(&ctx, createProductRecordRequestDTO.LastUpdateDate, createProductRecordRequestDTO.PurchasePrice, createProductRecordRequestDTO.SalePrice,
+		productRecordSaved, err := service.Save(ctx, createProductRecordRequestDTO.LastUpdateDate, createProductRecordRequestDTO.PurchasePrice, createProductRecordRequestDTO.SalePrice,
 			createProductRecordRequestDTO.ProductId)
 
 		assert.Equal(t, errors.New("error"), err)
@@ -218,7 +259,7 @@ func TestCreate(t *testing.T) {
 		productRecordRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.ProductRecord{}, errors.New("error"))
 
 		service := productRecord.NewService(productRecordRepositoryMock)
-		productRecordSaved, err := service.Save(&ctx, createProductRecordRequestDTO.LastUpdateDate, createProductRecordRequestDTO.PurchasePrice, createProductRecordRequestDTO.SalePrice,
+		productRecordSaved, err := service.Save(ctx, createProductRecordRequestDTO.LastUpdateDate, createProductRecordRequestDTO.PurchasePrice, createProductRecordRequestDTO.SalePrice,
 			createProductRecordRequestDTO.ProductId)
 
 		assert.Equal(t, errors.New("error"), err)
@@ -249,7 +290,7 @@ func TestCreate(t *testing.T) {
 		productRecordRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(*expectedProductRecord, nil)
 
 		service := productRecord.NewService(productRecordRepositoryMock)
-		productRecordSaved, err := service.Save(&ctx, createProductRecordRequestDTO.LastUpdateDate, createProductRecordRequestDTO.PurchasePrice, createProductRecordRequestDTO.SalePrice,
+		productRecordSaved, err := service.Save(ctx, createProductRecordRequestDTO.LastUpdateDate, createProductRecordRequestDTO.PurchasePrice, createProductRecordRequestDTO.SalePrice,
 			createProductRecordRequestDTO.ProductId)
 
 		assert.Equal(t, productRecordSaved, expectedProductRecord)
@@ -282,7 +323,7 @@ func TestUpdate(t *testing.T) {
 		productRecordRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.ProductRecord")).Return(nil)
 
 		service := productRecord.NewService(productRecordRepositoryMock)
-		productRecordUpdate, err := service.Update(&ctx, updateProductRecordRequestDTO.LastUpdateDate, updateProductRecordRequestDTO.PurchasePrice, updateProductRecordRequestDTO.SalePrice,
+		productRecordUpdate, err := service.Update(ctx, updateProductRecordRequestDTO.LastUpdateDate, updateProductRecordRequestDTO.PurchasePrice, updateProductRecordRequestDTO.SalePrice,
 			updateProductRecordRequestDTO.ProductId, 1)
 
 		assert.Equal(t, productRecordUpdate, originalProductRecord)
@@ -309,10 +350,10 @@ func TestUpdate(t *testing.T) {
 		productRecordRepositoryMock := new(mocks.ProductRecordRepositoryMock)
 		productRecordRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(*originalProductRecord, nil)
 		productRecordRepositoryMock.On("Exists", ctx, mock.AnythingOfType("int")).Return(false)
-		productRecordRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.ProductRecord")).Return(sql.ErrNoRows)
+		productRecordRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.ProductRecord")).Return(productRecord.ErrNotFound)
 
 		service := productRecord.NewService(productRecordRepositoryMock)
-		productRecordUpdate, err := service.Update(&ctx, updateProductRecordRequestDTO.LastUpdateDate, updateProductRecordRequestDTO.PurchasePrice, updateProductRecordRequestDTO.SalePrice,
+		productRecordUpdate, err := service.Update(ctx, updateProductRecordRequestDTO.LastUpdateDate, updateProductRecordRequestDTO.PurchasePrice, updateProductRecordRequestDTO.SalePrice,
 			updateProductRecordRequestDTO.ProductId, 1)
 
 		assert.Equal(t, productRecord.ErrNotFound, err)
@@ -342,7 +383,7 @@ func TestUpdate(t *testing.T) {
 		productRecordRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.ProductRecord")).Return(errors.New("error"))
 
 		service := productRecord.NewService(productRecordRepositoryMock)
-		productRecordUpdate, err := service.Update(&ctx, updateProductRecordRequestDTO.LastUpdateDate, updateProductRecordRequestDTO.PurchasePrice, updateProductRecordRequestDTO.SalePrice,
+		productRecordUpdate, err := service.Update(ctx, updateProductRecordRequestDTO.LastUpdateDate, updateProductRecordRequestDTO.PurchasePrice, updateProductRecordRequestDTO.SalePrice,
 			updateProductRecordRequestDTO.ProductId, 1)
 
 		assert.Equal(t, errors.New("error"), err)
@@ -370,7 +411,7 @@ func TestUpdate(t *testing.T) {
 		productRecordRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.ProductRecord{}, errors.New("error"))
 
 		service := productRecord.NewService(productRecordRepositoryMock)
-		productRecordUpdate, err := service.Update(&ctx, updateProductRecordRequestDTO.LastUpdateDate, updateProductRecordRequestDTO.PurchasePrice, updateProductRecordRequestDTO.SalePrice,
+		productRecordUpdate, err := service.Update(ctx, updateProductRecordRequestDTO.LastUpdateDate, updateProductRecordRequestDTO.PurchasePrice, updateProductRecordRequestDTO.SalePrice,
 			updateProductRecordRequestDTO.ProductId, 1)
 
 		assert.Equal(t, errors.New("error"), err)
@@ -400,7 +441,7 @@ func TestUpdate(t *testing.T) {
 		productRecordRepositoryMock.On("Exists", ctx, mock.AnythingOfType("int")).Return(true)
 
 		service := productRecord.NewService(productRecordRepositoryMock)
-		productRecordUpdate, err := service.Update(&ctx, updateProductRecordRequestDTO.LastUpdateDate, updateProductRecordRequestDTO.PurchasePrice, updateProductRecordRequestDTO.SalePrice,
+		productRecordUpdate, err := service.Update(ctx, updateProductRecordRequestDTO.LastUpdateDate, updateProductRecordRequestDTO.PurchasePrice, updateProductRecordRequestDTO.SalePrice,
 			updateProductRecordRequestDTO.ProductId, 1)
 
 		assert.Equal(t, productRecord.ErrConflict, err)
@@ -417,7 +458,7 @@ func TestGetNumberRecords(t *testing.T) {
 		productRecordRepositoryMock.On("NumberRecords", ctx, mock.AnythingOfType("int")).Return(1, nil)
 
 		service := productRecord.NewService(productRecordRepositoryMock)
-		count, err := service.NumberRecords(&ctx, 1)
+		count, err := service.NumberRecords(ctx, 1)
 
 		assert.Equal(t, 1, count)
 		assert.Nil(t, err)
@@ -431,7 +472,7 @@ func TestGetNumberRecords(t *testing.T) {
 		productRecordRepositoryMock.On("NumberRecords", ctx, mock.AnythingOfType("int")).Return(0, assert.AnError)
 
 		service := productRecord.NewService(productRecordRepositoryMock)
-		count, err := service.NumberRecords(&ctx, 1)
+		count, err := service.NumberRecords(ctx, 1)
 
 		assert.Equal(t, 0, count)
 		assert.NotNil(t, err)
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/productbatches"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -14,13 +16,13 @@ func NewProductBatchesRepositoryMock() *MockIRepository {
 	return &MockIRepository{}
 }
 
-func (m *MockIRepository) SectionProductsReports() ([]domain.ProductBySection, error) {
-	args := m.Called()
+func (m *MockIRepository) SectionProductsReports(ctx context.Context, filter productbatches.SectionReportFilter) ([]domain.ProductBySection, error) {
+	args := m.Called(ctx, filter)
 	return args.Get(0).([]domain.ProductBySection), args.Error(1)
 }
 
-func (m *MockIRepository) SectionProductsReportsBySection(sectionID int) ([]domain.ProductBySection, error) {
-	args := m.Called(sectionID)
+func (m *MockIRepository) SectionProductsReportsBySection(ctx context.Context, sectionID int) ([]domain.ProductBySection, error) {
+	args := m.Called(ctx, sectionID)
 	return args.Get(0).([]domain.ProductBySection), args.Error(1)
 }
 
@@ -34,6 +36,51 @@ func (m *MockIRepository) Get(ctx context.Context, id int) (domain.ProductBatche
 	return args.Get(0).(domain.ProductBatches), args.Error(1)
 }
 
+func (m *MockIRepository) GetPage(ctx context.Context, options listing.Options) ([]domain.ProductBatches, int, error) {
+	args := m.Called(ctx, options)
+	return args.Get(0).([]domain.ProductBatches), args.Int(1), args.Error(2)
+}
+
+func (m *MockIRepository) Each(ctx context.Context, options listing.Options, fn func(domain.ProductBatches) error) error {
+	args := m.Called(ctx, options)
+	for _, productBatch := range args.Get(0).([]domain.ProductBatches) {
+		if err := fn(productBatch); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (m *MockIRepository) GetPageBySection(ctx context.Context, sectionID int, options listing.Options) ([]domain.SectionProductBatch, int, error) {
+	args := m.Called(ctx, sectionID, options)
+	return args.Get(0).([]domain.SectionProductBatch), args.Int(1), args.Error(2)
+}
+
+func (m *MockIRepository) Update(ctx context.Context, product domain.ProductBatches) error {
+	args := m.Called(ctx, product)
+	return args.Error(0)
+}
+
+func (m *MockIRepository) Delete(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockIRepository) IncreaseCurrentQuantity(ctx context.Context, id int, quantity int) error {
+	args := m.Called(ctx, id, quantity)
+	return args.Error(0)
+}
+
+func (m *MockIRepository) GetExpiring(ctx context.Context, days int) ([]domain.ExpiringBatch, error) {
+	args := m.Called(ctx, days)
+	return args.Get(0).([]domain.ExpiringBatch), args.Error(1)
+}
+
+func (m *MockIRepository) GetAvailableByProduct(ctx context.Context, productID int) ([]domain.ProductBatches, error) {
+	args := m.Called(ctx, productID)
+	return args.Get(0).([]domain.ProductBatches), args.Error(1)
+}
+
 func (m *MockIRepository) ExistsProductBatch(ctx context.Context, batchNumber int) bool {
 	args := m.Called(ctx, batchNumber)
 	return args.Bool(0)
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/productbatches"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -11,19 +13,64 @@ type ProductBatchServiceMock struct {
 	mock.Mock
 }
 
-func (p *ProductBatchServiceMock) Save(ctx *context.Context, product domain.ProductBatches) (*domain.ProductBatches, error) {
+func (p *ProductBatchServiceMock) Save(ctx context.Context, product domain.ProductBatches) (*domain.ProductBatches, error) {
 	args := p.Called(ctx, product)
 	r0, r1 := args.Get(0).(*domain.ProductBatches), args.Error(1)
 	return r0, r1
 }
 
-func (p *ProductBatchServiceMock) SectionProductsReports() ([]domain.ProductBySection, error) {
-	args := p.Called()
+func (p *ProductBatchServiceMock) SectionProductsReports(ctx context.Context, filter productbatches.SectionReportFilter) ([]domain.ProductBySection, error) {
+	args := p.Called(ctx, filter)
 	return args.Get(0).([]domain.ProductBySection), args.Error(1)
 }
-func (p *ProductBatchServiceMock) SectionProductsReportsBySection(sectionID int) ([]domain.ProductBySection, error) {
-	args := p.Called(sectionID)
+func (p *ProductBatchServiceMock) SectionProductsReportsBySection(ctx context.Context, sectionID int) ([]domain.ProductBySection, error) {
+	args := p.Called(ctx, sectionID)
 	return args.Get(0).([]domain.ProductBySection), args.Error(1)
 }
 
 //mockgen -source=../productbatches/repository.go -destination=internal/productbatches/mocks/repository_mock.go -package=mocks
+
+func (p *ProductBatchServiceMock) Get(ctx context.Context, id int) (*domain.ProductBatches, error) {
+	args := p.Called(ctx, id)
+	return args.Get(0).(*domain.ProductBatches), args.Error(1)
+}
+
+func (p *ProductBatchServiceMock) GetPage(ctx context.Context, options listing.Options) (*[]domain.ProductBatches, int, error) {
+	args := p.Called(ctx, options)
+	return args.Get(0).(*[]domain.ProductBatches), args.Int(1), args.Error(2)
+}
+
+func (p *ProductBatchServiceMock) Each(ctx context.Context, options listing.Options, fn func(domain.ProductBatches) error) error {
+	args := p.Called(ctx, options)
+	for _, productBatch := range args.Get(0).([]domain.ProductBatches) {
+		if err := fn(productBatch); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (p *ProductBatchServiceMock) GetPageBySection(ctx context.Context, sectionID int, options listing.Options) (*[]domain.SectionProductBatch, int, error) {
+	args := p.Called(ctx, sectionID, options)
+	return args.Get(0).(*[]domain.SectionProductBatch), args.Int(1), args.Error(2)
+}
+
+func (p *ProductBatchServiceMock) Update(ctx context.Context, id int, currentQuantity *int, currentTemperature *float64) (*domain.ProductBatches, error) {
+	args := p.Called(ctx, id, currentQuantity, currentTemperature)
+	return args.Get(0).(*domain.ProductBatches), args.Error(1)
+}
+
+func (p *ProductBatchServiceMock) Delete(ctx context.Context, id int) error {
+	args := p.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (p *ProductBatchServiceMock) ExpiringReport(ctx context.Context, days int) ([]domain.ExpiringBatchesByWarehouse, error) {
+	args := p.Called(ctx, days)
+	return args.Get(0).([]domain.ExpiringBatchesByWarehouse), args.Error(1)
+}
+
+func (p *ProductBatchServiceMock) Pick(ctx context.Context, productID int, quantity int) ([]domain.BatchPick, error) {
+	args := p.Called(ctx, productID, quantity)
+	return args.Get(0).([]domain.BatchPick), args.Error(1)
+}
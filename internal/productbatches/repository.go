@@ -3,24 +3,61 @@ package productbatches
 import (
 	"context"
 	"database/sql"
+	"strconv"
+	"strings"
 
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 )
 
 type IRepository interface {
-	SectionProductsReports() ([]domain.ProductBySection, error)
-	SectionProductsReportsBySection(sectionID int) ([]domain.ProductBySection, error)
+	SectionProductsReports(ctx context.Context, filter SectionReportFilter) ([]domain.ProductBySection, error)
+	SectionProductsReportsBySection(ctx context.Context, sectionID int) ([]domain.ProductBySection, error)
 	Save(ctx context.Context, product domain.ProductBatches) (int, error)
 	Get(ctx context.Context, id int) (domain.ProductBatches, error)
+	GetPage(ctx context.Context, options listing.Options) ([]domain.ProductBatches, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.ProductBatches) error) error
+	GetPageBySection(ctx context.Context, sectionID int, options listing.Options) ([]domain.SectionProductBatch, int, error)
+	Update(ctx context.Context, product domain.ProductBatches) error
+	Delete(ctx context.Context, id int) error
+	IncreaseCurrentQuantity(ctx context.Context, id int, quantity int) error
+	GetExpiring(ctx context.Context, days int) ([]domain.ExpiringBatch, error)
+	GetAvailableByProduct(ctx context.Context, productID int) ([]domain.ProductBatches, error)
 	ExistsProductBatch(ctx context.Context, batchNumber int) bool
 }
 
 const (
 	ExistProductBatch = "SELECT batch_number FROM product_batches WHERE batch_number=?"
 	Get               = "SELECT * from product_batches WHERE id=?"
-	//Get               = "SELECT id, batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minimum_temperature, product_id, section_id FROM product_batches WHERE id=?"
+
+	sectionProductsReportSelect = "SELECT s.id, s.section_number, w.id, w.warehouse_code, COUNT(pb.id), COALESCE(SUM(pb.current_quantity), 0) FROM sections s JOIN warehouses w ON s.warehouse_id = w.id LEFT JOIN product_batches pb ON pb.section_id = s.id"
+	sectionProductsReportGroup  = " GROUP BY s.id, s.section_number, w.id, w.warehouse_code ORDER BY s.id"
 )
 
+// SectionReportFilter narrows the section products report; zero values are not filtered.
+type SectionReportFilter struct {
+	WarehouseID   int
+	ProductTypeID int
+}
+
+// Fields are the product batch fields list requests can sort and filter by.
+var Fields = listing.Fields{
+	"id":                  "pb.id",
+	"batch_number":        "pb.batch_number",
+	"current_quantity":    "pb.current_quantity",
+	"current_temperature": "pb.current_temperature",
+	"due_date":            "pb.due_date",
+	"initial_quantity":    "pb.initial_quantity",
+	"manufacturing_date":  "pb.manufacturing_date",
+	"manufacturing_hour":  "pb.manufacturing_hour",
+	"minimum_temperature": "pb.minimum_temperature",
+	"product_id":          "pb.product_id",
+	"section_id":          "pb.section_id",
+}
+
+const columns = "pb.id, pb.batch_number, pb.current_quantity, pb.current_temperature, pb.due_date, pb.initial_quantity, pb.manufacturing_date, pb.manufacturing_hour, pb.minimum_temperature, pb.product_id, pb.section_id"
+
 type repository struct {
 	db *sql.DB
 }
@@ -32,16 +69,16 @@ func NewRepository(db *sql.DB) IRepository {
 }
 
 func (r *repository) ExistsProductBatch(ctx context.Context, batchNumber int) bool {
-	row := r.db.QueryRow(ExistProductBatch, batchNumber)
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, ExistProductBatch, batchNumber)
 	err := row.Scan(&batchNumber)
 	return err == nil
 }
 func (r *repository) Get(ctx context.Context, id int) (domain.ProductBatches, error) {
-	row := r.db.QueryRow(Get, id)
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, Get, id)
 	pb := domain.ProductBatches{}
 	err := row.Scan(&pb.ID, &pb.BatchNumber, &pb.CurrentQuantity, &pb.CurrentTemperature, &pb.DueDate, &pb.InitialQuantity, &pb.ManufacturingDate, &pb.ManufacturingHour, &pb.MinimumTemperature, &pb.ProductID, &pb.SectionID)
 	if err != nil {
-		return domain.ProductBatches{}, err
+		return domain.ProductBatches{}, database.TranslateError(err, "product batch")
 	}
 
 	return pb, nil
@@ -49,11 +86,11 @@ func (r *repository) Get(ctx context.Context, id int) (domain.ProductBatches, er
 
 func (r *repository) Save(ctx context.Context, product domain.ProductBatches) (int, error) {
 	query := "INSERT INTO product_batches ( batch_number, current_quantity, current_temperature, due_date, initial_quantity, manufacturing_date, manufacturing_hour, minimum_temperature, product_id, section_id) VALUES (?,?,?,?,?,?,?,?,?,?)"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, query)
 	if err != nil {
 		return 0, err
 	}
-	res, err := stmt.Exec(
+	res, err := stmt.ExecContext(ctx,
 		&product.BatchNumber,
 		&product.CurrentQuantity,
 		&product.CurrentTemperature,
@@ -66,7 +103,7 @@ func (r *repository) Save(ctx context.Context, product domain.ProductBatches) (i
 		&product.SectionID,
 	)
 	if err != nil {
-		return 0, err
+		return 0, database.TranslateError(err, "product batch")
 	}
 
 	id, err := res.LastInsertId()
@@ -77,41 +114,246 @@ func (r *repository) Save(ctx context.Context, product domain.ProductBatches) (i
 	return int(id), nil
 }
 
-func (r *repository) SectionProductsReports() ([]domain.ProductBySection, error) {
-	rows, err := r.db.Query("SELECT count(pb.id) as `products_count`, pb.section_id, s.section_number FROM product_batches pb JOIN sections s ON pb.section_id = s.id GROUP BY pb.section_id")
+func (r *repository) GetPage(ctx context.Context, options listing.Options) ([]domain.ProductBatches, int, error) {
+	var total int
+	query, args := options.CountQuery("SELECT COUNT(*) FROM product_batches pb")
+	if err := database.Conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	productBatches := make([]domain.ProductBatches, 0)
+	err := r.Each(ctx, options, func(pb domain.ProductBatches) error {
+		productBatches = append(productBatches, pb)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return productBatches, total, nil
+}
+
+func (r *repository) Each(ctx context.Context, options listing.Options, fn func(domain.ProductBatches) error) error {
+	query, args := options.Query("SELECT " + columns + " FROM product_batches pb")
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		pb := domain.ProductBatches{}
+		if err := rows.Scan(&pb.ID, &pb.BatchNumber, &pb.CurrentQuantity, &pb.CurrentTemperature, &pb.DueDate, &pb.InitialQuantity, &pb.ManufacturingDate, &pb.ManufacturingHour, &pb.MinimumTemperature, &pb.ProductID, &pb.SectionID); err != nil {
+			return err
+		}
+		if err := fn(pb); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// GetPageBySection lists the batches stored in the section with the description of their product.
+func (r *repository) GetPageBySection(ctx context.Context, sectionID int, options listing.Options) ([]domain.SectionProductBatch, int, error) {
+	filters := make([]listing.Filter, 0, len(options.Filters)+1)
+	filters = append(filters, listing.Filter{Column: "pb.section_id", Value: strconv.Itoa(sectionID)})
+	options.Filters = append(filters, options.Filters...)
+
+	var total int
+	query, args := options.CountQuery("SELECT COUNT(*) FROM product_batches pb")
+	if err := database.Conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query, args = options.Query("SELECT " + columns + ", p.description FROM product_batches pb JOIN products p ON pb.product_id = p.id")
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	productBatches := make([]domain.SectionProductBatch, 0)
+	for rows.Next() {
+		pb := domain.SectionProductBatch{}
+		if err := rows.Scan(&pb.ID, &pb.BatchNumber, &pb.CurrentQuantity, &pb.CurrentTemperature, &pb.DueDate, &pb.InitialQuantity, &pb.ManufacturingDate, &pb.ManufacturingHour, &pb.MinimumTemperature, &pb.ProductID, &pb.SectionID, &pb.ProductDescription); err != nil {
+			return nil, 0, err
+		}
+		productBatches = append(productBatches, pb)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return productBatches, total, nil
+}
+
+// Update stores the current quantity and temperature of the batch, the only fields that change after it is placed.
+func (r *repository) Update(ctx context.Context, product domain.ProductBatches) error {
+	query := "UPDATE product_batches SET current_quantity=?, current_temperature=? WHERE id=?"
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, &product.CurrentQuantity, &product.CurrentTemperature, &product.ID)
+	if err != nil {
+		return database.TranslateError(err, "product batch")
+	}
+
+	return nil
+}
+
+func (r *repository) Delete(ctx context.Context, id int) error {
+	query := "DELETE FROM product_batches WHERE id=?"
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return database.TranslateError(err, "product batch")
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affect < 1 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// IncreaseCurrentQuantity adds quantity to the current quantity of the batch in a single statement.
+func (r *repository) IncreaseCurrentQuantity(ctx context.Context, id int, quantity int) error {
+	query := "UPDATE product_batches SET current_quantity=current_quantity+? WHERE id=?"
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, quantity, id)
+	if err != nil {
+		return err
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affect < 1 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// GetExpiring lists the batches with stock due from today to days ahead, ordered by warehouse, section and due date.
+func (r *repository) GetExpiring(ctx context.Context, days int) ([]domain.ExpiringBatch, error) {
+	query := "SELECT pb.id, pb.batch_number, pb.product_id, pb.current_quantity, pb.due_date, s.id, s.section_number, s.warehouse_id FROM product_batches pb JOIN sections s ON pb.section_id = s.id WHERE pb.current_quantity > 0 AND pb.due_date BETWEEN CURDATE() AND DATE_ADD(CURDATE(), INTERVAL ? DAY) ORDER BY s.warehouse_id, s.id, pb.due_date, pb.id"
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, days)
 	if err != nil {
 		return nil, err
 	}
-	var productsBySection []domain.ProductBySection
+	defer rows.Close()
+
+	batches := make([]domain.ExpiringBatch, 0)
 	for rows.Next() {
-		var product domain.ProductBySection
-		err := rows.Scan(&product.ProductsCount, &product.SectionID, &product.SectionNumber)
-		if err != nil {
+		b := domain.ExpiringBatch{}
+		if err := rows.Scan(&b.ID, &b.BatchNumber, &b.ProductID, &b.CurrentQuantity, &b.DueDate, &b.SectionID, &b.SectionNumber, &b.WarehouseID); err != nil {
 			return nil, err
 		}
-		productsBySection = append(productsBySection, product)
+		batches = append(batches, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return batches, nil
+}
+
+// GetAvailableByProduct lists the unexpired batches of the product with stock, first due first.
+// The rows stay locked until the surrounding unit of work ends, so concurrent picks wait for each other.
+func (r *repository) GetAvailableByProduct(ctx context.Context, productID int) ([]domain.ProductBatches, error) {
+	query := "SELECT " + columns + " FROM product_batches pb WHERE pb.product_id = ? AND pb.current_quantity > 0 AND pb.due_date >= CURDATE() ORDER BY pb.due_date, pb.id FOR UPDATE"
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	productBatches := make([]domain.ProductBatches, 0)
+	for rows.Next() {
+		pb := domain.ProductBatches{}
+		if err := rows.Scan(&pb.ID, &pb.BatchNumber, &pb.CurrentQuantity, &pb.CurrentTemperature, &pb.DueDate, &pb.InitialQuantity, &pb.ManufacturingDate, &pb.ManufacturingHour, &pb.MinimumTemperature, &pb.ProductID, &pb.SectionID); err != nil {
+			return nil, err
+		}
+		productBatches = append(productBatches, pb)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return productBatches, nil
+}
+
+// SectionProductsReports counts the batches and sums the product units stored in each section of the
+// warehouse and product type informed in the filter. Sections without batches are reported with zeros.
+func (r *repository) SectionProductsReports(ctx context.Context, filter SectionReportFilter) ([]domain.ProductBySection, error) {
+	var conditions []string
+	var args []interface{}
+	if filter.WarehouseID > 0 {
+		conditions = append(conditions, "s.warehouse_id = ?")
+		args = append(args, filter.WarehouseID)
+	}
+	if filter.ProductTypeID > 0 {
+		conditions = append(conditions, "s.product_type_id = ?")
+		args = append(args, filter.ProductTypeID)
+	}
+
+	return r.sectionProductsReports(ctx, conditions, args...)
+}
+
+// SectionProductsReportsBySection reports a single section, returning ErrNotFoundSection when it does not exist.
+func (r *repository) SectionProductsReportsBySection(ctx context.Context, sectionID int) ([]domain.ProductBySection, error) {
+	productsBySection, err := r.sectionProductsReports(ctx, []string{"s.id = ?"}, sectionID)
+	if err != nil {
+		return nil, err
+	}
+	if len(productsBySection) == 0 {
+		return nil, ErrNotFoundSection
 	}
 	return productsBySection, nil
 }
 
-func (r *repository) SectionProductsReportsBySection(sectionID int) ([]domain.ProductBySection, error) {
-	rows, err := r.db.Query("SELECT count(pb.id) as `products_count`, pb.section_id, s.section_number FROM product_batches pb JOIN sections s ON pb.section_id = s.id WHERE pb.section_id = ? GROUP BY pb.section_id", sectionID)
+func (r *repository) sectionProductsReports(ctx context.Context, conditions []string, args ...interface{}) ([]domain.ProductBySection, error) {
+	query := sectionProductsReportSelect
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += sectionProductsReportGroup
+
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	found := false
-	var productBySection []domain.ProductBySection
+	defer rows.Close()
+
+	productsBySection := make([]domain.ProductBySection, 0)
 	for rows.Next() {
-		var pb domain.ProductBySection
-		err := rows.Scan(&pb.SectionID, &pb.SectionNumber, &pb.ProductsCount)
-		if err != nil {
-			return productBySection, err
+		var report domain.ProductBySection
+		if err := rows.Scan(&report.SectionID, &report.SectionNumber, &report.WarehouseID, &report.WarehouseCode, &report.BatchesCount, &report.ProductsCount); err != nil {
+			return nil, err
 		}
-		productBySection = append(productBySection, pb)
-		found = true
+		productsBySection = append(productsBySection, report)
 	}
-	if !found {
-		return productBySection, sql.ErrNoRows
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
-	return productBySection, nil
+
+	return productsBySection, nil
 }
@@ -9,12 +9,16 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/productbatches"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/assert"
 )
 
 var (
-	expectedQuery = `SELECT count\(pb\.id\) as ` + "`products_count`" + `, pb.section_id, s.section_number FROM product_batches pb JOIN sections s ON pb.section_id = s.id WHERE pb.section_id = \? GROUP BY pb.section_id`
-	query         = `SELECT count\(pb\.id\) as ` + "`products_count`" + `, pb.section_id, s.section_number FROM product_batches pb JOIN sections s ON pb.section_id = s.id GROUP BY pb.section_id`
+	reportSelect  = "SELECT s.id, s.section_number, w.id, w.warehouse_code, COUNT(pb.id), COALESCE(SUM(pb.current_quantity), 0) FROM sections s JOIN warehouses w ON s.warehouse_id = w.id LEFT JOIN product_batches pb ON pb.section_id = s.id"
+	reportGroup   = " GROUP BY s.id, s.section_number, w.id, w.warehouse_code ORDER BY s.id"
+	expectedQuery = regexp.QuoteMeta(reportSelect + " WHERE s.id = ?" + reportGroup)
+	query         = regexp.QuoteMeta(reportSelect + reportGroup)
+	reportColumns = []string{"section_id", "section_number", "warehouse_id", "warehouse_code", "batches_count", "products_count"}
 )
 
 func TestRepositoryExistsProductBatch(t *testing.T) {
@@ -87,6 +91,166 @@ func TestRepositoryGet(t *testing.T) {
 		assert.Equal(t, err, nil)
 	})
 }
+func TestRepositoryGetPage(t *testing.T) {
+	expectedPB := domain.ProductBatches{
+		ID:                 1,
+		BatchNumber:        123,
+		CurrentQuantity:    10,
+		CurrentTemperature: 25.5,
+		DueDate:            "2023-07-10",
+		InitialQuantity:    100,
+		ManufacturingDate:  "2023-07-01",
+		ManufacturingHour:  8,
+		MinimumTemperature: 20.0,
+		ProductID:          456,
+		SectionID:          789,
+	}
+	columns := []string{"id", "batch_number", "current_quantity", "current_temperature", "due_date", "initial_quantity", "manufacturing_date", "manufacturing_hour", "minimum_temperature", "product_id", "section_id"}
+
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	ctx := context.TODO()
+
+	t.Run("GetPage", func(t *testing.T) {
+		r := productbatches.NewRepository(db)
+		options := listing.Options{
+			Limit: listing.DefaultLimit,
+			Sort:  "pb.due_date",
+			Filters: []listing.Filter{
+				{Column: "pb.product_id", Value: "456"},
+				{Column: "pb.due_date", Operator: listing.OperatorFrom, Value: "2023-07-01"},
+			},
+		}
+
+		mock.ExpectQuery("SELECT COUNT(*) FROM product_batches pb WHERE pb.product_id = ? AND pb.due_date >= ?").
+			WithArgs("456", "2023-07-01").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery("SELECT pb.id, pb.batch_number, pb.current_quantity, pb.current_temperature, pb.due_date, pb.initial_quantity, pb.manufacturing_date, pb.manufacturing_hour, pb.minimum_temperature, pb.product_id, pb.section_id FROM product_batches pb WHERE pb.product_id = ? AND pb.due_date >= ? ORDER BY pb.due_date ASC LIMIT ? OFFSET ?").
+			WithArgs("456", "2023-07-01", listing.DefaultLimit, 0).
+			WillReturnRows(sqlmock.NewRows(columns).
+				AddRow(expectedPB.ID, expectedPB.BatchNumber, expectedPB.CurrentQuantity, expectedPB.CurrentTemperature, expectedPB.DueDate, expectedPB.InitialQuantity, expectedPB.ManufacturingDate, expectedPB.ManufacturingHour, expectedPB.MinimumTemperature, expectedPB.ProductID, expectedPB.SectionID))
+
+		productBatches, total, err := r.GetPage(ctx, options)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, []domain.ProductBatches{expectedPB}, productBatches)
+	})
+
+	t.Run("GetPage count error", func(t *testing.T) {
+		r := productbatches.NewRepository(db)
+
+		mock.ExpectQuery("SELECT COUNT(*) FROM product_batches pb").WillReturnError(sql.ErrConnDone)
+
+		_, _, err := r.GetPage(ctx, listing.Options{Limit: listing.DefaultLimit})
+
+		assert.Equal(t, sql.ErrConnDone, err)
+	})
+
+	t.Run("GetPageBySection", func(t *testing.T) {
+		r := productbatches.NewRepository(db)
+		options := listing.Options{Limit: listing.DefaultLimit, Sort: "pb.id"}
+
+		mock.ExpectQuery("SELECT COUNT(*) FROM product_batches pb WHERE pb.section_id = ?").
+			WithArgs("789").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+		mock.ExpectQuery("SELECT pb.id, pb.batch_number, pb.current_quantity, pb.current_temperature, pb.due_date, pb.initial_quantity, pb.manufacturing_date, pb.manufacturing_hour, pb.minimum_temperature, pb.product_id, pb.section_id, p.description FROM product_batches pb JOIN products p ON pb.product_id = p.id WHERE pb.section_id = ? ORDER BY pb.id ASC LIMIT ? OFFSET ?").
+			WithArgs("789", listing.DefaultLimit, 0).
+			WillReturnRows(sqlmock.NewRows(append(columns, "description")).
+				AddRow(expectedPB.ID, expectedPB.BatchNumber, expectedPB.CurrentQuantity, expectedPB.CurrentTemperature, expectedPB.DueDate, expectedPB.InitialQuantity, expectedPB.ManufacturingDate, expectedPB.ManufacturingHour, expectedPB.MinimumTemperature, expectedPB.ProductID, expectedPB.SectionID, "Product 1"))
+
+		productBatches, total, err := r.GetPageBySection(ctx, 789, options)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, []domain.SectionProductBatch{{ProductBatches: expectedPB, ProductDescription: "Product 1"}}, productBatches)
+		assert.Empty(t, options.Filters)
+	})
+}
+
+func TestRepositoryUpdate(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	ctx := context.TODO()
+	query := "UPDATE product_batches SET current_quantity=?, current_temperature=? WHERE id=?"
+
+	t.Run("Update", func(t *testing.T) {
+		r := productbatches.NewRepository(db)
+
+		mock.ExpectPrepare(query)
+		mock.ExpectExec(query).WithArgs(20, -18.5, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := r.Update(ctx, domain.ProductBatches{ID: 1, CurrentQuantity: 20, CurrentTemperature: -18.5})
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("Update error", func(t *testing.T) {
+		r := productbatches.NewRepository(db)
+
+		mock.ExpectPrepare(query)
+		mock.ExpectExec(query).WithArgs(20, -18.5, 1).WillReturnError(sql.ErrConnDone)
+
+		err := r.Update(ctx, domain.ProductBatches{ID: 1, CurrentQuantity: 20, CurrentTemperature: -18.5})
+
+		assert.Equal(t, sql.ErrConnDone, err)
+	})
+}
+
+func TestRepositoryDelete(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	ctx := context.TODO()
+	query := "DELETE FROM product_batches WHERE id=?"
+
+	t.Run("Delete", func(t *testing.T) {
+		r := productbatches.NewRepository(db)
+
+		mock.ExpectPrepare(query)
+		mock.ExpectExec(query).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := r.Delete(ctx, 1)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("Delete not found", func(t *testing.T) {
+		r := productbatches.NewRepository(db)
+
+		mock.ExpectPrepare(query)
+		mock.ExpectExec(query).WithArgs(99).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := r.Delete(ctx, 99)
+
+		assert.Equal(t, productbatches.ErrNotFound, err)
+	})
+}
+
+func TestRepositoryIncreaseCurrentQuantity(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	ctx := context.TODO()
+	query := "UPDATE product_batches SET current_quantity=current_quantity+? WHERE id=?"
+
+	t.Run("IncreaseCurrentQuantity", func(t *testing.T) {
+		r := productbatches.NewRepository(db)
+
+		mock.ExpectPrepare(query)
+		mock.ExpectExec(query).WithArgs(10, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := r.IncreaseCurrentQuantity(ctx, 1, 10)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("IncreaseCurrentQuantity not found", func(t *testing.T) {
+		r := productbatches.NewRepository(db)
+
+		mock.ExpectPrepare(query)
+		mock.ExpectExec(query).WithArgs(10, 99).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := r.IncreaseCurrentQuantity(ctx, 99, 10)
+
+		assert.Equal(t, productbatches.ErrNotFound, err)
+	})
+}
+
 func TestRepositorySave(t *testing.T) {
 	// type fields struct {
 	// 	db *sql.DB
@@ -200,39 +364,57 @@ func TestRepositorySectionProductsReports(t *testing.T) {
 			{
 				SectionID:     1,
 				SectionNumber: "10",
+				WarehouseID:   1,
+				WarehouseCode: "WH-1",
+				BatchesCount:  2,
 				ProductsCount: 100,
 			},
 			{
 				SectionID:     2,
 				SectionNumber: "20",
-				ProductsCount: 200,
+				WarehouseID:   1,
+				WarehouseCode: "WH-1",
+				BatchesCount:  0,
+				ProductsCount: 0,
 			},
 		}
 		r := productbatches.NewRepository(db)
 
-		rows := sqlmock.NewRows([]string{"products_count", "section_id", "section_number"})
+		rows := sqlmock.NewRows(reportColumns)
 
 		for _, reportProduct := range expectedReportProducts {
-			rows.AddRow(reportProduct.ProductsCount, reportProduct.SectionID, reportProduct.SectionNumber)
+			rows.AddRow(reportProduct.SectionID, reportProduct.SectionNumber, reportProduct.WarehouseID, reportProduct.WarehouseCode, reportProduct.BatchesCount, reportProduct.ProductsCount)
 		}
 		mock.ExpectQuery(query).WillReturnRows(rows)
 
-		actualReportProducts, err := r.SectionProductsReports()
+		actualReportProducts, err := r.SectionProductsReports(context.TODO(), productbatches.SectionReportFilter{})
 
 		assert.Equal(t, expectedReportProducts, actualReportProducts)
 		assert.Nil(t, err)
 	})
 
+	t.Run("GET ALL - Filtered by warehouse and product type", func(t *testing.T) {
+		r := productbatches.NewRepository(db)
+		mock.ExpectQuery(regexp.QuoteMeta(reportSelect+" WHERE s.warehouse_id = ? AND s.product_type_id = ?"+reportGroup)).
+			WithArgs(1, 3).
+			WillReturnRows(sqlmock.NewRows(reportColumns).AddRow(1, "10", 1, "WH-1", 2, 100))
+
+		actualReportProducts, err := r.SectionProductsReports(context.TODO(), productbatches.SectionReportFilter{WarehouseID: 1, ProductTypeID: 3})
+
+		assert.Nil(t, err)
+		assert.Len(t, actualReportProducts, 1)
+	})
+
 	t.Run("GET ALL - Error", func(t *testing.T) {
 		r := productbatches.NewRepository(db)
 		mock.ExpectQuery(query).
 			WithArgs().
-			WillReturnError(sql.ErrNoRows)
+			WillReturnError(sql.ErrConnDone)
 
-		actualReportProducts, err := r.SectionProductsReports()
+		actualReportProducts, err := r.SectionProductsReports(context.TODO(), productbatches.SectionReportFilter{})
 
 		assert.Equal(t, []domain.ProductBySection(nil), actualReportProducts)
-		assert.NotNil(t, err)
+		assert.Equal(t, sql.ErrConnDone, err)
 	})
 }
 func TestRepositorySectionProductsReportsBySection(t *testing.T) {
@@ -242,30 +424,81 @@ func TestRepositorySectionProductsReportsBySection(t *testing.T) {
 			{
 				SectionID:     1,
 				SectionNumber: "10",
+				WarehouseID:   1,
+				WarehouseCode: "WH-1",
+				BatchesCount:  2,
 				ProductsCount: 100,
 			},
 		}
 
 		r := productbatches.NewRepository(db)
-		rows := sqlmock.NewRows([]string{"section_id", "section_number", "products_count"})
+		rows := sqlmock.NewRows(reportColumns)
 		for _, reportProduct := range expectedReportProductsBySection {
-			rows.AddRow(reportProduct.SectionID, reportProduct.SectionNumber, reportProduct.ProductsCount)
+			rows.AddRow(reportProduct.SectionID, reportProduct.SectionNumber, reportProduct.WarehouseID, reportProduct.WarehouseCode, reportProduct.BatchesCount, reportProduct.ProductsCount)
 		}
 		mock.ExpectQuery(expectedQuery).WithArgs(expectedReportProductsBySection[0].SectionID).WillReturnRows(rows)
 
-		actualReportProductsBySection, error := r.SectionProductsReportsBySection(1)
+		actualReportProductsBySection, error := r.SectionProductsReportsBySection(context.TODO(), 1)
 
 		assert.Equal(t, actualReportProductsBySection, expectedReportProductsBySection)
 		assert.Nil(t, error)
 	})
 	t.Run("SectionID - FALSE", func(t *testing.T) {
 		r := productbatches.NewRepository(db)
-		rows := sqlmock.NewRows([]string{"section_id", "section_number", "products_count"})
+		rows := sqlmock.NewRows(reportColumns)
 		mock.ExpectQuery(expectedQuery).WillReturnRows(rows)
 
-		actualReportProductsBySection, error := r.SectionProductsReportsBySection(3)
+		actualReportProductsBySection, error := r.SectionProductsReportsBySection(context.TODO(), 3)
 
 		assert.Empty(t, actualReportProductsBySection, []domain.ProductBySection{})
-		assert.NotNil(t, error)
+		assert.Equal(t, productbatches.ErrNotFoundSection, error)
+	})
+	t.Run("SectionID - Query Error", func(t *testing.T) {
+		r := productbatches.NewRepository(db)
+		mock.ExpectQuery(expectedQuery).WithArgs(1).WillReturnError(sql.ErrConnDone)
+
+		actualReportProductsBySection, error := r.SectionProductsReportsBySection(context.TODO(), 1)
+
+		assert.Nil(t, actualReportProductsBySection)
+		assert.Equal(t, sql.ErrConnDone, error)
+	})
+}
+
+func TestRepositoryGetExpiring(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	ctx := context.TODO()
+	query := "SELECT pb.id, pb.batch_number, pb.product_id, pb.current_quantity, pb.due_date, s.id, s.section_number, s.warehouse_id FROM product_batches pb JOIN sections s ON pb.section_id = s.id WHERE pb.current_quantity > 0 AND pb.due_date BETWEEN CURDATE() AND DATE_ADD(CURDATE(), INTERVAL ? DAY) ORDER BY s.warehouse_id, s.id, pb.due_date, pb.id"
+
+	t.Run("GetExpiring", func(t *testing.T) {
+		r := productbatches.NewRepository(db)
+
+		mock.ExpectQuery(query).WithArgs(7).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "batch_number", "product_id", "current_quantity", "due_date", "section_id", "section_number", "warehouse_id"}).
+				AddRow(1, 123, 456, 10, "2023-07-10", 789, 3, 2))
+
+		batches, err := r.GetExpiring(ctx, 7)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []domain.ExpiringBatch{{ID: 1, BatchNumber: 123, ProductID: 456, CurrentQuantity: 10, DueDate: "2023-07-10", SectionID: 789, SectionNumber: 3, WarehouseID: 2}}, batches)
+	})
+}
+
+func TestRepositoryGetAvailableByProduct(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	ctx := context.TODO()
+	query := "SELECT pb.id, pb.batch_number, pb.current_quantity, pb.current_temperature, pb.due_date, pb.initial_quantity, pb.manufacturing_date, pb.manufacturing_hour, pb.minimum_temperature, pb.product_id, pb.section_id FROM product_batches pb WHERE pb.product_id = ? AND pb.current_quantity > 0 AND pb.due_date >= CURDATE() ORDER BY pb.due_date, pb.id FOR UPDATE"
+
+	t.Run("GetAvailableByProduct", func(t *testing.T) {
+		r := productbatches.NewRepository(db)
+
+		mock.ExpectQuery(query).WithArgs(456).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "batch_number", "current_quantity", "current_temperature", "due_date", "initial_quantity", "manufacturing_date", "manufacturing_hour", "minimum_temperature", "product_id", "section_id"}).
+				AddRow(1, 123, 10, 25.5, "2023-07-10", 100, "2023-07-01", 8, 20.0, 456, 789))
+
+		productBatches, err := r.GetAvailableByProduct(ctx, 456)
+
+		assert.Nil(t, err)
+		assert.Len(t, productBatches, 1)
+		assert.Equal(t, 10, productBatches[0].CurrentQuantity)
 	})
 }
@@ -2,76 +2,310 @@ package productbatches
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 
+	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/product"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/section"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 )
 
 var (
-	ErrNotFound        = errors.New("product Batches not found")
-	ErrNotFoundSection = errors.New("informed section does not exist in the system")
-	ErrConflict        = errors.New("product batches with batch_number already exists")
+	ErrNotFound        = &errors2.Error{Kind: errors2.KindNotFound, Entity: "product batch", Message: "product Batches not found"}
+	ErrNotFoundSection = &errors2.Error{Kind: errors2.KindNotFound, Entity: "section", Message: "informed section does not exist in the system"}
+	ErrConflict        = &errors2.Error{Kind: errors2.KindConflict, Entity: "product batch", Field: "batch_number", Message: "product batches with batch_number already exists"}
+
+	ErrInvalidProduct      = errors2.Validation("product batch", "product_id", "product does not exist")
+	ErrInvalidSection      = errors2.Validation("product batch", "section_id", "section does not exist")
+	ErrCapacityExceeded    = &errors2.Error{Kind: errors2.KindConflict, Entity: "product batch", Field: "current_quantity", Message: "product batch exceeds the section maximum capacity"}
+	ErrProductTypeMismatch = errors2.Validation("product batch", "section_id", "product type does not match the section product type")
+	ErrSectionTooWarm      = errors2.Validation("product batch", "section_id", "section is too warm for the product batch")
+
+	ErrNotFoundProduct   = &errors2.Error{Kind: errors2.KindNotFound, Entity: "product", Message: "informed product does not exist in the system"}
+	ErrInsufficientStock = &errors2.Error{Kind: errors2.KindConflict, Entity: "product batch", Field: "quantity", Message: "unexpired product batches do not hold the requested quantity"}
 )
 
 type IService interface {
-	Save(ctx *context.Context, product domain.ProductBatches) (*domain.ProductBatches, error)
-	SectionProductsReports() ([]domain.ProductBySection, error)
-	SectionProductsReportsBySection(sectionID int) ([]domain.ProductBySection, error)
+	Save(ctx context.Context, product domain.ProductBatches) (*domain.ProductBatches, error)
+	Get(ctx context.Context, id int) (*domain.ProductBatches, error)
+	GetPage(ctx context.Context, options listing.Options) (*[]domain.ProductBatches, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.ProductBatches) error) error
+	GetPageBySection(ctx context.Context, sectionID int, options listing.Options) (*[]domain.SectionProductBatch, int, error)
+	Update(ctx context.Context, id int, currentQuantity *int, currentTemperature *float64) (*domain.ProductBatches, error)
+	Delete(ctx context.Context, id int) error
+	ExpiringReport(ctx context.Context, days int) ([]domain.ExpiringBatchesByWarehouse, error)
+	Pick(ctx context.Context, productID int, quantity int) ([]domain.BatchPick, error)
+	SectionProductsReports(ctx context.Context, filter SectionReportFilter) ([]domain.ProductBySection, error)
+	SectionProductsReportsBySection(ctx context.Context, sectionID int) ([]domain.ProductBySection, error)
 }
 type Service struct {
 	productBatchRepository IRepository
 	productRepo            product.Repository
 	sectionRepo            section.Repository
+	unitOfWork             database.UnitOfWork
 }
 
-func NewService(r IRepository, productRepo product.Repository, sectionRepo section.Repository) IService {
+func NewService(r IRepository, productRepo product.Repository, sectionRepo section.Repository, unitOfWork database.UnitOfWork) IService {
 	return &Service{
 		productBatchRepository: r,
 		productRepo:            productRepo,
 		sectionRepo:            sectionRepo,
+		unitOfWork:             unitOfWork,
 	}
 }
 
-func (s *Service) SectionProductsReports() ([]domain.ProductBySection, error) {
-	sectionProductsReports, err := s.productBatchRepository.SectionProductsReports()
+func (s *Service) SectionProductsReports(ctx context.Context, filter SectionReportFilter) ([]domain.ProductBySection, error) {
+	sectionProductsReports, err := s.productBatchRepository.SectionProductsReports(ctx, filter)
 	if err != nil {
 		return sectionProductsReports, err
 	}
 	return sectionProductsReports, nil
 }
-func (s *Service) SectionProductsReportsBySection(sectionID int) ([]domain.ProductBySection, error) {
-	sectionProductsBySection, err := s.productBatchRepository.SectionProductsReportsBySection(sectionID)
+func (s *Service) SectionProductsReportsBySection(ctx context.Context, sectionID int) ([]domain.ProductBySection, error) {
+	sectionProductsBySection, err := s.productBatchRepository.SectionProductsReportsBySection(ctx, sectionID)
 	if err != nil {
-		switch err {
-		case sql.ErrNoRows:
-			return nil, ErrNotFoundSection
-		default:
-			return nil, err
-		}
+		return nil, err
 	}
 	return sectionProductsBySection, nil
 }
 
-func (s *Service) Save(ctx *context.Context, product domain.ProductBatches) (*domain.ProductBatches, error) {
-	batchNumberExist := s.productBatchRepository.ExistsProductBatch(*ctx, product.BatchNumber)
-	productIdExist := s.productRepo.ExistsByID(*ctx, product.ProductID)
-	sectionIdExist := s.sectionRepo.ExistsByID(*ctx, product.SectionID)
+// Save validates the batch number, product and section, checks the placement rules and inserts the batch
+// in a single unit of work, adding its current quantity to the section capacity.
+func (s *Service) Save(ctx context.Context, product domain.ProductBatches) (*domain.ProductBatches, error) {
+	var savedProductBatches domain.ProductBatches
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if s.productBatchRepository.ExistsProductBatch(ctx, product.BatchNumber) {
+			return ErrConflict
+		}
+
+		batchProduct, err := s.productRepo.Get(ctx, product.ProductID)
+		if err != nil {
+			if errors.Is(err, errors2.ErrNotFound) {
+				return ErrInvalidProduct
+			}
+			return err
+		}
+
+		batchSection, err := s.sectionRepo.Get(ctx, product.SectionID)
+		if err != nil {
+			if errors.Is(err, errors2.ErrNotFound) {
+				return ErrInvalidSection
+			}
+			return err
+		}
+
+		if err := checkPlacement(product, batchProduct, batchSection); err != nil {
+			return err
+		}
+
+		if product.CurrentQuantity > 0 {
+			err = s.sectionRepo.IncreaseCurrentCapacity(ctx, product.SectionID, product.CurrentQuantity)
+			if err != nil {
+				if errors.Is(err, section.ErrCapacityExceeded) {
+					return ErrCapacityExceeded
+				}
+				return err
+			}
+		}
 
-	if batchNumberExist || !productIdExist || !sectionIdExist {
-		//return nil, fmt.Errorf("batch_number exists: %t, product_id exists: %t, section_id exists: %t", batchNumberExist, productIdExist, sectionIdExist)
-		return &domain.ProductBatches{}, ErrConflict
+		productBatchesID, err := s.productBatchRepository.Save(ctx, product)
+		if err != nil {
+			return err
+		}
+		savedProductBatches, err = s.productBatchRepository.Get(ctx, productBatchesID)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
+	return &savedProductBatches, nil
+}
 
-	productBatchesID, err := s.productBatchRepository.Save(*ctx, product)
+func (s *Service) Get(ctx context.Context, id int) (*domain.ProductBatches, error) {
+	productBatch, err := s.productBatchRepository.Get(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	savedProductBatches, err := s.productBatchRepository.Get(*ctx, productBatchesID)
+	return &productBatch, nil
+}
+
+func (s *Service) GetPage(ctx context.Context, options listing.Options) (*[]domain.ProductBatches, int, error) {
+	productBatches, total, err := s.productBatchRepository.GetPage(ctx, options)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &productBatches, total, nil
+}
+
+func (s *Service) Each(ctx context.Context, options listing.Options, fn func(domain.ProductBatches) error) error {
+	return s.productBatchRepository.Each(ctx, options, fn)
+}
+
+// GetPageBySection lists the batches of an existing section with the description of their product.
+func (s *Service) GetPageBySection(ctx context.Context, sectionID int, options listing.Options) (*[]domain.SectionProductBatch, int, error) {
+	if !s.sectionRepo.ExistsByID(ctx, sectionID) {
+		return nil, 0, ErrNotFoundSection
+	}
+
+	productBatches, total, err := s.productBatchRepository.GetPageBySection(ctx, sectionID, options)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &productBatches, total, nil
+}
+
+// Update changes the current quantity and temperature of the batch in a single unit of work,
+// moving the difference in quantity into or out of the section capacity.
+func (s *Service) Update(ctx context.Context, id int, currentQuantity *int, currentTemperature *float64) (*domain.ProductBatches, error) {
+	var productBatch domain.ProductBatches
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		productBatch, err = s.productBatchRepository.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if currentQuantity != nil {
+			if difference := *currentQuantity - productBatch.CurrentQuantity; difference != 0 {
+				err = s.sectionRepo.IncreaseCurrentCapacity(ctx, productBatch.SectionID, difference)
+				if err != nil {
+					if errors.Is(err, section.ErrCapacityExceeded) {
+						return ErrCapacityExceeded
+					}
+					return err
+				}
+			}
+			productBatch.CurrentQuantity = *currentQuantity
+		}
+		if currentTemperature != nil {
+			productBatch.CurrentTemperature = *currentTemperature
+		}
+
+		return s.productBatchRepository.Update(ctx, productBatch)
+	})
 	if err != nil {
 		return nil, err
 	}
-	return &savedProductBatches, nil
+	return &productBatch, nil
+}
+
+// Delete removes the batch and releases its current quantity from the section capacity in a single unit of work.
+func (s *Service) Delete(ctx context.Context, id int) error {
+	return s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		productBatch, err := s.productBatchRepository.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if productBatch.CurrentQuantity > 0 {
+			err = s.sectionRepo.IncreaseCurrentCapacity(ctx, productBatch.SectionID, -productBatch.CurrentQuantity)
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.productBatchRepository.Delete(ctx, id)
+	})
+}
+
+// ExpiringReport lists the batches with stock due within days, grouped by warehouse and section.
+func (s *Service) ExpiringReport(ctx context.Context, days int) ([]domain.ExpiringBatchesByWarehouse, error) {
+	batches, err := s.productBatchRepository.GetExpiring(ctx, days)
+	if err != nil {
+		return nil, err
+	}
+
+	report := make([]domain.ExpiringBatchesByWarehouse, 0)
+	for _, b := range batches {
+		if len(report) == 0 || report[len(report)-1].WarehouseID != b.WarehouseID {
+			report = append(report, domain.ExpiringBatchesByWarehouse{WarehouseID: b.WarehouseID})
+		}
+		warehouse := &report[len(report)-1]
+
+		if len(warehouse.Sections) == 0 || warehouse.Sections[len(warehouse.Sections)-1].SectionID != b.SectionID {
+			warehouse.Sections = append(warehouse.Sections, domain.ExpiringBatchesBySection{SectionID: b.SectionID, SectionNumber: b.SectionNumber})
+		}
+		sectionBatches := &warehouse.Sections[len(warehouse.Sections)-1]
+		sectionBatches.Batches = append(sectionBatches.Batches, b)
+	}
+	return report, nil
+}
+
+// Pick takes quantity units of the product from its unexpired batches, first expired first out,
+// decrementing each batch and releasing the section capacity in a single unit of work.
+func (s *Service) Pick(ctx context.Context, productID int, quantity int) ([]domain.BatchPick, error) {
+	var picks []domain.BatchPick
+	err := s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if !s.productRepo.ExistsByID(ctx, productID) {
+			return ErrNotFoundProduct
+		}
+
+		productBatches, err := s.productBatchRepository.GetAvailableByProduct(ctx, productID)
+		if err != nil {
+			return err
+		}
+
+		available := 0
+		for _, pb := range productBatches {
+			available += pb.CurrentQuantity
+		}
+		if available < quantity {
+			return ErrInsufficientStock
+		}
+
+		picks = make([]domain.BatchPick, 0)
+		remaining := quantity
+		for _, pb := range productBatches {
+			if remaining == 0 {
+				break
+			}
+			picked := pb.CurrentQuantity
+			if remaining < picked {
+				picked = remaining
+			}
+
+			if err := s.productBatchRepository.IncreaseCurrentQuantity(ctx, pb.ID, -picked); err != nil {
+				return err
+			}
+			if err := s.sectionRepo.IncreaseCurrentCapacity(ctx, pb.SectionID, -picked); err != nil {
+				return err
+			}
+
+			picks = append(picks, domain.BatchPick{
+				ProductBatchID:    pb.ID,
+				BatchNumber:       pb.BatchNumber,
+				SectionID:         pb.SectionID,
+				DueDate:           pb.DueDate,
+				Quantity:          picked,
+				RemainingQuantity: pb.CurrentQuantity - picked,
+			})
+			remaining -= picked
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return picks, nil
+}
+
+// checkPlacement returns the rule the batch breaks when placed in the section, or nil when it fits.
+// The section is too warm when its current temperature is above the recommended freezing temperature
+// of the product, or when its minimum temperature is above the minimum temperature of the batch.
+func checkPlacement(productBatch domain.ProductBatches, p domain.Product, s domain.Section) error {
+	if p.ProductTypeID != s.ProductTypeID {
+		return ErrProductTypeMismatch
+	}
+
+	if float32(s.CurrentTemperature) > p.RecomFreezTemp || float64(s.MinimumTemperature) > productBatch.MinimumTemperature {
+		return ErrSectionTooWarm
+	}
+
+	if s.CurrentCapacity+productBatch.CurrentQuantity > s.MaximumCapacity {
+		return ErrCapacityExceeded
+	}
+
+	return nil
 }
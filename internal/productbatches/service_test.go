@@ -2,14 +2,17 @@ package productbatches_test
 
 import (
 	"context"
-	"database/sql"
 	"testing"
 
+	database_mocks "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database/mocks"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/product"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/product/product_mocks"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/productbatches"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/productbatches/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/section"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/section/section_mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -40,6 +43,19 @@ var (
 		ProductID:          456,
 		SectionID:          789,
 	}
+	batchProduct = domain.Product{
+		ID:             456,
+		RecomFreezTemp: 20,
+		ProductTypeID:  1,
+	}
+	batchSection = domain.Section{
+		ID:                 789,
+		CurrentTemperature: 15,
+		MinimumTemperature: 10,
+		CurrentCapacity:    50,
+		MaximumCapacity:    100,
+		ProductTypeID:      1,
+	}
 )
 
 func TestCreate(t *testing.T) {
@@ -50,15 +66,16 @@ func TestCreate(t *testing.T) {
 		productRepository := new(product_mocks.ProductRepositoryMock)
 		sectionRepository := new(section_mocks.SectionRepositoryMock)
 
-		service := productbatches.NewService(productBatchesRepositoryMock, productRepository, sectionRepository)
+		service := productbatches.NewService(productBatchesRepositoryMock, productRepository, sectionRepository, database_mocks.NewUnitOfWorkMock())
 
 		productBatchesRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.ProductBatches")).Return(1, nil)
 		productBatchesRepositoryMock.On("ExistsProductBatch", ctx, payload.BatchNumber).Return(false)
-		productRepository.On("ExistsByID", ctx, payload.ProductID).Return(true)
-		sectionRepository.On("ExistsByID", ctx, payload.SectionID).Return(true)
+		productRepository.On("Get", ctx, payload.ProductID).Return(batchProduct, nil)
+		sectionRepository.On("Get", ctx, payload.SectionID).Return(batchSection, nil)
+		sectionRepository.On("IncreaseCurrentCapacity", ctx, payload.SectionID, payload.CurrentQuantity).Return(nil)
 		productBatchesRepositoryMock.On("Get", ctx, 1).Return(expectedProductBatch, nil)
 
-		productBatchSaved, err := service.Save(&ctx, payload)
+		productBatchSaved, err := service.Save(ctx, payload)
 		assert.Equal(t, *productBatchSaved, expectedProductBatch)
 		assert.Nil(t, err)
 	})
@@ -69,15 +86,17 @@ func TestCreate(t *testing.T) {
 		productRepository := new(product_mocks.ProductRepositoryMock)
 		sectionRepository := new(section_mocks.SectionRepositoryMock)
 
-		service := productbatches.NewService(productBatchesRepositoryMock, productRepository, sectionRepository)
+		service := productbatches.NewService(productBatchesRepositoryMock, productRepository, sectionRepository, database_mocks.NewUnitOfWorkMock())
 
 		productBatchesRepositoryMock.On("ExistsProductBatch", ctx, payload.BatchNumber).Return(true)
-		productRepository.On("ExistsByID", ctx, payload.ProductID).Return(true)
-		sectionRepository.On("ExistsByID", ctx, payload.SectionID).Return(true)
+		productRepository.On("Get", ctx, payload.ProductID).Return(batchProduct, nil)
+		sectionRepository.On("Get", ctx, payload.SectionID).Return(batchSection, nil)
+		sectionRepository.On("IncreaseCurrentCapacity", ctx, payload.SectionID, payload.CurrentQuantity).Return(nil)
 		productBatchesRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.ProductBatches")).Return(domain.ProductBatches{}, productbatches.ErrConflict)
 
-		_, err := service.Save(&ctx, payload)
+		productBatches, err := service.Save(ctx, payload)
 		assert.Equal(t, productbatches.ErrConflict, err)
+		assert.Nil(t, productBatches)
 	})
 	t.Run("CREATE - Err GET", func(t *testing.T) {
 		ctx := context.TODO()
@@ -86,15 +105,16 @@ func TestCreate(t *testing.T) {
 		productRepository := new(product_mocks.ProductRepositoryMock)
 		sectionRepository := new(section_mocks.SectionRepositoryMock)
 
-		service := productbatches.NewService(productBatchesRepositoryMock, productRepository, sectionRepository)
+		service := productbatches.NewService(productBatchesRepositoryMock, productRepository, sectionRepository, database_mocks.NewUnitOfWorkMock())
 
 		productBatchesRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.ProductBatches")).Return(1, nil)
 		productBatchesRepositoryMock.On("ExistsProductBatch", ctx, payload.BatchNumber).Return(false)
-		productRepository.On("ExistsByID", ctx, payload.ProductID).Return(true)
-		sectionRepository.On("ExistsByID", ctx, payload.SectionID).Return(true)
+		productRepository.On("Get", ctx, payload.ProductID).Return(batchProduct, nil)
+		sectionRepository.On("Get", ctx, payload.SectionID).Return(batchSection, nil)
+		sectionRepository.On("IncreaseCurrentCapacity", ctx, payload.SectionID, payload.CurrentQuantity).Return(nil)
 		productBatchesRepositoryMock.On("Get", ctx, 1).Return(domain.ProductBatches{}, assert.AnError)
 
-		productBatchSaved, err := service.Save(&ctx, payload)
+		productBatchSaved, err := service.Save(ctx, payload)
 
 		assert.Equal(t, assert.AnError, err)
 		assert.Nil(t, productBatchSaved)
@@ -107,21 +127,103 @@ func TestCreate(t *testing.T) {
 		productRepository := new(product_mocks.ProductRepositoryMock)
 		sectionRepository := new(section_mocks.SectionRepositoryMock)
 
-		service := productbatches.NewService(productBatchesRepositoryMock, productRepository, sectionRepository)
+		service := productbatches.NewService(productBatchesRepositoryMock, productRepository, sectionRepository, database_mocks.NewUnitOfWorkMock())
 
 		productBatchesRepositoryMock.On("ExistsProductBatch", ctx, payload.BatchNumber).Return(false)
-		productRepository.On("ExistsByID", ctx, payload.ProductID).Return(true)
-		sectionRepository.On("ExistsByID", ctx, payload.SectionID).Return(true)
+		productRepository.On("Get", ctx, payload.ProductID).Return(batchProduct, nil)
+		sectionRepository.On("Get", ctx, payload.SectionID).Return(batchSection, nil)
+		sectionRepository.On("IncreaseCurrentCapacity", ctx, payload.SectionID, payload.CurrentQuantity).Return(nil)
 		productBatchesRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.ProductBatches")).Return(0, assert.AnError)
 
 		productBatchesRepositoryMock.On("Get", ctx, 1).Return(expectedProductBatch, nil)
 
-		_, err := service.Save(&ctx, payload)
+		_, err := service.Save(ctx, payload)
 
 		assert.Equal(t, assert.AnError, err)
 	})
 
 }
+func TestCreatePlacementRules(t *testing.T) {
+	tests := []struct {
+		name             string
+		product          domain.Product
+		productError     error
+		section          domain.Section
+		sectionError     error
+		capacityError    error
+		expectedCapacity bool
+		wantErr          error
+	}{
+		{
+			name:         "product not found",
+			productError: product.ErrNotFound,
+			wantErr:      productbatches.ErrInvalidProduct,
+		},
+		{
+			name:         "section not found",
+			product:      batchProduct,
+			sectionError: section.ErrNotFound,
+			wantErr:      productbatches.ErrInvalidSection,
+		},
+		{
+			name:    "product type mismatch",
+			product: domain.Product{ID: 456, RecomFreezTemp: 20, ProductTypeID: 2},
+			section: batchSection,
+			wantErr: productbatches.ErrProductTypeMismatch,
+		},
+		{
+			name:    "section too warm for the product",
+			product: domain.Product{ID: 456, RecomFreezTemp: 10, ProductTypeID: 1},
+			section: batchSection,
+			wantErr: productbatches.ErrSectionTooWarm,
+		},
+		{
+			name:    "section too warm for the batch minimum temperature",
+			product: batchProduct,
+			section: domain.Section{ID: 789, CurrentTemperature: 15, MinimumTemperature: 25, CurrentCapacity: 50, MaximumCapacity: 100, ProductTypeID: 1},
+			wantErr: productbatches.ErrSectionTooWarm,
+		},
+		{
+			name:    "capacity exceeded",
+			product: batchProduct,
+			section: domain.Section{ID: 789, CurrentTemperature: 15, MinimumTemperature: 10, CurrentCapacity: 95, MaximumCapacity: 100, ProductTypeID: 1},
+			wantErr: productbatches.ErrCapacityExceeded,
+		},
+		{
+			name:             "capacity exceeded by a concurrent placement",
+			product:          batchProduct,
+			section:          batchSection,
+			capacityError:    section.ErrCapacityExceeded,
+			expectedCapacity: true,
+			wantErr:          productbatches.ErrCapacityExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			productBatchesRepositoryMock := mocks.NewProductBatchesRepositoryMock()
+			productRepository := new(product_mocks.ProductRepositoryMock)
+			sectionRepository := new(section_mocks.SectionRepositoryMock)
+
+			service := productbatches.NewService(productBatchesRepositoryMock, productRepository, sectionRepository, database_mocks.NewUnitOfWorkMock())
+
+			productBatchesRepositoryMock.On("ExistsProductBatch", ctx, payload.BatchNumber).Return(false)
+			productRepository.On("Get", ctx, payload.ProductID).Return(tt.product, tt.productError)
+			sectionRepository.On("Get", ctx, payload.SectionID).Return(tt.section, tt.sectionError)
+			sectionRepository.On("IncreaseCurrentCapacity", ctx, payload.SectionID, payload.CurrentQuantity).Return(tt.capacityError)
+
+			_, err := service.Save(ctx, payload)
+
+			assert.Equal(t, tt.wantErr, err)
+			if !tt.expectedCapacity {
+				sectionRepository.AssertNotCalled(t, "IncreaseCurrentCapacity", ctx, payload.SectionID, payload.CurrentQuantity)
+			}
+			productBatchesRepositoryMock.AssertNotCalled(t, "Save", ctx, mock.Anything)
+		})
+	}
+}
+
 func TestSectionProductsReports(t *testing.T) {
 	t.Run("SectionProductsReports - Internal Server Error", func(t *testing.T) {
 
@@ -129,10 +231,10 @@ func TestSectionProductsReports(t *testing.T) {
 		productRepository := new(product_mocks.ProductRepositoryMock)
 		sectionRepository := new(section_mocks.SectionRepositoryMock)
 
-		service := productbatches.NewService(productBatchesRepositoryMock, productRepository, sectionRepository)
-		productBatchesRepositoryMock.On("SectionProductsReports").Return([]domain.ProductBySection{}, assert.AnError)
+		service := productbatches.NewService(productBatchesRepositoryMock, productRepository, sectionRepository, database_mocks.NewUnitOfWorkMock())
+		productBatchesRepositoryMock.On("SectionProductsReports", mock.Anything, productbatches.SectionReportFilter{}).Return([]domain.ProductBySection{}, assert.AnError)
 
-		_, err := service.SectionProductsReports()
+		_, err := service.SectionProductsReports(context.TODO(), productbatches.SectionReportFilter{})
 
 		assert.Equal(t, assert.AnError, err)
 	})
@@ -153,10 +255,10 @@ func TestSectionProductsReports(t *testing.T) {
 		productRepository := new(product_mocks.ProductRepositoryMock)
 		sectionRepository := new(section_mocks.SectionRepositoryMock)
 
-		service := productbatches.NewService(productBatchesRepositoryMock, productRepository, sectionRepository)
-		productBatchesRepositoryMock.On("SectionProductsReports").Return(expectedReportProducts, nil)
+		service := productbatches.NewService(productBatchesRepositoryMock, productRepository, sectionRepository, database_mocks.NewUnitOfWorkMock())
+		productBatchesRepositoryMock.On("SectionProductsReports", mock.Anything, productbatches.SectionReportFilter{}).Return(expectedReportProducts, nil)
 
-		sectionProductsReportsActual, err := service.SectionProductsReports()
+		sectionProductsReportsActual, err := service.SectionProductsReports(context.TODO(), productbatches.SectionReportFilter{})
 
 		assert.Equal(t, sectionProductsReportsActual, expectedReportProducts)
 		assert.Equal(t, nil, err)
@@ -169,10 +271,10 @@ func TestSectionProductsReportsBySection(t *testing.T) {
 		productRepository := new(product_mocks.ProductRepositoryMock)
 		sectionRepository := new(section_mocks.SectionRepositoryMock)
 
-		service := productbatches.NewService(productBatchesRepositoryMock, productRepository, sectionRepository)
-		productBatchesRepositoryMock.On("SectionProductsReportsBySection", mock.AnythingOfType("int")).Return([]domain.ProductBySection{}, sql.ErrNoRows)
+		service := productbatches.NewService(productBatchesRepositoryMock, productRepository, sectionRepository, database_mocks.NewUnitOfWorkMock())
+		productBatchesRepositoryMock.On("SectionProductsReportsBySection", mock.Anything, mock.AnythingOfType("int")).Return([]domain.ProductBySection{}, productbatches.ErrNotFoundSection)
 		id := 1
-		received, err := service.SectionProductsReportsBySection(id)
+		received, err := service.SectionProductsReportsBySection(context.TODO(), id)
 
 		assert.Nil(t, received)
 		assert.Equal(t, productbatches.ErrNotFoundSection, err)
@@ -183,10 +285,10 @@ func TestSectionProductsReportsBySection(t *testing.T) {
 		productRepository := new(product_mocks.ProductRepositoryMock)
 		sectionRepository := new(section_mocks.SectionRepositoryMock)
 
-		service := productbatches.NewService(productBatchesRepositoryMock, productRepository, sectionRepository)
-		productBatchesRepositoryMock.On("SectionProductsReportsBySection", mock.AnythingOfType("int")).Return([]domain.ProductBySection{}, assert.AnError)
+		service := productbatches.NewService(productBatchesRepositoryMock, productRepository, sectionRepository, database_mocks.NewUnitOfWorkMock())
+		productBatchesRepositoryMock.On("SectionProductsReportsBySection", mock.Anything, mock.AnythingOfType("int")).Return([]domain.ProductBySection{}, assert.AnError)
 		id := 1
-		received, err := service.SectionProductsReportsBySection(id)
+		received, err := service.SectionProductsReportsBySection(context.TODO(), id)
 
 		assert.Nil(t, received)
 		assert.Equal(t, assert.AnError, err)
@@ -203,13 +305,268 @@ func TestSectionProductsReportsBySection(t *testing.T) {
 		productRepository := new(product_mocks.ProductRepositoryMock)
 		sectionRepository := new(section_mocks.SectionRepositoryMock)
 
-		service := productbatches.NewService(productBatchesRepositoryMock, productRepository, sectionRepository)
-		productBatchesRepositoryMock.On("SectionProductsReportsBySection", mock.AnythingOfType("int")).Return(expectedReportProductsBySection, nil)
+		service := productbatches.NewService(productBatchesRepositoryMock, productRepository, sectionRepository, database_mocks.NewUnitOfWorkMock())
+		productBatchesRepositoryMock.On("SectionProductsReportsBySection", mock.Anything, mock.AnythingOfType("int")).Return(expectedReportProductsBySection, nil)
 		id := 1
-		received, err := service.SectionProductsReportsBySection(id)
+		received, err := service.SectionProductsReportsBySection(context.TODO(), id)
 
 		assert.Equal(t, expectedReportProductsBySection, received)
 		assert.Equal(t, nil, err)
 	})
 
 }
+
+func TestGet(t *testing.T) {
+	t.Run("GET - OK", func(t *testing.T) {
+		productBatchesRepositoryMock := mocks.NewProductBatchesRepositoryMock()
+		service := productbatches.NewService(productBatchesRepositoryMock, new(product_mocks.ProductRepositoryMock), new(section_mocks.SectionRepositoryMock), database_mocks.NewUnitOfWorkMock())
+		productBatchesRepositoryMock.On("Get", mock.Anything, 1).Return(expectedProductBatch, nil)
+
+		productBatch, err := service.Get(context.TODO(), 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedProductBatch, *productBatch)
+	})
+	t.Run("GET - Not Found", func(t *testing.T) {
+		productBatchesRepositoryMock := mocks.NewProductBatchesRepositoryMock()
+		service := productbatches.NewService(productBatchesRepositoryMock, new(product_mocks.ProductRepositoryMock), new(section_mocks.SectionRepositoryMock), database_mocks.NewUnitOfWorkMock())
+		productBatchesRepositoryMock.On("Get", mock.Anything, 99).Return(domain.ProductBatches{}, productbatches.ErrNotFound)
+
+		productBatch, err := service.Get(context.TODO(), 99)
+
+		assert.Equal(t, productbatches.ErrNotFound, err)
+		assert.Nil(t, productBatch)
+	})
+}
+
+func TestGetPage(t *testing.T) {
+	options := listing.Options{Limit: listing.DefaultLimit}
+
+	t.Run("GetPage - OK", func(t *testing.T) {
+		productBatchesRepositoryMock := mocks.NewProductBatchesRepositoryMock()
+		service := productbatches.NewService(productBatchesRepositoryMock, new(product_mocks.ProductRepositoryMock), new(section_mocks.SectionRepositoryMock), database_mocks.NewUnitOfWorkMock())
+		productBatchesRepositoryMock.On("GetPage", mock.Anything, options).Return([]domain.ProductBatches{expectedProductBatch}, 1, nil)
+
+		productBatches, total, err := service.GetPage(context.TODO(), options)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, []domain.ProductBatches{expectedProductBatch}, *productBatches)
+	})
+	t.Run("GetPageBySection - OK", func(t *testing.T) {
+		expected := []domain.SectionProductBatch{{ProductBatches: expectedProductBatch, ProductDescription: "Product 1"}}
+		productBatchesRepositoryMock := mocks.NewProductBatchesRepositoryMock()
+		sectionRepository := new(section_mocks.SectionRepositoryMock)
+		service := productbatches.NewService(productBatchesRepositoryMock, new(product_mocks.ProductRepositoryMock), sectionRepository, database_mocks.NewUnitOfWorkMock())
+		sectionRepository.On("ExistsByID", mock.Anything, 789).Return(true)
+		productBatchesRepositoryMock.On("GetPageBySection", mock.Anything, 789, options).Return(expected, 1, nil)
+
+		productBatches, total, err := service.GetPageBySection(context.TODO(), 789, options)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, expected, *productBatches)
+	})
+	t.Run("GetPageBySection - Section Not Found", func(t *testing.T) {
+		productBatchesRepositoryMock := mocks.NewProductBatchesRepositoryMock()
+		sectionRepository := new(section_mocks.SectionRepositoryMock)
+		service := productbatches.NewService(productBatchesRepositoryMock, new(product_mocks.ProductRepositoryMock), sectionRepository, database_mocks.NewUnitOfWorkMock())
+		sectionRepository.On("ExistsByID", mock.Anything, 99).Return(false)
+
+		_, _, err := service.GetPageBySection(context.TODO(), 99, options)
+
+		assert.Equal(t, productbatches.ErrNotFoundSection, err)
+		productBatchesRepositoryMock.AssertNotCalled(t, "GetPageBySection", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	currentQuantity := 25
+	currentTemperature := -18.0
+
+	t.Run("UPDATE - OK", func(t *testing.T) {
+		ctx := context.TODO()
+		expected := expectedProductBatch
+		expected.CurrentQuantity = currentQuantity
+		expected.CurrentTemperature = currentTemperature
+
+		productBatchesRepositoryMock := mocks.NewProductBatchesRepositoryMock()
+		sectionRepository := new(section_mocks.SectionRepositoryMock)
+		service := productbatches.NewService(productBatchesRepositoryMock, new(product_mocks.ProductRepositoryMock), sectionRepository, database_mocks.NewUnitOfWorkMock())
+		productBatchesRepositoryMock.On("Get", ctx, 1).Return(expectedProductBatch, nil)
+		sectionRepository.On("IncreaseCurrentCapacity", ctx, expectedProductBatch.SectionID, currentQuantity-expectedProductBatch.CurrentQuantity).Return(nil)
+		productBatchesRepositoryMock.On("Update", ctx, expected).Return(nil)
+
+		productBatch, err := service.Update(ctx, 1, &currentQuantity, &currentTemperature)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expected, *productBatch)
+	})
+	t.Run("UPDATE - Temperature only does not move capacity", func(t *testing.T) {
+		ctx := context.TODO()
+		expected := expectedProductBatch
+		expected.CurrentTemperature = currentTemperature
+
+		productBatchesRepositoryMock := mocks.NewProductBatchesRepositoryMock()
+		sectionRepository := new(section_mocks.SectionRepositoryMock)
+		service := productbatches.NewService(productBatchesRepositoryMock, new(product_mocks.ProductRepositoryMock), sectionRepository, database_mocks.NewUnitOfWorkMock())
+		productBatchesRepositoryMock.On("Get", ctx, 1).Return(expectedProductBatch, nil)
+		productBatchesRepositoryMock.On("Update", ctx, expected).Return(nil)
+
+		_, err := service.Update(ctx, 1, nil, &currentTemperature)
+
+		assert.Nil(t, err)
+		sectionRepository.AssertNotCalled(t, "IncreaseCurrentCapacity", mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("UPDATE - Capacity Exceeded", func(t *testing.T) {
+		ctx := context.TODO()
+		productBatchesRepositoryMock := mocks.NewProductBatchesRepositoryMock()
+		sectionRepository := new(section_mocks.SectionRepositoryMock)
+		service := productbatches.NewService(productBatchesRepositoryMock, new(product_mocks.ProductRepositoryMock), sectionRepository, database_mocks.NewUnitOfWorkMock())
+		productBatchesRepositoryMock.On("Get", ctx, 1).Return(expectedProductBatch, nil)
+		sectionRepository.On("IncreaseCurrentCapacity", ctx, expectedProductBatch.SectionID, currentQuantity-expectedProductBatch.CurrentQuantity).Return(section.ErrCapacityExceeded)
+
+		productBatch, err := service.Update(ctx, 1, &currentQuantity, nil)
+
+		assert.Equal(t, productbatches.ErrCapacityExceeded, err)
+		assert.Nil(t, productBatch)
+		productBatchesRepositoryMock.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+	t.Run("UPDATE - Not Found", func(t *testing.T) {
+		ctx := context.TODO()
+		productBatchesRepositoryMock := mocks.NewProductBatchesRepositoryMock()
+		service := productbatches.NewService(productBatchesRepositoryMock, new(product_mocks.ProductRepositoryMock), new(section_mocks.SectionRepositoryMock), database_mocks.NewUnitOfWorkMock())
+		productBatchesRepositoryMock.On("Get", ctx, 99).Return(domain.ProductBatches{}, productbatches.ErrNotFound)
+
+		_, err := service.Update(ctx, 99, &currentQuantity, nil)
+
+		assert.Equal(t, productbatches.ErrNotFound, err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	t.Run("DELETE - OK releases the section capacity", func(t *testing.T) {
+		ctx := context.TODO()
+		productBatchesRepositoryMock := mocks.NewProductBatchesRepositoryMock()
+		sectionRepository := new(section_mocks.SectionRepositoryMock)
+		unitOfWork := database_mocks.NewUnitOfWorkMock()
+		service := productbatches.NewService(productBatchesRepositoryMock, new(product_mocks.ProductRepositoryMock), sectionRepository, unitOfWork)
+		productBatchesRepositoryMock.On("Get", ctx, 1).Return(expectedProductBatch, nil)
+		sectionRepository.On("IncreaseCurrentCapacity", ctx, expectedProductBatch.SectionID, -expectedProductBatch.CurrentQuantity).Return(nil)
+		productBatchesRepositoryMock.On("Delete", ctx, 1).Return(nil)
+
+		err := service.Delete(ctx, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, unitOfWork.Calls)
+		productBatchesRepositoryMock.AssertCalled(t, "Delete", ctx, 1)
+	})
+	t.Run("DELETE - Not Found", func(t *testing.T) {
+		ctx := context.TODO()
+		productBatchesRepositoryMock := mocks.NewProductBatchesRepositoryMock()
+		service := productbatches.NewService(productBatchesRepositoryMock, new(product_mocks.ProductRepositoryMock), new(section_mocks.SectionRepositoryMock), database_mocks.NewUnitOfWorkMock())
+		productBatchesRepositoryMock.On("Get", ctx, 99).Return(domain.ProductBatches{}, productbatches.ErrNotFound)
+
+		err := service.Delete(ctx, 99)
+
+		assert.Equal(t, productbatches.ErrNotFound, err)
+		productBatchesRepositoryMock.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestExpiringReport(t *testing.T) {
+	t.Run("EXPIRING - Groups by warehouse and section", func(t *testing.T) {
+		ctx := context.TODO()
+		productBatchesRepositoryMock := mocks.NewProductBatchesRepositoryMock()
+		service := productbatches.NewService(productBatchesRepositoryMock, new(product_mocks.ProductRepositoryMock), new(section_mocks.SectionRepositoryMock), database_mocks.NewUnitOfWorkMock())
+		batches := []domain.ExpiringBatch{
+			{ID: 1, DueDate: "2023-07-10", SectionID: 10, SectionNumber: 1, WarehouseID: 1},
+			{ID: 2, DueDate: "2023-07-11", SectionID: 10, SectionNumber: 1, WarehouseID: 1},
+			{ID: 3, DueDate: "2023-07-10", SectionID: 11, SectionNumber: 2, WarehouseID: 1},
+			{ID: 4, DueDate: "2023-07-12", SectionID: 20, SectionNumber: 1, WarehouseID: 2},
+		}
+		productBatchesRepositoryMock.On("GetExpiring", ctx, 7).Return(batches, nil)
+
+		report, err := service.ExpiringReport(ctx, 7)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []domain.ExpiringBatchesByWarehouse{
+			{WarehouseID: 1, Sections: []domain.ExpiringBatchesBySection{
+				{SectionID: 10, SectionNumber: 1, Batches: batches[0:2]},
+				{SectionID: 11, SectionNumber: 2, Batches: batches[2:3]},
+			}},
+			{WarehouseID: 2, Sections: []domain.ExpiringBatchesBySection{
+				{SectionID: 20, SectionNumber: 1, Batches: batches[3:4]},
+			}},
+		}, report)
+	})
+	t.Run("EXPIRING - Empty", func(t *testing.T) {
+		ctx := context.TODO()
+		productBatchesRepositoryMock := mocks.NewProductBatchesRepositoryMock()
+		service := productbatches.NewService(productBatchesRepositoryMock, new(product_mocks.ProductRepositoryMock), new(section_mocks.SectionRepositoryMock), database_mocks.NewUnitOfWorkMock())
+		productBatchesRepositoryMock.On("GetExpiring", ctx, 7).Return([]domain.ExpiringBatch{}, nil)
+
+		report, err := service.ExpiringReport(ctx, 7)
+
+		assert.Nil(t, err)
+		assert.Empty(t, report)
+	})
+}
+
+func TestPick(t *testing.T) {
+	available := []domain.ProductBatches{
+		{ID: 1, BatchNumber: 100, CurrentQuantity: 5, DueDate: "2023-07-10", ProductID: 456, SectionID: 10},
+		{ID: 2, BatchNumber: 200, CurrentQuantity: 8, DueDate: "2023-07-12", ProductID: 456, SectionID: 11},
+		{ID: 3, BatchNumber: 300, CurrentQuantity: 4, DueDate: "2023-07-20", ProductID: 456, SectionID: 10},
+	}
+
+	t.Run("PICK - First expired first out", func(t *testing.T) {
+		ctx := context.TODO()
+		productBatchesRepositoryMock := mocks.NewProductBatchesRepositoryMock()
+		productRepository := new(product_mocks.ProductRepositoryMock)
+		sectionRepository := new(section_mocks.SectionRepositoryMock)
+		unitOfWork := database_mocks.NewUnitOfWorkMock()
+		service := productbatches.NewService(productBatchesRepositoryMock, productRepository, sectionRepository, unitOfWork)
+		productRepository.On("ExistsByID", ctx, 456).Return(true)
+		productBatchesRepositoryMock.On("GetAvailableByProduct", ctx, 456).Return(available, nil)
+		productBatchesRepositoryMock.On("IncreaseCurrentQuantity", ctx, 1, -5).Return(nil)
+		sectionRepository.On("IncreaseCurrentCapacity", ctx, 10, -5).Return(nil)
+		productBatchesRepositoryMock.On("IncreaseCurrentQuantity", ctx, 2, -6).Return(nil)
+		sectionRepository.On("IncreaseCurrentCapacity", ctx, 11, -6).Return(nil)
+
+		picks, err := service.Pick(ctx, 456, 11)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []domain.BatchPick{
+			{ProductBatchID: 1, BatchNumber: 100, SectionID: 10, DueDate: "2023-07-10", Quantity: 5, RemainingQuantity: 0},
+			{ProductBatchID: 2, BatchNumber: 200, SectionID: 11, DueDate: "2023-07-12", Quantity: 6, RemainingQuantity: 2},
+		}, picks)
+		assert.Equal(t, 1, unitOfWork.Calls)
+		productBatchesRepositoryMock.AssertNotCalled(t, "IncreaseCurrentQuantity", ctx, 3, mock.Anything)
+	})
+	t.Run("PICK - Insufficient Stock", func(t *testing.T) {
+		ctx := context.TODO()
+		productBatchesRepositoryMock := mocks.NewProductBatchesRepositoryMock()
+		productRepository := new(product_mocks.ProductRepositoryMock)
+		service := productbatches.NewService(productBatchesRepositoryMock, productRepository, new(section_mocks.SectionRepositoryMock), database_mocks.NewUnitOfWorkMock())
+		productRepository.On("ExistsByID", ctx, 456).Return(true)
+		productBatchesRepositoryMock.On("GetAvailableByProduct", ctx, 456).Return(available, nil)
+
+		picks, err := service.Pick(ctx, 456, 18)
+
+		assert.Equal(t, productbatches.ErrInsufficientStock, err)
+		assert.Nil(t, picks)
+		productBatchesRepositoryMock.AssertNotCalled(t, "IncreaseCurrentQuantity", mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("PICK - Product Not Found", func(t *testing.T) {
+		ctx := context.TODO()
+		productBatchesRepositoryMock := mocks.NewProductBatchesRepositoryMock()
+		productRepository := new(product_mocks.ProductRepositoryMock)
+		service := productbatches.NewService(productBatchesRepositoryMock, productRepository, new(section_mocks.SectionRepositoryMock), database_mocks.NewUnitOfWorkMock())
+		productRepository.On("ExistsByID", ctx, 99).Return(false)
+
+		_, err := service.Pick(ctx, 99, 1)
+
+		assert.Equal(t, productbatches.ErrNotFoundProduct, err)
+	})
+}
@@ -0,0 +1,169 @@
+package province
+
+import (
+	"context"
+	"database/sql"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+)
+
+type ProvinceRepository interface {
+	GetAll(ctx context.Context) ([]domain.Province, error)
+	GetAllByCountryID(ctx context.Context, countryID int) ([]domain.Province, error)
+	Get(ctx context.Context, id int) (domain.Province, error)
+	GetByName(ctx context.Context, provinceName string, countryID int) (domain.Province, error)
+	Exists(ctx context.Context, id int) bool
+	Save(ctx context.Context, province domain.Province) (int, error)
+	Update(ctx context.Context, province domain.Province) error
+	Delete(ctx context.Context, id int) error
+	Dependents(ctx context.Context, id int) (map[string]int, error)
+}
+
+const (
+	GetAllProvinces            = "SELECT provinces.id, provinces.province_name, provinces.country_id FROM provinces"
+	GetAllProvincesByCountryID = "SELECT provinces.id, provinces.province_name, provinces.country_id FROM provinces WHERE country_id = ?"
+	GetProvinceByID            = "SELECT provinces.id, provinces.province_name, provinces.country_id FROM provinces WHERE id = ?"
+	GetProvinceByName          = "SELECT provinces.id, provinces.province_name, provinces.country_id FROM provinces WHERE province_name = ? AND country_id = ?"
+	ExistsProvinceByID         = "SELECT id FROM provinces WHERE id=?"
+	SaveProvince               = "INSERT INTO provinces(province_name, country_id) VALUES (?,?)"
+	UpdateProvince             = "UPDATE provinces SET province_name=?, country_id=? WHERE id=?"
+	DeleteProvinceByID         = "DELETE FROM provinces WHERE id = ?"
+)
+
+var dependents = []database.Dependent{
+	{Table: "localities", Column: "province_id"},
+}
+
+type provinceRepository struct {
+	db *sql.DB
+}
+
+func NewProvinceRepository(db *sql.DB) ProvinceRepository {
+	return &provinceRepository{
+		db: db,
+	}
+}
+
+func (r *provinceRepository) GetAll(ctx context.Context) ([]domain.Province, error) {
+	return r.query(ctx, GetAllProvinces)
+}
+
+func (r *provinceRepository) GetAllByCountryID(ctx context.Context, countryID int) ([]domain.Province, error) {
+	return r.query(ctx, GetAllProvincesByCountryID, countryID)
+}
+
+func (r *provinceRepository) Get(ctx context.Context, id int) (domain.Province, error) {
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, GetProvinceByID, id)
+	return scanProvince(row)
+}
+
+func (r *provinceRepository) GetByName(ctx context.Context, provinceName string, countryID int) (domain.Province, error) {
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, GetProvinceByName, provinceName, countryID)
+	return scanProvince(row)
+}
+
+func (r *provinceRepository) Exists(ctx context.Context, id int) bool {
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, ExistsProvinceByID, id)
+	var foundId int
+	err := row.Scan(&foundId)
+	return err == nil
+}
+
+func (r *provinceRepository) Save(ctx context.Context, province domain.Province) (int, error) {
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, SaveProvince)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := stmt.ExecContext(ctx, &province.ProvinceName, &province.CountryID)
+	if err != nil {
+		return 0, database.TranslateError(err, "province")
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
+func (r *provinceRepository) Update(ctx context.Context, province domain.Province) error {
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, UpdateProvince)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, &province.ProvinceName, &province.CountryID, &province.ID)
+	if err != nil {
+		return database.TranslateError(err, "province")
+	}
+
+	_, err = res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *provinceRepository) Delete(ctx context.Context, id int) error {
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, DeleteProvinceByID)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return database.TranslateError(err, "province")
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affect < 1 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *provinceRepository) Dependents(ctx context.Context, id int) (map[string]int, error) {
+	return database.CountDependents(ctx, database.Conn(ctx, r.db), id, dependents)
+}
+
+func (r *provinceRepository) query(ctx context.Context, query string, args ...interface{}) ([]domain.Province, error) {
+	provinces := make([]domain.Province, 0)
+
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return provinces, err
+	}
+
+	for rows.Next() {
+		province := domain.Province{}
+		err := rows.Scan(&province.ID, &province.ProvinceName, &province.CountryID)
+		if err != nil {
+			return provinces, err
+		}
+
+		provinces = append(provinces, province)
+	}
+
+	return provinces, rows.Err()
+}
+
+func scanProvince(row *sql.Row) (domain.Province, error) {
+	province := domain.Province{}
+	err := row.Scan(&province.ID, &province.ProvinceName, &province.CountryID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.Province{}, ErrNotFound
+		}
+		return domain.Province{}, err
+	}
+
+	return province, nil
+}
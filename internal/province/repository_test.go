@@ -0,0 +1,251 @@
+package province
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"regexp"
+	"testing"
+)
+
+func Test_provinceRepository_GetAll(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	validProvincesSerialized, _ := os.ReadFile("../../test/resources/valid_provinces.json")
+	var validProvinces []domain.Province
+	if err := json.Unmarshal(validProvincesSerialized, &validProvinces); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		want    []domain.Province
+		wantErr bool
+	}{
+		{
+			name:    "Successfully get provinces",
+			want:    validProvinces,
+			wantErr: false,
+		},
+		{
+			name:    "Error getting provinces",
+			want:    []domain.Province{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewProvinceRepository(db)
+
+			rows := sqlmock.NewRows([]string{"id", "province_name", "country_id"})
+			for _, province := range validProvinces {
+				rows.AddRow(province.ID, province.ProvinceName, province.CountryID)
+			}
+			if tt.wantErr {
+				rows.RowError(0, sql.ErrNoRows)
+			}
+
+			mock.ExpectQuery(regexp.QuoteMeta(GetAllProvinces)).
+				WillReturnRows(rows)
+
+			got, err := r.GetAll(ctx)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func Test_provinceRepository_GetAllByCountryID(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	r := NewProvinceRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(GetAllProvincesByCountryID)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "province_name", "country_id"}).AddRow(1, "Buenos Aires", 1))
+
+	got, err := r.GetAllByCountryID(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.Province{{ID: 1, ProvinceName: "Buenos Aires", CountryID: 1}}, got)
+}
+
+func Test_provinceRepository_Get(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	provinceSerialized, _ := os.ReadFile("../../test/resources/valid_province.json")
+	var validProvince domain.Province
+	if err := json.Unmarshal(provinceSerialized, &validProvince); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		queryErr error
+		want     domain.Province
+		wantErr  error
+	}{
+		{
+			name:    "Successfully get province",
+			want:    validProvince,
+			wantErr: nil,
+		},
+		{
+			name:     "Error nonexistent province",
+			queryErr: sql.ErrNoRows,
+			want:     domain.Province{},
+			wantErr:  ErrNotFound,
+		},
+		{
+			name:     "Error querying province",
+			queryErr: assert.AnError,
+			want:     domain.Province{},
+			wantErr:  assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewProvinceRepository(db)
+
+			expected := mock.ExpectQuery(regexp.QuoteMeta(GetProvinceByID)).
+				WithArgs(validProvince.ID)
+			if tt.queryErr != nil {
+				expected.WillReturnError(tt.queryErr)
+			} else {
+				expected.WillReturnRows(sqlmock.NewRows([]string{"id", "province_name", "country_id"}).AddRow(tt.want.ID, tt.want.ProvinceName, tt.want.CountryID))
+			}
+
+			got, err := r.Get(ctx, validProvince.ID)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_provinceRepository_GetByName(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	r := NewProvinceRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(GetProvinceByName)).
+		WithArgs("Buenos Aires", 1).
+		WillReturnError(sql.ErrNoRows)
+
+	got, err := r.GetByName(ctx, "Buenos Aires", 1)
+
+	assert.Equal(t, ErrNotFound, err)
+	assert.Equal(t, domain.Province{}, got)
+}
+
+func Test_provinceRepository_Save(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	r := NewProvinceRepository(db)
+
+	mock.ExpectPrepare(regexp.QuoteMeta(SaveProvince))
+	mock.ExpectExec(regexp.QuoteMeta(SaveProvince)).
+		WithArgs("Buenos Aires", 1).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	got, err := r.Save(ctx, domain.Province{ProvinceName: "Buenos Aires", CountryID: 1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, got)
+}
+
+func Test_provinceRepository_Update(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	r := NewProvinceRepository(db)
+
+	mock.ExpectPrepare(regexp.QuoteMeta(UpdateProvince))
+	mock.ExpectExec(regexp.QuoteMeta(UpdateProvince)).
+		WithArgs("Buenos Aires", 1, 1).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := r.Update(ctx, domain.Province{ID: 1, ProvinceName: "Buenos Aires", CountryID: 1})
+
+	assert.NoError(t, err)
+}
+
+func Test_provinceRepository_Delete(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantErr      error
+	}{
+		{
+			name:         "Successfully delete province",
+			rowsAffected: 1,
+			wantErr:      nil,
+		},
+		{
+			name:         "No province to delete",
+			rowsAffected: 0,
+			wantErr:      ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewProvinceRepository(db)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(DeleteProvinceByID))
+			mock.ExpectExec(regexp.QuoteMeta(DeleteProvinceByID)).
+				WithArgs(1).
+				WillReturnResult(sqlmock.NewResult(1, tt.rowsAffected))
+
+			err := r.Delete(ctx, 1)
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_provinceRepository_Delete_Referenced(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+	r := NewProvinceRepository(db)
+
+	mock.ExpectPrepare(regexp.QuoteMeta(DeleteProvinceByID))
+	mock.ExpectExec(regexp.QuoteMeta(DeleteProvinceByID)).
+		WithArgs(1).
+		WillReturnError(&mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row: a foreign key constraint fails"})
+
+	err := r.Delete(ctx, 1)
+
+	assert.ErrorIs(t, err, errors.ErrConflict)
+}
+
+func Test_provinceRepository_Dependents(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+	r := NewProvinceRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM localities WHERE province_id=?")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	dependents, err := r.Dependents(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"localities": 2}, dependents)
+}
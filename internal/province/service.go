@@ -0,0 +1,131 @@
+package province
+
+import (
+	"context"
+	"errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos"
+	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/country"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+)
+
+// Errors
+var (
+	ErrNotFound = errors2.NotFound("province")
+	ErrConflict = errors2.Conflict("province", "province_name")
+)
+
+type ProvinceService interface {
+	Get(ctx context.Context, id int) (domain.Province, error)
+	GetAll(ctx context.Context) ([]domain.Province, error)
+	GetAllByCountryID(ctx context.Context, countryID int) ([]domain.Province, error)
+	Create(ctx context.Context, province domain.Province) (domain.Province, error)
+	Update(ctx context.Context, id int, updateProvinceRequest dtos.UpdateProvinceRequestDTO) (domain.Province, error)
+	Delete(ctx context.Context, id int) error
+}
+
+type provinceService struct {
+	provinceRepository ProvinceRepository
+	countryRepository  country.CountryRepository
+}
+
+func NewProvinceService(r ProvinceRepository, countryRepository country.CountryRepository) ProvinceService {
+	return &provinceService{
+		provinceRepository: r,
+		countryRepository:  countryRepository,
+	}
+}
+
+func (service *provinceService) Get(ctx context.Context, id int) (domain.Province, error) {
+	province, err := service.provinceRepository.Get(ctx, id)
+	if err != nil {
+		return domain.Province{}, err
+	}
+
+	return province, nil
+}
+
+func (service *provinceService) GetAll(ctx context.Context) ([]domain.Province, error) {
+	provinces, err := service.provinceRepository.GetAll(ctx)
+	if err != nil {
+		return []domain.Province{}, err
+	}
+
+	return provinces, nil
+}
+
+func (service *provinceService) GetAllByCountryID(ctx context.Context, countryID int) ([]domain.Province, error) {
+	if !service.countryRepository.Exists(ctx, countryID) {
+		return []domain.Province{}, country.ErrNotFound
+	}
+
+	provinces, err := service.provinceRepository.GetAllByCountryID(ctx, countryID)
+	if err != nil {
+		return []domain.Province{}, err
+	}
+
+	return provinces, nil
+}
+
+func (service *provinceService) Create(ctx context.Context, province domain.Province) (domain.Province, error) {
+	if !service.countryRepository.Exists(ctx, province.CountryID) {
+		return domain.Province{}, country.ErrNotFound
+	}
+
+	if _, err := service.provinceRepository.GetByName(ctx, province.ProvinceName, province.CountryID); err == nil {
+		return domain.Province{}, ErrConflict
+	} else if !errors.Is(err, errors2.ErrNotFound) {
+		return domain.Province{}, err
+	}
+
+	id, err := service.provinceRepository.Save(ctx, province)
+	if err != nil {
+		return domain.Province{}, err
+	}
+
+	province.ID = id
+
+	return province, nil
+}
+
+func (service *provinceService) Update(ctx context.Context, id int, updateProvinceRequest dtos.UpdateProvinceRequestDTO) (domain.Province, error) {
+	existingProvince, err := service.provinceRepository.Get(ctx, id)
+	if err != nil {
+		return domain.Province{}, err
+	}
+
+	if updateProvinceRequest.ProvinceName != nil {
+		existingProvince.ProvinceName = *updateProvinceRequest.ProvinceName
+	}
+	if updateProvinceRequest.CountryID != nil {
+		if !service.countryRepository.Exists(ctx, *updateProvinceRequest.CountryID) {
+			return domain.Province{}, country.ErrNotFound
+		}
+		existingProvince.CountryID = *updateProvinceRequest.CountryID
+	}
+
+	if foundProvince, err := service.provinceRepository.GetByName(ctx, existingProvince.ProvinceName, existingProvince.CountryID); err == nil && foundProvince.ID != id {
+		return domain.Province{}, ErrConflict
+	} else if err != nil && !errors.Is(err, errors2.ErrNotFound) {
+		return domain.Province{}, err
+	}
+
+	if err = service.provinceRepository.Update(ctx, existingProvince); err != nil {
+		return domain.Province{}, err
+	}
+
+	return existingProvince, nil
+}
+
+func (service *provinceService) Delete(ctx context.Context, id int) error {
+	dependents, err := service.provinceRepository.Dependents(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := database.CheckDependents(dependents); err != nil {
+		return err
+	}
+
+	return service.provinceRepository.Delete(ctx, id)
+}
@@ -0,0 +1,315 @@
+package province
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/country"
+	countryMocks "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/country/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/province/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"os"
+	"testing"
+)
+
+func Test_provinceService_GetAll(t *testing.T) {
+	ctx := context.TODO()
+
+	var expectedProvinces []domain.Province
+	expectedProvincesSerialized, _ := os.ReadFile("../../test/resources/valid_provinces.json")
+	if err := json.Unmarshal(expectedProvincesSerialized, &expectedProvinces); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                 string
+		expectedGetAllResult []domain.Province
+		expectedGetAllError  error
+		want                 []domain.Province
+		wantErr              error
+	}{
+		{
+			name:                 "Successfully get all provinces",
+			expectedGetAllResult: expectedProvinces,
+			want:                 expectedProvinces,
+		},
+		{
+			name:                 "Error getting all",
+			expectedGetAllResult: []domain.Province{},
+			expectedGetAllError:  assert.AnError,
+			want:                 []domain.Province{},
+			wantErr:              assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provinceRepositoryMock := mocks.NewMockProvinceRepository(t)
+			provinceRepositoryMock.On("GetAll", ctx).Return(tt.expectedGetAllResult, tt.expectedGetAllError)
+
+			service := NewProvinceService(provinceRepositoryMock, countryMocks.NewMockCountryRepository(t))
+			got, err := service.GetAll(ctx)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_provinceService_GetAllByCountryID(t *testing.T) {
+	ctx := context.TODO()
+
+	var expectedProvinces []domain.Province
+	expectedProvincesSerialized, _ := os.ReadFile("../../test/resources/valid_provinces.json")
+	if err := json.Unmarshal(expectedProvincesSerialized, &expectedProvinces); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                 string
+		expectedExistsResult bool
+		expectedGetAllCalls  int
+		want                 []domain.Province
+		wantErr              error
+	}{
+		{
+			name:                 "Successfully get provinces of country",
+			expectedExistsResult: true,
+			expectedGetAllCalls:  1,
+			want:                 expectedProvinces[:1],
+		},
+		{
+			name:                 "Error country not found",
+			expectedExistsResult: false,
+			expectedGetAllCalls:  0,
+			want:                 []domain.Province{},
+			wantErr:              country.ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provinceRepositoryMock := mocks.NewMockProvinceRepository(t)
+			provinceRepositoryMock.On("GetAllByCountryID", ctx, 1).Return(expectedProvinces[:1], nil)
+
+			countryRepositoryMock := countryMocks.NewMockCountryRepository(t)
+			countryRepositoryMock.On("Exists", ctx, 1).Return(tt.expectedExistsResult)
+
+			service := NewProvinceService(provinceRepositoryMock, countryRepositoryMock)
+			got, err := service.GetAllByCountryID(ctx, 1)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+
+			provinceRepositoryMock.AssertNumberOfCalls(t, "GetAllByCountryID", tt.expectedGetAllCalls)
+		})
+	}
+}
+
+func Test_provinceService_Get(t *testing.T) {
+	ctx := context.TODO()
+
+	var expectedProvince domain.Province
+	expectedProvinceSerialized, _ := os.ReadFile("../../test/resources/valid_province.json")
+	if err := json.Unmarshal(expectedProvinceSerialized, &expectedProvince); err != nil {
+		t.Fatal(err)
+	}
+
+	provinceRepositoryMock := mocks.NewMockProvinceRepository(t)
+	provinceRepositoryMock.On("Get", ctx, expectedProvince.ID).Return(expectedProvince, nil)
+	provinceRepositoryMock.On("Get", ctx, 999).Return(domain.Province{}, ErrNotFound)
+
+	service := NewProvinceService(provinceRepositoryMock, countryMocks.NewMockCountryRepository(t))
+
+	got, err := service.Get(ctx, expectedProvince.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedProvince, got)
+
+	got, err = service.Get(ctx, 999)
+	assert.Equal(t, ErrNotFound, err)
+	assert.Equal(t, domain.Province{}, got)
+}
+
+func Test_provinceService_Create(t *testing.T) {
+	ctx := context.TODO()
+
+	var expectedProvince domain.Province
+	expectedProvinceSerialized, _ := os.ReadFile("../../test/resources/valid_province.json")
+	if err := json.Unmarshal(expectedProvinceSerialized, &expectedProvince); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                 string
+		expectedExistsResult bool
+		expectedGetByNameErr error
+		expectedSaveError    error
+		expectedSaveCalls    int
+		want                 domain.Province
+		wantErr              error
+	}{
+		{
+			name:                 "Successfully create province",
+			expectedExistsResult: true,
+			expectedGetByNameErr: ErrNotFound,
+			expectedSaveCalls:    1,
+			want:                 expectedProvince,
+		},
+		{
+			name:                 "Error country not found",
+			expectedExistsResult: false,
+			want:                 domain.Province{},
+			wantErr:              country.ErrNotFound,
+		},
+		{
+			name:                 "Error duplicated province name",
+			expectedExistsResult: true,
+			expectedGetByNameErr: nil,
+			want:                 domain.Province{},
+			wantErr:              ErrConflict,
+		},
+		{
+			name:                 "Error saving province",
+			expectedExistsResult: true,
+			expectedGetByNameErr: ErrNotFound,
+			expectedSaveError:    assert.AnError,
+			expectedSaveCalls:    1,
+			want:                 domain.Province{},
+			wantErr:              assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provinceRepositoryMock := mocks.NewMockProvinceRepository(t)
+			provinceRepositoryMock.On("GetByName", ctx, expectedProvince.ProvinceName, expectedProvince.CountryID).Return(expectedProvince, tt.expectedGetByNameErr)
+			provinceRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.Province")).Return(expectedProvince.ID, tt.expectedSaveError)
+
+			countryRepositoryMock := countryMocks.NewMockCountryRepository(t)
+			countryRepositoryMock.On("Exists", ctx, expectedProvince.CountryID).Return(tt.expectedExistsResult)
+
+			request := expectedProvince
+			request.ID = 0
+
+			service := NewProvinceService(provinceRepositoryMock, countryRepositoryMock)
+			got, err := service.Create(ctx, request)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+
+			provinceRepositoryMock.AssertNumberOfCalls(t, "Save", tt.expectedSaveCalls)
+		})
+	}
+}
+
+func Test_provinceService_Update(t *testing.T) {
+	ctx := context.TODO()
+
+	var originalProvince domain.Province
+	originalProvinceSerialized, _ := os.ReadFile("../../test/resources/valid_province.json")
+	if err := json.Unmarshal(originalProvinceSerialized, &originalProvince); err != nil {
+		t.Fatal(err)
+	}
+
+	newProvinceName := "Cordoba"
+	newCountryID := 2
+	updatedProvince := domain.Province{ID: originalProvince.ID, ProvinceName: newProvinceName, CountryID: newCountryID}
+
+	tests := []struct {
+		name                 string
+		expectedGetResult    domain.Province
+		expectedGetError     error
+		expectedExistsResult bool
+		expectedGetByName    domain.Province
+		expectedUpdateError  error
+		expectedUpdateCalls  int
+		want                 domain.Province
+		wantErr              error
+	}{
+		{
+			name:                 "Successfully update province",
+			expectedGetResult:    originalProvince,
+			expectedExistsResult: true,
+			expectedUpdateCalls:  1,
+			want:                 updatedProvince,
+		},
+		{
+			name:              "Error province not found",
+			expectedGetResult: domain.Province{},
+			expectedGetError:  ErrNotFound,
+			want:              domain.Province{},
+			wantErr:           ErrNotFound,
+		},
+		{
+			name:                 "Error new country not found",
+			expectedGetResult:    originalProvince,
+			expectedExistsResult: false,
+			want:                 domain.Province{},
+			wantErr:              country.ErrNotFound,
+		},
+		{
+			name:                 "Error duplicated province name in the country",
+			expectedGetResult:    originalProvince,
+			expectedExistsResult: true,
+			expectedGetByName:    domain.Province{ID: 2, ProvinceName: newProvinceName, CountryID: newCountryID},
+			want:                 domain.Province{},
+			wantErr:              ErrConflict,
+		},
+		{
+			name:                 "Error updating province",
+			expectedGetResult:    originalProvince,
+			expectedExistsResult: true,
+			expectedUpdateError:  assert.AnError,
+			expectedUpdateCalls:  1,
+			want:                 domain.Province{},
+			wantErr:              assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provinceRepositoryMock := mocks.NewMockProvinceRepository(t)
+			provinceRepositoryMock.On("Get", ctx, originalProvince.ID).Return(tt.expectedGetResult, tt.expectedGetError)
+			getByNameError := error(ErrNotFound)
+			if tt.expectedGetByName.ID != 0 {
+				getByNameError = nil
+			}
+			provinceRepositoryMock.On("GetByName", ctx, newProvinceName, newCountryID).Return(tt.expectedGetByName, getByNameError)
+			provinceRepositoryMock.On("Update", ctx, updatedProvince).Return(tt.expectedUpdateError)
+
+			countryRepositoryMock := countryMocks.NewMockCountryRepository(t)
+			countryRepositoryMock.On("Exists", ctx, newCountryID).Return(tt.expectedExistsResult)
+
+			service := NewProvinceService(provinceRepositoryMock, countryRepositoryMock)
+			got, err := service.Update(ctx, originalProvince.ID, dtos.UpdateProvinceRequestDTO{
+				ProvinceName: &newProvinceName,
+				CountryID:    &newCountryID,
+			})
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+
+			provinceRepositoryMock.AssertNumberOfCalls(t, "Update", tt.expectedUpdateCalls)
+		})
+	}
+}
+
+func Test_provinceService_Delete(t *testing.T) {
+	ctx := context.TODO()
+
+	provinceRepositoryMock := mocks.NewMockProvinceRepository(t)
+	provinceRepositoryMock.On("Dependents", ctx, 1).Return(map[string]int{"localities": 0}, nil)
+	provinceRepositoryMock.On("Dependents", ctx, 999).Return(map[string]int{"localities": 0}, nil)
+	provinceRepositoryMock.On("Dependents", ctx, 2).Return(map[string]int{"localities": 3}, nil)
+	provinceRepositoryMock.On("Delete", ctx, 1).Return(nil)
+	provinceRepositoryMock.On("Delete", ctx, 999).Return(ErrNotFound)
+
+	service := NewProvinceService(provinceRepositoryMock, countryMocks.NewMockCountryRepository(t))
+
+	assert.NoError(t, service.Delete(ctx, 1))
+	assert.Equal(t, ErrNotFound, service.Delete(ctx, 999))
+
+	err := service.Delete(ctx, 2)
+	assert.ErrorIs(t, err, database.ErrHasDependents)
+	assert.Equal(t, &database.DependentsError{Dependents: map[string]int{"localities": 3}}, err)
+	provinceRepositoryMock.AssertNotCalled(t, "Delete", ctx, 2)
+}
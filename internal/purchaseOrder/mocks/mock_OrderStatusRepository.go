@@ -0,0 +1,153 @@
+// Code generated by mockery v2.30.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockOrderStatusRepository is an autogenerated mock type for the OrderStatusRepository type
+type MockOrderStatusRepository struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *MockOrderStatusRepository) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *MockOrderStatusRepository) Get(ctx context.Context, id int) (domain.OrderStatus, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 domain.OrderStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.OrderStatus, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) domain.OrderStatus); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.OrderStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx
+func (_m *MockOrderStatusRepository) GetAll(ctx context.Context) ([]domain.OrderStatus, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []domain.OrderStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.OrderStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.OrderStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.OrderStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByCode provides a mock function with given fields: ctx, code
+func (_m *MockOrderStatusRepository) GetByCode(ctx context.Context, code string) (domain.OrderStatus, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 domain.OrderStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (domain.OrderStatus, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.OrderStatus); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Get(0).(domain.OrderStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, orderStatus
+func (_m *MockOrderStatusRepository) Save(ctx context.Context, orderStatus domain.OrderStatus) (int, error) {
+	ret := _m.Called(ctx, orderStatus)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OrderStatus) (int, error)); ok {
+		return rf(ctx, orderStatus)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OrderStatus) int); ok {
+		r0 = rf(ctx, orderStatus)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.OrderStatus) error); ok {
+		r1 = rf(ctx, orderStatus)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, orderStatus
+func (_m *MockOrderStatusRepository) Update(ctx context.Context, orderStatus domain.OrderStatus) error {
+	ret := _m.Called(ctx, orderStatus)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OrderStatus) error); ok {
+		r0 = rf(ctx, orderStatus)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMockOrderStatusRepository creates a new instance of MockOrderStatusRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOrderStatusRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOrderStatusRepository {
+	mock := &MockOrderStatusRepository{}
+	mock.Mock.Test(t)
+
+	return mock
+}
@@ -0,0 +1,141 @@
+// Code generated by mockery v2.30.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/purchase_order"
+	domain "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockOrderStatusService is an autogenerated mock type for the OrderStatusService type
+type MockOrderStatusService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, orderStatus
+func (_m *MockOrderStatusService) Create(ctx context.Context, orderStatus domain.OrderStatus) (domain.OrderStatus, error) {
+	ret := _m.Called(ctx, orderStatus)
+
+	var r0 domain.OrderStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OrderStatus) (domain.OrderStatus, error)); ok {
+		return rf(ctx, orderStatus)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.OrderStatus) domain.OrderStatus); ok {
+		r0 = rf(ctx, orderStatus)
+	} else {
+		r0 = ret.Get(0).(domain.OrderStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.OrderStatus) error); ok {
+		r1 = rf(ctx, orderStatus)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *MockOrderStatusService) Delete(ctx context.Context, id int) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *MockOrderStatusService) Get(ctx context.Context, id int) (domain.OrderStatus, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 domain.OrderStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.OrderStatus, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) domain.OrderStatus); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(domain.OrderStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx
+func (_m *MockOrderStatusService) GetAll(ctx context.Context) ([]domain.OrderStatus, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []domain.OrderStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.OrderStatus, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.OrderStatus); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.OrderStatus)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, id, updateOrderStatusRequest
+func (_m *MockOrderStatusService) Update(ctx context.Context, id int, updateOrderStatusRequest dtos.UpdateOrderStatusRequestDTO) (domain.OrderStatus, error) {
+	ret := _m.Called(ctx, id, updateOrderStatusRequest)
+
+	var r0 domain.OrderStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, dtos.UpdateOrderStatusRequestDTO) (domain.OrderStatus, error)); ok {
+		return rf(ctx, id, updateOrderStatusRequest)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, dtos.UpdateOrderStatusRequestDTO) domain.OrderStatus); ok {
+		r0 = rf(ctx, id, updateOrderStatusRequest)
+	} else {
+		r0 = ret.Get(0).(domain.OrderStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, dtos.UpdateOrderStatusRequestDTO) error); ok {
+		r1 = rf(ctx, id, updateOrderStatusRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockOrderStatusService creates a new instance of MockOrderStatusService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOrderStatusService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOrderStatusService {
+	mock := &MockOrderStatusService{}
+	mock.Mock.Test(t)
+
+	return mock
+}
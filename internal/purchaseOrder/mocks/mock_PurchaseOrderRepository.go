@@ -4,7 +4,11 @@ package mocks
 
 import (
 	context "context"
-	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/buyer"
+	domain "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+
+	listing "github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -52,6 +56,20 @@ func (_m *MockPurchaseOrderRepository) Delete(ctx context.Context, id int) error
 	return r0
 }
 
+// Each provides a mock function with given fields: ctx, options, fn
+func (_m *MockPurchaseOrderRepository) Each(ctx context.Context, options listing.Options, fn func(domain.PurchaseOrder) error) error {
+	ret := _m.Called(ctx, options, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options, func(domain.PurchaseOrder) error) error); ok {
+		r0 = rf(ctx, options, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Exists provides a mock function with given fields: ctx, id
 func (_m *MockPurchaseOrderRepository) Exists(ctx context.Context, id int) bool {
 	ret := _m.Called(ctx, id)
@@ -116,6 +134,65 @@ func (_m *MockPurchaseOrderRepository) GetAll(ctx context.Context) ([]domain.Pur
 	return r0, r1
 }
 
+// GetPage provides a mock function with given fields: ctx, options
+func (_m *MockPurchaseOrderRepository) GetPage(ctx context.Context, options listing.Options) ([]domain.PurchaseOrder, int, error) {
+	ret := _m.Called(ctx, options)
+
+	var r0 []domain.PurchaseOrder
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) ([]domain.PurchaseOrder, int, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) []domain.PurchaseOrder); ok {
+		r0 = rf(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PurchaseOrder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Options) int); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Options) error); ok {
+		r2 = rf(ctx, options)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ReportByBuyers provides a mock function with given fields: ctx, buyerID, filter
+func (_m *MockPurchaseOrderRepository) ReportByBuyers(ctx context.Context, buyerID int, filter dtos.GetPurchaseOrdersReportRequestDTO) ([]dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO, error) {
+	ret := _m.Called(ctx, buyerID, filter)
+
+	var r0 []dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, dtos.GetPurchaseOrdersReportRequestDTO) ([]dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO, error)); ok {
+		return rf(ctx, buyerID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, dtos.GetPurchaseOrdersReportRequestDTO) []dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO); ok {
+		r0 = rf(ctx, buyerID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, dtos.GetPurchaseOrdersReportRequestDTO) error); ok {
+		r1 = rf(ctx, buyerID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: ctx, _a1
 func (_m *MockPurchaseOrderRepository) Save(ctx context.Context, _a1 domain.PurchaseOrder) (int, error) {
 	ret := _m.Called(ctx, _a1)
@@ -154,6 +231,20 @@ func (_m *MockPurchaseOrderRepository) Update(ctx context.Context, _a1 domain.Pu
 	return r0
 }
 
+// UpdateStatus provides a mock function with given fields: ctx, id, fromStatusID, toStatusID
+func (_m *MockPurchaseOrderRepository) UpdateStatus(ctx context.Context, id int, fromStatusID int, toStatusID int) error {
+	ret := _m.Called(ctx, id, fromStatusID, toStatusID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, int) error); ok {
+		r0 = rf(ctx, id, fromStatusID, toStatusID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewMockPurchaseOrderRepository creates a new instance of MockPurchaseOrderRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockPurchaseOrderRepository(t interface {
@@ -4,11 +4,15 @@ package mocks
 
 import (
 	context "context"
-	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 
-	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/purchase_order"
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/buyer"
+	domain "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+
+	listing "github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 
 	mock "github.com/stretchr/testify/mock"
+
+	purchase_order "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/purchase_order"
 )
 
 // MockPurchaseOrderService is an autogenerated mock type for the PurchaseOrderService type
@@ -17,21 +21,21 @@ type MockPurchaseOrderService struct {
 }
 
 // CountByBuyerID provides a mock function with given fields: ctx, buyerID
-func (_m *MockPurchaseOrderService) CountByBuyerID(ctx *context.Context, buyerID int) (int, error) {
+func (_m *MockPurchaseOrderService) CountByBuyerID(ctx context.Context, buyerID int) (int, error) {
 	ret := _m.Called(ctx, buyerID)
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*context.Context, int) (int, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
 		return rf(ctx, buyerID)
 	}
-	if rf, ok := ret.Get(0).(func(*context.Context, int) int); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int) int); ok {
 		r0 = rf(ctx, buyerID)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(*context.Context, int) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
 		r1 = rf(ctx, buyerID)
 	} else {
 		r1 = ret.Error(1)
@@ -41,21 +45,21 @@ func (_m *MockPurchaseOrderService) CountByBuyerID(ctx *context.Context, buyerID
 }
 
 // Create provides a mock function with given fields: ctx, _a1
-func (_m *MockPurchaseOrderService) Create(ctx *context.Context, _a1 domain.PurchaseOrder) (domain.PurchaseOrder, error) {
+func (_m *MockPurchaseOrderService) Create(ctx context.Context, _a1 domain.PurchaseOrder) (domain.PurchaseOrder, error) {
 	ret := _m.Called(ctx, _a1)
 
 	var r0 domain.PurchaseOrder
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*context.Context, domain.PurchaseOrder) (domain.PurchaseOrder, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PurchaseOrder) (domain.PurchaseOrder, error)); ok {
 		return rf(ctx, _a1)
 	}
-	if rf, ok := ret.Get(0).(func(*context.Context, domain.PurchaseOrder) domain.PurchaseOrder); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PurchaseOrder) domain.PurchaseOrder); ok {
 		r0 = rf(ctx, _a1)
 	} else {
 		r0 = ret.Get(0).(domain.PurchaseOrder)
 	}
 
-	if rf, ok := ret.Get(1).(func(*context.Context, domain.PurchaseOrder) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PurchaseOrder) error); ok {
 		r1 = rf(ctx, _a1)
 	} else {
 		r1 = ret.Error(1)
@@ -65,11 +69,11 @@ func (_m *MockPurchaseOrderService) Create(ctx *context.Context, _a1 domain.Purc
 }
 
 // Delete provides a mock function with given fields: ctx, id
-func (_m *MockPurchaseOrderService) Delete(ctx *context.Context, id int) error {
+func (_m *MockPurchaseOrderService) Delete(ctx context.Context, id int) error {
 	ret := _m.Called(ctx, id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*context.Context, int) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
 		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
@@ -78,22 +82,36 @@ func (_m *MockPurchaseOrderService) Delete(ctx *context.Context, id int) error {
 	return r0
 }
 
+// Each provides a mock function with given fields: ctx, options, fn
+func (_m *MockPurchaseOrderService) Each(ctx context.Context, options listing.Options, fn func(domain.PurchaseOrder) error) error {
+	ret := _m.Called(ctx, options, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options, func(domain.PurchaseOrder) error) error); ok {
+		r0 = rf(ctx, options, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ctx, id
-func (_m *MockPurchaseOrderService) Get(ctx *context.Context, id int) (domain.PurchaseOrder, error) {
+func (_m *MockPurchaseOrderService) Get(ctx context.Context, id int) (domain.PurchaseOrder, error) {
 	ret := _m.Called(ctx, id)
 
 	var r0 domain.PurchaseOrder
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*context.Context, int) (domain.PurchaseOrder, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int) (domain.PurchaseOrder, error)); ok {
 		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(*context.Context, int) domain.PurchaseOrder); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int) domain.PurchaseOrder); ok {
 		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Get(0).(domain.PurchaseOrder)
 	}
 
-	if rf, ok := ret.Get(1).(func(*context.Context, int) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
 		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
@@ -103,15 +121,15 @@ func (_m *MockPurchaseOrderService) Get(ctx *context.Context, id int) (domain.Pu
 }
 
 // GetAll provides a mock function with given fields: ctx
-func (_m *MockPurchaseOrderService) GetAll(ctx *context.Context) ([]domain.PurchaseOrder, error) {
+func (_m *MockPurchaseOrderService) GetAll(ctx context.Context) ([]domain.PurchaseOrder, error) {
 	ret := _m.Called(ctx)
 
 	var r0 []domain.PurchaseOrder
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*context.Context) ([]domain.PurchaseOrder, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.PurchaseOrder, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(*context.Context) []domain.PurchaseOrder); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.PurchaseOrder); ok {
 		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
@@ -119,7 +137,7 @@ func (_m *MockPurchaseOrderService) GetAll(ctx *context.Context) ([]domain.Purch
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*context.Context) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
 		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
@@ -128,22 +146,105 @@ func (_m *MockPurchaseOrderService) GetAll(ctx *context.Context) ([]domain.Purch
 	return r0, r1
 }
 
+// GetPage provides a mock function with given fields: ctx, options
+func (_m *MockPurchaseOrderService) GetPage(ctx context.Context, options listing.Options) ([]domain.PurchaseOrder, int, error) {
+	ret := _m.Called(ctx, options)
+
+	var r0 []domain.PurchaseOrder
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) ([]domain.PurchaseOrder, int, error)); ok {
+		return rf(ctx, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options) []domain.PurchaseOrder); ok {
+		r0 = rf(ctx, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PurchaseOrder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, listing.Options) int); ok {
+		r1 = rf(ctx, options)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, listing.Options) error); ok {
+		r2 = rf(ctx, options)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ReportAllBuyers provides a mock function with given fields: ctx, filter
+func (_m *MockPurchaseOrderService) ReportAllBuyers(ctx context.Context, filter dtos.GetPurchaseOrdersReportRequestDTO) ([]dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dtos.GetPurchaseOrdersReportRequestDTO) ([]dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dtos.GetPurchaseOrdersReportRequestDTO) []dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dtos.GetPurchaseOrdersReportRequestDTO) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReportByBuyer provides a mock function with given fields: ctx, buyerID, filter
+func (_m *MockPurchaseOrderService) ReportByBuyer(ctx context.Context, buyerID int, filter dtos.GetPurchaseOrdersReportRequestDTO) (dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO, error) {
+	ret := _m.Called(ctx, buyerID, filter)
+
+	var r0 dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, dtos.GetPurchaseOrdersReportRequestDTO) (dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO, error)); ok {
+		return rf(ctx, buyerID, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, dtos.GetPurchaseOrdersReportRequestDTO) dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO); ok {
+		r0 = rf(ctx, buyerID, filter)
+	} else {
+		r0 = ret.Get(0).(dtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, dtos.GetPurchaseOrdersReportRequestDTO) error); ok {
+		r1 = rf(ctx, buyerID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, id, updatePurchaseOrderRequest
-func (_m *MockPurchaseOrderService) Update(ctx *context.Context, id int, updatePurchaseOrderRequest dtos.UpdatePurchaseOrderRequestDTO) (domain.PurchaseOrder, error) {
+func (_m *MockPurchaseOrderService) Update(ctx context.Context, id int, updatePurchaseOrderRequest purchase_order.UpdatePurchaseOrderRequestDTO) (domain.PurchaseOrder, error) {
 	ret := _m.Called(ctx, id, updatePurchaseOrderRequest)
 
 	var r0 domain.PurchaseOrder
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*context.Context, int, dtos.UpdatePurchaseOrderRequestDTO) (domain.PurchaseOrder, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int, purchase_order.UpdatePurchaseOrderRequestDTO) (domain.PurchaseOrder, error)); ok {
 		return rf(ctx, id, updatePurchaseOrderRequest)
 	}
-	if rf, ok := ret.Get(0).(func(*context.Context, int, dtos.UpdatePurchaseOrderRequestDTO) domain.PurchaseOrder); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, int, purchase_order.UpdatePurchaseOrderRequestDTO) domain.PurchaseOrder); ok {
 		r0 = rf(ctx, id, updatePurchaseOrderRequest)
 	} else {
 		r0 = ret.Get(0).(domain.PurchaseOrder)
 	}
 
-	if rf, ok := ret.Get(1).(func(*context.Context, int, dtos.UpdatePurchaseOrderRequestDTO) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, int, purchase_order.UpdatePurchaseOrderRequestDTO) error); ok {
 		r1 = rf(ctx, id, updatePurchaseOrderRequest)
 	} else {
 		r1 = ret.Error(1)
@@ -0,0 +1,77 @@
+// Code generated by mockery v2.30.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTransitionRepository is an autogenerated mock type for the TransitionRepository type
+type MockTransitionRepository struct {
+	mock.Mock
+}
+
+// GetAllByPurchaseOrderID provides a mock function with given fields: ctx, purchaseOrderID
+func (_m *MockTransitionRepository) GetAllByPurchaseOrderID(ctx context.Context, purchaseOrderID int) ([]domain.PurchaseOrderTransition, error) {
+	ret := _m.Called(ctx, purchaseOrderID)
+
+	var r0 []domain.PurchaseOrderTransition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.PurchaseOrderTransition, error)); ok {
+		return rf(ctx, purchaseOrderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.PurchaseOrderTransition); ok {
+		r0 = rf(ctx, purchaseOrderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PurchaseOrderTransition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, purchaseOrderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, transition
+func (_m *MockTransitionRepository) Save(ctx context.Context, transition domain.PurchaseOrderTransition) (int, error) {
+	ret := _m.Called(ctx, transition)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PurchaseOrderTransition) (int, error)); ok {
+		return rf(ctx, transition)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.PurchaseOrderTransition) int); ok {
+		r0 = rf(ctx, transition)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.PurchaseOrderTransition) error); ok {
+		r1 = rf(ctx, transition)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockTransitionRepository creates a new instance of MockTransitionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTransitionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTransitionRepository {
+	mock := &MockTransitionRepository{}
+	mock.Mock.Test(t)
+
+	return mock
+}
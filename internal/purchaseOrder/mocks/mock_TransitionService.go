@@ -0,0 +1,77 @@
+// Code generated by mockery v2.30.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	domain "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTransitionService is an autogenerated mock type for the TransitionService type
+type MockTransitionService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, purchaseOrderID, statusCode
+func (_m *MockTransitionService) Create(ctx context.Context, purchaseOrderID int, statusCode string) (domain.PurchaseOrderTransition, error) {
+	ret := _m.Called(ctx, purchaseOrderID, statusCode)
+
+	var r0 domain.PurchaseOrderTransition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (domain.PurchaseOrderTransition, error)); ok {
+		return rf(ctx, purchaseOrderID, statusCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) domain.PurchaseOrderTransition); ok {
+		r0 = rf(ctx, purchaseOrderID, statusCode)
+	} else {
+		r0 = ret.Get(0).(domain.PurchaseOrderTransition)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, purchaseOrderID, statusCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, purchaseOrderID
+func (_m *MockTransitionService) GetAll(ctx context.Context, purchaseOrderID int) ([]domain.PurchaseOrderTransition, error) {
+	ret := _m.Called(ctx, purchaseOrderID)
+
+	var r0 []domain.PurchaseOrderTransition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]domain.PurchaseOrderTransition, error)); ok {
+		return rf(ctx, purchaseOrderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []domain.PurchaseOrderTransition); ok {
+		r0 = rf(ctx, purchaseOrderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.PurchaseOrderTransition)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, purchaseOrderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockTransitionService creates a new instance of MockTransitionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTransitionService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTransitionService {
+	mock := &MockTransitionService{}
+	mock.Mock.Test(t)
+
+	return mock
+}
@@ -0,0 +1,130 @@
+package purchaseOrder
+
+import (
+	"context"
+	"database/sql"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+)
+
+type OrderDetailRepository interface {
+	GetAllByPurchaseOrderID(ctx context.Context, purchaseOrderID int) ([]domain.OrderDetail, error)
+	Get(ctx context.Context, purchaseOrderID int, id int) (domain.OrderDetail, error)
+	Save(ctx context.Context, orderDetail domain.OrderDetail) (int, error)
+	Update(ctx context.Context, orderDetail domain.OrderDetail) error
+	Delete(ctx context.Context, purchaseOrderID int, id int) error
+}
+
+const (
+	GetAllOrderDetailsByPurchaseOrderID = "SELECT order_details.id, order_details.clean_liness_status, order_details.quantity, order_details.temperature, order_details.product_record_id, order_details.purchase_order_id FROM order_details WHERE purchase_order_id = ?"
+	GetOrderDetailByID                  = "SELECT order_details.id, order_details.clean_liness_status, order_details.quantity, order_details.temperature, order_details.product_record_id, order_details.purchase_order_id FROM order_details WHERE purchase_order_id = ? AND id = ?"
+	SaveOrderDetail                     = "INSERT INTO order_details(clean_liness_status, quantity, temperature, product_record_id, purchase_order_id) VALUES (?,?,?,?,?)"
+	UpdateOrderDetail                   = "UPDATE order_details SET clean_liness_status=?, quantity=?, temperature=?, product_record_id=? WHERE purchase_order_id=? AND id=?"
+	DeleteOrderDetailByID               = "DELETE FROM order_details WHERE purchase_order_id = ? AND id = ?"
+)
+
+type orderDetailRepository struct {
+	db *sql.DB
+}
+
+func NewOrderDetailRepository(db *sql.DB) OrderDetailRepository {
+	return &orderDetailRepository{
+		db: db,
+	}
+}
+
+func (r *orderDetailRepository) GetAllByPurchaseOrderID(ctx context.Context, purchaseOrderID int) ([]domain.OrderDetail, error) {
+	orderDetails := make([]domain.OrderDetail, 0)
+
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, GetAllOrderDetailsByPurchaseOrderID, purchaseOrderID)
+	if err != nil {
+		return orderDetails, err
+	}
+
+	for rows.Next() {
+		orderDetail := domain.OrderDetail{}
+		err := rows.Scan(&orderDetail.ID, &orderDetail.CleanLinessStatus, &orderDetail.Quantity, &orderDetail.Temperature, &orderDetail.ProductRecordID, &orderDetail.PurchaseOrderID)
+		if err != nil {
+			return orderDetails, err
+		}
+
+		orderDetails = append(orderDetails, orderDetail)
+	}
+
+	return orderDetails, rows.Err()
+}
+
+func (r *orderDetailRepository) Get(ctx context.Context, purchaseOrderID int, id int) (domain.OrderDetail, error) {
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, GetOrderDetailByID, purchaseOrderID, id)
+	orderDetail := domain.OrderDetail{}
+	err := row.Scan(&orderDetail.ID, &orderDetail.CleanLinessStatus, &orderDetail.Quantity, &orderDetail.Temperature, &orderDetail.ProductRecordID, &orderDetail.PurchaseOrderID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.OrderDetail{}, ErrOrderDetailNotFound
+		}
+		return domain.OrderDetail{}, err
+	}
+
+	return orderDetail, nil
+}
+
+func (r *orderDetailRepository) Save(ctx context.Context, orderDetail domain.OrderDetail) (int, error) {
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, SaveOrderDetail)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := stmt.ExecContext(ctx, &orderDetail.CleanLinessStatus, &orderDetail.Quantity, &orderDetail.Temperature, &orderDetail.ProductRecordID, &orderDetail.PurchaseOrderID)
+	if err != nil {
+		return 0, database.TranslateError(err, "order detail")
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
+func (r *orderDetailRepository) Update(ctx context.Context, orderDetail domain.OrderDetail) error {
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, UpdateOrderDetail)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, &orderDetail.CleanLinessStatus, &orderDetail.Quantity, &orderDetail.Temperature, &orderDetail.ProductRecordID, &orderDetail.PurchaseOrderID, &orderDetail.ID)
+	if err != nil {
+		return database.TranslateError(err, "order detail")
+	}
+
+	_, err = res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *orderDetailRepository) Delete(ctx context.Context, purchaseOrderID int, id int) error {
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, DeleteOrderDetailByID)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, purchaseOrderID, id)
+	if err != nil {
+		return err
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affect < 1 {
+		return ErrOrderDetailNotFound
+	}
+
+	return nil
+}
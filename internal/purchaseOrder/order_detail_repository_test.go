@@ -0,0 +1,217 @@
+package purchaseOrder
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"regexp"
+	"testing"
+)
+
+func Test_orderDetailRepository_GetAllByPurchaseOrderID(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	validOrderDetailsSerialized, _ := os.ReadFile("../../test/resources/valid_order_details.json")
+	var validOrderDetails []domain.OrderDetail
+	if err := json.Unmarshal(validOrderDetailsSerialized, &validOrderDetails); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		want    []domain.OrderDetail
+		wantErr bool
+	}{
+		{
+			name:    "Successfully get order details",
+			want:    validOrderDetails,
+			wantErr: false,
+		},
+		{
+			name:    "Error getting order details",
+			want:    []domain.OrderDetail{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewOrderDetailRepository(db)
+
+			rows := sqlmock.NewRows([]string{"id", "clean_liness_status", "quantity", "temperature", "product_record_id", "purchase_order_id"})
+			for _, orderDetail := range validOrderDetails {
+				rows.AddRow(orderDetail.ID, orderDetail.CleanLinessStatus, orderDetail.Quantity, orderDetail.Temperature, orderDetail.ProductRecordID, orderDetail.PurchaseOrderID)
+			}
+			if tt.wantErr {
+				rows.RowError(0, sql.ErrNoRows)
+			}
+
+			mock.ExpectQuery(regexp.QuoteMeta(GetAllOrderDetailsByPurchaseOrderID)).
+				WithArgs(1).
+				WillReturnRows(rows)
+
+			got, err := r.GetAllByPurchaseOrderID(ctx, 1)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func Test_orderDetailRepository_Get(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	orderDetailSerialized, _ := os.ReadFile("../../test/resources/valid_order_detail.json")
+	var validOrderDetail domain.OrderDetail
+	if err := json.Unmarshal(orderDetailSerialized, &validOrderDetail); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		queryErr error
+		want     domain.OrderDetail
+		wantErr  error
+	}{
+		{
+			name:    "Successfully get order detail",
+			want:    validOrderDetail,
+			wantErr: nil,
+		},
+		{
+			name:     "Error nonexistent order detail",
+			queryErr: sql.ErrNoRows,
+			want:     domain.OrderDetail{},
+			wantErr:  ErrOrderDetailNotFound,
+		},
+		{
+			name:     "Error querying order detail",
+			queryErr: assert.AnError,
+			want:     domain.OrderDetail{},
+			wantErr:  assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewOrderDetailRepository(db)
+
+			expected := mock.ExpectQuery(regexp.QuoteMeta(GetOrderDetailByID)).
+				WithArgs(validOrderDetail.PurchaseOrderID, validOrderDetail.ID)
+			if tt.queryErr != nil {
+				expected.WillReturnError(tt.queryErr)
+			} else {
+				rows := sqlmock.NewRows([]string{"id", "clean_liness_status", "quantity", "temperature", "product_record_id", "purchase_order_id"})
+				rows.AddRow(tt.want.ID, tt.want.CleanLinessStatus, tt.want.Quantity, tt.want.Temperature, tt.want.ProductRecordID, tt.want.PurchaseOrderID)
+				expected.WillReturnRows(rows)
+			}
+
+			got, err := r.Get(ctx, validOrderDetail.PurchaseOrderID, validOrderDetail.ID)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_orderDetailRepository_Save(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	orderDetailSerialized, _ := os.ReadFile("../../test/resources/valid_order_detail.json")
+	var orderDetail domain.OrderDetail
+	if err := json.Unmarshal(orderDetailSerialized, &orderDetail); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewOrderDetailRepository(db)
+
+	mock.ExpectPrepare(regexp.QuoteMeta(SaveOrderDetail))
+	mock.ExpectExec(regexp.QuoteMeta(SaveOrderDetail)).
+		WithArgs(
+			orderDetail.CleanLinessStatus,
+			orderDetail.Quantity,
+			orderDetail.Temperature,
+			orderDetail.ProductRecordID,
+			orderDetail.PurchaseOrderID,
+		).
+		WillReturnResult(sqlmock.NewResult(int64(orderDetail.ID), 1))
+
+	got, err := r.Save(ctx, orderDetail)
+
+	assert.Equal(t, orderDetail.ID, got)
+	assert.NoError(t, err)
+}
+
+func Test_orderDetailRepository_Update(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	orderDetailSerialized, _ := os.ReadFile("../../test/resources/valid_order_detail.json")
+	var orderDetail domain.OrderDetail
+	if err := json.Unmarshal(orderDetailSerialized, &orderDetail); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewOrderDetailRepository(db)
+
+	mock.ExpectPrepare(regexp.QuoteMeta(UpdateOrderDetail))
+	mock.ExpectExec(regexp.QuoteMeta(UpdateOrderDetail)).
+		WithArgs(
+			orderDetail.CleanLinessStatus,
+			orderDetail.Quantity,
+			orderDetail.Temperature,
+			orderDetail.ProductRecordID,
+			orderDetail.PurchaseOrderID,
+			orderDetail.ID,
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	err := r.Update(ctx, orderDetail)
+
+	assert.NoError(t, err)
+}
+
+func Test_orderDetailRepository_Delete(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	tests := []struct {
+		name    string
+		wantErr error
+	}{
+		{
+			name:    "Successfully delete order detail",
+			wantErr: nil,
+		},
+		{
+			name:    "No order detail to delete",
+			wantErr: ErrOrderDetailNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewOrderDetailRepository(db)
+
+			rowsAffected := int64(1)
+			if tt.wantErr != nil {
+				rowsAffected = 0
+			}
+
+			mock.ExpectPrepare(regexp.QuoteMeta(DeleteOrderDetailByID))
+			mock.ExpectExec(regexp.QuoteMeta(DeleteOrderDetailByID)).
+				WithArgs(1, 2).
+				WillReturnResult(sqlmock.NewResult(1, rowsAffected))
+
+			err := r.Delete(ctx, 1, 2)
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
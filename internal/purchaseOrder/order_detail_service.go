@@ -0,0 +1,122 @@
+package purchaseOrder
+
+import (
+	"context"
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/purchase_order"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+)
+
+type OrderDetailService interface {
+	GetAll(ctx context.Context, purchaseOrderID int) ([]domain.OrderDetail, error)
+	Get(ctx context.Context, purchaseOrderID int, id int) (domain.OrderDetail, error)
+	Create(ctx context.Context, purchaseOrderID int, orderDetail domain.OrderDetail) (domain.OrderDetail, error)
+	Update(ctx context.Context, purchaseOrderID int, id int, updateOrderDetailRequest dtos.UpdateOrderDetailRequestDTO) (domain.OrderDetail, error)
+	Delete(ctx context.Context, purchaseOrderID int, id int) error
+}
+
+type orderDetailService struct {
+	orderDetailRepository   OrderDetailRepository
+	purchaseOrderRepository PurchaseOrderRepository
+	unitOfWork              database.UnitOfWork
+}
+
+func NewOrderDetailService(r OrderDetailRepository, purchaseOrderRepository PurchaseOrderRepository, unitOfWork database.UnitOfWork) OrderDetailService {
+	return &orderDetailService{
+		orderDetailRepository:   r,
+		purchaseOrderRepository: purchaseOrderRepository,
+		unitOfWork:              unitOfWork,
+	}
+}
+
+func (service *orderDetailService) GetAll(ctx context.Context, purchaseOrderID int) ([]domain.OrderDetail, error) {
+	if !service.purchaseOrderRepository.Exists(ctx, purchaseOrderID) {
+		return []domain.OrderDetail{}, ErrNotFound
+	}
+
+	orderDetails, err := service.orderDetailRepository.GetAllByPurchaseOrderID(ctx, purchaseOrderID)
+	if err != nil {
+		return []domain.OrderDetail{}, err
+	}
+
+	return orderDetails, nil
+}
+
+func (service *orderDetailService) Get(ctx context.Context, purchaseOrderID int, id int) (domain.OrderDetail, error) {
+	if !service.purchaseOrderRepository.Exists(ctx, purchaseOrderID) {
+		return domain.OrderDetail{}, ErrNotFound
+	}
+
+	orderDetail, err := service.orderDetailRepository.Get(ctx, purchaseOrderID, id)
+	if err != nil {
+		return domain.OrderDetail{}, err
+	}
+
+	return orderDetail, nil
+}
+
+// Create checks the purchase order and saves the detail in a single unit of work.
+func (service *orderDetailService) Create(ctx context.Context, purchaseOrderID int, orderDetail domain.OrderDetail) (domain.OrderDetail, error) {
+	err := service.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if !service.purchaseOrderRepository.Exists(ctx, purchaseOrderID) {
+			return ErrNotFound
+		}
+
+		orderDetail.PurchaseOrderID = purchaseOrderID
+
+		id, err := service.orderDetailRepository.Save(ctx, orderDetail)
+		if err != nil {
+			return err
+		}
+
+		orderDetail.ID = id
+
+		return nil
+	})
+	if err != nil {
+		return domain.OrderDetail{}, err
+	}
+
+	return orderDetail, nil
+}
+
+func (service *orderDetailService) Update(ctx context.Context, purchaseOrderID int, id int, updateOrderDetailRequest dtos.UpdateOrderDetailRequestDTO) (domain.OrderDetail, error) {
+	if !service.purchaseOrderRepository.Exists(ctx, purchaseOrderID) {
+		return domain.OrderDetail{}, ErrNotFound
+	}
+
+	existingOrderDetail, err := service.orderDetailRepository.Get(ctx, purchaseOrderID, id)
+	if err != nil {
+		return domain.OrderDetail{}, err
+	}
+
+	if updateOrderDetailRequest.CleanLinessStatus != nil {
+		existingOrderDetail.CleanLinessStatus = *updateOrderDetailRequest.CleanLinessStatus
+	}
+
+	if updateOrderDetailRequest.Quantity != nil {
+		existingOrderDetail.Quantity = *updateOrderDetailRequest.Quantity
+	}
+
+	if updateOrderDetailRequest.Temperature != nil {
+		existingOrderDetail.Temperature = *updateOrderDetailRequest.Temperature
+	}
+
+	if updateOrderDetailRequest.ProductRecordID != nil {
+		existingOrderDetail.ProductRecordID = *updateOrderDetailRequest.ProductRecordID
+	}
+
+	if err = service.orderDetailRepository.Update(ctx, existingOrderDetail); err != nil {
+		return domain.OrderDetail{}, err
+	}
+
+	return existingOrderDetail, nil
+}
+
+func (service *orderDetailService) Delete(ctx context.Context, purchaseOrderID int, id int) error {
+	if !service.purchaseOrderRepository.Exists(ctx, purchaseOrderID) {
+		return ErrNotFound
+	}
+
+	return service.orderDetailRepository.Delete(ctx, purchaseOrderID, id)
+}
@@ -0,0 +1,345 @@
+package purchaseOrder
+
+import (
+	"context"
+	"encoding/json"
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/purchase_order"
+	database_mocks "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/purchaseOrder/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"os"
+	"testing"
+)
+
+func Test_orderDetailService_GetAll(t *testing.T) {
+	ctx := context.TODO()
+
+	var expectedOrderDetails []domain.OrderDetail
+	expectedOrderDetailsSerialized, _ := os.ReadFile("../../test/resources/valid_order_details.json")
+	if err := json.Unmarshal(expectedOrderDetailsSerialized, &expectedOrderDetails); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                 string
+		expectedExistsResult bool
+		expectedGetAllResult []domain.OrderDetail
+		expectedGetAllError  error
+		expectedGetAllCalls  int
+		want                 []domain.OrderDetail
+		wantErr              error
+	}{
+		{
+			name:                 "Successfully get all",
+			expectedExistsResult: true,
+			expectedGetAllResult: expectedOrderDetails,
+			expectedGetAllCalls:  1,
+			want:                 expectedOrderDetails,
+			wantErr:              nil,
+		},
+		{
+			name:                 "Error purchaseOrder not found",
+			expectedExistsResult: false,
+			expectedGetAllCalls:  0,
+			want:                 []domain.OrderDetail{},
+			wantErr:              ErrNotFound,
+		},
+		{
+			name:                 "Error getting all",
+			expectedExistsResult: true,
+			expectedGetAllResult: []domain.OrderDetail{},
+			expectedGetAllError:  assert.AnError,
+			expectedGetAllCalls:  1,
+			want:                 []domain.OrderDetail{},
+			wantErr:              assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			purchaseOrderRepositoryMock := mocks.NewMockPurchaseOrderRepository(t)
+			purchaseOrderRepositoryMock.On("Exists", ctx, 1).Return(tt.expectedExistsResult)
+
+			orderDetailRepositoryMock := mocks.NewMockOrderDetailRepository(t)
+			orderDetailRepositoryMock.On("GetAllByPurchaseOrderID", ctx, 1).Return(tt.expectedGetAllResult, tt.expectedGetAllError)
+
+			service := NewOrderDetailService(orderDetailRepositoryMock, purchaseOrderRepositoryMock, database_mocks.NewUnitOfWorkMock())
+			got, err := service.GetAll(ctx, 1)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+
+			orderDetailRepositoryMock.AssertNumberOfCalls(t, "GetAllByPurchaseOrderID", tt.expectedGetAllCalls)
+		})
+	}
+}
+
+func Test_orderDetailService_Get(t *testing.T) {
+	ctx := context.TODO()
+
+	var expectedOrderDetail domain.OrderDetail
+	expectedOrderDetailSerialized, _ := os.ReadFile("../../test/resources/valid_order_detail.json")
+	if err := json.Unmarshal(expectedOrderDetailSerialized, &expectedOrderDetail); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                 string
+		expectedExistsResult bool
+		expectedGetResult    domain.OrderDetail
+		expectedGetError     error
+		expectedGetCalls     int
+		want                 domain.OrderDetail
+		wantErr              error
+	}{
+		{
+			name:                 "Successfully get order detail",
+			expectedExistsResult: true,
+			expectedGetResult:    expectedOrderDetail,
+			expectedGetCalls:     1,
+			want:                 expectedOrderDetail,
+			wantErr:              nil,
+		},
+		{
+			name:                 "Order detail not found",
+			expectedExistsResult: true,
+			expectedGetResult:    domain.OrderDetail{},
+			expectedGetError:     ErrOrderDetailNotFound,
+			expectedGetCalls:     1,
+			want:                 domain.OrderDetail{},
+			wantErr:              ErrOrderDetailNotFound,
+		},
+		{
+			name:                 "Purchase order not found",
+			expectedExistsResult: false,
+			expectedGetCalls:     0,
+			want:                 domain.OrderDetail{},
+			wantErr:              ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			purchaseOrderRepositoryMock := mocks.NewMockPurchaseOrderRepository(t)
+			purchaseOrderRepositoryMock.On("Exists", ctx, 1).Return(tt.expectedExistsResult)
+
+			orderDetailRepositoryMock := mocks.NewMockOrderDetailRepository(t)
+			orderDetailRepositoryMock.On("Get", ctx, 1, 1).Return(tt.expectedGetResult, tt.expectedGetError).Maybe()
+
+			service := NewOrderDetailService(orderDetailRepositoryMock, purchaseOrderRepositoryMock, database_mocks.NewUnitOfWorkMock())
+			got, err := service.Get(ctx, 1, 1)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+
+			orderDetailRepositoryMock.AssertNumberOfCalls(t, "Get", tt.expectedGetCalls)
+		})
+	}
+}
+
+func Test_orderDetailService_Create(t *testing.T) {
+	ctx := context.TODO()
+
+	var expectedOrderDetail domain.OrderDetail
+	expectedOrderDetailSerialized, _ := os.ReadFile("../../test/resources/valid_order_detail.json")
+	if err := json.Unmarshal(expectedOrderDetailSerialized, &expectedOrderDetail); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name                 string
+		expectedExistsResult bool
+		expectedSaveResult   int
+		expectedSaveError    error
+		expectedSaveCalls    int
+		want                 domain.OrderDetail
+		wantErr              error
+	}{
+		{
+			name:                 "Successfully create order detail",
+			expectedExistsResult: true,
+			expectedSaveResult:   expectedOrderDetail.ID,
+			expectedSaveCalls:    1,
+			want:                 expectedOrderDetail,
+			wantErr:              nil,
+		},
+		{
+			name:                 "Error purchaseOrder not found",
+			expectedExistsResult: false,
+			expectedSaveCalls:    0,
+			want:                 domain.OrderDetail{},
+			wantErr:              ErrNotFound,
+		},
+		{
+			name:                 "Error saving order detail",
+			expectedExistsResult: true,
+			expectedSaveError:    assert.AnError,
+			expectedSaveCalls:    1,
+			want:                 domain.OrderDetail{},
+			wantErr:              assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			purchaseOrderRepositoryMock := mocks.NewMockPurchaseOrderRepository(t)
+			purchaseOrderRepositoryMock.On("Exists", ctx, expectedOrderDetail.PurchaseOrderID).Return(tt.expectedExistsResult)
+
+			orderDetailRepositoryMock := mocks.NewMockOrderDetailRepository(t)
+			orderDetailRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.OrderDetail")).Return(tt.expectedSaveResult, tt.expectedSaveError)
+
+			request := expectedOrderDetail
+			request.ID = 0
+			request.PurchaseOrderID = 0
+
+			service := NewOrderDetailService(orderDetailRepositoryMock, purchaseOrderRepositoryMock, database_mocks.NewUnitOfWorkMock())
+			got, err := service.Create(ctx, expectedOrderDetail.PurchaseOrderID, request)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+
+			orderDetailRepositoryMock.AssertNumberOfCalls(t, "Save", tt.expectedSaveCalls)
+		})
+	}
+}
+
+func Test_orderDetailService_Update(t *testing.T) {
+	ctx := context.TODO()
+
+	var originalOrderDetail domain.OrderDetail
+	originalOrderDetailSerialized, _ := os.ReadFile("../../test/resources/valid_order_detail.json")
+	if err := json.Unmarshal(originalOrderDetailSerialized, &originalOrderDetail); err != nil {
+		t.Fatal(err)
+	}
+
+	newCleanLinessStatus := "Not clean"
+	newQuantity := 30
+	newTemperature := -20.0
+	newProductRecordID := 2
+
+	updateOrderDetailRequest := dtos.UpdateOrderDetailRequestDTO{
+		CleanLinessStatus: &newCleanLinessStatus,
+		Quantity:          &newQuantity,
+		Temperature:       &newTemperature,
+		ProductRecordID:   &newProductRecordID,
+	}
+
+	updatedOrderDetail := domain.OrderDetail{
+		ID:                originalOrderDetail.ID,
+		CleanLinessStatus: newCleanLinessStatus,
+		Quantity:          newQuantity,
+		Temperature:       newTemperature,
+		ProductRecordID:   newProductRecordID,
+		PurchaseOrderID:   originalOrderDetail.PurchaseOrderID,
+	}
+
+	tests := []struct {
+		name                 string
+		expectedExistsResult bool
+		expectedGetResult    domain.OrderDetail
+		expectedGetError     error
+		expectedUpdateError  error
+		expectedUpdateCalls  int
+		want                 domain.OrderDetail
+		wantErr              error
+	}{
+		{
+			name:                 "Successfully update order detail",
+			expectedExistsResult: true,
+			expectedGetResult:    originalOrderDetail,
+			expectedUpdateCalls:  1,
+			want:                 updatedOrderDetail,
+			wantErr:              nil,
+		},
+		{
+			name:                 "Error order detail not found",
+			expectedExistsResult: true,
+			expectedGetResult:    domain.OrderDetail{},
+			expectedGetError:     ErrOrderDetailNotFound,
+			expectedUpdateCalls:  0,
+			want:                 domain.OrderDetail{},
+			wantErr:              ErrOrderDetailNotFound,
+		},
+		{
+			name:                 "Error purchase order not found",
+			expectedExistsResult: false,
+			expectedUpdateCalls:  0,
+			want:                 domain.OrderDetail{},
+			wantErr:              ErrNotFound,
+		},
+		{
+			name:                 "Error updating order detail",
+			expectedExistsResult: true,
+			expectedGetResult:    originalOrderDetail,
+			expectedUpdateError:  assert.AnError,
+			expectedUpdateCalls:  1,
+			want:                 domain.OrderDetail{},
+			wantErr:              assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			purchaseOrderRepositoryMock := mocks.NewMockPurchaseOrderRepository(t)
+			purchaseOrderRepositoryMock.On("Exists", ctx, originalOrderDetail.PurchaseOrderID).Return(tt.expectedExistsResult)
+
+			orderDetailRepositoryMock := mocks.NewMockOrderDetailRepository(t)
+			orderDetailRepositoryMock.On("Get", ctx, originalOrderDetail.PurchaseOrderID, originalOrderDetail.ID).Return(tt.expectedGetResult, tt.expectedGetError).Maybe()
+			orderDetailRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.OrderDetail")).Return(tt.expectedUpdateError).Maybe()
+
+			service := NewOrderDetailService(orderDetailRepositoryMock, purchaseOrderRepositoryMock, database_mocks.NewUnitOfWorkMock())
+			got, err := service.Update(ctx, originalOrderDetail.PurchaseOrderID, originalOrderDetail.ID, updateOrderDetailRequest)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+
+			orderDetailRepositoryMock.AssertNumberOfCalls(t, "Update", tt.expectedUpdateCalls)
+		})
+	}
+}
+
+func Test_orderDetailService_Delete(t *testing.T) {
+	ctx := context.TODO()
+
+	tests := []struct {
+		name                 string
+		expectedExistsResult bool
+		expectedDeleteError  error
+		expectedDeleteCalls  int
+		wantErr              error
+	}{
+		{
+			name:                 "Successfully delete order detail",
+			expectedExistsResult: true,
+			expectedDeleteCalls:  1,
+			wantErr:              nil,
+		},
+		{
+			name:                 "Error order detail not found",
+			expectedExistsResult: true,
+			expectedDeleteError:  ErrOrderDetailNotFound,
+			expectedDeleteCalls:  1,
+			wantErr:              ErrOrderDetailNotFound,
+		},
+		{
+			name:                 "Error purchase order not found",
+			expectedExistsResult: false,
+			expectedDeleteCalls:  0,
+			wantErr:              ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			purchaseOrderRepositoryMock := mocks.NewMockPurchaseOrderRepository(t)
+			purchaseOrderRepositoryMock.On("Exists", ctx, 1).Return(tt.expectedExistsResult)
+
+			orderDetailRepositoryMock := mocks.NewMockOrderDetailRepository(t)
+			orderDetailRepositoryMock.On("Delete", ctx, 1, 1).Return(tt.expectedDeleteError).Maybe()
+
+			service := NewOrderDetailService(orderDetailRepositoryMock, purchaseOrderRepositoryMock, database_mocks.NewUnitOfWorkMock())
+			err := service.Delete(ctx, 1, 1)
+
+			assert.Equal(t, tt.wantErr, err)
+
+			orderDetailRepositoryMock.AssertNumberOfCalls(t, "Delete", tt.expectedDeleteCalls)
+		})
+	}
+}
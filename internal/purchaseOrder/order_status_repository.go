@@ -0,0 +1,131 @@
+package purchaseOrder
+
+import (
+	"context"
+	"database/sql"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+)
+
+type OrderStatusRepository interface {
+	GetAll(ctx context.Context) ([]domain.OrderStatus, error)
+	Get(ctx context.Context, id int) (domain.OrderStatus, error)
+	GetByCode(ctx context.Context, code string) (domain.OrderStatus, error)
+	Save(ctx context.Context, orderStatus domain.OrderStatus) (int, error)
+	Update(ctx context.Context, orderStatus domain.OrderStatus) error
+	Delete(ctx context.Context, id int) error
+}
+
+const (
+	GetAllOrderStatuses   = "SELECT order_status.id, order_status.code, order_status.description FROM order_status"
+	GetOrderStatusByID    = "SELECT order_status.id, order_status.code, order_status.description FROM order_status WHERE id = ?"
+	GetOrderStatusByCode  = "SELECT order_status.id, order_status.code, order_status.description FROM order_status WHERE code = ?"
+	SaveOrderStatus       = "INSERT INTO order_status(code, description) VALUES (?,?)"
+	UpdateOrderStatus     = "UPDATE order_status SET description=? WHERE id=?"
+	DeleteOrderStatusByID = "DELETE FROM order_status WHERE id = ?"
+)
+
+type orderStatusRepository struct {
+	db *sql.DB
+}
+
+func NewOrderStatusRepository(db *sql.DB) OrderStatusRepository {
+	return &orderStatusRepository{
+		db: db,
+	}
+}
+
+func (r *orderStatusRepository) GetAll(ctx context.Context) ([]domain.OrderStatus, error) {
+	orderStatuses := make([]domain.OrderStatus, 0)
+
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, GetAllOrderStatuses)
+	if err != nil {
+		return orderStatuses, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		orderStatus := domain.OrderStatus{}
+		if err := rows.Scan(&orderStatus.ID, &orderStatus.Code, &orderStatus.Description); err != nil {
+			return orderStatuses, err
+		}
+
+		orderStatuses = append(orderStatuses, orderStatus)
+	}
+
+	return orderStatuses, rows.Err()
+}
+
+func (r *orderStatusRepository) Get(ctx context.Context, id int) (domain.OrderStatus, error) {
+	return r.get(ctx, GetOrderStatusByID, id)
+}
+
+func (r *orderStatusRepository) GetByCode(ctx context.Context, code string) (domain.OrderStatus, error) {
+	return r.get(ctx, GetOrderStatusByCode, code)
+}
+
+func (r *orderStatusRepository) get(ctx context.Context, query string, arg interface{}) (domain.OrderStatus, error) {
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, query, arg)
+	orderStatus := domain.OrderStatus{}
+	err := row.Scan(&orderStatus.ID, &orderStatus.Code, &orderStatus.Description)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.OrderStatus{}, ErrOrderStatusNotFound
+		}
+		return domain.OrderStatus{}, err
+	}
+
+	return orderStatus, nil
+}
+
+func (r *orderStatusRepository) Save(ctx context.Context, orderStatus domain.OrderStatus) (int, error) {
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, SaveOrderStatus)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := stmt.ExecContext(ctx, orderStatus.Code, orderStatus.Description)
+	if err != nil {
+		return 0, database.TranslateError(err, "order status")
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
+
+func (r *orderStatusRepository) Update(ctx context.Context, orderStatus domain.OrderStatus) error {
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, UpdateOrderStatus)
+	if err != nil {
+		return err
+	}
+
+	_, err = stmt.ExecContext(ctx, orderStatus.Description, orderStatus.ID)
+	return err
+}
+
+func (r *orderStatusRepository) Delete(ctx context.Context, id int) error {
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, DeleteOrderStatusByID)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return database.TranslateError(err, "order status")
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affect < 1 {
+		return ErrOrderStatusNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package purchaseOrder
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func Test_orderStatusRepository_GetByCode(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	tests := []struct {
+		name    string
+		code    string
+		rows    *sqlmock.Rows
+		want    domain.OrderStatus
+		wantErr error
+	}{
+		{
+			name:    "Successfully get order status",
+			code:    domain.OrderStatusShipped,
+			rows:    sqlmock.NewRows([]string{"id", "code", "description"}).AddRow(3, domain.OrderStatusShipped, "Shipped"),
+			want:    domain.OrderStatus{ID: 3, Code: domain.OrderStatusShipped, Description: "Shipped"},
+			wantErr: nil,
+		},
+		{
+			name:    "Error order status not found",
+			code:    "lost",
+			rows:    sqlmock.NewRows([]string{"id", "code", "description"}),
+			want:    domain.OrderStatus{},
+			wantErr: ErrOrderStatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewOrderStatusRepository(db)
+
+			mock.ExpectQuery(regexp.QuoteMeta(GetOrderStatusByCode)).
+				WithArgs(tt.code).
+				WillReturnRows(tt.rows)
+
+			got, err := r.GetByCode(ctx, tt.code)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_orderStatusRepository_Delete(t *testing.T) {
+	ctx := context.TODO()
+
+	tests := []struct {
+		name      string
+		execError error
+		affected  int64
+		wantErr   error
+	}{
+		{
+			name:     "Successfully delete order status",
+			affected: 1,
+			wantErr:  nil,
+		},
+		{
+			name:     "Error order status not found",
+			affected: 0,
+			wantErr:  ErrOrderStatusNotFound,
+		},
+		{
+			name:      "Error order status in use",
+			execError: &mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row: a foreign key constraint fails"},
+			wantErr:   errors.Conflict("order status", ""),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			r := NewOrderStatusRepository(db)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(DeleteOrderStatusByID))
+			exec := mock.ExpectExec(regexp.QuoteMeta(DeleteOrderStatusByID)).WithArgs(1)
+			if tt.execError != nil {
+				exec.WillReturnError(tt.execError)
+			} else {
+				exec.WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			}
+
+			err := r.Delete(ctx, 1)
+
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
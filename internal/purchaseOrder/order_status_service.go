@@ -0,0 +1,66 @@
+package purchaseOrder
+
+import (
+	"context"
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/purchase_order"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+)
+
+type OrderStatusService interface {
+	GetAll(ctx context.Context) ([]domain.OrderStatus, error)
+	Get(ctx context.Context, id int) (domain.OrderStatus, error)
+	Create(ctx context.Context, orderStatus domain.OrderStatus) (domain.OrderStatus, error)
+	Update(ctx context.Context, id int, updateOrderStatusRequest dtos.UpdateOrderStatusRequestDTO) (domain.OrderStatus, error)
+	Delete(ctx context.Context, id int) error
+}
+
+type orderStatusService struct {
+	orderStatusRepository OrderStatusRepository
+}
+
+func NewOrderStatusService(r OrderStatusRepository) OrderStatusService {
+	return &orderStatusService{
+		orderStatusRepository: r,
+	}
+}
+
+func (service *orderStatusService) GetAll(ctx context.Context) ([]domain.OrderStatus, error) {
+	return service.orderStatusRepository.GetAll(ctx)
+}
+
+func (service *orderStatusService) Get(ctx context.Context, id int) (domain.OrderStatus, error) {
+	return service.orderStatusRepository.Get(ctx, id)
+}
+
+func (service *orderStatusService) Create(ctx context.Context, orderStatus domain.OrderStatus) (domain.OrderStatus, error) {
+	id, err := service.orderStatusRepository.Save(ctx, orderStatus)
+	if err != nil {
+		return domain.OrderStatus{}, err
+	}
+
+	orderStatus.ID = id
+
+	return orderStatus, nil
+}
+
+func (service *orderStatusService) Update(ctx context.Context, id int, updateOrderStatusRequest dtos.UpdateOrderStatusRequestDTO) (domain.OrderStatus, error) {
+	existingOrderStatus, err := service.orderStatusRepository.Get(ctx, id)
+	if err != nil {
+		return domain.OrderStatus{}, err
+	}
+
+	if updateOrderStatusRequest.Description != nil {
+		existingOrderStatus.Description = *updateOrderStatusRequest.Description
+	}
+
+	if err = service.orderStatusRepository.Update(ctx, existingOrderStatus); err != nil {
+		return domain.OrderStatus{}, err
+	}
+
+	return existingOrderStatus, nil
+}
+
+// Delete fails with a conflict while purchase orders or their transitions are in the status.
+func (service *orderStatusService) Delete(ctx context.Context, id int) error {
+	return service.orderStatusRepository.Delete(ctx, id)
+}
@@ -0,0 +1,69 @@
+package purchaseOrder
+
+import (
+	"context"
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/purchase_order"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/purchaseOrder/mocks"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_orderStatusService_Create(t *testing.T) {
+	ctx := context.TODO()
+
+	orderStatus := domain.OrderStatus{Code: "returned", Description: "Returned"}
+
+	orderStatusRepositoryMock := mocks.NewMockOrderStatusRepository(t)
+	orderStatusRepositoryMock.On("Save", ctx, orderStatus).Return(6, nil)
+
+	service := NewOrderStatusService(orderStatusRepositoryMock)
+	got, err := service.Create(ctx, orderStatus)
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.OrderStatus{ID: 6, Code: "returned", Description: "Returned"}, got)
+}
+
+func Test_orderStatusService_Update(t *testing.T) {
+	ctx := context.TODO()
+
+	existing := domain.OrderStatus{ID: 3, Code: domain.OrderStatusShipped, Description: "Shipped"}
+	newDescription := "On its way"
+
+	tests := []struct {
+		name                string
+		expectedGetError    error
+		expectedUpdateCalls int
+		want                domain.OrderStatus
+		wantErr             error
+	}{
+		{
+			name:                "Successfully update the description",
+			expectedUpdateCalls: 1,
+			want:                domain.OrderStatus{ID: 3, Code: domain.OrderStatusShipped, Description: newDescription},
+			wantErr:             nil,
+		},
+		{
+			name:                "Error order status not found",
+			expectedGetError:    ErrOrderStatusNotFound,
+			expectedUpdateCalls: 0,
+			want:                domain.OrderStatus{},
+			wantErr:             ErrOrderStatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orderStatusRepositoryMock := mocks.NewMockOrderStatusRepository(t)
+			orderStatusRepositoryMock.On("Get", ctx, 3).Return(existing, tt.expectedGetError)
+			orderStatusRepositoryMock.On("Update", ctx, tt.want).Return(nil).Maybe()
+
+			service := NewOrderStatusService(orderStatusRepositoryMock)
+			got, err := service.Update(ctx, 3, dtos.UpdateOrderStatusRequestDTO{Description: &newDescription})
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+
+			orderStatusRepositoryMock.AssertNumberOfCalls(t, "Update", tt.expectedUpdateCalls)
+		})
+	}
+}
@@ -3,30 +3,57 @@ package purchaseOrder
 import (
 	"context"
 	"database/sql"
-	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"fmt"
+	buyerDtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/buyer"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
+	"sort"
 )
 
 type PurchaseOrderRepository interface {
 	GetAll(ctx context.Context) ([]domain.PurchaseOrder, error)
+	GetPage(ctx context.Context, options listing.Options) ([]domain.PurchaseOrder, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.PurchaseOrder) error) error
 	Get(ctx context.Context, id int) (domain.PurchaseOrder, error)
 	Exists(ctx context.Context, id int) bool
 	Save(ctx context.Context, purchaseOrder domain.PurchaseOrder) (int, error)
 	Update(ctx context.Context, purchaseOrder domain.PurchaseOrder) error
+	UpdateStatus(ctx context.Context, id int, fromStatusID int, toStatusID int) error
 	Delete(ctx context.Context, id int) error
 	CountByBuyerID(ctx context.Context, buyerID int) (int, error)
+	ReportByBuyers(ctx context.Context, buyerID int, filter buyerDtos.GetPurchaseOrdersReportRequestDTO) ([]buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO, error)
 }
 
 const (
-	GetAllPurchaseOrders    = "SELECT  purchase_orders.id, purchase_orders.order_number, purchase_orders.order_date, purchase_orders.tracking_code, purchase_orders.buyer_id, purchase_orders.carrier_id, purchase_orders.order_status_id, purchase_orders.warehouse_id, purchase_orders.product_record_id FROM purchase_orders"
-	GetPurchaseOrderByID    = "SELECT  purchase_orders.id, purchase_orders.order_number, purchase_orders.order_date, purchase_orders.tracking_code, purchase_orders.buyer_id, purchase_orders.carrier_id, purchase_orders.order_status_id, purchase_orders.warehouse_id, purchase_orders.product_record_id FROM purchase_orders WHERE id = ?"
-	ExistsPurchaseOrderByID = "SELECT id FROM purchase_orders WHERE id=?"
-	SavePurchaseOrder       = "INSERT INTO purchase_orders(order_number, order_date, tracking_code, buyer_id, carrier_id, order_status_id, warehouse_id, product_record_id) VALUES (?,?,?,?,?,?,?,?)"
-	UpdatePurchaseOrder     = "UPDATE purchase_orders SET order_number=?, order_date=?, tracking_code=?, buyer_id=?, carrier_id=?, order_status_id=?, warehouse_id=?, product_record_id=? WHERE id=?"
-	DeletePurchaseOrderByID = "DELETE FROM purchase_orders WHERE id = ?"
-	CountByBuyerID          = "SELECT COUNT(*) from purchase_orders where id = ?"
+	GetAllPurchaseOrders      = "SELECT  purchase_orders.id, purchase_orders.order_number, purchase_orders.order_date, purchase_orders.tracking_code, purchase_orders.buyer_id, purchase_orders.carrier_id, purchase_orders.order_status_id, purchase_orders.warehouse_id, purchase_orders.product_record_id FROM purchase_orders"
+	CountPurchaseOrders       = "SELECT COUNT(*) FROM purchase_orders"
+	GetPurchaseOrderByID      = "SELECT  purchase_orders.id, purchase_orders.order_number, purchase_orders.order_date, purchase_orders.tracking_code, purchase_orders.buyer_id, purchase_orders.carrier_id, purchase_orders.order_status_id, purchase_orders.warehouse_id, purchase_orders.product_record_id FROM purchase_orders WHERE id = ?"
+	ExistsPurchaseOrderByID   = "SELECT id FROM purchase_orders WHERE id=?"
+	SavePurchaseOrder         = "INSERT INTO purchase_orders(order_number, order_date, tracking_code, buyer_id, carrier_id, order_status_id, warehouse_id, product_record_id) VALUES (?,?,?,?,?,?,?,?)"
+	UpdatePurchaseOrder       = "UPDATE purchase_orders SET order_number=?, order_date=?, tracking_code=?, buyer_id=?, carrier_id=?, warehouse_id=?, product_record_id=? WHERE id=?"
+	UpdatePurchaseOrderStatus = "UPDATE purchase_orders SET order_status_id=? WHERE id=? AND order_status_id=?"
+	DeletePurchaseOrderByID   = "DELETE FROM purchase_orders WHERE id = ?"
+	CountByBuyerID            = "SELECT COUNT(*) FROM purchase_orders WHERE buyer_id = ?"
+	// ReportPurchaseOrdersByBuyers counts the orders of every buyer by status and carrier, the first %s takes
+	// the conditions on the orders joined and the second those on the buyers. Buyers without orders have a single
+	// row with a count of 0.
+	ReportPurchaseOrdersByBuyers = "SELECT buyers.id, buyers.card_number_id, buyers.first_name, buyers.last_name, purchase_orders.order_status_id, order_status.code, purchase_orders.carrier_id, carriers.company_name, COUNT(purchase_orders.id) FROM buyers LEFT JOIN purchase_orders ON purchase_orders.buyer_id = buyers.id%s LEFT JOIN order_status ON order_status.id = purchase_orders.order_status_id LEFT JOIN carriers ON carriers.id = purchase_orders.carrier_id WHERE buyers.deleted_at IS NULL%s GROUP BY buyers.id, buyers.card_number_id, buyers.first_name, buyers.last_name, purchase_orders.order_status_id, order_status.code, purchase_orders.carrier_id, carriers.company_name ORDER BY buyers.id, purchase_orders.order_status_id, purchase_orders.carrier_id"
 )
 
+// Fields are the purchase order fields list requests can sort and filter by.
+var Fields = listing.Fields{
+	"id":                "purchase_orders.id",
+	"order_number":      "purchase_orders.order_number",
+	"order_date":        "purchase_orders.order_date",
+	"tracking_code":     "purchase_orders.tracking_code",
+	"buyer_id":          "purchase_orders.buyer_id",
+	"carrier_id":        "purchase_orders.carrier_id",
+	"order_status_id":   "purchase_orders.order_status_id",
+	"warehouse_id":      "purchase_orders.warehouse_id",
+	"product_record_id": "purchase_orders.product_record_id",
+}
+
 type purchaseOrderRepository struct {
 	db *sql.DB
 }
@@ -38,31 +65,41 @@ func NewPurchaseOrderRepository(db *sql.DB) PurchaseOrderRepository {
 }
 
 func (r *purchaseOrderRepository) GetAll(ctx context.Context) ([]domain.PurchaseOrder, error) {
-	localities := make([]domain.PurchaseOrder, 0)
+	return r.query(ctx, GetAllPurchaseOrders)
+}
 
-	rows, err := r.db.Query(GetAllPurchaseOrders)
-	if err != nil {
-		return localities, err
+func (r *purchaseOrderRepository) GetPage(ctx context.Context, options listing.Options) ([]domain.PurchaseOrder, int, error) {
+	var total int
+	query, args := options.CountQuery(CountPurchaseOrders)
+	if err := database.Conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return make([]domain.PurchaseOrder, 0), 0, err
 	}
 
-	for rows.Next() {
-		purchaseOrder := domain.PurchaseOrder{}
-		err := rows.Scan(&purchaseOrder.ID, &purchaseOrder.OrderNumber, &purchaseOrder.OrderDate, &purchaseOrder.TrackingCode, &purchaseOrder.BuyerID, &purchaseOrder.CarrierID, &purchaseOrder.OrderStatusID, &purchaseOrder.WarehouseID, &purchaseOrder.ProductRecordID)
-		if err != nil {
-			return localities, err
-		}
-
-		localities = append(localities, purchaseOrder)
+	purchaseOrders := make([]domain.PurchaseOrder, 0)
+	err := r.Each(ctx, options, func(purchaseOrder domain.PurchaseOrder) error {
+		purchaseOrders = append(purchaseOrders, purchaseOrder)
+		return nil
+	})
+	if err != nil {
+		return purchaseOrders, 0, err
 	}
 
-	return localities, rows.Err()
+	return purchaseOrders, total, nil
+}
+
+func (r *purchaseOrderRepository) Each(ctx context.Context, options listing.Options, fn func(domain.PurchaseOrder) error) error {
+	query, args := options.Query(GetAllPurchaseOrders)
+	return r.each(ctx, fn, query, args...)
 }
 
 func (r *purchaseOrderRepository) Get(ctx context.Context, id int) (domain.PurchaseOrder, error) {
-	row := r.db.QueryRow(GetPurchaseOrderByID, id)
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, GetPurchaseOrderByID, id)
 	purchaseOrder := domain.PurchaseOrder{}
 	err := row.Scan(&purchaseOrder.ID, &purchaseOrder.OrderNumber, &purchaseOrder.OrderDate, &purchaseOrder.TrackingCode, &purchaseOrder.BuyerID, &purchaseOrder.CarrierID, &purchaseOrder.OrderStatusID, &purchaseOrder.WarehouseID, &purchaseOrder.ProductRecordID)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.PurchaseOrder{}, ErrNotFound
+		}
 		return domain.PurchaseOrder{}, err
 	}
 
@@ -70,21 +107,21 @@ func (r *purchaseOrderRepository) Get(ctx context.Context, id int) (domain.Purch
 }
 
 func (r *purchaseOrderRepository) Exists(ctx context.Context, id int) bool {
-	row := r.db.QueryRow(ExistsPurchaseOrderByID, id)
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, ExistsPurchaseOrderByID, id)
 	var foundId int
 	err := row.Scan(&foundId)
 	return err == nil
 }
 
 func (r *purchaseOrderRepository) Save(ctx context.Context, purchaseOrder domain.PurchaseOrder) (int, error) {
-	stmt, err := r.db.Prepare(SavePurchaseOrder)
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, SavePurchaseOrder)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(&purchaseOrder.OrderNumber, &purchaseOrder.OrderDate, &purchaseOrder.TrackingCode, &purchaseOrder.BuyerID, &purchaseOrder.CarrierID, &purchaseOrder.OrderStatusID, &purchaseOrder.WarehouseID, &purchaseOrder.ProductRecordID)
+	res, err := stmt.ExecContext(ctx, &purchaseOrder.OrderNumber, &purchaseOrder.OrderDate, &purchaseOrder.TrackingCode, &purchaseOrder.BuyerID, &purchaseOrder.CarrierID, &purchaseOrder.OrderStatusID, &purchaseOrder.WarehouseID, &purchaseOrder.ProductRecordID)
 	if err != nil {
-		return 0, err
+		return 0, database.TranslateError(err, "purchase order")
 	}
 
 	id, err := res.LastInsertId()
@@ -96,14 +133,14 @@ func (r *purchaseOrderRepository) Save(ctx context.Context, purchaseOrder domain
 }
 
 func (r *purchaseOrderRepository) Update(ctx context.Context, purchaseOrder domain.PurchaseOrder) error {
-	stmt, err := r.db.Prepare(UpdatePurchaseOrder)
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, UpdatePurchaseOrder)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(&purchaseOrder.OrderNumber, &purchaseOrder.OrderDate, &purchaseOrder.TrackingCode, &purchaseOrder.BuyerID, &purchaseOrder.CarrierID, &purchaseOrder.OrderStatusID, &purchaseOrder.WarehouseID, &purchaseOrder.ProductRecordID, &purchaseOrder.ID)
+	res, err := stmt.ExecContext(ctx, &purchaseOrder.OrderNumber, &purchaseOrder.OrderDate, &purchaseOrder.TrackingCode, &purchaseOrder.BuyerID, &purchaseOrder.CarrierID, &purchaseOrder.WarehouseID, &purchaseOrder.ProductRecordID, &purchaseOrder.ID)
 	if err != nil {
-		return err
+		return database.TranslateError(err, "purchase order")
 	}
 
 	_, err = res.RowsAffected()
@@ -114,13 +151,38 @@ func (r *purchaseOrderRepository) Update(ctx context.Context, purchaseOrder doma
 	return nil
 }
 
+// UpdateStatus moves the purchase order from one status to another. It fails with ErrStatusChanged
+// when the order is no longer in fromStatusID, so concurrent transitions can't both apply.
+func (r *purchaseOrderRepository) UpdateStatus(ctx context.Context, id int, fromStatusID int, toStatusID int) error {
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, UpdatePurchaseOrderStatus)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, toStatusID, id, fromStatusID)
+	if err != nil {
+		return database.TranslateError(err, "purchase order")
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affect < 1 {
+		return ErrStatusChanged
+	}
+
+	return nil
+}
+
 func (r *purchaseOrderRepository) Delete(ctx context.Context, id int) error {
-	stmt, err := r.db.Prepare(DeletePurchaseOrderByID)
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, DeletePurchaseOrderByID)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(id)
+	res, err := stmt.ExecContext(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -131,7 +193,7 @@ func (r *purchaseOrderRepository) Delete(ctx context.Context, id int) error {
 	}
 
 	if affect < 1 {
-		return errors.ErrNotFound
+		return ErrNotFound
 	}
 
 	return nil
@@ -139,8 +201,154 @@ func (r *purchaseOrderRepository) Delete(ctx context.Context, id int) error {
 
 func (r *purchaseOrderRepository) CountByBuyerID(ctx context.Context, id int) (int, error) {
 	count := 0
-	row := r.db.QueryRow(CountByBuyerID, id)
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, CountByBuyerID, id)
 	err := row.Scan(&count)
 
 	return count, err
 }
+
+// ReportByBuyers reports the purchase orders of the buyer with buyerID, or of every buyer when it is 0,
+// ordered between the dates of filter. Soft-deleted buyers are left out.
+func (r *purchaseOrderRepository) ReportByBuyers(ctx context.Context, buyerID int, filter buyerDtos.GetPurchaseOrdersReportRequestDTO) ([]buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO, error) {
+	reports := make([]buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO, 0)
+
+	var orderConditions, buyerConditions string
+	var args []interface{}
+	if filter.OrderDateFrom != "" {
+		orderConditions += " AND purchase_orders.order_date >= ?"
+		args = append(args, filter.OrderDateFrom)
+	}
+	if filter.OrderDateTo != "" {
+		orderConditions += " AND purchase_orders.order_date < DATE_ADD(?, INTERVAL 1 DAY)"
+		args = append(args, filter.OrderDateTo)
+	}
+	if buyerID != 0 {
+		buyerConditions = " AND buyers.id = ?"
+		args = append(args, buyerID)
+	}
+
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, fmt.Sprintf(ReportPurchaseOrdersByBuyers, orderConditions, buyerConditions), args...)
+	if err != nil {
+		return reports, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			report        buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO
+			orderStatusID sql.NullInt64
+			orderStatus   sql.NullString
+			carrierID     sql.NullInt64
+			companyName   sql.NullString
+			count         int
+		)
+		if err := rows.Scan(&report.BuyerID, &report.CardNumberID, &report.FirstName, &report.LastName, &orderStatusID, &orderStatus, &carrierID, &companyName, &count); err != nil {
+			return reports, err
+		}
+
+		if len(reports) == 0 || reports[len(reports)-1].BuyerID != report.BuyerID {
+			report.ByOrderStatus = make([]buyerDtos.PurchaseOrdersByOrderStatusDTO, 0)
+			report.ByCarrier = make([]buyerDtos.PurchaseOrdersByCarrierDTO, 0)
+			reports = append(reports, report)
+		}
+		if count == 0 {
+			continue
+		}
+
+		current := &reports[len(reports)-1]
+		current.PurchaseOrdersCount += count
+		addToOrderStatus(current, int(orderStatusID.Int64), orderStatus.String, count)
+		addToCarrier(current, carrierID, companyName, count)
+	}
+	if err := rows.Err(); err != nil {
+		return reports, err
+	}
+
+	for _, report := range reports {
+		sortByCarrier(report.ByCarrier)
+	}
+
+	return reports, nil
+}
+
+// addToOrderStatus adds count to the breakdown of the status, rows come sorted by status so it can only be the last one.
+func addToOrderStatus(report *buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO, orderStatusID int, orderStatus string, count int) {
+	last := len(report.ByOrderStatus) - 1
+	if last >= 0 && report.ByOrderStatus[last].OrderStatusID == orderStatusID {
+		report.ByOrderStatus[last].PurchaseOrdersCount += count
+		return
+	}
+	report.ByOrderStatus = append(report.ByOrderStatus, buyerDtos.PurchaseOrdersByOrderStatusDTO{
+		OrderStatusID:       orderStatusID,
+		OrderStatus:         orderStatus,
+		PurchaseOrdersCount: count,
+	})
+}
+
+func addToCarrier(report *buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO, carrierID sql.NullInt64, companyName sql.NullString, count int) {
+	var id *int
+	var name *string
+	if carrierID.Valid {
+		value := int(carrierID.Int64)
+		id = &value
+	}
+	if companyName.Valid {
+		name = &companyName.String
+	}
+
+	for i := range report.ByCarrier {
+		if carrierKey(report.ByCarrier[i].CarrierID) == carrierKey(id) {
+			report.ByCarrier[i].PurchaseOrdersCount += count
+			return
+		}
+	}
+	report.ByCarrier = append(report.ByCarrier, buyerDtos.PurchaseOrdersByCarrierDTO{
+		CarrierID:           id,
+		CompanyName:         name,
+		PurchaseOrdersCount: count,
+	})
+}
+
+func sortByCarrier(byCarrier []buyerDtos.PurchaseOrdersByCarrierDTO) {
+	sort.SliceStable(byCarrier, func(i, j int) bool {
+		return carrierKey(byCarrier[i].CarrierID) < carrierKey(byCarrier[j].CarrierID)
+	})
+}
+
+// carrierKey orders and compares carriers by id, with the orders without a carrier first.
+func carrierKey(carrierID *int) int {
+	if carrierID == nil {
+		return 0
+	}
+	return *carrierID
+}
+
+func (r *purchaseOrderRepository) query(ctx context.Context, query string, args ...interface{}) ([]domain.PurchaseOrder, error) {
+	purchaseOrders := make([]domain.PurchaseOrder, 0)
+	err := r.each(ctx, func(purchaseOrder domain.PurchaseOrder) error {
+		purchaseOrders = append(purchaseOrders, purchaseOrder)
+		return nil
+	}, query, args...)
+	return purchaseOrders, err
+}
+
+// each scans the rows of query one at a time, passing them to fn.
+func (r *purchaseOrderRepository) each(ctx context.Context, fn func(domain.PurchaseOrder) error, query string, args ...interface{}) error {
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		purchaseOrder := domain.PurchaseOrder{}
+		if err := rows.Scan(&purchaseOrder.ID, &purchaseOrder.OrderNumber, &purchaseOrder.OrderDate, &purchaseOrder.TrackingCode, &purchaseOrder.BuyerID, &purchaseOrder.CarrierID, &purchaseOrder.OrderStatusID, &purchaseOrder.WarehouseID, &purchaseOrder.ProductRecordID); err != nil {
+			return err
+		}
+		if err := fn(purchaseOrder); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -3,9 +3,15 @@ package purchaseOrder
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
+	"fmt"
 	"github.com/DATA-DOG/go-sqlmock"
+	buyerDtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/buyer"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 	"os"
 	"regexp"
@@ -84,6 +90,56 @@ func Test_purchaseOrderRepository_GetAll(t *testing.T) {
 	}
 }
 
+func Test_purchaseOrderRepository_GetPage(t *testing.T) {
+	validPurchaseOrdersSerialized, _ := os.ReadFile("../../test/resources/valid_purchase_orders.json")
+	var validPurchaseOrders []domain.PurchaseOrder
+	if err := json.Unmarshal(validPurchaseOrdersSerialized, &validPurchaseOrders); err != nil {
+		t.Fatal(err)
+	}
+
+	options := listing.Options{
+		Limit:   5,
+		Offset:  0,
+		Sort:    "purchase_orders.order_date",
+		Desc:    true,
+		Filters: []listing.Filter{{Column: "purchase_orders.buyer_id", Value: "1"}},
+	}
+
+	t.Run("Successfully get a page of purchaseOrders", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		r := NewPurchaseOrderRepository(db)
+
+		rows := sqlmock.NewRows([]string{"id", "order_number", "order_date", "tracking_code", "buyer_id", "carrier_id", "order_status_id", "warehouse_id", "product_record_id"})
+		for _, purchaseOrder := range validPurchaseOrders {
+			rows.AddRow(purchaseOrder.ID, purchaseOrder.OrderNumber, purchaseOrder.OrderDate, purchaseOrder.TrackingCode, purchaseOrder.BuyerID, purchaseOrder.CarrierID, purchaseOrder.OrderStatusID, purchaseOrder.WarehouseID, purchaseOrder.ProductRecordID)
+		}
+
+		mock.ExpectQuery(regexp.QuoteMeta(CountPurchaseOrders + " WHERE purchase_orders.buyer_id = ?")).
+			WithArgs("1").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+		mock.ExpectQuery(regexp.QuoteMeta(GetAllPurchaseOrders+" WHERE purchase_orders.buyer_id = ? ORDER BY purchase_orders.order_date DESC")).
+			WithArgs("1", 5, 0).
+			WillReturnRows(rows)
+
+		got, total, err := r.GetPage(context.TODO(), options)
+
+		assert.NoError(t, err)
+		assert.Equal(t, validPurchaseOrders, got)
+		assert.Equal(t, 7, total)
+	})
+
+	t.Run("Error counting purchaseOrders", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		r := NewPurchaseOrderRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(CountPurchaseOrders)).WillReturnError(sql.ErrConnDone)
+
+		_, _, err := r.GetPage(context.TODO(), options)
+
+		assert.Equal(t, true, err != nil)
+	})
+}
+
 func Test_purchaseOrderRepository_Get(t *testing.T) {
 	type fields struct {
 		db *sql.DB
@@ -287,6 +343,31 @@ func Test_purchaseOrderRepository_Save(t *testing.T) {
 	}
 }
 
+func Test_purchaseOrderRepository_TranslatesErrors(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("Missing purchaseOrder is not found", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		mock.ExpectQuery(regexp.QuoteMeta(GetPurchaseOrderByID)).WithArgs(999).WillReturnError(sql.ErrNoRows)
+
+		_, err := NewPurchaseOrderRepository(db).Get(ctx, 999)
+
+		assert.Equal(t, ErrNotFound, err)
+	})
+
+	t.Run("Unknown buyer is a foreign key error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		driverErr := &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails (`melisprint`.`purchase_orders`, CONSTRAINT `fk_purchase_orders_buyers1` FOREIGN KEY (`buyer_id`) REFERENCES `buyers` (`id`))"}
+		mock.ExpectPrepare(regexp.QuoteMeta(SavePurchaseOrder))
+		mock.ExpectExec(regexp.QuoteMeta(SavePurchaseOrder)).WillReturnError(driverErr)
+
+		_, err := NewPurchaseOrderRepository(db).Save(ctx, domain.PurchaseOrder{BuyerID: 999})
+
+		assert.ErrorIs(t, err, errors.ForeignKey("purchase order", "buyer_id"))
+		assert.ErrorIs(t, err, driverErr)
+	})
+}
+
 func Test_purchaseOrderRepository_Update(t *testing.T) {
 	type fields struct {
 		db *sql.DB
@@ -337,7 +418,6 @@ func Test_purchaseOrderRepository_Update(t *testing.T) {
 					tt.args.purchaseOrder.TrackingCode,
 					tt.args.purchaseOrder.BuyerID,
 					tt.args.purchaseOrder.CarrierID,
-					tt.args.purchaseOrder.OrderStatusID,
 					tt.args.purchaseOrder.WarehouseID,
 					tt.args.purchaseOrder.ProductRecordID,
 					tt.args.purchaseOrder.ID,
@@ -351,6 +431,42 @@ func Test_purchaseOrderRepository_Update(t *testing.T) {
 	}
 }
 
+func Test_purchaseOrderRepository_UpdateStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		rowsAffected int64
+		wantErr      error
+	}{
+		{
+			name:         "Successfully update status",
+			rowsAffected: 1,
+			wantErr:      nil,
+		},
+		{
+			name:         "Error status changed meanwhile",
+			rowsAffected: 0,
+			wantErr:      ErrStatusChanged,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			r := NewPurchaseOrderRepository(db)
+
+			mock.ExpectPrepare(regexp.QuoteMeta(UpdatePurchaseOrderStatus))
+			mock.ExpectExec(regexp.QuoteMeta(UpdatePurchaseOrderStatus)).
+				WithArgs(2, 1, 1).
+				WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+
+			err := r.UpdateStatus(context.TODO(), 1, 1, 2)
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func Test_purchaseOrderRepository_Delete(t *testing.T) {
 	type fields struct {
 		db *sql.DB
@@ -468,3 +584,106 @@ func Test_purchaseOrderRepository_CountByBuyerID(t *testing.T) {
 		})
 	}
 }
+
+func Test_purchaseOrderRepository_ReportByBuyers(t *testing.T) {
+	ctx := context.TODO()
+
+	columns := []string{"id", "card_number_id", "first_name", "last_name", "order_status_id", "code", "carrier_id", "company_name", "count"}
+	carrierOne, carrierTwo := 1, 2
+	companyOne, companyTwo := "Meli Express", "Fast Cargo"
+
+	tests := []struct {
+		name    string
+		buyerID int
+		filter  buyerDtos.GetPurchaseOrdersReportRequestDTO
+		query   string
+		args    []driver.Value
+		rows    *sqlmock.Rows
+		want    []buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO
+	}{
+		{
+			name:    "Successfully report the orders of a buyer by status and carrier",
+			buyerID: 1,
+			filter:  buyerDtos.GetPurchaseOrdersReportRequestDTO{OrderDateFrom: "2023-01-01", OrderDateTo: "2023-12-31"},
+			query:   fmt.Sprintf(ReportPurchaseOrdersByBuyers, " AND purchase_orders.order_date >= ? AND purchase_orders.order_date < DATE_ADD(?, INTERVAL 1 DAY)", " AND buyers.id = ?"),
+			args:    []driver.Value{"2023-01-01", "2023-12-31", 1},
+			rows: sqlmock.NewRows(columns).
+				AddRow(1, "402323", "Jhon", "Doe", 1, "created", nil, nil, 1).
+				AddRow(1, "402323", "Jhon", "Doe", 1, "created", 2, companyTwo, 2).
+				AddRow(1, "402323", "Jhon", "Doe", 3, "shipped", 1, companyOne, 1).
+				AddRow(1, "402323", "Jhon", "Doe", 3, "shipped", 2, companyTwo, 1),
+			want: []buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO{
+				{
+					BuyerID:             1,
+					CardNumberID:        "402323",
+					FirstName:           "Jhon",
+					LastName:            "Doe",
+					PurchaseOrdersCount: 5,
+					ByOrderStatus: []buyerDtos.PurchaseOrdersByOrderStatusDTO{
+						{OrderStatusID: 1, OrderStatus: "created", PurchaseOrdersCount: 3},
+						{OrderStatusID: 3, OrderStatus: "shipped", PurchaseOrdersCount: 2},
+					},
+					ByCarrier: []buyerDtos.PurchaseOrdersByCarrierDTO{
+						{PurchaseOrdersCount: 1},
+						{CarrierID: &carrierOne, CompanyName: &companyOne, PurchaseOrdersCount: 1},
+						{CarrierID: &carrierTwo, CompanyName: &companyTwo, PurchaseOrdersCount: 3},
+					},
+				},
+			},
+		},
+		{
+			name:  "Successfully report every buyer, with the ones without orders",
+			query: fmt.Sprintf(ReportPurchaseOrdersByBuyers, "", ""),
+			rows: sqlmock.NewRows(columns).
+				AddRow(1, "402323", "Jhon", "Doe", 1, "created", 1, companyOne, 1).
+				AddRow(2, "402324", "Jane", "Roe", nil, nil, nil, nil, 0),
+			want: []buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO{
+				{
+					BuyerID:             1,
+					CardNumberID:        "402323",
+					FirstName:           "Jhon",
+					LastName:            "Doe",
+					PurchaseOrdersCount: 1,
+					ByOrderStatus: []buyerDtos.PurchaseOrdersByOrderStatusDTO{
+						{OrderStatusID: 1, OrderStatus: "created", PurchaseOrdersCount: 1},
+					},
+					ByCarrier: []buyerDtos.PurchaseOrdersByCarrierDTO{
+						{CarrierID: &carrierOne, CompanyName: &companyOne, PurchaseOrdersCount: 1},
+					},
+				},
+				{
+					BuyerID:       2,
+					CardNumberID:  "402324",
+					FirstName:     "Jane",
+					LastName:      "Roe",
+					ByOrderStatus: []buyerDtos.PurchaseOrdersByOrderStatusDTO{},
+					ByCarrier:     []buyerDtos.PurchaseOrdersByCarrierDTO{},
+				},
+			},
+		},
+		{
+			name:    "Buyer not found",
+			buyerID: 999,
+			query:   fmt.Sprintf(ReportPurchaseOrdersByBuyers, "", " AND buyers.id = ?"),
+			args:    []driver.Value{999},
+			rows:    sqlmock.NewRows(columns),
+			want:    []buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			r := NewPurchaseOrderRepository(db)
+
+			mock.ExpectQuery(tt.query).
+				WithArgs(tt.args...).
+				WillReturnRows(tt.rows)
+
+			got, err := r.ReportByBuyers(ctx, tt.buyerID, tt.filter)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
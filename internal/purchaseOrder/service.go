@@ -2,35 +2,59 @@ package purchaseOrder
 
 import (
 	"context"
+	buyerDtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/buyer"
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/purchase_order"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/buyer"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
+)
+
+// Errors
+var (
+	ErrNotFound            = errors.NotFound("purchase order")
+	ErrConflict            = errors.Conflict("purchase order", "id")
+	ErrOrderDetailNotFound = errors.NotFound("order detail")
+	ErrOrderStatusNotFound = errors.NotFound("order status")
+	ErrStatusChanged       = &errors.Error{Kind: errors.KindConflict, Entity: "purchase order", Field: "order_status_id", Message: "purchase order status changed, try again"}
+	ErrStatusUpdate        = errors.Validation("purchase order", "order_status_id", "order_status_id can only be changed by a transition")
+	ErrReportDateRange     = errors.Validation("purchase order report", "order_date_to", "order_date_to must not be before order_date_from")
 )
 
 type PurchaseOrderService interface {
-	Get(ctx *context.Context, id int) (domain.PurchaseOrder, error)
-	GetAll(ctx *context.Context) ([]domain.PurchaseOrder, error)
-	Create(ctx *context.Context, purchaseOrder domain.PurchaseOrder) (domain.PurchaseOrder, error)
-	Update(ctx *context.Context, id int, updatePurchaseOrderRequest dtos.UpdatePurchaseOrderRequestDTO) (domain.PurchaseOrder, error)
-	Delete(ctx *context.Context, id int) error
-	CountByBuyerID(ctx *context.Context, buyerID int) (int, error)
+	Get(ctx context.Context, id int) (domain.PurchaseOrder, error)
+	GetAll(ctx context.Context) ([]domain.PurchaseOrder, error)
+	GetPage(ctx context.Context, options listing.Options) ([]domain.PurchaseOrder, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.PurchaseOrder) error) error
+	Create(ctx context.Context, purchaseOrder domain.PurchaseOrder) (domain.PurchaseOrder, error)
+	Update(ctx context.Context, id int, updatePurchaseOrderRequest dtos.UpdatePurchaseOrderRequestDTO) (domain.PurchaseOrder, error)
+	Delete(ctx context.Context, id int) error
+	CountByBuyerID(ctx context.Context, buyerID int) (int, error)
+	ReportByBuyer(ctx context.Context, buyerID int, filter buyerDtos.GetPurchaseOrdersReportRequestDTO) (buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO, error)
+	ReportAllBuyers(ctx context.Context, filter buyerDtos.GetPurchaseOrdersReportRequestDTO) ([]buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO, error)
 }
 
 type purchaseOrderService struct {
 	purchaseOrderRepository PurchaseOrderRepository
+	orderDetailRepository   OrderDetailRepository
 	buyerRepository         buyer.BuyerRepository
+	orderStatusRepository   OrderStatusRepository
+	unitOfWork              database.UnitOfWork
 }
 
-func NewPurchaseOrderService(r PurchaseOrderRepository, buyerRepository buyer.BuyerRepository) PurchaseOrderService {
+func NewPurchaseOrderService(r PurchaseOrderRepository, orderDetailRepository OrderDetailRepository, buyerRepository buyer.BuyerRepository, orderStatusRepository OrderStatusRepository, unitOfWork database.UnitOfWork) PurchaseOrderService {
 	return &purchaseOrderService{
 		purchaseOrderRepository: r,
+		orderDetailRepository:   orderDetailRepository,
 		buyerRepository:         buyerRepository,
+		orderStatusRepository:   orderStatusRepository,
+		unitOfWork:              unitOfWork,
 	}
 }
 
-func (service *purchaseOrderService) Get(ctx *context.Context, id int) (domain.PurchaseOrder, error) {
-	purchaseOrder, err := service.purchaseOrderRepository.Get(*ctx, id)
+func (service *purchaseOrderService) Get(ctx context.Context, id int) (domain.PurchaseOrder, error) {
+	purchaseOrder, err := service.purchaseOrderRepository.Get(ctx, id)
 	if err != nil {
 		return domain.PurchaseOrder{}, err
 	}
@@ -38,10 +62,10 @@ func (service *purchaseOrderService) Get(ctx *context.Context, id int) (domain.P
 	return purchaseOrder, nil
 }
 
-func (service *purchaseOrderService) GetAll(ctx *context.Context) ([]domain.PurchaseOrder, error) {
+func (service *purchaseOrderService) GetAll(ctx context.Context) ([]domain.PurchaseOrder, error) {
 	localities := make([]domain.PurchaseOrder, 0)
 
-	localities, err := service.purchaseOrderRepository.GetAll(*ctx)
+	localities, err := service.purchaseOrderRepository.GetAll(ctx)
 	if err != nil {
 		return localities, err
 	}
@@ -49,31 +73,75 @@ func (service *purchaseOrderService) GetAll(ctx *context.Context) ([]domain.Purc
 	return localities, nil
 }
 
-func (service *purchaseOrderService) Create(ctx *context.Context, purchaseOrder domain.PurchaseOrder) (domain.PurchaseOrder, error) {
-	existingPurchaseOrder := service.purchaseOrderRepository.Exists(*ctx, purchaseOrder.ID)
-	if existingPurchaseOrder {
-		return domain.PurchaseOrder{}, errors.ErrConflict
+func (service *purchaseOrderService) GetPage(ctx context.Context, options listing.Options) ([]domain.PurchaseOrder, int, error) {
+	purchaseOrders, total, err := service.purchaseOrderRepository.GetPage(ctx, options)
+	if err != nil {
+		return purchaseOrders, 0, err
 	}
 
-	id, err := service.purchaseOrderRepository.Save(*ctx, purchaseOrder)
+	return purchaseOrders, total, nil
+}
+
+func (service *purchaseOrderService) Each(ctx context.Context, options listing.Options, fn func(domain.PurchaseOrder) error) error {
+	return service.purchaseOrderRepository.Each(ctx, options, fn)
+}
+
+// Create saves the purchase order and its details in a single unit of work,
+// so an order is never persisted without its line items. Every order starts in the created status.
+func (service *purchaseOrderService) Create(ctx context.Context, purchaseOrder domain.PurchaseOrder) (domain.PurchaseOrder, error) {
+	err := service.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		existingPurchaseOrder := service.purchaseOrderRepository.Exists(ctx, purchaseOrder.ID)
+		if existingPurchaseOrder {
+			return ErrConflict
+		}
+
+		createdStatus, err := service.orderStatusRepository.GetByCode(ctx, domain.OrderStatusCreated)
+		if err != nil {
+			return err
+		}
+		purchaseOrder.OrderStatusID = createdStatus.ID
+
+		id, err := service.purchaseOrderRepository.Save(ctx, purchaseOrder)
+		if err != nil {
+			return err
+		}
+
+		purchaseOrder.ID = id
+
+		if len(purchaseOrder.Details) == 0 {
+			return nil
+		}
+
+		details := make([]domain.OrderDetail, 0, len(purchaseOrder.Details))
+		for _, detail := range purchaseOrder.Details {
+			detail.PurchaseOrderID = purchaseOrder.ID
+
+			detail.ID, err = service.orderDetailRepository.Save(ctx, detail)
+			if err != nil {
+				return err
+			}
+
+			details = append(details, detail)
+		}
+		purchaseOrder.Details = details
+
+		return nil
+	})
 	if err != nil {
 		return domain.PurchaseOrder{}, err
 	}
 
-	purchaseOrder.ID = id
-
 	return purchaseOrder, nil
 }
 
-func (service *purchaseOrderService) Update(ctx *context.Context, id int, updatePurchaseOrderRequest dtos.UpdatePurchaseOrderRequestDTO) (domain.PurchaseOrder, error) {
-	existingPurchaseOrder, err := service.purchaseOrderRepository.Get(*ctx, id)
+func (service *purchaseOrderService) Update(ctx context.Context, id int, updatePurchaseOrderRequest dtos.UpdatePurchaseOrderRequestDTO) (domain.PurchaseOrder, error) {
+	existingPurchaseOrder, err := service.purchaseOrderRepository.Get(ctx, id)
 	if err != nil {
 		return domain.PurchaseOrder{}, err
 	}
 
-	existingPurchaseOrderSearch := service.purchaseOrderRepository.Exists(*ctx, id)
-	if existingPurchaseOrderSearch {
-		return domain.PurchaseOrder{}, errors.ErrConflict
+	if updatePurchaseOrderRequest.OrderStatusID != nil && *updatePurchaseOrderRequest.OrderStatusID != existingPurchaseOrder.OrderStatusID {
+		return domain.PurchaseOrder{}, ErrStatusUpdate
 	}
 
 	if updatePurchaseOrderRequest.OrderNumber != nil {
@@ -96,10 +164,6 @@ func (service *purchaseOrderService) Update(ctx *context.Context, id int, update
 		existingPurchaseOrder.CarrierID = *updatePurchaseOrderRequest.CarrierID
 	}
 
-	if updatePurchaseOrderRequest.OrderStatusID != nil {
-		existingPurchaseOrder.OrderStatusID = *updatePurchaseOrderRequest.OrderStatusID
-	}
-
 	if updatePurchaseOrderRequest.WarehouseID != nil {
 		existingPurchaseOrder.WarehouseID = *updatePurchaseOrderRequest.WarehouseID
 	}
@@ -108,20 +172,20 @@ func (service *purchaseOrderService) Update(ctx *context.Context, id int, update
 		existingPurchaseOrder.ProductRecordID = *updatePurchaseOrderRequest.ProductRecordID
 	}
 
-	if err = service.purchaseOrderRepository.Update(*ctx, existingPurchaseOrder); err != nil {
+	if err = service.purchaseOrderRepository.Update(ctx, existingPurchaseOrder); err != nil {
 		return domain.PurchaseOrder{}, err
 	}
 
 	return existingPurchaseOrder, nil
 }
 
-func (service *purchaseOrderService) Delete(ctx *context.Context, id int) error {
-	_, err := service.purchaseOrderRepository.Get(*ctx, id)
+func (service *purchaseOrderService) Delete(ctx context.Context, id int) error {
+	_, err := service.purchaseOrderRepository.Get(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	err = service.purchaseOrderRepository.Delete(*ctx, id)
+	err = service.purchaseOrderRepository.Delete(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -129,13 +193,13 @@ func (service *purchaseOrderService) Delete(ctx *context.Context, id int) error
 	return nil
 }
 
-func (service *purchaseOrderService) CountByBuyerID(ctx *context.Context, buyerID int) (int, error) {
-	_, err := service.buyerRepository.Get(*ctx, buyerID)
+func (service *purchaseOrderService) CountByBuyerID(ctx context.Context, buyerID int) (int, error) {
+	_, err := service.buyerRepository.Get(ctx, buyerID)
 	if err != nil {
 		return 0, err
 	}
 
-	count, err := service.purchaseOrderRepository.CountByBuyerID(*ctx, buyerID)
+	count, err := service.purchaseOrderRepository.CountByBuyerID(ctx, buyerID)
 	if err != nil {
 		return 0, err
 	}
@@ -143,3 +207,36 @@ func (service *purchaseOrderService) CountByBuyerID(ctx *context.Context, buyerI
 	return count, nil
 
 }
+
+// ReportByBuyer reports the purchase orders of a buyer, the report query finds no row when the buyer does not exist.
+func (service *purchaseOrderService) ReportByBuyer(ctx context.Context, buyerID int, filter buyerDtos.GetPurchaseOrdersReportRequestDTO) (buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO, error) {
+	if err := checkReportDates(filter); err != nil {
+		return buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO{}, err
+	}
+
+	reports, err := service.purchaseOrderRepository.ReportByBuyers(ctx, buyerID, filter)
+	if err != nil {
+		return buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO{}, err
+	}
+	if len(reports) == 0 {
+		return buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO{}, buyer.ErrNotFound
+	}
+
+	return reports[0], nil
+}
+
+func (service *purchaseOrderService) ReportAllBuyers(ctx context.Context, filter buyerDtos.GetPurchaseOrdersReportRequestDTO) ([]buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO, error) {
+	if err := checkReportDates(filter); err != nil {
+		return make([]buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO, 0), err
+	}
+
+	return service.purchaseOrderRepository.ReportByBuyers(ctx, 0, filter)
+}
+
+// checkReportDates rejects a date range ending before it starts, dates in the format 2006-01-02 compare as text.
+func checkReportDates(filter buyerDtos.GetPurchaseOrdersReportRequestDTO) error {
+	if filter.OrderDateFrom != "" && filter.OrderDateTo != "" && filter.OrderDateTo < filter.OrderDateFrom {
+		return ErrReportDateRange
+	}
+	return nil
+}
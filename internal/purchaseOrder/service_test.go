@@ -3,11 +3,15 @@ package purchaseOrder
 import (
 	"context"
 	"encoding/json"
+	buyerDtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/buyer"
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/purchase_order"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/buyer"
 	buyer_mock "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/buyer/mocks"
+	database_mocks "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database/mocks"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/purchaseOrder/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"os"
@@ -16,7 +20,7 @@ import (
 
 func Test_purchaseOrderService_GetAll(t *testing.T) {
 	type args struct {
-		ctx                  *context.Context
+		ctx                  context.Context
 		expectedGetAllResult []domain.PurchaseOrder
 		expectedGetAllError  error
 		expectedGetAllCalls  int
@@ -39,7 +43,7 @@ func Test_purchaseOrderService_GetAll(t *testing.T) {
 		{
 			name: "Successfully get all",
 			args: args{
-				ctx:                  &ctx,
+				ctx:                  ctx,
 				expectedGetAllResult: expectedPurchaseOrders,
 				expectedGetAllError:  nil,
 				expectedGetAllCalls:  1,
@@ -50,7 +54,7 @@ func Test_purchaseOrderService_GetAll(t *testing.T) {
 		{
 			name: "Error getting all",
 			args: args{
-				ctx:                  &ctx,
+				ctx:                  ctx,
 				expectedGetAllResult: []domain.PurchaseOrder{},
 				expectedGetAllError:  assert.AnError,
 				expectedGetAllCalls:  1,
@@ -62,11 +66,11 @@ func Test_purchaseOrderService_GetAll(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			sellerRepositoryMock := mocks.NewMockPurchaseOrderRepository(t)
-			sellerRepositoryMock.On("GetAll", *tt.args.ctx).Return(tt.args.expectedGetAllResult, tt.args.expectedGetAllError)
+			sellerRepositoryMock.On("GetAll", tt.args.ctx).Return(tt.args.expectedGetAllResult, tt.args.expectedGetAllError)
 
 			buyerRepositoryMock := buyer_mock.NewBuyerRepositoryMock()
 
-			service := NewPurchaseOrderService(sellerRepositoryMock, buyerRepositoryMock)
+			service := NewPurchaseOrderService(sellerRepositoryMock, mocks.NewMockOrderDetailRepository(t), buyerRepositoryMock, mocks.NewMockOrderStatusRepository(t), database_mocks.NewUnitOfWorkMock())
 			got, err := service.GetAll(tt.args.ctx)
 
 			assert.Equal(t, tt.want, got)
@@ -77,9 +81,61 @@ func Test_purchaseOrderService_GetAll(t *testing.T) {
 	}
 }
 
+func Test_purchaseOrderService_GetPage(t *testing.T) {
+	ctx := context.TODO()
+
+	var expectedPurchaseOrders []domain.PurchaseOrder
+	expectedPurchaseOrdersSerialized, _ := os.ReadFile("../../test/resources/valid_purchase_orders.json")
+	if err := json.Unmarshal(expectedPurchaseOrdersSerialized, &expectedPurchaseOrders); err != nil {
+		t.Fatal(err)
+	}
+
+	options := listing.Options{Limit: listing.DefaultLimit}
+
+	tests := []struct {
+		name      string
+		result    []domain.PurchaseOrder
+		total     int
+		err       error
+		want      []domain.PurchaseOrder
+		wantTotal int
+		wantErr   error
+	}{
+		{
+			name:      "Successfully get a page",
+			result:    expectedPurchaseOrders,
+			total:     len(expectedPurchaseOrders) + 10,
+			want:      expectedPurchaseOrders,
+			wantTotal: len(expectedPurchaseOrders) + 10,
+		},
+		{
+			name:    "Error getting a page",
+			result:  []domain.PurchaseOrder{},
+			err:     assert.AnError,
+			want:    []domain.PurchaseOrder{},
+			wantErr: assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			purchaseOrderRepositoryMock := mocks.NewMockPurchaseOrderRepository(t)
+			purchaseOrderRepositoryMock.On("GetPage", ctx, options).Return(tt.result, tt.total, tt.err)
+
+			buyerRepositoryMock := buyer_mock.NewBuyerRepositoryMock()
+
+			service := NewPurchaseOrderService(purchaseOrderRepositoryMock, mocks.NewMockOrderDetailRepository(t), buyerRepositoryMock, mocks.NewMockOrderStatusRepository(t), database_mocks.NewUnitOfWorkMock())
+			got, total, err := service.GetPage(ctx, options)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantTotal, total)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
 func Test_purchaseOrder_Get(t *testing.T) {
 	type args struct {
-		ctx               *context.Context
+		ctx               context.Context
 		id                int
 		expectedGetResult domain.PurchaseOrder
 		expectedGetError  error
@@ -103,7 +159,7 @@ func Test_purchaseOrder_Get(t *testing.T) {
 		{
 			name: "Successfully get purchaseOrder from db",
 			args: args{
-				ctx:               &ctx,
+				ctx:               ctx,
 				id:                expectedPurchaseOrder.ID,
 				expectedGetResult: expectedPurchaseOrder,
 				expectedGetError:  nil,
@@ -115,19 +171,19 @@ func Test_purchaseOrder_Get(t *testing.T) {
 		{
 			name: "PurchaseOrder not found in db",
 			args: args{
-				ctx:               &ctx,
+				ctx:               ctx,
 				id:                expectedPurchaseOrder.ID,
 				expectedGetResult: domain.PurchaseOrder{},
-				expectedGetError:  errors.ErrNotFound,
+				expectedGetError:  ErrNotFound,
 				expectedGetCalls:  1,
 			},
 			want:    domain.PurchaseOrder{},
-			wantErr: errors.ErrNotFound,
+			wantErr: ErrNotFound,
 		},
 		{
 			name: "Error connecting db",
 			args: args{
-				ctx:               &ctx,
+				ctx:               ctx,
 				id:                expectedPurchaseOrder.ID,
 				expectedGetResult: domain.PurchaseOrder{},
 				expectedGetError:  assert.AnError,
@@ -140,10 +196,10 @@ func Test_purchaseOrder_Get(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			purchaseOrderRepositoryMock := mocks.NewMockPurchaseOrderRepository(t)
-			purchaseOrderRepositoryMock.On("Get", *tt.args.ctx, mock.AnythingOfType("int")).Return(tt.args.expectedGetResult, tt.args.expectedGetError)
+			purchaseOrderRepositoryMock.On("Get", tt.args.ctx, mock.AnythingOfType("int")).Return(tt.args.expectedGetResult, tt.args.expectedGetError)
 
 			buyerRepositoryMock := buyer_mock.NewBuyerRepositoryMock()
-			service := NewPurchaseOrderService(purchaseOrderRepositoryMock, buyerRepositoryMock)
+			service := NewPurchaseOrderService(purchaseOrderRepositoryMock, mocks.NewMockOrderDetailRepository(t), buyerRepositoryMock, mocks.NewMockOrderStatusRepository(t), database_mocks.NewUnitOfWorkMock())
 			got, err := service.Get(tt.args.ctx, tt.args.id)
 
 			assert.Equal(t, tt.want, got)
@@ -157,10 +213,11 @@ func Test_purchaseOrder_Get(t *testing.T) {
 
 func Test_purchaseOrder_Create(t *testing.T) {
 	type args struct {
-		ctx                  *context.Context
+		ctx                  context.Context
 		purchaseOrder        domain.PurchaseOrder
 		expectedExistsResult bool
 		expectedExistsCalls  int
+		expectedStatusError  error
 		expectedSaveResult   int
 		expectedSaveError    error
 		expectedSaveCalls    int
@@ -173,6 +230,10 @@ func Test_purchaseOrder_Create(t *testing.T) {
 	if err := json.Unmarshal(expectedPurchaseOrderSerialized, &expectedPurchaseOrder); err != nil {
 		t.Fatal(err)
 	}
+	createdStatus := domain.OrderStatus{ID: expectedPurchaseOrder.OrderStatusID, Code: domain.OrderStatusCreated}
+
+	newPurchaseOrder := expectedPurchaseOrder
+	newPurchaseOrder.OrderStatusID = 0
 
 	tests := []struct {
 		name    string
@@ -183,8 +244,8 @@ func Test_purchaseOrder_Create(t *testing.T) {
 		{
 			name: "Successfully create purchaseOrder",
 			args: args{
-				ctx:                  &ctx,
-				purchaseOrder:        expectedPurchaseOrder,
+				ctx:                  ctx,
+				purchaseOrder:        newPurchaseOrder,
 				expectedExistsResult: false,
 				expectedExistsCalls:  1,
 				expectedSaveResult:   expectedPurchaseOrder.ID,
@@ -197,7 +258,7 @@ func Test_purchaseOrder_Create(t *testing.T) {
 		{
 			name: "Error duplicated purchaseOrder",
 			args: args{
-				ctx:                  &ctx,
+				ctx:                  ctx,
 				purchaseOrder:        expectedPurchaseOrder,
 				expectedExistsResult: true,
 				expectedExistsCalls:  1,
@@ -206,12 +267,12 @@ func Test_purchaseOrder_Create(t *testing.T) {
 				expectedSaveCalls:    0,
 			},
 			want:    domain.PurchaseOrder{},
-			wantErr: errors.ErrConflict,
+			wantErr: ErrConflict,
 		},
 		{
 			name: "Error saving purchaseOrder",
 			args: args{
-				ctx:                  &ctx,
+				ctx:                  ctx,
 				purchaseOrder:        expectedPurchaseOrder,
 				expectedExistsResult: false,
 				expectedExistsCalls:  1,
@@ -221,6 +282,19 @@ func Test_purchaseOrder_Create(t *testing.T) {
 			want:    domain.PurchaseOrder{},
 			wantErr: assert.AnError,
 		},
+		{
+			name: "Error finding the created status",
+			args: args{
+				ctx:                  ctx,
+				purchaseOrder:        newPurchaseOrder,
+				expectedExistsResult: false,
+				expectedExistsCalls:  1,
+				expectedStatusError:  ErrOrderStatusNotFound,
+				expectedSaveCalls:    0,
+			},
+			want:    domain.PurchaseOrder{},
+			wantErr: ErrOrderStatusNotFound,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -228,7 +302,7 @@ func Test_purchaseOrder_Create(t *testing.T) {
 
 			purchaseOrderRepositoryMock.On(
 				"Exists",
-				*tt.args.ctx,
+				tt.args.ctx,
 				mock.AnythingOfType("int"),
 			).Return(
 				tt.args.expectedExistsResult,
@@ -236,7 +310,7 @@ func Test_purchaseOrder_Create(t *testing.T) {
 
 			purchaseOrderRepositoryMock.On(
 				"Save",
-				*tt.args.ctx,
+				tt.args.ctx,
 				mock.AnythingOfType("domain.PurchaseOrder"),
 			).Return(
 				tt.args.expectedSaveResult,
@@ -244,7 +318,10 @@ func Test_purchaseOrder_Create(t *testing.T) {
 			)
 
 			buyerRepositoryMock := buyer_mock.NewBuyerRepositoryMock()
-			service := NewPurchaseOrderService(purchaseOrderRepositoryMock, buyerRepositoryMock)
+			orderStatusRepositoryMock := mocks.NewMockOrderStatusRepository(t)
+			orderStatusRepositoryMock.On("GetByCode", tt.args.ctx, domain.OrderStatusCreated).Return(createdStatus, tt.args.expectedStatusError).Maybe()
+
+			service := NewPurchaseOrderService(purchaseOrderRepositoryMock, mocks.NewMockOrderDetailRepository(t), buyerRepositoryMock, orderStatusRepositoryMock, database_mocks.NewUnitOfWorkMock())
 			got, err := service.Create(tt.args.ctx, tt.args.purchaseOrder)
 
 			assert.Equal(t, tt.want, got)
@@ -256,16 +333,101 @@ func Test_purchaseOrder_Create(t *testing.T) {
 	}
 }
 
+func Test_purchaseOrder_CreateWithDetails(t *testing.T) {
+	ctx := context.TODO()
+
+	var purchaseOrder domain.PurchaseOrder
+	purchaseOrderSerialized, _ := os.ReadFile("../../test/resources/valid_purchase_order.json")
+	if err := json.Unmarshal(purchaseOrderSerialized, &purchaseOrder); err != nil {
+		t.Fatal(err)
+	}
+	orderDetailsSerialized, _ := os.ReadFile("../../test/resources/valid_order_details.json")
+	if err := json.Unmarshal(orderDetailsSerialized, &purchaseOrder.Details); err != nil {
+		t.Fatal(err)
+	}
+	purchaseOrder.ID = purchaseOrder.Details[0].PurchaseOrderID
+
+	tests := []struct {
+		name                string
+		beginError          error
+		expectedSaveError   error
+		expectedSaveCalls   int
+		expectedDetailError error
+		expectedDetailCalls int
+		want                domain.PurchaseOrder
+		wantErr             error
+	}{
+		{
+			name:                "Successfully create purchaseOrder with details",
+			expectedSaveCalls:   1,
+			expectedDetailCalls: len(purchaseOrder.Details),
+			want:                purchaseOrder,
+			wantErr:             nil,
+		},
+		{
+			name:                "Error saving purchaseOrder with details",
+			expectedSaveError:   assert.AnError,
+			expectedSaveCalls:   1,
+			expectedDetailCalls: 0,
+			want:                domain.PurchaseOrder{},
+			wantErr:             assert.AnError,
+		},
+		{
+			name:                "Error saving order detail",
+			expectedSaveCalls:   1,
+			expectedDetailError: assert.AnError,
+			expectedDetailCalls: 1,
+			want:                domain.PurchaseOrder{},
+			wantErr:             assert.AnError,
+		},
+		{
+			name:                "Error starting the transaction",
+			beginError:          assert.AnError,
+			expectedSaveCalls:   0,
+			expectedDetailCalls: 0,
+			want:                domain.PurchaseOrder{},
+			wantErr:             assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			purchaseOrderRepositoryMock := mocks.NewMockPurchaseOrderRepository(t)
+			purchaseOrderRepositoryMock.On("Exists", ctx, mock.AnythingOfType("int")).Return(false).Maybe()
+			purchaseOrderRepositoryMock.On("Save", ctx, purchaseOrder).Return(purchaseOrder.ID, tt.expectedSaveError).Maybe()
+
+			orderDetailRepositoryMock := mocks.NewMockOrderDetailRepository(t)
+			orderDetailRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.OrderDetail")).Return(
+				func(_ context.Context, orderDetail domain.OrderDetail) int { return orderDetail.ID },
+				tt.expectedDetailError,
+			).Maybe()
+
+			unitOfWork := database_mocks.NewUnitOfWorkMock()
+			unitOfWork.BeginErr = tt.beginError
+
+			orderStatusRepositoryMock := mocks.NewMockOrderStatusRepository(t)
+			orderStatusRepositoryMock.On("GetByCode", ctx, domain.OrderStatusCreated).Return(domain.OrderStatus{ID: purchaseOrder.OrderStatusID, Code: domain.OrderStatusCreated}, nil).Maybe()
+
+			service := NewPurchaseOrderService(purchaseOrderRepositoryMock, orderDetailRepositoryMock, buyer_mock.NewBuyerRepositoryMock(), orderStatusRepositoryMock, unitOfWork)
+			got, err := service.Create(ctx, purchaseOrder)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+
+			assert.Equal(t, 1, unitOfWork.Calls)
+			purchaseOrderRepositoryMock.AssertNumberOfCalls(t, "Save", tt.expectedSaveCalls)
+			orderDetailRepositoryMock.AssertNumberOfCalls(t, "Save", tt.expectedDetailCalls)
+		})
+	}
+}
+
 func Test_purchaseOrder_Update(t *testing.T) {
 	type args struct {
-		ctx                        *context.Context
+		ctx                        context.Context
 		id                         int
 		updatePurchaseOrderRequest dtos.UpdatePurchaseOrderRequestDTO
 		expectedGetResult          domain.PurchaseOrder
 		expectedGetError           error
 		expectedGetCalls           int
-		expectedExistsResult       bool
-		expectedExistsCalls        int
 		expectedUpdateError        error
 		expectedUpdateCalls        int
 	}
@@ -284,8 +446,8 @@ func Test_purchaseOrder_Update(t *testing.T) {
 	newTrackingCode := "abcdf124"
 	newBuyerID := 2
 	newCarrierID := 2
-	newOrderStatusID := 2
 	newWarehouseID := 2
+	newOrderStatusID := originalPurchaseOrder.OrderStatusID + 1
 	newProductRecordID := 2
 
 	updatePurchaseOrderRequest := dtos.UpdatePurchaseOrderRequestDTO{
@@ -294,7 +456,6 @@ func Test_purchaseOrder_Update(t *testing.T) {
 		TrackingCode:    &newTrackingCode,
 		BuyerID:         &newBuyerID,
 		CarrierID:       &newCarrierID,
-		OrderStatusID:   &newOrderStatusID,
 		WarehouseID:     &newWarehouseID,
 		ProductRecordID: &newProductRecordID,
 	}
@@ -306,7 +467,7 @@ func Test_purchaseOrder_Update(t *testing.T) {
 		TrackingCode:    newTrackingCode,
 		BuyerID:         newBuyerID,
 		CarrierID:       newCarrierID,
-		OrderStatusID:   newOrderStatusID,
+		OrderStatusID:   originalPurchaseOrder.OrderStatusID,
 		WarehouseID:     newWarehouseID,
 		ProductRecordID: newProductRecordID,
 	}
@@ -320,59 +481,80 @@ func Test_purchaseOrder_Update(t *testing.T) {
 		{
 			name: "Successfully updating all fields",
 			args: args{
-				ctx:                        &ctx,
+				ctx:                        ctx,
 				id:                         originalPurchaseOrder.ID,
 				updatePurchaseOrderRequest: updatePurchaseOrderRequest,
 				expectedGetResult:          originalPurchaseOrder,
 				expectedGetError:           nil,
 				expectedGetCalls:           1,
-				expectedExistsResult:       false,
-				expectedExistsCalls:        1,
 				expectedUpdateError:        nil,
 				expectedUpdateCalls:        1,
 			},
 			want:    updatedPurchaseOrder,
 			wantErr: nil,
 		},
+		{
+			name: "Successfully updating the same order status",
+			args: args{
+				ctx:                        ctx,
+				id:                         originalPurchaseOrder.ID,
+				updatePurchaseOrderRequest: dtos.UpdatePurchaseOrderRequestDTO{OrderStatusID: &originalPurchaseOrder.OrderStatusID},
+				expectedGetResult:          originalPurchaseOrder,
+				expectedGetCalls:           1,
+				expectedUpdateCalls:        1,
+			},
+			want:    originalPurchaseOrder,
+			wantErr: nil,
+		},
+		{
+			name: "Error changing the order status",
+			args: args{
+				ctx:                        ctx,
+				id:                         originalPurchaseOrder.ID,
+				updatePurchaseOrderRequest: dtos.UpdatePurchaseOrderRequestDTO{OrderStatusID: &newOrderStatusID},
+				expectedGetResult:          originalPurchaseOrder,
+				expectedGetCalls:           1,
+			},
+			want:    domain.PurchaseOrder{},
+			wantErr: ErrStatusUpdate,
+		},
 		{
 			name: "Error purchaseOrder doesn't exists",
 			args: args{
-				ctx:                        &ctx,
+				ctx:                        ctx,
 				id:                         originalPurchaseOrder.ID,
 				updatePurchaseOrderRequest: updatePurchaseOrderRequest,
 				expectedGetResult:          domain.PurchaseOrder{},
-				expectedGetError:           errors.ErrNotFound,
+				expectedGetError:           ErrNotFound,
 				expectedGetCalls:           1,
 			},
 			want:    domain.PurchaseOrder{},
-			wantErr: errors.ErrNotFound,
+			wantErr: ErrNotFound,
 		},
 		{
-			name: "Error duplicated purchaseOrder_id",
+			name: "Error duplicated order_number",
 			args: args{
-				ctx:                        &ctx,
+				ctx:                        ctx,
 				id:                         originalPurchaseOrder.ID,
 				updatePurchaseOrderRequest: updatePurchaseOrderRequest,
 				expectedGetResult:          originalPurchaseOrder,
 				expectedGetError:           nil,
 				expectedGetCalls:           1,
-				expectedExistsResult:       true,
-				expectedExistsCalls:        1,
+				expectedUpdateError:        errors.Conflict("purchase order", "order_number"),
+				expectedUpdateCalls:        1,
 			},
 			want:    domain.PurchaseOrder{},
-			wantErr: errors.ErrConflict,
+			wantErr: errors.Conflict("purchase order", "order_number"),
 		},
 		{
 			name: "Error updating purchaseOrder",
 			args: args{
-				ctx:                        &ctx,
+				ctx:                        ctx,
 				id:                         originalPurchaseOrder.ID,
 				updatePurchaseOrderRequest: updatePurchaseOrderRequest,
 				expectedGetResult:          originalPurchaseOrder,
 				expectedGetError:           nil,
 				expectedGetCalls:           1,
-				expectedExistsResult:       false,
-				expectedExistsCalls:        1,
 				expectedUpdateError:        assert.AnError,
 				expectedUpdateCalls:        1,
 			},
@@ -385,11 +567,10 @@ func Test_purchaseOrder_Update(t *testing.T) {
 			purchaseOrderRepositoryMock := mocks.NewMockPurchaseOrderRepository(t)
 			buyerRepositoryMock := buyer_mock.NewBuyerRepositoryMock()
 
-			service := NewPurchaseOrderService(purchaseOrderRepositoryMock, buyerRepositoryMock)
+			service := NewPurchaseOrderService(purchaseOrderRepositoryMock, mocks.NewMockOrderDetailRepository(t), buyerRepositoryMock, mocks.NewMockOrderStatusRepository(t), database_mocks.NewUnitOfWorkMock())
 
-			purchaseOrderRepositoryMock.On("Get", *tt.args.ctx, mock.AnythingOfType("int")).Return(tt.args.expectedGetResult, tt.args.expectedGetError)
-			purchaseOrderRepositoryMock.On("Exists", *tt.args.ctx, mock.AnythingOfType("int")).Return(tt.args.expectedExistsResult)
-			purchaseOrderRepositoryMock.On("Update", *tt.args.ctx, mock.AnythingOfType("domain.PurchaseOrder")).Return(tt.args.expectedUpdateError)
+			purchaseOrderRepositoryMock.On("Get", tt.args.ctx, mock.AnythingOfType("int")).Return(tt.args.expectedGetResult, tt.args.expectedGetError)
+			purchaseOrderRepositoryMock.On("Update", tt.args.ctx, mock.AnythingOfType("domain.PurchaseOrder")).Return(tt.args.expectedUpdateError)
 
 			newPurchaseOrder, err := service.Update(tt.args.ctx, tt.args.id, tt.args.updatePurchaseOrderRequest)
 
@@ -397,7 +578,6 @@ func Test_purchaseOrder_Update(t *testing.T) {
 			assert.Equal(t, tt.wantErr, err)
 
 			purchaseOrderRepositoryMock.AssertNumberOfCalls(t, "Get", tt.args.expectedGetCalls)
-			purchaseOrderRepositoryMock.AssertNumberOfCalls(t, "Exists", tt.args.expectedExistsCalls)
 			purchaseOrderRepositoryMock.AssertNumberOfCalls(t, "Update", tt.args.expectedUpdateCalls)
 
 		})
@@ -406,7 +586,7 @@ func Test_purchaseOrder_Update(t *testing.T) {
 
 func Test_purchaseOrder_Delete(t *testing.T) {
 	type args struct {
-		ctx                 *context.Context
+		ctx                 context.Context
 		id                  int
 		expectedGetResult   domain.PurchaseOrder
 		expectedGetError    error
@@ -431,7 +611,7 @@ func Test_purchaseOrder_Delete(t *testing.T) {
 		{
 			name: "Successfully deleting purchaseOrder",
 			args: args{
-				ctx:                 &ctx,
+				ctx:                 ctx,
 				id:                  1,
 				expectedGetResult:   expectedPurchaseOrder,
 				expectedGetError:    nil,
@@ -444,7 +624,7 @@ func Test_purchaseOrder_Delete(t *testing.T) {
 		{
 			name: "Error getting purchaseOrder",
 			args: args{
-				ctx:                 &ctx,
+				ctx:                 ctx,
 				id:                  1,
 				expectedGetResult:   domain.PurchaseOrder{},
 				expectedGetError:    assert.AnError,
@@ -457,7 +637,7 @@ func Test_purchaseOrder_Delete(t *testing.T) {
 		{
 			name: "Error deleting purchaseOrder",
 			args: args{
-				ctx:                 &ctx,
+				ctx:                 ctx,
 				id:                  1,
 				expectedGetError:    nil,
 				expectedGetCalls:    1,
@@ -474,9 +654,9 @@ func Test_purchaseOrder_Delete(t *testing.T) {
 			purchaseOrderRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(tt.args.expectedDeleteError)
 
 			buyerRepositoryMock := buyer_mock.NewBuyerRepositoryMock()
-			service := NewPurchaseOrderService(purchaseOrderRepositoryMock, buyerRepositoryMock)
+			service := NewPurchaseOrderService(purchaseOrderRepositoryMock, mocks.NewMockOrderDetailRepository(t), buyerRepositoryMock, mocks.NewMockOrderStatusRepository(t), database_mocks.NewUnitOfWorkMock())
 
-			err := service.Delete(&ctx, tt.args.id)
+			err := service.Delete(ctx, tt.args.id)
 
 			assert.Equal(t, tt.wantErr, err)
 			purchaseOrderRepositoryMock.On("Get", tt.args.expectedGetCalls)
@@ -487,7 +667,7 @@ func Test_purchaseOrder_Delete(t *testing.T) {
 
 func Test_purchaseOrderService_CountByPurchaseOrderID(t *testing.T) {
 	type args struct {
-		ctx                          *context.Context
+		ctx                          context.Context
 		id                           int
 		expectedGetResult            domain.Buyer
 		expectedGetError             error
@@ -514,7 +694,7 @@ func Test_purchaseOrderService_CountByPurchaseOrderID(t *testing.T) {
 		{
 			name: "Successfully counting puchaseOrders by buyer id",
 			args: args{
-				ctx:                          &ctx,
+				ctx:                          ctx,
 				id:                           1,
 				expectedGetResult:            expectedBuyer,
 				expectedGetError:             nil,
@@ -529,7 +709,7 @@ func Test_purchaseOrderService_CountByPurchaseOrderID(t *testing.T) {
 		{
 			name: "Error getting purchaseOrders by buyer id",
 			args: args{
-				ctx:                          &ctx,
+				ctx:                          ctx,
 				id:                           1,
 				expectedGetResult:            expectedBuyer,
 				expectedGetError:             nil,
@@ -544,7 +724,7 @@ func Test_purchaseOrderService_CountByPurchaseOrderID(t *testing.T) {
 		{
 			name: "Error getting buyer",
 			args: args{
-				ctx:                          &ctx,
+				ctx:                          ctx,
 				id:                           1,
 				expectedGetResult:            domain.Buyer{},
 				expectedGetError:             assert.AnError,
@@ -566,9 +746,9 @@ func Test_purchaseOrderService_CountByPurchaseOrderID(t *testing.T) {
 			purchaseOrderRepositoryMock := mocks.NewMockPurchaseOrderRepository(t)
 			purchaseOrderRepositoryMock.On("CountByBuyerID", ctx, mock.AnythingOfType("int")).Return(tt.args.expectedCountByBuyerIDResult, tt.args.expectedCountByBuyerIDError)
 
-			service := NewPurchaseOrderService(purchaseOrderRepositoryMock, buyerRepositoryMock)
+			service := NewPurchaseOrderService(purchaseOrderRepositoryMock, mocks.NewMockOrderDetailRepository(t), buyerRepositoryMock, mocks.NewMockOrderStatusRepository(t), database_mocks.NewUnitOfWorkMock())
 
-			got, err := service.CountByBuyerID(&ctx, tt.args.id)
+			got, err := service.CountByBuyerID(ctx, tt.args.id)
 
 			assert.Equal(t, tt.want, got)
 			assert.Equal(t, tt.wantErr, err)
@@ -579,3 +759,88 @@ func Test_purchaseOrderService_CountByPurchaseOrderID(t *testing.T) {
 		})
 	}
 }
+
+func Test_purchaseOrderService_ReportByBuyer(t *testing.T) {
+	ctx := context.TODO()
+
+	report := buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO{BuyerID: 1, PurchaseOrdersCount: 2}
+
+	tests := []struct {
+		name                        string
+		filter                      buyerDtos.GetPurchaseOrdersReportRequestDTO
+		expectedReportByBuyersRows  []buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO
+		expectedReportByBuyersCalls int
+		want                        buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO
+		wantErr                     error
+	}{
+		{
+			name:                        "Successfully report the purchaseOrders of a buyer",
+			filter:                      buyerDtos.GetPurchaseOrdersReportRequestDTO{OrderDateFrom: "2023-01-01", OrderDateTo: "2023-01-01"},
+			expectedReportByBuyersRows:  []buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO{report},
+			expectedReportByBuyersCalls: 1,
+			want:                        report,
+			wantErr:                     nil,
+		},
+		{
+			name:                        "Error buyer not found",
+			expectedReportByBuyersRows:  []buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO{},
+			expectedReportByBuyersCalls: 1,
+			want:                        buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO{},
+			wantErr:                     buyer.ErrNotFound,
+		},
+		{
+			name:                        "Error date range ending before it starts",
+			filter:                      buyerDtos.GetPurchaseOrdersReportRequestDTO{OrderDateFrom: "2023-02-01", OrderDateTo: "2023-01-31"},
+			expectedReportByBuyersCalls: 0,
+			want:                        buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO{},
+			wantErr:                     ErrReportDateRange,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			purchaseOrderRepositoryMock := mocks.NewMockPurchaseOrderRepository(t)
+			purchaseOrderRepositoryMock.On("ReportByBuyers", ctx, 1, tt.filter).Return(tt.expectedReportByBuyersRows, nil).Maybe()
+
+			service := NewPurchaseOrderService(purchaseOrderRepositoryMock, mocks.NewMockOrderDetailRepository(t), buyer_mock.NewBuyerRepositoryMock(), mocks.NewMockOrderStatusRepository(t), database_mocks.NewUnitOfWorkMock())
+
+			got, err := service.ReportByBuyer(ctx, 1, tt.filter)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+
+			purchaseOrderRepositoryMock.AssertNumberOfCalls(t, "ReportByBuyers", tt.expectedReportByBuyersCalls)
+		})
+	}
+}
+
+func Test_purchaseOrderService_ReportAllBuyers(t *testing.T) {
+	ctx := context.TODO()
+
+	reports := []buyerDtos.GetNumberOfPurchaseOrdersByBuyerResponseDTO{
+		{BuyerID: 1, PurchaseOrdersCount: 2},
+		{BuyerID: 2, PurchaseOrdersCount: 0},
+	}
+
+	t.Run("Successfully report the purchaseOrders of every buyer", func(t *testing.T) {
+		purchaseOrderRepositoryMock := mocks.NewMockPurchaseOrderRepository(t)
+		purchaseOrderRepositoryMock.On("ReportByBuyers", ctx, 0, buyerDtos.GetPurchaseOrdersReportRequestDTO{}).Return(reports, nil)
+
+		service := NewPurchaseOrderService(purchaseOrderRepositoryMock, mocks.NewMockOrderDetailRepository(t), buyer_mock.NewBuyerRepositoryMock(), mocks.NewMockOrderStatusRepository(t), database_mocks.NewUnitOfWorkMock())
+
+		got, err := service.ReportAllBuyers(ctx, buyerDtos.GetPurchaseOrdersReportRequestDTO{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, reports, got)
+	})
+
+	t.Run("Error date range ending before it starts", func(t *testing.T) {
+		purchaseOrderRepositoryMock := mocks.NewMockPurchaseOrderRepository(t)
+
+		service := NewPurchaseOrderService(purchaseOrderRepositoryMock, mocks.NewMockOrderDetailRepository(t), buyer_mock.NewBuyerRepositoryMock(), mocks.NewMockOrderStatusRepository(t), database_mocks.NewUnitOfWorkMock())
+
+		_, err := service.ReportAllBuyers(ctx, buyerDtos.GetPurchaseOrdersReportRequestDTO{OrderDateFrom: "2023-02-01", OrderDateTo: "2023-01-31"})
+
+		assert.Equal(t, ErrReportDateRange, err)
+		purchaseOrderRepositoryMock.AssertNotCalled(t, "ReportByBuyers")
+	})
+}
@@ -0,0 +1,69 @@
+package purchaseOrder
+
+import (
+	"context"
+	"database/sql"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+)
+
+type TransitionRepository interface {
+	GetAllByPurchaseOrderID(ctx context.Context, purchaseOrderID int) ([]domain.PurchaseOrderTransition, error)
+	Save(ctx context.Context, transition domain.PurchaseOrderTransition) (int, error)
+}
+
+const (
+	GetAllTransitionsByPurchaseOrderID = "SELECT t.id, t.purchase_order_id, t.from_status_id, f.code, t.to_status_id, s.code, t.created_at FROM purchase_order_transitions t INNER JOIN order_status f ON f.id = t.from_status_id INNER JOIN order_status s ON s.id = t.to_status_id WHERE t.purchase_order_id = ? ORDER BY t.created_at, t.id"
+	SaveTransition                     = "INSERT INTO purchase_order_transitions(purchase_order_id, from_status_id, to_status_id, created_at) VALUES (?,?,?,?)"
+)
+
+type transitionRepository struct {
+	db *sql.DB
+}
+
+func NewTransitionRepository(db *sql.DB) TransitionRepository {
+	return &transitionRepository{
+		db: db,
+	}
+}
+
+func (r *transitionRepository) GetAllByPurchaseOrderID(ctx context.Context, purchaseOrderID int) ([]domain.PurchaseOrderTransition, error) {
+	transitions := make([]domain.PurchaseOrderTransition, 0)
+
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, GetAllTransitionsByPurchaseOrderID, purchaseOrderID)
+	if err != nil {
+		return transitions, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		transition := domain.PurchaseOrderTransition{}
+		err := rows.Scan(&transition.ID, &transition.PurchaseOrderID, &transition.FromStatusID, &transition.FromStatus, &transition.ToStatusID, &transition.ToStatus, &transition.CreatedAt)
+		if err != nil {
+			return transitions, err
+		}
+
+		transitions = append(transitions, transition)
+	}
+
+	return transitions, rows.Err()
+}
+
+func (r *transitionRepository) Save(ctx context.Context, transition domain.PurchaseOrderTransition) (int, error) {
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, SaveTransition)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := stmt.ExecContext(ctx, transition.PurchaseOrderID, transition.FromStatusID, transition.ToStatusID, transition.CreatedAt)
+	if err != nil {
+		return 0, database.TranslateError(err, "purchase order transition")
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(id), nil
+}
@@ -0,0 +1,57 @@
+package purchaseOrder
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func Test_transitionRepository_GetAllByPurchaseOrderID(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	transitions := []domain.PurchaseOrderTransition{
+		{ID: 1, PurchaseOrderID: 1, FromStatusID: 1, FromStatus: domain.OrderStatusCreated, ToStatusID: 2, ToStatus: domain.OrderStatusPicked, CreatedAt: "2023-07-02 15:00:00"},
+		{ID: 3, PurchaseOrderID: 1, FromStatusID: 2, FromStatus: domain.OrderStatusPicked, ToStatusID: 3, ToStatus: domain.OrderStatusShipped, CreatedAt: "2023-07-03 09:30:00"},
+	}
+
+	r := NewTransitionRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "purchase_order_id", "from_status_id", "from_status", "to_status_id", "to_status", "created_at"})
+	for _, transition := range transitions {
+		rows.AddRow(transition.ID, transition.PurchaseOrderID, transition.FromStatusID, transition.FromStatus, transition.ToStatusID, transition.ToStatus, transition.CreatedAt)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(GetAllTransitionsByPurchaseOrderID)).
+		WithArgs(1).
+		WillReturnRows(rows)
+
+	got, err := r.GetAllByPurchaseOrderID(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, transitions, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_transitionRepository_Save(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	transition := domain.PurchaseOrderTransition{PurchaseOrderID: 1, FromStatusID: 1, ToStatusID: 5, CreatedAt: "2023-07-02 15:00:00"}
+
+	r := NewTransitionRepository(db)
+
+	mock.ExpectPrepare(regexp.QuoteMeta(SaveTransition))
+	mock.ExpectExec(regexp.QuoteMeta(SaveTransition)).
+		WithArgs(transition.PurchaseOrderID, transition.FromStatusID, transition.ToStatusID, transition.CreatedAt).
+		WillReturnResult(sqlmock.NewResult(4, 1))
+
+	got, err := r.Save(ctx, transition)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,93 @@
+package purchaseOrder
+
+import (
+	"context"
+	"fmt"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"time"
+)
+
+// transitionTimeLayout is the text format of the created_at DATETIME column of transitions.
+const transitionTimeLayout = "2006-01-02 15:04:05"
+
+type TransitionService interface {
+	GetAll(ctx context.Context, purchaseOrderID int) ([]domain.PurchaseOrderTransition, error)
+	Create(ctx context.Context, purchaseOrderID int, statusCode string) (domain.PurchaseOrderTransition, error)
+}
+
+type transitionService struct {
+	transitionRepository    TransitionRepository
+	purchaseOrderRepository PurchaseOrderRepository
+	orderStatusRepository   OrderStatusRepository
+	unitOfWork              database.UnitOfWork
+}
+
+func NewTransitionService(r TransitionRepository, purchaseOrderRepository PurchaseOrderRepository, orderStatusRepository OrderStatusRepository, unitOfWork database.UnitOfWork) TransitionService {
+	return &transitionService{
+		transitionRepository:    r,
+		purchaseOrderRepository: purchaseOrderRepository,
+		orderStatusRepository:   orderStatusRepository,
+		unitOfWork:              unitOfWork,
+	}
+}
+
+func (service *transitionService) GetAll(ctx context.Context, purchaseOrderID int) ([]domain.PurchaseOrderTransition, error) {
+	if !service.purchaseOrderRepository.Exists(ctx, purchaseOrderID) {
+		return []domain.PurchaseOrderTransition{}, ErrNotFound
+	}
+
+	return service.transitionRepository.GetAllByPurchaseOrderID(ctx, purchaseOrderID)
+}
+
+// Create moves the purchase order to the status with statusCode and records the transition in a single unit of work.
+// A status that does not exist is a validation error and one the lifecycle does not allow from the current status a conflict.
+func (service *transitionService) Create(ctx context.Context, purchaseOrderID int, statusCode string) (domain.PurchaseOrderTransition, error) {
+	var transition domain.PurchaseOrderTransition
+	err := service.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		purchaseOrder, err := service.purchaseOrderRepository.Get(ctx, purchaseOrderID)
+		if err != nil {
+			return err
+		}
+
+		from, err := service.orderStatusRepository.Get(ctx, purchaseOrder.OrderStatusID)
+		if err != nil {
+			return err
+		}
+
+		to, err := service.orderStatusRepository.GetByCode(ctx, statusCode)
+		if err == ErrOrderStatusNotFound {
+			return errors.Validation("purchase order transition", "status", fmt.Sprintf("order status %s does not exist", statusCode))
+		}
+		if err != nil {
+			return err
+		}
+
+		if !from.CanTransitionTo(to) {
+			invalid := errors.Conflict("purchase order", "order_status_id")
+			invalid.Message = fmt.Sprintf("purchase order can't go from %s to %s", from.Code, to.Code)
+			return invalid
+		}
+
+		if err := service.purchaseOrderRepository.UpdateStatus(ctx, purchaseOrderID, from.ID, to.ID); err != nil {
+			return err
+		}
+
+		transition = domain.PurchaseOrderTransition{
+			PurchaseOrderID: purchaseOrderID,
+			FromStatusID:    from.ID,
+			FromStatus:      from.Code,
+			ToStatusID:      to.ID,
+			ToStatus:        to.Code,
+			CreatedAt:       time.Now().UTC().Format(transitionTimeLayout),
+		}
+		transition.ID, err = service.transitionRepository.Save(ctx, transition)
+		return err
+	})
+	if err != nil {
+		return domain.PurchaseOrderTransition{}, err
+	}
+
+	return transition, nil
+}
@@ -0,0 +1,163 @@
+package purchaseOrder
+
+import (
+	"context"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	database_mocks "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/purchaseOrder/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func Test_transitionService_GetAll(t *testing.T) {
+	ctx := context.TODO()
+
+	transitions := []domain.PurchaseOrderTransition{
+		{ID: 1, PurchaseOrderID: 1, FromStatusID: 1, FromStatus: domain.OrderStatusCreated, ToStatusID: 2, ToStatus: domain.OrderStatusPicked, CreatedAt: "2023-07-02 15:00:00"},
+	}
+
+	tests := []struct {
+		name                 string
+		expectedExistsResult bool
+		expectedGetAllCalls  int
+		want                 []domain.PurchaseOrderTransition
+		wantErr              error
+	}{
+		{
+			name:                 "Successfully get all",
+			expectedExistsResult: true,
+			expectedGetAllCalls:  1,
+			want:                 transitions,
+			wantErr:              nil,
+		},
+		{
+			name:                 "Error purchaseOrder not found",
+			expectedExistsResult: false,
+			expectedGetAllCalls:  0,
+			want:                 []domain.PurchaseOrderTransition{},
+			wantErr:              ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			purchaseOrderRepositoryMock := mocks.NewMockPurchaseOrderRepository(t)
+			purchaseOrderRepositoryMock.On("Exists", ctx, 1).Return(tt.expectedExistsResult)
+
+			transitionRepositoryMock := mocks.NewMockTransitionRepository(t)
+			transitionRepositoryMock.On("GetAllByPurchaseOrderID", ctx, 1).Return(transitions, nil).Maybe()
+
+			service := NewTransitionService(transitionRepositoryMock, purchaseOrderRepositoryMock, mocks.NewMockOrderStatusRepository(t), database_mocks.NewUnitOfWorkMock())
+			got, err := service.GetAll(ctx, 1)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+
+			transitionRepositoryMock.AssertNumberOfCalls(t, "GetAllByPurchaseOrderID", tt.expectedGetAllCalls)
+		})
+	}
+}
+
+func Test_transitionService_Create(t *testing.T) {
+	ctx := context.TODO()
+
+	statuses := map[string]domain.OrderStatus{
+		domain.OrderStatusCreated:   {ID: 1, Code: domain.OrderStatusCreated, Description: "Created"},
+		domain.OrderStatusPicked:    {ID: 2, Code: domain.OrderStatusPicked, Description: "Picked"},
+		domain.OrderStatusDelivered: {ID: 4, Code: domain.OrderStatusDelivered, Description: "Delivered"},
+	}
+
+	tests := []struct {
+		name                     string
+		currentStatus            string
+		statusCode               string
+		expectedGetError         error
+		expectedUpdateStatusErr  error
+		expectedUpdateStatusCall int
+		expectedSaveCalls        int
+		want                     domain.PurchaseOrderTransition
+		wantErr                  error
+	}{
+		{
+			name:                     "Successfully move a created purchaseOrder to picked",
+			currentStatus:            domain.OrderStatusCreated,
+			statusCode:               domain.OrderStatusPicked,
+			expectedUpdateStatusCall: 1,
+			expectedSaveCalls:        1,
+			want:                     domain.PurchaseOrderTransition{ID: 7, PurchaseOrderID: 1, FromStatusID: 1, FromStatus: domain.OrderStatusCreated, ToStatusID: 2, ToStatus: domain.OrderStatusPicked},
+		},
+		{
+			name:          "Error moving a delivered purchaseOrder",
+			currentStatus: domain.OrderStatusDelivered,
+			statusCode:    domain.OrderStatusPicked,
+			wantErr:       &errors.Error{Kind: errors.KindConflict, Entity: "purchase order", Field: "order_status_id", Message: "purchase order can't go from delivered to picked"},
+		},
+		{
+			name:          "Error moving to the same status",
+			currentStatus: domain.OrderStatusPicked,
+			statusCode:    domain.OrderStatusPicked,
+			wantErr:       &errors.Error{Kind: errors.KindConflict, Entity: "purchase order", Field: "order_status_id", Message: "purchase order can't go from picked to picked"},
+		},
+		{
+			name:          "Error status does not exist",
+			currentStatus: domain.OrderStatusCreated,
+			statusCode:    "lost",
+			wantErr:       errors.Validation("purchase order transition", "status", "order status lost does not exist"),
+		},
+		{
+			name:             "Error purchaseOrder not found",
+			statusCode:       domain.OrderStatusPicked,
+			expectedGetError: ErrNotFound,
+			wantErr:          ErrNotFound,
+		},
+		{
+			name:                     "Error status changed by another transition",
+			currentStatus:            domain.OrderStatusCreated,
+			statusCode:               domain.OrderStatusPicked,
+			expectedUpdateStatusErr:  ErrStatusChanged,
+			expectedUpdateStatusCall: 1,
+			wantErr:                  ErrStatusChanged,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := statuses[tt.currentStatus]
+
+			purchaseOrderRepositoryMock := mocks.NewMockPurchaseOrderRepository(t)
+			purchaseOrderRepositoryMock.On("Get", ctx, 1).Return(domain.PurchaseOrder{ID: 1, OrderStatusID: current.ID}, tt.expectedGetError)
+			purchaseOrderRepositoryMock.On("UpdateStatus", ctx, 1, mock.AnythingOfType("int"), mock.AnythingOfType("int")).Return(tt.expectedUpdateStatusErr).Maybe()
+
+			orderStatusRepositoryMock := mocks.NewMockOrderStatusRepository(t)
+			orderStatusRepositoryMock.On("Get", ctx, current.ID).Return(current, nil).Maybe()
+			orderStatusRepositoryMock.On("GetByCode", ctx, tt.statusCode).Return(func(_ context.Context, code string) (domain.OrderStatus, error) {
+				if status, ok := statuses[code]; ok {
+					return status, nil
+				}
+				return domain.OrderStatus{}, ErrOrderStatusNotFound
+			}).Maybe()
+
+			transitionRepositoryMock := mocks.NewMockTransitionRepository(t)
+			transitionRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.PurchaseOrderTransition")).Return(7, nil).Maybe()
+
+			unitOfWork := database_mocks.NewUnitOfWorkMock()
+
+			service := NewTransitionService(transitionRepositoryMock, purchaseOrderRepositoryMock, orderStatusRepositoryMock, unitOfWork)
+			got, err := service.Create(ctx, 1, tt.statusCode)
+
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr == nil {
+				assert.NotEmpty(t, got.CreatedAt)
+				got.CreatedAt = ""
+			}
+			assert.Equal(t, tt.want, got)
+
+			assert.Equal(t, 1, unitOfWork.Calls)
+			purchaseOrderRepositoryMock.AssertNumberOfCalls(t, "UpdateStatus", tt.expectedUpdateStatusCall)
+			transitionRepositoryMock.AssertNumberOfCalls(t, "Save", tt.expectedSaveCalls)
+			if tt.expectedUpdateStatusCall > 0 {
+				purchaseOrderRepositoryMock.AssertCalled(t, "UpdateStatus", ctx, 1, 1, 2)
+			}
+		})
+	}
+}
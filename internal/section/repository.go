@@ -3,19 +3,45 @@ package section
 import (
 	"context"
 	"database/sql"
+	"errors"
 
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 )
 
 // Repository encapsulates the storage of a section.
 type Repository interface {
 	GetAll(ctx context.Context) ([]domain.Section, error)
+	GetPage(ctx context.Context, options listing.Options) ([]domain.Section, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.Section) error) error
 	Get(ctx context.Context, id int) (domain.Section, error)
 	Exists(ctx context.Context, sectionNumber int) bool
 	ExistsByID(ctx context.Context, id int) bool
 	Save(ctx context.Context, s domain.Section) (int, error)
 	Update(ctx context.Context, s domain.Section) error
+	IncreaseCurrentCapacity(ctx context.Context, id int, quantity int) error
 	Delete(ctx context.Context, id int) error
+	Dependents(ctx context.Context, id int) (map[string]int, error)
+	GetAllByWarehouse(ctx context.Context, warehouseID int) ([]domain.Section, error)
+}
+
+// Fields are the section fields list requests can sort and filter by.
+var Fields = listing.Fields{
+	"id":                  "id",
+	"section_number":      "section_number",
+	"current_temperature": "current_temperature",
+	"minimum_temperature": "minimum_temperature",
+	"current_capacity":    "current_capacity",
+	"minimum_capacity":    "minimum_capacity",
+	"maximum_capacity":    "maximum_capacity",
+	"warehouse_id":        "warehouse_id",
+	"product_type_id":     "product_type_id",
+}
+
+// dependents are the columns referencing a section; batches stored in it block its deletion.
+var dependents = []database.Dependent{
+	{Table: "product_batches", Column: "section_id"},
 }
 
 type repository struct {
@@ -30,7 +56,7 @@ func NewRepository(db *sql.DB) Repository {
 
 func (r *repository) GetAll(ctx context.Context) ([]domain.Section, error) {
 	query := "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, product_type_id FROM sections WHERE id=?"
-	rows, err := r.db.Query(query)
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -46,13 +72,52 @@ func (r *repository) GetAll(ctx context.Context) ([]domain.Section, error) {
 	return sections, nil
 }
 
+func (r *repository) GetPage(ctx context.Context, options listing.Options) ([]domain.Section, int, error) {
+	var total int
+	query, args := options.CountQuery("SELECT COUNT(*) FROM sections")
+	if err := database.Conn(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	sections := make([]domain.Section, 0)
+	err := r.Each(ctx, options, func(s domain.Section) error {
+		sections = append(sections, s)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return sections, total, nil
+}
+
+func (r *repository) Each(ctx context.Context, options listing.Options, fn func(domain.Section) error) error {
+	query, args := options.Query("SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, product_type_id FROM sections")
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		s := domain.Section{}
+		if err := rows.Scan(&s.ID, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID); err != nil {
+			return err
+		}
+		if err := fn(s); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 func (r *repository) Get(ctx context.Context, id int) (domain.Section, error) {
 	query := "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, product_type_id FROM sections WHERE id=?"
-	row := r.db.QueryRow(query, id)
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, query, id)
 	s := domain.Section{}
 	err := row.Scan(&s.ID, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID)
 	if err != nil {
-		return domain.Section{}, err
+		return domain.Section{}, database.TranslateError(err, "section")
 	}
 
 	return s, nil
@@ -60,28 +125,28 @@ func (r *repository) Get(ctx context.Context, id int) (domain.Section, error) {
 
 func (r *repository) Exists(ctx context.Context, sectionNumber int) bool {
 	query := "SELECT section_number FROM sections WHERE section_number=?;"
-	row := r.db.QueryRow(query, sectionNumber)
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, query, sectionNumber)
 	err := row.Scan(&sectionNumber)
 	return err == nil
 }
 
 func (r *repository) ExistsByID(ctx context.Context, id int) bool {
 	query := "SELECT id FROM sections WHERE id=?;"
-	row := r.db.QueryRow(query, id)
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, query, id)
 	err := row.Scan(&id)
 	return err == nil
 }
 
 func (r *repository) Save(ctx context.Context, s domain.Section) (int, error) {
 	query := "INSERT INTO sections (section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type) VALUES (?, ?, ?, ?, ?, ?, ?, ?);"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, query)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(&s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID)
+	res, err := stmt.ExecContext(ctx, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID)
 	if err != nil {
-		return 0, err
+		return 0, database.TranslateError(err, "section")
 	}
 
 	id, err := res.LastInsertId()
@@ -94,14 +159,14 @@ func (r *repository) Save(ctx context.Context, s domain.Section) (int, error) {
 
 func (r *repository) Update(ctx context.Context, s domain.Section) error {
 	query := "UPDATE sections SET section_number=?, current_temperature=?, minimum_temperature=?, current_capacity=?, minimum_capacity=?, maximum_capacity=?, warehouse_id=?, id_product_type=? WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, query)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(&s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID, &s.ID)
+	res, err := stmt.ExecContext(ctx, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID, &s.ID)
 	if err != nil {
-		return err
+		return database.TranslateError(err, "section")
 	}
 
 	_, err = res.RowsAffected()
@@ -112,16 +177,57 @@ func (r *repository) Update(ctx context.Context, s domain.Section) error {
 	return nil
 }
 
+// IncreaseCurrentCapacity adds quantity to the current capacity of the section in a single statement,
+// it fails with ErrCapacityExceeded when the result would go over the maximum capacity and with ErrNotFound
+// when there is no such section. A negative quantity releases capacity.
+func (r *repository) IncreaseCurrentCapacity(ctx context.Context, id int, quantity int) error {
+	query := "UPDATE sections SET current_capacity=current_capacity+? WHERE id=? AND current_capacity+?<=maximum_capacity"
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, quantity, id, quantity)
+	if err != nil {
+		return err
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affect > 0 {
+		return nil
+	}
+
+	// No row changed: the section is missing, full, or the quantity left it as it was.
+	var fits bool
+	query = "SELECT current_capacity+?<=maximum_capacity FROM sections WHERE id=?"
+	err = database.Conn(ctx, r.db).QueryRowContext(ctx, query, quantity, id).Scan(&fits)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !fits {
+		return ErrCapacityExceeded
+	}
+
+	return nil
+}
+
 func (r *repository) Delete(ctx context.Context, id int) error {
 	query := "DELETE FROM sections WHERE id=?;"
-	stmt, err := r.db.Prepare(query)
+	stmt, err := database.Conn(ctx, r.db).PrepareContext(ctx, query)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(id)
+	res, err := stmt.ExecContext(ctx, id)
 	if err != nil {
-		return err
+		return database.TranslateError(err, "section")
 	}
 
 	affect, err := res.RowsAffected()
@@ -135,3 +241,31 @@ func (r *repository) Delete(ctx context.Context, id int) error {
 
 	return nil
 }
+
+func (r *repository) Dependents(ctx context.Context, id int) (map[string]int, error) {
+	return database.CountDependents(ctx, database.Conn(ctx, r.db), id, dependents)
+}
+
+// GetAllByWarehouse lists every section of the warehouse.
+func (r *repository) GetAllByWarehouse(ctx context.Context, warehouseID int) ([]domain.Section, error) {
+	query := "SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, product_type_id FROM sections WHERE warehouse_id=? ORDER BY id"
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, query, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sections := make([]domain.Section, 0)
+	for rows.Next() {
+		s := domain.Section{}
+		if err := rows.Scan(&s.ID, &s.SectionNumber, &s.CurrentTemperature, &s.MinimumTemperature, &s.CurrentCapacity, &s.MinimumCapacity, &s.MaximumCapacity, &s.WarehouseID, &s.ProductTypeID); err != nil {
+			return nil, err
+		}
+		sections = append(sections, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sections, nil
+}
@@ -3,12 +3,16 @@ package section_test
 import (
 	"context"
 	"database/sql"
+	"net/url"
 	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/section"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 )
 var(
@@ -142,6 +146,47 @@ func TestRepositoryGet(t *testing.T) {
 		})
 	}
 
+func TestRepositoryGetPage(t *testing.T) {
+	options, _ := listing.Parse(url.Values{"warehouse_id": {"1"}, "offset": {"5"}}, section.Fields)
+
+	t.Run("GET PAGE Ok", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		r := section.NewRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM sections WHERE warehouse_id = ?")).
+			WithArgs("1").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(6))
+		rows := sqlmock.NewRows([]string{"id", "section_number", "current_temperature", "minimum_temperature", "current_capacity", "minimum_capacity", "maximum_capacity",
+			"warehouse_id", "product_type_id"}).
+			AddRow(expectedSection.ID, expectedSection.SectionNumber, expectedSection.CurrentTemperature, expectedSection.MinimumTemperature, expectedSection.CurrentCapacity, expectedSection.MinimumCapacity, expectedSection.MaximumCapacity, expectedSection.WarehouseID, expectedSection.ProductTypeID)
+		mock.ExpectQuery(regexp.QuoteMeta("FROM sections WHERE warehouse_id = ? ORDER BY id ASC LIMIT ? OFFSET ?")).
+			WithArgs("1", listing.DefaultLimit, 5).
+			WillReturnRows(rows)
+
+		sectionsReceived, total, err := r.GetPage(context.TODO(), options)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []domain.Section{expectedSection}, sectionsReceived)
+		assert.Equal(t, 6, total)
+		assert.Nil(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("GET PAGE Error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		r := section.NewRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM sections")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(6))
+		mock.ExpectQuery(regexp.QuoteMeta("FROM sections")).
+			WillReturnError(sql.ErrConnDone)
+
+		sectionsReceived, _, err := r.GetPage(context.TODO(), options)
+
+		assert.Nil(t, sectionsReceived)
+		assert.Equal(t, sql.ErrConnDone, err)
+	})
+}
+
 func TestRepositoryExists(t *testing.T) {
 	type fields struct {
 		db *sql.DB
@@ -245,6 +290,80 @@ func TestRepositoryDelete(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 }
+func TestRepositoryIncreaseCurrentCapacity(t *testing.T) {
+	type fields struct {
+		db *sql.DB
+	}
+
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+	query := "UPDATE sections SET current_capacity=current_capacity+? WHERE id=? AND current_capacity+?<=maximum_capacity"
+	fits := "SELECT current_capacity+?<=maximum_capacity FROM sections WHERE id=?"
+	t.Run("INCREASE - OK", func(t *testing.T) {
+		r := section.NewRepository(fields{db}.db)
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query))
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(5, expectedSection.ID, 5).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		err := r.IncreaseCurrentCapacity(ctx, expectedSection.ID, 5)
+		assert.Nil(t, err)
+	})
+
+	t.Run("INCREASE - Error - Capacity exceeded", func(t *testing.T) {
+		r := section.NewRepository(fields{db}.db)
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query))
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(500, expectedSection.ID, 500).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(fits)).
+			WithArgs(500, expectedSection.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"fits"}).AddRow(false))
+		err := r.IncreaseCurrentCapacity(ctx, expectedSection.ID, 500)
+		assert.Equal(t, section.ErrCapacityExceeded, err)
+	})
+
+	t.Run("INCREASE - Error - Section not found", func(t *testing.T) {
+		r := section.NewRepository(fields{db}.db)
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query))
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(5, 999, 5).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(fits)).
+			WithArgs(5, 999).
+			WillReturnRows(sqlmock.NewRows([]string{"fits"}))
+		err := r.IncreaseCurrentCapacity(ctx, 999, 5)
+		assert.Equal(t, section.ErrNotFound, err)
+	})
+
+	t.Run("INCREASE - OK - Nothing to change", func(t *testing.T) {
+		r := section.NewRepository(fields{db}.db)
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query))
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(0, expectedSection.ID, 0).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectQuery(regexp.QuoteMeta(fits)).
+			WithArgs(0, expectedSection.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"fits"}).AddRow(true))
+		err := r.IncreaseCurrentCapacity(ctx, expectedSection.ID, 0)
+		assert.Nil(t, err)
+	})
+
+	t.Run("INCREASE - Error - Exec", func(t *testing.T) {
+		r := section.NewRepository(fields{db}.db)
+
+		mock.ExpectPrepare(regexp.QuoteMeta(query))
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(5, expectedSection.ID, 5).
+			WillReturnError(sql.ErrConnDone)
+		err := r.IncreaseCurrentCapacity(ctx, expectedSection.ID, 5)
+		assert.Equal(t, sql.ErrConnDone, err)
+	})
+}
+
 func TestRepositorySave(t *testing.T) {
 	type fields struct {
 		db *sql.DB
@@ -280,6 +399,19 @@ func TestRepositorySave(t *testing.T) {
 		assert.NotNil(t, err)
 	})
 
+	t.Run("SAVE - Error - Unknown warehouse", func(t *testing.T) {
+		r := section.NewRepository(fields{db}.db)
+		query := "INSERT INTO sections (section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type) VALUES (?, ?, ?, ?, ?, ?, ?, ?);"
+		driverErr := &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails (`melisprint`.`sections`, CONSTRAINT `fk_sections_warehouses1` FOREIGN KEY (`warehouse_id`) REFERENCES `warehouses` (`id`))"}
+		mock.ExpectPrepare(regexp.QuoteMeta(query))
+		mock.ExpectExec(regexp.QuoteMeta(query)).WillReturnError(driverErr)
+
+		_, err := r.Save(ctx, expectedSection)
+
+		assert.ErrorIs(t, err, errors.ForeignKey("section", "warehouse_id"))
+		assert.ErrorIs(t, err, driverErr)
+	})
+
 	t.Run("SAVE - Error - RowlsAffected0", func(t *testing.T) {
 		r := section.NewRepository(fields{db}.db)
 		query := "INSERT INTO sections (section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, id_product_type) VALUES (?, ?, ?, ?, ?, ?, ?, ?);"
@@ -353,4 +485,54 @@ func TestRepositoryUpdate(t *testing.T) {
 
 		assert.NotNil(t, err)
 	})
-}
\ No newline at end of file
+}
+func TestRepositoryGetAllByWarehouse(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("SELECT id, section_number, current_temperature, minimum_temperature, current_capacity, minimum_capacity, maximum_capacity, warehouse_id, product_type_id FROM sections WHERE warehouse_id=? ORDER BY id")
+
+	t.Run("get_all_by_warehouse_ok", func(t *testing.T) {
+		r := section.NewRepository(db)
+		mock.ExpectQuery(query).WithArgs(1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "section_number", "current_temperature", "minimum_temperature", "current_capacity", "minimum_capacity", "maximum_capacity", "warehouse_id", "product_type_id"}).
+				AddRow(1, 10, -18, -20, 50, 20, 100, 1, 1))
+
+		sections, err := r.GetAllByWarehouse(ctx, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []domain.Section{{ID: 1, SectionNumber: 10, CurrentTemperature: -18, MinimumTemperature: -20, CurrentCapacity: 50, MinimumCapacity: 20, MaximumCapacity: 100, WarehouseID: 1, ProductTypeID: 1}}, sections)
+	})
+	t.Run("get_all_by_warehouse_error", func(t *testing.T) {
+		r := section.NewRepository(db)
+		mock.ExpectQuery(query).WithArgs(1).WillReturnError(sql.ErrConnDone)
+
+		sections, err := r.GetAllByWarehouse(ctx, 1)
+
+		assert.Nil(t, sections)
+		assert.Equal(t, sql.ErrConnDone, err)
+	})
+}
+
+func TestRepositoryDependents(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	t.Run("dependents_ok", func(t *testing.T) {
+		r := section.NewRepository(db)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM product_batches WHERE section_id=?")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		dependents, err := r.Dependents(ctx, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]int{"product_batches": 3}, dependents)
+	})
+	t.Run("dependents_error", func(t *testing.T) {
+		r := section.NewRepository(db)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM product_batches WHERE section_id=?")).WithArgs(1).WillReturnError(sql.ErrConnDone)
+
+		dependents, err := r.Dependents(ctx, 1)
+
+		assert.Equal(t, sql.ErrConnDone, err)
+		assert.Nil(t, dependents)
+	})
+}
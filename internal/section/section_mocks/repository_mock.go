@@ -3,6 +3,7 @@ package section_mocks
 import (
 	"context"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -20,6 +21,23 @@ func (r *SectionRepositoryMock) GetAll(ctx context.Context) ([]domain.Section, e
 	return args.Get(0).([]domain.Section), args.Error(1)
 }
 
+func (r *SectionRepositoryMock) GetPage(ctx context.Context, options listing.Options) ([]domain.Section, int, error) {
+	args := r.Called(ctx, options)
+
+	return args.Get(0).([]domain.Section), args.Int(1), args.Error(2)
+}
+
+func (r *SectionRepositoryMock) Each(ctx context.Context, options listing.Options, fn func(domain.Section) error) error {
+	args := r.Called(ctx, options)
+
+	for _, section := range args.Get(0).([]domain.Section) {
+		if err := fn(section); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
 func (r *SectionRepositoryMock) Get(ctx context.Context, id int) (domain.Section, error) {
 	args := r.Called(ctx, id)
 
@@ -50,8 +68,26 @@ func (r *SectionRepositoryMock) Update(ctx context.Context, b domain.Section) er
 	return args.Error(0)
 }
 
+func (r *SectionRepositoryMock) IncreaseCurrentCapacity(ctx context.Context, id int, quantity int) error {
+	args := r.Called(ctx, id, quantity)
+
+	return args.Error(0)
+}
+
 func (r *SectionRepositoryMock) Delete(ctx context.Context, id int) error {
 	args := r.Called(ctx, id)
 
 	return args.Error(0)
 }
+
+func (r *SectionRepositoryMock) Dependents(ctx context.Context, id int) (map[string]int, error) {
+	args := r.Called(ctx, id)
+
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (r *SectionRepositoryMock) GetAllByWarehouse(ctx context.Context, warehouseID int) ([]domain.Section, error) {
+	args := r.Called(ctx, warehouseID)
+
+	return args.Get(0).([]domain.Section), args.Error(1)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -11,17 +12,32 @@ type SectionServiceMock struct {
 	mock.Mock
 }
 
-func (s *SectionServiceMock) Get(ctx *context.Context, id int) (*domain.Section, error) {
+func (s *SectionServiceMock) Get(ctx context.Context, id int) (*domain.Section, error) {
 	args := s.Called(ctx, id)
 	return args.Get(0).(*domain.Section), args.Error(1)
 }
 
-func (s *SectionServiceMock) GetAll(ctx *context.Context) (*[]domain.Section, error) {
+func (s *SectionServiceMock) GetAll(ctx context.Context) (*[]domain.Section, error) {
 	args := s.Called(ctx)
 	return args.Get(0).(*[]domain.Section), args.Error(1)
 }
 
-func (s *SectionServiceMock) Save(ctx *context.Context, sectionNumber, currentTemperature, minimumTemperature, currentCapacity, minimumCapacity,
+func (s *SectionServiceMock) GetPage(ctx context.Context, options listing.Options) (*[]domain.Section, int, error) {
+	args := s.Called(ctx, options)
+	return args.Get(0).(*[]domain.Section), args.Int(1), args.Error(2)
+}
+
+func (s *SectionServiceMock) Each(ctx context.Context, options listing.Options, fn func(domain.Section) error) error {
+	args := s.Called(ctx, options)
+	for _, section := range args.Get(0).([]domain.Section) {
+		if err := fn(section); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (s *SectionServiceMock) Save(ctx context.Context, sectionNumber, currentTemperature, minimumTemperature, currentCapacity, minimumCapacity,
 	maximumCapacity, warehouseID, productTypeID int) (*domain.Section, error) {
 	args := s.Called(ctx, sectionNumber, currentTemperature, minimumTemperature, currentCapacity, minimumCapacity,
 		maximumCapacity, warehouseID, productTypeID)
@@ -35,7 +51,12 @@ func (s *SectionServiceMock) Update(ctx context.Context, sectionNumber, currentT
 	return args.Get(0).(*domain.Section), args.Error(1)
 }
 
-func (s *SectionServiceMock) Delete(ctx *context.Context, id int) error {
+func (s *SectionServiceMock) Delete(ctx context.Context, id int) error {
 	args := s.Called(ctx, id)
 	return args.Error(0)
 }
+
+func (s *SectionServiceMock) DeletePreview(ctx context.Context, id int) (*domain.DeletePreview, error) {
+	args := s.Called(ctx, id)
+	return args.Get(0).(*domain.DeletePreview), args.Error(1)
+}
@@ -2,25 +2,32 @@ package section
 
 import (
 	"context"
-	"database/sql"
-	"errors"
 
+	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 )
 
 // Errors
 var (
-	ErrNotFound            = errors.New("section not found")
-	ErrConflict            = errors.New("section with Section Number already exists")
-	ErrUnprocessableEntity = errors.New("error processing entity")
+	ErrNotFound         = errors2.NotFound("section")
+	ErrConflict         = &errors2.Error{Kind: errors2.KindConflict, Entity: "section", Field: "section_number", Message: "section with Section Number already exists"}
+	ErrCapacityExceeded = &errors2.Error{Kind: errors2.KindConflict, Entity: "section", Field: "current_capacity", Message: "section maximum capacity exceeded"}
+	ErrCapacityUpdate   = errors2.Validation("section", "current_capacity", "current_capacity can only be changed by moving stock")
+	ErrCapacityRange    = errors2.Validation("section", "maximum_capacity", "maximum_capacity must not be below minimum_capacity")
+	ErrCapacityInUse    = errors2.Validation("section", "maximum_capacity", "maximum_capacity must not be below current_capacity")
 )
 
 type Service interface {
-	Save(ctx *context.Context, sectionNumber, currentTemperature, minimumTemperature, currentCapacity, minimumCapacity, maximumCapacity,
+	Save(ctx context.Context, sectionNumber, currentTemperature, minimumTemperature, currentCapacity, minimumCapacity, maximumCapacity,
 		warehouseID, productTypeID int) (*domain.Section, error)
-	GetAll(ctx *context.Context) (*[]domain.Section, error)
-	Get(ctx *context.Context, id int) (*domain.Section, error)
-	Delete(ctx *context.Context, id int) error
+	GetAll(ctx context.Context) (*[]domain.Section, error)
+	GetPage(ctx context.Context, options listing.Options) (*[]domain.Section, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.Section) error) error
+	Get(ctx context.Context, id int) (*domain.Section, error)
+	Delete(ctx context.Context, id int) error
+	DeletePreview(ctx context.Context, id int) (*domain.DeletePreview, error)
 	Update(ctx context.Context, sectionNumber, currentTemperature, minimumTemperature, currentCapacity, minimumCapacity, maximumCapacity,
 		warehouseID, productTypeID *int, id int) (*domain.Section, error)
 }
@@ -35,9 +42,9 @@ func NewService(r Repository) Service {
 	}
 }
 
-func (s *service) Save(ctx *context.Context, sectionNumber, currentTemperature, minimumTemperature, currentCapacity, minimumCapacity,
+func (s *service) Save(ctx context.Context, sectionNumber, currentTemperature, minimumTemperature, currentCapacity, minimumCapacity,
 	maximumCapacity, warehouseID, productTypeID int) (*domain.Section, error) {
-	existingSection := s.sectionRepository.Exists(*ctx, sectionNumber)
+	existingSection := s.sectionRepository.Exists(ctx, sectionNumber)
 
 	if existingSection {
 		return nil, ErrConflict
@@ -54,13 +61,13 @@ func (s *service) Save(ctx *context.Context, sectionNumber, currentTemperature,
 		ProductTypeID:      productTypeID,
 	}
 
-	sectionId, err := s.sectionRepository.Save(*ctx, newSection)
+	sectionId, err := s.sectionRepository.Save(ctx, newSection)
 	if err != nil {
 		return nil, err
 
 	}
 
-	savedSection, err := s.sectionRepository.Get(*ctx, sectionId)
+	savedSection, err := s.sectionRepository.Get(ctx, sectionId)
 	if err != nil {
 		return nil, err
 	}
@@ -68,9 +75,9 @@ func (s *service) Save(ctx *context.Context, sectionNumber, currentTemperature,
 	return &savedSection, nil
 }
 
-func (s *service) GetAll(ctx *context.Context) (*[]domain.Section, error) {
+func (s *service) GetAll(ctx context.Context) (*[]domain.Section, error) {
 	sections := make([]domain.Section, 0)
-	sections, err := s.sectionRepository.GetAll(*ctx)
+	sections, err := s.sectionRepository.GetAll(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -78,33 +85,59 @@ func (s *service) GetAll(ctx *context.Context) (*[]domain.Section, error) {
 	return &sections, nil
 }
 
-func (s *service) Get(ctx *context.Context, id int) (*domain.Section, error) {
-	section, err := s.sectionRepository.Get(*ctx, id)
+func (s *service) GetPage(ctx context.Context, options listing.Options) (*[]domain.Section, int, error) {
+	sections, total, err := s.sectionRepository.GetPage(ctx, options)
 	if err != nil {
-		switch err {
-		case sql.ErrNoRows:
-			return nil, ErrNotFound
-		default:
-			return nil, err
-		}
+		return nil, 0, err
+	}
+
+	return &sections, total, nil
+}
+
+func (s *service) Each(ctx context.Context, options listing.Options, fn func(domain.Section) error) error {
+	return s.sectionRepository.Each(ctx, options, fn)
+}
+
+func (s *service) Get(ctx context.Context, id int) (*domain.Section, error) {
+	section, err := s.sectionRepository.Get(ctx, id)
+	if err != nil {
+		return nil, err
 	}
 
 	return &section, nil
 }
 
-func (s *service) Delete(ctx *context.Context, id int) error {
-	err := s.sectionRepository.Delete(*ctx, id)
+func (s *service) Delete(ctx context.Context, id int) error {
+	dependents, err := s.sectionRepository.Dependents(ctx, id)
 	if err != nil {
-		switch err {
-		case sql.ErrNoRows:
-			return ErrNotFound
-		default:
-			return err
-		}
+		return err
 	}
-	return nil
+	if err := database.CheckDependents(dependents); err != nil {
+		return err
+	}
+
+	return s.sectionRepository.Delete(ctx, id)
 }
 
+func (s *service) DeletePreview(ctx context.Context, id int) (*domain.DeletePreview, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+
+	dependents, err := s.sectionRepository.Dependents(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.DeletePreview{
+		ID:         id,
+		Dependents: dependents,
+		Deletable:  database.CheckDependents(dependents) == nil,
+	}, nil
+}
+
+// Update merges the given fields into the section. The current_capacity follows the stock moved in and out of
+// the section, so it can't be changed here, and the capacities of the merged section must stay consistent.
 func (s *service) Update(ctx context.Context, sectionNumber, currentTemperature, minimumTemperature, currentCapacity, minimumCapacity, maximumCapacity,
 	warehouseID, productTypeID *int, id int) (*domain.Section, error) {
 	existingSection, err := s.sectionRepository.Get(ctx, id)
@@ -125,8 +158,8 @@ func (s *service) Update(ctx context.Context, sectionNumber, currentTemperature,
 	if minimumTemperature != nil {
 		existingSection.MinimumTemperature = *minimumTemperature
 	}
-	if currentCapacity != nil {
-		existingSection.CurrentCapacity = *currentCapacity
+	if currentCapacity != nil && *currentCapacity != existingSection.CurrentCapacity {
+		return nil, ErrCapacityUpdate
 	}
 	if minimumCapacity != nil {
 		existingSection.MinimumCapacity = *minimumCapacity
@@ -141,14 +174,16 @@ func (s *service) Update(ctx context.Context, sectionNumber, currentTemperature,
 		existingSection.ProductTypeID = *productTypeID
 	}
 
+	if existingSection.MaximumCapacity < existingSection.MinimumCapacity {
+		return nil, ErrCapacityRange
+	}
+	if existingSection.MaximumCapacity < existingSection.CurrentCapacity {
+		return nil, ErrCapacityInUse
+	}
+
 	err1 := s.sectionRepository.Update(ctx, existingSection)
 	if err1 != nil {
-		switch err1 {
-		case sql.ErrNoRows:
-			return nil, ErrNotFound
-		default:
-			return nil, err1
-		}
+		return nil, err1
 	}
 
 	return &existingSection, nil
@@ -2,13 +2,14 @@ package section_test
 
 import (
 	"context"
-	"database/sql"
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/sections"
 	"testing"
 
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/section"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/section/section_mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -30,7 +31,7 @@ func TestGet(t *testing.T) {
 		ctx := context.TODO()
 		sectionRepositoryMock, service := InitMock()
 		sectionRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(*expectedSection, nil)
-		receivedSection, err := service.Get(&ctx, 1)
+		receivedSection, err := service.Get(ctx, 1)
 
 		assert.Equal(t, *expectedSection, *receivedSection)
 		assert.Equal(t, nil, err)
@@ -39,8 +40,8 @@ func TestGet(t *testing.T) {
 	t.Run("GET - get_find_by_id_non_existent", func(t *testing.T) {
 		ctx := context.TODO()
 		sectionRepositoryMock, service := InitMock()
-		sectionRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.Section{}, sql.ErrNoRows)
-		sectionReceived, err := service.Get(&ctx, 1)
+		sectionRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.Section{}, section.ErrNotFound)
+		sectionReceived, err := service.Get(ctx, 1)
 
 		assert.Nil(t, sectionReceived)
 		assert.Equal(t, section.ErrNotFound, err)
@@ -51,7 +52,7 @@ func TestGet(t *testing.T) {
 		ctx := context.TODO()
 		sectionRepositoryMock, service := InitMock()
 		sectionRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.Section{}, assert.AnError)
-		sectionReceived, err := service.Get(&ctx, 1)
+		sectionReceived, err := service.Get(ctx, 1)
 
 		assert.Nil(t, sectionReceived)
 		assert.Equal(t, assert.AnError, err)
@@ -90,7 +91,7 @@ func TestGetAll(t *testing.T) {
 		sectionRepositoryMock, service := InitMock()
 		sectionRepositoryMock.On("GetAll", ctx).Return(*expectedSections, nil)
 
-		sectionsReceived, err := service.GetAll(&ctx)
+		sectionsReceived, err := service.GetAll(ctx)
 
 		assert.Equal(t, *expectedSections, *sectionsReceived)
 		assert.Equal(t, nil, err)
@@ -103,7 +104,37 @@ func TestGetAll(t *testing.T) {
 		sectionRepositoryMock, service := InitMock()
 		sectionRepositoryMock.On("GetAll", ctx).Return([]domain.Section{}, assert.AnError)
 
-		sectionsReceived, err := service.GetAll(&ctx)
+		sectionsReceived, err := service.GetAll(ctx)
+
+		assert.Nil(t, sectionsReceived)
+		assert.Equal(t, assert.AnError, err)
+	})
+}
+
+func TestGetPage(t *testing.T) {
+	t.Run("GET PAGE - getPage_find_page", func(t *testing.T) {
+		expectedSections := []domain.Section{{ID: 1, SectionNumber: 10, WarehouseID: 1, ProductTypeID: 1}}
+		options := listing.Options{Limit: 1}
+		ctx := context.TODO()
+
+		sectionRepositoryMock, service := InitMock()
+		sectionRepositoryMock.On("GetPage", ctx, options).Return(expectedSections, 4, nil)
+
+		sectionsReceived, total, err := service.GetPage(ctx, options)
+
+		assert.Equal(t, expectedSections, *sectionsReceived)
+		assert.Equal(t, 4, total)
+		assert.Nil(t, err)
+	})
+
+	t.Run("GET PAGE - getPage_unexpected_error", func(t *testing.T) {
+		options := listing.Options{Limit: 1}
+		ctx := context.TODO()
+
+		sectionRepositoryMock, service := InitMock()
+		sectionRepositoryMock.On("GetPage", ctx, options).Return([]domain.Section{}, 0, assert.AnError)
+
+		sectionsReceived, _, err := service.GetPage(ctx, options)
 
 		assert.Nil(t, sectionsReceived)
 		assert.Equal(t, assert.AnError, err)
@@ -117,9 +148,10 @@ func TestDelete(t *testing.T) {
 
 		sectionRepositoryMock, service := InitMock()
 
+		sectionRepositoryMock.On("Dependents", ctx, mock.AnythingOfType("int")).Return(map[string]int{}, nil)
 		sectionRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(nil)
 
-		err := service.Delete(&ctx, 1)
+		err := service.Delete(ctx, 1)
 
 		assert.Nil(t, err)
 	})
@@ -130,9 +162,10 @@ func TestDelete(t *testing.T) {
 
 		sectionRepositoryMock, service := InitMock()
 
-		sectionRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(sql.ErrNoRows)
+		sectionRepositoryMock.On("Dependents", ctx, mock.AnythingOfType("int")).Return(map[string]int{}, nil)
+		sectionRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(section.ErrNotFound)
 
-		err := service.Delete(&ctx, 1)
+		err := service.Delete(ctx, 1)
 
 		assert.Equal(t, section.ErrNotFound, err)
 	})
@@ -143,12 +176,56 @@ func TestDelete(t *testing.T) {
 
 		sectionRepositoryMock, service := InitMock()
 
+		sectionRepositoryMock.On("Dependents", ctx, mock.AnythingOfType("int")).Return(map[string]int{}, nil)
 		sectionRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(assert.AnError)
 
-		err := service.Delete(&ctx, 1)
+		err := service.Delete(ctx, 1)
 
 		assert.Equal(t, assert.AnError, err)
 	})
+
+	t.Run("DELETE - delete_has_dependents", func(t *testing.T) {
+
+		ctx := context.TODO()
+
+		sectionRepositoryMock, service := InitMock()
+		sectionRepositoryMock.On("Dependents", ctx, 1).Return(map[string]int{"product_batches": 2}, nil)
+
+		err := service.Delete(ctx, 1)
+
+		assert.ErrorIs(t, err, database.ErrHasDependents)
+		assert.Equal(t, &database.DependentsError{Dependents: map[string]int{"product_batches": 2}}, err)
+		sectionRepositoryMock.AssertNotCalled(t, "Delete", ctx, 1)
+	})
+}
+
+func TestDeletePreview(t *testing.T) {
+	t.Run("DELETE PREVIEW - preview_ok", func(t *testing.T) {
+
+		ctx := context.TODO()
+
+		sectionRepositoryMock, service := InitMock()
+		sectionRepositoryMock.On("Get", ctx, 1).Return(domain.Section{ID: 1}, nil)
+		sectionRepositoryMock.On("Dependents", ctx, 1).Return(map[string]int{"product_batches": 2}, nil)
+
+		preview, err := service.DeletePreview(ctx, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &domain.DeletePreview{ID: 1, Dependents: map[string]int{"product_batches": 2}, Deletable: false}, preview)
+	})
+
+	t.Run("DELETE PREVIEW - preview_non_existent", func(t *testing.T) {
+
+		ctx := context.TODO()
+
+		sectionRepositoryMock, service := InitMock()
+		sectionRepositoryMock.On("Get", ctx, 1).Return(domain.Section{}, section.ErrNotFound)
+
+		preview, err := service.DeletePreview(ctx, 1)
+
+		assert.Nil(t, preview)
+		assert.Equal(t, section.ErrNotFound, err)
+	})
 }
 
 func TestCreate(t *testing.T) {
@@ -170,7 +247,7 @@ func TestCreate(t *testing.T) {
 		sectionRepositoryMock, service := InitMock()
 		sectionRepositoryMock.On("Exists", ctx, mock.AnythingOfType("int")).Return(true)
 
-		sectionSaved, err := service.Save(&ctx, requestSection.SectionNumber, requestSection.CurrentTemperature, requestSection.MinimumTemperature, requestSection.CurrentCapacity, requestSection.MinimumCapacity, requestSection.MaximumCapacity, requestSection.WarehouseID, requestSection.ProductTypeID)
+		sectionSaved, err := service.Save(ctx, requestSection.SectionNumber, requestSection.CurrentTemperature, requestSection.MinimumTemperature, requestSection.CurrentCapacity, requestSection.MinimumCapacity, requestSection.MaximumCapacity, requestSection.WarehouseID, requestSection.ProductTypeID)
 
 		assert.Equal(t, section.ErrConflict, err)
 		assert.Nil(t, sectionSaved)
@@ -197,7 +274,7 @@ func TestCreate(t *testing.T) {
 		sectionRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.Section")).Return(0, assert.AnError)
 
 		service := section.NewService(sectionRepositoryMock)
-		sectionSaved, err := service.Save(&ctx, requestSection.SectionNumber, requestSection.CurrentTemperature, requestSection.MinimumTemperature, requestSection.CurrentCapacity, requestSection.MinimumCapacity, requestSection.MaximumCapacity, requestSection.WarehouseID, requestSection.ProductTypeID)
+		sectionSaved, err := service.Save(ctx, requestSection.SectionNumber, requestSection.CurrentTemperature, requestSection.MinimumTemperature, requestSection.CurrentCapacity, requestSection.MinimumCapacity, requestSection.MaximumCapacity, requestSection.WarehouseID, requestSection.ProductTypeID)
 
 		assert.Equal(t, assert.AnError, err)
 		assert.Nil(t, sectionSaved)
@@ -224,7 +301,7 @@ func TestCreate(t *testing.T) {
 		sectionRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.Section")).Return(1, nil)
 		sectionRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.Section{}, assert.AnError)
 
-		sectionSaved, err := service.Save(&ctx, requestSection.SectionNumber, requestSection.CurrentTemperature, requestSection.MinimumTemperature, requestSection.CurrentCapacity, requestSection.MinimumCapacity, requestSection.MaximumCapacity, requestSection.WarehouseID, requestSection.ProductTypeID)
+		sectionSaved, err := service.Save(ctx, requestSection.SectionNumber, requestSection.CurrentTemperature, requestSection.MinimumTemperature, requestSection.CurrentCapacity, requestSection.MinimumCapacity, requestSection.MaximumCapacity, requestSection.WarehouseID, requestSection.ProductTypeID)
 
 		assert.Equal(t, assert.AnError, err)
 		assert.Nil(t, sectionSaved)
@@ -261,7 +338,7 @@ func TestCreate(t *testing.T) {
 		sectionRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.Section")).Return(1, nil)
 		sectionRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(*expectedSection, nil)
 
-		sectionSaved, err := service.Save(&ctx, requestSection.SectionNumber, requestSection.CurrentTemperature, requestSection.MinimumTemperature, requestSection.CurrentCapacity, requestSection.MinimumCapacity, requestSection.MaximumCapacity, requestSection.WarehouseID, requestSection.ProductTypeID)
+		sectionSaved, err := service.Save(ctx, requestSection.SectionNumber, requestSection.CurrentTemperature, requestSection.MinimumTemperature, requestSection.CurrentCapacity, requestSection.MinimumCapacity, requestSection.MaximumCapacity, requestSection.WarehouseID, requestSection.ProductTypeID)
 
 		assert.Equal(t, sectionSaved, expectedSection)
 		assert.Nil(t, err)
@@ -332,7 +409,7 @@ func TestUpdate(t *testing.T) {
 		sectionRepositoryMock, service := InitMock()
 		sectionRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(*expectedSection, nil)
 		sectionRepositoryMock.On("Exists", ctx, mock.AnythingOfType("int")).Return(false)
-		sectionRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.Section")).Return(sql.ErrNoRows)
+		sectionRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.Section")).Return(section.ErrNotFound)
 
 		sectionUpdate, err := service.Update(ctx, requestSection.SectionNumber, requestSection.CurrentTemperature, requestSection.MinimumTemperature, requestSection.CurrentCapacity, requestSection.MinimumCapacity, requestSection.MaximumCapacity, requestSection.WarehouseID, requestSection.ProductTypeID, 1)
 
@@ -443,6 +520,47 @@ func TestUpdate(t *testing.T) {
 		assert.Equal(t, section.ErrConflict, err)
 		assert.Nil(t, sectionUpdate)
 	})
+
+	t.Run("UPDATE - update_invalid_capacities", func(t *testing.T) {
+		storedSection := domain.Section{ID: 1, SectionNumber: 10, CurrentCapacity: 40, MinimumCapacity: 10, MaximumCapacity: 100}
+		currentCapacity := 50
+		minimumCapacity := 60
+		maximumCapacity := 30
+		unchangedCapacity := 40
+
+		tests := []struct {
+			name            string
+			currentCapacity *int
+			minimumCapacity *int
+			maximumCapacity *int
+			expectedErr     error
+		}{
+			{name: "current capacity changed", currentCapacity: &currentCapacity, expectedErr: section.ErrCapacityUpdate},
+			{name: "minimum equal to maximum", minimumCapacity: &minimumCapacity, maximumCapacity: &minimumCapacity, expectedErr: nil},
+			{name: "minimum above maximum", minimumCapacity: &currentCapacity, maximumCapacity: &maximumCapacity, expectedErr: section.ErrCapacityRange},
+			{name: "maximum below current", maximumCapacity: &maximumCapacity, expectedErr: section.ErrCapacityInUse},
+			{name: "current capacity unchanged", currentCapacity: &unchangedCapacity, maximumCapacity: &unchangedCapacity, expectedErr: nil},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				ctx := context.TODO()
+				sectionRepositoryMock, service := InitMock()
+				sectionRepositoryMock.On("Get", ctx, 1).Return(storedSection, nil)
+				sectionRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.Section")).Return(nil)
+
+				sectionUpdate, err := service.Update(ctx, nil, nil, nil, tt.currentCapacity, tt.minimumCapacity, tt.maximumCapacity, nil, nil, 1)
+
+				assert.Equal(t, tt.expectedErr, err)
+				if tt.expectedErr != nil {
+					assert.Nil(t, sectionUpdate)
+					sectionRepositoryMock.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				} else {
+					assert.Equal(t, storedSection.CurrentCapacity, sectionUpdate.CurrentCapacity)
+				}
+			})
+		}
+	})
 }
 
 func InitMock() (*section_mocks.SectionRepositoryMock, section.Service) {
@@ -4,6 +4,7 @@ import (
 	context "context"
 
 	domain "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	listing "github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -30,6 +31,32 @@ func (m *SellerRepositoryMock) Delete(ctx context.Context, id int) error {
 	return r0
 }
 
+// Dependents provides a mock function with given fields: ctx, id
+func (m *SellerRepositoryMock) Dependents(ctx context.Context, id int) (map[string]int, error) {
+	ret := m.Called(ctx, id)
+
+	var r0 map[string]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (map[string]int, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) map[string]int); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Exists provides a mock function with given fields: ctx, cid
 func (m *SellerRepositoryMock) Exists(ctx context.Context, cid int) bool {
 	ret := m.Called(ctx, cid)
@@ -96,6 +123,46 @@ func (m *SellerRepositoryMock) GetAll(ctx context.Context) ([]domain.Seller, err
 	return r0, r1
 }
 
+// GetPage provides a mock function with given fields: ctx, options
+func (m *SellerRepositoryMock) GetPage(ctx context.Context, options listing.Options) ([]domain.Seller, int, error) {
+	ret := m.Called(ctx, options)
+
+	var r0 []domain.Seller
+	if ret.Get(0) != nil {
+		r0 = ret.Get(0).([]domain.Seller)
+	}
+
+	return r0, ret.Int(1), ret.Error(2)
+}
+
+// Each provides a mock function with given fields: ctx, options, fn
+func (m *SellerRepositoryMock) Each(ctx context.Context, options listing.Options, fn func(domain.Seller) error) error {
+	ret := m.Called(ctx, options, fn)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, listing.Options, func(domain.Seller) error) error); ok {
+		r0 = rf(ctx, options, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (m *SellerRepositoryMock) Restore(ctx context.Context, id int) error {
+	ret := m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Save provides a mock function with given fields: ctx, s
 func (m *SellerRepositoryMock) Save(ctx context.Context, s domain.Seller) (int, error) {
 	ret := m.Called(ctx, s)
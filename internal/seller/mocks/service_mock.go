@@ -5,6 +5,7 @@ import (
 
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/sellers"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -12,30 +13,59 @@ type SellerServiceMock struct {
 	mock.Mock
 }
 
-func (service *SellerServiceMock) Get(ctx *context.Context, id int) (*domain.Seller, error) {
+func (service *SellerServiceMock) Get(ctx context.Context, id int) (*domain.Seller, error) {
 	args := service.Called(ctx, id)
 
 	return args.Get(0).(*domain.Seller), args.Error(1)
 }
 
-func (service *SellerServiceMock) GetAll(ctx *context.Context) (*[]domain.Seller, error) {
+func (service *SellerServiceMock) GetAll(ctx context.Context) (*[]domain.Seller, error) {
 	args := service.Called(ctx)
 
 	return args.Get(0).(*[]domain.Seller), args.Error(1)
 }
 
-func (service *SellerServiceMock) Save(ctx *context.Context, seller domain.Seller) (*domain.Seller, error) {
+func (service *SellerServiceMock) GetPage(ctx context.Context, options listing.Options) (*[]domain.Seller, int, error) {
+	args := service.Called(ctx, options)
+
+	return args.Get(0).(*[]domain.Seller), args.Int(1), args.Error(2)
+}
+
+func (service *SellerServiceMock) Each(ctx context.Context, options listing.Options, fn func(domain.Seller) error) error {
+	args := service.Called(ctx, options)
+
+	for _, seller := range args.Get(0).([]domain.Seller) {
+		if err := fn(seller); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (service *SellerServiceMock) Save(ctx context.Context, seller domain.Seller) (*domain.Seller, error) {
 	args := service.Called(ctx, seller)
 	return args.Get(0).(*domain.Seller), args.Error(1)
 }
 
-func (service *SellerServiceMock) Update(ctx *context.Context, id int, updateSellerRequest *dtos.UpdateSellerRequestDTO) (*domain.Seller, error) {
+func (service *SellerServiceMock) Update(ctx context.Context, id int, updateSellerRequest *dtos.UpdateSellerRequestDTO) (*domain.Seller, error) {
 	args := service.Called(ctx, id, updateSellerRequest)
 	return args.Get(0).(*domain.Seller), args.Error(1)
 }
 
-func (service *SellerServiceMock) Delete(ctx *context.Context, id int) error {
+func (service *SellerServiceMock) Delete(ctx context.Context, id int) error {
 	args := service.Called(ctx, id)
 
 	return args.Error(0)
 }
+
+func (service *SellerServiceMock) Restore(ctx context.Context, id int) (*domain.Seller, error) {
+	args := service.Called(ctx, id)
+
+	return args.Get(0).(*domain.Seller), args.Error(1)
+}
+
+func (service *SellerServiceMock) DeletePreview(ctx context.Context, id int) (*domain.DeletePreview, error) {
+	args := service.Called(ctx, id)
+
+	return args.Get(0).(*domain.DeletePreview), args.Error(1)
+}
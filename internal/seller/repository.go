@@ -4,28 +4,51 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 )
 
 // Repository encapsulates the storage of a Seller.
 type Repository interface {
 	GetAll(ctx context.Context) ([]domain.Seller, error)
+	GetPage(ctx context.Context, options listing.Options) ([]domain.Seller, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.Seller) error) error
 	Get(ctx context.Context, id int) (*domain.Seller, error)
 	Exists(ctx context.Context, cid int) bool
 	Save(ctx context.Context, s domain.Seller) (int, error)
 	Update(ctx context.Context, s domain.Seller) error
 	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) error
+	Dependents(ctx context.Context, id int) (map[string]int, error)
 }
 
 const (
-	GetAllSellers     = "SELECT sellers.id, sellers.cid, sellers.company_name, sellers.address, sellers.telephone, sellers.locality_id FROM sellers"
-	GetSellerByID     = "SELECT sellers.id, sellers.cid, sellers.company_name, sellers.address, sellers.telephone, sellers.locality_id FROM sellers WHERE id=?"
+	GetAllSellers     = "SELECT sellers.id, sellers.cid, sellers.company_name, sellers.address, sellers.telephone, sellers.locality_id, sellers.deleted_at FROM sellers"
+	CountSellers      = "SELECT COUNT(*) FROM sellers"
+	GetSellerByID     = "SELECT sellers.id, sellers.cid, sellers.company_name, sellers.address, sellers.telephone, sellers.locality_id, sellers.deleted_at FROM sellers WHERE id=? AND deleted_at IS NULL"
 	ExistsSellerByCID = "SELECT cid FROM sellers WHERE cid=?"
 	SaveSeller        = "INSERT INTO sellers (cid, company_name, address, telephone, locality_id) VALUES (?, ?, ?, ?, ?)"
 	UpdateSeller      = "UPDATE sellers SET cid=?, company_name=?, address=?, telephone=?, locality_id=? WHERE id=?"
-	DeleteSellerByID  = "DELETE FROM sellers WHERE id=?"
+	DeleteSellerByID  = "UPDATE sellers SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"
+	RestoreSellerByID = "UPDATE sellers SET deleted_at=NULL WHERE id=? AND deleted_at IS NOT NULL"
 )
 
+// Fields are the seller fields list requests can sort and filter by.
+var Fields = listing.Fields{
+	"id":           "sellers.id",
+	"cid":          "sellers.cid",
+	"company_name": "sellers.company_name",
+	"address":      "sellers.address",
+	"telephone":    "sellers.telephone",
+	"locality_id":  "sellers.locality_id",
+}
+
+// dependents are the columns referencing a seller, deleted products do not count.
+var dependents = []database.Dependent{
+	{Table: "products", Column: "seller_id", SoftDeleted: true},
+}
+
 type repository struct {
 	db *sql.DB
 }
@@ -37,60 +60,68 @@ func NewSellerRepository(db *sql.DB) Repository {
 }
 
 func (r *repository) GetAll(ctx context.Context) ([]domain.Seller, error) {
-	sellers := make([]domain.Seller, 0)
-
-	rows, err := r.db.Query(GetAllSellers)
+	rows, err := r.db.QueryContext(ctx, GetAllSellers+" WHERE sellers.deleted_at IS NULL")
 	if err != nil {
-		return sellers, err
+		return make([]domain.Seller, 0), err
 	}
 
-	for rows.Next() {
-		s := domain.Seller{}
-		err := rows.Scan(&s.ID, &s.CID, &s.CompanyName, &s.Address, &s.Telephone, &s.LocalityID)
-		if err != nil {
-			return sellers, err
-		}
+	return scanSellers(rows)
+}
+
+func (r *repository) GetPage(ctx context.Context, options listing.Options) ([]domain.Seller, int, error) {
+	var total int
+	query, args := options.CountQuery(CountSellers)
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return make([]domain.Seller, 0), 0, err
+	}
+
+	sellers := make([]domain.Seller, 0)
+	err := r.Each(ctx, options, func(s domain.Seller) error {
 		sellers = append(sellers, s)
+		return nil
+	})
+	if err != nil {
+		return sellers, 0, err
 	}
 
-	tre := rows.Err()
-	if tre != nil {
-		return sellers, tre
+	return sellers, total, nil
+}
+
+func (r *repository) Each(ctx context.Context, options listing.Options, fn func(domain.Seller) error) error {
+	query, args := options.Query(GetAllSellers)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
 	}
 
-	return sellers, rows.Err()
+	return eachSeller(rows, fn)
 }
 
 func (r *repository) Get(ctx context.Context, id int) (*domain.Seller, error) {
-	row := r.db.QueryRow(GetSellerByID, id)
+	row := r.db.QueryRowContext(ctx, GetSellerByID, id)
 	s := domain.Seller{}
-	err := row.Scan(&s.ID, &s.CID, &s.CompanyName, &s.Address, &s.Telephone, &s.LocalityID)
+	err := row.Scan(&s.ID, &s.CID, &s.CompanyName, &s.Address, &s.Telephone, &s.LocalityID, &s.DeletedAt)
 	if err != nil {
-		switch err {
-		case sql.ErrNoRows:
-			return nil, ErrNotFound
-		default:
-			return &domain.Seller{}, err
-		}
+		return nil, database.TranslateError(err, "seller")
 	}
 	return &s, nil
 }
 
 func (r *repository) Exists(ctx context.Context, cid int) bool {
-	row := r.db.QueryRow(ExistsSellerByCID, cid)
+	row := r.db.QueryRowContext(ctx, ExistsSellerByCID, cid)
 	err := row.Scan(&cid)
 	return err == nil
 }
 
 func (r *repository) Save(ctx context.Context, s domain.Seller) (int, error) {
-	stmt, err := r.db.Prepare(SaveSeller)
+	stmt, err := r.db.PrepareContext(ctx, SaveSeller)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID)
+	res, err := stmt.ExecContext(ctx, s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID)
 	if err != nil {
-		return 0, err
+		return 0, database.TranslateError(err, "seller")
 	}
 
 	id, err := res.LastInsertId()
@@ -102,14 +133,14 @@ func (r *repository) Save(ctx context.Context, s domain.Seller) (int, error) {
 }
 
 func (r *repository) Update(ctx context.Context, s domain.Seller) error {
-	stmt, err := r.db.Prepare(UpdateSeller)
+	stmt, err := r.db.PrepareContext(ctx, UpdateSeller)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID, s.ID)
+	res, err := stmt.ExecContext(ctx, s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID, s.ID)
 	if err != nil {
-		return err
+		return database.TranslateError(err, "seller")
 	}
 
 	_, err = res.RowsAffected()
@@ -121,16 +152,39 @@ func (r *repository) Update(ctx context.Context, s domain.Seller) error {
 }
 
 func (r *repository) Delete(ctx context.Context, id int) error {
-	stmt, err := r.db.Prepare(DeleteSellerByID)
+	stmt, err := r.db.PrepareContext(ctx, DeleteSellerByID)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(id)
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return database.TranslateError(err, "seller")
+	}
+
+	affect, err := res.RowsAffected()
 	if err != nil {
 		return err
 	}
 
+	if affect < 1 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) Restore(ctx context.Context, id int) error {
+	stmt, err := r.db.PrepareContext(ctx, RestoreSellerByID)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return database.TranslateError(err, "seller")
+	}
+
 	affect, err := res.RowsAffected()
 	if err != nil {
 		return err
@@ -142,3 +196,34 @@ func (r *repository) Delete(ctx context.Context, id int) error {
 
 	return nil
 }
+
+func (r *repository) Dependents(ctx context.Context, id int) (map[string]int, error) {
+	return database.CountDependents(ctx, database.Conn(ctx, r.db), id, dependents)
+}
+
+func scanSellers(rows *sql.Rows) ([]domain.Seller, error) {
+	sellers := make([]domain.Seller, 0)
+	err := eachSeller(rows, func(s domain.Seller) error {
+		sellers = append(sellers, s)
+		return nil
+	})
+	return sellers, err
+}
+
+// eachSeller scans rows one at a time, passing them to fn, and closes them.
+func eachSeller(rows *sql.Rows, fn func(domain.Seller) error) error {
+	defer rows.Close()
+
+	for rows.Next() {
+		s := domain.Seller{}
+		err := rows.Scan(&s.ID, &s.CID, &s.CompanyName, &s.Address, &s.Telephone, &s.LocalityID, &s.DeletedAt)
+		if err != nil {
+			return err
+		}
+		if err := fn(s); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
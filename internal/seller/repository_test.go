@@ -5,7 +5,10 @@ import (
 	"database/sql"
 	"encoding/json"
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/assert"
 	"os"
 	"regexp"
@@ -64,16 +67,16 @@ func Test_repository_GetAll(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			r := NewSellerRepository(tt.fields.db)
 
-			rows := sqlmock.NewRows([]string{"id", "cid", "company_name", "address", "telephone", "locality_id"})
+			rows := sqlmock.NewRows([]string{"id", "cid", "company_name", "address", "telephone", "locality_id", "deleted_at"})
 			for _, s := range validSellers {
-				rows.AddRow(s.ID, s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID)
+				rows.AddRow(s.ID, s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID, nil)
 			}
 
 			if tt.wantErr {
 				rows.RowError(0, sql.ErrNoRows)
 			}
 
-			mock.ExpectQuery(GetAllSellers).WillReturnRows(rows)
+			mock.ExpectQuery(regexp.QuoteMeta(GetAllSellers + " WHERE sellers.deleted_at IS NULL")).WillReturnRows(rows)
 
 			got, err := r.GetAll(tt.args.ctx)
 
@@ -95,6 +98,70 @@ func Test_repository_GetAll(t *testing.T) {
 	})
 }
 
+func Test_repository_GetPage(t *testing.T) {
+	sellersSerialized, _ := os.ReadFile("../../test/resources/valid_sellers.json")
+	var validSellers []domain.Seller
+	if err := json.Unmarshal(sellersSerialized, &validSellers); err != nil {
+		t.Fatal(err)
+	}
+
+	options := listing.Options{
+		Limit:   2,
+		Offset:  4,
+		Sort:    "sellers.company_name",
+		Desc:    true,
+		Filters: []listing.Filter{{Column: "sellers.locality_id", Value: "3"}},
+	}
+
+	t.Run("Successfully get a page of sellers", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		r := NewSellerRepository(db)
+
+		rows := sqlmock.NewRows([]string{"id", "cid", "company_name", "address", "telephone", "locality_id", "deleted_at"})
+		for _, s := range validSellers {
+			rows.AddRow(s.ID, s.CID, s.CompanyName, s.Address, s.Telephone, s.LocalityID, nil)
+		}
+
+		mock.ExpectQuery(regexp.QuoteMeta(CountSellers + " WHERE sellers.locality_id = ?")).
+			WithArgs("3").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(10))
+		mock.ExpectQuery(regexp.QuoteMeta(GetAllSellers+" WHERE sellers.locality_id = ? ORDER BY sellers.company_name DESC LIMIT ? OFFSET ?")).
+			WithArgs("3", 2, 4).
+			WillReturnRows(rows)
+
+		got, total, err := r.GetPage(context.TODO(), options)
+
+		assert.NoError(t, err)
+		assert.Equal(t, validSellers, got)
+		assert.Equal(t, 10, total)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error counting sellers", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		r := NewSellerRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(CountSellers)).WillReturnError(sql.ErrConnDone)
+
+		_, _, err := r.GetPage(context.TODO(), options)
+
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+	})
+
+	t.Run("Error getting sellers", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		r := NewSellerRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta(CountSellers)).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(10))
+		mock.ExpectQuery(regexp.QuoteMeta(GetAllSellers)).WillReturnError(sql.ErrConnDone)
+
+		_, _, err := r.GetPage(context.TODO(), options)
+
+		assert.ErrorIs(t, err, sql.ErrConnDone)
+	})
+}
+
 func Test_repository_Get(t *testing.T) {
 	type fields struct {
 		db *sql.DB
@@ -146,16 +213,20 @@ func Test_repository_Get(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			r := NewSellerRepository(tt.fields.db)
 
-			rows := sqlmock.NewRows([]string{"id", "cid", "company_name", "address", "telephone", "locality_id"}).
-				AddRow(tt.want.ID, tt.want.CID, tt.want.CompanyName, tt.want.Address, tt.want.Telephone, tt.want.LocalityID)
+			rows := sqlmock.NewRows([]string{"id", "cid", "company_name", "address", "telephone", "locality_id", "deleted_at"}).
+				AddRow(tt.want.ID, tt.want.CID, tt.want.CompanyName, tt.want.Address, tt.want.Telephone, tt.want.LocalityID, nil)
 
-			mock.ExpectQuery(GetSellerByID).
+			mock.ExpectQuery(regexp.QuoteMeta(GetSellerByID)).
 				WithArgs(tt.want.ID).
 				WillReturnRows(rows)
 
 			got, err := r.Get(tt.args.ctx, tt.args.id)
 
-			assert.Equal(t, tt.want, got)
+			if tt.wantErr {
+				assert.Nil(t, got)
+			} else {
+				assert.Equal(t, tt.want, got)
+			}
 			assert.Equal(t, tt.wantErr, err != nil)
 		})
 	}
@@ -164,7 +235,7 @@ func Test_repository_Get(t *testing.T) {
 		db, mock, _ := sqlmock.New()
 		ctx := context.TODO()
 
-		mock.ExpectQuery(GetSellerByID).WithArgs(999).WillReturnError(sql.ErrNoRows)
+		mock.ExpectQuery(regexp.QuoteMeta(GetSellerByID)).WithArgs(999).WillReturnError(sql.ErrNoRows)
 
 		r := NewSellerRepository(db)
 
@@ -335,6 +406,34 @@ func Test_repository_Save(t *testing.T) {
 	})
 }
 
+func Test_repository_TranslatesErrors(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("Duplicate cid is a conflict", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		driverErr := &mysql.MySQLError{Number: 1062, Message: "Duplicate entry '1' for key 'sellers.cid_UNIQUE'"}
+		mock.ExpectPrepare(regexp.QuoteMeta(SaveSeller))
+		mock.ExpectExec(regexp.QuoteMeta(SaveSeller)).WillReturnError(driverErr)
+
+		_, err := NewSellerRepository(db).Save(ctx, domain.Seller{CID: 1})
+
+		assert.ErrorIs(t, err, ErrConflict)
+		assert.ErrorIs(t, err, driverErr)
+	})
+
+	t.Run("Referenced seller can't be deleted", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		driverErr := &mysql.MySQLError{Number: 1451, Message: "Cannot delete or update a parent row: a foreign key constraint fails"}
+		mock.ExpectPrepare(regexp.QuoteMeta(DeleteSellerByID))
+		mock.ExpectExec(regexp.QuoteMeta(DeleteSellerByID)).WillReturnError(driverErr)
+
+		err := NewSellerRepository(db).Delete(ctx, 1)
+
+		assert.ErrorIs(t, err, errors.Conflict("seller", ""))
+		assert.ErrorIs(t, err, driverErr)
+	})
+}
+
 func Test_repository_Update(t *testing.T) {
 	type fields struct {
 		db *sql.DB
@@ -523,3 +622,60 @@ func Test_repository_Delete(t *testing.T) {
 
 	})
 }
+
+func Test_repository_Restore(t *testing.T) {
+	t.Run("Successfully restore seller", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+
+		mock.ExpectPrepare(regexp.QuoteMeta(RestoreSellerByID))
+		mock.ExpectExec(regexp.QuoteMeta(RestoreSellerByID)).
+			WithArgs(6700).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		r := NewSellerRepository(db)
+
+		err := r.Restore(context.TODO(), 6700)
+
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("No deleted seller to restore", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+
+		mock.ExpectPrepare(regexp.QuoteMeta(RestoreSellerByID))
+		mock.ExpectExec(regexp.QuoteMeta(RestoreSellerByID)).
+			WithArgs(6700).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		r := NewSellerRepository(db)
+
+		err := r.Restore(context.TODO(), 6700)
+
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
+
+func Test_repository_Dependents(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	t.Run("dependents_ok", func(t *testing.T) {
+		r := NewSellerRepository(db)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM products WHERE seller_id=? AND deleted_at IS NULL")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+		dependents, err := r.Dependents(ctx, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]int{"products": 4}, dependents)
+	})
+	t.Run("dependents_error", func(t *testing.T) {
+		r := NewSellerRepository(db)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM products WHERE seller_id=? AND deleted_at IS NULL")).WithArgs(1).WillReturnError(sql.ErrConnDone)
+
+		dependents, err := r.Dependents(ctx, 1)
+
+		assert.Equal(t, sql.ErrConnDone, err)
+		assert.Nil(t, dependents)
+	})
+}
@@ -2,24 +2,30 @@ package seller
 
 import (
 	"context"
-	"errors"
 
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/sellers"
+	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 )
 
 // Errors
 var (
-	ErrNotFound = errors.New("seller not found")
-	ErrConflict = errors.New("Seller with CID already exists")
+	ErrNotFound = errors2.NotFound("seller")
+	ErrConflict = &errors2.Error{Kind: errors2.KindConflict, Entity: "seller", Field: "cid", Message: "Seller with CID already exists"}
 )
 
 type Service interface {
-	GetAll(ctx *context.Context) (*[]domain.Seller, error)
-	Get(ctx *context.Context, id int) (*domain.Seller, error)
-	Save(ctx *context.Context, seller domain.Seller) (*domain.Seller, error)
-	Update(ctx *context.Context, id int, updateSellerRequest *dtos.UpdateSellerRequestDTO) (*domain.Seller, error)
-	Delete(ctx *context.Context, id int) error
+	GetAll(ctx context.Context) (*[]domain.Seller, error)
+	GetPage(ctx context.Context, options listing.Options) (*[]domain.Seller, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.Seller) error) error
+	Get(ctx context.Context, id int) (*domain.Seller, error)
+	Save(ctx context.Context, seller domain.Seller) (*domain.Seller, error)
+	Update(ctx context.Context, id int, updateSellerRequest *dtos.UpdateSellerRequestDTO) (*domain.Seller, error)
+	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) (*domain.Seller, error)
+	DeletePreview(ctx context.Context, id int) (*domain.DeletePreview, error)
 }
 
 type service struct {
@@ -32,10 +38,10 @@ func NewService(r Repository) Service {
 	}
 }
 
-func (s *service) GetAll(ctx *context.Context) (*[]domain.Seller, error) {
+func (s *service) GetAll(ctx context.Context) (*[]domain.Seller, error) {
 	sellers := make([]domain.Seller, 0)
 
-	sellers, err := s.sellerRepository.GetAll(*ctx)
+	sellers, err := s.sellerRepository.GetAll(ctx)
 
 	if err != nil {
 		return nil, err
@@ -43,8 +49,20 @@ func (s *service) GetAll(ctx *context.Context) (*[]domain.Seller, error) {
 	return &sellers, nil
 }
 
-func (s *service) Get(ctx *context.Context, id int) (*domain.Seller, error) {
-	seller, err := s.sellerRepository.Get(*ctx, id)
+func (s *service) GetPage(ctx context.Context, options listing.Options) (*[]domain.Seller, int, error) {
+	sellers, total, err := s.sellerRepository.GetPage(ctx, options)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &sellers, total, nil
+}
+
+func (s *service) Each(ctx context.Context, options listing.Options, fn func(domain.Seller) error) error {
+	return s.sellerRepository.Each(ctx, options, fn)
+}
+
+func (s *service) Get(ctx context.Context, id int) (*domain.Seller, error) {
+	seller, err := s.sellerRepository.Get(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -52,13 +70,13 @@ func (s *service) Get(ctx *context.Context, id int) (*domain.Seller, error) {
 	return seller, nil
 }
 
-func (s *service) Save(ctx *context.Context, seller domain.Seller) (*domain.Seller, error) {
-	existingSeller := s.sellerRepository.Exists(*ctx, seller.CID)
+func (s *service) Save(ctx context.Context, seller domain.Seller) (*domain.Seller, error) {
+	existingSeller := s.sellerRepository.Exists(ctx, seller.CID)
 	if existingSeller {
 		return &domain.Seller{}, ErrConflict
 	}
 
-	id, err := s.sellerRepository.Save(*ctx, seller)
+	id, err := s.sellerRepository.Save(ctx, seller)
 	if err != nil {
 		return &domain.Seller{}, err
 	}
@@ -68,14 +86,14 @@ func (s *service) Save(ctx *context.Context, seller domain.Seller) (*domain.Sell
 	return &seller, nil
 }
 
-func (s *service) Update(ctx *context.Context, id int, updateSellerRequest *dtos.UpdateSellerRequestDTO) (*domain.Seller, error) {
-	existingSeller, err := s.sellerRepository.Get(*ctx, id)
+func (s *service) Update(ctx context.Context, id int, updateSellerRequest *dtos.UpdateSellerRequestDTO) (*domain.Seller, error) {
+	existingSeller, err := s.sellerRepository.Get(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
 	if updateSellerRequest.CID != nil {
-		existingSellerSearch := s.sellerRepository.Exists(*ctx, *updateSellerRequest.CID)
+		existingSellerSearch := s.sellerRepository.Exists(ctx, *updateSellerRequest.CID)
 		if existingSellerSearch {
 			return nil, ErrConflict
 		}
@@ -95,7 +113,7 @@ func (s *service) Update(ctx *context.Context, id int, updateSellerRequest *dtos
 		existingSeller.LocalityID = *updateSellerRequest.LocalityID
 	}
 
-	err1 := s.sellerRepository.Update(*ctx, *existingSeller)
+	err1 := s.sellerRepository.Update(ctx, *existingSeller)
 	if err1 != nil {
 		return nil, err1
 	}
@@ -103,16 +121,49 @@ func (s *service) Update(ctx *context.Context, id int, updateSellerRequest *dtos
 	return existingSeller, nil
 }
 
-func (s *service) Delete(ctx *context.Context, id int) error {
-	_, err := s.sellerRepository.Get(*ctx, id)
+func (s *service) Delete(ctx context.Context, id int) error {
+	_, err := s.sellerRepository.Get(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	err = s.sellerRepository.Delete(*ctx, id)
+	dependents, err := s.sellerRepository.Dependents(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := database.CheckDependents(dependents); err != nil {
+		return err
+	}
+
+	err = s.sellerRepository.Delete(ctx, id)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+func (s *service) Restore(ctx context.Context, id int) (*domain.Seller, error) {
+	if err := s.sellerRepository.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.sellerRepository.Get(ctx, id)
+}
+
+func (s *service) DeletePreview(ctx context.Context, id int) (*domain.DeletePreview, error) {
+	if _, err := s.sellerRepository.Get(ctx, id); err != nil {
+		return nil, err
+	}
+
+	dependents, err := s.sellerRepository.Dependents(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.DeletePreview{
+		ID:         id,
+		Dependents: dependents,
+		Deletable:  database.CheckDependents(dependents) == nil,
+	}, nil
+}
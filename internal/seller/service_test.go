@@ -7,9 +7,11 @@ import (
 	"testing"
 
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/sellers"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/seller"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/seller/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -30,7 +32,7 @@ func TestCreate(t *testing.T) {
 		sellerRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.Seller")).Return(1, nil)
 
 		service := seller.NewService(sellerRepositoryMock)
-		newSeller, err := service.Save(&ctx, *expectedSeller)
+		newSeller, err := service.Save(ctx, *expectedSeller)
 
 		assert.Equal(t, expectedSeller, newSeller)
 		assert.Equal(t, nil, err)
@@ -49,7 +51,7 @@ func TestCreate(t *testing.T) {
 		sellerRepositoryMock.On("Exists", ctx, mock.AnythingOfType("int")).Return(true)
 
 		service := seller.NewService(sellerRepositoryMock)
-		_, err := service.Save(&ctx, sellerToCreate)
+		_, err := service.Save(ctx, sellerToCreate)
 
 		assert.Equal(t, seller.ErrConflict, err)
 
@@ -68,7 +70,7 @@ func TestCreate(t *testing.T) {
 		sellerRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.Seller")).Return(1, assert.AnError)
 
 		service := seller.NewService(sellerRepositoryMock)
-		_, err := service.Save(&ctx, sellerToCreate)
+		_, err := service.Save(ctx, sellerToCreate)
 
 		assert.Equal(t, assert.AnError, err)
 
@@ -91,7 +93,7 @@ func TestGet(t *testing.T) {
 		sellerRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(expectedSeller, nil)
 
 		service := seller.NewService(sellerRepositoryMock)
-		sellerReceived, err := service.Get(&ctx, 1)
+		sellerReceived, err := service.Get(ctx, 1)
 
 		assert.Equal(t, *expectedSeller, *sellerReceived)
 		assert.Equal(t, nil, err)
@@ -105,7 +107,7 @@ func TestGet(t *testing.T) {
 		sellerRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(&domain.Seller{}, seller.ErrNotFound)
 
 		service := seller.NewService(sellerRepositoryMock)
-		sellerReceived, err := service.Get(&ctx, 1)
+		sellerReceived, err := service.Get(ctx, 1)
 
 		assert.Nil(t, sellerReceived)
 		assert.Equal(t, seller.ErrNotFound, err)
@@ -126,13 +128,50 @@ func TestGetAll(t *testing.T) {
 		sellerRepositoryMock.On("GetAll", ctx).Return(expectedSellers, nil)
 
 		service := seller.NewService(sellerRepositoryMock)
-		sellersReceived, err := service.GetAll(&ctx)
+		sellersReceived, err := service.GetAll(ctx)
 
 		assert.Equal(t, expectedSellers, *sellersReceived)
 		assert.Equal(t, nil, err)
 	})
 }
 
+func TestGetPage(t *testing.T) {
+	t.Run("getPage_find_page", func(t *testing.T) {
+		sellersSerialized, _ := os.ReadFile("../../test/resources/valid_sellers.json")
+		var expectedSellers []domain.Seller
+		if err := json.Unmarshal(sellersSerialized, &expectedSellers); err != nil {
+			t.Fatal(err)
+		}
+
+		ctx := context.TODO()
+		options := listing.Options{Limit: 2}
+
+		sellerRepositoryMock := new(mocks.SellerRepositoryMock)
+		sellerRepositoryMock.On("GetPage", ctx, options).Return(expectedSellers, 10, nil)
+
+		service := seller.NewService(sellerRepositoryMock)
+		sellersReceived, total, err := service.GetPage(ctx, options)
+
+		assert.Equal(t, expectedSellers, *sellersReceived)
+		assert.Equal(t, 10, total)
+		assert.Equal(t, nil, err)
+	})
+
+	t.Run("getPage_error", func(t *testing.T) {
+		ctx := context.TODO()
+		options := listing.Options{Limit: 2}
+
+		sellerRepositoryMock := new(mocks.SellerRepositoryMock)
+		sellerRepositoryMock.On("GetPage", ctx, options).Return(nil, 0, assert.AnError)
+
+		service := seller.NewService(sellerRepositoryMock)
+		sellersReceived, _, err := service.GetPage(ctx, options)
+
+		assert.Nil(t, sellersReceived)
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
 func TestDelete(t *testing.T) {
 	t.Run("delete_ok", func(t *testing.T) {
 
@@ -146,10 +185,11 @@ func TestDelete(t *testing.T) {
 
 		sellerRepositoryMock := new(mocks.SellerRepositoryMock)
 		sellerRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(&sellerToDelete, nil)
+		sellerRepositoryMock.On("Dependents", ctx, mock.AnythingOfType("int")).Return(map[string]int{}, nil)
 		sellerRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(nil)
 
 		service := seller.NewService(sellerRepositoryMock)
-		err := service.Delete(&ctx, 1)
+		err := service.Delete(ctx, 1)
 
 		assert.Nil(t, err)
 	})
@@ -162,7 +202,7 @@ func TestDelete(t *testing.T) {
 		sellerRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(&domain.Seller{}, seller.ErrNotFound)
 
 		service := seller.NewService(sellerRepositoryMock)
-		err := service.Delete(&ctx, 1)
+		err := service.Delete(ctx, 1)
 
 		assert.Equal(t, seller.ErrNotFound, err)
 	})
@@ -179,14 +219,93 @@ func TestDelete(t *testing.T) {
 
 		sellerRepositoryMock := new(mocks.SellerRepositoryMock)
 		sellerRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(&sellerToDelete, nil)
+		sellerRepositoryMock.On("Dependents", ctx, mock.AnythingOfType("int")).Return(map[string]int{}, nil)
 		sellerRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(assert.AnError)
 
 		service := seller.NewService(sellerRepositoryMock)
-		err := service.Delete(&ctx, 1)
+		err := service.Delete(ctx, 1)
 
 		assert.Equal(t, assert.AnError, err)
 	})
 
+	t.Run("delete_has_dependents", func(t *testing.T) {
+
+		ctx := context.TODO()
+
+		sellerRepositoryMock := mocks.NewSellerRepositoryMock()
+		sellerRepositoryMock.On("Get", ctx, 1).Return(&domain.Seller{ID: 1}, nil)
+		sellerRepositoryMock.On("Dependents", ctx, 1).Return(map[string]int{"products": 2}, nil)
+
+		service := seller.NewService(sellerRepositoryMock)
+		err := service.Delete(ctx, 1)
+
+		assert.ErrorIs(t, err, database.ErrHasDependents)
+		assert.Equal(t, &database.DependentsError{Dependents: map[string]int{"products": 2}}, err)
+		sellerRepositoryMock.AssertNotCalled(t, "Delete", ctx, 1)
+	})
+}
+
+func TestDeletePreview(t *testing.T) {
+	t.Run("delete_preview_ok", func(t *testing.T) {
+
+		ctx := context.TODO()
+
+		sellerRepositoryMock := mocks.NewSellerRepositoryMock()
+		sellerRepositoryMock.On("Get", ctx, 1).Return(&domain.Seller{ID: 1}, nil)
+		sellerRepositoryMock.On("Dependents", ctx, 1).Return(map[string]int{"products": 2}, nil)
+
+		service := seller.NewService(sellerRepositoryMock)
+		preview, err := service.DeletePreview(ctx, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &domain.DeletePreview{ID: 1, Dependents: map[string]int{"products": 2}, Deletable: false}, preview)
+	})
+
+	t.Run("delete_preview_non_existent", func(t *testing.T) {
+
+		ctx := context.TODO()
+
+		sellerRepositoryMock := mocks.NewSellerRepositoryMock()
+		sellerRepositoryMock.On("Get", ctx, 1).Return(&domain.Seller{}, seller.ErrNotFound)
+
+		service := seller.NewService(sellerRepositoryMock)
+		preview, err := service.DeletePreview(ctx, 1)
+
+		assert.Nil(t, preview)
+		assert.Equal(t, seller.ErrNotFound, err)
+	})
+}
+
+func TestRestore(t *testing.T) {
+	t.Run("restore_ok", func(t *testing.T) {
+
+		ctx := context.TODO()
+
+		sellerRepositoryMock := mocks.NewSellerRepositoryMock()
+		sellerRepositoryMock.On("Restore", ctx, 1).Return(nil)
+		sellerRepositoryMock.On("Get", ctx, 1).Return(&domain.Seller{ID: 1}, nil)
+
+		service := seller.NewService(sellerRepositoryMock)
+		restored, err := service.Restore(ctx, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &domain.Seller{ID: 1}, restored)
+	})
+
+	t.Run("restore_not_deleted", func(t *testing.T) {
+
+		ctx := context.TODO()
+
+		sellerRepositoryMock := mocks.NewSellerRepositoryMock()
+		sellerRepositoryMock.On("Restore", ctx, 1).Return(seller.ErrNotFound)
+
+		service := seller.NewService(sellerRepositoryMock)
+		restored, err := service.Restore(ctx, 1)
+
+		assert.Nil(t, restored)
+		assert.Equal(t, seller.ErrNotFound, err)
+		sellerRepositoryMock.AssertNotCalled(t, "Get", ctx, 1)
+	})
 }
 
 func TestUpdate(t *testing.T) {
@@ -231,7 +350,7 @@ func TestUpdate(t *testing.T) {
 		sellerRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.Seller")).Return(nil)
 
 		service := seller.NewService(sellerRepositoryMock)
-		updatedSeller, err := service.Update(&ctx, 1, updateSellerRequest)
+		updatedSeller, err := service.Update(ctx, 1, updateSellerRequest)
 
 		assert.Equal(t, *updatedSeller, *expectedSeller)
 		assert.Nil(t, err)
@@ -259,7 +378,7 @@ func TestUpdate(t *testing.T) {
 		sellerRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(nil, seller.ErrNotFound)
 
 		service := seller.NewService(sellerRepositoryMock)
-		_, err := service.Update(&ctx, 1, updateSellerRequest)
+		_, err := service.Update(ctx, 1, updateSellerRequest)
 
 		assert.Equal(t, seller.ErrNotFound, err)
 	})
@@ -0,0 +1,172 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+)
+
+type UserRepository interface {
+	GetAll(ctx context.Context) ([]domain.User, error)
+	Get(ctx context.Context, id int) (domain.User, error)
+	GetByUsername(ctx context.Context, username string) (domain.User, error)
+	ExistsByUsername(ctx context.Context, username string) bool
+	Save(ctx context.Context, user domain.User) (int, error)
+	Delete(ctx context.Context, id int) error
+}
+
+const (
+	selectUsers          = "SELECT users.id, users.username, users.passoword, COALESCE(GROUP_CONCAT(roles.rol_name ORDER BY roles.rol_name), '') FROM users LEFT JOIN user_rol ON user_rol.usuario_id = users.id LEFT JOIN roles ON roles.id = user_rol.rol_id"
+	groupUsers           = " GROUP BY users.id, users.username, users.passoword"
+	GetAllUsers          = selectUsers + groupUsers
+	GetUserByID          = selectUsers + " WHERE users.id = ?" + groupUsers
+	GetUserByUsername    = selectUsers + " WHERE users.username = ?" + groupUsers
+	ExistsUserByUsername = "SELECT id FROM users WHERE username = ?"
+	SaveUser             = "INSERT INTO users(passoword, username) VALUES (?, ?)"
+	SaveUserRole         = "INSERT INTO user_rol(usuario_id, rol_id) SELECT ?, roles.id FROM roles WHERE roles.rol_name = ?"
+	DeleteUserRoles      = "DELETE FROM user_rol WHERE usuario_id = ?"
+	DeleteUserByID       = "DELETE FROM users WHERE id = ?"
+)
+
+type userRepository struct {
+	db *sql.DB
+}
+
+func NewUserRepository(db *sql.DB) UserRepository {
+	return &userRepository{
+		db: db,
+	}
+}
+
+func (r *userRepository) GetAll(ctx context.Context) ([]domain.User, error) {
+	users := make([]domain.User, 0)
+
+	rows, err := database.Conn(ctx, r.db).QueryContext(ctx, GetAllUsers)
+	if err != nil {
+		return users, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return users, err
+		}
+
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+func (r *userRepository) Get(ctx context.Context, id int) (domain.User, error) {
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, GetUserByID, id)
+	return scanUserRow(row)
+}
+
+func (r *userRepository) GetByUsername(ctx context.Context, username string) (domain.User, error) {
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, GetUserByUsername, username)
+	return scanUserRow(row)
+}
+
+func (r *userRepository) ExistsByUsername(ctx context.Context, username string) bool {
+	row := database.Conn(ctx, r.db).QueryRowContext(ctx, ExistsUserByUsername, username)
+	var foundId int
+	err := row.Scan(&foundId)
+	return err == nil
+}
+
+// Save inserts the user and links it to each of its roles, failing with ErrInvalidRole when one
+// of the role names is not on the roles table. It must run in a unit of work for the links to be
+// rolled back with the user.
+func (r *userRepository) Save(ctx context.Context, user domain.User) (int, error) {
+	conn := database.Conn(ctx, r.db)
+
+	res, err := conn.ExecContext(ctx, SaveUser, &user.Password, &user.Username)
+	if err != nil {
+		return 0, database.TranslateError(err, "user")
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	for _, role := range user.Roles {
+		res, err := conn.ExecContext(ctx, SaveUserRole, id, role)
+		if err != nil {
+			return 0, database.TranslateError(err, "user")
+		}
+
+		affect, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+
+		if affect < 1 {
+			return 0, errors.ErrInvalidRole
+		}
+	}
+
+	return int(id), nil
+}
+
+// Delete removes the user and its role links. It must run in a unit of work for both to be removed together.
+func (r *userRepository) Delete(ctx context.Context, id int) error {
+	conn := database.Conn(ctx, r.db)
+
+	if _, err := conn.ExecContext(ctx, DeleteUserRoles, id); err != nil {
+		return database.TranslateError(err, "user")
+	}
+
+	res, err := conn.ExecContext(ctx, DeleteUserByID, id)
+	if err != nil {
+		return database.TranslateError(err, "user")
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affect < 1 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanUser(row scanner) (domain.User, error) {
+	user := domain.User{}
+	var roles string
+	if err := row.Scan(&user.ID, &user.Username, &user.Password, &roles); err != nil {
+		return domain.User{}, err
+	}
+
+	user.Roles = make([]string, 0)
+	if roles != "" {
+		user.Roles = strings.Split(roles, ",")
+	}
+
+	return user, nil
+}
+
+func scanUserRow(row *sql.Row) (domain.User, error) {
+	user, err := scanUser(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.User{}, ErrNotFound
+		}
+		return domain.User{}, err
+	}
+
+	return user, nil
+}
@@ -0,0 +1,241 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_userRepository_GetAll(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	validUsersSerialized, _ := os.ReadFile("../../test/resources/valid_users.json")
+	var validUsers []domain.User
+	if err := json.Unmarshal(validUsersSerialized, &validUsers); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		want    []domain.User
+		wantErr bool
+	}{
+		{
+			name:    "Successfully get users",
+			want:    validUsers,
+			wantErr: false,
+		},
+		{
+			name:    "Error getting users",
+			want:    []domain.User{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewUserRepository(db)
+
+			rows := sqlmock.NewRows([]string{"id", "username", "passoword", "roles"})
+			for _, user := range validUsers {
+				rows.AddRow(user.ID, user.Username, user.Password, strings.Join(user.Roles, ","))
+			}
+			if tt.wantErr {
+				rows.RowError(0, sql.ErrNoRows)
+			}
+
+			mock.ExpectQuery(regexp.QuoteMeta(GetAllUsers)).WillReturnRows(rows)
+
+			got, err := r.GetAll(ctx)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
+
+func Test_userRepository_GetByUsername(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	userSerialized, _ := os.ReadFile("../../test/resources/valid_user.json")
+	var validUser domain.User
+	if err := json.Unmarshal(userSerialized, &validUser); err != nil {
+		t.Fatal(err)
+	}
+	validUser.Password = "$2a$10$hash"
+
+	userWithoutRoles := validUser
+	userWithoutRoles.Roles = []string{}
+
+	tests := []struct {
+		name     string
+		roles    string
+		queryErr error
+		want     domain.User
+		wantErr  error
+	}{
+		{
+			name:    "Successfully get user",
+			roles:   "admin",
+			want:    validUser,
+			wantErr: nil,
+		},
+		{
+			name:    "Successfully get user without roles",
+			roles:   "",
+			want:    userWithoutRoles,
+			wantErr: nil,
+		},
+		{
+			name:     "Error nonexistent user",
+			queryErr: sql.ErrNoRows,
+			want:     domain.User{},
+			wantErr:  ErrNotFound,
+		},
+		{
+			name:     "Error querying user",
+			queryErr: assert.AnError,
+			want:     domain.User{},
+			wantErr:  assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewUserRepository(db)
+
+			expected := mock.ExpectQuery(regexp.QuoteMeta(GetUserByUsername)).WithArgs(validUser.Username)
+			if tt.queryErr != nil {
+				expected.WillReturnError(tt.queryErr)
+			} else {
+				rows := sqlmock.NewRows([]string{"id", "username", "passoword", "roles"})
+				rows.AddRow(tt.want.ID, tt.want.Username, tt.want.Password, tt.roles)
+				expected.WillReturnRows(rows)
+			}
+
+			got, err := r.GetByUsername(ctx, validUser.Username)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_userRepository_Save(t *testing.T) {
+	user := domain.User{
+		Username: "user3",
+		Password: "$2a$10$hash",
+		Roles:    []string{"analyst", "operator"},
+	}
+
+	tests := []struct {
+		name         string
+		roleAffected int64
+		want         int
+		wantErr      error
+	}{
+		{
+			name:         "Successfully save user with roles",
+			roleAffected: 1,
+			want:         3,
+			wantErr:      nil,
+		},
+		{
+			name:         "Error when a role does not exist",
+			roleAffected: 0,
+			want:         0,
+			wantErr:      errors.ErrInvalidRole,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			r := NewUserRepository(db)
+
+			mock.ExpectExec(regexp.QuoteMeta(SaveUser)).
+				WithArgs(user.Password, user.Username).
+				WillReturnResult(sqlmock.NewResult(3, 1))
+			mock.ExpectExec(regexp.QuoteMeta(SaveUserRole)).
+				WithArgs(3, "analyst").
+				WillReturnResult(sqlmock.NewResult(0, tt.roleAffected))
+			if tt.wantErr == nil {
+				mock.ExpectExec(regexp.QuoteMeta(SaveUserRole)).
+					WithArgs(3, "operator").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			got, err := r.Save(context.TODO(), user)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_userRepository_Save_DuplicateUsername(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	r := NewUserRepository(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(SaveUser)).
+		WithArgs("$2a$10$hash", "user1").
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'user1' for key 'users.username_UNIQUE'"})
+
+	got, err := r.Save(context.TODO(), domain.User{Username: "user1", Password: "$2a$10$hash"})
+
+	assert.Equal(t, 0, got)
+	assert.ErrorIs(t, err, errors.ErrConflict)
+	assert.Equal(t, "username", err.(*errors.Error).Field)
+}
+
+func Test_userRepository_Delete(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr error
+	}{
+		{
+			name:    "Successfully delete user",
+			wantErr: nil,
+		},
+		{
+			name:    "No user to delete",
+			wantErr: ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			r := NewUserRepository(db)
+
+			rowsAffected := int64(1)
+			if tt.wantErr != nil {
+				rowsAffected = 0
+			}
+
+			mock.ExpectExec(regexp.QuoteMeta(DeleteUserRoles)).
+				WithArgs(1).
+				WillReturnResult(sqlmock.NewResult(0, rowsAffected))
+			mock.ExpectExec(regexp.QuoteMeta(DeleteUserByID)).
+				WithArgs(1).
+				WillReturnResult(sqlmock.NewResult(0, rowsAffected))
+
+			err := r.Delete(context.TODO(), 1)
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
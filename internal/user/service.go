@@ -0,0 +1,123 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/user"
+	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/auth"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Errors
+var (
+	ErrNotFound = errors2.NotFound("user")
+	ErrConflict = errors2.Conflict("user", "username")
+	// ErrPasswordTooLong is returned for passwords over the 72 bytes bcrypt hashes, the request binding
+	// counts characters so a multi-byte password can still get here.
+	ErrPasswordTooLong = errors2.Validation("user", "password", "password must be at most 72 bytes")
+)
+
+type UserService interface {
+	Get(ctx context.Context, id int) (domain.User, error)
+	GetAll(ctx context.Context) ([]domain.User, error)
+	Create(ctx context.Context, user domain.User) (domain.User, error)
+	Delete(ctx context.Context, id int) error
+	Login(ctx context.Context, loginRequest dtos.LoginRequestDTO) (dtos.LoginResponseDTO, error)
+}
+
+type userService struct {
+	userRepository UserRepository
+	tokens         *auth.JWT
+	unitOfWork     database.UnitOfWork
+}
+
+func NewUserService(r UserRepository, tokens *auth.JWT, unitOfWork database.UnitOfWork) UserService {
+	return &userService{
+		userRepository: r,
+		tokens:         tokens,
+		unitOfWork:     unitOfWork,
+	}
+}
+
+func (service *userService) Get(ctx context.Context, id int) (domain.User, error) {
+	user, err := service.userRepository.Get(ctx, id)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	return user, nil
+}
+
+func (service *userService) GetAll(ctx context.Context) ([]domain.User, error) {
+	users, err := service.userRepository.GetAll(ctx)
+	if err != nil {
+		return []domain.User{}, err
+	}
+
+	return users, nil
+}
+
+// Create stores the user with a bcrypt hash of its password; the plaintext password is never persisted.
+func (service *userService) Create(ctx context.Context, user domain.User) (domain.User, error) {
+	if service.userRepository.ExistsByUsername(ctx, user.Username) {
+		return domain.User{}, ErrConflict
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.DefaultCost)
+	if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+		return domain.User{}, ErrPasswordTooLong
+	}
+	if err != nil {
+		return domain.User{}, err
+	}
+	user.Password = string(hash)
+
+	// The user and its role links are saved together, a role that does not exist leaves no user behind
+	err = service.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		user.ID, err = service.userRepository.Save(ctx, user)
+		return err
+	})
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	user.Password = ""
+
+	return user, nil
+}
+
+func (service *userService) Delete(ctx context.Context, id int) error {
+	return service.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		return service.userRepository.Delete(ctx, id)
+	})
+}
+
+// Login checks the credentials against the stored hash and issues a signed token carrying the user roles.
+// Unknown users and wrong passwords both fail with ErrUnauthorized.
+func (service *userService) Login(ctx context.Context, loginRequest dtos.LoginRequestDTO) (dtos.LoginResponseDTO, error) {
+	user, err := service.userRepository.GetByUsername(ctx, loginRequest.Username)
+	if err != nil {
+		if errors.Is(err, errors2.ErrNotFound) {
+			return dtos.LoginResponseDTO{}, errors2.ErrUnauthorized
+		}
+		return dtos.LoginResponseDTO{}, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(loginRequest.Password)); err != nil {
+		return dtos.LoginResponseDTO{}, errors2.ErrUnauthorized
+	}
+
+	token, expiresAt, err := service.tokens.Sign(user.ID, user.Username, user.Roles)
+	if err != nil {
+		return dtos.LoginResponseDTO{}, err
+	}
+
+	return dtos.LoginResponseDTO{
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
@@ -0,0 +1,180 @@
+package user
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/user"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	database_mocks "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/user/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func Test_userService_Create(t *testing.T) {
+	ctx := context.TODO()
+
+	request := domain.User{
+		Username: "user3",
+		Password: "password3",
+		Roles:    []string{"analyst"},
+	}
+
+	tests := []struct {
+		name                 string
+		expectedExistsResult bool
+		expectedSaveError    error
+		expectedSaveCalls    int
+		want                 domain.User
+		wantErr              error
+	}{
+		{
+			name:              "Successfully create user",
+			expectedSaveCalls: 1,
+			want: domain.User{
+				ID:       3,
+				Username: request.Username,
+				Roles:    request.Roles,
+			},
+			wantErr: nil,
+		},
+		{
+			name:                 "Error username already exists",
+			expectedExistsResult: true,
+			expectedSaveCalls:    0,
+			want:                 domain.User{},
+			wantErr:              ErrConflict,
+		},
+		{
+			name:              "Error invalid role",
+			expectedSaveError: errors.ErrInvalidRole,
+			expectedSaveCalls: 1,
+			want:              domain.User{},
+			wantErr:           errors.ErrInvalidRole,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepositoryMock := mocks.NewMockUserRepository(t)
+			userRepositoryMock.On("ExistsByUsername", ctx, request.Username).Return(tt.expectedExistsResult)
+			userRepositoryMock.On("Save", ctx, mock.MatchedBy(func(user domain.User) bool {
+				return bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.Password)) == nil
+			})).Return(3, tt.expectedSaveError)
+
+			unitOfWork := database_mocks.NewUnitOfWorkMock()
+
+			service := NewUserService(userRepositoryMock, auth.NewJWT([]byte("secret"), time.Hour), unitOfWork)
+			got, err := service.Create(ctx, request)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+
+			userRepositoryMock.AssertNumberOfCalls(t, "Save", tt.expectedSaveCalls)
+			assert.Equal(t, tt.expectedSaveCalls, unitOfWork.Calls)
+		})
+	}
+}
+
+func Test_userService_Create_PasswordTooLong(t *testing.T) {
+	ctx := context.TODO()
+
+	userRepositoryMock := mocks.NewMockUserRepository(t)
+	userRepositoryMock.On("ExistsByUsername", ctx, "user3").Return(false)
+
+	service := NewUserService(userRepositoryMock, auth.NewJWT([]byte("secret"), time.Hour), database_mocks.NewUnitOfWorkMock())
+	_, err := service.Create(ctx, domain.User{
+		Username: "user3",
+		Password: strings.Repeat("é", 40),
+		Roles:    []string{"analyst"},
+	})
+
+	assert.Equal(t, ErrPasswordTooLong, err)
+	userRepositoryMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func Test_userService_Login(t *testing.T) {
+	ctx := context.TODO()
+	tokens := auth.NewJWT([]byte("secret"), time.Hour)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password1"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	storedUser := domain.User{
+		ID:       1,
+		Username: "user1",
+		Password: string(hash),
+		Roles:    []string{"admin"},
+	}
+
+	tests := []struct {
+		name             string
+		password         string
+		expectedGetUser  domain.User
+		expectedGetError error
+		wantErr          error
+	}{
+		{
+			name:            "Successfully login",
+			password:        "password1",
+			expectedGetUser: storedUser,
+			wantErr:         nil,
+		},
+		{
+			name:            "Error wrong password",
+			password:        "password2",
+			expectedGetUser: storedUser,
+			wantErr:         errors.ErrUnauthorized,
+		},
+		{
+			name:             "Error unknown user",
+			password:         "password1",
+			expectedGetError: ErrNotFound,
+			wantErr:          errors.ErrUnauthorized,
+		},
+		{
+			name:             "Error querying user",
+			password:         "password1",
+			expectedGetError: assert.AnError,
+			wantErr:          assert.AnError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepositoryMock := mocks.NewMockUserRepository(t)
+			userRepositoryMock.On("GetByUsername", ctx, "user1").Return(tt.expectedGetUser, tt.expectedGetError)
+
+			service := NewUserService(userRepositoryMock, tokens, database_mocks.NewUnitOfWorkMock())
+			got, err := service.Login(ctx, dtos.LoginRequestDTO{Username: "user1", Password: tt.password})
+
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantErr != nil {
+				assert.Equal(t, dtos.LoginResponseDTO{}, got)
+				return
+			}
+
+			claims, err := tokens.Parse(got.Token)
+			assert.NoError(t, err)
+			assert.Equal(t, storedUser.ID, claims.Subject)
+			assert.Equal(t, storedUser.Roles, claims.Roles)
+		})
+	}
+}
+
+func Test_userService_Delete(t *testing.T) {
+	ctx := context.TODO()
+
+	userRepositoryMock := mocks.NewMockUserRepository(t)
+	userRepositoryMock.On("Delete", ctx, 1).Return(ErrNotFound)
+
+	service := NewUserService(userRepositoryMock, auth.NewJWT([]byte("secret"), time.Hour), database_mocks.NewUnitOfWorkMock())
+	err := service.Delete(ctx, 1)
+
+	assert.Equal(t, ErrNotFound, err)
+}
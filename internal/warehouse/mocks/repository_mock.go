@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -21,6 +22,23 @@ func (repository *WarehouseRepositoryMock) GetAll(ctx context.Context) ([]domain
 	return args.Get(0).([]domain.Warehouse), args.Error(1)
 }
 
+func (repository *WarehouseRepositoryMock) GetPage(ctx context.Context, options listing.Options) ([]domain.Warehouse, int, error) {
+	args := repository.Called(ctx, options)
+
+	return args.Get(0).([]domain.Warehouse), args.Int(1), args.Error(2)
+}
+
+func (repository *WarehouseRepositoryMock) Each(ctx context.Context, options listing.Options, fn func(domain.Warehouse) error) error {
+	args := repository.Called(ctx, options)
+
+	for _, warehouse := range args.Get(0).([]domain.Warehouse) {
+		if err := fn(warehouse); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
 func (repository *WarehouseRepositoryMock) Get(ctx context.Context, id int) (domain.Warehouse, error) {
 	args := repository.Called(ctx, id)
 
@@ -51,3 +69,21 @@ func (repository *WarehouseRepositoryMock) Delete(ctx context.Context, id int) e
 	return args.Error(0)
 
 }
+
+func (repository *WarehouseRepositoryMock) Restore(ctx context.Context, id int) error {
+	args := repository.Called(ctx, id)
+
+	return args.Error(0)
+}
+
+func (repository *WarehouseRepositoryMock) Dependents(ctx context.Context, id int) (map[string]int, error) {
+	args := repository.Called(ctx, id)
+
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+func (repository *WarehouseRepositoryMock) GetSectionsInventory(ctx context.Context, id int) ([]domain.SectionInventory, error) {
+	args := repository.Called(ctx, id)
+
+	return args.Get(0).([]domain.SectionInventory), args.Error(1)
+}
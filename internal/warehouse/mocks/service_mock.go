@@ -5,6 +5,7 @@ import (
 
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/warehousesdto"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -12,38 +13,91 @@ type WarehouseServiceMock struct {
 	mock.Mock
 }
 
-func (service *WarehouseServiceMock) GetOne(ctx *context.Context, id int) (*domain.Warehouse, error) {
+func (service *WarehouseServiceMock) GetOne(ctx context.Context, id int) (*domain.Warehouse, error) {
 	args := service.Called(ctx, id)
 
 	return args.Get(0).(*domain.Warehouse), args.Error(1)
 }
 
-func (service *WarehouseServiceMock) Exists(ctx *context.Context, warehouseCode string) bool {
+func (service *WarehouseServiceMock) Exists(ctx context.Context, warehouseCode string) bool {
 	args := service.Called(ctx, warehouseCode)
 
 	return args.Get(0).(bool)
 }
 
-func (service *WarehouseServiceMock) GetAll(ctx *context.Context) (*[]domain.Warehouse, error) {
+func (service *WarehouseServiceMock) GetAll(ctx context.Context) (*[]domain.Warehouse, error) {
 	args := service.Called(ctx)
 
 	return args.Get(0).(*[]domain.Warehouse), args.Error(1)
 }
 
-func (service *WarehouseServiceMock) Create(ctx *context.Context, warehouse dtos.WarehouseRequestDTO) (*domain.Warehouse, error) {
+func (service *WarehouseServiceMock) GetPage(ctx context.Context, options listing.Options) (*[]domain.Warehouse, int, error) {
+	args := service.Called(ctx, options)
+
+	return args.Get(0).(*[]domain.Warehouse), args.Int(1), args.Error(2)
+}
+
+func (service *WarehouseServiceMock) Each(ctx context.Context, options listing.Options, fn func(domain.Warehouse) error) error {
+	args := service.Called(ctx, options)
+
+	for _, warehouse := range args.Get(0).([]domain.Warehouse) {
+		if err := fn(warehouse); err != nil {
+			return err
+		}
+	}
+	return args.Error(1)
+}
+
+func (service *WarehouseServiceMock) Create(ctx context.Context, warehouse dtos.WarehouseRequestDTO) (*domain.Warehouse, error) {
 	args := service.Called(ctx, warehouse)
 
 	return args.Get(0).(*domain.Warehouse), args.Error(1)
 }
 
-func (service *WarehouseServiceMock) Update(ctx *context.Context, id int, updateWarehouseRequest dtos.WarehouseRequestDTO) (*domain.Warehouse, error) {
+func (service *WarehouseServiceMock) Update(ctx context.Context, id int, updateWarehouseRequest dtos.WarehouseRequestDTO) (*domain.Warehouse, error) {
 	args := service.Called(ctx, updateWarehouseRequest)
 
 	return args.Get(0).(*domain.Warehouse), args.Error(1)
 }
 
-func (service *WarehouseServiceMock) Delete(ctx *context.Context, id int) error {
+func (service *WarehouseServiceMock) Delete(ctx context.Context, id int) error {
 	args := service.Called(ctx, id)
 
 	return args.Error(0)
 }
+
+func (service *WarehouseServiceMock) Restore(ctx context.Context, id int) (*domain.Warehouse, error) {
+	args := service.Called(ctx, id)
+
+	return args.Get(0).(*domain.Warehouse), args.Error(1)
+}
+
+func (service *WarehouseServiceMock) DeletePreview(ctx context.Context, id int) (*domain.DeletePreview, error) {
+	args := service.Called(ctx, id)
+
+	return args.Get(0).(*domain.DeletePreview), args.Error(1)
+}
+
+func (service *WarehouseServiceMock) GetInventory(ctx context.Context, id int) (*domain.WarehouseInventory, error) {
+	args := service.Called(ctx, id)
+
+	return args.Get(0).(*domain.WarehouseInventory), args.Error(1)
+}
+
+func (service *WarehouseServiceMock) GetEmployees(ctx context.Context, id int, options listing.Options) (*[]domain.Employee, int, error) {
+	args := service.Called(ctx, id, options)
+
+	return args.Get(0).(*[]domain.Employee), args.Int(1), args.Error(2)
+}
+
+func (service *WarehouseServiceMock) GetSections(ctx context.Context, id int, options listing.Options) (*[]domain.Section, int, error) {
+	args := service.Called(ctx, id, options)
+
+	return args.Get(0).(*[]domain.Section), args.Int(1), args.Error(2)
+}
+
+func (service *WarehouseServiceMock) GetSummary(ctx context.Context, id int, days int) (*domain.WarehouseSummary, error) {
+	args := service.Called(ctx, id, days)
+
+	return args.Get(0).(*domain.WarehouseSummary), args.Error(1)
+}
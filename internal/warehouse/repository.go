@@ -4,17 +4,42 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 )
 
 // Repository encapsulates the storage of a warehouses.
 type Repository interface {
 	GetAll(ctx context.Context) ([]domain.Warehouse, error)
+	GetPage(ctx context.Context, options listing.Options) ([]domain.Warehouse, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.Warehouse) error) error
 	Get(ctx context.Context, id int) (domain.Warehouse, error)
 	Exists(ctx context.Context, warehouseCode string) bool
 	Save(ctx context.Context, w domain.Warehouse) (int, error)
 	Update(ctx context.Context, w domain.Warehouse) error
 	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) error
+	Dependents(ctx context.Context, id int) (map[string]int, error)
+	GetSectionsInventory(ctx context.Context, id int) ([]domain.SectionInventory, error)
+}
+
+// Fields are the warehouse fields list requests can sort and filter by.
+var Fields = listing.Fields{
+	"id":                  "id",
+	"address":             "address",
+	"telephone":           "telephone",
+	"warehouse_code":      "warehouse_code",
+	"minimum_capacity":    "minimum_capacity",
+	"minimum_temperature": "minimum_temperature",
+	"locality_id":         "locality_id",
+}
+
+// dependents are the columns referencing a warehouse, which block its deletion.
+// Inbound and purchase orders are history and keep pointing to the soft-deleted warehouse.
+var dependents = []database.Dependent{
+	{Table: "sections", Column: "warehouse_id"},
+	{Table: "employees", Column: "warehouse_id", SoftDeleted: true},
 }
 
 type repository struct {
@@ -28,8 +53,8 @@ func NewRepository(db *sql.DB) Repository {
 }
 
 func (r *repository) GetAll(ctx context.Context) ([]domain.Warehouse, error) {
-	query := "SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature FROM warehouses"
-	rows, err := r.db.Query(query)
+	query := "SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature, locality_id, deleted_at FROM warehouses WHERE deleted_at IS NULL"
+	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -38,20 +63,59 @@ func (r *repository) GetAll(ctx context.Context) ([]domain.Warehouse, error) {
 
 	for rows.Next() {
 		w := domain.Warehouse{}
-		_ = rows.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature)
+		_ = rows.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, &w.LocalityID, &w.DeletedAt)
 		warehouses = append(warehouses, w)
 	}
 
 	return warehouses, nil
 }
 
+func (r *repository) GetPage(ctx context.Context, options listing.Options) ([]domain.Warehouse, int, error) {
+	var total int
+	query, args := options.CountQuery("SELECT COUNT(*) FROM warehouses")
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	warehouses := make([]domain.Warehouse, 0)
+	err := r.Each(ctx, options, func(w domain.Warehouse) error {
+		warehouses = append(warehouses, w)
+		return nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return warehouses, total, nil
+}
+
+func (r *repository) Each(ctx context.Context, options listing.Options, fn func(domain.Warehouse) error) error {
+	query, args := options.Query("SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature, locality_id, deleted_at FROM warehouses")
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		w := domain.Warehouse{}
+		if err := rows.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, &w.LocalityID, &w.DeletedAt); err != nil {
+			return err
+		}
+		if err := fn(w); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
 func (r *repository) Get(ctx context.Context, id int) (domain.Warehouse, error) {
-	query := "SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature FROM warehouses WHERE id=?"
-	row := r.db.QueryRow(query, id)
+	query := "SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature, locality_id, deleted_at FROM warehouses WHERE id=? AND deleted_at IS NULL"
+	row := r.db.QueryRowContext(ctx, query, id)
 	w := domain.Warehouse{}
-	err := row.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature)
+	err := row.Scan(&w.ID, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, &w.LocalityID, &w.DeletedAt)
 	if err != nil {
-		return domain.Warehouse{}, err
+		return domain.Warehouse{}, database.TranslateError(err, "warehouse")
 	}
 
 	return w, nil
@@ -59,21 +123,21 @@ func (r *repository) Get(ctx context.Context, id int) (domain.Warehouse, error)
 
 func (r *repository) Exists(ctx context.Context, warehouseCode string) bool {
 	query := "SELECT warehouse_code FROM warehouses WHERE warehouse_code=?"
-	row := r.db.QueryRow(query, warehouseCode)
+	row := r.db.QueryRowContext(ctx, query, warehouseCode)
 	err := row.Scan(&warehouseCode)
 	return err == nil
 }
 
 func (r *repository) Save(ctx context.Context, w domain.Warehouse) (int, error) {
-	query := "INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature) VALUES (?, ?, ?, ?, ?)"
-	stmt, err := r.db.Prepare(query)
+	query := "INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature, locality_id) VALUES (?, ?, ?, ?, ?, ?)"
+	stmt, err := r.db.PrepareContext(ctx, query)
 	if err != nil {
 		return 0, err
 	}
 
-	res, err := stmt.Exec(&w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature)
+	res, err := stmt.ExecContext(ctx, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, &w.LocalityID)
 	if err != nil {
-		return 0, err
+		return 0, database.TranslateError(err, "warehouse")
 	}
 
 	id, err := res.LastInsertId()
@@ -85,15 +149,15 @@ func (r *repository) Save(ctx context.Context, w domain.Warehouse) (int, error)
 }
 
 func (r *repository) Update(ctx context.Context, w domain.Warehouse) error {
-	query := "UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=? WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	query := "UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=?, locality_id=? WHERE id=?"
+	stmt, err := r.db.PrepareContext(ctx, query)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(&w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, &w.ID)
+	res, err := stmt.ExecContext(ctx, &w.Address, &w.Telephone, &w.WarehouseCode, &w.MinimumCapacity, &w.MinimumTemperature, &w.LocalityID, &w.ID)
 	if err != nil {
-		return err
+		return database.TranslateError(err, "warehouse")
 	}
 
 	_, err = res.RowsAffected()
@@ -105,17 +169,41 @@ func (r *repository) Update(ctx context.Context, w domain.Warehouse) error {
 }
 
 func (r *repository) Delete(ctx context.Context, id int) error {
-	query := "DELETE FROM warehouses WHERE id=?"
-	stmt, err := r.db.Prepare(query)
+	query := "UPDATE warehouses SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"
+	stmt, err := r.db.PrepareContext(ctx, query)
 	if err != nil {
 		return err
 	}
 
-	res, err := stmt.Exec(id)
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return database.TranslateError(err, "warehouse")
+	}
+
+	affect, err := res.RowsAffected()
 	if err != nil {
 		return err
 	}
 
+	if affect < 1 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *repository) Restore(ctx context.Context, id int) error {
+	query := "UPDATE warehouses SET deleted_at=NULL WHERE id=? AND deleted_at IS NOT NULL"
+	stmt, err := r.db.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	res, err := stmt.ExecContext(ctx, id)
+	if err != nil {
+		return database.TranslateError(err, "warehouse")
+	}
+
 	affect, err := res.RowsAffected()
 	if err != nil {
 		return err
@@ -127,3 +215,47 @@ func (r *repository) Delete(ctx context.Context, id int) error {
 
 	return nil
 }
+
+func (r *repository) Dependents(ctx context.Context, id int) (map[string]int, error) {
+	return database.CountDependents(ctx, database.Conn(ctx, r.db), id, dependents)
+}
+
+// GetSectionsInventory lists the sections of the warehouse with the batches they hold, first due first.
+func (r *repository) GetSectionsInventory(ctx context.Context, id int) ([]domain.SectionInventory, error) {
+	query := "SELECT s.id, s.section_number, s.product_type_id, s.current_capacity, s.maximum_capacity, pb.id, pb.batch_number, pb.product_id, pb.current_quantity, pb.due_date FROM sections s LEFT JOIN product_batches pb ON pb.section_id = s.id WHERE s.warehouse_id=? ORDER BY s.id, pb.due_date, pb.id"
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sections := make([]domain.SectionInventory, 0)
+	for rows.Next() {
+		s := domain.SectionInventory{}
+		var batchID, batchNumber, productID, currentQuantity sql.NullInt64
+		var dueDate sql.NullString
+		if err := rows.Scan(&s.SectionID, &s.SectionNumber, &s.ProductTypeID, &s.CurrentCapacity, &s.MaximumCapacity, &batchID, &batchNumber, &productID, &currentQuantity, &dueDate); err != nil {
+			return nil, err
+		}
+
+		if len(sections) == 0 || sections[len(sections)-1].SectionID != s.SectionID {
+			s.Batches = make([]domain.InventoryBatch, 0)
+			sections = append(sections, s)
+		}
+		if batchID.Valid {
+			section := &sections[len(sections)-1]
+			section.Batches = append(section.Batches, domain.InventoryBatch{
+				ID:              int(batchID.Int64),
+				BatchNumber:     int(batchNumber.Int64),
+				ProductID:       int(productID.Int64),
+				CurrentQuantity: int(currentQuantity.Int64),
+				DueDate:         dueDate.String,
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sections, nil
+}
@@ -3,12 +3,14 @@ package warehouse_test
 import (
 	"context"
 	"database/sql"
+	"net/url"
 	"regexp"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/warehouse"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -33,11 +35,11 @@ func TestRepositoryGet(t *testing.T) {
 
 		r := warehouse.NewRepository(fields{db}.db)
 
-		rows := sqlmock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature"}).
+		rows := sqlmock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature", "locality_id", "deleted_at"}).
 			AddRow(expectedWarehouse.ID, expectedWarehouse.Address, expectedWarehouse.Telephone, expectedWarehouse.WarehouseCode,
-				expectedWarehouse.MinimumCapacity, expectedWarehouse.MinimumTemperature)
+				expectedWarehouse.MinimumCapacity, expectedWarehouse.MinimumTemperature, expectedWarehouse.LocalityID, nil)
 
-		mock.ExpectQuery("SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature FROM warehouses WHERE id=?").
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature, locality_id, deleted_at FROM warehouses WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(expectedWarehouse.ID).
 			WillReturnRows(rows)
 
@@ -60,11 +62,11 @@ func TestRepositoryGet(t *testing.T) {
 
 		r := warehouse.NewRepository(fields{db}.db)
 
-		rows := sqlmock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature"}).
+		rows := sqlmock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature", "locality_id", "deleted_at"}).
 			AddRow(expectedWarehouse.ID, expectedWarehouse.Address, expectedWarehouse.Telephone, expectedWarehouse.WarehouseCode,
-				expectedWarehouse.MinimumCapacity, expectedWarehouse.MinimumTemperature)
+				expectedWarehouse.MinimumCapacity, expectedWarehouse.MinimumTemperature, expectedWarehouse.LocalityID, nil)
 
-		mock.ExpectQuery("SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature FROM warehouses WHERE id=?").
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature, locality_id, deleted_at FROM warehouses WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(expectedWarehouse.ID).
 			WillReturnRows(rows)
 
@@ -106,13 +108,13 @@ func TestRepositoryGetAll(t *testing.T) {
 
 		r := warehouse.NewRepository(fields{db}.db)
 
-		rows := sqlmock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature"})
+		rows := sqlmock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature", "locality_id", "deleted_at"})
 
 		for _, expectedWarehouse := range expectedWarehouses {
 			rows.AddRow(expectedWarehouse.ID, expectedWarehouse.Address, expectedWarehouse.Telephone, expectedWarehouse.WarehouseCode,
-				expectedWarehouse.MinimumCapacity, expectedWarehouse.MinimumTemperature)
+				expectedWarehouse.MinimumCapacity, expectedWarehouse.MinimumTemperature, expectedWarehouse.LocalityID, nil)
 		}
-		mock.ExpectQuery("SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature FROM warehouses").
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature, locality_id, deleted_at FROM warehouses WHERE deleted_at IS NULL")).
 			WillReturnRows(rows)
 
 		warehousesReceived, err := r.GetAll(ctx)
@@ -125,7 +127,7 @@ func TestRepositoryGetAll(t *testing.T) {
 
 		r := warehouse.NewRepository(fields{db}.db)
 
-		mock.ExpectQuery("SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature FROM warehouses").
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT id, address, telephone, warehouse_code, minimum_capacity, minimum_temperature, locality_id, deleted_at FROM warehouses WHERE deleted_at IS NULL")).
 			WithArgs().
 			WillReturnError(sql.ErrNoRows)
 
@@ -136,6 +138,51 @@ func TestRepositoryGetAll(t *testing.T) {
 	})
 }
 
+func TestRepositoryGetPage(t *testing.T) {
+	options, _ := listing.Parse(url.Values{"sort": {"minimum_temperature"}, "order": {"desc"}, "limit": {"2"}}, warehouse.Fields)
+
+	t.Run("get_page_ok", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+		expectedWarehouses := []domain.Warehouse{
+			{ID: 2, Address: "Rua Teste", Telephone: "11938473125", WarehouseCode: "CX-2281-TCE", MinimumCapacity: 12, MinimumTemperature: 20},
+			{ID: 1, Address: "Rua Teste", Telephone: "11938473125", WarehouseCode: "CX-2281-TCD", MinimumCapacity: 12, MinimumTemperature: 18},
+		}
+
+		r := warehouse.NewRepository(db)
+
+		rows := sqlmock.NewRows([]string{"id", "address", "telephone", "warehouse_code", "minimum_capacity", "minimum_temperature", "locality_id", "deleted_at"})
+		for _, expectedWarehouse := range expectedWarehouses {
+			rows.AddRow(expectedWarehouse.ID, expectedWarehouse.Address, expectedWarehouse.Telephone, expectedWarehouse.WarehouseCode,
+				expectedWarehouse.MinimumCapacity, expectedWarehouse.MinimumTemperature, expectedWarehouse.LocalityID, nil)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM warehouses")).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+		mock.ExpectQuery(regexp.QuoteMeta("FROM warehouses ORDER BY minimum_temperature DESC, id DESC LIMIT ? OFFSET ?")).
+			WithArgs(2, 0).
+			WillReturnRows(rows)
+
+		warehousesReceived, total, err := r.GetPage(context.TODO(), options)
+
+		assert.Nil(t, err)
+		assert.Equal(t, expectedWarehouses, warehousesReceived)
+		assert.Equal(t, 5, total)
+	})
+
+	t.Run("get_page_error", func(t *testing.T) {
+		db, mock, _ := sqlmock.New()
+
+		r := warehouse.NewRepository(db)
+
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM warehouses")).
+			WillReturnError(sql.ErrConnDone)
+
+		warehousesReceived, _, err := r.GetPage(context.TODO(), options)
+
+		assert.Nil(t, warehousesReceived)
+		assert.NotNil(t, err)
+	})
+}
+
 func TestRepositoryExists(t *testing.T) {
 	type fields struct {
 		db *sql.DB
@@ -216,8 +263,8 @@ func TestRepositoryDelete(t *testing.T) {
 		r := warehouse.NewRepository(fields{db}.db)
 
 		rowsAffected := int64(1)
-		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM warehouses WHERE id=?"))
-		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM warehouses WHERE id=?")).
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE warehouses SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE warehouses SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(expectedWarehouse.ID).
 			WillReturnResult(sqlmock.NewResult(1, rowsAffected))
 
@@ -239,8 +286,8 @@ func TestRepositoryDelete(t *testing.T) {
 
 		r := warehouse.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM warehouses WHERE id=?"))
-		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM warehouses WHERE id=?")).
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE warehouses SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE warehouses SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(expectedWarehouse.ID).
 			WillReturnError(sql.ErrNoRows)
 
@@ -261,8 +308,8 @@ func TestRepositoryDelete(t *testing.T) {
 
 		r := warehouse.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM warehouses WHERE id=?"))
-		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM warehouses WHERE id=?")).
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE warehouses SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE warehouses SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(expectedWarehouse.ID).
 			WillReturnResult(sqlmock.NewErrorResult(sql.ErrNoRows))
 
@@ -283,8 +330,8 @@ func TestRepositoryDelete(t *testing.T) {
 
 		r := warehouse.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM warehouses WHERE id=?"))
-		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM warehouses WHERE id=?")).
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE warehouses SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE warehouses SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(expectedWarehouse.ID).
 			WillReturnResult(sqlmock.NewErrorResult(sql.ErrNoRows))
 
@@ -305,8 +352,8 @@ func TestRepositoryDelete(t *testing.T) {
 
 		r := warehouse.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("DELETE FROM warehouses WHERE id=?"))
-		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM warehouses WHERE id=?")).
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE warehouses SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE warehouses SET deleted_at=NOW() WHERE id=? AND deleted_at IS NULL")).
 			WithArgs(1).
 			WillReturnResult(sqlmock.NewResult(1, 0))
 
@@ -316,6 +363,32 @@ func TestRepositoryDelete(t *testing.T) {
 	})
 }
 
+func TestRepositoryRestore(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+	query := regexp.QuoteMeta("UPDATE warehouses SET deleted_at=NULL WHERE id=? AND deleted_at IS NOT NULL")
+
+	t.Run("restore_ok", func(t *testing.T) {
+		r := warehouse.NewRepository(db)
+		mock.ExpectPrepare(query)
+		mock.ExpectExec(query).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := r.Restore(ctx, 1)
+
+		assert.Nil(t, err)
+	})
+
+	t.Run("restore_not_deleted", func(t *testing.T) {
+		r := warehouse.NewRepository(db)
+		mock.ExpectPrepare(query)
+		mock.ExpectExec(query).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := r.Restore(ctx, 1)
+
+		assert.Equal(t, warehouse.ErrNotFound, err)
+	})
+}
+
 func TestRepositoryUpdate(t *testing.T) {
 	type fields struct {
 		db *sql.DB
@@ -337,10 +410,10 @@ func TestRepositoryUpdate(t *testing.T) {
 
 		r := warehouse.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=? WHERE id=?"))
-		mock.ExpectExec(regexp.QuoteMeta("UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=? WHERE id=?")).
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=?, locality_id=? WHERE id=?"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=?, locality_id=? WHERE id=?")).
 			WithArgs(expectedWarehouse.ID, expectedWarehouse.Address, expectedWarehouse.Telephone, expectedWarehouse.WarehouseCode, expectedWarehouse.MinimumCapacity,
-				expectedWarehouse.MinimumTemperature).
+				expectedWarehouse.MinimumTemperature, expectedWarehouse.LocalityID).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		err := r.Update(ctx, expectedWarehouse)
@@ -360,10 +433,10 @@ func TestRepositoryUpdate(t *testing.T) {
 
 		r := warehouse.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=? WHERE id=?"))
-		mock.ExpectExec(regexp.QuoteMeta("UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=? WHERE id=?")).
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=?, locality_id=? WHERE id=?"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=?, locality_id=? WHERE id=?")).
 			WithArgs(expectedWarehouse.ID, expectedWarehouse.Address, expectedWarehouse.Telephone, expectedWarehouse.WarehouseCode, expectedWarehouse.MinimumCapacity,
-				expectedWarehouse.MinimumTemperature).
+				expectedWarehouse.MinimumTemperature, expectedWarehouse.LocalityID).
 			WillReturnError(sql.ErrNoRows)
 
 		err := r.Update(ctx, expectedWarehouse)
@@ -384,10 +457,10 @@ func TestRepositoryUpdate(t *testing.T) {
 
 		r := warehouse.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=? WHERE id=?"))
-		mock.ExpectExec(regexp.QuoteMeta("UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=? WHERE id=?")).
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=?, locality_id=? WHERE id=?"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=?, locality_id=? WHERE id=?")).
 			WithArgs(expectedWarehouse.ID, expectedWarehouse.Address, expectedWarehouse.Telephone, expectedWarehouse.WarehouseCode, expectedWarehouse.MinimumCapacity,
-				expectedWarehouse.MinimumTemperature).
+				expectedWarehouse.MinimumTemperature, expectedWarehouse.LocalityID).
 			WillReturnResult(sqlmock.NewErrorResult(sql.ErrNoRows))
 
 		err := r.Update(ctx, expectedWarehouse)
@@ -408,10 +481,10 @@ func TestRepositoryUpdate(t *testing.T) {
 
 		r := warehouse.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=? WHERE id=?"))
-		mock.ExpectExec(regexp.QuoteMeta("UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=? WHERE id=?")).
+		mock.ExpectPrepare(regexp.QuoteMeta("UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=?, locality_id=? WHERE id=?"))
+		mock.ExpectExec(regexp.QuoteMeta("UPDATE warehouses SET address=?, telephone=?, warehouse_code=?, minimum_capacity=?, minimum_temperature=?, locality_id=? WHERE id=?")).
 			WithArgs(expectedWarehouse.ID, expectedWarehouse.Address, expectedWarehouse.Telephone, expectedWarehouse.WarehouseCode, expectedWarehouse.MinimumCapacity,
-				expectedWarehouse.MinimumTemperature).
+				expectedWarehouse.MinimumTemperature, expectedWarehouse.LocalityID).
 			WillReturnResult(sqlmock.NewErrorResult(sql.ErrNoRows))
 
 		err := r.Update(ctx, expectedWarehouse)
@@ -441,10 +514,10 @@ func TestRepositorySave(t *testing.T) {
 
 		r := warehouse.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature) VALUES (?, ?, ?, ?, ?)"))
-		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature) VALUES (?, ?, ?, ?, ?)")).
+		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature, locality_id) VALUES (?, ?, ?, ?, ?, ?)"))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature, locality_id) VALUES (?, ?, ?, ?, ?, ?)")).
 			WithArgs(expectedWarehouse.Address, expectedWarehouse.Telephone, expectedWarehouse.WarehouseCode, expectedWarehouse.MinimumCapacity,
-				expectedWarehouse.MinimumTemperature).
+				expectedWarehouse.MinimumTemperature, expectedWarehouse.LocalityID).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 
 		id, err := r.Save(ctx, expectedWarehouse)
@@ -465,10 +538,10 @@ func TestRepositorySave(t *testing.T) {
 
 		r := warehouse.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature) VALUES (?, ?, ?, ?, ?)"))
-		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature) VALUES (?, ?, ?, ?, ?)")).
+		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature, locality_id) VALUES (?, ?, ?, ?, ?, ?)"))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature, locality_id) VALUES (?, ?, ?, ?, ?, ?)")).
 			WithArgs(expectedWarehouse.ID, expectedWarehouse.Address, expectedWarehouse.Telephone, expectedWarehouse.WarehouseCode, expectedWarehouse.MinimumCapacity,
-				expectedWarehouse.MinimumTemperature).
+				expectedWarehouse.MinimumTemperature, expectedWarehouse.LocalityID).
 			WillReturnError(sql.ErrNoRows)
 
 		_, err := r.Save(ctx, expectedWarehouse)
@@ -489,10 +562,10 @@ func TestRepositorySave(t *testing.T) {
 
 		r := warehouse.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature) VALUES (?, ?, ?, ?, ?)"))
-		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature) VALUES (?, ?, ?, ?, ?)")).
+		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature, locality_id) VALUES (?, ?, ?, ?, ?, ?)"))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature, locality_id) VALUES (?, ?, ?, ?, ?, ?)")).
 			WithArgs(expectedWarehouse.ID, expectedWarehouse.Address, expectedWarehouse.Telephone, expectedWarehouse.WarehouseCode, expectedWarehouse.MinimumCapacity,
-				expectedWarehouse.MinimumTemperature).
+				expectedWarehouse.MinimumTemperature, expectedWarehouse.LocalityID).
 			WillReturnResult(sqlmock.NewErrorResult(sql.ErrNoRows))
 		_, err := r.Save(ctx, expectedWarehouse)
 
@@ -512,13 +585,76 @@ func TestRepositorySave(t *testing.T) {
 
 		r := warehouse.NewRepository(fields{db}.db)
 
-		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature) VALUES (?, ?, ?, ?, ?)"))
-		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature) VALUES (?, ?, ?, ?, ?)")).
+		mock.ExpectPrepare(regexp.QuoteMeta("INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature, locality_id) VALUES (?, ?, ?, ?, ?, ?)"))
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO warehouses (address, telephone, warehouse_code, minimum_capacity, minimum_temperature, locality_id) VALUES (?, ?, ?, ?, ?, ?)")).
 			WithArgs(expectedWarehouse.ID, expectedWarehouse.Address, expectedWarehouse.Telephone, expectedWarehouse.WarehouseCode, expectedWarehouse.MinimumCapacity,
-				expectedWarehouse.MinimumTemperature).
+				expectedWarehouse.MinimumTemperature, expectedWarehouse.LocalityID).
 			WillReturnResult(sqlmock.NewErrorResult(sql.ErrNoRows))
 		_, err := r.Save(ctx, expectedWarehouse)
 
 		assert.NotNil(t, err)
 	})
 }
+
+func TestRepositoryGetSectionsInventory(t *testing.T) {
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	ctx := context.TODO()
+	query := "SELECT s.id, s.section_number, s.product_type_id, s.current_capacity, s.maximum_capacity, pb.id, pb.batch_number, pb.product_id, pb.current_quantity, pb.due_date FROM sections s LEFT JOIN product_batches pb ON pb.section_id = s.id WHERE s.warehouse_id=? ORDER BY s.id, pb.due_date, pb.id"
+
+	t.Run("inventory_ok", func(t *testing.T) {
+		r := warehouse.NewRepository(db)
+
+		rows := sqlmock.NewRows([]string{"id", "section_number", "product_type_id", "current_capacity", "maximum_capacity", "id", "batch_number", "product_id", "current_quantity", "due_date"}).
+			AddRow(1, 10, 1, 30, 100, 1, 123, 456, 10, "2023-07-10").
+			AddRow(1, 10, 1, 30, 100, 2, 124, 456, 20, "2023-07-11").
+			AddRow(2, 20, 2, 0, 100, nil, nil, nil, nil, nil)
+		mock.ExpectQuery(query).WithArgs(1).WillReturnRows(rows)
+
+		sections, err := r.GetSectionsInventory(ctx, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []domain.SectionInventory{
+			{SectionID: 1, SectionNumber: 10, ProductTypeID: 1, CurrentCapacity: 30, MaximumCapacity: 100, Batches: []domain.InventoryBatch{
+				{ID: 1, BatchNumber: 123, ProductID: 456, CurrentQuantity: 10, DueDate: "2023-07-10"},
+				{ID: 2, BatchNumber: 124, ProductID: 456, CurrentQuantity: 20, DueDate: "2023-07-11"},
+			}},
+			{SectionID: 2, SectionNumber: 20, ProductTypeID: 2, CurrentCapacity: 0, MaximumCapacity: 100, Batches: []domain.InventoryBatch{}},
+		}, sections)
+	})
+
+	t.Run("inventory_error", func(t *testing.T) {
+		r := warehouse.NewRepository(db)
+
+		mock.ExpectQuery(query).WithArgs(1).WillReturnError(sql.ErrConnDone)
+
+		sections, err := r.GetSectionsInventory(ctx, 1)
+
+		assert.Nil(t, sections)
+		assert.Equal(t, sql.ErrConnDone, err)
+	})
+}
+
+func TestRepositoryDependents(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	ctx := context.TODO()
+
+	t.Run("dependents_ok", func(t *testing.T) {
+		r := warehouse.NewRepository(db)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM sections WHERE warehouse_id=?")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM employees WHERE warehouse_id=? AND deleted_at IS NULL")).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		dependents, err := r.Dependents(ctx, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, map[string]int{"sections": 2, "employees": 3}, dependents)
+	})
+	t.Run("dependents_error", func(t *testing.T) {
+		r := warehouse.NewRepository(db)
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM sections WHERE warehouse_id=?")).WithArgs(1).WillReturnError(sql.ErrConnDone)
+
+		dependents, err := r.Dependents(ctx, 1)
+
+		assert.Equal(t, sql.ErrConnDone, err)
+		assert.Nil(t, dependents)
+	})
+}
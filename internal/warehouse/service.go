@@ -2,42 +2,71 @@ package warehouse
 
 import (
 	"context"
-	"errors"
+	"strconv"
 
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/warehousesdto"
+	errors2 "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/errors"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/employee"
+	inbound_order "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/inbound_order"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/locality"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/section"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 )
 
 // Errors
 var (
-	ErrNotFound            = errors.New("warehouses not found")
-	ErrConflict            = errors.New("a warehouses with this warehouse_code already exists")
-	ErrUnprocessableEntity = errors.New("all fields are required")
+	ErrNotFound         = &errors2.Error{Kind: errors2.KindNotFound, Entity: "warehouse", Message: "warehouses not found"}
+	ErrConflict         = &errors2.Error{Kind: errors2.KindConflict, Entity: "warehouse", Field: "warehouse_code", Message: "a warehouses with this warehouse_code already exists"}
+	ErrLocalityNotFound = &errors2.Error{Kind: errors2.KindForeignKey, Entity: "warehouse", Field: "locality_id", Message: "informed locality does not exist in the system"}
 )
 
 type Service interface {
-	Create(c *context.Context, dto dtos.WarehouseRequestDTO) (*domain.Warehouse, error)
-	GetAll(c *context.Context) (*[]domain.Warehouse, error)
-	GetOne(c *context.Context, id int) (*domain.Warehouse, error)
-	Update(c *context.Context, id int, dto dtos.WarehouseRequestDTO) (*domain.Warehouse, error)
-	Delete(c *context.Context, id int) error
+	Create(ctx context.Context, dto dtos.WarehouseRequestDTO) (*domain.Warehouse, error)
+	GetAll(ctx context.Context) (*[]domain.Warehouse, error)
+	GetPage(ctx context.Context, options listing.Options) (*[]domain.Warehouse, int, error)
+	Each(ctx context.Context, options listing.Options, fn func(domain.Warehouse) error) error
+	GetOne(ctx context.Context, id int) (*domain.Warehouse, error)
+	Update(ctx context.Context, id int, dto dtos.WarehouseRequestDTO) (*domain.Warehouse, error)
+	Delete(ctx context.Context, id int) error
+	Restore(ctx context.Context, id int) (*domain.Warehouse, error)
+	DeletePreview(ctx context.Context, id int) (*domain.DeletePreview, error)
+	GetInventory(ctx context.Context, id int) (*domain.WarehouseInventory, error)
+	GetEmployees(ctx context.Context, id int, options listing.Options) (*[]domain.Employee, int, error)
+	GetSections(ctx context.Context, id int, options listing.Options) (*[]domain.Section, int, error)
+	GetSummary(ctx context.Context, id int, days int) (*domain.WarehouseSummary, error)
 }
 
 type service struct {
-	repository Repository
+	repository             Repository
+	localityRepository     locality.LocalityRepository
+	employeeRepository     employee.Repository
+	sectionRepository      section.Repository
+	inboundOrderRepository inbound_order.Repository
 }
 
-func NewService(r Repository) Service {
-	return &service{repository: r}
+func NewService(r Repository, localityRepository locality.LocalityRepository, employeeRepository employee.Repository, sectionRepository section.Repository, inboundOrderRepository inbound_order.Repository) Service {
+	return &service{
+		repository:             r,
+		localityRepository:     localityRepository,
+		employeeRepository:     employeeRepository,
+		sectionRepository:      sectionRepository,
+		inboundOrderRepository: inboundOrderRepository,
+	}
 }
 
-func (s *service) Create(c *context.Context, dto dtos.WarehouseRequestDTO) (*domain.Warehouse, error) {
+func (s *service) Create(ctx context.Context, dto dtos.WarehouseRequestDTO) (*domain.Warehouse, error) {
 
-	exists := s.repository.Exists(*c, dto.WarehouseCode)
+	exists := s.repository.Exists(ctx, dto.WarehouseCode)
 	if exists {
 		return nil, ErrConflict
 	}
 
+	if !s.localityRepository.Exists(ctx, dto.LocalityID) {
+		return nil, ErrLocalityNotFound
+	}
+
 	var formatter domain.Warehouse = domain.Warehouse{
 		ID:                 0,
 		Address:            dto.Address,
@@ -45,9 +74,10 @@ func (s *service) Create(c *context.Context, dto dtos.WarehouseRequestDTO) (*dom
 		WarehouseCode:      dto.WarehouseCode,
 		MinimumCapacity:    dto.MinimumCapacity,
 		MinimumTemperature: dto.MinimumTemperature,
+		LocalityID:         dto.LocalityID,
 	}
 
-	id, err := s.repository.Save(*c, formatter)
+	id, err := s.repository.Save(ctx, formatter)
 
 	if err != nil {
 		return nil, err
@@ -58,8 +88,8 @@ func (s *service) Create(c *context.Context, dto dtos.WarehouseRequestDTO) (*dom
 	return &formatter, nil
 }
 
-func (s *service) GetAll(c *context.Context) (*[]domain.Warehouse, error) {
-	warehouses, err := s.repository.GetAll(*c)
+func (s *service) GetAll(ctx context.Context) (*[]domain.Warehouse, error) {
+	warehouses, err := s.repository.GetAll(ctx)
 
 	if err != nil {
 		return nil, err
@@ -68,23 +98,36 @@ func (s *service) GetAll(c *context.Context) (*[]domain.Warehouse, error) {
 	return &warehouses, nil
 }
 
-func (s *service) GetOne(c *context.Context, id int) (*domain.Warehouse, error) {
-	result, err := s.repository.Get(*c, id)
+func (s *service) GetPage(ctx context.Context, options listing.Options) (*[]domain.Warehouse, int, error) {
+	warehouses, total, err := s.repository.GetPage(ctx, options)
 	if err != nil {
-		return nil, ErrNotFound
+		return nil, 0, err
+	}
+
+	return &warehouses, total, nil
+}
+
+func (s *service) Each(ctx context.Context, options listing.Options, fn func(domain.Warehouse) error) error {
+	return s.repository.Each(ctx, options, fn)
+}
+
+func (s *service) GetOne(ctx context.Context, id int) (*domain.Warehouse, error) {
+	result, err := s.repository.Get(ctx, id)
+	if err != nil {
+		return nil, err
 	}
 
 	return &result, nil
 }
 
-func (s *service) Update(c *context.Context, id int, dto dtos.WarehouseRequestDTO) (*domain.Warehouse, error) {
-	newWarehouse, er := s.GetOne(c, id)
+func (s *service) Update(ctx context.Context, id int, dto dtos.WarehouseRequestDTO) (*domain.Warehouse, error) {
+	newWarehouse, er := s.GetOne(ctx, id)
 
 	if er != nil {
-		return nil, ErrNotFound
+		return nil, er
 	}
 
-	exists := s.repository.Exists(*c, dto.WarehouseCode)
+	exists := s.repository.Exists(ctx, dto.WarehouseCode)
 
 	if exists {
 		if newWarehouse.WarehouseCode != dto.WarehouseCode {
@@ -92,9 +135,13 @@ func (s *service) Update(c *context.Context, id int, dto dtos.WarehouseRequestDT
 		}
 	}
 
+	if dto.LocalityID != 0 && dto.LocalityID != newWarehouse.LocalityID && !s.localityRepository.Exists(ctx, dto.LocalityID) {
+		return nil, ErrLocalityNotFound
+	}
+
 	newWarehouse = updateFormatter(dto, *newWarehouse)
 
-	err := s.repository.Update(*c, *newWarehouse)
+	err := s.repository.Update(ctx, *newWarehouse)
 
 	if err != nil {
 		return nil, err
@@ -103,14 +150,153 @@ func (s *service) Update(c *context.Context, id int, dto dtos.WarehouseRequestDT
 	return newWarehouse, nil
 }
 
-func (s *service) Delete(c *context.Context, id int) error {
-	result := s.repository.Delete(*c, id)
+func (s *service) Delete(ctx context.Context, id int) error {
+	dependents, err := s.repository.Dependents(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := database.CheckDependents(dependents); err != nil {
+		return err
+	}
+
+	return s.repository.Delete(ctx, id)
+}
+
+// Restore brings back a soft-deleted warehouse.
+func (s *service) Restore(ctx context.Context, id int) (*domain.Warehouse, error) {
+	if err := s.repository.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.GetOne(ctx, id)
+}
+
+// DeletePreview counts the records referencing the warehouse, which would block deleting it.
+func (s *service) DeletePreview(ctx context.Context, id int) (*domain.DeletePreview, error) {
+	if _, err := s.GetOne(ctx, id); err != nil {
+		return nil, err
+	}
+
+	dependents, err := s.repository.Dependents(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.DeletePreview{
+		ID:         id,
+		Dependents: dependents,
+		Deletable:  database.CheckDependents(dependents) == nil,
+	}, nil
+}
+
+// GetInventory aggregates the sections of the warehouse, the batches they hold, their quantities and capacity utilisation.
+func (s *service) GetInventory(ctx context.Context, id int) (*domain.WarehouseInventory, error) {
+	w, err := s.GetOne(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	sections, err := s.repository.GetSectionsInventory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := domain.WarehouseInventory{Warehouse: *w, SectionsCount: len(sections), Sections: sections}
+	for i := range sections {
+		sec := &sections[i]
+		sec.BatchesCount = len(sec.Batches)
+		for _, b := range sec.Batches {
+			sec.ProductsCount += b.CurrentQuantity
+		}
+		sec.CapacityUtilization = utilization(sec.CurrentCapacity, sec.MaximumCapacity)
+
+		inventory.BatchesCount += sec.BatchesCount
+		inventory.ProductsCount += sec.ProductsCount
+		inventory.CurrentCapacity += sec.CurrentCapacity
+		inventory.MaximumCapacity += sec.MaximumCapacity
+	}
+	inventory.CapacityUtilization = utilization(inventory.CurrentCapacity, inventory.MaximumCapacity)
+
+	return &inventory, nil
+}
+
+// GetEmployees lists the employees of an existing warehouse.
+func (s *service) GetEmployees(ctx context.Context, id int, options listing.Options) (*[]domain.Employee, int, error) {
+	if _, err := s.GetOne(ctx, id); err != nil {
+		return nil, 0, err
+	}
+
+	options.Filters = withWarehouse(employee.Fields["warehouse_id"], id, options.Filters)
+	employees, total, err := s.employeeRepository.GetPage(ctx, options)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &employees, total, nil
+}
+
+// GetSections lists the sections of an existing warehouse.
+func (s *service) GetSections(ctx context.Context, id int, options listing.Options) (*[]domain.Section, int, error) {
+	if _, err := s.GetOne(ctx, id); err != nil {
+		return nil, 0, err
+	}
+
+	options.Filters = withWarehouse(section.Fields["warehouse_id"], id, options.Filters)
+	sections, total, err := s.sectionRepository.GetPage(ctx, options)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &sections, total, nil
+}
+
+// GetSummary counts the employees of the warehouse and its inbound orders of the last days,
+// and reports how full each of its sections is.
+func (s *service) GetSummary(ctx context.Context, id int, days int) (*domain.WarehouseSummary, error) {
+	w, err := s.GetOne(ctx, id)
+	if err != nil {
+		return nil, err
+	}
 
-	if result != nil {
-		return ErrNotFound
+	summary := domain.WarehouseSummary{WarehouseID: w.ID, WarehouseCode: w.WarehouseCode, Days: days}
+	if summary.EmployeesCount, err = s.employeeRepository.CountByWarehouse(ctx, id); err != nil {
+		return nil, err
+	}
+	if summary.InboundOrdersCount, err = s.inboundOrderRepository.CountByWarehouseSince(ctx, id, days); err != nil {
+		return nil, err
 	}
 
-	return nil
+	sections, err := s.sectionRepository.GetAllByWarehouse(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	summary.SectionsCount = len(sections)
+	summary.Sections = make([]domain.SectionFillRate, 0, len(sections))
+	for _, sec := range sections {
+		summary.Sections = append(summary.Sections, domain.SectionFillRate{
+			SectionID:       sec.ID,
+			SectionNumber:   sec.SectionNumber,
+			CurrentCapacity: sec.CurrentCapacity,
+			MaximumCapacity: sec.MaximumCapacity,
+			FillRate:        utilization(sec.CurrentCapacity, sec.MaximumCapacity),
+		})
+		summary.CurrentCapacity += sec.CurrentCapacity
+		summary.MaximumCapacity += sec.MaximumCapacity
+	}
+	summary.FillRate = utilization(summary.CurrentCapacity, summary.MaximumCapacity)
+
+	return &summary, nil
+}
+
+// withWarehouse puts the warehouse filter before the filters of the request.
+func withWarehouse(column string, id int, filters []listing.Filter) []listing.Filter {
+	return append([]listing.Filter{{Column: column, Value: strconv.Itoa(id)}}, filters...)
+}
+
+// utilization is the share of the maximum capacity in use, 0 when there is no capacity.
+func utilization(current, maximum int) float64 {
+	if maximum <= 0 {
+		return 0
+	}
+	return float64(current) / float64(maximum)
 }
 
 func updateFormatter(dto dtos.WarehouseRequestDTO, newWarehouse domain.Warehouse) *domain.Warehouse {
@@ -129,6 +315,9 @@ func updateFormatter(dto dtos.WarehouseRequestDTO, newWarehouse domain.Warehouse
 	if dto.MinimumTemperature != 0 {
 		newWarehouse.MinimumTemperature = dto.MinimumTemperature
 	}
+	if dto.LocalityID != 0 {
+		newWarehouse.LocalityID = dto.LocalityID
+	}
 
 	return &newWarehouse
 }
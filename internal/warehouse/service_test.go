@@ -7,9 +7,15 @@ import (
 	"testing"
 
 	dtos "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/application/dtos/warehousesdto"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/database"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/domain"
+	employee_mocks "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/employee/mocks"
+	inbound_mocks "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/inbound_order/mocks"
+	locality_mocks "github.com/extmatperez/meli_bootcamp_go_w2-2/internal/locality/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/section/section_mocks"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/warehouse"
 	"github.com/extmatperez/meli_bootcamp_go_w2-2/internal/warehouse/mocks"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -30,8 +36,8 @@ func TestGet(t *testing.T) {
 		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
 		warehouseRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(*expectedWarehouse, nil)
 
-		service := warehouse.NewService(warehouseRepositoryMock)
-		warehouseReceived, err := service.GetOne(&ctx, 1)
+		service := newService(warehouseRepositoryMock, existingLocality())
+		warehouseReceived, err := service.GetOne(ctx, 1)
 
 		assert.Equal(t, *expectedWarehouse, *warehouseReceived)
 		assert.Equal(t, nil, err)
@@ -42,10 +48,10 @@ func TestGet(t *testing.T) {
 		ctx := context.TODO()
 
 		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
-		warehouseRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.Warehouse{}, sql.ErrNoRows)
+		warehouseRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.Warehouse{}, warehouse.ErrNotFound)
 
-		service := warehouse.NewService(warehouseRepositoryMock)
-		warehouseReceived, err := service.GetOne(&ctx, 1)
+		service := newService(warehouseRepositoryMock, existingLocality())
+		warehouseReceived, err := service.GetOne(ctx, 1)
 
 		assert.Nil(t, warehouseReceived)
 		assert.Equal(t, warehouse.ErrNotFound, err)
@@ -57,8 +63,8 @@ func TestGet(t *testing.T) {
 		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
 		warehouseRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.Warehouse{}, errors.New("warehouses not found"))
 
-		service := warehouse.NewService(warehouseRepositoryMock)
-		warehouseReceived, err := service.GetOne(&ctx, 1)
+		service := newService(warehouseRepositoryMock, existingLocality())
+		warehouseReceived, err := service.GetOne(ctx, 1)
 
 		assert.Nil(t, warehouseReceived)
 		assert.Equal(t, errors.New("warehouses not found"), err)
@@ -91,8 +97,8 @@ func TestGetAll(t *testing.T) {
 		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
 		warehouseRepositoryMock.On("GetAll", ctx).Return(*expectedWarehouses, nil)
 
-		service := warehouse.NewService(warehouseRepositoryMock)
-		warehousesReceived, err := service.GetAll(&ctx)
+		service := newService(warehouseRepositoryMock, existingLocality())
+		warehousesReceived, err := service.GetAll(ctx)
 
 		assert.Equal(t, *expectedWarehouses, *warehousesReceived)
 		assert.Equal(t, nil, err)
@@ -105,8 +111,8 @@ func TestGetAll(t *testing.T) {
 		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
 		warehouseRepositoryMock.On("GetAll", ctx).Return([]domain.Warehouse{}, errors.New("error"))
 
-		service := warehouse.NewService(warehouseRepositoryMock)
-		warehousesReceived, err := service.GetAll(&ctx)
+		service := newService(warehouseRepositoryMock, existingLocality())
+		warehousesReceived, err := service.GetAll(ctx)
 
 		assert.Nil(t, warehousesReceived)
 		assert.Equal(t, errors.New("error"), err)
@@ -114,6 +120,38 @@ func TestGetAll(t *testing.T) {
 
 }
 
+func TestGetPage(t *testing.T) {
+	t.Run("find_page", func(t *testing.T) {
+		expectedWarehouses := []domain.Warehouse{{ID: 1, Address: "Rua Teste", WarehouseCode: "CX-2281-TCD"}}
+		options := listing.Options{Limit: 1}
+		ctx := context.TODO()
+
+		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
+		warehouseRepositoryMock.On("GetPage", ctx, options).Return(expectedWarehouses, 3, nil)
+
+		service := newService(warehouseRepositoryMock, existingLocality())
+		warehousesReceived, total, err := service.GetPage(ctx, options)
+
+		assert.Equal(t, expectedWarehouses, *warehousesReceived)
+		assert.Equal(t, 3, total)
+		assert.Nil(t, err)
+	})
+
+	t.Run("find_page_error", func(t *testing.T) {
+		options := listing.Options{Limit: 1}
+		ctx := context.TODO()
+
+		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
+		warehouseRepositoryMock.On("GetPage", ctx, options).Return([]domain.Warehouse{}, 0, errors.New("error"))
+
+		service := newService(warehouseRepositoryMock, existingLocality())
+		warehousesReceived, _, err := service.GetPage(ctx, options)
+
+		assert.Nil(t, warehousesReceived)
+		assert.NotNil(t, err)
+	})
+}
+
 func TestDelete(t *testing.T) {
 	t.Run("delete_ok", func(t *testing.T) {
 		expectedWarehouse := &domain.Warehouse{
@@ -129,10 +167,11 @@ func TestDelete(t *testing.T) {
 
 		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
 		warehouseRepositoryMock.On("Get", ctx, 1).Return(expectedWarehouse, nil)
+		warehouseRepositoryMock.On("Dependents", ctx, 1).Return(map[string]int{}, nil)
 		warehouseRepositoryMock.On("Delete", ctx, 1).Return(nil)
 
-		service := warehouse.NewService(warehouseRepositoryMock)
-		err := service.Delete(&ctx, 1)
+		service := newService(warehouseRepositoryMock, existingLocality())
+		err := service.Delete(ctx, 1)
 
 		assert.Equal(t, nil, err)
 	})
@@ -142,10 +181,11 @@ func TestDelete(t *testing.T) {
 		ctx := context.TODO()
 
 		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
+		warehouseRepositoryMock.On("Dependents", ctx, 1).Return(map[string]int{}, nil)
 		warehouseRepositoryMock.On("Delete", ctx, 1).Return(warehouse.ErrNotFound)
 
-		service := warehouse.NewService(warehouseRepositoryMock)
-		err := service.Delete(&ctx, 1)
+		service := newService(warehouseRepositoryMock, existingLocality())
+		err := service.Delete(ctx, 1)
 
 		assert.Equal(t, warehouse.ErrNotFound, err)
 	})
@@ -154,13 +194,91 @@ func TestDelete(t *testing.T) {
 		ctx := context.TODO()
 
 		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
+		warehouseRepositoryMock.On("Dependents", ctx, mock.AnythingOfType("int")).Return(map[string]int{}, nil)
 		warehouseRepositoryMock.On("Delete", ctx, mock.AnythingOfType("int")).Return(errors.New("warehouses not found"))
 
-		service := warehouse.NewService(warehouseRepositoryMock)
-		err := service.Delete(&ctx, 1)
+		service := newService(warehouseRepositoryMock, existingLocality())
+		err := service.Delete(ctx, 1)
 
 		assert.Equal(t, errors.New("warehouses not found"), err)
 	})
+
+	t.Run("delete_has_dependents", func(t *testing.T) {
+
+		ctx := context.TODO()
+
+		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
+		warehouseRepositoryMock.On("Dependents", ctx, 1).Return(map[string]int{"sections": 2, "employees": 3}, nil)
+
+		service := newService(warehouseRepositoryMock, existingLocality())
+		err := service.Delete(ctx, 1)
+
+		assert.ErrorIs(t, err, database.ErrHasDependents)
+		assert.Equal(t, &database.DependentsError{Dependents: map[string]int{"sections": 2, "employees": 3}}, err)
+		warehouseRepositoryMock.AssertNotCalled(t, "Delete", ctx, 1)
+	})
+}
+
+func TestDeletePreview(t *testing.T) {
+	t.Run("delete_preview_ok", func(t *testing.T) {
+
+		ctx := context.TODO()
+
+		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
+		warehouseRepositoryMock.On("Get", ctx, 1).Return(domain.Warehouse{ID: 1}, nil)
+		warehouseRepositoryMock.On("Dependents", ctx, 1).Return(map[string]int{"sections": 2, "employees": 3}, nil)
+
+		service := newService(warehouseRepositoryMock, existingLocality())
+		preview, err := service.DeletePreview(ctx, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &domain.DeletePreview{ID: 1, Dependents: map[string]int{"sections": 2, "employees": 3}, Deletable: false}, preview)
+	})
+
+	t.Run("delete_preview_non_existent", func(t *testing.T) {
+
+		ctx := context.TODO()
+
+		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
+		warehouseRepositoryMock.On("Get", ctx, 1).Return(domain.Warehouse{}, warehouse.ErrNotFound)
+
+		service := newService(warehouseRepositoryMock, existingLocality())
+		preview, err := service.DeletePreview(ctx, 1)
+
+		assert.Nil(t, preview)
+		assert.Equal(t, warehouse.ErrNotFound, err)
+	})
+}
+
+func TestRestore(t *testing.T) {
+	t.Run("restore_ok", func(t *testing.T) {
+
+		ctx := context.TODO()
+
+		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
+		warehouseRepositoryMock.On("Restore", ctx, 1).Return(nil)
+		warehouseRepositoryMock.On("Get", ctx, 1).Return(domain.Warehouse{ID: 1}, nil)
+
+		service := newService(warehouseRepositoryMock, existingLocality())
+		restored, err := service.Restore(ctx, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &domain.Warehouse{ID: 1}, restored)
+	})
+
+	t.Run("restore_not_deleted", func(t *testing.T) {
+
+		ctx := context.TODO()
+
+		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
+		warehouseRepositoryMock.On("Restore", ctx, 1).Return(warehouse.ErrNotFound)
+
+		service := newService(warehouseRepositoryMock, existingLocality())
+		restored, err := service.Restore(ctx, 1)
+
+		assert.Nil(t, restored)
+		assert.Equal(t, warehouse.ErrNotFound, err)
+	})
 }
 
 func TestCreate(t *testing.T) {
@@ -179,8 +297,8 @@ func TestCreate(t *testing.T) {
 		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
 		warehouseRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(true)
 
-		service := warehouse.NewService(warehouseRepositoryMock)
-		warehousetSaved, err := service.Create(&ctx, createWarehouseRequestDTO)
+		service := newService(warehouseRepositoryMock, existingLocality())
+		warehousetSaved, err := service.Create(ctx, createWarehouseRequestDTO)
 
 		assert.Equal(t, warehouse.ErrConflict, err)
 		assert.Nil(t, warehousetSaved)
@@ -203,8 +321,8 @@ func TestCreate(t *testing.T) {
 		warehousetRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(false)
 		warehousetRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.Warehouse")).Return(0, errors.New("error"))
 
-		service := warehouse.NewService(warehousetRepositoryMock)
-		warehouseSaved, err := service.Create(&ctx, createWarehouseRequestDTO)
+		service := newService(warehousetRepositoryMock, existingLocality())
+		warehouseSaved, err := service.Create(ctx, createWarehouseRequestDTO)
 
 		assert.Equal(t, errors.New("error"), err)
 		assert.Nil(t, warehouseSaved)
@@ -219,6 +337,7 @@ func TestCreate(t *testing.T) {
 			WarehouseCode:      "CX-2281-TCD",
 			MinimumCapacity:    12,
 			MinimumTemperature: 18,
+			LocalityID:         3,
 		}
 		createWarehouseRequestDTO := dtos.WarehouseRequestDTO{
 			Address:            "Rua Teste2",
@@ -226,6 +345,7 @@ func TestCreate(t *testing.T) {
 			WarehouseCode:      "CX-2281-TCD",
 			MinimumCapacity:    12,
 			MinimumTemperature: 18,
+			LocalityID:         3,
 		}
 
 		ctx := context.TODO()
@@ -234,14 +354,41 @@ func TestCreate(t *testing.T) {
 		warehouseRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(false)
 		warehouseRepositoryMock.On("Save", ctx, mock.AnythingOfType("domain.Warehouse")).Return(1, nil)
 
-		service := warehouse.NewService(warehouseRepositoryMock)
-		warehouseSaved, err := service.Create(&ctx, createWarehouseRequestDTO)
+		service := newService(warehouseRepositoryMock, existingLocality())
+		warehouseSaved, err := service.Create(ctx, createWarehouseRequestDTO)
 
 		assert.Equal(t, warehouseSaved, expectedWarehouse)
 		assert.Nil(t, err)
 	})
 }
 
+func TestCreateLocality(t *testing.T) {
+	t.Run("create_locality_not_found", func(t *testing.T) {
+		createWarehouseRequestDTO := dtos.WarehouseRequestDTO{
+			Address:            "Rua Teste2",
+			Telephone:          "11938473322",
+			WarehouseCode:      "CX-2281-TCD",
+			MinimumCapacity:    12,
+			MinimumTemperature: 18,
+			LocalityID:         99,
+		}
+
+		ctx := context.TODO()
+
+		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
+		warehouseRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(false)
+		localityRepositoryMock := new(locality_mocks.MockLocalityRepository)
+		localityRepositoryMock.On("Exists", ctx, 99).Return(false)
+
+		service := newService(warehouseRepositoryMock, localityRepositoryMock)
+		warehouseSaved, err := service.Create(ctx, createWarehouseRequestDTO)
+
+		assert.Equal(t, warehouse.ErrLocalityNotFound, err)
+		assert.Nil(t, warehouseSaved)
+		warehouseRepositoryMock.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
 func TestUpdate(t *testing.T) {
 	t.Run("update_existent", func(t *testing.T) {
 		originalWarehouse := &domain.Warehouse{
@@ -266,8 +413,8 @@ func TestUpdate(t *testing.T) {
 		warehouseRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(false)
 		warehouseRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.Warehouse")).Return(nil)
 
-		service := warehouse.NewService(warehouseRepositoryMock)
-		warehouseUpdated, err := service.Update(&ctx, 1, updateWarehouseRequestDTO)
+		service := newService(warehouseRepositoryMock, existingLocality())
+		warehouseUpdated, err := service.Update(ctx, 1, updateWarehouseRequestDTO)
 
 		assert.Equal(t, originalWarehouse, warehouseUpdated)
 		assert.Nil(t, err)
@@ -286,8 +433,8 @@ func TestUpdate(t *testing.T) {
 		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
 		warehouseRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.Warehouse{}, warehouse.ErrNotFound)
 
-		service := warehouse.NewService(warehouseRepositoryMock)
-		warehouseUpdated, err := service.Update(&ctx, 1, updateWarehouseRequestDTO)
+		service := newService(warehouseRepositoryMock, existingLocality())
+		warehouseUpdated, err := service.Update(ctx, 1, updateWarehouseRequestDTO)
 
 		assert.Equal(t, warehouse.ErrNotFound, err)
 		assert.Nil(t, warehouseUpdated)
@@ -308,8 +455,8 @@ func TestUpdate(t *testing.T) {
 		warehouseRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(false)
 		warehouseRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.Warehouse")).Return(errors.New(""))
 
-		service := warehouse.NewService(warehouseRepositoryMock)
-		warehouseUpdated, err := service.Update(&ctx, 1, updateWarehouseRequestDTO)
+		service := newService(warehouseRepositoryMock, existingLocality())
+		warehouseUpdated, err := service.Update(ctx, 1, updateWarehouseRequestDTO)
 		assert.Nil(t, warehouseUpdated)
 		assert.Error(t, err)
 	})
@@ -328,8 +475,8 @@ func TestUpdate(t *testing.T) {
 		warehouseRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(domain.Warehouse{}, warehouse.ErrNotFound)
 		warehouseRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(true)
 
-		service := warehouse.NewService(warehouseRepositoryMock)
-		warehouseUpdated, err := service.Update(&ctx, 1, updateWarehouseRequestDTO)
+		service := newService(warehouseRepositoryMock, existingLocality())
+		warehouseUpdated, err := service.Update(ctx, 1, updateWarehouseRequestDTO)
 		assert.Nil(t, warehouseUpdated)
 		assert.Error(t, err)
 	})
@@ -358,10 +505,200 @@ func TestUpdate(t *testing.T) {
 		warehouseRepositoryMock.On("Get", ctx, mock.AnythingOfType("int")).Return(*originalWarehouse, nil)
 		warehouseRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(true)
 
-		service := warehouse.NewService(warehouseRepositoryMock)
-		warehouseUpdated, err := service.Update(&ctx, 1, updateWarehouseRequestDTO)
+		service := newService(warehouseRepositoryMock, existingLocality())
+		warehouseUpdated, err := service.Update(ctx, 1, updateWarehouseRequestDTO)
 
 		assert.Equal(t, warehouse.ErrConflict, err)
 		assert.Nil(t, warehouseUpdated)
 	})
+	t.Run("update_locality", func(t *testing.T) {
+		originalWarehouse := domain.Warehouse{ID: 1, WarehouseCode: "CX-2281-TCD", LocalityID: 1}
+
+		ctx := context.TODO()
+		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
+		warehouseRepositoryMock.On("Get", ctx, 1).Return(originalWarehouse, nil)
+		warehouseRepositoryMock.On("Exists", ctx, mock.AnythingOfType("string")).Return(false)
+		localityRepositoryMock := new(locality_mocks.MockLocalityRepository)
+
+		t.Run("not_found", func(t *testing.T) {
+			localityRepositoryMock.On("Exists", ctx, 99).Return(false).Once()
+
+			service := newService(warehouseRepositoryMock, localityRepositoryMock)
+			warehouseUpdated, err := service.Update(ctx, 1, dtos.WarehouseRequestDTO{LocalityID: 99})
+
+			assert.Equal(t, warehouse.ErrLocalityNotFound, err)
+			assert.Nil(t, warehouseUpdated)
+		})
+		t.Run("ok", func(t *testing.T) {
+			localityRepositoryMock.On("Exists", ctx, 2).Return(true).Once()
+			warehouseRepositoryMock.On("Update", ctx, mock.AnythingOfType("domain.Warehouse")).Return(nil)
+
+			service := newService(warehouseRepositoryMock, localityRepositoryMock)
+			warehouseUpdated, err := service.Update(ctx, 1, dtos.WarehouseRequestDTO{LocalityID: 2})
+
+			assert.Nil(t, err)
+			assert.Equal(t, 2, warehouseUpdated.LocalityID)
+		})
+	})
+}
+
+func TestGetInventory(t *testing.T) {
+	t.Run("inventory_ok", func(t *testing.T) {
+		ctx := context.TODO()
+		storedWarehouse := domain.Warehouse{ID: 1, WarehouseCode: "CX-2281-TCD", LocalityID: 1}
+		sections := []domain.SectionInventory{
+			{SectionID: 1, SectionNumber: 10, CurrentCapacity: 30, MaximumCapacity: 100, Batches: []domain.InventoryBatch{
+				{ID: 1, CurrentQuantity: 10},
+				{ID: 2, CurrentQuantity: 20},
+			}},
+			{SectionID: 2, SectionNumber: 20, CurrentCapacity: 0, MaximumCapacity: 100, Batches: []domain.InventoryBatch{}},
+		}
+
+		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
+		warehouseRepositoryMock.On("Get", ctx, 1).Return(storedWarehouse, nil)
+		warehouseRepositoryMock.On("GetSectionsInventory", ctx, 1).Return(sections, nil)
+
+		service := newService(warehouseRepositoryMock, existingLocality())
+		inventory, err := service.GetInventory(ctx, 1)
+
+		assert.Nil(t, err)
+		assert.Equal(t, storedWarehouse, inventory.Warehouse)
+		assert.Equal(t, 2, inventory.SectionsCount)
+		assert.Equal(t, 2, inventory.BatchesCount)
+		assert.Equal(t, 30, inventory.ProductsCount)
+		assert.Equal(t, 200, inventory.MaximumCapacity)
+		assert.Equal(t, 0.15, inventory.CapacityUtilization)
+		assert.Equal(t, 2, inventory.Sections[0].BatchesCount)
+		assert.Equal(t, 30, inventory.Sections[0].ProductsCount)
+		assert.Equal(t, 0.3, inventory.Sections[0].CapacityUtilization)
+		assert.Equal(t, 0.0, inventory.Sections[1].CapacityUtilization)
+	})
+	t.Run("inventory_not_found", func(t *testing.T) {
+		ctx := context.TODO()
+		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
+		warehouseRepositoryMock.On("Get", ctx, 99).Return(domain.Warehouse{}, warehouse.ErrNotFound)
+
+		service := newService(warehouseRepositoryMock, existingLocality())
+		inventory, err := service.GetInventory(ctx, 99)
+
+		assert.Equal(t, warehouse.ErrNotFound, err)
+		assert.Nil(t, inventory)
+		warehouseRepositoryMock.AssertNotCalled(t, "GetSectionsInventory", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWarehouseViews(t *testing.T) {
+	storedWarehouse := domain.Warehouse{ID: 1, WarehouseCode: "CX-2281-TCD", LocalityID: 1}
+
+	t.Run("employees_scoped_to_warehouse", func(t *testing.T) {
+		ctx := context.TODO()
+		options := listing.Options{Limit: 50, Filters: []listing.Filter{{Column: "first_name", Value: "Ana"}}}
+		scoped := options
+		scoped.Filters = []listing.Filter{{Column: "warehouse_id", Value: "1"}, {Column: "first_name", Value: "Ana"}}
+		expectedEmployees := []domain.Employee{{ID: 1, FirstName: "Ana", WarehouseID: 1}}
+
+		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
+		warehouseRepositoryMock.On("Get", ctx, 1).Return(storedWarehouse, nil)
+		employeeRepositoryMock := new(employee_mocks.EmployeeRepositoryMock)
+		employeeRepositoryMock.On("GetPage", ctx, scoped).Return(expectedEmployees, 1, nil)
+
+		service := warehouse.NewService(warehouseRepositoryMock, existingLocality(), employeeRepositoryMock, new(section_mocks.SectionRepositoryMock), new(inbound_mocks.InboundOrdersRepositoryMock))
+		employees, total, err := service.GetEmployees(ctx, 1, options)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, expectedEmployees, *employees)
+	})
+	t.Run("sections_scoped_to_warehouse", func(t *testing.T) {
+		ctx := context.TODO()
+		options := listing.Options{Limit: 50}
+		scoped := options
+		scoped.Filters = []listing.Filter{{Column: "warehouse_id", Value: "1"}}
+		expectedSections := []domain.Section{{ID: 1, WarehouseID: 1}}
+
+		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
+		warehouseRepositoryMock.On("Get", ctx, 1).Return(storedWarehouse, nil)
+		sectionRepositoryMock := new(section_mocks.SectionRepositoryMock)
+		sectionRepositoryMock.On("GetPage", ctx, scoped).Return(expectedSections, 1, nil)
+
+		service := warehouse.NewService(warehouseRepositoryMock, existingLocality(), new(employee_mocks.EmployeeRepositoryMock), sectionRepositoryMock, new(inbound_mocks.InboundOrdersRepositoryMock))
+		sections, total, err := service.GetSections(ctx, 1, options)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, expectedSections, *sections)
+	})
+	t.Run("views_warehouse_not_found", func(t *testing.T) {
+		ctx := context.TODO()
+		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
+		warehouseRepositoryMock.On("Get", ctx, 99).Return(domain.Warehouse{}, warehouse.ErrNotFound)
+
+		service := newService(warehouseRepositoryMock, existingLocality())
+		_, _, employeesErr := service.GetEmployees(ctx, 99, listing.Options{})
+		_, _, sectionsErr := service.GetSections(ctx, 99, listing.Options{})
+		_, summaryErr := service.GetSummary(ctx, 99, 30)
+
+		assert.Equal(t, warehouse.ErrNotFound, employeesErr)
+		assert.Equal(t, warehouse.ErrNotFound, sectionsErr)
+		assert.Equal(t, warehouse.ErrNotFound, summaryErr)
+	})
+	t.Run("summary_ok", func(t *testing.T) {
+		ctx := context.TODO()
+		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
+		warehouseRepositoryMock.On("Get", ctx, 1).Return(storedWarehouse, nil)
+		employeeRepositoryMock := new(employee_mocks.EmployeeRepositoryMock)
+		employeeRepositoryMock.On("CountByWarehouse", ctx, 1).Return(4, nil)
+		inboundOrderRepositoryMock := new(inbound_mocks.InboundOrdersRepositoryMock)
+		inboundOrderRepositoryMock.On("CountByWarehouseSince", ctx, 1, 7).Return(3, nil)
+		sectionRepositoryMock := new(section_mocks.SectionRepositoryMock)
+		sectionRepositoryMock.On("GetAllByWarehouse", ctx, 1).Return([]domain.Section{
+			{ID: 1, SectionNumber: 10, CurrentCapacity: 50, MaximumCapacity: 100},
+			{ID: 2, SectionNumber: 20, CurrentCapacity: 25, MaximumCapacity: 100},
+		}, nil)
+
+		service := warehouse.NewService(warehouseRepositoryMock, existingLocality(), employeeRepositoryMock, sectionRepositoryMock, inboundOrderRepositoryMock)
+		summary, err := service.GetSummary(ctx, 1, 7)
+
+		assert.Nil(t, err)
+		assert.Equal(t, &domain.WarehouseSummary{
+			WarehouseID:        1,
+			WarehouseCode:      "CX-2281-TCD",
+			EmployeesCount:     4,
+			Days:               7,
+			InboundOrdersCount: 3,
+			SectionsCount:      2,
+			CurrentCapacity:    75,
+			MaximumCapacity:    200,
+			FillRate:           0.375,
+			Sections: []domain.SectionFillRate{
+				{SectionID: 1, SectionNumber: 10, CurrentCapacity: 50, MaximumCapacity: 100, FillRate: 0.5},
+				{SectionID: 2, SectionNumber: 20, CurrentCapacity: 25, MaximumCapacity: 100, FillRate: 0.25},
+			},
+		}, summary)
+	})
+	t.Run("summary_count_error", func(t *testing.T) {
+		ctx := context.TODO()
+		warehouseRepositoryMock := new(mocks.WarehouseRepositoryMock)
+		warehouseRepositoryMock.On("Get", ctx, 1).Return(storedWarehouse, nil)
+		employeeRepositoryMock := new(employee_mocks.EmployeeRepositoryMock)
+		employeeRepositoryMock.On("CountByWarehouse", ctx, 1).Return(0, sql.ErrConnDone)
+
+		service := warehouse.NewService(warehouseRepositoryMock, existingLocality(), employeeRepositoryMock, new(section_mocks.SectionRepositoryMock), new(inbound_mocks.InboundOrdersRepositoryMock))
+		summary, err := service.GetSummary(ctx, 1, 7)
+
+		assert.Nil(t, summary)
+		assert.Equal(t, sql.ErrConnDone, err)
+	})
+}
+
+// newService builds the service with empty mocks for the repositories of the warehouse views.
+func newService(r warehouse.Repository, localityRepository *locality_mocks.MockLocalityRepository) warehouse.Service {
+	return warehouse.NewService(r, localityRepository, new(employee_mocks.EmployeeRepositoryMock), new(section_mocks.SectionRepositoryMock), new(inbound_mocks.InboundOrdersRepositoryMock))
+}
+
+// existingLocality is a locality repository where every locality exists.
+func existingLocality() *locality_mocks.MockLocalityRepository {
+	localityRepositoryMock := new(locality_mocks.MockLocalityRepository)
+	localityRepositoryMock.On("Exists", mock.Anything, mock.Anything).Return(true)
+	return localityRepositoryMock
 }
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWT_SignAndParse(t *testing.T) {
+	issuedAt := time.Date(2023, 7, 5, 16, 0, 0, 0, time.UTC)
+	jwt := NewJWT([]byte("secret"), time.Hour)
+	jwt.now = func() time.Time { return issuedAt }
+
+	token, expiresAt, err := jwt.Sign(1, "user1", []string{"admin"})
+	assert.NoError(t, err)
+	assert.Equal(t, issuedAt.Add(time.Hour), expiresAt)
+
+	tests := []struct {
+		name    string
+		token   string
+		parser  *JWT
+		now     time.Time
+		want    Claims
+		wantErr error
+	}{
+		{
+			name:  "Successfully parse token",
+			token: token,
+			now:   issuedAt.Add(time.Minute),
+			want: Claims{
+				Subject:   1,
+				Username:  "user1",
+				Roles:     []string{"admin"},
+				IssuedAt:  issuedAt.Unix(),
+				ExpiresAt: expiresAt.Unix(),
+			},
+		},
+		{
+			name:    "Error expired token",
+			token:   token,
+			now:     expiresAt,
+			wantErr: ErrExpiredToken,
+		},
+		{
+			name:    "Error token signed with another secret",
+			token:   token,
+			parser:  NewJWT([]byte("another secret"), time.Hour),
+			now:     issuedAt,
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "Error tampered payload",
+			token:   strings.Join([]string{strings.Split(token, ".")[0], "eyJzdWIiOjIsInJvbGVzIjpbImFkbWluIl19", strings.Split(token, ".")[2]}, "."),
+			now:     issuedAt,
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "Error unsigned token",
+			token:   `eyJhbGciOiJub25lIiwidHlwIjoiSldUIn0.` + strings.Split(token, ".")[1] + ".",
+			now:     issuedAt,
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "Error malformed token",
+			token:   "not a token",
+			now:     issuedAt,
+			wantErr: ErrInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := jwt
+			if tt.parser != nil {
+				parser = tt.parser
+			}
+			now := tt.now
+			parser.now = func() time.Time { return now }
+
+			got, err := parser.Parse(tt.token)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package auth
+
+// Any matches every resource or every HTTP method in Permissions.
+const Any = "*"
+
+// Permissions maps each role to the HTTP methods it may use on each resource.
+// An entry for a specific resource takes precedence over the Any entry of the same role.
+type Permissions map[string]map[string][]string
+
+// Allows reports whether any of the roles may use the method on the resource.
+func (p Permissions) Allows(roles []string, resource string, method string) bool {
+	for _, role := range roles {
+		resources := p[role]
+
+		methods, ok := resources[resource]
+		if !ok {
+			methods = resources[Any]
+		}
+
+		if allowsMethod(methods, method) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func allowsMethod(methods []string, method string) bool {
+	for _, m := range methods {
+		if m == Any || m == method {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissions_Allows(t *testing.T) {
+	permissions := Permissions{
+		"admin": {
+			Any: {Any},
+		},
+		"analyst": {
+			"users": {},
+			Any:     {http.MethodGet},
+		},
+		"operator": {
+			"inbound-orders": {http.MethodPost},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		roles    []string
+		resource string
+		method   string
+		want     bool
+	}{
+		{name: "Admin can do anything", roles: []string{"admin"}, resource: "users", method: http.MethodDelete, want: true},
+		{name: "Analyst can read", roles: []string{"analyst"}, resource: "sections", method: http.MethodGet, want: true},
+		{name: "Analyst cannot write", roles: []string{"analyst"}, resource: "sections", method: http.MethodPost, want: false},
+		{name: "Resource entry overrides any", roles: []string{"analyst"}, resource: "users", method: http.MethodGet, want: false},
+		{name: "Operator can create inbound orders", roles: []string{"operator"}, resource: "inbound-orders", method: http.MethodPost, want: true},
+		{name: "Operator cannot read inbound orders", roles: []string{"operator"}, resource: "inbound-orders", method: http.MethodGet, want: false},
+		{name: "Operator cannot touch other resources", roles: []string{"operator"}, resource: "sections", method: http.MethodPost, want: false},
+		{name: "Any of the roles allows", roles: []string{"operator", "analyst"}, resource: "sections", method: http.MethodGet, want: true},
+		{name: "Unknown role", roles: []string{"guest"}, resource: "sections", method: http.MethodGet, want: false},
+		{name: "No roles", roles: nil, resource: "sections", method: http.MethodGet, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, permissions.Allows(tt.roles, tt.resource, tt.method))
+		})
+	}
+}
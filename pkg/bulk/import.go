@@ -0,0 +1,168 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/validation"
+
+	"github.com/gin-gonic/gin/binding"
+)
+
+// Mode tells what happens to the valid rows of an import with invalid ones.
+type Mode string
+
+const (
+	// AllOrNothing saves the rows in one transaction that is rolled back when any row fails.
+	AllOrNothing Mode = "all_or_nothing"
+	// BestEffort saves every valid row on its own and reports the others.
+	BestEffort Mode = "best_effort"
+)
+
+// ParseMode reads the mode query parameter, AllOrNothing when it is empty.
+func ParseMode(value string) (Mode, error) {
+	switch Mode(value) {
+	case "":
+		return AllOrNothing, nil
+	case AllOrNothing, BestEffort:
+		return Mode(value), nil
+	default:
+		return "", fmt.Errorf("mode must be %s or %s", AllOrNothing, BestEffort)
+	}
+}
+
+// Atomic runs fn in a transaction, it is implemented by database.UnitOfWork.
+type Atomic interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Save creates the record of a decoded and validated row and returns its id.
+// Errors made with Reject only fail the row, any other error stops the import.
+type Save func(ctx context.Context, row interface{}) (int, error)
+
+// Row is the outcome of one row, numbered from 1 in the order of the body.
+type Row struct {
+	Row    int                     `json:"row"`
+	ID     int                     `json:"id,omitempty"`
+	Error  string                  `json:"error,omitempty"`
+	Fields []validation.FieldError `json:"fields,omitempty"`
+}
+
+// Report lists the outcome of every row of an import.
+type Report struct {
+	Mode       Mode  `json:"mode"`
+	Total      int   `json:"total"`
+	Created    int   `json:"created"`
+	Failed     int   `json:"failed"`
+	RolledBack bool  `json:"rolled_back,omitempty"`
+	Rows       []Row `json:"rows"`
+}
+
+type rejection struct {
+	err error
+}
+
+func (r *rejection) Error() string {
+	return r.err.Error()
+}
+
+func (r *rejection) Unwrap() error {
+	return r.err
+}
+
+// Reject marks err, returned by a Save, as a problem of the row, such as a duplicated code, rather than of the import.
+func Reject(err error) error {
+	return &rejection{err: err}
+}
+
+// errRowsFailed rolls back an all-or-nothing import.
+var errRowsFailed = errors.New("rows failed")
+
+// Import reads every row of reader into a value made by newRow, validates it with its binding rules and saves it.
+// The report is returned along with the error that stopped the import, if any; an import stopped in AllOrNothing
+// mode has nothing saved.
+func Import(ctx context.Context, atomic Atomic, mode Mode, reader Reader, newRow func() interface{}, save Save) (*Report, error) {
+	report := &Report{Mode: mode, Rows: []Row{}}
+
+	if mode == BestEffort {
+		err := importRows(ctx, report, reader, newRow, save)
+		return report, err
+	}
+
+	err := atomic.Do(ctx, func(ctx context.Context) error {
+		if err := importRows(ctx, report, reader, newRow, save); err != nil {
+			return err
+		}
+		if report.Failed > 0 {
+			return errRowsFailed
+		}
+		return nil
+	})
+	if err != nil {
+		report.RolledBack = true
+		report.Created = 0
+		for i := range report.Rows {
+			report.Rows[i].ID = 0
+		}
+	}
+	if errors.Is(err, errRowsFailed) {
+		return report, nil
+	}
+	return report, err
+}
+
+func importRows(ctx context.Context, report *Report, reader Reader, newRow func() interface{}, save Save) error {
+	for {
+		row := newRow()
+		err := reader.Next(row)
+		if err == io.EOF {
+			return nil
+		}
+
+		result := Row{Row: report.Total + 1}
+
+		var rowErr *RowError
+		switch {
+		case errors.As(err, &rowErr):
+			fail(&result, rowErr.Err)
+		case err != nil:
+			return err
+		default:
+			if err := binding.Validator.ValidateStruct(row); err != nil {
+				fail(&result, err)
+				break
+			}
+
+			id, err := save(ctx, row)
+			var rejected *rejection
+			switch {
+			case errors.As(err, &rejected):
+				result.Error = rejected.Error()
+			case err != nil:
+				return fmt.Errorf("row %d: %w", result.Row, err)
+			default:
+				result.ID = id
+			}
+		}
+
+		report.Total++
+		if result.Error != "" {
+			report.Failed++
+		} else {
+			report.Created++
+		}
+		report.Rows = append(report.Rows, result)
+	}
+}
+
+// fail records a row that could not be decoded or validated.
+func fail(result *Row, err error) {
+	result.Fields = validation.FieldErrors(err)
+	if len(result.Fields) > 0 {
+		result.Error = "invalid row"
+	} else {
+		result.Error = err.Error()
+	}
+}
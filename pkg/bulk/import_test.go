@@ -0,0 +1,144 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+type importRow struct {
+	Code     string `json:"code" binding:"required"`
+	Quantity int    `json:"quantity" binding:"omitempty,gt=0"`
+}
+
+// atomicStub runs the work directly and tells whether it was rolled back.
+type atomicStub struct {
+	calls      int
+	rolledBack bool
+}
+
+func (a *atomicStub) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	a.calls++
+	err := fn(ctx)
+	a.rolledBack = err != nil
+	return err
+}
+
+var errDuplicated = errors.New("code already exists")
+
+// saveCodes saves every row but those with the code DUP, giving them ids from 1.
+func saveCodes(saved *[]string) Save {
+	return func(ctx context.Context, row interface{}) (int, error) {
+		code := row.(*importRow).Code
+		if code == "DUP" {
+			return 0, Reject(errDuplicated)
+		}
+		*saved = append(*saved, code)
+		return len(*saved), nil
+	}
+}
+
+func newImportRow() interface{} {
+	return &importRow{}
+}
+
+func TestParseMode(t *testing.T) {
+	mode, err := ParseMode("")
+	assert.NoError(t, err)
+	assert.Equal(t, AllOrNothing, mode)
+
+	mode, err = ParseMode("best_effort")
+	assert.NoError(t, err)
+	assert.Equal(t, BestEffort, mode)
+
+	_, err = ParseMode("some")
+	assert.EqualError(t, err, "mode must be all_or_nothing or best_effort")
+}
+
+func TestImport(t *testing.T) {
+	const body = "code,quantity\nA1,1\n,2\nDUP,1\nA2,x\nA3,3\n"
+
+	failedRows := []Row{
+		{Row: 2, Error: "invalid row", Fields: []validation.FieldError{{Field: "code", Rule: "required", Message: "is required"}}},
+		{Row: 3, Error: "code already exists"},
+		{Row: 4, Error: "invalid row", Fields: []validation.FieldError{{Field: "quantity", Rule: "type", Message: "must be of type int"}}},
+	}
+
+	t.Run("Best effort saves the valid rows", func(t *testing.T) {
+		var saved []string
+		atomic := &atomicStub{}
+		reader, _ := NewReader("text/csv", strings.NewReader(body))
+
+		report, err := Import(context.Background(), atomic, BestEffort, reader, newImportRow, saveCodes(&saved))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, atomic.calls)
+		assert.Equal(t, []string{"A1", "A3"}, saved)
+		assert.Equal(t, &Report{
+			Mode:    BestEffort,
+			Total:   5,
+			Created: 2,
+			Failed:  3,
+			Rows:    []Row{{Row: 1, ID: 1}, failedRows[0], failedRows[1], failedRows[2], {Row: 5, ID: 2}},
+		}, report)
+	})
+
+	t.Run("All or nothing rolls back when a row fails", func(t *testing.T) {
+		var saved []string
+		atomic := &atomicStub{}
+		reader, _ := NewReader("text/csv", strings.NewReader(body))
+
+		report, err := Import(context.Background(), atomic, AllOrNothing, reader, newImportRow, saveCodes(&saved))
+
+		assert.NoError(t, err)
+		assert.True(t, atomic.rolledBack)
+		assert.Equal(t, &Report{
+			Mode:       AllOrNothing,
+			Total:      5,
+			Failed:     3,
+			RolledBack: true,
+			Rows:       []Row{{Row: 1}, failedRows[0], failedRows[1], failedRows[2], {Row: 5}},
+		}, report)
+	})
+
+	t.Run("All or nothing commits when every row is valid", func(t *testing.T) {
+		var saved []string
+		atomic := &atomicStub{}
+		reader, _ := NewReader("application/json", strings.NewReader(`[{"code":"A1"},{"code":"A2","quantity":2}]`))
+
+		report, err := Import(context.Background(), atomic, AllOrNothing, reader, newImportRow, saveCodes(&saved))
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, atomic.calls)
+		assert.False(t, atomic.rolledBack)
+		assert.Equal(t, &Report{Mode: AllOrNothing, Total: 2, Created: 2, Rows: []Row{{Row: 1, ID: 1}, {Row: 2, ID: 2}}}, report)
+	})
+
+	t.Run("Malformed body stops the import", func(t *testing.T) {
+		var saved []string
+		atomic := &atomicStub{}
+		reader, _ := NewReader("application/json", strings.NewReader(`[{"code":"A1"},{"code":`))
+
+		report, err := Import(context.Background(), atomic, AllOrNothing, reader, newImportRow, saveCodes(&saved))
+
+		assert.ErrorIs(t, err, ErrMalformed)
+		assert.True(t, atomic.rolledBack)
+		assert.Equal(t, &Report{Mode: AllOrNothing, Total: 1, RolledBack: true, Rows: []Row{{Row: 1}}}, report)
+	})
+
+	t.Run("Unexpected error stops the import", func(t *testing.T) {
+		reader, _ := NewReader("application/json", strings.NewReader(`[{"code":"A1"},{"code":"A2"}]`))
+		save := func(ctx context.Context, row interface{}) (int, error) {
+			return 0, errors.New("connection refused")
+		}
+
+		report, err := Import(context.Background(), &atomicStub{}, BestEffort, reader, newImportRow, save)
+
+		assert.EqualError(t, err, "row 1: connection refused")
+		assert.Equal(t, 0, report.Total)
+	})
+}
@@ -0,0 +1,206 @@
+// Package bulk imports many records from one request body, a CSV file or a JSON array, reading
+// one row at a time so large files are never held in memory.
+package bulk
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrUnsupportedFormat is returned for bodies that are neither CSV nor JSON.
+	ErrUnsupportedFormat = errors.New("import must be text/csv or a JSON array in application/json")
+	// ErrMalformed wraps the errors that stop reading the body, such as broken JSON.
+	ErrMalformed = errors.New("malformed import")
+)
+
+// Reader streams the rows of an import.
+type Reader interface {
+	// Next decodes the next row into dst, a pointer to a struct whose fields are matched by their JSON names.
+	// It returns io.EOF after the last row, a *RowError when only the row could not be decoded and
+	// an error wrapping ErrMalformed when the body can't be read any further.
+	Next(dst interface{}) error
+}
+
+// RowError is why a row could not be decoded, the rows after it can still be read.
+type RowError struct {
+	Err error
+}
+
+func (e *RowError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// NewReader reads body as CSV when contentType is text/csv and as a JSON array when it is application/json or missing.
+func NewReader(contentType string, body io.Reader) (Reader, error) {
+	mediaType := ""
+	if contentType != "" {
+		parsed, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return nil, ErrUnsupportedFormat
+		}
+		mediaType = parsed
+	}
+
+	switch mediaType {
+	case "text/csv":
+		return &csvReader{reader: csv.NewReader(body)}, nil
+	case "", "application/json":
+		return &jsonReader{decoder: json.NewDecoder(body)}, nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+type jsonReader struct {
+	decoder *json.Decoder
+	started bool
+}
+
+func (r *jsonReader) Next(dst interface{}) error {
+	if !r.started {
+		token, err := r.decoder.Token()
+		if err != nil {
+			return malformed(err)
+		}
+		if delim, ok := token.(json.Delim); !ok || delim != '[' {
+			return fmt.Errorf("%w: expected a JSON array", ErrMalformed)
+		}
+		r.started = true
+	}
+
+	if !r.decoder.More() {
+		if _, err := r.decoder.Token(); err != nil {
+			return malformed(err)
+		}
+		return io.EOF
+	}
+
+	if err := r.decoder.Decode(dst); err != nil {
+		// A value of the wrong type is skipped by the decoder, which can go on with the next row.
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return &RowError{Err: err}
+		}
+		return malformed(err)
+	}
+	return nil
+}
+
+type csvReader struct {
+	reader *csv.Reader
+	header []string
+}
+
+func (r *csvReader) Next(dst interface{}) error {
+	if r.header == nil {
+		header, err := r.reader.Read()
+		if err != nil {
+			if err == io.EOF {
+				return fmt.Errorf("%w: missing CSV header", ErrMalformed)
+			}
+			return malformed(err)
+		}
+		for i, name := range header {
+			header[i] = strings.TrimSpace(name)
+		}
+		r.header = header
+	}
+
+	record, err := r.reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			return io.EOF
+		}
+		// The record is consumed even when it has a wrong number of fields.
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount) {
+			return &RowError{Err: err}
+		}
+		return malformed(err)
+	}
+
+	return decodeRecord(r.header, record, dst)
+}
+
+// decodeRecord sets the fields of dst named by header to the values of record, leaving empty values unset.
+func decodeRecord(header, record []string, dst interface{}) error {
+	value := reflect.ValueOf(dst).Elem()
+	fields := fieldsByName(value.Type())
+
+	for i, name := range header {
+		index, ok := fields[name]
+		if !ok || record[i] == "" {
+			continue
+		}
+		if err := setField(value.Field(index), strings.TrimSpace(record[i])); err != nil {
+			return &RowError{Err: &json.UnmarshalTypeError{Value: "string " + record[i], Type: value.Field(index).Type(), Struct: value.Type().Name(), Field: name}}
+		}
+	}
+	return nil
+}
+
+func fieldsByName(t reflect.Type) map[string]int {
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.SplitN(t.Field(i).Tag.Get("json"), ",", 2)[0]
+		if name == "" {
+			name = t.Field(i).Name
+		}
+		if name != "-" {
+			fields[name] = i
+		}
+	}
+	return fields
+}
+
+func setField(field reflect.Value, value string) error {
+	if field.Kind() == reflect.Ptr {
+		field.Set(reflect.New(field.Type().Elem()))
+		field = field.Elem()
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+func malformed(err error) error {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return fmt.Errorf("%w: %v", ErrMalformed, err)
+}
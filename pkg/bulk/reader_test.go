@@ -0,0 +1,96 @@
+package bulk
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type readerRow struct {
+	Code     string   `json:"code"`
+	Quantity int      `json:"quantity"`
+	Price    *float64 `json:"price"`
+}
+
+func readAll(t *testing.T, reader Reader) ([]readerRow, []error) {
+	var rows []readerRow
+	var errs []error
+	for {
+		var row readerRow
+		err := reader.Next(&row)
+		if err == io.EOF {
+			return rows, errs
+		}
+		var rowErr *RowError
+		if err != nil && !errors.As(err, &rowErr) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		rows = append(rows, row)
+		errs = append(errs, err)
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	for _, contentType := range []string{"", "application/json", "application/json; charset=utf-8", "text/csv"} {
+		_, err := NewReader(contentType, strings.NewReader(""))
+		assert.NoError(t, err, contentType)
+	}
+
+	_, err := NewReader("application/xml", strings.NewReader(""))
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestReaderCSV(t *testing.T) {
+	price := 10.5
+	reader, _ := NewReader("text/csv", strings.NewReader("code, quantity,price,ignored\nA1,2,10.5,x\nA2,,,\nA3,two,,\nA4,1\n"))
+
+	rows, errs := readAll(t, reader)
+
+	assert.Equal(t, []readerRow{{Code: "A1", Quantity: 2, Price: &price}, {Code: "A2"}, {Code: "A3"}, {}}, rows)
+	assert.Nil(t, errs[0])
+	assert.Nil(t, errs[1])
+	assert.EqualError(t, errs[2], "json: cannot unmarshal string two into Go struct field readerRow.quantity of type int")
+	assert.Error(t, errs[3])
+}
+
+func TestReaderJSON(t *testing.T) {
+	reader, _ := NewReader("application/json", strings.NewReader(`[{"code":"A1","quantity":2},{"code":"A2","quantity":"two"},{"code":"A3"}]`))
+
+	rows, errs := readAll(t, reader)
+
+	assert.Equal(t, []readerRow{{Code: "A1", Quantity: 2}, {Code: "A2"}, {Code: "A3"}}, rows)
+	assert.Nil(t, errs[0])
+	assert.Error(t, errs[1])
+	assert.Nil(t, errs[2])
+}
+
+func TestReaderMalformed(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{name: "Not an array", contentType: "application/json", body: `{"code":"A1"}`},
+		{name: "Broken JSON", contentType: "application/json", body: `[{"code":"A1"},{"code":`},
+		{name: "Unterminated array", contentType: "application/json", body: `[{"code":"A1"}`},
+		{name: "Empty CSV", contentType: "text/csv", body: ``},
+		{name: "Broken CSV", contentType: "text/csv", body: "code\n\"A1\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			reader, _ := NewReader(test.contentType, strings.NewReader(test.body))
+
+			var err error
+			for err == nil {
+				var row readerRow
+				err = reader.Next(&row)
+			}
+
+			assert.ErrorIs(t, err, ErrMalformed)
+		})
+	}
+}
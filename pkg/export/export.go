@@ -0,0 +1,236 @@
+// Package export encodes list and report responses as CSV or NDJSON, one row at a time, so a whole table
+// can be exported without holding it in memory.
+package export
+
+import (
+	"bufio"
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Format is the encoding of a list response.
+type Format string
+
+const (
+	// JSON is the default paginated response, it is not an export.
+	JSON Format = "json"
+	// CSV has a header row with the JSON names of the fields.
+	CSV Format = "csv"
+	// NDJSON has one JSON object per line.
+	NDJSON Format = "ndjson"
+)
+
+// Negotiate picks the format asked by the format query parameter or, when it is empty, by the Accept header.
+// An unknown format parameter is an error while an Accept without a known media type falls back to JSON.
+func Negotiate(format, accept string) (Format, error) {
+	if format != "" {
+		switch f := Format(strings.ToLower(format)); f {
+		case JSON, CSV, NDJSON:
+			return f, nil
+		}
+		return "", fmt.Errorf("format must be %s, %s or %s", JSON, CSV, NDJSON)
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case "text/csv":
+			return CSV, nil
+		case "application/x-ndjson":
+			return NDJSON, nil
+		case "application/json":
+			return JSON, nil
+		}
+	}
+	return JSON, nil
+}
+
+// ContentType is the media type of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case CSV:
+		return "text/csv; charset=utf-8"
+	case NDJSON:
+		return "application/x-ndjson"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// Writer encodes the rows of an export.
+type Writer interface {
+	// Write encodes row, a struct of the type the writer was made for or a pointer to one.
+	Write(row interface{}) error
+	// Flush writes the buffered rows to the underlying writer.
+	Flush() error
+}
+
+// NewWriter makes a writer of rows with the type of sample in format, CSV or NDJSON.
+// The CSV header is written right away, so an export without rows still has its columns.
+func NewWriter(format Format, w io.Writer, sample interface{}) (Writer, error) {
+	switch format {
+	case CSV:
+		columns := columnsOf(indirectType(reflect.TypeOf(sample)), nil)
+		names := make([]string, len(columns))
+		for i, column := range columns {
+			names[i] = column.name
+		}
+
+		writer := &csvWriter{writer: csv.NewWriter(w), columns: columns}
+		if err := writer.writer.Write(names); err != nil {
+			return nil, err
+		}
+		return writer, nil
+	case NDJSON:
+		buffered := bufio.NewWriter(w)
+		return &ndjsonWriter{buffered: buffered, encoder: json.NewEncoder(buffered)}, nil
+	default:
+		return nil, fmt.Errorf("cannot export as %s", format)
+	}
+}
+
+type ndjsonWriter struct {
+	buffered *bufio.Writer
+	encoder  *json.Encoder
+}
+
+// Write encodes the row followed by a newline.
+func (w *ndjsonWriter) Write(row interface{}) error {
+	return w.encoder.Encode(row)
+}
+
+func (w *ndjsonWriter) Flush() error {
+	return w.buffered.Flush()
+}
+
+// column is a field of the CSV rows, index is its path through embedded structs.
+type column struct {
+	name  string
+	index []int
+}
+
+type csvWriter struct {
+	writer  *csv.Writer
+	columns []column
+	record  []string
+}
+
+func (w *csvWriter) Write(row interface{}) error {
+	value := reflect.ValueOf(row)
+	for value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+
+	w.record = w.record[:0]
+	for _, column := range w.columns {
+		cell, err := formatCell(value.FieldByIndex(column.index))
+		if err != nil {
+			return fmt.Errorf("%s: %w", column.name, err)
+		}
+		w.record = append(w.record, cell)
+	}
+	return w.writer.Write(w.record)
+}
+
+func (w *csvWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// columnsOf lists the fields encoding/json would write for t, with the fields of untagged embedded structs inlined.
+func columnsOf(t reflect.Type, parent []int) []column {
+	var columns []column
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		index := append(append([]int{}, parent...), i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.SplitN(tag, ",", 2)[0]
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			columns = append(columns, columnsOf(field.Type, index)...)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		columns = append(columns, column{name: name, index: index})
+	}
+	return columns
+}
+
+// formatCell writes scalars as text, nil as an empty cell and any other value, such as a slice, as JSON.
+func formatCell(value reflect.Value) (string, error) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return "", nil
+		}
+		value = value.Elem()
+	}
+
+	if marshaler, ok := value.Interface().(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, value.Type().Bits()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), nil
+	default:
+		text, err := json.Marshal(value.Interface())
+		return string(text), err
+	}
+}
+
+// Rows passes every element of rows, a slice or a pointer to one, to write; it exports reports that are built in memory.
+// Any other value is written as the only row.
+func Rows(rows interface{}, write func(row interface{}) error) error {
+	value := reflect.ValueOf(rows)
+	for value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return nil
+	}
+	if value.Kind() != reflect.Slice && value.Kind() != reflect.Array {
+		return write(value.Interface())
+	}
+
+	for i := 0; i < value.Len(); i++ {
+		if err := write(value.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,99 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type base struct {
+	ID int `json:"id"`
+}
+
+type exportRow struct {
+	base
+	Code      string    `json:"code"`
+	Price     float32   `json:"price"`
+	Active    bool      `json:"active"`
+	DeletedAt *string   `json:"deleted_at,omitempty"`
+	Tags      []string  `json:"tags"`
+	Hidden    int       `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	internal  string
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		accept string
+		want   Format
+	}{
+		{name: "Default", want: JSON},
+		{name: "Format parameter", format: "CSV", accept: "application/x-ndjson", want: CSV},
+		{name: "Accept CSV", accept: "text/csv", want: CSV},
+		{name: "Accept NDJSON", accept: "text/html, application/x-ndjson;q=0.9", want: NDJSON},
+		{name: "Accept anything", accept: "*/*", want: JSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := Negotiate(tt.format, tt.accept)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, format)
+		})
+	}
+
+	_, err := Negotiate("xml", "")
+	assert.EqualError(t, err, "format must be json, csv or ndjson")
+}
+
+func TestWriter(t *testing.T) {
+	deletedAt := "2023-01-02 10:00:00"
+	createdAt := time.Date(2023, 1, 2, 10, 0, 0, 0, time.UTC)
+	rows := []exportRow{
+		{base: base{ID: 1}, Code: "A,1", Price: 10.5, Active: true, Tags: []string{"cold"}, Hidden: 7, CreatedAt: createdAt, internal: "x"},
+		{base: base{ID: 2}, Code: "A2", DeletedAt: &deletedAt, CreatedAt: createdAt},
+	}
+
+	t.Run("CSV", func(t *testing.T) {
+		var buffer bytes.Buffer
+		writer, err := NewWriter(CSV, &buffer, exportRow{})
+		assert.NoError(t, err)
+
+		assert.NoError(t, Rows(rows, writer.Write))
+		assert.NoError(t, writer.Flush())
+
+		assert.Equal(t, "id,code,price,active,deleted_at,tags,created_at\n"+
+			"1,\"A,1\",10.5,true,,\"[\"\"cold\"\"]\",2023-01-02T10:00:00Z\n"+
+			"2,A2,0,false,2023-01-02 10:00:00,null,2023-01-02T10:00:00Z\n", buffer.String())
+	})
+
+	t.Run("CSV without rows has the header", func(t *testing.T) {
+		var buffer bytes.Buffer
+		writer, _ := NewWriter(CSV, &buffer, &exportRow{})
+
+		assert.NoError(t, writer.Flush())
+		assert.Equal(t, "id,code,price,active,deleted_at,tags,created_at\n", buffer.String())
+	})
+
+	t.Run("NDJSON", func(t *testing.T) {
+		var buffer bytes.Buffer
+		writer, err := NewWriter(NDJSON, &buffer, exportRow{})
+		assert.NoError(t, err)
+
+		assert.NoError(t, Rows(&rows, writer.Write))
+		assert.NoError(t, writer.Flush())
+
+		assert.Equal(t, `{"id":1,"code":"A,1","price":10.5,"active":true,"tags":["cold"],"created_at":"2023-01-02T10:00:00Z"}`+"\n"+
+			`{"id":2,"code":"A2","price":0,"active":false,"deleted_at":"2023-01-02 10:00:00","tags":null,"created_at":"2023-01-02T10:00:00Z"}`+"\n", buffer.String())
+	})
+
+	t.Run("JSON is not an export", func(t *testing.T) {
+		_, err := NewWriter(JSON, &bytes.Buffer{}, exportRow{})
+
+		assert.EqualError(t, err, "cannot export as json")
+	})
+}
@@ -0,0 +1,223 @@
+package listing
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 500
+
+	OrderAsc  = "asc"
+	OrderDesc = "desc"
+
+	OperatorFrom = ">="
+	OperatorTo   = "<="
+	// OperatorIsNull matches the rows whose column is NULL, the filter value is ignored.
+	OperatorIsNull = "IS NULL"
+
+	// IncludeDeletedParam is the query parameter listing soft-deleted rows along with the others.
+	IncludeDeletedParam = "include_deleted"
+
+	// IDField is the field every resource sorts by by default and uses to break ties between equal sort values.
+	IDField = "id"
+)
+
+// Fields maps the field names accepted in query strings to the SQL column each one sorts and filters by.
+// Every Fields must include IDField.
+type Fields map[string]string
+
+// Filter restricts a listing to the rows whose column compares to the value with Operator,
+// or equals the value when Operator is empty.
+type Filter struct {
+	Column   string
+	Operator string
+	Value    string
+}
+
+// Options are the pagination, sorting and filtering settings of a list request.
+type Options struct {
+	Limit   int
+	Offset  int
+	Sort    string
+	Desc    bool
+	Filters []Filter
+	idKey   string
+	unpaged bool
+}
+
+// Parse reads limit, offset, sort, order and the field filters from the query string.
+// Sorting and filtering accept only the keys of fields; other query parameters are ignored.
+func Parse(values url.Values, fields Fields) (Options, error) {
+	options := Options{
+		Limit: DefaultLimit,
+		Sort:  fields[IDField],
+		idKey: fields[IDField],
+	}
+
+	if value := values.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > MaxLimit {
+			return Options{}, fmt.Errorf("limit must be a number between 1 and %d", MaxLimit)
+		}
+		options.Limit = limit
+	}
+
+	if value := values.Get("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			return Options{}, fmt.Errorf("offset must be a non-negative number")
+		}
+		options.Offset = offset
+	}
+
+	if value := values.Get("sort"); value != "" {
+		column, ok := fields[value]
+		if !ok {
+			return Options{}, fmt.Errorf("cannot sort by %s", value)
+		}
+		options.Sort = column
+	}
+
+	switch strings.ToLower(values.Get("order")) {
+	case "", OrderAsc:
+	case OrderDesc:
+		options.Desc = true
+	default:
+		return Options{}, fmt.Errorf("order must be %s or %s", OrderAsc, OrderDesc)
+	}
+
+	for field, column := range fields {
+		if value, ok := values[field]; ok {
+			options.Filters = append(options.Filters, Filter{Column: column, Value: value[0]})
+		}
+	}
+	// Map iteration is random; keep the generated SQL stable.
+	sort.Slice(options.Filters, func(i, j int) bool {
+		return options.Filters[i].Column < options.Filters[j].Column
+	})
+
+	return options, nil
+}
+
+// Range reads the <field>_from and <field>_to query parameters into filters bounding column, both inclusive.
+func Range(values url.Values, field, column string) []Filter {
+	var filters []Filter
+	if value := values.Get(field + "_from"); value != "" {
+		filters = append(filters, Filter{Column: column, Operator: OperatorFrom, Value: value})
+	}
+	if value := values.Get(field + "_to"); value != "" {
+		filters = append(filters, Filter{Column: column, Operator: OperatorTo, Value: value})
+	}
+	return filters
+}
+
+// SoftDeleted returns the filter skipping the soft-deleted rows, those whose column is set,
+// unless the include_deleted query parameter is true.
+func SoftDeleted(values url.Values, column string) ([]Filter, error) {
+	if value := values.Get(IncludeDeletedParam); value != "" {
+		include, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be true or false", IncludeDeletedParam)
+		}
+		if include {
+			return nil, nil
+		}
+	}
+	return []Filter{{Column: column, Operator: OperatorIsNull}}, nil
+}
+
+// ParseSoftDeleted is Parse for resources that are soft deleted through column,
+// skipping the deleted rows unless include_deleted is true.
+func ParseSoftDeleted(values url.Values, fields Fields, column string) (Options, error) {
+	options, err := Parse(values, fields)
+	if err != nil {
+		return Options{}, err
+	}
+
+	deleted, err := SoftDeleted(values, column)
+	if err != nil {
+		return Options{}, err
+	}
+	options.Filters = append(options.Filters, deleted...)
+
+	return options, nil
+}
+
+// Query appends the filters, sort and page of the options to base,
+// a SELECT without WHERE, ORDER BY or LIMIT clauses.
+func (o Options) Query(base string) (string, []interface{}) {
+	where, args := o.where()
+
+	query := base + where
+	if o.Sort != "" {
+		query += " ORDER BY " + o.Sort + o.direction()
+		if o.idKey != "" && o.idKey != o.Sort {
+			query += ", " + o.idKey + o.direction()
+		}
+	}
+
+	if o.unpaged {
+		return query, args
+	}
+
+	query += " LIMIT ? OFFSET ?"
+	args = append(args, o.Limit, o.Offset)
+
+	return query, args
+}
+
+// Unpaged returns the options without their limit and offset, so Query selects every matching row, as exports do.
+func (o Options) Unpaged() Options {
+	o.Limit, o.Offset = 0, 0
+	o.unpaged = true
+	return o
+}
+
+// CountQuery appends the filters of the options to base, a SELECT COUNT without a WHERE clause.
+func (o Options) CountQuery(base string) (string, []interface{}) {
+	where, args := o.where()
+	return base + where, args
+}
+
+// Next returns the offset of the following page, or -1 when this is the last one.
+func (o Options) Next(total int) int {
+	if next := o.Offset + o.Limit; next < total {
+		return next
+	}
+	return -1
+}
+
+func (o Options) where() (string, []interface{}) {
+	if len(o.Filters) == 0 {
+		return "", []interface{}{}
+	}
+
+	conditions := make([]string, 0, len(o.Filters))
+	args := make([]interface{}, 0, len(o.Filters))
+	for _, filter := range o.Filters {
+		operator := filter.Operator
+		if operator == OperatorIsNull {
+			conditions = append(conditions, filter.Column+" "+operator)
+			continue
+		}
+		if operator == "" {
+			operator = "="
+		}
+		conditions = append(conditions, filter.Column+" "+operator+" ?")
+		args = append(args, filter.Value)
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func (o Options) direction() string {
+	if o.Desc {
+		return " DESC"
+	}
+	return " ASC"
+}
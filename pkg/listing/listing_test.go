@@ -0,0 +1,173 @@
+package listing
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var fields = Fields{
+	"id":          "sellers.id",
+	"cid":         "sellers.cid",
+	"locality_id": "sellers.locality_id",
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    Options
+		wantErr bool
+	}{
+		{
+			name:  "Defaults",
+			query: "",
+			want:  Options{Limit: DefaultLimit, Sort: "sellers.id", idKey: "sellers.id"},
+		},
+		{
+			name:  "Every option",
+			query: "limit=10&offset=20&sort=cid&order=DESC&locality_id=3&cid=7&unknown=1",
+			want: Options{
+				Limit:  10,
+				Offset: 20,
+				Sort:   "sellers.cid",
+				Desc:   true,
+				Filters: []Filter{
+					{Column: "sellers.cid", Value: "7"},
+					{Column: "sellers.locality_id", Value: "3"},
+				},
+				idKey: "sellers.id",
+			},
+		},
+		{name: "Error limit not a number", query: "limit=ten", wantErr: true},
+		{name: "Error limit too big", query: "limit=501", wantErr: true},
+		{name: "Error limit zero", query: "limit=0", wantErr: true},
+		{name: "Error negative offset", query: "offset=-1", wantErr: true},
+		{name: "Error unknown sort field", query: "sort=password", wantErr: true},
+		{name: "Error invalid order", query: "order=up", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+
+			got, err := Parse(values, fields)
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			if !tt.wantErr {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestOptions_Query(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantQuery string
+		wantArgs  []interface{}
+		wantCount string
+	}{
+		{
+			name:      "Default page",
+			query:     "",
+			wantQuery: "SELECT * FROM sellers ORDER BY sellers.id ASC LIMIT ? OFFSET ?",
+			wantArgs:  []interface{}{DefaultLimit, 0},
+			wantCount: "SELECT COUNT(*) FROM sellers",
+		},
+		{
+			name:      "Filtered and sorted page breaks ties by id",
+			query:     "limit=5&offset=10&sort=cid&order=desc&locality_id=3",
+			wantQuery: "SELECT * FROM sellers WHERE sellers.locality_id = ? ORDER BY sellers.cid DESC, sellers.id DESC LIMIT ? OFFSET ?",
+			wantArgs:  []interface{}{"3", 5, 10},
+			wantCount: "SELECT COUNT(*) FROM sellers WHERE sellers.locality_id = ?",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+			options, err := Parse(values, fields)
+			assert.NoError(t, err)
+
+			query, args := options.Query("SELECT * FROM sellers")
+			count, _ := options.CountQuery("SELECT COUNT(*) FROM sellers")
+
+			assert.Equal(t, tt.wantQuery, query)
+			assert.Equal(t, tt.wantArgs, args)
+			assert.Equal(t, tt.wantCount, count)
+		})
+	}
+
+	t.Run("Unpaged", func(t *testing.T) {
+		values, _ := url.ParseQuery("limit=5&offset=10&locality_id=3")
+		options, _ := Parse(values, fields)
+
+		query, args := options.Unpaged().Query("SELECT * FROM sellers")
+
+		assert.Equal(t, "SELECT * FROM sellers WHERE sellers.locality_id = ? ORDER BY sellers.id ASC", query)
+		assert.Equal(t, []interface{}{"3"}, args)
+	})
+}
+
+func TestRange(t *testing.T) {
+	values, _ := url.ParseQuery("due_date_from=2023-07-01&due_date_to=2023-07-31")
+
+	options := Options{Limit: DefaultLimit, Filters: Range(values, "due_date", "pb.due_date")}
+	query, args := options.Query("SELECT * FROM product_batches pb")
+
+	assert.Equal(t, "SELECT * FROM product_batches pb WHERE pb.due_date >= ? AND pb.due_date <= ? LIMIT ? OFFSET ?", query)
+	assert.Equal(t, []interface{}{"2023-07-01", "2023-07-31", DefaultLimit, 0}, args)
+
+	values, _ = url.ParseQuery("due_date_to=2023-07-31")
+	assert.Equal(t, []Filter{{Column: "pb.due_date", Operator: OperatorTo, Value: "2023-07-31"}}, Range(values, "due_date", "pb.due_date"))
+
+	assert.Nil(t, Range(url.Values{}, "due_date", "pb.due_date"))
+}
+
+func TestSoftDeleted(t *testing.T) {
+	values, _ := url.ParseQuery("company_name=Meli")
+
+	filters, err := SoftDeleted(values, "deleted_at")
+	assert.Nil(t, err)
+
+	options := Options{Limit: DefaultLimit, Filters: append([]Filter{{Column: "company_name", Value: "Meli"}}, filters...)}
+	query, args := options.Query("SELECT * FROM sellers")
+
+	assert.Equal(t, "SELECT * FROM sellers WHERE company_name = ? AND deleted_at IS NULL LIMIT ? OFFSET ?", query)
+	assert.Equal(t, []interface{}{"Meli", DefaultLimit, 0}, args)
+
+	values, _ = url.ParseQuery("include_deleted=true")
+	filters, err = SoftDeleted(values, "deleted_at")
+	assert.Nil(t, err)
+	assert.Nil(t, filters)
+
+	values, _ = url.ParseQuery("include_deleted=maybe")
+	_, err = SoftDeleted(values, "deleted_at")
+	assert.EqualError(t, err, "include_deleted must be true or false")
+}
+
+func TestParseSoftDeleted(t *testing.T) {
+	fields := Fields{"id": "id", "cid": "cid"}
+
+	values, _ := url.ParseQuery("cid=10")
+	options, err := ParseSoftDeleted(values, fields, "deleted_at")
+	assert.Nil(t, err)
+	assert.Equal(t, []Filter{{Column: "cid", Value: "10"}, {Column: "deleted_at", Operator: OperatorIsNull}}, options.Filters)
+
+	values, _ = url.ParseQuery("cid=10&include_deleted=true")
+	options, err = ParseSoftDeleted(values, fields, "deleted_at")
+	assert.Nil(t, err)
+	assert.Equal(t, []Filter{{Column: "cid", Value: "10"}}, options.Filters)
+
+	values, _ = url.ParseQuery("include_deleted=1&limit=0")
+	_, err = ParseSoftDeleted(values, fields, "deleted_at")
+	assert.NotNil(t, err)
+}
+
+func TestOptions_Next(t *testing.T) {
+	options := Options{Limit: 10, Offset: 20}
+
+	assert.Equal(t, 30, options.Next(31))
+	assert.Equal(t, -1, options.Next(30))
+}
@@ -0,0 +1,181 @@
+// Package validation holds the rules request bodies are bound with, on top of the ones of
+// github.com/go-playground/validator, and turns their failures into one error per field.
+//
+// The rules are registered on gin's validator when the package is loaded, so every
+// ShouldBind* call of the handlers, and of their tests, applies them.
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// DateLayout and DateTimeLayout are the formats the date rule accepts, the latter for the columns that keep the time too.
+const (
+	DateLayout     = "2006-01-02"
+	DateTimeLayout = "2006-01-02 15:04:05"
+)
+
+var (
+	// phonePattern accepts digits grouped by spaces, dashes or parentheses, optionally starting with +.
+	phonePattern = regexp.MustCompile(`^\+?[0-9][0-9 ()-]{4,18}[0-9]$`)
+	// cidPattern accepts codes such as "CID#1" or "AR-123".
+	cidPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9#_-]{0,31}$`)
+)
+
+// FieldError is why a field of a request body was rejected.
+type FieldError struct {
+	// Field is the JSON path of the field, such as "details[0].quantity".
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		Register(v)
+	}
+}
+
+// Register names the fields of v's errors by their JSON names and adds the phone, cid and date rules.
+func Register(v *validator.Validate) {
+	v.RegisterTagNameFunc(jsonName)
+
+	_ = v.RegisterValidation("phone", matches(phonePattern))
+	_ = v.RegisterValidation("cid", matches(cidPattern))
+	_ = v.RegisterValidation("date", func(fl validator.FieldLevel) bool {
+		for _, layout := range []string{DateLayout, DateTimeLayout} {
+			if _, err := time.Parse(layout, fl.Field().String()); err == nil {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// FieldErrors lists the fields err, returned by binding a request body, is about.
+// It is empty for errors that are not about a field, such as malformed JSON.
+func FieldErrors(err error) []FieldError {
+	switch e := err.(type) {
+	case validator.ValidationErrors:
+		fieldErrors := make([]FieldError, len(e))
+		for i, fe := range e {
+			fieldErrors[i] = FieldError{Field: path(fe), Rule: fe.Tag(), Message: message(fe)}
+		}
+		return fieldErrors
+	case *json.UnmarshalTypeError:
+		return []FieldError{{Field: e.Field, Rule: "type", Message: fmt.Sprintf("must be of type %s", e.Type)}}
+	default:
+		return nil
+	}
+}
+
+func matches(pattern *regexp.Regexp) validator.Func {
+	return func(fl validator.FieldLevel) bool {
+		return pattern.MatchString(fl.Field().String())
+	}
+}
+
+func jsonName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	default:
+		return name
+	}
+}
+
+// path is the namespace of fe without the name of the request struct.
+func path(fe validator.FieldError) string {
+	namespace := fe.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+func message(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "required_without", "required_without_all":
+		return fmt.Sprintf("is required when %s is not given", fieldNames(fe.Param()))
+	case "min", "max", "len":
+		return sizeMessage(fe)
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", fe.Param())
+	case "gte":
+		return fmt.Sprintf("must be greater than or equal to %s", fe.Param())
+	case "lt":
+		return fmt.Sprintf("must be less than %s", fe.Param())
+	case "lte":
+		return fmt.Sprintf("must be less than or equal to %s", fe.Param())
+	case "gtfield":
+		return fmt.Sprintf("must be greater than %s", fieldNames(fe.Param()))
+	case "gtefield":
+		return fmt.Sprintf("must be greater than or equal to %s", fieldNames(fe.Param()))
+	case "ltfield":
+		return fmt.Sprintf("must be less than %s", fieldNames(fe.Param()))
+	case "ltefield":
+		return fmt.Sprintf("must be less than or equal to %s", fieldNames(fe.Param()))
+	case "oneof":
+		return fmt.Sprintf("must be one of %s", strings.Join(strings.Fields(fe.Param()), ", "))
+	case "phone":
+		return "must be a phone number, only digits, spaces, dashes, parentheses and a leading +"
+	case "cid":
+		return "must be a code of up to 32 letters, digits, #, _ or -"
+	case "date":
+		return fmt.Sprintf("must be a date in the format %s or %s", DateLayout, DateTimeLayout)
+	case "datetime":
+		return fmt.Sprintf("must be a date in the format %s", fe.Param())
+	default:
+		return fmt.Sprintf("failed the %s rule", fe.Tag())
+	}
+}
+
+func sizeMessage(fe validator.FieldError) string {
+	bound := map[string]string{"min": "at least", "max": "at most", "len": "exactly"}[fe.Tag()]
+
+	switch fe.Kind() {
+	case reflect.String:
+		return fmt.Sprintf("must have %s %s characters", bound, fe.Param())
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return fmt.Sprintf("must have %s %s items", bound, fe.Param())
+	default:
+		return fmt.Sprintf("must be %s %s", bound, fe.Param())
+	}
+}
+
+// fieldNames turns the Go field names of a rule parameter, such as "MinimumCapacity", into
+// the snake case of the JSON names used by the request bodies.
+func fieldNames(param string) string {
+	names := strings.Fields(param)
+	for i, name := range names {
+		names[i] = snakeCase(name)
+	}
+	return strings.Join(names, " or ")
+}
+
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if r >= 'A' && r <= 'Z' {
+			if i > 0 && !(name[i-1] >= 'A' && name[i-1] <= 'Z') {
+				b.WriteByte('_')
+			}
+			r += 'a' - 'A'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
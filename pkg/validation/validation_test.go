@@ -0,0 +1,117 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+type detailRequest struct {
+	Quantity int `json:"quantity" binding:"required,gt=0"`
+}
+
+type request struct {
+	CID             string          `json:"cid" binding:"required,cid"`
+	Telephone       string          `json:"telephone" binding:"omitempty,phone"`
+	OrderDate       string          `json:"order_date" binding:"omitempty,date"`
+	Name            string          `json:"name" binding:"omitempty,max=3"`
+	MinimumCapacity int             `json:"minimum_capacity"`
+	MaximumCapacity int             `json:"maximum_capacity" binding:"omitempty,gtefield=MinimumCapacity"`
+	Details         []detailRequest `json:"details" binding:"omitempty,dive"`
+}
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.SetTagName("binding")
+	Register(v)
+	return v
+}
+
+func TestFieldErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  request
+		expected []FieldError
+	}{
+		{
+			name: "Valid request",
+			request: request{
+				CID:             "CID#1",
+				Telephone:       "+55 (11) 93847-3322",
+				OrderDate:       "2023-07-01 10:00:00",
+				MinimumCapacity: 10,
+				MaximumCapacity: 10,
+				Details:         []detailRequest{{Quantity: 1}},
+			},
+		},
+		{
+			name:    "Missing field",
+			request: request{},
+			expected: []FieldError{
+				{Field: "cid", Rule: "required", Message: "is required"},
+			},
+		},
+		{
+			name: "Custom rules",
+			request: request{
+				CID:       "CID 1",
+				Telephone: "Test",
+				OrderDate: "01/07/2023",
+			},
+			expected: []FieldError{
+				{Field: "cid", Rule: "cid", Message: "must be a code of up to 32 letters, digits, #, _ or -"},
+				{Field: "telephone", Rule: "phone", Message: "must be a phone number, only digits, spaces, dashes, parentheses and a leading +"},
+				{Field: "order_date", Rule: "date", Message: "must be a date in the format 2006-01-02 or 2006-01-02 15:04:05"},
+			},
+		},
+		{
+			name: "Size and ordering of fields",
+			request: request{
+				CID:             "CID#1",
+				Name:            "Test",
+				MinimumCapacity: 10,
+				MaximumCapacity: 5,
+			},
+			expected: []FieldError{
+				{Field: "name", Rule: "max", Message: "must have at most 3 characters"},
+				{Field: "maximum_capacity", Rule: "gtefield", Message: "must be greater than or equal to minimum_capacity"},
+			},
+		},
+		{
+			name: "Nested field",
+			request: request{
+				CID:     "CID#1",
+				Details: []detailRequest{{Quantity: 1}, {Quantity: -1}},
+			},
+			expected: []FieldError{
+				{Field: "details[1].quantity", Rule: "gt", Message: "must be greater than 0"},
+			},
+		},
+	}
+
+	v := newValidator()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := v.Struct(test.request)
+			if test.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, test.expected, FieldErrors(err))
+		})
+	}
+
+	t.Run("Wrong type", func(t *testing.T) {
+		var r request
+		err := json.Unmarshal([]byte(`{"minimum_capacity":"ten"}`), &r)
+
+		assert.Equal(t, []FieldError{{Field: "minimum_capacity", Rule: "type", Message: "must be of type int"}}, FieldErrors(err))
+	})
+
+	t.Run("Not about a field", func(t *testing.T) {
+		assert.Empty(t, FieldErrors(errors.New("unexpected EOF")))
+	})
+}
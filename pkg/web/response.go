@@ -1,21 +1,36 @@
 package web
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/export"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/validation"
+
 	"github.com/gin-gonic/gin"
 )
 
 type response struct {
-	Data interface{} `json:"data"`
+	Data       interface{} `json:"data"`
+	Pagination *pagination `json:"pagination,omitempty"`
+}
+
+type pagination struct {
+	Total  int    `json:"total"`
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+	Next   string `json:"next,omitempty"`
 }
 
 type errorResponse struct {
-	Status  int    `json:"-"`
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Status  int         `json:"-"`
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
 }
 
 func Response(c *gin.Context, status int, data interface{}) {
@@ -26,14 +41,109 @@ func Success(c *gin.Context, status int, data interface{}) {
 	Response(c, status, response{Data: data})
 }
 
+// Page wraps one page of a listing along with the total count of items and,
+// unless it is the last page, the link to the next one.
+func Page(c *gin.Context, status int, data interface{}, total int, options listing.Options) {
+	page := &pagination{
+		Total:  total,
+		Limit:  options.Limit,
+		Offset: options.Offset,
+	}
+
+	if next := options.Next(total); next >= 0 {
+		nextURL := *c.Request.URL
+		values := nextURL.Query()
+		values.Set("offset", strconv.Itoa(next))
+		values.Set("limit", strconv.Itoa(options.Limit))
+		nextURL.RawQuery = values.Encode()
+		page.Next = nextURL.RequestURI()
+	}
+
+	Response(c, status, response{Data: data, Pagination: page})
+}
+
+// Export answers a list or report request with an attachment in format, CSV or NDJSON, instead of a page.
+// each passes every row to write, which encodes it as soon as it is read; sample is a row of the same type
+// and gives the CSV its header. An error before any row reached the client is left to the Errors middleware,
+// an error after that can only end the export early and is kept on the context for the request logger.
+func Export(c *gin.Context, format export.Format, sample interface{}, each func(write func(row interface{}) error) error) {
+	writer, err := export.NewWriter(format, c.Writer, sample)
+	if err != nil {
+		_ = c.Error(err)
+		return
+	}
+
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, exportName(c), format))
+	c.Status(http.StatusOK)
+
+	if err := each(writer.Write); err != nil {
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Type")
+			c.Writer.Header().Del("Content-Disposition")
+		}
+		_ = c.Error(err)
+		return
+	}
+
+	if err := writer.Flush(); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// Report answers a report request with its rows, a slice or a single row, wrapped like Success
+// or exported in format when the request asked for CSV or NDJSON.
+func Report(c *gin.Context, format export.Format, rows interface{}, sample interface{}) {
+	if format == export.JSON {
+		Success(c, http.StatusOK, rows)
+		return
+	}
+
+	Export(c, format, sample, func(write func(row interface{}) error) error {
+		return export.Rows(rows, write)
+	})
+}
+
+// exportName names an export after the last static segment of its route, such as products or reportProducts.
+func exportName(c *gin.Context) string {
+	segments := strings.Split(strings.Trim(c.FullPath(), "/"), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		if segments[i] != "" && !strings.HasPrefix(segments[i], ":") {
+			return segments[i]
+		}
+	}
+	return "export"
+}
+
 // NewErrorf creates a new error with the given status code and the message
 // formatted according to args and format.
 func Error(c *gin.Context, status int, format string, args ...interface{}) {
+	ErrorWithDetails(c, status, nil, format, args...)
+}
+
+// ErrorWithDetails is Error with details, such as the records blocking a delete, added to the response.
+func ErrorWithDetails(c *gin.Context, status int, details interface{}, format string, args ...interface{}) {
 	err := errorResponse{
 		Code:    strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_"),
 		Message: fmt.Sprintf(format, args...),
+		Details: details,
 		Status:  status,
 	}
 
+	// Keep the message on the context so middlewares, such as the request logger, can report it.
+	_ = c.Error(errors.New(err.Message))
+
 	Response(c, status, err)
 }
+
+// ValidationError answers a request body that could not be bound with a 422 listing the rejected fields,
+// or with the error itself when it is not about a field, such as malformed JSON.
+func ValidationError(c *gin.Context, err error) {
+	fieldErrors := validation.FieldErrors(err)
+	if len(fieldErrors) == 0 {
+		Error(c, http.StatusUnprocessableEntity, "invalid request body: %s", err.Error())
+		return
+	}
+
+	ErrorWithDetails(c, http.StatusUnprocessableEntity, fieldErrors, "invalid request body")
+}
@@ -0,0 +1,156 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/export"
+	"github.com/extmatperez/meli_bootcamp_go_w2-2/pkg/listing"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    int
+		wantBody string
+	}{
+		{
+			name:     "Page with a next link",
+			total:    25,
+			wantBody: `{"data":[1,2],"pagination":{"total":25,"limit":2,"offset":4,"next":"/api/v1/sellers?limit=2&locality_id=3&offset=6"}}`,
+		},
+		{
+			name:     "Last page",
+			total:    6,
+			wantBody: `{"data":[1,2],"pagination":{"total":6,"limit":2,"offset":4}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(res)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/v1/sellers?locality_id=3&offset=4&limit=2", nil)
+
+			Page(c, http.StatusOK, []int{1, 2}, tt.total, listing.Options{Limit: 2, Offset: 4})
+
+			assert.Equal(t, http.StatusOK, res.Code)
+			assert.JSONEq(t, tt.wantBody, res.Body.String())
+		})
+	}
+}
+
+func TestErrorWithDetails(t *testing.T) {
+	res := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(res)
+	c.Request = httptest.NewRequest(http.MethodDelete, "/api/v1/sections/1", nil)
+
+	ErrorWithDetails(c, http.StatusConflict, map[string]int{"product_batches": 2}, "cannot delete section %d", 1)
+
+	assert.Equal(t, http.StatusConflict, res.Code)
+	assert.JSONEq(t, `{"code":"conflict","message":"cannot delete section 1","details":{"product_batches":2}}`, res.Body.String())
+	assert.Equal(t, "cannot delete section 1", c.Errors.Last().Error())
+}
+
+func TestValidationError(t *testing.T) {
+	type request struct {
+		CID int `json:"cid" binding:"required"`
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		wantBody string
+	}{
+		{
+			name:     "Field errors",
+			body:     `{}`,
+			wantBody: `{"code":"unprocessable_entity","message":"invalid request body","details":[{"field":"cid","rule":"required","message":"is required"}]}`,
+		},
+		{
+			name:     "Malformed body",
+			body:     `{`,
+			wantBody: `{"code":"unprocessable_entity","message":"invalid request body: unexpected EOF"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(res)
+			c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/sellers", strings.NewReader(tt.body))
+
+			var req request
+			ValidationError(c, c.ShouldBindJSON(&req))
+
+			assert.Equal(t, http.StatusUnprocessableEntity, res.Code)
+			assert.JSONEq(t, tt.wantBody, res.Body.String())
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	type row struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	tests := []struct {
+		name            string
+		format          export.Format
+		err             error
+		wantBody        string
+		wantContentType string
+		wantDisposition string
+		wantError       string
+	}{
+		{
+			name:            "CSV",
+			format:          export.CSV,
+			wantBody:        "id,name\n1,a\n2,b\n",
+			wantContentType: "text/csv; charset=utf-8",
+			wantDisposition: `attachment; filename="reportProducts.csv"`,
+		},
+		{
+			name:            "NDJSON",
+			format:          export.NDJSON,
+			wantBody:        "{\"id\":1,\"name\":\"a\"}\n{\"id\":2,\"name\":\"b\"}\n",
+			wantContentType: "application/x-ndjson",
+			wantDisposition: `attachment; filename="reportProducts.ndjson"`,
+		},
+		{
+			name:      "Error before any row was sent",
+			format:    export.CSV,
+			err:       errors.New("connection refused"),
+			wantError: "connection refused",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs []string
+			r := gin.New()
+			r.GET("/api/v1/sections/reportProducts/:id", func(c *gin.Context) {
+				Export(c, tt.format, row{}, func(write func(row interface{}) error) error {
+					if tt.err != nil {
+						return tt.err
+					}
+					return export.Rows([]row{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}, write)
+				})
+				errs = c.Errors.Errors()
+			})
+			res := httptest.NewRecorder()
+
+			r.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/api/v1/sections/reportProducts/1", nil))
+
+			assert.Equal(t, tt.wantBody, res.Body.String())
+			assert.Equal(t, tt.wantContentType, res.Header().Get("Content-Type"))
+			assert.Equal(t, tt.wantDisposition, res.Header().Get("Content-Disposition"))
+			if tt.wantError != "" {
+				assert.Equal(t, []string{tt.wantError}, errs)
+			}
+		})
+	}
+}